@@ -4,6 +4,7 @@ WHITESPACE: [ \r\n\t]+ -> skip;
 DIGITS: '0' | [1-9] [0-9]*;
 HEX_DIGITS: ('a' .. 'f' | 'A' .. 'F' | [0-9])+;
 NET: DIGITS '.' DIGITS '.' DIGITS '.' DIGITS '/' DIGITS;
+NET6: [0-9a-fA-F]* ':' [0-9a-fA-F:.]* '/' DIGITS;
 
 ANY: 'ANY' | 'any';
 ALL: 'ALL' | 'all';
@@ -16,8 +17,14 @@ TOS: 'TOS' | 'tos';
 PROTOCOL: 'PROTOCOL' | 'protocol';
 SRCPORT: 'SRCPORT' | 'srcport';
 DSTPORT: 'DSTPORT' | 'dstport';
+TC: 'TC' | 'tc';
+FLOWLABEL: 'FLOWLABEL' | 'flowlabel';
+NEXTHDR: 'NEXTHDR' | 'nexthdr';
+TCPFLAGS: 'TCPFLAGS' | 'tcpflags';
+ICMPTYPE: 'ICMPTYPE' | 'icmptype';
+ICMPCODE: 'ICMPCODE' | 'icmpcode';
 
-STRING: [a-zA-Z]+;
+STRING: [a-zA-Z] [a-zA-Z0-9]*;
 
 matchSrc: SRC '=' NET;
 matchDst: DST '=' NET;
@@ -30,6 +37,17 @@ matchSrcPortRange: SRCPORT '=' DIGITS '-' DIGITS;
 matchDstPort: DSTPORT '=' DIGITS;
 matchDstPortRange: DSTPORT '=' DIGITS '-' DIGITS;
 
+matchSrc6: SRC '=' NET6;
+matchDst6: DST '=' NET6;
+matchTC: TC '=0x' (HEX_DIGITS | DIGITS);
+matchFlowLabel: FLOWLABEL '=' DIGITS;
+matchNextHdr: NEXTHDR '=' STRING;
+
+matchTCPFlags: TCPFLAGS '=0x' (HEX_DIGITS | DIGITS);
+matchTCPFlagsMask: TCPFLAGS '=0x' (HEX_DIGITS | DIGITS) '/0x' (HEX_DIGITS | DIGITS);
+matchICMPType: ICMPTYPE '=' DIGITS;
+matchICMPCode: ICMPCODE '=' DIGITS;
+
 condCls: 'cls=' DIGITS;
 condAny: ANY '(' cond (',' cond)* ')';
 condAll: ALL '(' cond (',' cond)* ')';
@@ -37,7 +55,11 @@ condNot: NOT '(' cond ')';
 condBool: BOOL '=' ('true' | 'false');
 
 condIPv4: matchSrc | matchDst | matchDSCP | matchTOS | matchProtocol;
+condIPv6: matchSrc6 | matchDst6 | matchTC | matchFlowLabel | matchNextHdr;
 condPort: matchSrcPort | matchSrcPortRange | matchDstPort | matchDstPortRange;
-cond: condAll | condAny | condNot | condIPv4 | condPort | condCls | condBool;
+condTCP: matchTCPFlags | matchTCPFlagsMask;
+condICMP: matchICMPType | matchICMPCode;
+cond: condAll | condAny | condNot | condIPv4 | condIPv6 | condPort | condTCP | condICMP | condCls
+    | condBool;
 
 trafficClass: cond EOF;
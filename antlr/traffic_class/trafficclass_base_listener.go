@@ -74,6 +74,60 @@ func (s *BaseTrafficClassListener) EnterMatchDstPortRange(ctx *MatchDstPortRange
 // ExitMatchDstPortRange is called when production matchDstPortRange is exited.
 func (s *BaseTrafficClassListener) ExitMatchDstPortRange(ctx *MatchDstPortRangeContext) {}
 
+// EnterMatchSrc6 is called when production matchSrc6 is entered.
+func (s *BaseTrafficClassListener) EnterMatchSrc6(ctx *MatchSrc6Context) {}
+
+// ExitMatchSrc6 is called when production matchSrc6 is exited.
+func (s *BaseTrafficClassListener) ExitMatchSrc6(ctx *MatchSrc6Context) {}
+
+// EnterMatchDst6 is called when production matchDst6 is entered.
+func (s *BaseTrafficClassListener) EnterMatchDst6(ctx *MatchDst6Context) {}
+
+// ExitMatchDst6 is called when production matchDst6 is exited.
+func (s *BaseTrafficClassListener) ExitMatchDst6(ctx *MatchDst6Context) {}
+
+// EnterMatchTC is called when production matchTC is entered.
+func (s *BaseTrafficClassListener) EnterMatchTC(ctx *MatchTCContext) {}
+
+// ExitMatchTC is called when production matchTC is exited.
+func (s *BaseTrafficClassListener) ExitMatchTC(ctx *MatchTCContext) {}
+
+// EnterMatchFlowLabel is called when production matchFlowLabel is entered.
+func (s *BaseTrafficClassListener) EnterMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// ExitMatchFlowLabel is called when production matchFlowLabel is exited.
+func (s *BaseTrafficClassListener) ExitMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// EnterMatchNextHdr is called when production matchNextHdr is entered.
+func (s *BaseTrafficClassListener) EnterMatchNextHdr(ctx *MatchNextHdrContext) {}
+
+// ExitMatchNextHdr is called when production matchNextHdr is exited.
+func (s *BaseTrafficClassListener) ExitMatchNextHdr(ctx *MatchNextHdrContext) {}
+
+// EnterMatchTCPFlags is called when production matchTCPFlags is entered.
+func (s *BaseTrafficClassListener) EnterMatchTCPFlags(ctx *MatchTCPFlagsContext) {}
+
+// ExitMatchTCPFlags is called when production matchTCPFlags is exited.
+func (s *BaseTrafficClassListener) ExitMatchTCPFlags(ctx *MatchTCPFlagsContext) {}
+
+// EnterMatchTCPFlagsMask is called when production matchTCPFlagsMask is entered.
+func (s *BaseTrafficClassListener) EnterMatchTCPFlagsMask(ctx *MatchTCPFlagsMaskContext) {}
+
+// ExitMatchTCPFlagsMask is called when production matchTCPFlagsMask is exited.
+func (s *BaseTrafficClassListener) ExitMatchTCPFlagsMask(ctx *MatchTCPFlagsMaskContext) {}
+
+// EnterMatchICMPType is called when production matchICMPType is entered.
+func (s *BaseTrafficClassListener) EnterMatchICMPType(ctx *MatchICMPTypeContext) {}
+
+// ExitMatchICMPType is called when production matchICMPType is exited.
+func (s *BaseTrafficClassListener) ExitMatchICMPType(ctx *MatchICMPTypeContext) {}
+
+// EnterMatchICMPCode is called when production matchICMPCode is entered.
+func (s *BaseTrafficClassListener) EnterMatchICMPCode(ctx *MatchICMPCodeContext) {}
+
+// ExitMatchICMPCode is called when production matchICMPCode is exited.
+func (s *BaseTrafficClassListener) ExitMatchICMPCode(ctx *MatchICMPCodeContext) {}
+
 // EnterCondCls is called when production condCls is entered.
 func (s *BaseTrafficClassListener) EnterCondCls(ctx *CondClsContext) {}
 
@@ -110,12 +164,30 @@ func (s *BaseTrafficClassListener) EnterCondIPv4(ctx *CondIPv4Context) {}
 // ExitCondIPv4 is called when production condIPv4 is exited.
 func (s *BaseTrafficClassListener) ExitCondIPv4(ctx *CondIPv4Context) {}
 
+// EnterCondIPv6 is called when production condIPv6 is entered.
+func (s *BaseTrafficClassListener) EnterCondIPv6(ctx *CondIPv6Context) {}
+
+// ExitCondIPv6 is called when production condIPv6 is exited.
+func (s *BaseTrafficClassListener) ExitCondIPv6(ctx *CondIPv6Context) {}
+
 // EnterCondPort is called when production condPort is entered.
 func (s *BaseTrafficClassListener) EnterCondPort(ctx *CondPortContext) {}
 
 // ExitCondPort is called when production condPort is exited.
 func (s *BaseTrafficClassListener) ExitCondPort(ctx *CondPortContext) {}
 
+// EnterCondTCP is called when production condTCP is entered.
+func (s *BaseTrafficClassListener) EnterCondTCP(ctx *CondTCPContext) {}
+
+// ExitCondTCP is called when production condTCP is exited.
+func (s *BaseTrafficClassListener) ExitCondTCP(ctx *CondTCPContext) {}
+
+// EnterCondICMP is called when production condICMP is entered.
+func (s *BaseTrafficClassListener) EnterCondICMP(ctx *CondICMPContext) {}
+
+// ExitCondICMP is called when production condICMP is exited.
+func (s *BaseTrafficClassListener) ExitCondICMP(ctx *CondICMPContext) {}
+
 // EnterCond is called when production cond is entered.
 func (s *BaseTrafficClassListener) EnterCond(ctx *CondContext) {}
 
@@ -14,113 +14,174 @@ var _ = fmt.Printf
 var _ = unicode.IsLetter
 
 var serializedLexerAtn = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 27, 238,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 35, 372,
 	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7,
 	9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12,
 	4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4,
 	18, 9, 18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23,
-	9, 23, 4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 3, 2, 3, 2, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 7,
-	3, 7, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3,
-	10, 3, 10, 3, 10, 3, 11, 6, 11, 85, 10, 11, 13, 11, 14, 11, 86, 3, 11,
-	3, 11, 3, 12, 3, 12, 3, 12, 7, 12, 94, 10, 12, 12, 12, 14, 12, 97, 11,
-	12, 5, 12, 99, 10, 12, 3, 13, 6, 13, 102, 10, 13, 13, 13, 14, 13, 103,
-	3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3,
-	15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 5, 15, 122, 10, 15, 3, 16, 3, 16,
-	3, 16, 3, 16, 3, 16, 3, 16, 5, 16, 130, 10, 16, 3, 17, 3, 17, 3, 17, 3,
-	17, 3, 17, 3, 17, 5, 17, 138, 10, 17, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18,
-	3, 18, 3, 18, 3, 18, 5, 18, 148, 10, 18, 3, 19, 3, 19, 3, 19, 3, 19, 3,
-	19, 3, 19, 5, 19, 156, 10, 19, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20,
-	5, 20, 164, 10, 20, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3,
-	21, 5, 21, 174, 10, 21, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 5, 22,
-	182, 10, 22, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3,
-	23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 5, 23, 200, 10, 23,
-	3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3,
-	24, 3, 24, 3, 24, 3, 24, 5, 24, 216, 10, 24, 3, 25, 3, 25, 3, 25, 3, 25,
-	3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 5,
-	25, 232, 10, 25, 3, 26, 6, 26, 235, 10, 26, 13, 26, 14, 26, 236, 2, 2,
-	27, 3, 3, 5, 4, 7, 5, 9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12,
-	23, 13, 25, 14, 27, 15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21,
-	41, 22, 43, 23, 45, 24, 47, 25, 49, 26, 51, 27, 3, 2, 7, 5, 2, 11, 12,
-	15, 15, 34, 34, 3, 2, 51, 59, 3, 2, 50, 59, 5, 2, 50, 59, 67, 72, 99, 104,
-	4, 2, 67, 92, 99, 124, 2, 253, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7,
-	3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2,
-	15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2,
-	2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2,
-	2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2,
-	2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3,
-	2, 2, 2, 2, 47, 3, 2, 2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 3, 53,
-	3, 2, 2, 2, 5, 55, 3, 2, 2, 2, 7, 59, 3, 2, 2, 2, 9, 61, 3, 2, 2, 2, 11,
-	66, 3, 2, 2, 2, 13, 68, 3, 2, 2, 2, 15, 70, 3, 2, 2, 2, 17, 72, 3, 2, 2,
-	2, 19, 77, 3, 2, 2, 2, 21, 84, 3, 2, 2, 2, 23, 98, 3, 2, 2, 2, 25, 101,
-	3, 2, 2, 2, 27, 105, 3, 2, 2, 2, 29, 121, 3, 2, 2, 2, 31, 129, 3, 2, 2,
-	2, 33, 137, 3, 2, 2, 2, 35, 147, 3, 2, 2, 2, 37, 155, 3, 2, 2, 2, 39, 163,
-	3, 2, 2, 2, 41, 173, 3, 2, 2, 2, 43, 181, 3, 2, 2, 2, 45, 199, 3, 2, 2,
-	2, 47, 215, 3, 2, 2, 2, 49, 231, 3, 2, 2, 2, 51, 234, 3, 2, 2, 2, 53, 54,
-	7, 63, 2, 2, 54, 4, 3, 2, 2, 2, 55, 56, 7, 63, 2, 2, 56, 57, 7, 50, 2,
-	2, 57, 58, 7, 122, 2, 2, 58, 6, 3, 2, 2, 2, 59, 60, 7, 47, 2, 2, 60, 8,
-	3, 2, 2, 2, 61, 62, 7, 101, 2, 2, 62, 63, 7, 110, 2, 2, 63, 64, 7, 117,
-	2, 2, 64, 65, 7, 63, 2, 2, 65, 10, 3, 2, 2, 2, 66, 67, 7, 42, 2, 2, 67,
-	12, 3, 2, 2, 2, 68, 69, 7, 46, 2, 2, 69, 14, 3, 2, 2, 2, 70, 71, 7, 43,
-	2, 2, 71, 16, 3, 2, 2, 2, 72, 73, 7, 118, 2, 2, 73, 74, 7, 116, 2, 2, 74,
-	75, 7, 119, 2, 2, 75, 76, 7, 103, 2, 2, 76, 18, 3, 2, 2, 2, 77, 78, 7,
-	104, 2, 2, 78, 79, 7, 99, 2, 2, 79, 80, 7, 110, 2, 2, 80, 81, 7, 117, 2,
-	2, 81, 82, 7, 103, 2, 2, 82, 20, 3, 2, 2, 2, 83, 85, 9, 2, 2, 2, 84, 83,
-	3, 2, 2, 2, 85, 86, 3, 2, 2, 2, 86, 84, 3, 2, 2, 2, 86, 87, 3, 2, 2, 2,
-	87, 88, 3, 2, 2, 2, 88, 89, 8, 11, 2, 2, 89, 22, 3, 2, 2, 2, 90, 99, 7,
-	50, 2, 2, 91, 95, 9, 3, 2, 2, 92, 94, 9, 4, 2, 2, 93, 92, 3, 2, 2, 2, 94,
-	97, 3, 2, 2, 2, 95, 93, 3, 2, 2, 2, 95, 96, 3, 2, 2, 2, 96, 99, 3, 2, 2,
-	2, 97, 95, 3, 2, 2, 2, 98, 90, 3, 2, 2, 2, 98, 91, 3, 2, 2, 2, 99, 24,
-	3, 2, 2, 2, 100, 102, 9, 5, 2, 2, 101, 100, 3, 2, 2, 2, 102, 103, 3, 2,
-	2, 2, 103, 101, 3, 2, 2, 2, 103, 104, 3, 2, 2, 2, 104, 26, 3, 2, 2, 2,
-	105, 106, 5, 23, 12, 2, 106, 107, 7, 48, 2, 2, 107, 108, 5, 23, 12, 2,
-	108, 109, 7, 48, 2, 2, 109, 110, 5, 23, 12, 2, 110, 111, 7, 48, 2, 2, 111,
-	112, 5, 23, 12, 2, 112, 113, 7, 49, 2, 2, 113, 114, 5, 23, 12, 2, 114,
-	28, 3, 2, 2, 2, 115, 116, 7, 67, 2, 2, 116, 117, 7, 80, 2, 2, 117, 122,
-	7, 91, 2, 2, 118, 119, 7, 99, 2, 2, 119, 120, 7, 112, 2, 2, 120, 122, 7,
-	123, 2, 2, 121, 115, 3, 2, 2, 2, 121, 118, 3, 2, 2, 2, 122, 30, 3, 2, 2,
-	2, 123, 124, 7, 67, 2, 2, 124, 125, 7, 78, 2, 2, 125, 130, 7, 78, 2, 2,
-	126, 127, 7, 99, 2, 2, 127, 128, 7, 110, 2, 2, 128, 130, 7, 110, 2, 2,
-	129, 123, 3, 2, 2, 2, 129, 126, 3, 2, 2, 2, 130, 32, 3, 2, 2, 2, 131, 132,
-	7, 80, 2, 2, 132, 133, 7, 81, 2, 2, 133, 138, 7, 86, 2, 2, 134, 135, 7,
-	112, 2, 2, 135, 136, 7, 113, 2, 2, 136, 138, 7, 118, 2, 2, 137, 131, 3,
-	2, 2, 2, 137, 134, 3, 2, 2, 2, 138, 34, 3, 2, 2, 2, 139, 140, 7, 68, 2,
-	2, 140, 141, 7, 81, 2, 2, 141, 142, 7, 81, 2, 2, 142, 148, 7, 78, 2, 2,
-	143, 144, 7, 100, 2, 2, 144, 145, 7, 113, 2, 2, 145, 146, 7, 113, 2, 2,
-	146, 148, 7, 110, 2, 2, 147, 139, 3, 2, 2, 2, 147, 143, 3, 2, 2, 2, 148,
-	36, 3, 2, 2, 2, 149, 150, 7, 85, 2, 2, 150, 151, 7, 84, 2, 2, 151, 156,
-	7, 69, 2, 2, 152, 153, 7, 117, 2, 2, 153, 154, 7, 116, 2, 2, 154, 156,
-	7, 101, 2, 2, 155, 149, 3, 2, 2, 2, 155, 152, 3, 2, 2, 2, 156, 38, 3, 2,
-	2, 2, 157, 158, 7, 70, 2, 2, 158, 159, 7, 85, 2, 2, 159, 164, 7, 86, 2,
-	2, 160, 161, 7, 102, 2, 2, 161, 162, 7, 117, 2, 2, 162, 164, 7, 118, 2,
-	2, 163, 157, 3, 2, 2, 2, 163, 160, 3, 2, 2, 2, 164, 40, 3, 2, 2, 2, 165,
-	166, 7, 70, 2, 2, 166, 167, 7, 85, 2, 2, 167, 168, 7, 69, 2, 2, 168, 174,
-	7, 82, 2, 2, 169, 170, 7, 102, 2, 2, 170, 171, 7, 117, 2, 2, 171, 172,
-	7, 101, 2, 2, 172, 174, 7, 114, 2, 2, 173, 165, 3, 2, 2, 2, 173, 169, 3,
-	2, 2, 2, 174, 42, 3, 2, 2, 2, 175, 176, 7, 86, 2, 2, 176, 177, 7, 81, 2,
-	2, 177, 182, 7, 85, 2, 2, 178, 179, 7, 118, 2, 2, 179, 180, 7, 113, 2,
-	2, 180, 182, 7, 117, 2, 2, 181, 175, 3, 2, 2, 2, 181, 178, 3, 2, 2, 2,
-	182, 44, 3, 2, 2, 2, 183, 184, 7, 82, 2, 2, 184, 185, 7, 84, 2, 2, 185,
-	186, 7, 81, 2, 2, 186, 187, 7, 86, 2, 2, 187, 188, 7, 81, 2, 2, 188, 189,
-	7, 69, 2, 2, 189, 190, 7, 81, 2, 2, 190, 200, 7, 78, 2, 2, 191, 192, 7,
-	114, 2, 2, 192, 193, 7, 116, 2, 2, 193, 194, 7, 113, 2, 2, 194, 195, 7,
-	118, 2, 2, 195, 196, 7, 113, 2, 2, 196, 197, 7, 101, 2, 2, 197, 198, 7,
-	113, 2, 2, 198, 200, 7, 110, 2, 2, 199, 183, 3, 2, 2, 2, 199, 191, 3, 2,
-	2, 2, 200, 46, 3, 2, 2, 2, 201, 202, 7, 85, 2, 2, 202, 203, 7, 84, 2, 2,
-	203, 204, 7, 69, 2, 2, 204, 205, 7, 82, 2, 2, 205, 206, 7, 81, 2, 2, 206,
-	207, 7, 84, 2, 2, 207, 216, 7, 86, 2, 2, 208, 209, 7, 117, 2, 2, 209, 210,
-	7, 116, 2, 2, 210, 211, 7, 101, 2, 2, 211, 212, 7, 114, 2, 2, 212, 213,
-	7, 113, 2, 2, 213, 214, 7, 116, 2, 2, 214, 216, 7, 118, 2, 2, 215, 201,
-	3, 2, 2, 2, 215, 208, 3, 2, 2, 2, 216, 48, 3, 2, 2, 2, 217, 218, 7, 70,
-	2, 2, 218, 219, 7, 85, 2, 2, 219, 220, 7, 86, 2, 2, 220, 221, 7, 82, 2,
-	2, 221, 222, 7, 81, 2, 2, 222, 223, 7, 84, 2, 2, 223, 232, 7, 86, 2, 2,
-	224, 225, 7, 102, 2, 2, 225, 226, 7, 117, 2, 2, 226, 227, 7, 118, 2, 2,
-	227, 228, 7, 114, 2, 2, 228, 229, 7, 113, 2, 2, 229, 230, 7, 116, 2, 2,
-	230, 232, 7, 118, 2, 2, 231, 217, 3, 2, 2, 2, 231, 224, 3, 2, 2, 2, 232,
-	50, 3, 2, 2, 2, 233, 235, 9, 6, 2, 2, 234, 233, 3, 2, 2, 2, 235, 236, 3,
-	2, 2, 2, 236, 234, 3, 2, 2, 2, 236, 237, 3, 2, 2, 2, 237, 52, 3, 2, 2,
-	2, 20, 2, 86, 95, 98, 101, 103, 121, 129, 137, 147, 155, 163, 173, 181,
-	199, 215, 231, 236, 3, 8, 2, 2,
+	9, 23, 4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 4, 28, 9,
+	28, 4, 29, 9, 29, 4, 30, 9, 30, 4, 31, 9, 31, 4, 32, 9, 32, 4, 33, 9, 33,
+	4, 34, 9, 34, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 5, 3,
+	5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 7, 3, 7, 3, 8, 3, 8, 3,
+	9, 3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11, 3, 11, 3, 11,
+	3, 11, 3, 11, 3, 12, 6, 12, 105, 10, 12, 13, 12, 14, 12, 106, 3, 12, 3,
+	12, 3, 13, 3, 13, 3, 13, 7, 13, 114, 10, 13, 12, 13, 14, 13, 117, 11, 13,
+	5, 13, 119, 10, 13, 3, 14, 6, 14, 122, 10, 14, 13, 14, 14, 14, 123, 3,
+	15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 16,
+	7, 16, 137, 10, 16, 12, 16, 14, 16, 140, 11, 16, 3, 16, 3, 16, 7, 16, 144,
+	10, 16, 12, 16, 14, 16, 147, 11, 16, 3, 16, 3, 16, 3, 16, 3, 17, 3, 17,
+	3, 17, 3, 17, 3, 17, 3, 17, 5, 17, 158, 10, 17, 3, 18, 3, 18, 3, 18, 3,
+	18, 3, 18, 3, 18, 5, 18, 166, 10, 18, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19,
+	3, 19, 5, 19, 174, 10, 19, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3,
+	20, 3, 20, 5, 20, 184, 10, 20, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21,
+	5, 21, 192, 10, 21, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 5, 22, 200,
+	10, 22, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 5, 23,
+	210, 10, 23, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 5, 24, 218, 10,
+	24, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25,
+	3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 5, 25, 236, 10, 25, 3, 26, 3,
+	26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26,
+	3, 26, 3, 26, 5, 26, 252, 10, 26, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3,
+	27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 5, 27, 268,
+	10, 27, 3, 28, 3, 28, 3, 28, 3, 28, 5, 28, 274, 10, 28, 3, 29, 3, 29, 3,
+	29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29,
+	3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 5, 29, 294, 10, 29, 3, 30, 3, 30, 3,
+	30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30,
+	3, 30, 5, 30, 310, 10, 30, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3,
+	31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 5, 31,
+	328, 10, 31, 3, 32, 3, 32, 3, 32, 3, 32, 3, 32, 3, 32, 3, 32, 3, 32, 3,
+	32, 3, 32, 3, 32, 3, 32, 3, 32, 3, 32, 3, 32, 3, 32, 5, 32, 346, 10, 32,
+	3, 33, 3, 33, 3, 33, 3, 33, 3, 33, 3, 33, 3, 33, 3, 33, 3, 33, 3, 33, 3,
+	33, 3, 33, 3, 33, 3, 33, 3, 33, 3, 33, 5, 33, 364, 10, 33, 3, 34, 3, 34,
+	7, 34, 368, 10, 34, 12, 34, 14, 34, 371, 11, 34, 2, 2, 35, 3, 3, 5, 4,
+	7, 5, 9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14,
+	27, 15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22, 43, 23,
+	45, 24, 47, 25, 49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 59, 31, 61, 32,
+	63, 33, 65, 34, 67, 35, 3, 2, 9, 5, 2, 11, 12, 15, 15, 34, 34, 3, 2, 51,
+	59, 3, 2, 50, 59, 5, 2, 50, 59, 67, 72, 99, 104, 6, 2, 48, 48, 50, 60,
+	67, 72, 99, 104, 4, 2, 67, 92, 99, 124, 5, 2, 50, 59, 67, 92, 99, 124,
+	2, 395, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 2, 9, 3,
+	2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2, 17,
+	3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2, 23, 3, 2, 2, 2, 2,
+	25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2, 2, 2, 31, 3, 2, 2, 2,
+	2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2, 2, 2, 2, 39, 3, 2, 2,
+	2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3, 2, 2, 2, 2, 47, 3, 2,
+	2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3, 2, 2, 2, 2, 55, 3,
+	2, 2, 2, 2, 57, 3, 2, 2, 2, 2, 59, 3, 2, 2, 2, 2, 61, 3, 2, 2, 2, 2, 63,
+	3, 2, 2, 2, 2, 65, 3, 2, 2, 2, 2, 67, 3, 2, 2, 2, 3, 69, 3, 2, 2, 2, 5,
+	71, 3, 2, 2, 2, 7, 75, 3, 2, 2, 2, 9, 77, 3, 2, 2, 2, 11, 81, 3, 2, 2,
+	2, 13, 86, 3, 2, 2, 2, 15, 88, 3, 2, 2, 2, 17, 90, 3, 2, 2, 2, 19, 92,
+	3, 2, 2, 2, 21, 97, 3, 2, 2, 2, 23, 104, 3, 2, 2, 2, 25, 118, 3, 2, 2,
+	2, 27, 121, 3, 2, 2, 2, 29, 125, 3, 2, 2, 2, 31, 138, 3, 2, 2, 2, 33, 157,
+	3, 2, 2, 2, 35, 165, 3, 2, 2, 2, 37, 173, 3, 2, 2, 2, 39, 183, 3, 2, 2,
+	2, 41, 191, 3, 2, 2, 2, 43, 199, 3, 2, 2, 2, 45, 209, 3, 2, 2, 2, 47, 217,
+	3, 2, 2, 2, 49, 235, 3, 2, 2, 2, 51, 251, 3, 2, 2, 2, 53, 267, 3, 2, 2,
+	2, 55, 273, 3, 2, 2, 2, 57, 293, 3, 2, 2, 2, 59, 309, 3, 2, 2, 2, 61, 327,
+	3, 2, 2, 2, 63, 345, 3, 2, 2, 2, 65, 363, 3, 2, 2, 2, 67, 365, 3, 2, 2,
+	2, 69, 70, 7, 63, 2, 2, 70, 4, 3, 2, 2, 2, 71, 72, 7, 63, 2, 2, 72, 73,
+	7, 50, 2, 2, 73, 74, 7, 122, 2, 2, 74, 6, 3, 2, 2, 2, 75, 76, 7, 47, 2,
+	2, 76, 8, 3, 2, 2, 2, 77, 78, 7, 49, 2, 2, 78, 79, 7, 50, 2, 2, 79, 80,
+	7, 122, 2, 2, 80, 10, 3, 2, 2, 2, 81, 82, 7, 101, 2, 2, 82, 83, 7, 110,
+	2, 2, 83, 84, 7, 117, 2, 2, 84, 85, 7, 63, 2, 2, 85, 12, 3, 2, 2, 2, 86,
+	87, 7, 42, 2, 2, 87, 14, 3, 2, 2, 2, 88, 89, 7, 46, 2, 2, 89, 16, 3, 2,
+	2, 2, 90, 91, 7, 43, 2, 2, 91, 18, 3, 2, 2, 2, 92, 93, 7, 118, 2, 2, 93,
+	94, 7, 116, 2, 2, 94, 95, 7, 119, 2, 2, 95, 96, 7, 103, 2, 2, 96, 20, 3,
+	2, 2, 2, 97, 98, 7, 104, 2, 2, 98, 99, 7, 99, 2, 2, 99, 100, 7, 110, 2,
+	2, 100, 101, 7, 117, 2, 2, 101, 102, 7, 103, 2, 2, 102, 22, 3, 2, 2, 2,
+	103, 105, 9, 2, 2, 2, 104, 103, 3, 2, 2, 2, 105, 106, 3, 2, 2, 2, 106,
+	104, 3, 2, 2, 2, 106, 107, 3, 2, 2, 2, 107, 108, 3, 2, 2, 2, 108, 109,
+	8, 12, 2, 2, 109, 24, 3, 2, 2, 2, 110, 119, 7, 50, 2, 2, 111, 115, 9, 3,
+	2, 2, 112, 114, 9, 4, 2, 2, 113, 112, 3, 2, 2, 2, 114, 117, 3, 2, 2, 2,
+	115, 113, 3, 2, 2, 2, 115, 116, 3, 2, 2, 2, 116, 119, 3, 2, 2, 2, 117,
+	115, 3, 2, 2, 2, 118, 110, 3, 2, 2, 2, 118, 111, 3, 2, 2, 2, 119, 26, 3,
+	2, 2, 2, 120, 122, 9, 5, 2, 2, 121, 120, 3, 2, 2, 2, 122, 123, 3, 2, 2,
+	2, 123, 121, 3, 2, 2, 2, 123, 124, 3, 2, 2, 2, 124, 28, 3, 2, 2, 2, 125,
+	126, 5, 25, 13, 2, 126, 127, 7, 48, 2, 2, 127, 128, 5, 25, 13, 2, 128,
+	129, 7, 48, 2, 2, 129, 130, 5, 25, 13, 2, 130, 131, 7, 48, 2, 2, 131, 132,
+	5, 25, 13, 2, 132, 133, 7, 49, 2, 2, 133, 134, 5, 25, 13, 2, 134, 30, 3,
+	2, 2, 2, 135, 137, 9, 5, 2, 2, 136, 135, 3, 2, 2, 2, 137, 140, 3, 2, 2,
+	2, 138, 136, 3, 2, 2, 2, 138, 139, 3, 2, 2, 2, 139, 141, 3, 2, 2, 2, 140,
+	138, 3, 2, 2, 2, 141, 145, 7, 60, 2, 2, 142, 144, 9, 6, 2, 2, 143, 142,
+	3, 2, 2, 2, 144, 147, 3, 2, 2, 2, 145, 143, 3, 2, 2, 2, 145, 146, 3, 2,
+	2, 2, 146, 148, 3, 2, 2, 2, 147, 145, 3, 2, 2, 2, 148, 149, 7, 49, 2, 2,
+	149, 150, 5, 25, 13, 2, 150, 32, 3, 2, 2, 2, 151, 152, 7, 67, 2, 2, 152,
+	153, 7, 80, 2, 2, 153, 158, 7, 91, 2, 2, 154, 155, 7, 99, 2, 2, 155, 156,
+	7, 112, 2, 2, 156, 158, 7, 123, 2, 2, 157, 151, 3, 2, 2, 2, 157, 154, 3,
+	2, 2, 2, 158, 34, 3, 2, 2, 2, 159, 160, 7, 67, 2, 2, 160, 161, 7, 78, 2,
+	2, 161, 166, 7, 78, 2, 2, 162, 163, 7, 99, 2, 2, 163, 164, 7, 110, 2, 2,
+	164, 166, 7, 110, 2, 2, 165, 159, 3, 2, 2, 2, 165, 162, 3, 2, 2, 2, 166,
+	36, 3, 2, 2, 2, 167, 168, 7, 80, 2, 2, 168, 169, 7, 81, 2, 2, 169, 174,
+	7, 86, 2, 2, 170, 171, 7, 112, 2, 2, 171, 172, 7, 113, 2, 2, 172, 174,
+	7, 118, 2, 2, 173, 167, 3, 2, 2, 2, 173, 170, 3, 2, 2, 2, 174, 38, 3, 2,
+	2, 2, 175, 176, 7, 68, 2, 2, 176, 177, 7, 81, 2, 2, 177, 178, 7, 81, 2,
+	2, 178, 184, 7, 78, 2, 2, 179, 180, 7, 100, 2, 2, 180, 181, 7, 113, 2,
+	2, 181, 182, 7, 113, 2, 2, 182, 184, 7, 110, 2, 2, 183, 175, 3, 2, 2, 2,
+	183, 179, 3, 2, 2, 2, 184, 40, 3, 2, 2, 2, 185, 186, 7, 85, 2, 2, 186,
+	187, 7, 84, 2, 2, 187, 192, 7, 69, 2, 2, 188, 189, 7, 117, 2, 2, 189, 190,
+	7, 116, 2, 2, 190, 192, 7, 101, 2, 2, 191, 185, 3, 2, 2, 2, 191, 188, 3,
+	2, 2, 2, 192, 42, 3, 2, 2, 2, 193, 194, 7, 70, 2, 2, 194, 195, 7, 85, 2,
+	2, 195, 200, 7, 86, 2, 2, 196, 197, 7, 102, 2, 2, 197, 198, 7, 117, 2,
+	2, 198, 200, 7, 118, 2, 2, 199, 193, 3, 2, 2, 2, 199, 196, 3, 2, 2, 2,
+	200, 44, 3, 2, 2, 2, 201, 202, 7, 70, 2, 2, 202, 203, 7, 85, 2, 2, 203,
+	204, 7, 69, 2, 2, 204, 210, 7, 82, 2, 2, 205, 206, 7, 102, 2, 2, 206, 207,
+	7, 117, 2, 2, 207, 208, 7, 101, 2, 2, 208, 210, 7, 114, 2, 2, 209, 201,
+	3, 2, 2, 2, 209, 205, 3, 2, 2, 2, 210, 46, 3, 2, 2, 2, 211, 212, 7, 86,
+	2, 2, 212, 213, 7, 81, 2, 2, 213, 218, 7, 85, 2, 2, 214, 215, 7, 118, 2,
+	2, 215, 216, 7, 113, 2, 2, 216, 218, 7, 117, 2, 2, 217, 211, 3, 2, 2, 2,
+	217, 214, 3, 2, 2, 2, 218, 48, 3, 2, 2, 2, 219, 220, 7, 82, 2, 2, 220,
+	221, 7, 84, 2, 2, 221, 222, 7, 81, 2, 2, 222, 223, 7, 86, 2, 2, 223, 224,
+	7, 81, 2, 2, 224, 225, 7, 69, 2, 2, 225, 226, 7, 81, 2, 2, 226, 236, 7,
+	78, 2, 2, 227, 228, 7, 114, 2, 2, 228, 229, 7, 116, 2, 2, 229, 230, 7,
+	113, 2, 2, 230, 231, 7, 118, 2, 2, 231, 232, 7, 113, 2, 2, 232, 233, 7,
+	101, 2, 2, 233, 234, 7, 113, 2, 2, 234, 236, 7, 110, 2, 2, 235, 219, 3,
+	2, 2, 2, 235, 227, 3, 2, 2, 2, 236, 50, 3, 2, 2, 2, 237, 238, 7, 85, 2,
+	2, 238, 239, 7, 84, 2, 2, 239, 240, 7, 69, 2, 2, 240, 241, 7, 82, 2, 2,
+	241, 242, 7, 81, 2, 2, 242, 243, 7, 84, 2, 2, 243, 252, 7, 86, 2, 2, 244,
+	245, 7, 117, 2, 2, 245, 246, 7, 116, 2, 2, 246, 247, 7, 101, 2, 2, 247,
+	248, 7, 114, 2, 2, 248, 249, 7, 113, 2, 2, 249, 250, 7, 116, 2, 2, 250,
+	252, 7, 118, 2, 2, 251, 237, 3, 2, 2, 2, 251, 244, 3, 2, 2, 2, 252, 52,
+	3, 2, 2, 2, 253, 254, 7, 70, 2, 2, 254, 255, 7, 85, 2, 2, 255, 256, 7,
+	86, 2, 2, 256, 257, 7, 82, 2, 2, 257, 258, 7, 81, 2, 2, 258, 259, 7, 84,
+	2, 2, 259, 268, 7, 86, 2, 2, 260, 261, 7, 102, 2, 2, 261, 262, 7, 117,
+	2, 2, 262, 263, 7, 118, 2, 2, 263, 264, 7, 114, 2, 2, 264, 265, 7, 113,
+	2, 2, 265, 266, 7, 116, 2, 2, 266, 268, 7, 118, 2, 2, 267, 253, 3, 2, 2,
+	2, 267, 260, 3, 2, 2, 2, 268, 54, 3, 2, 2, 2, 269, 270, 7, 86, 2, 2, 270,
+	274, 7, 69, 2, 2, 271, 272, 7, 118, 2, 2, 272, 274, 7, 101, 2, 2, 273,
+	269, 3, 2, 2, 2, 273, 271, 3, 2, 2, 2, 274, 56, 3, 2, 2, 2, 275, 276, 7,
+	72, 2, 2, 276, 277, 7, 78, 2, 2, 277, 278, 7, 81, 2, 2, 278, 279, 7, 89,
+	2, 2, 279, 280, 7, 78, 2, 2, 280, 281, 7, 67, 2, 2, 281, 282, 7, 68, 2,
+	2, 282, 283, 7, 71, 2, 2, 283, 294, 7, 78, 2, 2, 284, 285, 7, 104, 2, 2,
+	285, 286, 7, 110, 2, 2, 286, 287, 7, 113, 2, 2, 287, 288, 7, 121, 2, 2,
+	288, 289, 7, 110, 2, 2, 289, 290, 7, 99, 2, 2, 290, 291, 7, 100, 2, 2,
+	291, 292, 7, 103, 2, 2, 292, 294, 7, 110, 2, 2, 293, 275, 3, 2, 2, 2, 293,
+	284, 3, 2, 2, 2, 294, 58, 3, 2, 2, 2, 295, 296, 7, 80, 2, 2, 296, 297,
+	7, 71, 2, 2, 297, 298, 7, 90, 2, 2, 298, 299, 7, 86, 2, 2, 299, 300, 7,
+	74, 2, 2, 300, 301, 7, 70, 2, 2, 301, 310, 7, 84, 2, 2, 302, 303, 7, 112,
+	2, 2, 303, 304, 7, 103, 2, 2, 304, 305, 7, 122, 2, 2, 305, 306, 7, 118,
+	2, 2, 306, 307, 7, 106, 2, 2, 307, 308, 7, 102, 2, 2, 308, 310, 7, 116,
+	2, 2, 309, 295, 3, 2, 2, 2, 309, 302, 3, 2, 2, 2, 310, 60, 3, 2, 2, 2,
+	311, 312, 7, 86, 2, 2, 312, 313, 7, 69, 2, 2, 313, 314, 7, 82, 2, 2, 314,
+	315, 7, 72, 2, 2, 315, 316, 7, 78, 2, 2, 316, 317, 7, 67, 2, 2, 317, 318,
+	7, 73, 2, 2, 318, 328, 7, 85, 2, 2, 319, 320, 7, 118, 2, 2, 320, 321, 7,
+	101, 2, 2, 321, 322, 7, 114, 2, 2, 322, 323, 7, 104, 2, 2, 323, 324, 7,
+	110, 2, 2, 324, 325, 7, 99, 2, 2, 325, 326, 7, 105, 2, 2, 326, 328, 7,
+	117, 2, 2, 327, 311, 3, 2, 2, 2, 327, 319, 3, 2, 2, 2, 328, 62, 3, 2, 2,
+	2, 329, 330, 7, 75, 2, 2, 330, 331, 7, 69, 2, 2, 331, 332, 7, 79, 2, 2,
+	332, 333, 7, 82, 2, 2, 333, 334, 7, 86, 2, 2, 334, 335, 7, 91, 2, 2, 335,
+	336, 7, 82, 2, 2, 336, 346, 7, 71, 2, 2, 337, 338, 7, 107, 2, 2, 338, 339,
+	7, 101, 2, 2, 339, 340, 7, 111, 2, 2, 340, 341, 7, 114, 2, 2, 341, 342,
+	7, 118, 2, 2, 342, 343, 7, 123, 2, 2, 343, 344, 7, 114, 2, 2, 344, 346,
+	7, 103, 2, 2, 345, 329, 3, 2, 2, 2, 345, 337, 3, 2, 2, 2, 346, 64, 3, 2,
+	2, 2, 347, 348, 7, 75, 2, 2, 348, 349, 7, 69, 2, 2, 349, 350, 7, 79, 2,
+	2, 350, 351, 7, 82, 2, 2, 351, 352, 7, 69, 2, 2, 352, 353, 7, 81, 2, 2,
+	353, 354, 7, 70, 2, 2, 354, 364, 7, 71, 2, 2, 355, 356, 7, 107, 2, 2, 356,
+	357, 7, 101, 2, 2, 357, 358, 7, 111, 2, 2, 358, 359, 7, 114, 2, 2, 359,
+	360, 7, 101, 2, 2, 360, 361, 7, 113, 2, 2, 361, 362, 7, 102, 2, 2, 362,
+	364, 7, 103, 2, 2, 363, 347, 3, 2, 2, 2, 363, 355, 3, 2, 2, 2, 364, 66,
+	3, 2, 2, 2, 365, 369, 9, 7, 2, 2, 366, 368, 9, 8, 2, 2, 367, 366, 3, 2,
+	2, 2, 368, 371, 3, 2, 2, 2, 369, 367, 3, 2, 2, 2, 369, 370, 3, 2, 2, 2,
+	370, 68, 3, 2, 2, 2, 371, 369, 3, 2, 2, 2, 28, 2, 106, 115, 118, 121, 123,
+	138, 145, 157, 165, 173, 183, 191, 199, 209, 217, 235, 251, 267, 273, 293,
+	309, 327, 345, 363, 369, 3, 8, 2, 2,
 }
 
 var lexerDeserializer = antlr.NewATNDeserializer(nil)
@@ -135,19 +196,22 @@ var lexerModeNames = []string{
 }
 
 var lexerLiteralNames = []string{
-	"", "'='", "'=0x'", "'-'", "'cls='", "'('", "','", "')'", "'true'", "'false'",
+	"", "'='", "'=0x'", "'-'", "'/0x'", "'cls='", "'('", "','", "')'", "'true'",
+	"'false'",
 }
 
 var lexerSymbolicNames = []string{
-	"", "", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
-	"NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS", "PROTOCOL",
-	"SRCPORT", "DSTPORT", "STRING",
+	"", "", "", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
+	"NET", "NET6", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+	"PROTOCOL", "SRCPORT", "DSTPORT", "TC", "FLOWLABEL", "NEXTHDR", "TCPFLAGS",
+	"ICMPTYPE", "ICMPCODE", "STRING",
 }
 
 var lexerRuleNames = []string{
 	"T__0", "T__1", "T__2", "T__3", "T__4", "T__5", "T__6", "T__7", "T__8",
-	"WHITESPACE", "DIGITS", "HEX_DIGITS", "NET", "ANY", "ALL", "NOT", "BOOL",
-	"SRC", "DST", "DSCP", "TOS", "PROTOCOL", "SRCPORT", "DSTPORT", "STRING",
+	"T__9", "WHITESPACE", "DIGITS", "HEX_DIGITS", "NET", "NET6", "ANY", "ALL",
+	"NOT", "BOOL", "SRC", "DST", "DSCP", "TOS", "PROTOCOL", "SRCPORT", "DSTPORT",
+	"TC", "FLOWLABEL", "NEXTHDR", "TCPFLAGS", "ICMPTYPE", "ICMPCODE", "STRING",
 }
 
 type TrafficClassLexer struct {
@@ -194,20 +258,28 @@ const (
 	TrafficClassLexerT__6       = 7
 	TrafficClassLexerT__7       = 8
 	TrafficClassLexerT__8       = 9
-	TrafficClassLexerWHITESPACE = 10
-	TrafficClassLexerDIGITS     = 11
-	TrafficClassLexerHEX_DIGITS = 12
-	TrafficClassLexerNET        = 13
-	TrafficClassLexerANY        = 14
-	TrafficClassLexerALL        = 15
-	TrafficClassLexerNOT        = 16
-	TrafficClassLexerBOOL       = 17
-	TrafficClassLexerSRC        = 18
-	TrafficClassLexerDST        = 19
-	TrafficClassLexerDSCP       = 20
-	TrafficClassLexerTOS        = 21
-	TrafficClassLexerPROTOCOL   = 22
-	TrafficClassLexerSRCPORT    = 23
-	TrafficClassLexerDSTPORT    = 24
-	TrafficClassLexerSTRING     = 25
+	TrafficClassLexerT__9       = 10
+	TrafficClassLexerWHITESPACE = 11
+	TrafficClassLexerDIGITS     = 12
+	TrafficClassLexerHEX_DIGITS = 13
+	TrafficClassLexerNET        = 14
+	TrafficClassLexerNET6       = 15
+	TrafficClassLexerANY        = 16
+	TrafficClassLexerALL        = 17
+	TrafficClassLexerNOT        = 18
+	TrafficClassLexerBOOL       = 19
+	TrafficClassLexerSRC        = 20
+	TrafficClassLexerDST        = 21
+	TrafficClassLexerDSCP       = 22
+	TrafficClassLexerTOS        = 23
+	TrafficClassLexerPROTOCOL   = 24
+	TrafficClassLexerSRCPORT    = 25
+	TrafficClassLexerDSTPORT    = 26
+	TrafficClassLexerTC         = 27
+	TrafficClassLexerFLOWLABEL  = 28
+	TrafficClassLexerNEXTHDR    = 29
+	TrafficClassLexerTCPFLAGS   = 30
+	TrafficClassLexerICMPTYPE   = 31
+	TrafficClassLexerICMPCODE   = 32
+	TrafficClassLexerSTRING     = 33
 )
@@ -34,6 +34,33 @@ type TrafficClassListener interface {
 	// EnterMatchDstPortRange is called when entering the matchDstPortRange production.
 	EnterMatchDstPortRange(c *MatchDstPortRangeContext)
 
+	// EnterMatchSrc6 is called when entering the matchSrc6 production.
+	EnterMatchSrc6(c *MatchSrc6Context)
+
+	// EnterMatchDst6 is called when entering the matchDst6 production.
+	EnterMatchDst6(c *MatchDst6Context)
+
+	// EnterMatchTC is called when entering the matchTC production.
+	EnterMatchTC(c *MatchTCContext)
+
+	// EnterMatchFlowLabel is called when entering the matchFlowLabel production.
+	EnterMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// EnterMatchNextHdr is called when entering the matchNextHdr production.
+	EnterMatchNextHdr(c *MatchNextHdrContext)
+
+	// EnterMatchTCPFlags is called when entering the matchTCPFlags production.
+	EnterMatchTCPFlags(c *MatchTCPFlagsContext)
+
+	// EnterMatchTCPFlagsMask is called when entering the matchTCPFlagsMask production.
+	EnterMatchTCPFlagsMask(c *MatchTCPFlagsMaskContext)
+
+	// EnterMatchICMPType is called when entering the matchICMPType production.
+	EnterMatchICMPType(c *MatchICMPTypeContext)
+
+	// EnterMatchICMPCode is called when entering the matchICMPCode production.
+	EnterMatchICMPCode(c *MatchICMPCodeContext)
+
 	// EnterCondCls is called when entering the condCls production.
 	EnterCondCls(c *CondClsContext)
 
@@ -52,9 +79,18 @@ type TrafficClassListener interface {
 	// EnterCondIPv4 is called when entering the condIPv4 production.
 	EnterCondIPv4(c *CondIPv4Context)
 
+	// EnterCondIPv6 is called when entering the condIPv6 production.
+	EnterCondIPv6(c *CondIPv6Context)
+
 	// EnterCondPort is called when entering the condPort production.
 	EnterCondPort(c *CondPortContext)
 
+	// EnterCondTCP is called when entering the condTCP production.
+	EnterCondTCP(c *CondTCPContext)
+
+	// EnterCondICMP is called when entering the condICMP production.
+	EnterCondICMP(c *CondICMPContext)
+
 	// EnterCond is called when entering the cond production.
 	EnterCond(c *CondContext)
 
@@ -88,6 +124,33 @@ type TrafficClassListener interface {
 	// ExitMatchDstPortRange is called when exiting the matchDstPortRange production.
 	ExitMatchDstPortRange(c *MatchDstPortRangeContext)
 
+	// ExitMatchSrc6 is called when exiting the matchSrc6 production.
+	ExitMatchSrc6(c *MatchSrc6Context)
+
+	// ExitMatchDst6 is called when exiting the matchDst6 production.
+	ExitMatchDst6(c *MatchDst6Context)
+
+	// ExitMatchTC is called when exiting the matchTC production.
+	ExitMatchTC(c *MatchTCContext)
+
+	// ExitMatchFlowLabel is called when exiting the matchFlowLabel production.
+	ExitMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// ExitMatchNextHdr is called when exiting the matchNextHdr production.
+	ExitMatchNextHdr(c *MatchNextHdrContext)
+
+	// ExitMatchTCPFlags is called when exiting the matchTCPFlags production.
+	ExitMatchTCPFlags(c *MatchTCPFlagsContext)
+
+	// ExitMatchTCPFlagsMask is called when exiting the matchTCPFlagsMask production.
+	ExitMatchTCPFlagsMask(c *MatchTCPFlagsMaskContext)
+
+	// ExitMatchICMPType is called when exiting the matchICMPType production.
+	ExitMatchICMPType(c *MatchICMPTypeContext)
+
+	// ExitMatchICMPCode is called when exiting the matchICMPCode production.
+	ExitMatchICMPCode(c *MatchICMPCodeContext)
+
 	// ExitCondCls is called when exiting the condCls production.
 	ExitCondCls(c *CondClsContext)
 
@@ -106,9 +169,18 @@ type TrafficClassListener interface {
 	// ExitCondIPv4 is called when exiting the condIPv4 production.
 	ExitCondIPv4(c *CondIPv4Context)
 
+	// ExitCondIPv6 is called when exiting the condIPv6 production.
+	ExitCondIPv6(c *CondIPv6Context)
+
 	// ExitCondPort is called when exiting the condPort production.
 	ExitCondPort(c *CondPortContext)
 
+	// ExitCondTCP is called when exiting the condTCP production.
+	ExitCondTCP(c *CondTCPContext)
+
+	// ExitCondICMP is called when exiting the condICMP production.
+	ExitCondICMP(c *CondICMPContext)
+
 	// ExitCond is called when exiting the cond production.
 	ExitCond(c *CondContext)
 
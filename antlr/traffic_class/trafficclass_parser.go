@@ -15,79 +15,117 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 27, 140,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 35, 220,
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7,
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13,
 	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9,
-	18, 4, 19, 9, 19, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 4,
-	3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 7,
-	3, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9,
-	3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11, 3, 11, 3,
-	12, 3, 12, 3, 12, 3, 12, 3, 12, 7, 12, 87, 10, 12, 12, 12, 14, 12, 90,
-	11, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 7, 13, 99, 10,
-	13, 12, 13, 14, 13, 102, 11, 13, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14, 3,
-	14, 3, 14, 3, 15, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 16, 3, 16,
-	5, 16, 120, 10, 16, 3, 17, 3, 17, 3, 17, 3, 17, 5, 17, 126, 10, 17, 3,
-	18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 5, 18, 135, 10, 18, 3, 19,
-	3, 19, 3, 19, 3, 19, 2, 2, 20, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22,
-	24, 26, 28, 30, 32, 34, 36, 2, 4, 3, 2, 13, 14, 3, 2, 10, 11, 2, 136, 2,
-	38, 3, 2, 2, 2, 4, 42, 3, 2, 2, 2, 6, 46, 3, 2, 2, 2, 8, 50, 3, 2, 2, 2,
-	10, 54, 3, 2, 2, 2, 12, 58, 3, 2, 2, 2, 14, 62, 3, 2, 2, 2, 16, 68, 3,
-	2, 2, 2, 18, 72, 3, 2, 2, 2, 20, 78, 3, 2, 2, 2, 22, 81, 3, 2, 2, 2, 24,
-	93, 3, 2, 2, 2, 26, 105, 3, 2, 2, 2, 28, 110, 3, 2, 2, 2, 30, 119, 3, 2,
-	2, 2, 32, 125, 3, 2, 2, 2, 34, 134, 3, 2, 2, 2, 36, 136, 3, 2, 2, 2, 38,
-	39, 7, 20, 2, 2, 39, 40, 7, 3, 2, 2, 40, 41, 7, 15, 2, 2, 41, 3, 3, 2,
-	2, 2, 42, 43, 7, 21, 2, 2, 43, 44, 7, 3, 2, 2, 44, 45, 7, 15, 2, 2, 45,
-	5, 3, 2, 2, 2, 46, 47, 7, 22, 2, 2, 47, 48, 7, 4, 2, 2, 48, 49, 9, 2, 2,
-	2, 49, 7, 3, 2, 2, 2, 50, 51, 7, 23, 2, 2, 51, 52, 7, 4, 2, 2, 52, 53,
-	9, 2, 2, 2, 53, 9, 3, 2, 2, 2, 54, 55, 7, 24, 2, 2, 55, 56, 7, 3, 2, 2,
-	56, 57, 7, 27, 2, 2, 57, 11, 3, 2, 2, 2, 58, 59, 7, 25, 2, 2, 59, 60, 7,
-	3, 2, 2, 60, 61, 7, 13, 2, 2, 61, 13, 3, 2, 2, 2, 62, 63, 7, 25, 2, 2,
-	63, 64, 7, 3, 2, 2, 64, 65, 7, 13, 2, 2, 65, 66, 7, 5, 2, 2, 66, 67, 7,
-	13, 2, 2, 67, 15, 3, 2, 2, 2, 68, 69, 7, 26, 2, 2, 69, 70, 7, 3, 2, 2,
-	70, 71, 7, 13, 2, 2, 71, 17, 3, 2, 2, 2, 72, 73, 7, 26, 2, 2, 73, 74, 7,
-	3, 2, 2, 74, 75, 7, 13, 2, 2, 75, 76, 7, 5, 2, 2, 76, 77, 7, 13, 2, 2,
-	77, 19, 3, 2, 2, 2, 78, 79, 7, 6, 2, 2, 79, 80, 7, 13, 2, 2, 80, 21, 3,
-	2, 2, 2, 81, 82, 7, 16, 2, 2, 82, 83, 7, 7, 2, 2, 83, 88, 5, 34, 18, 2,
-	84, 85, 7, 8, 2, 2, 85, 87, 5, 34, 18, 2, 86, 84, 3, 2, 2, 2, 87, 90, 3,
-	2, 2, 2, 88, 86, 3, 2, 2, 2, 88, 89, 3, 2, 2, 2, 89, 91, 3, 2, 2, 2, 90,
-	88, 3, 2, 2, 2, 91, 92, 7, 9, 2, 2, 92, 23, 3, 2, 2, 2, 93, 94, 7, 17,
-	2, 2, 94, 95, 7, 7, 2, 2, 95, 100, 5, 34, 18, 2, 96, 97, 7, 8, 2, 2, 97,
-	99, 5, 34, 18, 2, 98, 96, 3, 2, 2, 2, 99, 102, 3, 2, 2, 2, 100, 98, 3,
-	2, 2, 2, 100, 101, 3, 2, 2, 2, 101, 103, 3, 2, 2, 2, 102, 100, 3, 2, 2,
-	2, 103, 104, 7, 9, 2, 2, 104, 25, 3, 2, 2, 2, 105, 106, 7, 18, 2, 2, 106,
-	107, 7, 7, 2, 2, 107, 108, 5, 34, 18, 2, 108, 109, 7, 9, 2, 2, 109, 27,
-	3, 2, 2, 2, 110, 111, 7, 19, 2, 2, 111, 112, 7, 3, 2, 2, 112, 113, 9, 3,
-	2, 2, 113, 29, 3, 2, 2, 2, 114, 120, 5, 2, 2, 2, 115, 120, 5, 4, 3, 2,
-	116, 120, 5, 6, 4, 2, 117, 120, 5, 8, 5, 2, 118, 120, 5, 10, 6, 2, 119,
-	114, 3, 2, 2, 2, 119, 115, 3, 2, 2, 2, 119, 116, 3, 2, 2, 2, 119, 117,
-	3, 2, 2, 2, 119, 118, 3, 2, 2, 2, 120, 31, 3, 2, 2, 2, 121, 126, 5, 12,
-	7, 2, 122, 126, 5, 14, 8, 2, 123, 126, 5, 16, 9, 2, 124, 126, 5, 18, 10,
-	2, 125, 121, 3, 2, 2, 2, 125, 122, 3, 2, 2, 2, 125, 123, 3, 2, 2, 2, 125,
-	124, 3, 2, 2, 2, 126, 33, 3, 2, 2, 2, 127, 135, 5, 24, 13, 2, 128, 135,
-	5, 22, 12, 2, 129, 135, 5, 26, 14, 2, 130, 135, 5, 30, 16, 2, 131, 135,
-	5, 32, 17, 2, 132, 135, 5, 20, 11, 2, 133, 135, 5, 28, 15, 2, 134, 127,
-	3, 2, 2, 2, 134, 128, 3, 2, 2, 2, 134, 129, 3, 2, 2, 2, 134, 130, 3, 2,
-	2, 2, 134, 131, 3, 2, 2, 2, 134, 132, 3, 2, 2, 2, 134, 133, 3, 2, 2, 2,
-	135, 35, 3, 2, 2, 2, 136, 137, 5, 34, 18, 2, 137, 138, 7, 2, 2, 3, 138,
-	37, 3, 2, 2, 2, 7, 88, 100, 119, 125, 134,
+	18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23, 9, 23,
+	4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 4, 28, 9, 28, 4,
+	29, 9, 29, 4, 30, 9, 30, 4, 31, 9, 31, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3,
+	6, 3, 6, 3, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3,
+	8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3,
+	11, 3, 11, 3, 11, 3, 11, 3, 12, 3, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13,
+	3, 13, 3, 14, 3, 14, 3, 14, 3, 14, 3, 15, 3, 15, 3, 15, 3, 15, 3, 16, 3,
+	16, 3, 16, 3, 16, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 3, 18, 3, 18,
+	3, 18, 3, 18, 3, 19, 3, 19, 3, 19, 3, 19, 3, 20, 3, 20, 3, 20, 3, 21, 3,
+	21, 3, 21, 3, 21, 3, 21, 7, 21, 149, 10, 21, 12, 21, 14, 21, 152, 11, 21,
+	3, 21, 3, 21, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 7, 22, 161, 10, 22, 12,
+	22, 14, 22, 164, 11, 22, 3, 22, 3, 22, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23,
+	3, 24, 3, 24, 3, 24, 3, 24, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 5, 25, 182,
+	10, 25, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 5, 26, 189, 10, 26, 3, 27, 3,
+	27, 3, 27, 3, 27, 5, 27, 195, 10, 27, 3, 28, 3, 28, 5, 28, 199, 10, 28,
+	3, 29, 3, 29, 5, 29, 203, 10, 29, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3,
+	30, 3, 30, 3, 30, 3, 30, 3, 30, 5, 30, 215, 10, 30, 3, 31, 3, 31, 3, 31,
+	3, 31, 2, 2, 32, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30,
+	32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 2, 4, 3, 2,
+	14, 15, 3, 2, 11, 12, 2, 213, 2, 62, 3, 2, 2, 2, 4, 66, 3, 2, 2, 2, 6,
+	70, 3, 2, 2, 2, 8, 74, 3, 2, 2, 2, 10, 78, 3, 2, 2, 2, 12, 82, 3, 2, 2,
+	2, 14, 86, 3, 2, 2, 2, 16, 92, 3, 2, 2, 2, 18, 96, 3, 2, 2, 2, 20, 102,
+	3, 2, 2, 2, 22, 106, 3, 2, 2, 2, 24, 110, 3, 2, 2, 2, 26, 114, 3, 2, 2,
+	2, 28, 118, 3, 2, 2, 2, 30, 122, 3, 2, 2, 2, 32, 126, 3, 2, 2, 2, 34, 132,
+	3, 2, 2, 2, 36, 136, 3, 2, 2, 2, 38, 140, 3, 2, 2, 2, 40, 143, 3, 2, 2,
+	2, 42, 155, 3, 2, 2, 2, 44, 167, 3, 2, 2, 2, 46, 172, 3, 2, 2, 2, 48, 181,
+	3, 2, 2, 2, 50, 188, 3, 2, 2, 2, 52, 194, 3, 2, 2, 2, 54, 198, 3, 2, 2,
+	2, 56, 202, 3, 2, 2, 2, 58, 214, 3, 2, 2, 2, 60, 216, 3, 2, 2, 2, 62, 63,
+	7, 22, 2, 2, 63, 64, 7, 3, 2, 2, 64, 65, 7, 16, 2, 2, 65, 3, 3, 2, 2, 2,
+	66, 67, 7, 23, 2, 2, 67, 68, 7, 3, 2, 2, 68, 69, 7, 16, 2, 2, 69, 5, 3,
+	2, 2, 2, 70, 71, 7, 24, 2, 2, 71, 72, 7, 4, 2, 2, 72, 73, 9, 2, 2, 2, 73,
+	7, 3, 2, 2, 2, 74, 75, 7, 25, 2, 2, 75, 76, 7, 4, 2, 2, 76, 77, 9, 2, 2,
+	2, 77, 9, 3, 2, 2, 2, 78, 79, 7, 26, 2, 2, 79, 80, 7, 3, 2, 2, 80, 81,
+	7, 35, 2, 2, 81, 11, 3, 2, 2, 2, 82, 83, 7, 27, 2, 2, 83, 84, 7, 3, 2,
+	2, 84, 85, 7, 14, 2, 2, 85, 13, 3, 2, 2, 2, 86, 87, 7, 27, 2, 2, 87, 88,
+	7, 3, 2, 2, 88, 89, 7, 14, 2, 2, 89, 90, 7, 5, 2, 2, 90, 91, 7, 14, 2,
+	2, 91, 15, 3, 2, 2, 2, 92, 93, 7, 28, 2, 2, 93, 94, 7, 3, 2, 2, 94, 95,
+	7, 14, 2, 2, 95, 17, 3, 2, 2, 2, 96, 97, 7, 28, 2, 2, 97, 98, 7, 3, 2,
+	2, 98, 99, 7, 14, 2, 2, 99, 100, 7, 5, 2, 2, 100, 101, 7, 14, 2, 2, 101,
+	19, 3, 2, 2, 2, 102, 103, 7, 22, 2, 2, 103, 104, 7, 3, 2, 2, 104, 105,
+	7, 17, 2, 2, 105, 21, 3, 2, 2, 2, 106, 107, 7, 23, 2, 2, 107, 108, 7, 3,
+	2, 2, 108, 109, 7, 17, 2, 2, 109, 23, 3, 2, 2, 2, 110, 111, 7, 29, 2, 2,
+	111, 112, 7, 4, 2, 2, 112, 113, 9, 2, 2, 2, 113, 25, 3, 2, 2, 2, 114, 115,
+	7, 30, 2, 2, 115, 116, 7, 3, 2, 2, 116, 117, 7, 14, 2, 2, 117, 27, 3, 2,
+	2, 2, 118, 119, 7, 31, 2, 2, 119, 120, 7, 3, 2, 2, 120, 121, 7, 35, 2,
+	2, 121, 29, 3, 2, 2, 2, 122, 123, 7, 32, 2, 2, 123, 124, 7, 4, 2, 2, 124,
+	125, 9, 2, 2, 2, 125, 31, 3, 2, 2, 2, 126, 127, 7, 32, 2, 2, 127, 128,
+	7, 4, 2, 2, 128, 129, 9, 2, 2, 2, 129, 130, 7, 6, 2, 2, 130, 131, 9, 2,
+	2, 2, 131, 33, 3, 2, 2, 2, 132, 133, 7, 33, 2, 2, 133, 134, 7, 3, 2, 2,
+	134, 135, 7, 14, 2, 2, 135, 35, 3, 2, 2, 2, 136, 137, 7, 34, 2, 2, 137,
+	138, 7, 3, 2, 2, 138, 139, 7, 14, 2, 2, 139, 37, 3, 2, 2, 2, 140, 141,
+	7, 7, 2, 2, 141, 142, 7, 14, 2, 2, 142, 39, 3, 2, 2, 2, 143, 144, 7, 18,
+	2, 2, 144, 145, 7, 8, 2, 2, 145, 150, 5, 58, 30, 2, 146, 147, 7, 9, 2,
+	2, 147, 149, 5, 58, 30, 2, 148, 146, 3, 2, 2, 2, 149, 152, 3, 2, 2, 2,
+	150, 148, 3, 2, 2, 2, 150, 151, 3, 2, 2, 2, 151, 153, 3, 2, 2, 2, 152,
+	150, 3, 2, 2, 2, 153, 154, 7, 10, 2, 2, 154, 41, 3, 2, 2, 2, 155, 156,
+	7, 19, 2, 2, 156, 157, 7, 8, 2, 2, 157, 162, 5, 58, 30, 2, 158, 159, 7,
+	9, 2, 2, 159, 161, 5, 58, 30, 2, 160, 158, 3, 2, 2, 2, 161, 164, 3, 2,
+	2, 2, 162, 160, 3, 2, 2, 2, 162, 163, 3, 2, 2, 2, 163, 165, 3, 2, 2, 2,
+	164, 162, 3, 2, 2, 2, 165, 166, 7, 10, 2, 2, 166, 43, 3, 2, 2, 2, 167,
+	168, 7, 20, 2, 2, 168, 169, 7, 8, 2, 2, 169, 170, 5, 58, 30, 2, 170, 171,
+	7, 10, 2, 2, 171, 45, 3, 2, 2, 2, 172, 173, 7, 21, 2, 2, 173, 174, 7, 3,
+	2, 2, 174, 175, 9, 3, 2, 2, 175, 47, 3, 2, 2, 2, 176, 182, 5, 2, 2, 2,
+	177, 182, 5, 4, 3, 2, 178, 182, 5, 6, 4, 2, 179, 182, 5, 8, 5, 2, 180,
+	182, 5, 10, 6, 2, 181, 176, 3, 2, 2, 2, 181, 177, 3, 2, 2, 2, 181, 178,
+	3, 2, 2, 2, 181, 179, 3, 2, 2, 2, 181, 180, 3, 2, 2, 2, 182, 49, 3, 2,
+	2, 2, 183, 189, 5, 20, 11, 2, 184, 189, 5, 22, 12, 2, 185, 189, 5, 24,
+	13, 2, 186, 189, 5, 26, 14, 2, 187, 189, 5, 28, 15, 2, 188, 183, 3, 2,
+	2, 2, 188, 184, 3, 2, 2, 2, 188, 185, 3, 2, 2, 2, 188, 186, 3, 2, 2, 2,
+	188, 187, 3, 2, 2, 2, 189, 51, 3, 2, 2, 2, 190, 195, 5, 12, 7, 2, 191,
+	195, 5, 14, 8, 2, 192, 195, 5, 16, 9, 2, 193, 195, 5, 18, 10, 2, 194, 190,
+	3, 2, 2, 2, 194, 191, 3, 2, 2, 2, 194, 192, 3, 2, 2, 2, 194, 193, 3, 2,
+	2, 2, 195, 53, 3, 2, 2, 2, 196, 199, 5, 30, 16, 2, 197, 199, 5, 32, 17,
+	2, 198, 196, 3, 2, 2, 2, 198, 197, 3, 2, 2, 2, 199, 55, 3, 2, 2, 2, 200,
+	203, 5, 34, 18, 2, 201, 203, 5, 36, 19, 2, 202, 200, 3, 2, 2, 2, 202, 201,
+	3, 2, 2, 2, 203, 57, 3, 2, 2, 2, 204, 215, 5, 42, 22, 2, 205, 215, 5, 40,
+	21, 2, 206, 215, 5, 44, 23, 2, 207, 215, 5, 48, 25, 2, 208, 215, 5, 50,
+	26, 2, 209, 215, 5, 52, 27, 2, 210, 215, 5, 54, 28, 2, 211, 215, 5, 56,
+	29, 2, 212, 215, 5, 38, 20, 2, 213, 215, 5, 46, 24, 2, 214, 204, 3, 2,
+	2, 2, 214, 205, 3, 2, 2, 2, 214, 206, 3, 2, 2, 2, 214, 207, 3, 2, 2, 2,
+	214, 208, 3, 2, 2, 2, 214, 209, 3, 2, 2, 2, 214, 210, 3, 2, 2, 2, 214,
+	211, 3, 2, 2, 2, 214, 212, 3, 2, 2, 2, 214, 213, 3, 2, 2, 2, 215, 59, 3,
+	2, 2, 2, 216, 217, 5, 58, 30, 2, 217, 218, 7, 2, 2, 3, 218, 61, 3, 2, 2,
+	2, 10, 150, 162, 181, 188, 194, 198, 202, 214,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
 
 var literalNames = []string{
-	"", "'='", "'=0x'", "'-'", "'cls='", "'('", "','", "')'", "'true'", "'false'",
+	"", "'='", "'=0x'", "'-'", "'/0x'", "'cls='", "'('", "','", "')'", "'true'",
+	"'false'",
 }
 var symbolicNames = []string{
-	"", "", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
-	"NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS", "PROTOCOL",
-	"SRCPORT", "DSTPORT", "STRING",
+	"", "", "", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
+	"NET", "NET6", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+	"PROTOCOL", "SRCPORT", "DSTPORT", "TC", "FLOWLABEL", "NEXTHDR", "TCPFLAGS",
+	"ICMPTYPE", "ICMPCODE", "STRING",
 }
 
 var ruleNames = []string{
 	"matchSrc", "matchDst", "matchDSCP", "matchTOS", "matchProtocol", "matchSrcPort",
-	"matchSrcPortRange", "matchDstPort", "matchDstPortRange", "condCls", "condAny",
-	"condAll", "condNot", "condBool", "condIPv4", "condPort", "cond", "trafficClass",
+	"matchSrcPortRange", "matchDstPort", "matchDstPortRange", "matchSrc6",
+	"matchDst6", "matchTC", "matchFlowLabel", "matchNextHdr", "matchTCPFlags",
+	"matchTCPFlagsMask", "matchICMPType", "matchICMPCode", "condCls", "condAny",
+	"condAll", "condNot", "condBool", "condIPv4", "condIPv6", "condPort", "condTCP",
+	"condICMP", "cond", "trafficClass",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -127,22 +165,30 @@ const (
 	TrafficClassParserT__6       = 7
 	TrafficClassParserT__7       = 8
 	TrafficClassParserT__8       = 9
-	TrafficClassParserWHITESPACE = 10
-	TrafficClassParserDIGITS     = 11
-	TrafficClassParserHEX_DIGITS = 12
-	TrafficClassParserNET        = 13
-	TrafficClassParserANY        = 14
-	TrafficClassParserALL        = 15
-	TrafficClassParserNOT        = 16
-	TrafficClassParserBOOL       = 17
-	TrafficClassParserSRC        = 18
-	TrafficClassParserDST        = 19
-	TrafficClassParserDSCP       = 20
-	TrafficClassParserTOS        = 21
-	TrafficClassParserPROTOCOL   = 22
-	TrafficClassParserSRCPORT    = 23
-	TrafficClassParserDSTPORT    = 24
-	TrafficClassParserSTRING     = 25
+	TrafficClassParserT__9       = 10
+	TrafficClassParserWHITESPACE = 11
+	TrafficClassParserDIGITS     = 12
+	TrafficClassParserHEX_DIGITS = 13
+	TrafficClassParserNET        = 14
+	TrafficClassParserNET6       = 15
+	TrafficClassParserANY        = 16
+	TrafficClassParserALL        = 17
+	TrafficClassParserNOT        = 18
+	TrafficClassParserBOOL       = 19
+	TrafficClassParserSRC        = 20
+	TrafficClassParserDST        = 21
+	TrafficClassParserDSCP       = 22
+	TrafficClassParserTOS        = 23
+	TrafficClassParserPROTOCOL   = 24
+	TrafficClassParserSRCPORT    = 25
+	TrafficClassParserDSTPORT    = 26
+	TrafficClassParserTC         = 27
+	TrafficClassParserFLOWLABEL  = 28
+	TrafficClassParserNEXTHDR    = 29
+	TrafficClassParserTCPFLAGS   = 30
+	TrafficClassParserICMPTYPE   = 31
+	TrafficClassParserICMPCODE   = 32
+	TrafficClassParserSTRING     = 33
 )
 
 // TrafficClassParser rules.
@@ -156,15 +202,27 @@ const (
 	TrafficClassParserRULE_matchSrcPortRange = 6
 	TrafficClassParserRULE_matchDstPort      = 7
 	TrafficClassParserRULE_matchDstPortRange = 8
-	TrafficClassParserRULE_condCls           = 9
-	TrafficClassParserRULE_condAny           = 10
-	TrafficClassParserRULE_condAll           = 11
-	TrafficClassParserRULE_condNot           = 12
-	TrafficClassParserRULE_condBool          = 13
-	TrafficClassParserRULE_condIPv4          = 14
-	TrafficClassParserRULE_condPort          = 15
-	TrafficClassParserRULE_cond              = 16
-	TrafficClassParserRULE_trafficClass      = 17
+	TrafficClassParserRULE_matchSrc6         = 9
+	TrafficClassParserRULE_matchDst6         = 10
+	TrafficClassParserRULE_matchTC           = 11
+	TrafficClassParserRULE_matchFlowLabel    = 12
+	TrafficClassParserRULE_matchNextHdr      = 13
+	TrafficClassParserRULE_matchTCPFlags     = 14
+	TrafficClassParserRULE_matchTCPFlagsMask = 15
+	TrafficClassParserRULE_matchICMPType     = 16
+	TrafficClassParserRULE_matchICMPCode     = 17
+	TrafficClassParserRULE_condCls           = 18
+	TrafficClassParserRULE_condAny           = 19
+	TrafficClassParserRULE_condAll           = 20
+	TrafficClassParserRULE_condNot           = 21
+	TrafficClassParserRULE_condBool          = 22
+	TrafficClassParserRULE_condIPv4          = 23
+	TrafficClassParserRULE_condIPv6          = 24
+	TrafficClassParserRULE_condPort          = 25
+	TrafficClassParserRULE_condTCP           = 26
+	TrafficClassParserRULE_condICMP          = 27
+	TrafficClassParserRULE_cond              = 28
+	TrafficClassParserRULE_trafficClass      = 29
 )
 
 // IMatchSrcContext is an interface to support dynamic dispatch.
@@ -255,15 +313,15 @@ func (p *TrafficClassParser) MatchSrc() (localctx IMatchSrcContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(36)
+		p.SetState(60)
 		p.Match(TrafficClassParserSRC)
 	}
 	{
-		p.SetState(37)
+		p.SetState(61)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(38)
+		p.SetState(62)
 		p.Match(TrafficClassParserNET)
 	}
 
@@ -358,15 +416,15 @@ func (p *TrafficClassParser) MatchDst() (localctx IMatchDstContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(40)
+		p.SetState(64)
 		p.Match(TrafficClassParserDST)
 	}
 	{
-		p.SetState(41)
+		p.SetState(65)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(42)
+		p.SetState(66)
 		p.Match(TrafficClassParserNET)
 	}
 
@@ -466,15 +524,15 @@ func (p *TrafficClassParser) MatchDSCP() (localctx IMatchDSCPContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(44)
+		p.SetState(68)
 		p.Match(TrafficClassParserDSCP)
 	}
 	{
-		p.SetState(45)
+		p.SetState(69)
 		p.Match(TrafficClassParserT__1)
 	}
 	{
-		p.SetState(46)
+		p.SetState(70)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
@@ -581,15 +639,15 @@ func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(48)
+		p.SetState(72)
 		p.Match(TrafficClassParserTOS)
 	}
 	{
-		p.SetState(49)
+		p.SetState(73)
 		p.Match(TrafficClassParserT__1)
 	}
 	{
-		p.SetState(50)
+		p.SetState(74)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
@@ -691,15 +749,15 @@ func (p *TrafficClassParser) MatchProtocol() (localctx IMatchProtocolContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(52)
+		p.SetState(76)
 		p.Match(TrafficClassParserPROTOCOL)
 	}
 	{
-		p.SetState(53)
+		p.SetState(77)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(54)
+		p.SetState(78)
 		p.Match(TrafficClassParserSTRING)
 	}
 
@@ -794,15 +852,15 @@ func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(56)
+		p.SetState(80)
 		p.Match(TrafficClassParserSRCPORT)
 	}
 	{
-		p.SetState(57)
+		p.SetState(81)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(58)
+		p.SetState(82)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
@@ -901,23 +959,23 @@ func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeCon
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(60)
+		p.SetState(84)
 		p.Match(TrafficClassParserSRCPORT)
 	}
 	{
-		p.SetState(61)
+		p.SetState(85)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(62)
+		p.SetState(86)
 		p.Match(TrafficClassParserDIGITS)
 	}
 	{
-		p.SetState(63)
+		p.SetState(87)
 		p.Match(TrafficClassParserT__2)
 	}
 	{
-		p.SetState(64)
+		p.SetState(88)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
@@ -1012,15 +1070,15 @@ func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(66)
+		p.SetState(90)
 		p.Match(TrafficClassParserDSTPORT)
 	}
 	{
-		p.SetState(67)
+		p.SetState(91)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(68)
+		p.SetState(92)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
@@ -1119,94 +1177,98 @@ func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeCon
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(70)
+		p.SetState(94)
 		p.Match(TrafficClassParserDSTPORT)
 	}
 	{
-		p.SetState(71)
+		p.SetState(95)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(72)
+		p.SetState(96)
 		p.Match(TrafficClassParserDIGITS)
 	}
 	{
-		p.SetState(73)
+		p.SetState(97)
 		p.Match(TrafficClassParserT__2)
 	}
 	{
-		p.SetState(74)
+		p.SetState(98)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
 	return localctx
 }
 
-// ICondClsContext is an interface to support dynamic dispatch.
-type ICondClsContext interface {
+// IMatchSrc6Context is an interface to support dynamic dispatch.
+type IMatchSrc6Context interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsCondClsContext differentiates from other interfaces.
-	IsCondClsContext()
+	// IsMatchSrc6Context differentiates from other interfaces.
+	IsMatchSrc6Context()
 }
 
-type CondClsContext struct {
+type MatchSrc6Context struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyCondClsContext() *CondClsContext {
-	var p = new(CondClsContext)
+func NewEmptyMatchSrc6Context() *MatchSrc6Context {
+	var p = new(MatchSrc6Context)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_condCls
+	p.RuleIndex = TrafficClassParserRULE_matchSrc6
 	return p
 }
 
-func (*CondClsContext) IsCondClsContext() {}
+func (*MatchSrc6Context) IsMatchSrc6Context() {}
 
-func NewCondClsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondClsContext {
-	var p = new(CondClsContext)
+func NewMatchSrc6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchSrc6Context {
+	var p = new(MatchSrc6Context)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_condCls
+	p.RuleIndex = TrafficClassParserRULE_matchSrc6
 
 	return p
 }
 
-func (s *CondClsContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchSrc6Context) GetParser() antlr.Parser { return s.parser }
 
-func (s *CondClsContext) DIGITS() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserDIGITS, 0)
+func (s *MatchSrc6Context) SRC() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSRC, 0)
 }
 
-func (s *CondClsContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchSrc6Context) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
+}
+
+func (s *MatchSrc6Context) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CondClsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchSrc6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *CondClsContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchSrc6Context) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterCondCls(s)
+		listenerT.EnterMatchSrc6(s)
 	}
 }
 
-func (s *CondClsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchSrc6Context) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitCondCls(s)
+		listenerT.ExitMatchSrc6(s)
 	}
 }
 
-func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
-	localctx = NewCondClsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, TrafficClassParserRULE_condCls)
+func (p *TrafficClassParser) MatchSrc6() (localctx IMatchSrc6Context) {
+	localctx = NewMatchSrc6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, TrafficClassParserRULE_matchSrc6)
 
 	defer func() {
 		p.ExitRule()
@@ -1226,106 +1288,90 @@ func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(76)
-		p.Match(TrafficClassParserT__3)
+		p.SetState(100)
+		p.Match(TrafficClassParserSRC)
 	}
 	{
-		p.SetState(77)
-		p.Match(TrafficClassParserDIGITS)
+		p.SetState(101)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(102)
+		p.Match(TrafficClassParserNET6)
 	}
 
 	return localctx
 }
 
-// ICondAnyContext is an interface to support dynamic dispatch.
-type ICondAnyContext interface {
+// IMatchDst6Context is an interface to support dynamic dispatch.
+type IMatchDst6Context interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsCondAnyContext differentiates from other interfaces.
-	IsCondAnyContext()
+	// IsMatchDst6Context differentiates from other interfaces.
+	IsMatchDst6Context()
 }
 
-type CondAnyContext struct {
+type MatchDst6Context struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyCondAnyContext() *CondAnyContext {
-	var p = new(CondAnyContext)
+func NewEmptyMatchDst6Context() *MatchDst6Context {
+	var p = new(MatchDst6Context)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_condAny
+	p.RuleIndex = TrafficClassParserRULE_matchDst6
 	return p
 }
 
-func (*CondAnyContext) IsCondAnyContext() {}
+func (*MatchDst6Context) IsMatchDst6Context() {}
 
-func NewCondAnyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondAnyContext {
-	var p = new(CondAnyContext)
+func NewMatchDst6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchDst6Context {
+	var p = new(MatchDst6Context)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_condAny
+	p.RuleIndex = TrafficClassParserRULE_matchDst6
 
 	return p
 }
 
-func (s *CondAnyContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *CondAnyContext) ANY() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserANY, 0)
-}
-
-func (s *CondAnyContext) AllCond() []ICondContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ICondContext)(nil)).Elem())
-	var tst = make([]ICondContext, len(ts))
-
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(ICondContext)
-		}
-	}
+func (s *MatchDst6Context) GetParser() antlr.Parser { return s.parser }
 
-	return tst
+func (s *MatchDst6Context) DST() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDST, 0)
 }
 
-func (s *CondAnyContext) Cond(i int) ICondContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondContext)(nil)).Elem(), i)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ICondContext)
+func (s *MatchDst6Context) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
 }
 
-func (s *CondAnyContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchDst6Context) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CondAnyContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchDst6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *CondAnyContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchDst6Context) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterCondAny(s)
+		listenerT.EnterMatchDst6(s)
 	}
 }
 
-func (s *CondAnyContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchDst6Context) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitCondAny(s)
+		listenerT.ExitMatchDst6(s)
 	}
 }
 
-func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
-	localctx = NewCondAnyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, TrafficClassParserRULE_condAny)
-	var _la int
+func (p *TrafficClassParser) MatchDst6() (localctx IMatchDst6Context) {
+	localctx = NewMatchDst6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, TrafficClassParserRULE_matchDst6)
 
 	defer func() {
 		p.ExitRule()
@@ -1345,131 +1391,94 @@ func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(79)
-		p.Match(TrafficClassParserANY)
-	}
-	{
-		p.SetState(80)
-		p.Match(TrafficClassParserT__4)
+		p.SetState(104)
+		p.Match(TrafficClassParserDST)
 	}
 	{
-		p.SetState(81)
-		p.Cond()
-	}
-	p.SetState(86)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
-
-	for _la == TrafficClassParserT__5 {
-		{
-			p.SetState(82)
-			p.Match(TrafficClassParserT__5)
-		}
-		{
-			p.SetState(83)
-			p.Cond()
-		}
-
-		p.SetState(88)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
+		p.SetState(105)
+		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(89)
-		p.Match(TrafficClassParserT__6)
+		p.SetState(106)
+		p.Match(TrafficClassParserNET6)
 	}
 
 	return localctx
 }
 
-// ICondAllContext is an interface to support dynamic dispatch.
-type ICondAllContext interface {
+// IMatchTCContext is an interface to support dynamic dispatch.
+type IMatchTCContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsCondAllContext differentiates from other interfaces.
-	IsCondAllContext()
+	// IsMatchTCContext differentiates from other interfaces.
+	IsMatchTCContext()
 }
 
-type CondAllContext struct {
+type MatchTCContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyCondAllContext() *CondAllContext {
-	var p = new(CondAllContext)
+func NewEmptyMatchTCContext() *MatchTCContext {
+	var p = new(MatchTCContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_condAll
+	p.RuleIndex = TrafficClassParserRULE_matchTC
 	return p
 }
 
-func (*CondAllContext) IsCondAllContext() {}
+func (*MatchTCContext) IsMatchTCContext() {}
 
-func NewCondAllContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondAllContext {
-	var p = new(CondAllContext)
+func NewMatchTCContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchTCContext {
+	var p = new(MatchTCContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_condAll
+	p.RuleIndex = TrafficClassParserRULE_matchTC
 
 	return p
 }
 
-func (s *CondAllContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchTCContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *CondAllContext) ALL() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserALL, 0)
+func (s *MatchTCContext) TC() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTC, 0)
 }
 
-func (s *CondAllContext) AllCond() []ICondContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ICondContext)(nil)).Elem())
-	var tst = make([]ICondContext, len(ts))
-
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(ICondContext)
-		}
-	}
-
-	return tst
+func (s *MatchTCContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
 }
 
-func (s *CondAllContext) Cond(i int) ICondContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondContext)(nil)).Elem(), i)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ICondContext)
+func (s *MatchTCContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
 }
 
-func (s *CondAllContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchTCContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CondAllContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchTCContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *CondAllContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchTCContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterCondAll(s)
+		listenerT.EnterMatchTC(s)
 	}
 }
 
-func (s *CondAllContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchTCContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitCondAll(s)
+		listenerT.ExitMatchTC(s)
 	}
 }
 
-func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
-	localctx = NewCondAllContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, TrafficClassParserRULE_condAll)
+func (p *TrafficClassParser) MatchTC() (localctx IMatchTCContext) {
+	localctx = NewMatchTCContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, TrafficClassParserRULE_matchTC)
 	var _la int
 
 	defer func() {
@@ -1490,118 +1499,97 @@ func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(91)
-		p.Match(TrafficClassParserALL)
+		p.SetState(108)
+		p.Match(TrafficClassParserTC)
 	}
 	{
-		p.SetState(92)
-		p.Match(TrafficClassParserT__4)
+		p.SetState(109)
+		p.Match(TrafficClassParserT__1)
 	}
 	{
-		p.SetState(93)
-		p.Cond()
-	}
-	p.SetState(98)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
+		p.SetState(110)
+		_la = p.GetTokenStream().LA(1)
 
-	for _la == TrafficClassParserT__5 {
-		{
-			p.SetState(94)
-			p.Match(TrafficClassParserT__5)
-		}
-		{
-			p.SetState(95)
-			p.Cond()
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
 		}
-
-		p.SetState(100)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
-	}
-	{
-		p.SetState(101)
-		p.Match(TrafficClassParserT__6)
 	}
 
 	return localctx
 }
 
-// ICondNotContext is an interface to support dynamic dispatch.
-type ICondNotContext interface {
+// IMatchFlowLabelContext is an interface to support dynamic dispatch.
+type IMatchFlowLabelContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsCondNotContext differentiates from other interfaces.
-	IsCondNotContext()
+	// IsMatchFlowLabelContext differentiates from other interfaces.
+	IsMatchFlowLabelContext()
 }
 
-type CondNotContext struct {
+type MatchFlowLabelContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyCondNotContext() *CondNotContext {
-	var p = new(CondNotContext)
+func NewEmptyMatchFlowLabelContext() *MatchFlowLabelContext {
+	var p = new(MatchFlowLabelContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_condNot
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
 	return p
 }
 
-func (*CondNotContext) IsCondNotContext() {}
+func (*MatchFlowLabelContext) IsMatchFlowLabelContext() {}
 
-func NewCondNotContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondNotContext {
-	var p = new(CondNotContext)
+func NewMatchFlowLabelContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchFlowLabelContext {
+	var p = new(MatchFlowLabelContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_condNot
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
 
 	return p
 }
 
-func (s *CondNotContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchFlowLabelContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *CondNotContext) NOT() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserNOT, 0)
+func (s *MatchFlowLabelContext) FLOWLABEL() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserFLOWLABEL, 0)
 }
 
-func (s *CondNotContext) Cond() ICondContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ICondContext)
+func (s *MatchFlowLabelContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
 }
 
-func (s *CondNotContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchFlowLabelContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CondNotContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchFlowLabelContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *CondNotContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchFlowLabelContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterCondNot(s)
+		listenerT.EnterMatchFlowLabel(s)
 	}
 }
 
-func (s *CondNotContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchFlowLabelContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitCondNot(s)
+		listenerT.ExitMatchFlowLabel(s)
 	}
 }
 
-func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
-	localctx = NewCondNotContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, TrafficClassParserRULE_condNot)
+func (p *TrafficClassParser) MatchFlowLabel() (localctx IMatchFlowLabelContext) {
+	localctx = NewMatchFlowLabelContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 24, TrafficClassParserRULE_matchFlowLabel)
 
 	defer func() {
 		p.ExitRule()
@@ -1621,43 +1609,1099 @@ func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(103)
-		p.Match(TrafficClassParserNOT)
-	}
-	{
-		p.SetState(104)
-		p.Match(TrafficClassParserT__4)
+		p.SetState(112)
+		p.Match(TrafficClassParserFLOWLABEL)
 	}
 	{
-		p.SetState(105)
-		p.Cond()
+		p.SetState(113)
+		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(106)
-		p.Match(TrafficClassParserT__6)
+		p.SetState(114)
+		p.Match(TrafficClassParserDIGITS)
 	}
 
 	return localctx
 }
 
-// ICondBoolContext is an interface to support dynamic dispatch.
-type ICondBoolContext interface {
+// IMatchNextHdrContext is an interface to support dynamic dispatch.
+type IMatchNextHdrContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsCondBoolContext differentiates from other interfaces.
-	IsCondBoolContext()
+	// IsMatchNextHdrContext differentiates from other interfaces.
+	IsMatchNextHdrContext()
 }
 
-type CondBoolContext struct {
+type MatchNextHdrContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyCondBoolContext() *CondBoolContext {
-	var p = new(CondBoolContext)
+func NewEmptyMatchNextHdrContext() *MatchNextHdrContext {
+	var p = new(MatchNextHdrContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchNextHdr
+	return p
+}
+
+func (*MatchNextHdrContext) IsMatchNextHdrContext() {}
+
+func NewMatchNextHdrContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchNextHdrContext {
+	var p = new(MatchNextHdrContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchNextHdr
+
+	return p
+}
+
+func (s *MatchNextHdrContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchNextHdrContext) NEXTHDR() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNEXTHDR, 0)
+}
+
+func (s *MatchNextHdrContext) STRING() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSTRING, 0)
+}
+
+func (s *MatchNextHdrContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchNextHdrContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchNextHdrContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchNextHdr(s)
+	}
+}
+
+func (s *MatchNextHdrContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchNextHdr(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchNextHdr() (localctx IMatchNextHdrContext) {
+	localctx = NewMatchNextHdrContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 26, TrafficClassParserRULE_matchNextHdr)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(116)
+		p.Match(TrafficClassParserNEXTHDR)
+	}
+	{
+		p.SetState(117)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(118)
+		p.Match(TrafficClassParserSTRING)
+	}
+
+	return localctx
+}
+
+// IMatchTCPFlagsContext is an interface to support dynamic dispatch.
+type IMatchTCPFlagsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchTCPFlagsContext differentiates from other interfaces.
+	IsMatchTCPFlagsContext()
+}
+
+type MatchTCPFlagsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchTCPFlagsContext() *MatchTCPFlagsContext {
+	var p = new(MatchTCPFlagsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchTCPFlags
+	return p
+}
+
+func (*MatchTCPFlagsContext) IsMatchTCPFlagsContext() {}
+
+func NewMatchTCPFlagsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchTCPFlagsContext {
+	var p = new(MatchTCPFlagsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchTCPFlags
+
+	return p
+}
+
+func (s *MatchTCPFlagsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchTCPFlagsContext) TCPFLAGS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTCPFLAGS, 0)
+}
+
+func (s *MatchTCPFlagsContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchTCPFlagsContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchTCPFlagsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchTCPFlagsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchTCPFlagsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchTCPFlags(s)
+	}
+}
+
+func (s *MatchTCPFlagsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchTCPFlags(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchTCPFlags() (localctx IMatchTCPFlagsContext) {
+	localctx = NewMatchTCPFlagsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, TrafficClassParserRULE_matchTCPFlags)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(120)
+		p.Match(TrafficClassParserTCPFLAGS)
+	}
+	{
+		p.SetState(121)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(122)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchTCPFlagsMaskContext is an interface to support dynamic dispatch.
+type IMatchTCPFlagsMaskContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchTCPFlagsMaskContext differentiates from other interfaces.
+	IsMatchTCPFlagsMaskContext()
+}
+
+type MatchTCPFlagsMaskContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchTCPFlagsMaskContext() *MatchTCPFlagsMaskContext {
+	var p = new(MatchTCPFlagsMaskContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchTCPFlagsMask
+	return p
+}
+
+func (*MatchTCPFlagsMaskContext) IsMatchTCPFlagsMaskContext() {}
+
+func NewMatchTCPFlagsMaskContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchTCPFlagsMaskContext {
+	var p = new(MatchTCPFlagsMaskContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchTCPFlagsMask
+
+	return p
+}
+
+func (s *MatchTCPFlagsMaskContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchTCPFlagsMaskContext) TCPFLAGS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTCPFLAGS, 0)
+}
+
+func (s *MatchTCPFlagsMaskContext) AllHEX_DIGITS() []antlr.TerminalNode {
+	return s.GetTokens(TrafficClassParserHEX_DIGITS)
+}
+
+func (s *MatchTCPFlagsMaskContext) HEX_DIGITS(i int) antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, i)
+}
+
+func (s *MatchTCPFlagsMaskContext) AllDIGITS() []antlr.TerminalNode {
+	return s.GetTokens(TrafficClassParserDIGITS)
+}
+
+func (s *MatchTCPFlagsMaskContext) DIGITS(i int) antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, i)
+}
+
+func (s *MatchTCPFlagsMaskContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchTCPFlagsMaskContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchTCPFlagsMaskContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchTCPFlagsMask(s)
+	}
+}
+
+func (s *MatchTCPFlagsMaskContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchTCPFlagsMask(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchTCPFlagsMask() (localctx IMatchTCPFlagsMaskContext) {
+	localctx = NewMatchTCPFlagsMaskContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 30, TrafficClassParserRULE_matchTCPFlagsMask)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(124)
+		p.Match(TrafficClassParserTCPFLAGS)
+	}
+	{
+		p.SetState(125)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(126)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+	{
+		p.SetState(127)
+		p.Match(TrafficClassParserT__3)
+	}
+	{
+		p.SetState(128)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchICMPTypeContext is an interface to support dynamic dispatch.
+type IMatchICMPTypeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchICMPTypeContext differentiates from other interfaces.
+	IsMatchICMPTypeContext()
+}
+
+type MatchICMPTypeContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchICMPTypeContext() *MatchICMPTypeContext {
+	var p = new(MatchICMPTypeContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchICMPType
+	return p
+}
+
+func (*MatchICMPTypeContext) IsMatchICMPTypeContext() {}
+
+func NewMatchICMPTypeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchICMPTypeContext {
+	var p = new(MatchICMPTypeContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchICMPType
+
+	return p
+}
+
+func (s *MatchICMPTypeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchICMPTypeContext) ICMPTYPE() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserICMPTYPE, 0)
+}
+
+func (s *MatchICMPTypeContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchICMPTypeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchICMPTypeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchICMPTypeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchICMPType(s)
+	}
+}
+
+func (s *MatchICMPTypeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchICMPType(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchICMPType() (localctx IMatchICMPTypeContext) {
+	localctx = NewMatchICMPTypeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 32, TrafficClassParserRULE_matchICMPType)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(130)
+		p.Match(TrafficClassParserICMPTYPE)
+	}
+	{
+		p.SetState(131)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(132)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// IMatchICMPCodeContext is an interface to support dynamic dispatch.
+type IMatchICMPCodeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchICMPCodeContext differentiates from other interfaces.
+	IsMatchICMPCodeContext()
+}
+
+type MatchICMPCodeContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchICMPCodeContext() *MatchICMPCodeContext {
+	var p = new(MatchICMPCodeContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchICMPCode
+	return p
+}
+
+func (*MatchICMPCodeContext) IsMatchICMPCodeContext() {}
+
+func NewMatchICMPCodeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchICMPCodeContext {
+	var p = new(MatchICMPCodeContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchICMPCode
+
+	return p
+}
+
+func (s *MatchICMPCodeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchICMPCodeContext) ICMPCODE() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserICMPCODE, 0)
+}
+
+func (s *MatchICMPCodeContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchICMPCodeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchICMPCodeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchICMPCodeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchICMPCode(s)
+	}
+}
+
+func (s *MatchICMPCodeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchICMPCode(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchICMPCode() (localctx IMatchICMPCodeContext) {
+	localctx = NewMatchICMPCodeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, TrafficClassParserRULE_matchICMPCode)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(134)
+		p.Match(TrafficClassParserICMPCODE)
+	}
+	{
+		p.SetState(135)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(136)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// ICondClsContext is an interface to support dynamic dispatch.
+type ICondClsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondClsContext differentiates from other interfaces.
+	IsCondClsContext()
+}
+
+type CondClsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondClsContext() *CondClsContext {
+	var p = new(CondClsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condCls
+	return p
+}
+
+func (*CondClsContext) IsCondClsContext() {}
+
+func NewCondClsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondClsContext {
+	var p = new(CondClsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condCls
+
+	return p
+}
+
+func (s *CondClsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondClsContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *CondClsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondClsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondClsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondCls(s)
+	}
+}
+
+func (s *CondClsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondCls(s)
+	}
+}
+
+func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
+	localctx = NewCondClsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 36, TrafficClassParserRULE_condCls)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(138)
+		p.Match(TrafficClassParserT__4)
+	}
+	{
+		p.SetState(139)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// ICondAnyContext is an interface to support dynamic dispatch.
+type ICondAnyContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondAnyContext differentiates from other interfaces.
+	IsCondAnyContext()
+}
+
+type CondAnyContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondAnyContext() *CondAnyContext {
+	var p = new(CondAnyContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condAny
+	return p
+}
+
+func (*CondAnyContext) IsCondAnyContext() {}
+
+func NewCondAnyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondAnyContext {
+	var p = new(CondAnyContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condAny
+
+	return p
+}
+
+func (s *CondAnyContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondAnyContext) ANY() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserANY, 0)
+}
+
+func (s *CondAnyContext) AllCond() []ICondContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ICondContext)(nil)).Elem())
+	var tst = make([]ICondContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ICondContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *CondAnyContext) Cond(i int) ICondContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondContext)
+}
+
+func (s *CondAnyContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondAnyContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondAnyContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondAny(s)
+	}
+}
+
+func (s *CondAnyContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondAny(s)
+	}
+}
+
+func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
+	localctx = NewCondAnyContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 38, TrafficClassParserRULE_condAny)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(141)
+		p.Match(TrafficClassParserANY)
+	}
+	{
+		p.SetState(142)
+		p.Match(TrafficClassParserT__5)
+	}
+	{
+		p.SetState(143)
+		p.Cond()
+	}
+	p.SetState(148)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == TrafficClassParserT__6 {
+		{
+			p.SetState(144)
+			p.Match(TrafficClassParserT__6)
+		}
+		{
+			p.SetState(145)
+			p.Cond()
+		}
+
+		p.SetState(150)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(151)
+		p.Match(TrafficClassParserT__7)
+	}
+
+	return localctx
+}
+
+// ICondAllContext is an interface to support dynamic dispatch.
+type ICondAllContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondAllContext differentiates from other interfaces.
+	IsCondAllContext()
+}
+
+type CondAllContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondAllContext() *CondAllContext {
+	var p = new(CondAllContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condAll
+	return p
+}
+
+func (*CondAllContext) IsCondAllContext() {}
+
+func NewCondAllContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondAllContext {
+	var p = new(CondAllContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condAll
+
+	return p
+}
+
+func (s *CondAllContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondAllContext) ALL() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserALL, 0)
+}
+
+func (s *CondAllContext) AllCond() []ICondContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ICondContext)(nil)).Elem())
+	var tst = make([]ICondContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ICondContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *CondAllContext) Cond(i int) ICondContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondContext)
+}
+
+func (s *CondAllContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondAllContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondAllContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondAll(s)
+	}
+}
+
+func (s *CondAllContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondAll(s)
+	}
+}
+
+func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
+	localctx = NewCondAllContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 40, TrafficClassParserRULE_condAll)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(153)
+		p.Match(TrafficClassParserALL)
+	}
+	{
+		p.SetState(154)
+		p.Match(TrafficClassParserT__5)
+	}
+	{
+		p.SetState(155)
+		p.Cond()
+	}
+	p.SetState(160)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == TrafficClassParserT__6 {
+		{
+			p.SetState(156)
+			p.Match(TrafficClassParserT__6)
+		}
+		{
+			p.SetState(157)
+			p.Cond()
+		}
+
+		p.SetState(162)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(163)
+		p.Match(TrafficClassParserT__7)
+	}
+
+	return localctx
+}
+
+// ICondNotContext is an interface to support dynamic dispatch.
+type ICondNotContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondNotContext differentiates from other interfaces.
+	IsCondNotContext()
+}
+
+type CondNotContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondNotContext() *CondNotContext {
+	var p = new(CondNotContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condNot
+	return p
+}
+
+func (*CondNotContext) IsCondNotContext() {}
+
+func NewCondNotContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondNotContext {
+	var p = new(CondNotContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condNot
+
+	return p
+}
+
+func (s *CondNotContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondNotContext) NOT() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNOT, 0)
+}
+
+func (s *CondNotContext) Cond() ICondContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondContext)
+}
+
+func (s *CondNotContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondNotContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondNotContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondNot(s)
+	}
+}
+
+func (s *CondNotContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondNot(s)
+	}
+}
+
+func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
+	localctx = NewCondNotContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 42, TrafficClassParserRULE_condNot)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(165)
+		p.Match(TrafficClassParserNOT)
+	}
+	{
+		p.SetState(166)
+		p.Match(TrafficClassParserT__5)
+	}
+	{
+		p.SetState(167)
+		p.Cond()
+	}
+	{
+		p.SetState(168)
+		p.Match(TrafficClassParserT__7)
+	}
+
+	return localctx
+}
+
+// ICondBoolContext is an interface to support dynamic dispatch.
+type ICondBoolContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondBoolContext differentiates from other interfaces.
+	IsCondBoolContext()
+}
+
+type CondBoolContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondBoolContext() *CondBoolContext {
+	var p = new(CondBoolContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
 	p.RuleIndex = TrafficClassParserRULE_condBool
 	return p
@@ -1704,7 +2748,7 @@ func (s *CondBoolContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 	localctx = NewCondBoolContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, TrafficClassParserRULE_condBool)
+	p.EnterRule(localctx, 44, TrafficClassParserRULE_condBool)
 	var _la int
 
 	defer func() {
@@ -1725,18 +2769,18 @@ func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(108)
+		p.SetState(170)
 		p.Match(TrafficClassParserBOOL)
 	}
 	{
-		p.SetState(109)
+		p.SetState(171)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(110)
+		p.SetState(172)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(_la == TrafficClassParserT__7 || _la == TrafficClassParserT__8) {
+		if !(_la == TrafficClassParserT__8 || _la == TrafficClassParserT__9) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -1766,98 +2810,272 @@ type CondIPv4Context struct {
 func NewEmptyCondIPv4Context() *CondIPv4Context {
 	var p = new(CondIPv4Context)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_condIPv4
+	p.RuleIndex = TrafficClassParserRULE_condIPv4
+	return p
+}
+
+func (*CondIPv4Context) IsCondIPv4Context() {}
+
+func NewCondIPv4Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondIPv4Context {
+	var p = new(CondIPv4Context)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condIPv4
+
+	return p
+}
+
+func (s *CondIPv4Context) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondIPv4Context) MatchSrc() IMatchSrcContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchSrcContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchSrcContext)
+}
+
+func (s *CondIPv4Context) MatchDst() IMatchDstContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDstContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchDstContext)
+}
+
+func (s *CondIPv4Context) MatchDSCP() IMatchDSCPContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDSCPContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchDSCPContext)
+}
+
+func (s *CondIPv4Context) MatchTOS() IMatchTOSContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTOSContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchTOSContext)
+}
+
+func (s *CondIPv4Context) MatchProtocol() IMatchProtocolContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchProtocolContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchProtocolContext)
+}
+
+func (s *CondIPv4Context) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondIPv4Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondIPv4Context) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondIPv4(s)
+	}
+}
+
+func (s *CondIPv4Context) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondIPv4(s)
+	}
+}
+
+func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
+	localctx = NewCondIPv4Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 46, TrafficClassParserRULE_condIPv4)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(179)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case TrafficClassParserSRC:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(174)
+			p.MatchSrc()
+		}
+
+	case TrafficClassParserDST:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(175)
+			p.MatchDst()
+		}
+
+	case TrafficClassParserDSCP:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(176)
+			p.MatchDSCP()
+		}
+
+	case TrafficClassParserTOS:
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(177)
+			p.MatchTOS()
+		}
+
+	case TrafficClassParserPROTOCOL:
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(178)
+			p.MatchProtocol()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// ICondIPv6Context is an interface to support dynamic dispatch.
+type ICondIPv6Context interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondIPv6Context differentiates from other interfaces.
+	IsCondIPv6Context()
+}
+
+type CondIPv6Context struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondIPv6Context() *CondIPv6Context {
+	var p = new(CondIPv6Context)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
 	return p
 }
 
-func (*CondIPv4Context) IsCondIPv4Context() {}
+func (*CondIPv6Context) IsCondIPv6Context() {}
 
-func NewCondIPv4Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondIPv4Context {
-	var p = new(CondIPv4Context)
+func NewCondIPv6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondIPv6Context {
+	var p = new(CondIPv6Context)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_condIPv4
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
 
 	return p
 }
 
-func (s *CondIPv4Context) GetParser() antlr.Parser { return s.parser }
+func (s *CondIPv6Context) GetParser() antlr.Parser { return s.parser }
 
-func (s *CondIPv4Context) MatchSrc() IMatchSrcContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchSrcContext)(nil)).Elem(), 0)
+func (s *CondIPv6Context) MatchSrc6() IMatchSrc6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchSrc6Context)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IMatchSrcContext)
+	return t.(IMatchSrc6Context)
 }
 
-func (s *CondIPv4Context) MatchDst() IMatchDstContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDstContext)(nil)).Elem(), 0)
+func (s *CondIPv6Context) MatchDst6() IMatchDst6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDst6Context)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IMatchDstContext)
+	return t.(IMatchDst6Context)
 }
 
-func (s *CondIPv4Context) MatchDSCP() IMatchDSCPContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDSCPContext)(nil)).Elem(), 0)
+func (s *CondIPv6Context) MatchTC() IMatchTCContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTCContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IMatchDSCPContext)
+	return t.(IMatchTCContext)
 }
 
-func (s *CondIPv4Context) MatchTOS() IMatchTOSContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTOSContext)(nil)).Elem(), 0)
+func (s *CondIPv6Context) MatchFlowLabel() IMatchFlowLabelContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchFlowLabelContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IMatchTOSContext)
+	return t.(IMatchFlowLabelContext)
 }
 
-func (s *CondIPv4Context) MatchProtocol() IMatchProtocolContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchProtocolContext)(nil)).Elem(), 0)
+func (s *CondIPv6Context) MatchNextHdr() IMatchNextHdrContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchNextHdrContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IMatchProtocolContext)
+	return t.(IMatchNextHdrContext)
 }
 
-func (s *CondIPv4Context) GetRuleContext() antlr.RuleContext {
+func (s *CondIPv6Context) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CondIPv4Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *CondIPv6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *CondIPv4Context) EnterRule(listener antlr.ParseTreeListener) {
+func (s *CondIPv6Context) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterCondIPv4(s)
+		listenerT.EnterCondIPv6(s)
 	}
 }
 
-func (s *CondIPv4Context) ExitRule(listener antlr.ParseTreeListener) {
+func (s *CondIPv6Context) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitCondIPv4(s)
+		listenerT.ExitCondIPv6(s)
 	}
 }
 
-func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
-	localctx = NewCondIPv4Context(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, TrafficClassParserRULE_condIPv4)
+func (p *TrafficClassParser) CondIPv6() (localctx ICondIPv6Context) {
+	localctx = NewCondIPv6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 48, TrafficClassParserRULE_condIPv6)
 
 	defer func() {
 		p.ExitRule()
@@ -1875,43 +3093,43 @@ func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 		}
 	}()
 
-	p.SetState(117)
+	p.SetState(186)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case TrafficClassParserSRC:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(112)
-			p.MatchSrc()
+			p.SetState(181)
+			p.MatchSrc6()
 		}
 
 	case TrafficClassParserDST:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(113)
-			p.MatchDst()
+			p.SetState(182)
+			p.MatchDst6()
 		}
 
-	case TrafficClassParserDSCP:
+	case TrafficClassParserTC:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(114)
-			p.MatchDSCP()
+			p.SetState(183)
+			p.MatchTC()
 		}
 
-	case TrafficClassParserTOS:
+	case TrafficClassParserFLOWLABEL:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(115)
-			p.MatchTOS()
+			p.SetState(184)
+			p.MatchFlowLabel()
 		}
 
-	case TrafficClassParserPROTOCOL:
+	case TrafficClassParserNEXTHDR:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(116)
-			p.MatchProtocol()
+			p.SetState(185)
+			p.MatchNextHdr()
 		}
 
 	default:
@@ -2021,7 +3239,7 @@ func (s *CondPortContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondPort() (localctx ICondPortContext) {
 	localctx = NewCondPortContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, TrafficClassParserRULE_condPort)
+	p.EnterRule(localctx, 50, TrafficClassParserRULE_condPort)
 
 	defer func() {
 		p.ExitRule()
@@ -2039,34 +3257,34 @@ func (p *TrafficClassParser) CondPort() (localctx ICondPortContext) {
 		}
 	}()
 
-	p.SetState(123)
+	p.SetState(192)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 3, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 4, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(119)
+			p.SetState(188)
 			p.MatchSrcPort()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(120)
+			p.SetState(189)
 			p.MatchSrcPortRange()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(121)
+			p.SetState(190)
 			p.MatchDstPort()
 		}
 
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(122)
+			p.SetState(191)
 			p.MatchDstPortRange()
 		}
 
@@ -2075,6 +3293,249 @@ func (p *TrafficClassParser) CondPort() (localctx ICondPortContext) {
 	return localctx
 }
 
+// ICondTCPContext is an interface to support dynamic dispatch.
+type ICondTCPContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondTCPContext differentiates from other interfaces.
+	IsCondTCPContext()
+}
+
+type CondTCPContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondTCPContext() *CondTCPContext {
+	var p = new(CondTCPContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condTCP
+	return p
+}
+
+func (*CondTCPContext) IsCondTCPContext() {}
+
+func NewCondTCPContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondTCPContext {
+	var p = new(CondTCPContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condTCP
+
+	return p
+}
+
+func (s *CondTCPContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondTCPContext) MatchTCPFlags() IMatchTCPFlagsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTCPFlagsContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchTCPFlagsContext)
+}
+
+func (s *CondTCPContext) MatchTCPFlagsMask() IMatchTCPFlagsMaskContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTCPFlagsMaskContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchTCPFlagsMaskContext)
+}
+
+func (s *CondTCPContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondTCPContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondTCPContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondTCP(s)
+	}
+}
+
+func (s *CondTCPContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondTCP(s)
+	}
+}
+
+func (p *TrafficClassParser) CondTCP() (localctx ICondTCPContext) {
+	localctx = NewCondTCPContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 52, TrafficClassParserRULE_condTCP)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(196)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 5, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(194)
+			p.MatchTCPFlags()
+		}
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(195)
+			p.MatchTCPFlagsMask()
+		}
+
+	}
+
+	return localctx
+}
+
+// ICondICMPContext is an interface to support dynamic dispatch.
+type ICondICMPContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondICMPContext differentiates from other interfaces.
+	IsCondICMPContext()
+}
+
+type CondICMPContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondICMPContext() *CondICMPContext {
+	var p = new(CondICMPContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condICMP
+	return p
+}
+
+func (*CondICMPContext) IsCondICMPContext() {}
+
+func NewCondICMPContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondICMPContext {
+	var p = new(CondICMPContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condICMP
+
+	return p
+}
+
+func (s *CondICMPContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondICMPContext) MatchICMPType() IMatchICMPTypeContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchICMPTypeContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchICMPTypeContext)
+}
+
+func (s *CondICMPContext) MatchICMPCode() IMatchICMPCodeContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchICMPCodeContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchICMPCodeContext)
+}
+
+func (s *CondICMPContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondICMPContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondICMPContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondICMP(s)
+	}
+}
+
+func (s *CondICMPContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondICMP(s)
+	}
+}
+
+func (p *TrafficClassParser) CondICMP() (localctx ICondICMPContext) {
+	localctx = NewCondICMPContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 54, TrafficClassParserRULE_condICMP)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(200)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case TrafficClassParserICMPTYPE:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(198)
+			p.MatchICMPType()
+		}
+
+	case TrafficClassParserICMPCODE:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(199)
+			p.MatchICMPCode()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
 // ICondContext is an interface to support dynamic dispatch.
 type ICondContext interface {
 	antlr.ParserRuleContext
@@ -2153,6 +3614,16 @@ func (s *CondContext) CondIPv4() ICondIPv4Context {
 	return t.(ICondIPv4Context)
 }
 
+func (s *CondContext) CondIPv6() ICondIPv6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondIPv6Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondIPv6Context)
+}
+
 func (s *CondContext) CondPort() ICondPortContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondPortContext)(nil)).Elem(), 0)
 
@@ -2163,6 +3634,26 @@ func (s *CondContext) CondPort() ICondPortContext {
 	return t.(ICondPortContext)
 }
 
+func (s *CondContext) CondTCP() ICondTCPContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondTCPContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondTCPContext)
+}
+
+func (s *CondContext) CondICMP() ICondICMPContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondICMPContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondICMPContext)
+}
+
 func (s *CondContext) CondCls() ICondClsContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondClsContext)(nil)).Elem(), 0)
 
@@ -2205,7 +3696,7 @@ func (s *CondContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 	localctx = NewCondContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 32, TrafficClassParserRULE_cond)
+	p.EnterRule(localctx, 56, TrafficClassParserRULE_cond)
 
 	defer func() {
 		p.ExitRule()
@@ -2223,61 +3714,79 @@ func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 		}
 	}()
 
-	p.SetState(132)
+	p.SetState(212)
 	p.GetErrorHandler().Sync(p)
-
-	switch p.GetTokenStream().LA(1) {
-	case TrafficClassParserALL:
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 7, p.GetParserRuleContext()) {
+	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(125)
+			p.SetState(202)
 			p.CondAll()
 		}
 
-	case TrafficClassParserANY:
+	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(126)
+			p.SetState(203)
 			p.CondAny()
 		}
 
-	case TrafficClassParserNOT:
+	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(127)
+			p.SetState(204)
 			p.CondNot()
 		}
 
-	case TrafficClassParserSRC, TrafficClassParserDST, TrafficClassParserDSCP, TrafficClassParserTOS, TrafficClassParserPROTOCOL:
+	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(128)
+			p.SetState(205)
 			p.CondIPv4()
 		}
 
-	case TrafficClassParserSRCPORT, TrafficClassParserDSTPORT:
+	case 5:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(129)
-			p.CondPort()
+			p.SetState(206)
+			p.CondIPv6()
 		}
 
-	case TrafficClassParserT__3:
+	case 6:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(130)
-			p.CondCls()
+			p.SetState(207)
+			p.CondPort()
 		}
 
-	case TrafficClassParserBOOL:
+	case 7:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(131)
+			p.SetState(208)
+			p.CondTCP()
+		}
+
+	case 8:
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(209)
+			p.CondICMP()
+		}
+
+	case 9:
+		p.EnterOuterAlt(localctx, 9)
+		{
+			p.SetState(210)
+			p.CondCls()
+		}
+
+	case 10:
+		p.EnterOuterAlt(localctx, 10)
+		{
+			p.SetState(211)
 			p.CondBool()
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
@@ -2357,7 +3866,7 @@ func (s *TrafficClassContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 	localctx = NewTrafficClassContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, TrafficClassParserRULE_trafficClass)
+	p.EnterRule(localctx, 58, TrafficClassParserRULE_trafficClass)
 
 	defer func() {
 		p.ExitRule()
@@ -2377,11 +3886,11 @@ func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(134)
+		p.SetState(214)
 		p.Cond()
 	}
 	{
-		p.SetState(135)
+		p.SetState(215)
 		p.Match(TrafficClassParserEOF)
 	}
 
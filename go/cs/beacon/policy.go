@@ -15,6 +15,8 @@
 package beacon
 
 import (
+	"crypto/sha256"
+	"errors"
 	"io/ioutil"
 
 	yaml "gopkg.in/yaml.v2"
@@ -48,6 +50,41 @@ const (
 	DefaultMaxExpTime = uint8(63)
 )
 
+var (
+	// ErrMaxHopsLength indicates that a beacon exceeds the maximum number of
+	// hops.
+	ErrMaxHopsLength = serrors.New("MaxHopsLength exceeded")
+	// ErrASLoop indicates that a beacon contains an AS loop.
+	ErrASLoop = serrors.New("AS loop")
+	// ErrISDLoop indicates that a beacon contains an ISD loop.
+	ErrISDLoop = serrors.New("ISD loop")
+	// ErrBlockedAS indicates that a beacon contains a blocked AS.
+	ErrBlockedAS = serrors.New("contains blocked AS")
+	// ErrBlockedISD indicates that a beacon contains a blocked ISD.
+	ErrBlockedISD = serrors.New("contains blocked ISD")
+)
+
+// FilterReason returns a short label for the reason why a beacon was
+// filtered, e.g., "as_loop". For beacons that are filtered by all policies,
+// the reason of the propagation policy is returned. If the error is not caused
+// by a filter, "other" is returned.
+func FilterReason(err error) string {
+	switch {
+	case errors.Is(err, ErrMaxHopsLength):
+		return "max_hops_length"
+	case errors.Is(err, ErrASLoop):
+		return "as_loop"
+	case errors.Is(err, ErrISDLoop):
+		return "isd_loop"
+	case errors.Is(err, ErrBlockedAS):
+		return "blocked_as"
+	case errors.Is(err, ErrBlockedISD):
+		return "blocked_isd"
+	default:
+		return "other"
+	}
+}
+
 // Policies keeps track of all policies for a non-core beacon store.
 type Policies struct {
 	// Prop is the propagation policy.
@@ -85,18 +122,18 @@ func (p *Policies) Validate() error {
 // Filter applies all filters and returns an error if all of them filter the
 // beacon. If at least one does not filter, no error is returned.
 func (p *Policies) Filter(beacon Beacon) error {
-	var errors []error
+	var errs []error
 	if err := p.Prop.Filter.Apply(beacon); err != nil {
-		errors = append(errors, err)
+		errs = append(errs, err)
 	}
 	if err := p.UpReg.Filter.Apply(beacon); err != nil {
-		errors = append(errors, err)
+		errs = append(errs, err)
 	}
 	if err := p.DownReg.Filter.Apply(beacon); err != nil {
-		errors = append(errors, err)
+		errs = append(errs, err)
 	}
-	if len(errors) == 3 {
-		return serrors.New("Filtered by all policies", "errs", errors)
+	if len(errs) == 3 {
+		return serrors.WrapStr("Filtered by all policies", errs[0], "errs", errs)
 	}
 	return nil
 }
@@ -147,15 +184,15 @@ func (p *CorePolicies) Validate() error {
 // Filter applies all filters and returns an error if all of them filter the
 // beacon. If at least one does not filter, no error is returned.
 func (p *CorePolicies) Filter(beacon Beacon) error {
-	var errors []error
+	var errs []error
 	if err := p.Prop.Filter.Apply(beacon); err != nil {
-		errors = append(errors, err)
+		errs = append(errs, err)
 	}
 	if err := p.CoreReg.Filter.Apply(beacon); err != nil {
-		errors = append(errors, err)
+		errs = append(errs, err)
 	}
-	if len(errors) == 2 {
-		return serrors.New("Filtered by all policies", "errs", errors)
+	if len(errs) == 2 {
+		return serrors.WrapStr("Filtered by all policies", errs[0], "errs", errs)
 	}
 	return nil
 }
@@ -214,6 +251,18 @@ func (p *Policy) initDefaults(t PolicyType) error {
 	return nil
 }
 
+// Digest returns the SHA-256 digest of the YAML encoding of the policy. Two
+// policies with the same parameters have the same digest, independent of how
+// they were specified.
+func (p *Policy) Digest() ([]byte, error) {
+	raw, err := yaml.Marshal(p)
+	if err != nil {
+		return nil, serrors.WrapStr("encoding policy", err)
+	}
+	digest := sha256.Sum256(raw)
+	return digest[:], nil
+}
+
 // ParsePolicyYaml parses the policy in yaml format and initializes the default values.
 func ParsePolicyYaml(b []byte, t PolicyType) (*Policy, error) {
 	p := &Policy{}
@@ -244,7 +293,8 @@ type Filter struct {
 	AsBlackList []addr.AS `yaml:"AsBlackList"`
 	// IsdBlackList contains all ISD that may not appear in a segment.
 	IsdBlackList []addr.ISD `yaml:"IsdBlackList"`
-	// AllowIsdLoop indicates whether ISD loops should not be filtered.
+	// AllowIsdLoop indicates whether ISD loops should not be filtered. If
+	// unset, ISD loops are not filtered.
 	AllowIsdLoop *bool `yaml:"AllowIsdLoop"`
 }
 
@@ -262,7 +312,7 @@ func (f *Filter) InitDefaults() {
 // Apply returns an error if the beacon is filtered.
 func (f Filter) Apply(beacon Beacon) error {
 	if len(beacon.Segment.ASEntries) > f.MaxHopsLength {
-		return serrors.New("MaxHopsLength exceeded", "max", f.MaxHopsLength,
+		return serrors.WithCtx(ErrMaxHopsLength, "max", f.MaxHopsLength,
 			"actual", len(beacon.Segment.ASEntries))
 	}
 	hops := buildHops(beacon)
@@ -272,12 +322,12 @@ func (f Filter) Apply(beacon Beacon) error {
 	for _, ia := range hops {
 		for _, as := range f.AsBlackList {
 			if ia.A == as {
-				return serrors.New("contains blocked AS", "isd_as", ia)
+				return serrors.WithCtx(ErrBlockedAS, "isd_as", ia)
 			}
 		}
 		for _, isd := range f.IsdBlackList {
 			if ia.I == isd {
-				return serrors.New("contains blocked ISD", "isd_as", ia)
+				return serrors.WithCtx(ErrBlockedISD, "isd_as", ia)
 			}
 		}
 	}
@@ -304,13 +354,13 @@ func buildHops(beacon Beacon) []addr.IA {
 
 func filterLoops(hops []addr.IA, allowIsdLoop bool) error {
 	if ia := filterAsLoop(hops); !ia.IsZero() {
-		return serrors.New("AS loop", "ia", ia)
+		return serrors.WithCtx(ErrASLoop, "ia", ia)
 	}
 	if allowIsdLoop {
 		return nil
 	}
 	if isd := filterIsdLoop(hops); isd != 0 {
-		return serrors.New("ISD loop", "isd", isd)
+		return serrors.WithCtx(ErrISDLoop, "isd", isd)
 	}
 	return nil
 }
@@ -15,9 +15,11 @@
 package beacon_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/cs/beacon"
 	"github.com/scionproto/scion/go/lib/addr"
@@ -79,6 +81,34 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 	}
 }
 
+func TestPolicyDigest(t *testing.T) {
+	fromFile, err := beacon.LoadPolicyFromYaml("testdata/typedPolicy.yml", beacon.PropPolicy)
+	require.NoError(t, err)
+	reformatted, err := beacon.ParsePolicyYaml([]byte(`
+BestSetSize: 6
+CandidateSetSize: 20
+MaxExpTime: 42
+Filter:
+  IsdBlackList: [1, 2, 3]
+  AsBlackList: ["ff00:0:110", "ff00:0:111"]
+  AllowIsdLoop: true
+  MaxHopsLength: 8
+`), beacon.PropPolicy)
+	require.NoError(t, err)
+	defaults, err := beacon.ParsePolicyYaml(nil, beacon.PropPolicy)
+	require.NoError(t, err)
+
+	digest, err := fromFile.Digest()
+	require.NoError(t, err)
+	assert.Len(t, digest, 32)
+	other, err := reformatted.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, digest, other, "same parameters")
+	other, err = defaults.Digest()
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, other, "different parameters")
+}
+
 func TestFilterApply(t *testing.T) {
 	defaultFilter := &beacon.Filter{
 		MaxHopsLength: 2,
@@ -154,6 +184,53 @@ func TestFilterApply(t *testing.T) {
 	}
 }
 
+func TestFilterReason(t *testing.T) {
+	filter := beacon.Filter{
+		MaxHopsLength: 3,
+		AsBlackList:   []addr.AS{ia112.A},
+		IsdBlackList:  []addr.ISD{2},
+		AllowIsdLoop:  &false_val,
+	}
+	testCases := map[string]struct {
+		Beacon beacon.Beacon
+		Reason string
+	}{
+		"max hops length": {
+			Beacon: newTestBeacon(ia110, ia111, ia113, ia311),
+			Reason: "max_hops_length",
+		},
+		"AS loop": {
+			Beacon: newTestBeacon(ia110, ia111, ia110),
+			Reason: "as_loop",
+		},
+		"ISD loop": {
+			Beacon: newTestBeacon(ia110, ia311, ia111),
+			Reason: "isd_loop",
+		},
+		"blocked AS": {
+			Beacon: newTestBeacon(ia110, ia112),
+			Reason: "blocked_as",
+		},
+		"blocked ISD": {
+			Beacon: newTestBeacon(ia110, ia210),
+			Reason: "blocked_isd",
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.Reason, beacon.FilterReason(filter.Apply(tc.Beacon)))
+			policies := beacon.CorePolicies{
+				Prop:    beacon.Policy{Filter: filter},
+				CoreReg: beacon.Policy{Filter: filter},
+			}
+			assert.Equal(t, tc.Reason, beacon.FilterReason(policies.Filter(tc.Beacon)))
+		})
+	}
+	assert.Equal(t, "other", beacon.FilterReason(errors.New("other")))
+}
+
 func TestFilterLoop(t *testing.T) {
 	testCases := []struct {
 		Name         string
@@ -16,6 +16,7 @@ package beacon
 
 import (
 	"context"
+	"sync"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
@@ -23,6 +24,13 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 )
 
+// SelectionRecorder records the outcome of the beacon selection.
+type SelectionRecorder interface {
+	// RecordSelection records that the selected beacons were chosen from the
+	// candidates according to the policy of the given type.
+	RecordSelection(policy PolicyType, candidates, selected []Beacon)
+}
+
 type usager interface {
 	Filter(beacon Beacon) error
 	Usage(beacon Beacon) Usage
@@ -57,21 +65,29 @@ func NewBeaconStore(policies Policies, db DB) (*Store, error) {
 // BeaconsToPropagate returns a slice  all beacons to propagate at the time of the call.
 // The selection is based on the configured propagation policy.
 func (s *Store) BeaconsToPropagate(ctx context.Context) ([]Beacon, error) {
-	return s.getBeacons(ctx, &s.policies.Prop)
+	s.mtx.RLock()
+	policy := s.policies.Prop
+	s.mtx.RUnlock()
+	return s.getBeacons(ctx, &policy)
 }
 
 // SegmentsToRegister returns a channel that provides all beacons to register at
 // the time of the call. The selections is based on the configured policy for
 // the requested segment type.
 func (s *Store) SegmentsToRegister(ctx context.Context, segType seg.Type) ([]Beacon, error) {
+	var policy Policy
+	s.mtx.RLock()
 	switch segType {
 	case seg.TypeDown:
-		return s.getBeacons(ctx, &s.policies.DownReg)
+		policy = s.policies.DownReg
 	case seg.TypeUp:
-		return s.getBeacons(ctx, &s.policies.UpReg)
+		policy = s.policies.UpReg
 	default:
+		s.mtx.RUnlock()
 		return nil, serrors.New("Unsupported segment type", "type", segType)
 	}
+	s.mtx.RUnlock()
+	return s.getBeacons(ctx, &policy)
 }
 
 // getBeacons fetches the candidate beacons from the database and serves the
@@ -82,11 +98,15 @@ func (s *Store) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, error
 	if err != nil {
 		return nil, err
 	}
-	return s.algo.SelectBeacons(beacons, policy.BestSetSize), nil
+	selected := s.algo.SelectBeacons(beacons, policy.BestSetSize)
+	s.recordSelection(policy.Type, beacons, selected)
+	return selected, nil
 }
 
 // MaxExpTime returns the segment maximum expiration time for the given policy.
 func (s *Store) MaxExpTime(policyType PolicyType) uint8 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
 	switch policyType {
 	case UpRegPolicy:
 		return *s.policies.UpReg.MaxExpTime
@@ -98,6 +118,50 @@ func (s *Store) MaxExpTime(policyType PolicyType) uint8 {
 	return DefaultMaxExpTime
 }
 
+// UpdatePolicy replaces the propagation, up or down segment registration
+// policy. The new policy applies to all beacons that are inserted after the
+// update. Beacons that are already stored keep their usage until they are
+// received again.
+func (s *Store) UpdatePolicy(ctx context.Context, policy Policy) error {
+	return s.UpdatePolicies(ctx, policy)
+}
+
+// UpdatePolicies replaces the given policies atomically. Either all of them are
+// applied, or none if any of them is invalid.
+func (s *Store) UpdatePolicies(ctx context.Context, policies ...Policy) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	updated := s.policies
+	types := make([]PolicyType, 0, len(policies))
+	for _, policy := range policies {
+		if err := policy.initDefaults(policy.Type); err != nil {
+			return err
+		}
+		switch policy.Type {
+		case PropPolicy:
+			updated.Prop = policy
+		case UpRegPolicy:
+			updated.UpReg = policy
+		case DownRegPolicy:
+			updated.DownReg = policy
+		default:
+			return serrors.New("Unsupported policy type", "type", policy.Type)
+		}
+		types = append(types, policy.Type)
+	}
+	s.policies = updated
+	log.FromCtx(ctx).Info("Beacon policies updated", "types", types)
+	return nil
+}
+
+// Policies returns the active propagation, up and down segment registration
+// policies.
+func (s *Store) Policies() []Policy {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return []Policy{s.policies.Prop, s.policies.UpReg, s.policies.DownReg}
+}
+
 // CoreStore provides abstracted access to the beacon database in a core AS. The
 // store helps inserting beacons and revocations, and selects the best beacons
 // for given purposes based on the configured policies. It should not be used in
@@ -127,7 +191,10 @@ func NewCoreBeaconStore(policies CorePolicies, db DB) (*CoreStore, error) {
 // BeaconsToPropagate returns a slice of all beacons to propagate at the time of the call.
 // The selection is based on the configured propagation policy.
 func (s *CoreStore) BeaconsToPropagate(ctx context.Context) ([]Beacon, error) {
-	return s.getBeacons(ctx, &s.policies.Prop)
+	s.mtx.RLock()
+	policy := s.policies.Prop
+	s.mtx.RUnlock()
+	return s.getBeacons(ctx, &policy)
 }
 
 // SegmentsToRegister returns a slice of all beacons to register at the time of the call.
@@ -137,7 +204,10 @@ func (s *CoreStore) SegmentsToRegister(ctx context.Context, segType seg.Type) ([
 	if segType != seg.TypeCore {
 		return nil, serrors.New("Unsupported segment type", "type", segType)
 	}
-	return s.getBeacons(ctx, &s.policies.CoreReg)
+	s.mtx.RLock()
+	policy := s.policies.CoreReg
+	s.mtx.RUnlock()
+	return s.getBeacons(ctx, &policy)
 }
 
 // getBeacons fetches the candidate beacons from the database and serves the
@@ -157,6 +227,7 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, e
 			continue
 		}
 		selBeacons := s.algo.SelectBeacons(candidateBeacons, policy.BestSetSize)
+		s.recordSelection(policy.Type, candidateBeacons, selBeacons)
 		beacons = append(beacons, selBeacons...)
 	}
 	return beacons, nil
@@ -164,6 +235,8 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, e
 
 // MaxExpTime returns the segment maximum expiration time for the given policy.
 func (s *CoreStore) MaxExpTime(policyType PolicyType) uint8 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
 	switch policyType {
 	case CoreRegPolicy:
 		return *s.policies.CoreReg.MaxExpTime
@@ -173,17 +246,78 @@ func (s *CoreStore) MaxExpTime(policyType PolicyType) uint8 {
 	return DefaultMaxExpTime
 }
 
+// UpdatePolicy replaces the propagation or core segment registration policy.
+// The new policy applies to all beacons that are inserted after the update.
+// Beacons that are already stored keep their usage until they are received
+// again.
+func (s *CoreStore) UpdatePolicy(ctx context.Context, policy Policy) error {
+	return s.UpdatePolicies(ctx, policy)
+}
+
+// UpdatePolicies replaces the given policies atomically. Either all of them are
+// applied, or none if any of them is invalid.
+func (s *CoreStore) UpdatePolicies(ctx context.Context, policies ...Policy) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	updated := s.policies
+	types := make([]PolicyType, 0, len(policies))
+	for _, policy := range policies {
+		if err := policy.initDefaults(policy.Type); err != nil {
+			return err
+		}
+		switch policy.Type {
+		case PropPolicy:
+			updated.Prop = policy
+		case CoreRegPolicy:
+			updated.CoreReg = policy
+		default:
+			return serrors.New("Unsupported policy type", "type", policy.Type)
+		}
+		types = append(types, policy.Type)
+	}
+	s.policies = updated
+	log.FromCtx(ctx).Info("Beacon policies updated", "types", types)
+	return nil
+}
+
+// Policies returns the active propagation and core segment registration
+// policies.
+func (s *CoreStore) Policies() []Policy {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return []Policy{s.policies.Prop, s.policies.CoreReg}
+}
+
 // baseStore is the basis for the beacon store.
 type baseStore struct {
 	db     DB
 	usager usager
 	algo   selectionAlgorithm
+	// mtx protects the policies that back the usager.
+	mtx sync.RWMutex
+	// selection records the outcome of the beacon selection. If nil, nothing
+	// is recorded.
+	selection SelectionRecorder
+}
+
+// SetSelectionRecorder sets the recorder for the outcome of the beacon
+// selection. It must be called before the store is used.
+func (s *baseStore) SetSelectionRecorder(r SelectionRecorder) {
+	s.selection = r
+}
+
+func (s *baseStore) recordSelection(t PolicyType, candidates, selected []Beacon) {
+	if s.selection != nil {
+		s.selection.RecordSelection(t, candidates, selected)
+	}
 }
 
 // PreFilter indicates whether the beacon will be filtered on insert by
 // returning an error with the reason. This allows the caller to drop
 // ignored beacons.
 func (s *baseStore) PreFilter(beacon Beacon) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
 	return s.usager.Filter(beacon)
 }
 
@@ -191,15 +325,11 @@ func (s *baseStore) PreFilter(beacon Beacon) error {
 // Beacon that contains revoked interfaces is inserted and does not cause an error.
 // If the beacon does not match any policy, it is not inserted, but does not cause an error.
 func (s *baseStore) InsertBeacon(ctx context.Context, beacon Beacon) (InsertStats, error) {
+	s.mtx.RLock()
 	usage := s.usager.Usage(beacon)
+	s.mtx.RUnlock()
 	if usage.None() {
 		return InsertStats{Filtered: 1}, nil
 	}
 	return s.db.InsertBeacon(ctx, beacon, usage)
 }
-
-// UpdatePolicy updates the policy. Beacons that are filtered by all
-// policies after the update are removed.
-func (s *baseStore) UpdatePolicy(ctx context.Context, policy Policy) error {
-	return serrors.New("policy update not supported")
-}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/cs/beacon"
@@ -143,6 +144,126 @@ func testStoreSelection(t *testing.T,
 	}
 }
 
+func TestStoreUpdatePolicy(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+	b := testBeacon(g, graph.If_120_X_111_B, graph.If_111_A_112_X)
+
+	db := mock_beacon.NewMockDB(mctrl)
+	store, err := beacon.NewBeaconStore(beacon.Policies{}, db)
+	require.NoError(t, err)
+
+	maxExpTime := uint8(42)
+	err = store.UpdatePolicy(context.Background(), beacon.Policy{
+		Type:       beacon.UpRegPolicy,
+		MaxExpTime: &maxExpTime,
+		Filter:     beacon.Filter{MaxHopsLength: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, maxExpTime, store.MaxExpTime(beacon.UpRegPolicy))
+	assert.Equal(t, beacon.DefaultMaxExpTime, store.MaxExpTime(beacon.DownRegPolicy))
+
+	// The updated filter is applied to newly inserted beacons.
+	db.EXPECT().InsertBeacon(gomock.Any(), b, beacon.UsageProp|beacon.UsageDownReg)
+	_, err = store.InsertBeacon(context.Background(), b)
+	require.NoError(t, err)
+
+	err = store.UpdatePolicy(context.Background(), beacon.Policy{Type: beacon.CoreRegPolicy})
+	assert.Error(t, err)
+}
+
+func TestStoreUpdatePolicies(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	store, err := beacon.NewBeaconStore(beacon.Policies{}, mock_beacon.NewMockDB(mctrl))
+	require.NoError(t, err)
+
+	// An invalid policy rejects the whole update.
+	err = store.UpdatePolicies(context.Background(),
+		beacon.Policy{Type: beacon.PropPolicy, BestSetSize: 5},
+		beacon.Policy{Type: beacon.CoreRegPolicy},
+	)
+	assert.Error(t, err)
+	assert.Equal(t, beacon.DefaultBestSetSize, store.Policies()[0].BestSetSize)
+
+	err = store.UpdatePolicies(context.Background(),
+		beacon.Policy{Type: beacon.PropPolicy, BestSetSize: 5},
+		beacon.Policy{Type: beacon.DownRegPolicy, BestSetSize: 6},
+	)
+	require.NoError(t, err)
+	policies := store.Policies()
+	require.Len(t, policies, 3)
+	assert.Equal(t, beacon.PropPolicy, policies[0].Type)
+	assert.Equal(t, 5, policies[0].BestSetSize)
+	assert.Equal(t, beacon.UpRegPolicy, policies[1].Type)
+	assert.Equal(t, beacon.DefaultBestSetSize, policies[1].BestSetSize)
+	assert.Equal(t, beacon.DownRegPolicy, policies[2].Type)
+	assert.Equal(t, 6, policies[2].BestSetSize)
+}
+
+func TestStoreSelectionRecorder(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+	stub := graph.If_111_A_112_X
+	beacons := []beacon.Beacon{
+		testBeacon(g, graph.If_120_X_111_B, stub),
+		testBeacon(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub),
+		testBeacon(g, graph.If_130_B_111_A, stub),
+	}
+
+	db := mock_beacon.NewMockDB(mctrl)
+	store, err := beacon.NewBeaconStore(beacon.Policies{
+		Prop: beacon.Policy{BestSetSize: 2},
+	}, db)
+	require.NoError(t, err)
+	recorder := &selectionRecorder{}
+	store.SetSelectionRecorder(recorder)
+
+	db.EXPECT().CandidateBeacons(gomock.Any(), gomock.Any(), gomock.Any(), addr.IA{}).
+		Return(beacons, nil)
+	selected, err := store.BeaconsToPropagate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, beacon.PropPolicy, recorder.policy)
+	assert.Equal(t, beacons, recorder.candidates)
+	assert.Equal(t, selected, recorder.selected)
+	assert.Len(t, recorder.selected, 2)
+}
+
+type selectionRecorder struct {
+	policy     beacon.PolicyType
+	candidates []beacon.Beacon
+	selected   []beacon.Beacon
+}
+
+func (r *selectionRecorder) RecordSelection(policy beacon.PolicyType,
+	candidates, selected []beacon.Beacon) {
+
+	r.policy, r.candidates, r.selected = policy, candidates, selected
+}
+
+func TestCoreStoreUpdatePolicy(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	store, err := beacon.NewCoreBeaconStore(beacon.CorePolicies{},
+		mock_beacon.NewMockDB(mctrl))
+	require.NoError(t, err)
+
+	maxExpTime := uint8(42)
+	err = store.UpdatePolicy(context.Background(), beacon.Policy{
+		Type:       beacon.CoreRegPolicy,
+		MaxExpTime: &maxExpTime,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, maxExpTime, store.MaxExpTime(beacon.CoreRegPolicy))
+
+	err = store.UpdatePolicy(context.Background(), beacon.Policy{Type: beacon.UpRegPolicy})
+	assert.Error(t, err)
+}
+
 func TestCoreStoreSegmentsToRegister(t *testing.T) {
 	testCoreStoreSelection(t, func(store *beacon.CoreStore) ([]beacon.Beacon, error) {
 		return store.SegmentsToRegister(context.Background(), seg.TypeCore)
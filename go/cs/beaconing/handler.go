@@ -17,6 +17,7 @@ package beaconing
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"github.com/opentracing/opentracing-go"
 
@@ -41,6 +42,16 @@ type BeaconInserter interface {
 	InsertBeacon(ctx context.Context, beacon beacon.Beacon) (beacon.InsertStats, error)
 }
 
+// StatsRecorder records per-beacon statistics.
+type StatsRecorder interface {
+	// RecordBeacon records a received beacon with the result of handling it.
+	// The reason is only set for beacons that are filtered by the policies.
+	RecordBeacon(b beacon.Beacon, result, reason string)
+	// RecordLoopDrop records that the beacon was not propagated on an egress
+	// interface, because it would have created a loop.
+	RecordLoopDrop(b beacon.Beacon)
+}
+
 // Handler handles beacons.
 type Handler struct {
 	LocalIA    addr.IA
@@ -49,6 +60,8 @@ type Handler struct {
 	Interfaces *ifstate.Interfaces
 
 	BeaconsHandled metrics.Counter
+	// Stats records the handled beacons. If nil, nothing is recorded.
+	Stats StatsRecorder
 }
 
 // HandleBeacon handles a baeacon received from peer.
@@ -60,7 +73,13 @@ func (h Handler) HandleBeacon(ctx context.Context, b beacon.Beacon, peer *snet.U
 	if intf == nil {
 		err := serrors.New("received beacon on non-existent interface",
 			"ingress_interface", b.InIfId)
-		h.updateMetric(span, labels.WithResult(prom.ErrNotClassified), err)
+		h.updateMetric(span, b, labels.WithResult(prom.ErrNotClassified), err)
+		return err
+	}
+	if intf.DrainCompleted(time.Now()) {
+		err := serrors.New("received beacon on drained interface",
+			"ingress_interface", b.InIfId)
+		h.updateMetric(span, b, labels.WithResult("err_drained"), err)
 		return err
 	}
 
@@ -68,6 +87,8 @@ func (h Handler) HandleBeacon(ctx context.Context, b beacon.Beacon, peer *snet.U
 	if span != nil {
 		span.SetTag("ingress_interface", b.InIfId)
 		span.SetTag("upstream", upstream)
+		tracing.SrcIA(span, b.Segment.FirstIA())
+		tracing.SegmentIDs(span, b.Segment.ID())
 	}
 	labels.Neighbor = upstream
 	logger := log.FromCtx(ctx).New("beacon", b, "upstream", upstream)
@@ -76,28 +97,28 @@ func (h Handler) HandleBeacon(ctx context.Context, b beacon.Beacon, peer *snet.U
 	logger.Debug("Received beacon")
 	if err := h.Inserter.PreFilter(b); err != nil {
 		logger.Debug("Beacon pre-filtered", "err", err)
-		h.updateMetric(span, labels.WithResult("err_prefilter"), err)
+		h.updateMetric(span, b, labels.WithResult(resultPreFilter), err)
 		return err
 	}
 	if err := h.validateASEntry(b, intf); err != nil {
 		logger.Info("Beacon validation failed", "err", err)
-		h.updateMetric(span, labels.WithResult(prom.ErrVerify), err)
+		h.updateMetric(span, b, labels.WithResult(prom.ErrVerify), err)
 		return err
 	}
 	if err := h.verifySegment(ctx, b.Segment, peer); err != nil {
 		logger.Info("Beacon verification failed", "err", err)
-		h.updateMetric(span, labels.WithResult(prom.ErrVerify), err)
+		h.updateMetric(span, b, labels.WithResult(prom.ErrVerify), err)
 		return serrors.WrapStr("verifying beacon", err)
 	}
 	stat, err := h.Inserter.InsertBeacon(ctx, b)
 	if err != nil {
 		logger.Debug("Failed to insert beacon", "err", err)
-		h.updateMetric(span, labels.WithResult(prom.ErrDB), err)
+		h.updateMetric(span, b, labels.WithResult(prom.ErrDB), err)
 		return serrors.WrapStr("inserting beacon", err)
 
 	}
 	labels = labels.WithResult(resultValue(stat.Inserted, stat.Updated, stat.Filtered))
-	h.updateMetric(span, labels, err)
+	h.updateMetric(span, b, labels, err)
 	logger.Debug("Inserted beacon")
 	return nil
 }
@@ -136,16 +157,29 @@ func (h Handler) verifySegment(ctx context.Context, segment *seg.PathSegment,
 	return segverifier.VerifySegment(ctx, h.Verifier, svcToQuery, segment)
 }
 
-func (h Handler) updateMetric(span opentracing.Span, l handlerLabels, err error) {
+func (h Handler) updateMetric(span opentracing.Span, b beacon.Beacon, l handlerLabels,
+	err error) {
+
 	if h.BeaconsHandled != nil {
 		h.BeaconsHandled.With(l.Expand()...).Add(1)
 	}
+	if h.Stats != nil {
+		var reason string
+		if l.Result == resultPreFilter {
+			reason = beacon.FilterReason(err)
+		}
+		h.Stats.RecordBeacon(b, l.Result, reason)
+	}
 	if span != nil {
 		tracing.ResultLabel(span, l.Result)
 		tracing.Error(span, err)
 	}
 }
 
+// resultPreFilter is the result of beacons that are filtered by the policies
+// before verification.
+const resultPreFilter = "err_prefilter"
+
 type handlerLabels struct {
 	Ingress  uint16
 	Neighbor addr.IA
@@ -16,7 +16,9 @@ package beaconing_test
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -57,11 +59,13 @@ func TestHandlerHandleBeacon(t *testing.T) {
 	}()
 
 	testCases := map[string]struct {
-		Inserter  func(mctrl *gomock.Controller) *mock_beaconing.MockBeaconInserter
-		Verifier  func(mctrl *gomock.Controller) *mock_infra.MockVerifier
-		Beacon    func(t *testing.T, mctrl *gomock.Controller) beacon.Beacon
-		Peer      func() *snet.UDPAddr
-		Assertion assert.ErrorAssertionFunc
+		Inserter    func(mctrl *gomock.Controller) *mock_beaconing.MockBeaconInserter
+		Verifier    func(mctrl *gomock.Controller) *mock_infra.MockVerifier
+		Beacon      func(t *testing.T, mctrl *gomock.Controller) beacon.Beacon
+		Peer        func() *snet.UDPAddr
+		Drained     []uint16
+		GracePeriod time.Duration
+		Assertion   assert.ErrorAssertionFunc
 	}{
 		"valid": {
 			Inserter: func(mctrl *gomock.Controller) *mock_beaconing.MockBeaconInserter {
@@ -91,6 +95,55 @@ func TestHandlerHandleBeacon(t *testing.T) {
 			},
 			Assertion: assert.NoError,
 		},
+		"received on draining interface": {
+			Inserter: func(mctrl *gomock.Controller) *mock_beaconing.MockBeaconInserter {
+				inserter := mock_beaconing.NewMockBeaconInserter(mctrl)
+				inserter.EXPECT().PreFilter(gomock.Any()).Return(nil)
+				inserter.EXPECT().InsertBeacon(gomock.Any(), validBeacon).Return(
+					beacon.InsertStats{}, nil,
+				)
+				return inserter
+			},
+			Verifier: func(mctrl *gomock.Controller) *mock_infra.MockVerifier {
+				verifier := mock_infra.NewMockVerifier(mctrl)
+				verifier.EXPECT().WithServer(gomock.Any()).MaxTimes(2).Return(verifier)
+				verifier.EXPECT().WithIA(gomock.Any()).MaxTimes(2).Return(verifier)
+				verifier.EXPECT().Verify(gomock.Any(), gomock.Any(),
+					gomock.Any()).MaxTimes(2).Return(nil, nil)
+				return verifier
+			},
+			Beacon: func(t *testing.T, mctrl *gomock.Controller) beacon.Beacon {
+				return validBeacon
+			},
+			Peer: func() *snet.UDPAddr {
+				return &snet.UDPAddr{
+					IA:   addr.IA{},
+					Path: spath.Path{},
+				}
+			},
+			Drained:     []uint16{localIF},
+			GracePeriod: time.Hour,
+			Assertion:   assert.NoError,
+		},
+		"received on drained interface": {
+			Inserter: func(mctrl *gomock.Controller) *mock_beaconing.MockBeaconInserter {
+				return mock_beaconing.NewMockBeaconInserter(mctrl)
+			},
+			Verifier: func(mctrl *gomock.Controller) *mock_infra.MockVerifier {
+				return mock_infra.NewMockVerifier(mctrl)
+			},
+			Beacon: func(t *testing.T, mctrl *gomock.Controller) beacon.Beacon {
+				return validBeacon
+			},
+			Peer: func() *snet.UDPAddr {
+				return &snet.UDPAddr{
+					IA:   addr.IA{},
+					Path: spath.Path{},
+				}
+			},
+			Drained:   []uint16{localIF},
+			Assertion: assert.Error,
+		},
 		"received on unknown interface": {
 			Inserter: func(mctrl *gomock.Controller) *mock_beaconing.MockBeaconInserter {
 				return mock_beaconing.NewMockBeaconInserter(mctrl)
@@ -259,10 +312,14 @@ func TestHandlerHandleBeacon(t *testing.T) {
 			mctrl := gomock.NewController(t)
 			defer mctrl.Finish()
 
+			intfs := testInterfaces(topo)
+			for _, ifID := range tc.Drained {
+				intfs.Get(ifID).SetAdminState(ifstate.AdminDrained, tc.GracePeriod)
+			}
 			handler := beaconing.Handler{
 				LocalIA:    localIA,
 				Inserter:   tc.Inserter(mctrl),
-				Interfaces: testInterfaces(topo),
+				Interfaces: intfs,
 				Verifier:   tc.Verifier(mctrl),
 			}
 			err := handler.HandleBeacon(context.Background(),
@@ -274,6 +331,60 @@ func TestHandlerHandleBeacon(t *testing.T) {
 	}
 }
 
+func TestHandlerStats(t *testing.T) {
+	topo, err := topology.FromJSONFile("testdata/topology-core.json")
+	require.NoError(t, err)
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+	b := beacon.Beacon{
+		Segment: testSegment(g, []uint16{graph.If_220_X_120_B, graph.If_120_A_110_X}),
+		InIfId:  localIF,
+	}
+
+	inserter := mock_beaconing.NewMockBeaconInserter(mctrl)
+	inserter.EXPECT().PreFilter(b).Return(serrors.WithCtx(beacon.ErrISDLoop, "isd", 2))
+	stats := &statsRecorder{}
+	handler := beaconing.Handler{
+		LocalIA:    localIA,
+		Inserter:   inserter,
+		Interfaces: testInterfaces(topo),
+		Verifier:   mock_infra.NewMockVerifier(mctrl),
+		Stats:      stats,
+	}
+	err = handler.HandleBeacon(context.Background(), b, &snet.UDPAddr{})
+	assert.Error(t, err)
+	assert.Equal(t, []recordedBeacon{{
+		Beacon: b,
+		Result: "err_prefilter",
+		Reason: "isd_loop",
+	}}, stats.beacons)
+}
+
+type recordedBeacon struct {
+	Beacon beacon.Beacon
+	Result string
+	Reason string
+}
+
+type statsRecorder struct {
+	mu        sync.Mutex
+	beacons   []recordedBeacon
+	loopDrops []beacon.Beacon
+}
+
+func (r *statsRecorder) RecordBeacon(b beacon.Beacon, result, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.beacons = append(r.beacons, recordedBeacon{Beacon: b, Result: result, Reason: reason})
+}
+
+func (r *statsRecorder) RecordLoopDrop(b beacon.Beacon) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loopDrops = append(r.loopDrops, b)
+}
+
 func testSegment(g *graph.Graph, ifids []uint16) *seg.PathSegment {
 	pseg := g.Beacon(ifids)
 	pseg.ASEntries = pseg.ASEntries[:len(pseg.ASEntries)-1]
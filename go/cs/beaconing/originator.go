@@ -119,8 +119,10 @@ func (o *Originator) originateBeacons(ctx context.Context) {
 	o.logSummary(logger, s)
 }
 
-// needBeacon returns a list of interfaces that need a beacon.
+// needBeacon returns a list of interfaces that need a beacon. Drained
+// interfaces never need a beacon.
 func (o *Originator) needBeacon(active []*ifstate.Interface) []*ifstate.Interface {
+	active = inService(active)
 	if o.Tick.Passed() {
 		return active
 	}
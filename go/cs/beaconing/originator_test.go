@@ -120,6 +120,47 @@ func TestOriginatorRun(t *testing.T) {
 		// The second run should not cause any beacons to originate.
 		o.Run(context.Background())
 	})
+	t.Run("run skips drained interfaces", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		intfs := ifstate.NewInterfaces(interfaceInfos(topo), ifstate.Config{})
+		drained := intfs.Filtered(originationFilter)[0].TopoInfo().ID
+		intfs.Get(drained).SetAdminState(ifstate.AdminDrained, 0)
+		senderFactory := mock_beaconing.NewMockSenderFactory(mctrl)
+		o := beaconing.Originator{
+			Extender: &beaconing.DefaultExtender{
+				IA:         topo.IA(),
+				MTU:        topo.MTU(),
+				Signer:     signer,
+				Intfs:      intfs,
+				MAC:        macFactory,
+				MaxExpTime: func() uint8 { return beacon.DefaultMaxExpTime },
+				StaticInfo: func() *beaconing.StaticInfoCfg { return nil },
+			},
+			SenderFactory: senderFactory,
+			IA:            topo.IA(),
+			Signer:        signer,
+			AllInterfaces: intfs,
+			OriginationInterfaces: func() []*ifstate.Interface {
+				return intfs.Filtered(originationFilter)
+			},
+			Tick: beaconing.NewTick(time.Hour),
+		}
+
+		senderFactory.EXPECT().NewSender(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Times(3).DoAndReturn(
+			func(_ context.Context, dstIA addr.IA, egIfId uint16,
+				nextHop *net.UDPAddr) (beaconing.Sender, error) {
+
+				assert.NotEqual(t, drained, egIfId)
+				sender := mock_beaconing.NewMockSender(mctrl)
+				sender.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1)
+				sender.EXPECT().Close().Times(1)
+				return sender, nil
+			},
+		)
+		o.Run(context.Background())
+	})
 	t.Run("Fast recovery", func(t *testing.T) {
 		mctrl := gomock.NewController(t)
 		defer mctrl.Finish()
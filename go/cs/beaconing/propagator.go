@@ -58,10 +58,16 @@ type Propagator struct {
 	Signer                seg.Signer
 	AllInterfaces         *ifstate.Interfaces
 	PropagationInterfaces func() []*ifstate.Interface
-	AllowIsdLoop          bool
+	// AllowIsdLoop indicates whether ISD loops are allowed. It is called in
+	// every run, such that the value can change at runtime. If nil, ISD loops
+	// are not allowed.
+	AllowIsdLoop func() bool
 
 	Propagated     metrics.Counter
 	InternalErrors metrics.Counter
+	// Stats records the beacons that are not propagated because of loops. If
+	// nil, nothing is recorded.
+	Stats StatsRecorder
 
 	// Tick is mutable.
 	Tick Tick
@@ -139,9 +145,9 @@ func (p *Propagator) run(ctx context.Context) error {
 
 // needsBeacons returns a list of active interfaces that beacons should be
 // propagated on. In a core AS, these are all active core links. In a non-core
-// AS, these are all active child links.
+// AS, these are all active child links. Drained interfaces are excluded.
 func (p *Propagator) needsBeacons() []*ifstate.Interface {
-	intfs := p.PropagationInterfaces()
+	intfs := inService(p.PropagationInterfaces())
 	sort.Slice(intfs, func(i, j int) bool {
 		return intfs[i].TopoInfo().ID < intfs[j].TopoInfo().ID
 	})
@@ -168,16 +174,20 @@ func (p *Propagator) beaconsPerInterface(
 	}
 	var beacons []beacon.Beacon
 	for _, b := range allBeacons {
-		if p.AllInterfaces.Get(b.InIfId) == nil {
+		if !ingressInService(p.AllInterfaces, b.InIfId) {
 			continue
 		}
 		beacons = append(beacons, b)
 	}
+	allowIsdLoop := p.AllowIsdLoop != nil && p.AllowIsdLoop()
 	r := make(map[*ifstate.Interface][]beacon.Beacon)
 	for _, intf := range intfs {
 		toPropagate := make([]beacon.Beacon, 0, len(beacons))
 		for _, b := range beacons {
-			if p.shouldIgnore(b, intf) {
+			if shouldIgnore(b, intf, allowIsdLoop) {
+				if p.Stats != nil {
+					p.Stats.RecordLoopDrop(b)
+				}
 				continue
 			}
 			ps, err := seg.BeaconFromPB(seg.PathSegmentToPB(b.Segment))
@@ -193,8 +203,8 @@ func (p *Propagator) beaconsPerInterface(
 
 // shouldIgnore indicates whether a beacon should not be sent on the egress
 // interface because it creates a loop.
-func (p *Propagator) shouldIgnore(bseg beacon.Beacon, intf *ifstate.Interface) bool {
-	if err := beacon.FilterLoop(bseg, intf.TopoInfo().IA, p.AllowIsdLoop); err != nil {
+func shouldIgnore(bseg beacon.Beacon, intf *ifstate.Interface, allowIsdLoop bool) bool {
+	if err := beacon.FilterLoop(bseg, intf.TopoInfo().IA, allowIsdLoop); err != nil {
 		return true
 	}
 	return false
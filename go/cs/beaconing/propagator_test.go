@@ -131,6 +131,7 @@ func TestPropagatorRunCore(t *testing.T) {
 	intfs := ifstate.NewInterfaces(interfaceInfos(topo), ifstate.Config{})
 	provider := mock_beaconing.NewMockBeaconProvider(mctrl)
 	senderFactory := mock_beaconing.NewMockSenderFactory(mctrl)
+	stats := &statsRecorder{}
 	filter := func(intf *ifstate.Interface) bool {
 		return intf.TopoInfo().LinkType == topology.Core
 	}
@@ -153,6 +154,7 @@ func TestPropagatorRunCore(t *testing.T) {
 		},
 		Tick:     beaconing.NewTick(time.Hour),
 		Provider: provider,
+		Stats:    stats,
 	}
 	g := graph.NewDefaultGraph(mctrl)
 	provider.EXPECT().BeaconsToPropagate(gomock.Any()).Times(2).DoAndReturn(
@@ -200,6 +202,8 @@ func TestPropagatorRunCore(t *testing.T) {
 	p.Run(context.Background())
 	// Check that no beacons are sent, since the period has not passed yet.
 	p.Run(context.Background())
+	// Beacons are not propagated on interfaces to ASes they already contain.
+	assert.NotEmpty(t, stats.loopDrops)
 }
 
 func TestPropagatorFastRecovery(t *testing.T) {
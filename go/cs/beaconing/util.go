@@ -20,6 +20,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/go/cs/ifstate"
 	"github.com/scionproto/scion/go/lib/addr"
@@ -28,13 +29,13 @@ import (
 	"github.com/scionproto/scion/go/lib/topology"
 )
 
-// sortedIntfs returns all interfaces of the given link type sorted by interface
-// ID.
+// sortedIntfs returns all interfaces of the given link type that are not
+// drained sorted by interface ID.
 func sortedIntfs(intfs *ifstate.Interfaces, linkType topology.LinkType) []uint16 {
 	var result []uint16
 	for ifid, intf := range intfs.All() {
 		topoInfo := intf.TopoInfo()
-		if topoInfo.LinkType != linkType {
+		if topoInfo.LinkType != linkType || intf.Drained() {
 			continue
 		}
 		result = append(result, ifid)
@@ -43,6 +44,34 @@ func sortedIntfs(intfs *ifstate.Interfaces, linkType topology.LinkType) []uint16
 	return result
 }
 
+// inService returns the interfaces that are not drained.
+func inService(intfs []*ifstate.Interface) []*ifstate.Interface {
+	result := make([]*ifstate.Interface, 0, len(intfs))
+	for _, intf := range intfs {
+		if !intf.Drained() {
+			result = append(result, intf)
+		}
+	}
+	return result
+}
+
+// ingressInService indicates whether the ingress interface of a beacon exists
+// and is not drained. Beacons that were received on a drained interface are
+// not propagated.
+func ingressInService(intfs *ifstate.Interfaces, ifID uint16) bool {
+	intf := intfs.Get(ifID)
+	return intf != nil && !intf.Drained()
+}
+
+// ingressRegistrable indicates whether the ingress interface of a beacon
+// exists and its drain has not completed. During the grace period of a drain,
+// the segments over the interface are still registered, such that the paths
+// over it remain available until the interface is taken out of service.
+func ingressRegistrable(intfs *ifstate.Interfaces, ifID uint16, now time.Time) bool {
+	intf := intfs.Get(ifID)
+	return intf != nil && !intf.DrainCompleted(now)
+}
+
 type summary struct {
 	mu    sync.Mutex
 	srcs  map[addr.IA]struct{}
@@ -164,8 +164,9 @@ func (r *RemoteWriter) Write(
 	s := newSummary()
 	var expected int
 	var wg sync.WaitGroup
+	now := time.Now()
 	for _, b := range segments {
-		if r.Intfs.Get(b.InIfId) == nil {
+		if !ingressRegistrable(r.Intfs, b.InIfId, now) {
 			continue
 		}
 		err := r.Extender.Extend(ctx, b.Segment, b.InIfId, 0, peers)
@@ -222,8 +223,9 @@ func (r *LocalWriter) Write(
 	logger := log.FromCtx(ctx)
 	beacons := make(map[string]beacon.Beacon)
 	var toRegister []*seg.Meta
+	now := time.Now()
 	for _, b := range segments {
-		if r.Intfs.Get(b.InIfId) == nil {
+		if !ingressRegistrable(r.Intfs, b.InIfId, now) {
 			continue
 		}
 		err := r.Extender.Extend(ctx, b.Segment, b.InIfId, 0, peers)
@@ -16,6 +16,7 @@
 package config
 
 import (
+	"fmt"
 	"io"
 	"strings"
 	"time"
@@ -44,6 +45,19 @@ const (
 	DefaultQueryInterval = 5 * time.Minute
 	// DefaultMaxASValidity is the default validity period for renewed AS certificates.
 	DefaultMaxASValidity = 3 * 24 * time.Hour
+	// DefaultLookupCacheSize is the default maximum number of cached segment
+	// lookup results.
+	DefaultLookupCacheSize = 10000
+	// DefaultLookupCacheTTL is the default duration for which segment lookup
+	// results are cached.
+	DefaultLookupCacheTTL = 10 * time.Second
+	// DefaultLookupCacheNegativeTTL is the default duration for which failed
+	// segment lookups are cached.
+	DefaultLookupCacheNegativeTTL = 2 * time.Second
+	// DefaultHiddenPathsState is the default file in which the accepted
+	// versions of the hidden path groups are stored. The placeholder is
+	// replaced with the service ID.
+	DefaultHiddenPathsState = "/share/data/%s.hidden_paths.yml"
 )
 
 var _ config.Config = (*Config)(nil)
@@ -55,6 +69,7 @@ type Config struct {
 	Logging     log.Config         `toml:"log,omitempty"`
 	Metrics     env.Metrics        `toml:"metrics,omitempty"`
 	API         api.Config         `toml:"api,omitempty"`
+	Management  ManagementAPI      `toml:"management_api,omitempty"`
 	Tracing     env.Tracing        `toml:"tracing,omitempty"`
 	QUIC        env.QUIC           `toml:"quic,omitempty"`
 	BeaconDB    storage.DBConfig   `toml:"beacon_db,omitempty"`
@@ -64,6 +79,7 @@ type Config struct {
 	PS          PSConfig           `toml:"path,omitempty"`
 	CA          CA                 `toml:"ca,omitempty"`
 	TrustEngine trustengine.Config `toml:"trustengine,omitempty"`
+	RateLimit   RateLimit          `toml:"rate_limit,omitempty"`
 }
 
 // InitDefaults initializes the default values for all parts of the config.
@@ -74,6 +90,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.API,
+		&cfg.Management,
 		&cfg.Tracing,
 		&cfg.BeaconDB,
 		&cfg.TrustDB,
@@ -82,7 +99,11 @@ func (cfg *Config) InitDefaults() {
 		&cfg.PS,
 		&cfg.CA,
 		&cfg.TrustEngine,
+		&cfg.RateLimit,
 	)
+	if cfg.PS.HiddenPathsState == "" {
+		cfg.PS.HiddenPathsState = fmt.Sprintf(DefaultHiddenPathsState, cfg.General.ID)
+	}
 }
 
 // Validate validates all parts of the config.
@@ -93,6 +114,7 @@ func (cfg *Config) Validate() error {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.API,
+		&cfg.Management,
 		&cfg.BeaconDB,
 		&cfg.TrustDB,
 		&cfg.PathDB,
@@ -100,6 +122,7 @@ func (cfg *Config) Validate() error {
 		&cfg.PS,
 		&cfg.CA,
 		&cfg.TrustEngine,
+		&cfg.RateLimit,
 	)
 }
 
@@ -111,6 +134,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.API,
+		&cfg.Management,
 		&cfg.Tracing,
 		&cfg.QUIC,
 		config.OverrideName(
@@ -138,9 +162,35 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.PS,
 		&cfg.CA,
 		&cfg.TrustEngine,
+		&cfg.RateLimit,
 	)
 }
 
+var _ config.Config = (*ManagementAPI)(nil)
+
+// ManagementAPI holds the configuration of the management endpoints of the
+// service API.
+type ManagementAPI struct {
+	// SharedSecret is the path to the PEM-encoded shared secret that is used to
+	// verify the JWT tokens of requests to the management endpoints. If it is
+	// not set, the management endpoints are disabled.
+	SharedSecret string `toml:"shared_secret,omitempty"`
+}
+
+func (cfg *ManagementAPI) InitDefaults() {}
+
+func (cfg *ManagementAPI) Validate() error {
+	return nil
+}
+
+func (cfg *ManagementAPI) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, managementAPISample)
+}
+
+func (cfg *ManagementAPI) ConfigName() string {
+	return "management_api"
+}
+
 var _ config.Config = (*BSConfig)(nil)
 
 // BSConfig holds the configuration specific to the beacon server.
@@ -200,29 +250,80 @@ type PSConfig struct {
 	// If HiddenPathsCfg begins with http:// or https://, it will be fetched
 	// over the network from the specified URL instead.
 	HiddenPathsCfg string `toml:"hidden_paths_cfg,omitempty"`
+	// HiddenPathsState is the file in which the accepted versions of the
+	// hidden path groups are stored. If empty, a file in the default data
+	// directory that is named after the service ID is used.
+	HiddenPathsState string `toml:"hidden_paths_state,omitempty"`
+	// LookupCache is the configuration of the cache for the segment lookups
+	// that are forwarded to the core ASes.
+	LookupCache LookupCache `toml:"lookup_cache,omitempty"`
 }
 
 func (cfg *PSConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	config.InitAll(&cfg.LookupCache)
 }
 
 func (cfg *PSConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("query_interval must not be zero")
 	}
-	return nil
+	return config.ValidateAll(&cfg.LookupCache)
 }
 
 func (cfg *PSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, psSample)
+	config.WriteSample(dst, path, ctx, &cfg.LookupCache)
 }
 
 func (cfg *PSConfig) ConfigName() string {
 	return "path"
 }
 
+var _ config.Config = (*LookupCache)(nil)
+
+// LookupCache is the configuration of the segment lookup cache.
+type LookupCache struct {
+	// Disable disables the lookup cache.
+	Disable bool `toml:"disable,omitempty"`
+	// Size is the maximum number of cached lookup results.
+	Size int `toml:"size,omitempty"`
+	// TTL is the duration for which lookup results with segments are cached.
+	TTL util.DurWrap `toml:"ttl,omitempty"`
+	// NegativeTTL is the duration for which failed lookups and lookups without
+	// segments are cached.
+	NegativeTTL util.DurWrap `toml:"negative_ttl,omitempty"`
+}
+
+func (cfg *LookupCache) InitDefaults() {
+	if cfg.Size == 0 {
+		cfg.Size = DefaultLookupCacheSize
+	}
+	initDurWrap(&cfg.TTL, DefaultLookupCacheTTL)
+	initDurWrap(&cfg.NegativeTTL, DefaultLookupCacheNegativeTTL)
+}
+
+func (cfg *LookupCache) Validate() error {
+	if cfg.Size < 0 {
+		return serrors.New("size must not be negative", "size", cfg.Size)
+	}
+	if cfg.TTL.Duration < 0 || cfg.NegativeTTL.Duration < 0 {
+		return serrors.New("ttl must not be negative",
+			"ttl", cfg.TTL, "negative_ttl", cfg.NegativeTTL)
+	}
+	return nil
+}
+
+func (cfg *LookupCache) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, lookupCacheSample)
+}
+
+func (cfg *LookupCache) ConfigName() string {
+	return "lookup_cache"
+}
+
 var _ config.Config = (*Policies)(nil)
 
 // Policies contains the file paths of the policies.
@@ -338,3 +439,70 @@ func (cfg *CAService) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
 func (cfg *CAService) ConfigName() string {
 	return "service"
 }
+
+var _ config.Config = (*RateLimit)(nil)
+
+// RateLimit holds the admission control configuration of the gRPC endpoints.
+type RateLimit struct {
+	// Default are the limits of the RPC methods that are not listed in
+	// Methods.
+	Default RateLimitEntry `toml:"default,omitempty"`
+	// Methods are the limits per RPC method, keyed by the full method name.
+	Methods map[string]RateLimitEntry `toml:"methods,omitempty"`
+}
+
+func (cfg *RateLimit) InitDefaults() {}
+
+func (cfg *RateLimit) Validate() error {
+	if err := cfg.Default.Validate(); err != nil {
+		return serrors.WrapStr("validating default limits", err)
+	}
+	for method, entry := range cfg.Methods {
+		if err := entry.Validate(); err != nil {
+			return serrors.WrapStr("validating method limits", err, "method", method)
+		}
+	}
+	return nil
+}
+
+func (cfg *RateLimit) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, rateLimitSample)
+	config.WriteSample(dst, path, ctx, &cfg.Default)
+}
+
+func (cfg *RateLimit) ConfigName() string {
+	return "rate_limit"
+}
+
+// RateLimitEntry are the admission limits of an RPC method. Zero values
+// disable the respective limit.
+type RateLimitEntry struct {
+	// PeerRate is the number of requests per second that a single peer can
+	// issue.
+	PeerRate float64 `toml:"peer_rate,omitempty"`
+	// PeerBurst is the number of requests that a single peer can issue at once.
+	PeerBurst int `toml:"peer_burst,omitempty"`
+	// MaxConcurrent is the number of requests that are served concurrently.
+	MaxConcurrent int `toml:"max_concurrent,omitempty"`
+	// MaxQueued is the number of requests that wait for being served.
+	MaxQueued int `toml:"max_queued,omitempty"`
+}
+
+func (cfg *RateLimitEntry) InitDefaults() {}
+
+func (cfg *RateLimitEntry) Validate() error {
+	if cfg.PeerRate < 0 || cfg.PeerBurst < 0 || cfg.MaxConcurrent < 0 || cfg.MaxQueued < 0 {
+		return serrors.New("limits must not be negative", "peer_rate", cfg.PeerRate,
+			"peer_burst", cfg.PeerBurst, "max_concurrent", cfg.MaxConcurrent,
+			"max_queued", cfg.MaxQueued)
+	}
+	return nil
+}
+
+func (cfg *RateLimitEntry) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, rateLimitEntrySample)
+}
+
+func (cfg *RateLimitEntry) ConfigName() string {
+	return "default"
+}
@@ -46,6 +46,7 @@ func InitTestConfig(cfg *Config) {
 	InitTestBSConfig(&cfg.BS)
 	InitTestPSConfig(&cfg.PS)
 	InitTestCA(&cfg.CA)
+	cfg.RateLimit.Default.PeerRate = 1
 }
 
 func InitTestBSConfig(cfg *BSConfig) {
@@ -61,6 +62,7 @@ func InitTestPolicies(cfg *Policies) {
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	apitest.CheckConfig(t, &cfg.API)
+	assert.Empty(t, cfg.Management.SharedSecret)
 	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, &cfg.Tracing, nil, id)
 	logtest.CheckTestLogging(t, &cfg.Logging, id)
 	storagetest.CheckTestTrustDBConfig(t, &cfg.TrustDB, id)
@@ -69,6 +71,7 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	CheckTestBSConfig(t, &cfg.BS)
 	CheckTestPSConfig(t, &cfg.PS, id)
 	CheckTestCA(t, &cfg.CA)
+	CheckTestRateLimit(t, &cfg.RateLimit)
 }
 
 func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
@@ -92,6 +95,15 @@ func InitTestPSConfig(cfg *PSConfig) {
 func CheckTestPSConfig(t *testing.T, cfg *PSConfig, id string) {
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
 	assert.Empty(t, cfg.HiddenPathsCfg)
+	assert.False(t, cfg.LookupCache.Disable)
+	assert.Equal(t, DefaultLookupCacheSize, cfg.LookupCache.Size)
+	assert.Equal(t, DefaultLookupCacheTTL, cfg.LookupCache.TTL.Duration)
+	assert.Equal(t, DefaultLookupCacheNegativeTTL, cfg.LookupCache.NegativeTTL.Duration)
+}
+
+func CheckTestRateLimit(t *testing.T, cfg *RateLimit) {
+	assert.Equal(t, RateLimitEntry{}, cfg.Default)
+	assert.Empty(t, cfg.Methods)
 }
 
 func InitTestCA(cfg *CA) {
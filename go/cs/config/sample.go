@@ -16,6 +16,13 @@ package config
 
 const idSample = "cs-1"
 
+const managementAPISample = `
+# The path to the PEM-encoded shared secret that is used to verify the JWT
+# tokens of requests to the management endpoints of the API. If it is not set,
+# the management endpoints are disabled. (default: "")
+shared_secret = ""
+`
+
 const psSample = `
 # The time after which segments for a destination are refetched. (default 5m)
 query_interval = "5m"
@@ -23,6 +30,10 @@ query_interval = "5m"
 # paths functionality is not enabled. If the path starts with http:// or
 # https:// the configuration is fetched from the given URL. (default: "")
 hidden_paths_cfg = ""
+# The file in which the accepted versions of the hidden path groups are stored,
+# such that they cannot be rolled back by restarting the service.
+# (default: "/share/data/<general.id>.hidden_paths.yml")
+hidden_paths_state = ""
 `
 
 const caSample = `
@@ -63,3 +74,57 @@ lifetime = "10m"
 # authorization tokens. If not set, the SCION ID is used instead.
 client_id = ""
 `
+
+const rateLimitSample = `
+# The admission limits of the gRPC endpoints. The limits in the default section
+# apply to all RPC methods that are not listed in the methods section. The
+# methods section is keyed by the full RPC method name, e.g.:
+#
+# [rate_limit.methods."/proto.control_plane.v1.SegmentLookupService/Segments"]
+# peer_rate = 10.0
+# peer_burst = 20
+# max_concurrent = 50
+# max_queued = 100
+#
+# Throttled requests fail with the RESOURCE_EXHAUSTED code and carry a retry
+# hint in the grpc-retry-pushback-ms trailer.
+`
+
+const rateLimitEntrySample = `
+# The number of requests per second that a single peer, i.e., an ISD-AS for
+# authenticated peers or an address otherwise, can issue per RPC method. If it
+# is zero, the requests are not rate limited. (default 0)
+peer_rate = 0.0
+
+# The number of requests that a single peer can issue at once. If it is zero,
+# the burst is set to the rate, but at least one. (default 0)
+peer_burst = 0
+
+# The number of requests per RPC method that are served concurrently. If it is
+# zero, the concurrency is not limited. (default 0)
+max_concurrent = 0
+
+# The number of requests per RPC method that wait for being served, if
+# max_concurrent requests are in service. Further requests are rejected.
+# (default 0)
+max_queued = 0
+`
+
+const lookupCacheSample = `
+# Disable the cache for the segment lookups that are forwarded to the core
+# ASes. (default false)
+disable = false
+
+# The maximum number of cached lookup results. If the cache is full, the least
+# recently used result is evicted. (default 10000)
+size = 10000
+
+# The duration for which lookup results with segments are cached. Cached
+# segments are invalidated earlier if they contain a revoked interface.
+# (default 10s)
+ttl = "10s"
+
+# The duration for which failed lookups and lookups without segments are
+# cached. (default 2s)
+negative_ttl = "2s"
+`
@@ -48,6 +48,20 @@ const (
 	DefaultKeepaliveTimeout = 3 * DefaultKeepaliveInterval
 )
 
+// AdminState is the administrative state of an interface that is set by the
+// operator.
+type AdminState string
+
+const (
+	// AdminUp indicates that the interface is in service.
+	AdminUp AdminState = "up"
+	// AdminDrained indicates that the interface is taken out of service. No
+	// new beacons are originated or propagated over a drained interface. Once
+	// the grace period has passed, beacons received on the interface are
+	// discarded and no segments over it are registered anymore.
+	AdminDrained AdminState = "drained"
+)
+
 // Config enables configuration of the interfaces.
 type Config struct {
 	// KeepaliveTimeout specifies for how long an interface can receive no
@@ -93,8 +107,9 @@ func (intfs *Interfaces) Update(ifInfomap map[uint16]InterfaceInfo) {
 			m[ifid] = intf
 		} else {
 			m[ifid] = &Interface{
-				topoInfo: info,
-				cfg:      intfs.cfg,
+				topoInfo:   info,
+				cfg:        intfs.cfg,
+				adminState: AdminUp,
 			}
 		}
 	}
@@ -115,7 +130,8 @@ func (intfs *Interfaces) Filtered(filter func(*Interface) bool) []*Interface {
 }
 
 // Reset resets all interface states to inactive. This should be called
-// by the beacon server if it is elected leader.
+// by the beacon server if it is elected leader. The administrative state is
+// preserved.
 func (intfs *Interfaces) Reset() {
 	intfs.mu.RLock()
 	defer intfs.mu.RUnlock()
@@ -149,6 +165,8 @@ type Interface struct {
 	lastOriginate time.Time
 	lastPropagate time.Time
 	cfg           Config
+	adminState    AdminState
+	drainDeadline time.Time
 }
 
 // Activate sets the remote interface ID.
@@ -196,6 +214,41 @@ func (intf *Interface) LastPropagate() time.Time {
 	return intf.lastPropagate
 }
 
+// SetAdminState sets the administrative state of the interface. The grace
+// period is only used if the interface is drained. Draining an interface that
+// is already drained does not extend the grace period.
+func (intf *Interface) SetAdminState(state AdminState, gracePeriod time.Duration) {
+	intf.mu.Lock()
+	defer intf.mu.Unlock()
+	switch {
+	case state != AdminDrained:
+		intf.drainDeadline = time.Time{}
+	case intf.adminState != AdminDrained:
+		intf.drainDeadline = time.Now().Add(gracePeriod)
+	}
+	intf.adminState = state
+}
+
+// AdminState returns the administrative state of the interface.
+func (intf *Interface) AdminState() AdminState {
+	intf.mu.RLock()
+	defer intf.mu.RUnlock()
+	return intf.adminState
+}
+
+// Drained indicates whether the interface is drained.
+func (intf *Interface) Drained() bool {
+	return intf.AdminState() == AdminDrained
+}
+
+// DrainCompleted indicates whether the interface is drained and the grace
+// period has passed at the given point in time.
+func (intf *Interface) DrainCompleted(now time.Time) bool {
+	intf.mu.RLock()
+	defer intf.mu.RUnlock()
+	return intf.adminState == AdminDrained && !now.Before(intf.drainDeadline)
+}
+
 func (intf *Interface) reset() {
 	intf.mu.Lock()
 	defer intf.mu.Unlock()
@@ -16,6 +16,7 @@ package ifstate_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -25,6 +26,7 @@ import (
 func TestInterfacesUpdate(t *testing.T) {
 	t.Run("The update retains the state of the interface", func(t *testing.T) {
 		intfs := testInterfaces(t)
+		intfs.Get(1).SetAdminState(ifstate.AdminDrained, 0)
 		topoMap := map[uint16]ifstate.InterfaceInfo{
 			1: {ID: 1, MTU: 1401},
 			2: {ID: 2, MTU: 1402},
@@ -36,6 +38,9 @@ func TestInterfacesUpdate(t *testing.T) {
 		// The remote ifid should be kept
 		assert.EqualValues(t, 11, intfs.Get(1).TopoInfo().RemoteID)
 		assert.EqualValues(t, 22, intfs.Get(2).TopoInfo().RemoteID)
+		// The administrative state should be kept.
+		assert.True(t, intfs.Get(1).Drained())
+		assert.False(t, intfs.Get(2).Drained())
 	})
 	t.Run("The update adds new interfaces and removes missing", func(t *testing.T) {
 		intfs := testInterfaces(t)
@@ -46,17 +51,41 @@ func TestInterfacesUpdate(t *testing.T) {
 		assert.Nil(t, intfs.Get(1))
 		assert.Nil(t, intfs.Get(2))
 		assert.Equal(t, uint16(1403), intfs.Get(3).TopoInfo().MTU)
+		assert.Equal(t, ifstate.AdminUp, intfs.Get(3).AdminState())
 	})
 }
 
 func TestInterfacesReset(t *testing.T) {
 	intfs := testInterfaces(t)
+	intfs.Get(2).SetAdminState(ifstate.AdminDrained, 0)
 	intfs.Reset()
 	// The topo info should remain.
 	assert.Equal(t, uint16(1301), intfs.Get(1).TopoInfo().MTU)
 	assert.Equal(t, uint16(1302), intfs.Get(2).TopoInfo().MTU)
 	assert.EqualValues(t, 11, intfs.Get(1).TopoInfo().RemoteID)
 	assert.EqualValues(t, 22, intfs.Get(2).TopoInfo().RemoteID)
+	// The administrative state should remain.
+	assert.True(t, intfs.Get(2).Drained())
+}
+
+func TestInterfaceDrain(t *testing.T) {
+	intfs := testInterfaces(t)
+	intf := intfs.Get(1)
+	now := time.Now()
+	assert.False(t, intf.DrainCompleted(now))
+
+	intf.SetAdminState(ifstate.AdminDrained, time.Minute)
+	assert.True(t, intf.Drained())
+	assert.False(t, intf.DrainCompleted(now))
+	assert.True(t, intf.DrainCompleted(now.Add(time.Minute+time.Second)))
+
+	// Draining again does not extend the grace period.
+	intf.SetAdminState(ifstate.AdminDrained, time.Hour)
+	assert.True(t, intf.DrainCompleted(now.Add(time.Minute+time.Second)))
+
+	intf.SetAdminState(ifstate.AdminUp, 0)
+	assert.False(t, intf.Drained())
+	assert.False(t, intf.DrainCompleted(now.Add(time.Hour)))
 }
 
 func TestInterfacesAll(t *testing.T) {
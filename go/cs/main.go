@@ -42,6 +42,7 @@ import (
 	segreggrpc "github.com/scionproto/scion/go/cs/segreg/grpc"
 	"github.com/scionproto/scion/go/cs/segreq"
 	segreqgrpc "github.com/scionproto/scion/go/cs/segreq/grpc"
+	"github.com/scionproto/scion/go/cs/stats"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/infra/infraenv"
@@ -73,6 +74,7 @@ import (
 	cstrustmetrics "github.com/scionproto/scion/go/pkg/cs/trust/metrics"
 	"github.com/scionproto/scion/go/pkg/discovery"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+	"github.com/scionproto/scion/go/pkg/hiddenpath"
 	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
 	dpb "github.com/scionproto/scion/go/pkg/proto/discovery"
 	"github.com/scionproto/scion/go/pkg/service"
@@ -155,38 +157,6 @@ func realMain(ctx context.Context) error {
 		return err
 	}
 
-	nc := infraenv.NetworkConfig{
-		IA:                    topo.IA(),
-		Public:                topo.ControlServiceAddress(globalCfg.General.ID),
-		ReconnectToDispatcher: globalCfg.General.ReconnectToDispatcher,
-		QUIC: infraenv.QUIC{
-			Address: globalCfg.QUIC.Address,
-		},
-		SVCResolver: topo,
-		SCMPHandler: snet.DefaultSCMPHandler{
-			RevocationHandler: cs.RevocationHandler{RevCache: revCache},
-			SCMPErrors:        metrics.SCMPErrors,
-		},
-		SCIONNetworkMetrics:    metrics.SCIONNetworkMetrics,
-		SCIONPacketConnMetrics: metrics.SCIONPacketConnMetrics,
-	}
-	quicStack, err := nc.QUICStack()
-	if err != nil {
-		return serrors.WrapStr("initializing QUIC stack", err)
-	}
-	defer quicStack.RedirectCloser()
-	tcpStack, err := nc.TCPStack()
-	if err != nil {
-		return serrors.WrapStr("initializing TCP stack", err)
-	}
-	dialer := &libgrpc.QUICDialer{
-		Rewriter: &onehop.AddressRewriter{
-			Rewriter: nc.AddressRewriter(nil),
-			MAC:      macGen(),
-		},
-		Dialer: quicStack.Dialer,
-	}
-
 	trustDB, err := storage.NewTrustStorage(globalCfg.TrustDB)
 	if err != nil {
 		return serrors.WrapStr("initializing trust storage", err)
@@ -221,6 +191,54 @@ func realMain(ctx context.Context) error {
 		return err
 	}
 
+	signer, err := cs.NewSigner(topo.IA(), trustDB, globalCfg.General.ConfigDir)
+	if err != nil {
+		return serrors.WrapStr("initializing AS signer", err)
+	}
+
+	// Authenticate QUIC peers with their AS certificate chain. Peers that
+	// cannot be authenticated are restricted to the RPCs of the authorizer.
+	tlsMgr := trust.NewTLSCryptoManager(signer, trustDB)
+	tlsMgr.AllowUnauthenticated = globalCfg.QUIC.AllowUnauthenticated
+	tlsMgr.RestrictUnauthenticated = true
+	tlsMgr.Bootstrap = true
+	tlsMgr.UnauthenticatedPeers = libmetrics.NewPromCounter(
+		trustmetrics.TLSUnauthenticatedPeersTotal)
+	tlsMgr.BootstrapPeers = libmetrics.NewPromCounter(trustmetrics.TLSBootstrapPeersTotal)
+
+	nc := infraenv.NetworkConfig{
+		IA:                    topo.IA(),
+		Public:                topo.ControlServiceAddress(globalCfg.General.ID),
+		ReconnectToDispatcher: globalCfg.General.ReconnectToDispatcher,
+		QUIC: infraenv.QUIC{
+			Address: globalCfg.QUIC.Address,
+			TLS:     tlsMgr,
+		},
+		SVCResolver: topo,
+		SCMPHandler: snet.DefaultSCMPHandler{
+			RevocationHandler: cs.RevocationHandler{RevCache: revCache},
+			SCMPErrors:        metrics.SCMPErrors,
+		},
+		SCIONNetworkMetrics:    metrics.SCIONNetworkMetrics,
+		SCIONPacketConnMetrics: metrics.SCIONPacketConnMetrics,
+	}
+	quicStack, err := nc.QUICStack()
+	if err != nil {
+		return serrors.WrapStr("initializing QUIC stack", err)
+	}
+	defer quicStack.RedirectCloser()
+	tcpStack, err := nc.TCPStack()
+	if err != nil {
+		return serrors.WrapStr("initializing TCP stack", err)
+	}
+	dialer := &libgrpc.QUICDialer{
+		Rewriter: &onehop.AddressRewriter{
+			Rewriter: nc.AddressRewriter(nil),
+			MAC:      macGen(),
+		},
+		Dialer: quicStack.Dialer,
+	}
+
 	beaconDB, err := storage.NewBeaconStorage(globalCfg.BeaconDB, topo.IA())
 	if err != nil {
 		return serrors.WrapStr("initializing beacon storage", err)
@@ -231,14 +249,30 @@ func realMain(ctx context.Context) error {
 		QueriesTotal: libmetrics.NewPromCounter(metrics.BeaconDBQueriesTotal),
 	})
 
-	beaconStore, isdLoopAllowed, err := createBeaconStore(
+	statsEngine := &stats.Engine{}
+	beaconStore, err := createBeaconStore(
 		beaconDB,
 		topo.Core(),
 		globalCfg.BS.Policies,
+		statsEngine,
 	)
 	if err != nil {
 		return serrors.WrapStr("initializing beacon store", err)
 	}
+	policies := &cs.PolicyManager{
+		Core:           topo.Core(),
+		Files:          globalCfg.BS.Policies,
+		StaticInfoFile: globalCfg.General.StaticInfoConfig(),
+		Store:          beaconStore,
+		Reloads:        libmetrics.NewPromCounter(metrics.BeaconingPolicyReloadsTotal),
+	}
+	policies.Init()
+	policyReload := app.SIGHUPChannel(errCtx)
+	g.Go(func() error {
+		defer log.HandlePanic()
+		policies.Run(errCtx, policyReload)
+		return nil
+	})
 
 	trustengineCache := globalCfg.TrustEngine.Cache.New()
 	cacheHits := libmetrics.NewPromCounter(trustmetrics.CacheHitsTotal)
@@ -288,8 +322,30 @@ func realMain(ctx context.Context) error {
 		Router: segreq.NewRouter(fetcherCfg),
 	}
 
-	quicServer := grpc.NewServer(libgrpc.UnaryServerInterceptor())
-	tcpServer := grpc.NewServer(libgrpc.UnaryServerInterceptor())
+	rateLimiter := newRateLimiter(globalCfg.RateLimit,
+		libmetrics.NewPromCounter(metrics.GRPCThrottledRequestsTotal))
+	quicOpts := []grpc.ServerOption{
+		grpc.Creds(libgrpc.PeerCredentials{Verifier: tlsMgr}),
+		libgrpc.UnaryServerInterceptor(),
+	}
+	if !globalCfg.QUIC.AllowUnauthenticated {
+		authorizer := newAuthorizer(
+			libmetrics.NewPromCounter(metrics.GRPCUnauthenticatedRequestsTotal))
+		quicOpts = append(quicOpts,
+			authorizer.UnaryServerInterceptor(),
+			authorizer.StreamServerInterceptor(),
+		)
+	}
+	quicOpts = append(quicOpts,
+		rateLimiter.UnaryServerInterceptor(),
+		rateLimiter.StreamServerInterceptor(),
+	)
+	quicServer := grpc.NewServer(quicOpts...)
+	tcpServer := grpc.NewServer(
+		libgrpc.UnaryServerInterceptor(),
+		rateLimiter.UnaryServerInterceptor(),
+		rateLimiter.StreamServerInterceptor(),
+	)
 
 	// Register trust material related handlers.
 	trustServer := &cstrustgrpc.MaterialServer{
@@ -308,6 +364,7 @@ func realMain(ctx context.Context) error {
 			Interfaces:     intfs,
 			Verifier:       verifier,
 			BeaconsHandled: libmetrics.NewPromCounter(metrics.BeaconingReceivedTotal),
+			Stats:          statsEngine,
 		},
 	})
 
@@ -321,6 +378,17 @@ func realMain(ctx context.Context) error {
 		RevCache:     revCache,
 		Requests:     libmetrics.NewPromCounter(metrics.SegmentLookupRequestsTotal),
 		SegmentsSent: libmetrics.NewPromCounter(metrics.SegmentLookupSegmentsSentTotal),
+		Stats:        statsEngine,
+	}
+	var lookupCache *segreq.LookupCache
+	if cacheCfg := globalCfg.PS.LookupCache; !cacheCfg.Disable {
+		lookupCache = segreq.NewLookupCache(segreq.LookupCacheConfig{
+			Size:        cacheCfg.Size,
+			TTL:         cacheCfg.TTL.Duration,
+			NegativeTTL: cacheCfg.NegativeTTL.Duration,
+			RevCache:    revCache,
+			Lookups:     libmetrics.NewPromCounter(metrics.SegmentLookupCacheTotal),
+		})
 	}
 	forwardingLookupServer := &segreqgrpc.LookupServer{
 		Lookuper: segreq.ForwardingLookup{
@@ -333,10 +401,12 @@ func realMain(ctx context.Context) error {
 				Inspector: inspector,
 				PathDB:    pathDB,
 			},
+			Cache: lookupCache,
 		},
 		RevCache:     revCache,
 		Requests:     libmetrics.NewPromCounter(metrics.SegmentLookupRequestsTotal),
 		SegmentsSent: libmetrics.NewPromCounter(metrics.SegmentLookupSegmentsSentTotal),
+		Stats:        statsEngine,
 	}
 
 	// Always register a forwarding lookup for AS internal requests.
@@ -363,11 +433,6 @@ func realMain(ctx context.Context) error {
 
 	}
 
-	signer, err := cs.NewSigner(topo.IA(), trustDB, globalCfg.General.ConfigDir)
-	if err != nil {
-		return serrors.WrapStr("initializing AS signer", err)
-	}
-
 	var chainBuilder renewal.ChainBuilder
 	if globalCfg.CA.Mode != config.Disabled {
 		renewalGauges := libmetrics.NewPromGauge(metrics.RenewalRegisteredHandlers)
@@ -517,6 +582,9 @@ func realMain(ctx context.Context) error {
 		FetcherConfig:     fetcherCfg,
 		IntraASTCPServer:  tcpServer,
 		InterASQUICServer: quicServer,
+		GroupStore: hiddenpath.FileGroupStore{
+			Path: globalCfg.PS.HiddenPathsState,
+		},
 	}
 	hpWriterCfg, err := hpCfg.Setup(globalCfg.PS.HiddenPathsCfg)
 	if err != nil {
@@ -558,23 +626,42 @@ func realMain(ctx context.Context) error {
 			CPPKIServer: cppkiapi.Server{
 				TrustDB: trustDB,
 			},
-			Beacons:  beaconDB,
-			CA:       chainBuilder,
-			Config:   service.NewConfigStatusPage(globalCfg).Handler,
-			Info:     service.NewInfoStatusPage().Handler,
-			LogLevel: service.NewLogLevelStatusPage().Handler,
-			Signer:   signer,
-			Topology: topo.HandleHTTP,
+			Beacons:        beaconDB,
+			CA:             chainBuilder,
+			Config:         service.NewConfigStatusPage(globalCfg).Handler,
+			Info:           service.NewInfoStatusPage().Handler,
+			LogLevel:       service.NewLogLevelStatusPage().Handler,
+			Signer:         signer,
+			Topology:       topo.HandleHTTP,
+			Segments:       pathDB,
+			Interfaces:     intfs,
+			BeaconPolicies: beaconStore,
+			Policies:       policyReloader{PolicyManager: policies},
+			Statistics:     statsEngine,
+			TrustDB:        trustDB,
 			Healther: &healther{
 				Signer:  signer,
 				TrustDB: trustDB,
 				ISD:     topo.IA().I,
 			},
 		}
-		log.Info("Exposing API", "addr", globalCfg.API.Addr)
+		var verifier *jwtauth.HTTPVerifier
+		if globalCfg.Management.SharedSecret != "" {
+			sharedSecret := caconfig.NewPEMSymmetricKey(globalCfg.Management.SharedSecret)
+			verifier = &jwtauth.HTTPVerifier{
+				Generator: sharedSecret.Get,
+				Logger:    log.New("component", "management_api"),
+			}
+		}
+		log.Info("Exposing API", "addr", globalCfg.API.Addr,
+			"management_enabled", verifier != nil)
 		s := http.Server{
-			Addr:    globalCfg.API.Addr,
-			Handler: api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1"),
+			Addr: globalCfg.API.Addr,
+			Handler: api.ManagementHandler(
+				api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1"),
+				"/api/v1",
+				verifier,
+			),
 		}
 		g.Go(func() error {
 			defer log.HandlePanic()
@@ -596,11 +683,6 @@ func realMain(ctx context.Context) error {
 		return err
 	}
 
-	staticInfo, err := beaconing.ParseStaticInfoCfg(globalCfg.General.StaticInfoConfig())
-	if err != nil {
-		log.Info("No static info file found. Static info settings disabled.", "err", err)
-	}
-
 	var propagationFilter func(intf *ifstate.Interface) bool
 	if topo.Core() {
 		propagationFilter = func(intf *ifstate.Interface) bool {
@@ -644,13 +726,14 @@ func realMain(ctx context.Context) error {
 		Metrics:         metrics,
 		MACGen:          macGen,
 		NextHopper:      topo,
-		StaticInfo:      func() *beaconing.StaticInfoCfg { return staticInfo },
+		StaticInfo:      policies.StaticInfo,
 
 		OriginationInterval:       globalCfg.BS.OriginationInterval.Duration,
 		PropagationInterval:       globalCfg.BS.PropagationInterval.Duration,
 		RegistrationInterval:      globalCfg.BS.RegistrationInterval.Duration,
 		HiddenPathRegistrationCfg: hpWriterCfg,
-		AllowIsdLoop:              isdLoopAllowed,
+		AllowIsdLoop:              policies.AllowIsdLoop,
+		Stats:                     statsEngine,
 	})
 	if err != nil {
 		return serrors.WrapStr("starting periodic tasks", err)
@@ -676,22 +759,72 @@ func createBeaconStore(
 	db storage.BeaconDB,
 	core bool,
 	policyConfig config.Policies,
-) (cs.Store, bool, error) {
+	selection beacon.SelectionRecorder,
+) (cs.Store, error) {
 
 	if core {
 		policies, err := cs.LoadCorePolicies(policyConfig)
 		if err != nil {
-			return nil, false, err
+			return nil, err
 		}
 		store, err := beacon.NewCoreBeaconStore(policies, db)
-		return store, *policies.Prop.Filter.AllowIsdLoop, err
+		if err != nil {
+			return nil, err
+		}
+		store.SetSelectionRecorder(selection)
+		return store, nil
 	}
 	policies, err := cs.LoadNonCorePolicies(policyConfig)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 	store, err := beacon.NewBeaconStore(policies, db)
-	return store, *policies.Prop.Filter.AllowIsdLoop, err
+	if err != nil {
+		return nil, err
+	}
+	store.SetSelectionRecorder(selection)
+	return store, nil
+}
+
+func newRateLimiter(cfg config.RateLimit, throttled libmetrics.Counter) *libgrpc.RateLimiter {
+	limits := func(e config.RateLimitEntry) libgrpc.Limits {
+		return libgrpc.Limits{
+			PeerRate:      e.PeerRate,
+			PeerBurst:     e.PeerBurst,
+			MaxConcurrent: e.MaxConcurrent,
+			MaxQueued:     e.MaxQueued,
+		}
+	}
+	methods := make(map[string]libgrpc.Limits, len(cfg.Methods))
+	for method, e := range cfg.Methods {
+		methods[method] = limits(e)
+	}
+	return &libgrpc.RateLimiter{
+		Default:   limits(cfg.Default),
+		Methods:   methods,
+		Throttled: throttled,
+	}
+}
+
+// newAuthorizer returns the authorizer of the inter-AS RPCs. Peers that cannot
+// be authenticated, e.g., gateways without an AS certificate chain, can only
+// discover the services of the AS. Peers of ISDs for which no TRC is available
+// can additionally fetch the trust material and exchange segments. The
+// handlers of these RPCs verify the segments with the fetched TRCs.
+func newAuthorizer(rejected libmetrics.Counter) *libgrpc.Authorizer {
+	return &libgrpc.Authorizer{
+		Unauthenticated: map[string]bool{
+			"/proto.discovery.v1.DiscoveryService/Gateways":              true,
+			"/proto.discovery.v1.DiscoveryService/HiddenSegmentServices": true,
+		},
+		Bootstrap: map[string]bool{
+			"/proto.control_plane.v1.TrustMaterialService/Chains":   true,
+			"/proto.control_plane.v1.TrustMaterialService/TRC":      true,
+			"/proto.control_plane.v1.SegmentCreationService/Beacon": true,
+			"/proto.control_plane.v1.SegmentLookupService/Segments": true,
+		},
+		Rejected: rejected,
+	}
 }
 
 func adaptInterfaceMap(in map[common.IFIDType]topology.IFInfo) map[uint16]ifstate.InterfaceInfo {
@@ -754,3 +887,30 @@ func (h *healther) GetTRCHealth(ctx context.Context) api.TRCHealthData {
 		TRCID: trc.TRC.ID,
 	}
 }
+
+type policyReloader struct {
+	*cs.PolicyManager
+}
+
+func (p policyReloader) Status() (api.PolicyStatusData, error) {
+	status, err := p.PolicyManager.Status()
+	if err != nil {
+		return api.PolicyStatusData{}, err
+	}
+	data := api.PolicyStatusData{
+		Digest:   status.Digest,
+		LoadedAt: status.LoadedAt,
+	}
+	for _, policy := range status.Policies {
+		data.Policies = append(data.Policies, api.PolicyData{
+			Type:   policy.Type,
+			File:   policy.File,
+			Digest: policy.Digest,
+		})
+	}
+	if status.StaticInfo != nil {
+		data.StaticInfoFile = status.StaticInfo.File
+		data.StaticInfoDigest = status.StaticInfo.Digest
+	}
+	return data, nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreq
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/lib/revcache"
+)
+
+// Results of the lookup cache for the metrics.
+const (
+	cacheHit         = "hit"
+	cacheNegativeHit = "negative_hit"
+	cacheMiss        = "miss"
+	cacheCoalesced   = "coalesced"
+	cacheRevoked     = "revoked"
+)
+
+// DefaultFetchTimeout is the default timeout of the fetch that is shared by
+// concurrent lookups.
+const DefaultFetchTimeout = 10 * time.Second
+
+// LookupCache caches the results of segment lookups, keyed by the source,
+// destination and segment type of the lookup. Results with segments are cached
+// for TTL, and are invalidated if one of the segments contains a revoked
+// interface. Failed lookups and lookups without segments are cached for
+// NegativeTTL. Concurrent lookups for the same key share one fetch. The shared
+// fetch is not bound to the context of any of the callers, it runs until it
+// completes or the fetch timeout expires.
+//
+// The zero value is not usable, use NewLookupCache to create a cache.
+type LookupCache struct {
+	size        int
+	ttl         time.Duration
+	negativeTTL time.Duration
+	revCache    revcache.RevCache
+	// fetchTimeout is the timeout of the shared fetch.
+	fetchTimeout time.Duration
+	// lookups counts the lookups by cache result. If nil, nothing is reported.
+	lookups metrics.Counter
+	// now returns the current time. It is only overwritten in tests.
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	// lru keeps the entries ordered by last use, the most recently used entry
+	// is at the front.
+	lru    list.List
+	flight singleflight.Group
+}
+
+// LookupCacheConfig is the configuration of the lookup cache.
+type LookupCacheConfig struct {
+	// Size is the maximum number of cached lookup results. It must be
+	// positive.
+	Size int
+	// TTL is the duration for which lookup results with segments are cached.
+	TTL time.Duration
+	// NegativeTTL is the duration for which failed lookups and lookups without
+	// segments are cached.
+	NegativeTTL time.Duration
+	// RevCache is used to invalidate cached segments that contain revoked
+	// interfaces. If it is nil, cached segments are not invalidated.
+	RevCache revcache.RevCache
+	// FetchTimeout is the timeout of the fetch that is shared by concurrent
+	// lookups. If it is zero, DefaultFetchTimeout is used.
+	FetchTimeout time.Duration
+	// Lookups counts the lookups by cache result. If it is nil, nothing is
+	// reported.
+	Lookups metrics.Counter
+}
+
+// NewLookupCache creates a new lookup cache.
+func NewLookupCache(cfg LookupCacheConfig) *LookupCache {
+	fetchTimeout := cfg.FetchTimeout
+	if fetchTimeout == 0 {
+		fetchTimeout = DefaultFetchTimeout
+	}
+	return &LookupCache{
+		size:         cfg.Size,
+		ttl:          cfg.TTL,
+		negativeTTL:  cfg.NegativeTTL,
+		revCache:     cfg.RevCache,
+		fetchTimeout: fetchTimeout,
+		lookups:      cfg.Lookups,
+		now:          time.Now,
+		entries:      make(map[cacheKey]*list.Element),
+	}
+}
+
+type cacheKey struct {
+	src     addr.IA
+	dst     addr.IA
+	segType seg.Type
+}
+
+func (k cacheKey) String() string {
+	return fmt.Sprintf("%s %s %s", k.src, k.dst, k.segType)
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	segs    segfetcher.Segments
+	err     error
+	expires time.Time
+}
+
+// Lookup returns the cached result for the lookup from src to dst of the
+// given segment type. On a cache miss, fetch is called to look up the
+// segments, and the result is cached. Concurrent misses for the same lookup
+// share the result of one call to fetch. The context passed to fetch is
+// detached from ctx, such that a caller that gives up does not abort the
+// lookup of the others. If ctx is done before the result is available, Lookup
+// returns early, and the result is still cached once fetch returns.
+func (c *LookupCache) Lookup(ctx context.Context, src, dst addr.IA, segType seg.Type,
+	fetch func(context.Context) (segfetcher.Segments, error)) (segfetcher.Segments, error) {
+
+	key := cacheKey{src: src, dst: dst, segType: segType}
+	if e, ok := c.get(key); ok {
+		if c.revoked(ctx, e.segs) {
+			c.remove(key)
+			c.inc(cacheRevoked)
+		} else {
+			if e.err != nil || len(e.segs) == 0 {
+				c.inc(cacheNegativeHit)
+			} else {
+				c.inc(cacheHit)
+			}
+			return e.segs, e.err
+		}
+	}
+	executed := false
+	logger := log.FromCtx(ctx)
+	ch := c.flight.DoChan(key.String(), func() (interface{}, error) {
+		executed = true
+		fetchCtx, cancel := context.WithTimeout(
+			log.CtxWith(context.Background(), logger), c.fetchTimeout)
+		defer cancel()
+		segs, err := fetch(fetchCtx)
+		c.add(key, segs, err)
+		return segs, err
+	})
+	select {
+	case r := <-ch:
+		if executed {
+			c.inc(cacheMiss)
+		} else {
+			c.inc(cacheCoalesced)
+		}
+		segs, _ := r.Val.(segfetcher.Segments)
+		return segs, r.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Len returns the number of cached lookup results.
+func (c *LookupCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *LookupCache) get(key cacheKey) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*cacheEntry)
+	if !c.now().Before(e.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return e, true
+}
+
+func (c *LookupCache) add(key cacheKey, segs segfetcher.Segments, err error) {
+	ttl := c.ttl
+	if err != nil || len(segs) == 0 {
+		ttl = c.negativeTTL
+	}
+	e := &cacheEntry{key: key, segs: segs, err: err, expires: c.now().Add(ttl)}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = e
+		c.lru.MoveToFront(elem)
+		return
+	}
+	for len(c.entries) >= c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	c.entries[key] = c.lru.PushFront(e)
+}
+
+func (c *LookupCache) remove(key cacheKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// revoked checks whether any of the segments contains a revoked interface.
+func (c *LookupCache) revoked(ctx context.Context, segs segfetcher.Segments) bool {
+	if c.revCache == nil {
+		return false
+	}
+	for _, s := range segs {
+		ok, err := revcache.NoRevokedHopIntf(ctx, c.revCache, s.Segment)
+		if err != nil {
+			log.FromCtx(ctx).Info("Failed to check revocations of cached segment", "err", err)
+			return true
+		}
+		if !ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *LookupCache) inc(result string) {
+	if c.lookups != nil {
+		c.lookups.With(prom.LabelResult, result).Add(1)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreq
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/revcache/mock_revcache"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+)
+
+func TestLookupCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	downSegs := segfetcher.Segments{{
+		Segment: g.Beacon([]uint16{graph.If_120_X_111_B}),
+		Type:    seg.TypeDown,
+	}}
+
+	newCache := func(now *time.Time, revCache revcache.RevCache) *LookupCache {
+		c := NewLookupCache(LookupCacheConfig{
+			Size:        2,
+			TTL:         10 * time.Second,
+			NegativeTTL: time.Second,
+			RevCache:    revCache,
+		})
+		c.now = func() time.Time { return *now }
+		return c
+	}
+	fetcher := func(segs segfetcher.Segments, err error) (func(context.Context) (
+		segfetcher.Segments, error), *int32) {
+
+		var calls int32
+		return func(context.Context) (segfetcher.Segments, error) {
+			atomic.AddInt32(&calls, 1)
+			return segs, err
+		}, &calls
+	}
+
+	t.Run("positive results are cached", func(t *testing.T) {
+		now := time.Now()
+		c := newCache(&now, nil)
+		fetch, calls := fetcher(downSegs, nil)
+		for i := 0; i < 3; i++ {
+			segs, err := c.Lookup(context.Background(), core120, nonCore111, seg.TypeDown, fetch)
+			require.NoError(t, err)
+			assert.Equal(t, downSegs, segs)
+		}
+		assert.EqualValues(t, 1, *calls)
+
+		// Other segment types are cached separately.
+		_, err := c.Lookup(context.Background(), core120, nonCore111, seg.TypeCore, fetch)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, *calls)
+
+		now = now.Add(10 * time.Second)
+		_, err = c.Lookup(context.Background(), core120, nonCore111, seg.TypeDown, fetch)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, *calls)
+	})
+	t.Run("negative results are cached shortly", func(t *testing.T) {
+		now := time.Now()
+		c := newCache(&now, nil)
+		fetch, calls := fetcher(nil, serrors.New("unreachable"))
+		for i := 0; i < 3; i++ {
+			_, err := c.Lookup(context.Background(), core120, nonCore112, seg.TypeDown, fetch)
+			assert.Error(t, err)
+		}
+		assert.EqualValues(t, 1, *calls)
+
+		now = now.Add(time.Second)
+		_, err := c.Lookup(context.Background(), core120, nonCore112, seg.TypeDown, fetch)
+		assert.Error(t, err)
+		assert.EqualValues(t, 2, *calls)
+	})
+	t.Run("revoked segments are invalidated", func(t *testing.T) {
+		now := time.Now()
+		revCache := mock_revcache.NewMockRevCache(ctrl)
+		c := newCache(&now, revCache)
+		fetch, calls := fetcher(downSegs, nil)
+		_, err := c.Lookup(context.Background(), core120, nonCore111, seg.TypeDown, fetch)
+		require.NoError(t, err)
+
+		revCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(revcache.Revocations{
+			revcache.Key{IA: core120, IfId: 1}: &path_mgmt.RevInfo{},
+		}, nil)
+		_, err = c.Lookup(context.Background(), core120, nonCore111, seg.TypeDown, fetch)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, *calls)
+	})
+	t.Run("least recently used entry is evicted", func(t *testing.T) {
+		now := time.Now()
+		c := newCache(&now, nil)
+		fetch, calls := fetcher(downSegs, nil)
+		ctx := context.Background()
+		c.Lookup(ctx, core120, nonCore111, seg.TypeDown, fetch)
+		c.Lookup(ctx, core120, nonCore112, seg.TypeDown, fetch)
+		c.Lookup(ctx, core120, nonCore111, seg.TypeDown, fetch)
+		c.Lookup(ctx, core110, nonCore111, seg.TypeDown, fetch)
+		assert.EqualValues(t, 3, *calls)
+		assert.Equal(t, 2, c.Len())
+
+		c.Lookup(ctx, core120, nonCore111, seg.TypeDown, fetch)
+		assert.EqualValues(t, 3, *calls)
+		c.Lookup(ctx, core120, nonCore112, seg.TypeDown, fetch)
+		assert.EqualValues(t, 4, *calls)
+	})
+	t.Run("concurrent lookups are coalesced", func(t *testing.T) {
+		now := time.Now()
+		c := newCache(&now, nil)
+		release := make(chan struct{})
+		var calls int32
+		fetch := func(context.Context) (segfetcher.Segments, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return downSegs, nil
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				segs, err := c.Lookup(context.Background(), core120, nonCore111,
+					seg.TypeDown, fetch)
+				assert.NoError(t, err)
+				assert.Equal(t, downSegs, segs)
+			}()
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+	t.Run("canceled caller does not abort shared fetch", func(t *testing.T) {
+		now := time.Now()
+		c := newCache(&now, nil)
+		started, release := make(chan struct{}), make(chan struct{})
+		var calls int32
+		fetch := func(ctx context.Context) (segfetcher.Segments, error) {
+			atomic.AddInt32(&calls, 1)
+			close(started)
+			select {
+			case <-release:
+				return downSegs, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		first := make(chan error, 1)
+		go func() {
+			_, err := c.Lookup(ctx, core120, nonCore111, seg.TypeDown, fetch)
+			first <- err
+		}()
+		<-started
+		second := make(chan error, 1)
+		go func() {
+			segs, err := c.Lookup(context.Background(), core120, nonCore111,
+				seg.TypeDown, fetch)
+			assert.Equal(t, downSegs, segs)
+			second <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		assert.ErrorIs(t, <-first, context.Canceled)
+		close(release)
+		assert.NoError(t, <-second)
+
+		segs, err := c.Lookup(context.Background(), core120, nonCore111, seg.TypeDown, fetch)
+		require.NoError(t, err)
+		assert.Equal(t, downSegs, segs)
+		assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+	t.Run("shared fetch times out", func(t *testing.T) {
+		now := time.Now()
+		c := NewLookupCache(LookupCacheConfig{
+			Size:         2,
+			NegativeTTL:  time.Second,
+			FetchTimeout: 10 * time.Millisecond,
+		})
+		c.now = func() time.Time { return now }
+		fetch := func(ctx context.Context) (segfetcher.Segments, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		_, err := c.Lookup(context.Background(), core120, nonCore111, seg.TypeDown, fetch)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
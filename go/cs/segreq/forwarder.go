@@ -31,6 +31,9 @@ type ForwardingLookup struct {
 	CoreChecker CoreChecker
 	Fetcher     *segfetcher.Fetcher
 	Expander    WildcardExpander
+	// Cache caches the lookup results. If it is nil, every lookup is resolved
+	// with the fetcher.
+	Cache *LookupCache
 }
 
 // LookupSegments looks up the segments for the given request
@@ -45,6 +48,18 @@ func (f ForwardingLookup) LookupSegments(ctx context.Context, src,
 	if err != nil {
 		return nil, err
 	}
+	if f.Cache == nil {
+		return f.fetch(ctx, src, dst, segType)
+	}
+	return f.Cache.Lookup(ctx, src, dst, segType,
+		func(ctx context.Context) (segfetcher.Segments, error) {
+			return f.fetch(ctx, src, dst, segType)
+		},
+	)
+}
+
+func (f ForwardingLookup) fetch(ctx context.Context, src, dst addr.IA,
+	segType seg.Type) (segfetcher.Segments, error) {
 
 	reqs, err := f.Expander.ExpandSrcWildcard(ctx,
 		segfetcher.Request{
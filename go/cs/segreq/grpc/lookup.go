@@ -27,6 +27,7 @@ import (
 	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/tracing"
+	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
 	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
 )
 
@@ -35,6 +36,13 @@ type Lookuper interface {
 	LookupSegments(ctx context.Context, src, dst addr.IA) (segfetcher.Segments, error)
 }
 
+// LookupRecorder records per-requester lookup statistics.
+type LookupRecorder interface {
+	// RecordLookup records a lookup with its result and the number of returned
+	// segments.
+	RecordLookup(requester, result string, segments int)
+}
+
 // LookupServer handles path segment lookups.
 type LookupServer struct {
 	Lookuper Lookuper
@@ -46,6 +54,8 @@ type LookupServer struct {
 	// SegmentsSent aggregates the number of segments that were transmitted in
 	// response to a segment request.
 	SegmentsSent metrics.Counter
+	// Stats records the lookups by requester. If nil, nothing is recorded.
+	Stats LookupRecorder
 }
 
 func (s LookupServer) Segments(ctx context.Context,
@@ -67,6 +77,7 @@ func (s LookupServer) Segments(ctx context.Context,
 		logger.Debug("Failed to lookup requested segments", "err", err)
 		s.updateMetric(span, labels.WithResult(segfetcher.ErrToMetricsLabel(err)), err)
 		if len(segs) == 0 {
+			s.recordLookup(ctx, labels.Result, 0)
 			// TODO(roosd): Differentiate errors and expose the applicable gRPC
 			// status codes.
 			return nil, err
@@ -77,6 +88,7 @@ func (s LookupServer) Segments(ctx context.Context,
 	labels.Desc.SegType = determineReplyType(segs)
 	if span != nil {
 		span.SetTag("seg_type", labels.Desc.SegType)
+		tracing.SegmentIDs(span, segIDs(segs)...)
 	}
 
 	m := map[int32]*cppb.SegmentsResponse_Segments{}
@@ -92,6 +104,7 @@ func (s LookupServer) Segments(ctx context.Context,
 	logger.Debug("Replied with segments", "count", len(segs))
 	s.updateMetric(span, labels.WithResult(prom.Success), nil)
 	s.incSent(s.SegmentsSent, labels.Desc, len(segs))
+	s.recordLookup(ctx, prom.Success, len(segs))
 	return &cppb.SegmentsResponse{
 		Segments: m,
 	}, nil
@@ -107,6 +120,12 @@ func (s LookupServer) updateMetric(span opentracing.Span, l requestLabels, err e
 	}
 }
 
+func (s LookupServer) recordLookup(ctx context.Context, result string, segments int) {
+	if s.Stats != nil {
+		s.Stats.RecordLookup(libgrpc.PeerKey(ctx), result, segments)
+	}
+}
+
 func (s LookupServer) incSent(c metrics.Counter, labels descLabels, inc int) {
 	if c != nil {
 		c.With(labels.Expand()...).Add(float64(inc))
@@ -117,7 +136,17 @@ func setQueryTags(span opentracing.Span, src, dst addr.IA) {
 	if span != nil {
 		span.SetTag("query.src", src)
 		span.SetTag("query.dst", dst)
+		tracing.SrcIA(span, src)
+		tracing.DstIA(span, dst)
+	}
+}
+
+func segIDs(segs segfetcher.Segments) [][]byte {
+	ids := make([][]byte, 0, len(segs))
+	for _, meta := range segs {
+		ids = append(ids, meta.Segment.ID())
 	}
+	return ids
 }
 
 type requestLabels struct {
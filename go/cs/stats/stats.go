@@ -0,0 +1,385 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stats keeps rolling statistics about the beacons that the control
+// service receives and about the segment lookups that it serves.
+//
+// The statistics cover a sliding window. The window is split into a fixed
+// number of slots, and the oldest slot is discarded when a new one starts.
+// Thus, the statistics cover at least the window duration minus the duration
+// of a slot.
+package stats
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/lib/addr"
+)
+
+const (
+	// DefaultWindow is the default duration covered by the statistics.
+	DefaultWindow = time.Hour
+	// OtherRequester aggregates the lookups of requesters that exceed the
+	// tracking limit.
+	OtherRequester = "other"
+
+	numSlots = 12
+	// maxRequesters is the maximum number of requesters that are tracked per
+	// slot.
+	maxRequesters = 1000
+	// maxBeaconKeys is the maximum number of beacon keys that are tracked per
+	// slot.
+	maxBeaconKeys = 1000
+)
+
+// AgeBuckets are the upper bounds of the buckets of the beacon age
+// distribution. The age is measured at reception. A last bucket without upper
+// bound follows.
+var AgeBuckets = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+}
+
+// BeaconKey identifies the beacons with the same origin AS that are received
+// on the same interface. The origin is taken from the beacon as received, i.e.,
+// before verification. Beacons that exceed the tracking limit are aggregated
+// under the zero key.
+type BeaconKey struct {
+	// Origin is the AS that originated the beacons.
+	Origin addr.IA
+	// Ingress is the interface the beacons are received on.
+	Ingress uint16
+}
+
+// BeaconStats are the statistics of the beacons with the same key.
+type BeaconStats struct {
+	BeaconKey
+	// Received is the number of received beacons.
+	Received uint64
+	// Results counts the received beacons by the result of handling them,
+	// e.g., ok_new or err_verify.
+	Results map[string]uint64
+	// FilterReasons counts the received beacons that were filtered by the
+	// beacon policies by reason, see beacon.FilterReason.
+	FilterReasons map[string]uint64
+	// Ages is the distribution of the beacon age at reception. It has one
+	// entry per bucket in AgeBuckets, and one for older beacons.
+	Ages []uint64
+	// LastReceived is the time the last beacon was received.
+	LastReceived time.Time
+	// Newest is the creation time of the newest received beacon.
+	Newest time.Time
+	// LoopDrops is the number of times a beacon was not propagated on an
+	// egress interface, because it would have created a loop.
+	LoopDrops uint64
+	// Selection counts the selection outcomes by policy type.
+	Selection map[beacon.PolicyType]SelectionStats
+}
+
+// SelectionStats are the outcomes of the beacon selection for one policy.
+type SelectionStats struct {
+	// Candidates is the number of times a beacon was a selection candidate.
+	Candidates uint64
+	// Selected is the number of times a beacon was selected.
+	Selected uint64
+}
+
+// LookupStats are the statistics of the segment lookups of one requester.
+type LookupStats struct {
+	// Requester identifies the requester. It is the ISD-AS of authenticated
+	// requesters, and the address otherwise.
+	Requester string
+	// Requests is the number of lookups.
+	Requests uint64
+	// Results counts the lookups by result, e.g., ok_success.
+	Results map[string]uint64
+	// Segments is the number of segments that were returned.
+	Segments uint64
+	// LastRequest is the time of the last lookup.
+	LastRequest time.Time
+}
+
+// Engine keeps the rolling statistics. It is safe for concurrent use.
+type Engine struct {
+	// Window is the duration covered by the statistics. If zero,
+	// DefaultWindow is used.
+	Window time.Duration
+
+	mu    sync.Mutex
+	slots [numSlots]slot
+	// now returns the current time. If nil, time.Now is used.
+	now func() time.Time
+}
+
+type slot struct {
+	start   time.Time
+	beacons map[BeaconKey]*BeaconStats
+	lookups map[string]*LookupStats
+}
+
+// RecordBeacon records a received beacon with the result of handling it. The
+// reason is the filter reason of beacons that were filtered by the policies,
+// and empty otherwise.
+func (e *Engine) RecordBeacon(b beacon.Beacon, result, reason string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.clock()
+	s := e.beaconStats(now, b)
+	s.Received++
+	s.Results[result]++
+	if reason != "" {
+		s.FilterReasons[reason]++
+	}
+	s.LastReceived = now
+	if b.Segment == nil {
+		return
+	}
+	created := b.Segment.Info.Timestamp
+	if created.After(s.Newest) {
+		s.Newest = created
+	}
+	age := now.Sub(created)
+	i := sort.Search(len(AgeBuckets), func(i int) bool { return age <= AgeBuckets[i] })
+	s.Ages[i]++
+}
+
+// RecordLoopDrop records that the beacon was not propagated on an egress
+// interface, because it would have created a loop.
+func (e *Engine) RecordLoopDrop(b beacon.Beacon) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.beaconStats(e.clock(), b).LoopDrops++
+}
+
+// RecordSelection records the outcome of the beacon selection. It implements
+// beacon.SelectionRecorder.
+func (e *Engine) RecordSelection(policy beacon.PolicyType,
+	candidates, selected []beacon.Beacon) {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.clock()
+	for _, b := range candidates {
+		s := e.beaconStats(now, b)
+		sel := s.Selection[policy]
+		sel.Candidates++
+		s.Selection[policy] = sel
+	}
+	for _, b := range selected {
+		s := e.beaconStats(now, b)
+		sel := s.Selection[policy]
+		sel.Selected++
+		s.Selection[policy] = sel
+	}
+}
+
+// RecordLookup records a segment lookup with its result and the number of
+// returned segments.
+func (e *Engine) RecordLookup(requester, result string, segments int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.clock()
+	cur := e.slot(now)
+	s, ok := cur.lookups[requester]
+	if !ok {
+		if len(cur.lookups) >= maxRequesters {
+			requester = OtherRequester
+			s, ok = cur.lookups[requester]
+		}
+		if !ok {
+			s = &LookupStats{Requester: requester, Results: map[string]uint64{}}
+			cur.lookups[requester] = s
+		}
+	}
+	s.Requests++
+	s.Results[result]++
+	s.Segments += uint64(segments)
+	s.LastRequest = now
+}
+
+// Beacons returns the beacon statistics in the window, sorted by origin and
+// ingress interface.
+func (e *Engine) Beacons() []BeaconStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	merged := make(map[BeaconKey]*BeaconStats)
+	for _, cur := range e.active(e.clock()) {
+		for key, s := range cur.beacons {
+			m, ok := merged[key]
+			if !ok {
+				m = newBeaconStats(key)
+				merged[key] = m
+			}
+			m.merge(s)
+		}
+	}
+	result := make([]BeaconStats, 0, len(merged))
+	for _, s := range merged {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Origin.Equal(result[j].Origin) {
+			return result[i].Origin.IAInt() < result[j].Origin.IAInt()
+		}
+		return result[i].Ingress < result[j].Ingress
+	})
+	return result
+}
+
+// Lookups returns the segment lookup statistics in the window, sorted by
+// requester.
+func (e *Engine) Lookups() []LookupStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	merged := make(map[string]*LookupStats)
+	for _, cur := range e.active(e.clock()) {
+		for requester, s := range cur.lookups {
+			m, ok := merged[requester]
+			if !ok {
+				m = &LookupStats{Requester: requester, Results: map[string]uint64{}}
+				merged[requester] = m
+			}
+			m.Requests += s.Requests
+			m.Segments += s.Segments
+			for result, c := range s.Results {
+				m.Results[result] += c
+			}
+			if s.LastRequest.After(m.LastRequest) {
+				m.LastRequest = s.LastRequest
+			}
+		}
+	}
+	result := make([]LookupStats, 0, len(merged))
+	for _, s := range merged {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Requester < result[j].Requester
+	})
+	return result
+}
+
+// WindowDuration returns the duration covered by the statistics.
+func (e *Engine) WindowDuration() time.Duration {
+	return e.slotDuration() * numSlots
+}
+
+func (e *Engine) beaconStats(now time.Time, b beacon.Beacon) *BeaconStats {
+	key := BeaconKey{Ingress: b.InIfId}
+	if b.Segment != nil {
+		key.Origin = b.Segment.FirstIA()
+	}
+	cur := e.slot(now)
+	s, ok := cur.beacons[key]
+	if !ok {
+		if len(cur.beacons) >= maxBeaconKeys {
+			key = BeaconKey{}
+			s, ok = cur.beacons[key]
+		}
+		if !ok {
+			s = newBeaconStats(key)
+			cur.beacons[key] = s
+		}
+	}
+	return s
+}
+
+// slot returns the slot for the current time. Stale slots are reset.
+func (e *Engine) slot(now time.Time) *slot {
+	d := e.slotDuration()
+	start := now.Truncate(d)
+	cur := &e.slots[(start.UnixNano()/int64(d))%numSlots]
+	if !cur.start.Equal(start) {
+		*cur = slot{
+			start:   start,
+			beacons: make(map[BeaconKey]*BeaconStats),
+			lookups: make(map[string]*LookupStats),
+		}
+	}
+	return cur
+}
+
+// active returns the slots that are in the window.
+func (e *Engine) active(now time.Time) []*slot {
+	d := e.slotDuration()
+	oldest := now.Truncate(d).Add(-time.Duration(numSlots-1) * d)
+	var slots []*slot
+	for i := range e.slots {
+		if !e.slots[i].start.IsZero() && !e.slots[i].start.Before(oldest) {
+			slots = append(slots, &e.slots[i])
+		}
+	}
+	return slots
+}
+
+func (e *Engine) slotDuration() time.Duration {
+	window := e.Window
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return window / numSlots
+}
+
+func (e *Engine) clock() time.Time {
+	if e.now != nil {
+		return e.now()
+	}
+	return time.Now()
+}
+
+func newBeaconStats(key BeaconKey) *BeaconStats {
+	return &BeaconStats{
+		BeaconKey:     key,
+		Results:       map[string]uint64{},
+		FilterReasons: map[string]uint64{},
+		Ages:          make([]uint64, len(AgeBuckets)+1),
+		Selection:     map[beacon.PolicyType]SelectionStats{},
+	}
+}
+
+func (s *BeaconStats) merge(other *BeaconStats) {
+	s.Received += other.Received
+	for result, c := range other.Results {
+		s.Results[result] += c
+	}
+	for reason, c := range other.FilterReasons {
+		s.FilterReasons[reason] += c
+	}
+	for i, c := range other.Ages {
+		s.Ages[i] += c
+	}
+	if other.LastReceived.After(s.LastReceived) {
+		s.LastReceived = other.LastReceived
+	}
+	if other.Newest.After(s.Newest) {
+		s.Newest = other.Newest
+	}
+	s.LoopDrops += other.LoopDrops
+	for policy, sel := range other.Selection {
+		m := s.Selection[policy]
+		m.Candidates += sel.Candidates
+		m.Selected += sel.Selected
+		s.Selection[policy] = m
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestEngineBeacons(t *testing.T) {
+	now := time.Date(2021, 11, 25, 12, 0, 0, 0, time.UTC)
+	e := &Engine{now: func() time.Time { return now }}
+	ia110, ia120 := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:120")
+	fresh := newBeacon(ia110, 1, now.Add(-30*time.Second))
+	old := newBeacon(ia110, 1, now.Add(-2*time.Hour))
+	other := newBeacon(ia120, 2, now.Add(-10*time.Minute))
+
+	e.RecordBeacon(fresh, "ok_new", "")
+	e.RecordBeacon(old, "err_prefilter", beacon.FilterReason(beacon.ErrISDLoop))
+	e.RecordBeacon(other, "ok_new", "")
+	e.RecordLoopDrop(other)
+	e.RecordSelection(beacon.PropPolicy, []beacon.Beacon{fresh, other}, []beacon.Beacon{other})
+
+	stats := e.Beacons()
+	require.Len(t, stats, 2)
+	assert.Equal(t, BeaconStats{
+		BeaconKey:     BeaconKey{Origin: ia110, Ingress: 1},
+		Received:      2,
+		Results:       map[string]uint64{"ok_new": 1, "err_prefilter": 1},
+		FilterReasons: map[string]uint64{"isd_loop": 1},
+		Ages:          []uint64{1, 0, 0, 0, 1, 0},
+		LastReceived:  now,
+		Newest:        now.Add(-30 * time.Second),
+		Selection: map[beacon.PolicyType]SelectionStats{
+			beacon.PropPolicy: {Candidates: 1},
+		},
+	}, stats[0])
+	assert.Equal(t, BeaconStats{
+		BeaconKey:     BeaconKey{Origin: ia120, Ingress: 2},
+		Received:      1,
+		Results:       map[string]uint64{"ok_new": 1},
+		FilterReasons: map[string]uint64{},
+		Ages:          []uint64{0, 0, 1, 0, 0, 0},
+		LastReceived:  now,
+		Newest:        now.Add(-10 * time.Minute),
+		LoopDrops:     1,
+		Selection: map[beacon.PolicyType]SelectionStats{
+			beacon.PropPolicy: {Candidates: 1, Selected: 1},
+		},
+	}, stats[1])
+
+	// Statistics are merged across slots and expire with the window.
+	now = now.Add(10 * time.Minute)
+	e.RecordBeacon(fresh, "ok_old", "")
+	stats = e.Beacons()
+	require.Len(t, stats, 2)
+	assert.Equal(t, uint64(3), stats[0].Received)
+	assert.Equal(t, now, stats[0].LastReceived)
+
+	now = now.Add(55 * time.Minute)
+	stats = e.Beacons()
+	require.Len(t, stats, 1)
+	assert.Equal(t, uint64(1), stats[0].Received)
+	assert.Equal(t, map[string]uint64{"ok_old": 1}, stats[0].Results)
+
+	now = now.Add(time.Hour)
+	assert.Empty(t, e.Beacons())
+
+	t.Run("key limit", func(t *testing.T) {
+		e := &Engine{now: func() time.Time { return now }}
+		for i := 0; i < maxBeaconKeys+10; i++ {
+			ia := addr.IA{I: 1, A: addr.AS(i + 1)}
+			e.RecordBeacon(newBeacon(ia, 1, now), "err_verify", "")
+		}
+		stats := e.Beacons()
+		assert.Len(t, stats, maxBeaconKeys+1)
+		for _, s := range stats {
+			if s.BeaconKey == (BeaconKey{}) {
+				assert.Equal(t, uint64(10), s.Received)
+			}
+		}
+	})
+}
+
+func TestEngineLookups(t *testing.T) {
+	now := time.Date(2021, 11, 25, 12, 0, 0, 0, time.UTC)
+	e := &Engine{Window: 12 * time.Minute, now: func() time.Time { return now }}
+
+	e.RecordLookup("1-ff00:0:110", "ok_success", 3)
+	e.RecordLookup("1-ff00:0:110", "err_timeout", 0)
+	now = now.Add(time.Minute)
+	e.RecordLookup("1-ff00:0:110", "ok_success", 2)
+	e.RecordLookup("1-ff00:0:111,127.0.0.1", "ok_success", 1)
+
+	assert.Equal(t, []LookupStats{
+		{
+			Requester:   "1-ff00:0:110",
+			Requests:    3,
+			Results:     map[string]uint64{"ok_success": 2, "err_timeout": 1},
+			Segments:    5,
+			LastRequest: now,
+		},
+		{
+			Requester:   "1-ff00:0:111,127.0.0.1",
+			Requests:    1,
+			Results:     map[string]uint64{"ok_success": 1},
+			Segments:    1,
+			LastRequest: now,
+		},
+	}, e.Lookups())
+
+	now = now.Add(12 * time.Minute)
+	assert.Empty(t, e.Lookups())
+
+	t.Run("requester limit", func(t *testing.T) {
+		e := &Engine{now: func() time.Time { return now }}
+		for i := 0; i < maxRequesters+10; i++ {
+			e.RecordLookup(fmt.Sprintf("requester-%d", i), "ok_success", 1)
+		}
+		lookups := e.Lookups()
+		assert.Len(t, lookups, maxRequesters+1)
+		for _, l := range lookups {
+			if l.Requester == OtherRequester {
+				assert.Equal(t, uint64(10), l.Requests)
+			}
+		}
+	})
+}
+
+func newBeacon(origin addr.IA, ingress uint16, created time.Time) beacon.Beacon {
+	return beacon.Beacon{
+		InIfId: ingress,
+		Segment: &seg.PathSegment{
+			Info:      seg.Info{Timestamp: created},
+			ASEntries: []seg.ASEntry{{Local: origin}},
+		},
+	}
+}
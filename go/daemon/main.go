@@ -17,6 +17,7 @@ package main
 import (
 	"context"
 	"errors"
+	"math/rand"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
@@ -43,6 +44,8 @@ import (
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/scrypto/signed"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	hostresolver "github.com/scionproto/scion/go/lib/snet/resolver"
 	"github.com/scionproto/scion/go/lib/topology"
 	cppkiapi "github.com/scionproto/scion/go/pkg/api/cppki/api"
 	segapi "github.com/scionproto/scion/go/pkg/api/segments/api"
@@ -52,9 +55,11 @@ import (
 	"github.com/scionproto/scion/go/pkg/daemon/api"
 	"github.com/scionproto/scion/go/pkg/daemon/config"
 	"github.com/scionproto/scion/go/pkg/daemon/fetcher"
+	"github.com/scionproto/scion/go/pkg/daemon/quality"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
 	"github.com/scionproto/scion/go/pkg/hiddenpath"
 	hpgrpc "github.com/scionproto/scion/go/pkg/hiddenpath/grpc"
+	"github.com/scionproto/scion/go/pkg/pathprobe"
 	cryptopb "github.com/scionproto/scion/go/pkg/proto/crypto"
 	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
 	"github.com/scionproto/scion/go/pkg/service"
@@ -202,28 +207,67 @@ func realMain(ctx context.Context) error {
 		}}
 	}
 
+	var pathFetcher fetcher.Fetcher = fetcher.NewFetcher(
+		fetcher.FetcherConfig{
+			IA:         topo.IA(),
+			MTU:        topo.MTU(),
+			Core:       topo.Core(),
+			NextHopper: topo,
+			RPC:        requester,
+			PathDB:     pathDB,
+			Inspector:  engine,
+			Verifier:   createVerifier(),
+			RevCache:   revCache,
+			Cfg:        globalCfg.SD,
+			Metrics: fetcher.Metrics{
+				Lookups: metrics.NewPromCounter(prom.NewCounterVec("sd", "path",
+					"lookups_total",
+					"The total number of path lookups, by whether they were served from cache.",
+					[]string{prom.LabelResult},
+				)),
+			},
+		},
+	)
+	if prefetchCfg := globalCfg.SD.Prefetch; prefetchCfg.Enable {
+		warmup, err := prefetchCfg.DestinationIAs()
+		if err != nil {
+			return serrors.WrapStr("parsing prefetch destinations", err)
+		}
+		prefetcher := &fetcher.Prefetcher{
+			Fetcher:         pathFetcher,
+			Window:          prefetchCfg.Window.Duration,
+			MinRequests:     prefetchCfg.MinRequests,
+			MaxDestinations: prefetchCfg.MaxDestinations,
+			RefreshBefore:   prefetchCfg.RefreshBefore.Duration,
+			Metrics:         prefetchMetrics(),
+		}
+		go func() {
+			defer log.HandlePanic()
+			prefetcher.Warmup(errCtx, warmup)
+		}()
+		prefetchRunner := periodic.Start(prefetcher,
+			prefetchCfg.Interval.Duration, prefetchCfg.Interval.Duration)
+		defer prefetchRunner.Stop()
+		pathFetcher = prefetcher
+	}
+	var qualityStore *quality.Store
+	if qualityCfg := globalCfg.SD.PathQuality; !qualityCfg.Disable {
+		qualityStore = &quality.Store{
+			HalfLife: qualityCfg.HalfLife.Duration,
+			MaxAge:   qualityCfg.MaxAge.Duration,
+			MaxPaths: qualityCfg.MaxPaths,
+		}
+	}
 	server := grpc.NewServer(libgrpc.UnaryServerInterceptor())
 	sdpb.RegisterDaemonServiceServer(server, daemon.NewServer(
 		daemon.ServerConfig{
 			IA:       topo.IA(),
 			MTU:      topo.MTU(),
 			Topology: topo,
-			Fetcher: fetcher.NewFetcher(
-				fetcher.FetcherConfig{
-					IA:         topo.IA(),
-					MTU:        topo.MTU(),
-					Core:       topo.Core(),
-					NextHopper: topo,
-					RPC:        requester,
-					PathDB:     pathDB,
-					Inspector:  engine,
-					Verifier:   createVerifier(),
-					RevCache:   revCache,
-					Cfg:        globalCfg.SD,
-				},
-			),
+			Fetcher:  pathFetcher,
 			Engine:   engine,
 			RevCache: revCache,
+			Quality:  qualityStore,
 		},
 	))
 
@@ -256,6 +300,9 @@ func realMain(ctx context.Context) error {
 			Config:   service.NewConfigStatusPage(globalCfg).Handler,
 			Info:     service.NewInfoStatusPage().Handler,
 			LogLevel: service.NewLogLevelStatusPage().Handler,
+			Paths:    pathFetcher,
+			Prober:   pathProber{localIA: topo.IA()},
+			Hosts:    &hostresolver.Cache{Resolver: hostresolver.Local()},
 		}
 		log.Info("Exposing API", "addr", globalCfg.API.Addr)
 		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
@@ -315,6 +362,36 @@ func (v acceptAllVerifier) WithIA(addr.IA) infra.Verifier {
 	return v
 }
 
+// pathProber probes paths from the local AS for the REST API.
+type pathProber struct {
+	localIA addr.IA
+}
+
+func (p pathProber) GetStatuses(ctx context.Context, dst addr.IA,
+	paths []snet.Path) (map[string]pathprobe.Status, error) {
+
+	return pathprobe.Prober{
+		DstIA:   dst,
+		LocalIA: p.localIA,
+		ID:      uint16(rand.Uint32()),
+	}.GetStatuses(ctx, paths)
+}
+
+func prefetchMetrics() fetcher.PrefetchMetrics {
+	return fetcher.PrefetchMetrics{
+		Prefetches: metrics.NewPromCounter(prom.NewCounterVec("sd", "path",
+			"prefetches_total",
+			"The total number of path lookups issued by the prefetcher.",
+			[]string{prom.LabelResult},
+		)),
+		PopularDestinations: metrics.NewPromGauge(prom.NewGaugeVec("sd", "path",
+			"popular_destinations",
+			"The number of destinations whose paths are prefetched.",
+			[]string{},
+		)),
+	}
+}
+
 func loaderMetrics() topology.LoaderMetrics {
 	updates := prom.NewCounterVec("", "",
 		"topology_updates_total",
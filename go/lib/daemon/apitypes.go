@@ -17,6 +17,7 @@ package daemon
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
@@ -29,6 +30,23 @@ import (
 type PathReqFlags struct {
 	Refresh bool
 	Hidden  bool
+	// OrderByQuality orders the paths by the quality that was reported to the
+	// daemon, best first. Paths without reported quality are ordered last.
+	OrderByQuality bool
+}
+
+// PathQualityReport is a measurement of the quality of a path that is
+// reported to the daemon.
+type PathQualityReport struct {
+	// Fingerprint identifies the path.
+	Fingerprint snet.PathFingerprint
+	// Latency is the average round-trip latency of the received probes. It is
+	// ignored if no probe was received.
+	Latency time.Duration
+	// Sent is the number of probes that were sent on the path.
+	Sent int
+	// Received is the number of probes for which a reply was received.
+	Received int
 }
 
 // ASInfo provides information about the local AS.
@@ -54,6 +54,8 @@ func NewService(name string) Service {
 			InterfacesRequests:         libmetrics.NewPromCounter(metrics.IFInfos.CounterVec()),
 			ServicesRequests:           libmetrics.NewPromCounter(metrics.SVCInfos.CounterVec()),
 			InterfaceDownNotifications: libmetrics.NewPromCounter(metrics.Revocations.CounterVec()),
+			PathQualityReports: libmetrics.NewPromCounter(
+				metrics.PathQualityReports.CounterVec()),
 		},
 	}
 }
@@ -80,6 +82,10 @@ type Connector interface {
 	SVCInfo(ctx context.Context, svcTypes []addr.HostSVC) (map[addr.HostSVC]string, error)
 	// RevNotification sends a RevocationInfo message to the daemon.
 	RevNotification(ctx context.Context, revInfo *path_mgmt.RevInfo) error
+	// ReportPathQuality reports the quality that was observed on paths to the
+	// daemon. The daemon aggregates the reports of all applications, and
+	// annotates the paths it returns with the observed quality.
+	ReportPathQuality(ctx context.Context, reports []PathQualityReport) error
 	// Close shuts down the connection to the daemon.
 	Close(ctx context.Context) error
 }
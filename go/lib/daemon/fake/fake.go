@@ -185,6 +185,12 @@ func (c connector) RevNotification(ctx context.Context,
 	panic("not implemented")
 }
 
+func (c connector) ReportPathQuality(ctx context.Context,
+	reports []daemon.PathQualityReport) error {
+
+	panic("not implemented")
+}
+
 func (c connector) Close(ctx context.Context) error {
 	return nil
 }
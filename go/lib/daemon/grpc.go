@@ -19,6 +19,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -81,6 +82,7 @@ func (c grpcConn) Paths(ctx context.Context, dst, src addr.IA,
 		DestinationIsdAs: uint64(dst.IAInt()),
 		Hidden:           f.Hidden,
 		Refresh:          f.Refresh,
+		OrderByQuality:   f.OrderByQuality,
 	})
 	if err != nil {
 		c.metrics.incPaths(err)
@@ -157,6 +159,24 @@ func (c grpcConn) RevNotification(ctx context.Context, revInfo *path_mgmt.RevInf
 
 }
 
+func (c grpcConn) ReportPathQuality(ctx context.Context, reports []PathQualityReport) error {
+	req := &sdpb.ReportPathQualityRequest{
+		Reports: make([]*sdpb.PathQualityReport, 0, len(reports)),
+	}
+	for _, r := range reports {
+		req.Reports = append(req.Reports, &sdpb.PathQualityReport{
+			Fingerprint: []byte(r.Fingerprint),
+			Latency:     ptypes.DurationProto(r.Latency),
+			Sent:        uint32(r.Sent),
+			Received:    uint32(r.Received),
+		})
+	}
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	_, err := client.ReportPathQuality(ctx, req)
+	c.metrics.incQuality(err)
+	return err
+}
+
 func (c grpcConn) Close(_ context.Context) error {
 	return c.conn.Close()
 }
@@ -226,10 +246,22 @@ func convertPath(p *sdpb.Path, dst addr.IA) (path.Path, error) {
 			LinkType:     linkType,
 			InternalHops: p.InternalHops,
 			Notes:        p.Notes,
+			Quality:      qualityFromPB(p.Quality),
 		},
 	}, nil
 }
 
+func qualityFromPB(q *sdpb.PathQuality) *snet.PathQuality {
+	if q == nil {
+		return nil
+	}
+	return &snet.PathQuality{
+		Latency:    q.Latency.AsDuration(),
+		Loss:       q.Loss,
+		LastReport: q.LastReport.AsTime(),
+	}
+}
+
 func linkTypeFromPB(lt sdpb.LinkType) snet.LinkType {
 	switch lt {
 	case sdpb.LinkType_LINK_TYPE_DIRECT:
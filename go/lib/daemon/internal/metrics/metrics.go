@@ -30,6 +30,7 @@ const (
 	subsystemIFInfo     = "if_info"
 	subsystemSVCInfo    = "service_info"
 	subsystemRevocation = "revocation"
+	subsystemQuality    = "path_quality"
 )
 
 // Result values
@@ -67,6 +68,8 @@ var (
 	SVCInfos = newSVCInfo()
 	// Conns contains metrics for connections to SCIOND.
 	Conns = newConn()
+	// PathQualityReports contains metrics for path quality reports.
+	PathQualityReports = newPathQualityReport()
 )
 
 // Request is the generic metric for requests.
@@ -124,3 +127,10 @@ func newIFInfo() Request {
 			"The amount of IF info requests sent.", resultLabel{}),
 	}
 }
+
+func newPathQualityReport() Request {
+	return Request{
+		count: prom.NewCounterVecWithLabels(Namespace, subsystemQuality, "reports_total",
+			"The amount of path quality reports sent.", resultLabel{}),
+	}
+}
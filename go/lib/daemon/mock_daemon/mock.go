@@ -114,6 +114,20 @@ func (mr *MockConnectorMockRecorder) Paths(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Paths", reflect.TypeOf((*MockConnector)(nil).Paths), arg0, arg1, arg2, arg3)
 }
 
+// ReportPathQuality mocks base method.
+func (m *MockConnector) ReportPathQuality(arg0 context.Context, arg1 []daemon.PathQualityReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportPathQuality", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportPathQuality indicates an expected call of ReportPathQuality.
+func (mr *MockConnectorMockRecorder) ReportPathQuality(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportPathQuality", reflect.TypeOf((*MockConnector)(nil).ReportPathQuality), arg0, arg1)
+}
+
 // RevNotification mocks base method.
 func (m *MockConnector) RevNotification(arg0 context.Context, arg1 *path_mgmt.RevInfo) error {
 	m.ctrl.T.Helper()
@@ -43,6 +43,8 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	_ "github.com/scionproto/scion/go/lib/scrypto" // Make sure math/rand is seeded
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/tracing"
+	"github.com/scionproto/scion/go/lib/tracing/otlp"
 	"github.com/scionproto/scion/go/lib/util"
 )
 
@@ -204,6 +206,24 @@ func (cfg *Metrics) ServePrometheus(ctx context.Context) error {
 	return nil
 }
 
+// Tracing exporters.
+const (
+	// TracingExporterJaeger reports spans to a Jaeger agent over UDP.
+	TracingExporterJaeger = "jaeger"
+	// TracingExporterOTLP exports spans to an OpenTelemetry collector.
+	TracingExporterOTLP = "otlp"
+)
+
+// Tracing propagation formats.
+const (
+	// TracingPropagationJaeger uses the Jaeger uber-trace-id header.
+	TracingPropagationJaeger = "jaeger"
+	// TracingPropagationW3C uses the W3C trace-context headers.
+	TracingPropagationW3C = "w3c"
+)
+
+var _ config.Config = (*Tracing)(nil)
+
 // Tracing contains configuration for tracing.
 type Tracing struct {
 	// Enabled enables tracing for this service.
@@ -211,8 +231,21 @@ type Tracing struct {
 	// Enable debug mode.
 	Debug bool `toml:"debug,omitempty"`
 	// Agent is the address of the local agent that handles the reported
-	// traces. (default: localhost:6831)
+	// traces. Only used with the jaeger exporter. (default: localhost:6831)
 	Agent string `toml:"agent,omitempty"`
+	// Exporter selects where spans are sent to, either "jaeger" or "otlp".
+	// (default: jaeger)
+	Exporter string `toml:"exporter,omitempty"`
+	// OTLPEndpoint is the address of the OpenTelemetry collector. (default:
+	// localhost:4317 for grpc, localhost:4318 for http)
+	OTLPEndpoint string `toml:"otlp_endpoint,omitempty"`
+	// OTLPProtocol is the protocol used to export spans to the collector,
+	// either "grpc" or "http". (default: grpc)
+	OTLPProtocol string `toml:"otlp_protocol,omitempty"`
+	// Propagation is the format used to propagate the trace context across
+	// process boundaries, e.g., in gRPC metadata. Either "jaeger" or "w3c".
+	// (default: jaeger)
+	Propagation string `toml:"propagation,omitempty"`
 }
 
 func (cfg *Tracing) InitDefaults() {
@@ -222,6 +255,40 @@ func (cfg *Tracing) InitDefaults() {
 			strconv.Itoa(jaeger.DefaultUDPSpanServerPort),
 		)
 	}
+	if cfg.Exporter == "" {
+		cfg.Exporter = TracingExporterJaeger
+	}
+	if cfg.OTLPProtocol == "" {
+		cfg.OTLPProtocol = string(otlp.GRPC)
+	}
+	if cfg.OTLPEndpoint == "" {
+		cfg.OTLPEndpoint = otlp.DefaultGRPCEndpoint
+		if cfg.OTLPProtocol == string(otlp.HTTP) {
+			cfg.OTLPEndpoint = otlp.DefaultHTTPEndpoint
+		}
+	}
+	if cfg.Propagation == "" {
+		cfg.Propagation = TracingPropagationJaeger
+	}
+}
+
+func (cfg *Tracing) Validate() error {
+	switch cfg.Exporter {
+	case "", TracingExporterJaeger, TracingExporterOTLP:
+	default:
+		return serrors.New("unknown tracing exporter", "exporter", cfg.Exporter)
+	}
+	switch otlp.Protocol(cfg.OTLPProtocol) {
+	case "", otlp.GRPC, otlp.HTTP:
+	default:
+		return serrors.New("unknown OTLP protocol", "protocol", cfg.OTLPProtocol)
+	}
+	switch cfg.Propagation {
+	case "", TracingPropagationJaeger, TracingPropagationW3C:
+	default:
+		return serrors.New("unknown tracing propagation", "propagation", cfg.Propagation)
+	}
+	return nil
 }
 
 func (cfg *Tracing) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
@@ -235,6 +302,9 @@ func (cfg *Tracing) ConfigName() string {
 // NewTracer creates a new Tracer for the given configuration. In case tracing
 // is disabled this still returns noop-objects for convenience of the caller.
 func (cfg *Tracing) NewTracer(id string) (opentracing.Tracer, io.Closer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
 	traceConfig := jaegercfg.Configuration{
 		ServiceName: id,
 		Disabled:    !cfg.Enabled,
@@ -249,14 +319,59 @@ func (cfg *Tracing) NewTracer(id string) (opentracing.Tracer, io.Closer, error)
 		}
 	}
 	bp := jaeger.NewBinaryPropagator(nil)
-	return traceConfig.NewTracer(
+	opts := []jaegercfg.Option{
 		jaegercfg.Extractor(opentracing.Binary, bp),
-		jaegercfg.Injector(opentracing.Binary, bp))
+		jaegercfg.Injector(opentracing.Binary, bp),
+	}
+	if cfg.Propagation == TracingPropagationW3C {
+		// W3C trace-context requires 128 bit trace IDs.
+		traceConfig.Gen128Bit = true
+		// Peers that have not been migrated yet still send the jaeger
+		// headers, accept those as well.
+		headers := (&jaeger.HeadersConfig{}).ApplyDefaults()
+		textMap := tracing.TraceContextPropagator{
+			Fallback: jaeger.NewTextMapPropagator(headers, *jaeger.NewNullMetrics()),
+		}
+		httpHeaders := tracing.TraceContextPropagator{
+			Fallback: jaeger.NewHTTPHeaderPropagator(headers, *jaeger.NewNullMetrics()),
+		}
+		opts = append(opts,
+			jaegercfg.Extractor(opentracing.TextMap, textMap),
+			jaegercfg.Injector(opentracing.TextMap, textMap),
+			jaegercfg.Extractor(opentracing.HTTPHeaders, httpHeaders),
+			jaegercfg.Injector(opentracing.HTTPHeaders, httpHeaders),
+		)
+	}
+	if cfg.Enabled && cfg.Exporter == TracingExporterOTLP {
+		traceConfig.Gen128Bit = true
+		if traceConfig.Sampler == nil {
+			// The default remote sampler polls the Jaeger agent, which is
+			// not present in an OpenTelemetry deployment. Use the same
+			// sampling rate the remote sampler uses by default.
+			traceConfig.Sampler = &jaegercfg.SamplerConfig{
+				Type:  jaeger.SamplerTypeProbabilistic,
+				Param: 0.001,
+			}
+		}
+		reporter, err := otlp.NewReporter(otlp.Config{
+			Endpoint:    cfg.OTLPEndpoint,
+			Protocol:    otlp.Protocol(cfg.OTLPProtocol),
+			ServiceName: id,
+		})
+		if err != nil {
+			return nil, nil, serrors.WrapStr("creating OTLP reporter", err)
+		}
+		opts = append(opts, jaegercfg.Reporter(reporter))
+	}
+	return traceConfig.NewTracer(opts...)
 }
 
 // QUIC contains configuration for control-plane speakers.
 type QUIC struct {
 	Address string `toml:"address,omitempty"`
+	// AllowUnauthenticated indicates that peers that cannot be authenticated
+	// with their AS certificate chain are accepted.
+	AllowUnauthenticated bool `toml:"allow_unauthenticated,omitempty"`
 }
 
 func (cfg *QUIC) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
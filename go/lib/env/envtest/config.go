@@ -53,6 +53,9 @@ func InitTestMetrics(cfg *env.Metrics) {}
 func InitTestTracing(cfg *env.Tracing) {
 	cfg.Enabled = true
 	cfg.Debug = true
+	cfg.Exporter = env.TracingExporterOTLP
+	cfg.OTLPProtocol = "http"
+	cfg.Propagation = env.TracingPropagationW3C
 }
 
 func InitTestSCIOND(cfg *env.Daemon) {
@@ -98,6 +101,10 @@ func CheckTestTracing(t *testing.T, cfg *env.Tracing) {
 		),
 		cfg.Agent,
 	)
+	assert.Equal(t, env.TracingExporterJaeger, cfg.Exporter)
+	assert.Equal(t, "localhost:4317", cfg.OTLPEndpoint)
+	assert.Equal(t, "grpc", cfg.OTLPProtocol)
+	assert.Equal(t, env.TracingPropagationJaeger, cfg.Propagation)
 }
 
 func CheckTestSciond(t *testing.T, cfg *env.Daemon, id string) {
@@ -50,13 +50,37 @@ const tracingSample = `
 enabled = false
 # Enable debug mode. (default false)
 debug = false
-# Address of the local agent that handles the reported traces.
-# (default: localhost:6831)
+# Address of the local agent that handles the reported traces. Only used with
+# the jaeger exporter. (default: localhost:6831)
 agent = "localhost:6831"
+# The exporter that is used to report the traces, either "jaeger" or "otlp".
+# (default: jaeger)
+exporter = "jaeger"
+# Address of the OpenTelemetry collector. Only used with the otlp exporter.
+# (default: localhost:4317 for grpc, localhost:4318 for http)
+otlp_endpoint = "localhost:4317"
+# The protocol used to export traces to the OpenTelemetry collector, either
+# "grpc" or "http". (default: grpc)
+otlp_protocol = "grpc"
+# The format used to propagate the trace context to remote peers, either
+# "jaeger" or "w3c". (default: jaeger)
+propagation = "jaeger"
 `
 
 const quicSample = `
 # The address to start a QUIC server on (ip:port). If not set, a QUIC server on
 # the public IP and a high port is started. (default "")
 address = ""
+
+# Serve all RPCs to peers that cannot be authenticated with their AS
+# certificate chain. Otherwise, such peers can only issue the discovery RPCs,
+# e.g., gateways that are not configured with tunnel_encryption.config_dir.
+# Peers of ISDs for which no TRC is available can additionally fetch trust
+# material and exchange segments, to bootstrap the trust between ISDs. This is
+# meant for migrating from unauthenticated connections and should be disabled
+# once all peers present their AS certificate. Unauthenticated peers are counted
+# in the trustengine_tls_unauthenticated_peers_total and
+# trustengine_tls_bootstrap_peers_total metrics, rejected requests in
+# control_grpc_unauthenticated_requests_total. (default false)
+allow_unauthenticated = false
 `
@@ -48,6 +48,16 @@ import (
 type QUIC struct {
 	// Address is the UDP address to start the QUIC server on.
 	Address string
+	// TLS provides the TLS configurations of the QUIC server and client. If
+	// nil, a throwaway self-signed certificate is used and peers are not
+	// authenticated.
+	TLS TLSConfigs
+}
+
+// TLSConfigs provides the TLS configurations for QUIC servers and clients.
+type TLSConfigs interface {
+	ServerTLSConfig() *tls.Config
+	ClientTLSConfig() *tls.Config
 }
 
 // NetworkConfig describes the networking configuration of a SCION
@@ -105,11 +115,11 @@ func (nc *NetworkConfig) QUICStack() (*QUICStack, error) {
 	log.Info("QUIC server conn initialized", "local_addr", server.LocalAddr())
 	log.Info("QUIC client conn initialized", "local_addr", client.LocalAddr())
 
-	tlsConfig, err := GenerateTLSConfig()
+	serverTLSConfig, clientTLSConfig, err := nc.tlsConfigs()
 	if err != nil {
 		return nil, err
 	}
-	listener, err := quic.Listen(server, tlsConfig, nil)
+	listener, err := quic.Listen(server, serverTLSConfig, nil)
 	if err != nil {
 		return nil, serrors.WrapStr("listening QUIC/SCION", err)
 	}
@@ -123,12 +133,24 @@ func (nc *NetworkConfig) QUICStack() (*QUICStack, error) {
 		Listener: squic.NewConnListener(listener),
 		Dialer: &squic.ConnDialer{
 			Conn:      client,
-			TLSConfig: tlsConfig,
+			TLSConfig: clientTLSConfig,
 		},
 		RedirectCloser: cancel,
 	}, nil
 }
 
+func (nc *NetworkConfig) tlsConfigs() (*tls.Config, *tls.Config, error) {
+	if nc.QUIC.TLS != nil {
+		return nc.QUIC.TLS.ServerTLSConfig(), nc.QUIC.TLS.ClientTLSConfig(), nil
+	}
+	log.Info("QUIC peers are not authenticated, using self-signed TLS certificate")
+	tlsConfig, err := GenerateTLSConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	return tlsConfig, tlsConfig, nil
+}
+
 // GenerateTLSConfig generates a self-signed certificate.
 func GenerateTLSConfig() (*tls.Config, error) {
 	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
@@ -0,0 +1,162 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// DefaultMaxCandidates is the default number of path candidates considered by
+// CombineDiverse.
+const DefaultMaxCandidates = 512
+
+// DiversityConfig configures the diversity-aware path enumeration of
+// CombineDiverse.
+type DiversityConfig struct {
+	// MaxPaths is the maximum number of returned paths. It must be positive.
+	MaxPaths int
+	// MaxCandidates is the maximum number of path candidates that are
+	// considered for the selection. The candidates are the combinations with
+	// the fewest AS hops that are accepted by the filter. If zero,
+	// DefaultMaxCandidates is used.
+	MaxCandidates int
+	// Filter, if set, discards the paths for which it returns false before
+	// they become candidates, e.g., expired or revoked paths.
+	Filter func(Path) bool
+	// Cost returns the cost of a path, lower is better. If nil, HopCost is
+	// used.
+	Cost func(Path) float64
+	// DisjointnessBonus is subtracted from the cost of a candidate, scaled by
+	// the fraction of its links that are not used by any of the already
+	// selected paths. A fully link-disjoint candidate thus gets the full bonus,
+	// a candidate that only uses already selected links gets none. If zero,
+	// the paths with the lowest cost are selected.
+	DisjointnessBonus float64
+}
+
+// HopCost is the default cost function. The cost of a path is the number of
+// inter-AS links it traverses.
+func HopCost(p Path) float64 {
+	return float64(len(p.Metadata.Interfaces) / 2)
+}
+
+// CombineDiverse constructs at most cfg.MaxPaths paths between src and dst
+// using the supplied segments. In contrast to Combine, only the
+// cfg.MaxCandidates combinations with the fewest AS hops are constructed; the
+// combinations that cannot be among them are pruned while searching. Among
+// those, the paths are selected greedily: In each step, the candidate with the
+// lowest cost minus the disjointness bonus is selected. This favors cheap
+// paths that do not share links with the paths selected before.
+//
+// The paths are returned in the order of selection, i.e., the first path is
+// the cheapest one. Paths with identical sequences of path interfaces are
+// only considered once, see Combine for details.
+func CombineDiverse(src, dst addr.IA, ups, cores, downs []*seg.PathSegment,
+	cfg DiversityConfig) []Path {
+
+	if cfg.MaxPaths <= 0 {
+		return nil
+	}
+	maxCandidates := cfg.MaxCandidates
+	if maxCandidates == 0 {
+		maxCandidates = DefaultMaxCandidates
+	}
+	built := make(map[*pathSolution]Path)
+	accept := func(solution *pathSolution) bool {
+		p := solution.Path()
+		if cfg.Filter != nil && !cfg.Filter(p) {
+			return false
+		}
+		built[solution] = p
+		return true
+	}
+	solutions := newDMG(ups, cores, downs).GetBestPaths(vertexFromIA(src),
+		vertexFromIA(dst), maxCandidates, accept)
+	paths := make([]Path, len(solutions))
+	for i, solution := range solutions {
+		paths[i] = built[solution]
+	}
+	paths = filterDuplicates(filterLongPaths(paths))
+	return selectDiverse(paths, cfg)
+}
+
+// link identifies an inter-AS link by the interfaces on both ends.
+type link [2]snet.PathInterface
+
+// selectDiverse greedily selects at most cfg.MaxPaths paths. On equal score,
+// the path that comes first in the input is selected.
+func selectDiverse(paths []Path, cfg DiversityConfig) []Path {
+	cost := cfg.Cost
+	if cost == nil {
+		cost = HopCost
+	}
+	costs := make([]float64, len(paths))
+	for i, p := range paths {
+		costs[i] = cost(p)
+	}
+
+	used := make(map[link]struct{})
+	selected := make([]bool, len(paths))
+	var result []Path
+	for len(result) < cfg.MaxPaths {
+		best, bestScore := -1, 0.0
+		for i, p := range paths {
+			if selected[i] {
+				continue
+			}
+			score := costs[i] - cfg.DisjointnessBonus*novelty(p, used)
+			if best == -1 || score < bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best == -1 {
+			break
+		}
+		selected[best] = true
+		for _, l := range links(paths[best]) {
+			used[l] = struct{}{}
+		}
+		result = append(result, paths[best])
+	}
+	return result
+}
+
+// novelty returns the fraction of the links of the path that are not in used.
+func novelty(p Path, used map[link]struct{}) float64 {
+	ls := links(p)
+	if len(ls) == 0 {
+		return 0
+	}
+	var unused int
+	for _, l := range ls {
+		if _, ok := used[l]; !ok {
+			unused++
+		}
+	}
+	return float64(unused) / float64(len(ls))
+}
+
+// links returns the inter-AS links of the path. Consecutive pairs of path
+// interfaces form a link.
+func links(p Path) []link {
+	intfs := p.Metadata.Interfaces
+	ls := make([]link, 0, len(intfs)/2)
+	for i := 0; i+1 < len(intfs); i += 2 {
+		ls = append(ls, link{intfs[i], intfs[i+1]})
+	}
+	return ls
+}
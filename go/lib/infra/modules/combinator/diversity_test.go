@@ -0,0 +1,134 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+)
+
+func TestCombineDiverse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+
+	src := xtest.MustParseIA("1-ff00:0:112")
+	dst := xtest.MustParseIA("2-ff00:0:212")
+	ups := []*seg.PathSegment{
+		g.Beacon([]uint16{graph.If_130_B_111_A, graph.If_111_A_112_X}),
+	}
+	cores := []*seg.PathSegment{
+		g.Beacon([]uint16{graph.If_210_X_110_X, graph.If_110_X_130_A}),
+	}
+	downs := []*seg.PathSegment{
+		g.Beacon([]uint16{graph.If_210_X_211_A, graph.If_211_A_212_X}),
+	}
+	// The paths in the order of Combine: Two paths over the two peering links
+	// between 1-ff00:0:111 and 2-ff00:0:211 with 3 hops each, and one path over
+	// the core with 6 hops. All of them share the first and the last link.
+	all := combinator.Combine(src, dst, ups, cores, downs, false)
+	require.Len(t, all, 3)
+	require.Len(t, all[0].Metadata.Interfaces, 6)
+	require.Len(t, all[1].Metadata.Interfaces, 6)
+	require.Len(t, all[2].Metadata.Interfaces, 12)
+
+	testCases := map[string]struct {
+		Config   combinator.DiversityConfig
+		Expected []int
+	}{
+		"no paths": {
+			Config: combinator.DiversityConfig{},
+		},
+		"lowest cost": {
+			Config:   combinator.DiversityConfig{MaxPaths: 2},
+			Expected: []int{0, 1},
+		},
+		"disjointness bonus": {
+			Config: combinator.DiversityConfig{
+				MaxPaths:          2,
+				DisjointnessBonus: 10,
+			},
+			Expected: []int{0, 2},
+		},
+		"small disjointness bonus": {
+			Config: combinator.DiversityConfig{
+				MaxPaths:          2,
+				DisjointnessBonus: 1,
+			},
+			Expected: []int{0, 1},
+		},
+		"more than available": {
+			Config: combinator.DiversityConfig{
+				MaxPaths:          10,
+				DisjointnessBonus: 10,
+			},
+			Expected: []int{0, 2, 1},
+		},
+		"max candidates": {
+			Config: combinator.DiversityConfig{
+				MaxPaths:          10,
+				MaxCandidates:     2,
+				DisjointnessBonus: 10,
+			},
+			Expected: []int{0, 1},
+		},
+		"filter before max candidates": {
+			Config: combinator.DiversityConfig{
+				MaxPaths:          10,
+				MaxCandidates:     2,
+				DisjointnessBonus: 10,
+				Filter: func(p combinator.Path) bool {
+					return !assert.ObjectsAreEqual(all[0].Metadata.Interfaces,
+						p.Metadata.Interfaces)
+				},
+			},
+			Expected: []int{1, 2},
+		},
+		"custom cost": {
+			Config: combinator.DiversityConfig{
+				MaxPaths: 1,
+				Cost: func(p combinator.Path) float64 {
+					return -combinator.HopCost(p)
+				},
+			},
+			Expected: []int{2},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			paths := combinator.CombineDiverse(src, dst, ups, cores, downs, tc.Config)
+			var expected [][]snet.PathInterface
+			for _, i := range tc.Expected {
+				expected = append(expected, all[i].Metadata.Interfaces)
+			}
+			var actual [][]snet.PathInterface
+			for _, p := range paths {
+				actual = append(actual, p.Metadata.Interfaces)
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
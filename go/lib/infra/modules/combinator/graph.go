@@ -16,6 +16,7 @@ package combinator
 
 import (
 	"bytes"
+	"container/heap"
 	"encoding/binary"
 	"fmt"
 	"sort"
@@ -195,25 +196,7 @@ func (g *dmg) GetPaths(src, dst vertex) pathSolutionList {
 				if !validNextSeg(currentPathSolution.currentSeg, segment) {
 					continue
 				}
-				// Create a copy of the old solution s.t. trail slices do not
-				// get mixed during appends.
-				newSolution := &pathSolution{
-					edges:         append([]*solutionEdge{}, currentPathSolution.edges...),
-					currentVertex: nextVertex,
-					currentSeg:    segment,
-					cost:          currentPathSolution.cost + e.Weight,
-				}
-
-				// Append the explored edge to the solution, and add it to the
-				// queue of candidate solutions.
-				newSolution.edges = append(newSolution.edges,
-					&solutionEdge{
-						edge:    e,
-						segment: segment,
-						src:     currentPathSolution.currentVertex,
-						dst:     nextVertex,
-					})
-
+				newSolution := currentPathSolution.extend(nextVertex, segment, e)
 				if nextVertex == dst {
 					solutions = append(solutions, newSolution)
 					// Do not break, because we want all solutions
@@ -227,6 +210,62 @@ func (g *dmg) GetPaths(src, dst vertex) pathSolutionList {
 	return solutions
 }
 
+// GetBestPaths returns at most max paths from src to dst that are accepted by
+// the filter, sorted according to weight. The result is the same as filtering
+// and truncating the result of GetPaths. However, the graph is explored in the
+// order of the cost, and partial solutions that cost more than the max
+// cheapest accepted solutions found so far are not explored further. Edge
+// weights are non-negative, thus, they cannot lead to a cheaper solution.
+func (g *dmg) GetBestPaths(src, dst vertex, max int,
+	accept func(*pathSolution) bool) pathSolutionList {
+
+	if max <= 0 {
+		return nil
+	}
+	// costs contains the sorted costs of the max cheapest accepted solutions.
+	costs := make([]int, 0, max+1)
+	exceeds := func(cost int) bool {
+		return len(costs) == max && cost > costs[max-1]
+	}
+	var solutions pathSolutionList
+	queue := &solutionQueue{&pathSolution{currentVertex: src}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(*pathSolution)
+		if exceeds(current.cost) {
+			break
+		}
+		for nextVertex, edgeList := range g.Adjacencies[current.currentVertex] {
+			for segment, e := range edgeList {
+				if !validNextSeg(current.currentSeg, segment) {
+					continue
+				}
+				next := current.extend(nextVertex, segment, e)
+				if exceeds(next.cost) {
+					continue
+				}
+				if nextVertex != dst {
+					heap.Push(queue, next)
+					continue
+				}
+				if !accept(next) {
+					continue
+				}
+				solutions = append(solutions, next)
+				i := sort.SearchInts(costs, next.cost)
+				costs = append(costs[:i], append([]int{next.cost}, costs[i:]...)...)
+				if len(costs) > max {
+					costs = costs[:max]
+				}
+			}
+		}
+	}
+	sort.Sort(solutions)
+	if len(solutions) > max {
+		solutions = solutions[:max]
+	}
+	return solutions
+}
+
 // inputSegment is a local representation of a path segment that includes the
 // segment's type.
 type inputSegment struct {
@@ -293,6 +332,29 @@ type pathSolution struct {
 	cost int
 }
 
+// extend returns a new solution that extends the solution by the edge to the
+// next vertex.
+func (solution *pathSolution) extend(next vertex, segment *inputSegment,
+	e *edge) *pathSolution {
+
+	// Create a copy of the old solution s.t. trail slices do not get mixed
+	// during appends.
+	edges := make([]*solutionEdge, 0, len(solution.edges)+1)
+	edges = append(edges, solution.edges...)
+	edges = append(edges, &solutionEdge{
+		edge:    e,
+		segment: segment,
+		src:     solution.currentVertex,
+		dst:     next,
+	})
+	return &pathSolution{
+		edges:         edges,
+		currentVertex: next,
+		currentSeg:    segment,
+		cost:          solution.cost + e.Weight,
+	}
+}
+
 // Path builds the forwarding path with metadata by extracting it from a path
 // between source and destination in the DMG.
 func (solution *pathSolution) Path() Path {
@@ -482,6 +544,35 @@ func (sl pathSolutionList) Swap(i, j int) {
 	sl[i], sl[j] = sl[j], sl[i]
 }
 
+// solutionQueue is a heap.Interface implementation that pops the solution with
+// the lowest cost first.
+type solutionQueue []*pathSolution
+
+func (q solutionQueue) Len() int {
+	return len(q)
+}
+
+func (q solutionQueue) Less(i, j int) bool {
+	return q[i].cost < q[j].cost
+}
+
+func (q solutionQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *solutionQueue) Push(x interface{}) {
+	*q = append(*q, x.(*pathSolution))
+}
+
+func (q *solutionQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return x
+}
+
 // solutionEdge contains a graph edge and additional metadata required during
 // graph exploration.
 type solutionEdge struct {
@@ -49,6 +49,10 @@ type Pather struct {
 	RevCache revcache.RevCache
 	Fetcher  *Fetcher
 	Splitter Splitter
+	// Diversity, if set, limits the number of paths per destination AS to a
+	// small, diverse set, see combinator.CombineDiverse. Revoked and expired
+	// paths are removed before the selection. If nil, all paths are returned.
+	Diversity *combinator.DiversityConfig
 }
 
 // GetPaths returns all non-revoked and non-expired paths to the destination.
@@ -82,8 +86,7 @@ func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 	if fetchErr != nil {
 		logger.Debug("Fetching failed, attempting to build paths anyway", "err", err)
 	}
-	paths := p.buildAllPaths(src, dst, segs)
-	paths = p.filterRevoked(ctx, paths)
+	paths := p.buildAllPaths(ctx, src, dst, segs)
 	if len(paths) == 0 {
 		if fetchErr != nil {
 			return nil, fetchErr
@@ -93,22 +96,39 @@ func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 	return p.translatePaths(paths)
 }
 
-func (p *Pather) buildAllPaths(src, dst addr.IA, segs Segments) []combinator.Path {
+// buildAllPaths builds the non-revoked and non-expired paths to the
+// destination.
+func (p *Pather) buildAllPaths(ctx context.Context, src, dst addr.IA,
+	segs Segments) []combinator.Path {
+
 	up, core, down := categorizeSegs(segs)
 	destinations := p.findDestinations(dst, up, core)
+	filter := &pathFilter{
+		ctx:      ctx,
+		now:      time.Now(),
+		revCache: p.RevCache,
+		revoked:  make(map[snet.PathInterface]struct{}),
+	}
 	var paths []combinator.Path
 	for dst := range destinations {
-		paths = append(paths, combinator.Combine(src, dst, up, core, down, false)...)
-	}
-	// Filter expired paths
-	now := time.Now()
-	var validPaths []combinator.Path
-	for _, path := range paths {
-		if path.Metadata.Expiry.After(now) {
-			validPaths = append(validPaths, path)
+		if p.Diversity != nil {
+			cfg := *p.Diversity
+			cfg.Filter = filter.usable
+			paths = append(paths, combinator.CombineDiverse(src, dst, up, core, down, cfg)...)
+			continue
 		}
+		for _, path := range combinator.Combine(src, dst, up, core, down, false) {
+			if filter.usable(path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	if filter.numRevoked > 0 {
+		log.FromCtx(ctx).Debug("Filtered paths with revocations",
+			"num_revoked_paths", filter.numRevoked,
+			"revoked_due_to", revocationsString(filter.revoked))
 	}
-	return validPaths
+	return paths
 }
 
 func (p *Pather) findDestinations(dst addr.IA, ups, cores seg.Segments) map[addr.IA]struct{} {
@@ -127,37 +147,39 @@ func (p *Pather) findDestinations(dst addr.IA, ups, cores seg.Segments) map[addr
 	return destinations
 }
 
-func (p *Pather) filterRevoked(ctx context.Context,
-	paths []combinator.Path) []combinator.Path {
+// pathFilter discards expired and revoked paths.
+type pathFilter struct {
+	ctx      context.Context
+	now      time.Time
+	revCache revcache.RevCache
 
-	logger := log.FromCtx(ctx)
-	var newPaths []combinator.Path
-	revokedInterfaces := make(map[snet.PathInterface]struct{})
-	for _, path := range paths {
-		revoked := false
-		for _, iface := range path.Metadata.Interfaces {
-			// cache automatically expires outdated revocations every second,
-			// so a cache hit implies revocation is still active.
-			revs, err := p.RevCache.Get(ctx, revcache.SingleKey(iface.IA, iface.ID))
-			if err != nil {
-				logger.Error("Failed to get revocation", "err", err)
-				// continue, the client might still get some usable paths like this.
-			}
-			if len(revs) > 0 {
-				revokedInterfaces[snet.PathInterface{IA: iface.IA, ID: iface.ID}] = struct{}{}
-			}
-			revoked = revoked || len(revs) > 0
+	numRevoked int
+	revoked    map[snet.PathInterface]struct{}
+}
+
+// usable returns whether the path is neither expired nor revoked.
+func (f *pathFilter) usable(path combinator.Path) bool {
+	if !path.Metadata.Expiry.After(f.now) {
+		return false
+	}
+	revoked := false
+	for _, iface := range path.Metadata.Interfaces {
+		// cache automatically expires outdated revocations every second,
+		// so a cache hit implies revocation is still active.
+		revs, err := f.revCache.Get(f.ctx, revcache.SingleKey(iface.IA, iface.ID))
+		if err != nil {
+			log.FromCtx(f.ctx).Error("Failed to get revocation", "err", err)
+			// continue, the client might still get some usable paths like this.
 		}
-		if !revoked {
-			newPaths = append(newPaths, path)
+		if len(revs) > 0 {
+			f.revoked[snet.PathInterface{IA: iface.IA, ID: iface.ID}] = struct{}{}
+			revoked = true
 		}
 	}
-	if len(paths) != len(newPaths) {
-		logger.Debug("Filtered paths with revocations",
-			"num_paths", len(paths), "num_revoked_paths", len(paths)-len(newPaths),
-			"revoked_due_to", revocationsString(revokedInterfaces))
+	if revoked {
+		f.numRevoked++
 	}
-	return newPaths
+	return !revoked
 }
 
 // revocationsString pretty-prints the revocations map to a string.
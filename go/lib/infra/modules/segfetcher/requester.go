@@ -103,13 +103,20 @@ func (r *DefaultRequester) requestWorker(ctx context.Context, reqs Requests, i i
 		},
 	)
 	defer span.Finish()
+	tracing.SrcIA(span, req.Src)
+	tracing.DstIA(span, req.Dst)
 
 	logger := log.FromCtx(ctx).New("req_id", log.NewDebugID(), "request", req)
 	ctx = log.CtxWith(ctx, logger)
 
 	reply := func(reply ReplyOrErr) {
-		replies <- reply
+		ids := make([][]byte, 0, len(reply.Segments))
+		for _, meta := range reply.Segments {
+			ids = append(ids, meta.Segment.ID())
+		}
+		tracing.SegmentIDs(span, ids...)
 		tracing.Error(span, reply.Err)
+		replies <- reply
 	}
 
 	// Keep retrying until the allocated time is up.
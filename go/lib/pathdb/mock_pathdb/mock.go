@@ -55,6 +55,21 @@ func (mr *MockDBMockRecorder) BeginTransaction(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockDB)(nil).BeginTransaction), arg0, arg1)
 }
 
+// Delete mocks base method.
+func (m *MockDB) Delete(arg0 context.Context, arg1 *query.Params) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDBMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDB)(nil).Delete), arg0, arg1)
+}
+
 // DeleteExpired mocks base method.
 func (m *MockDB) DeleteExpired(arg0 context.Context, arg1 time.Time) (int, error) {
 	m.ctrl.T.Helper()
@@ -197,6 +212,21 @@ func (mr *MockTransactionMockRecorder) Commit() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockTransaction)(nil).Commit))
 }
 
+// Delete mocks base method.
+func (m *MockTransaction) Delete(arg0 context.Context, arg1 *query.Params) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTransactionMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTransaction)(nil).Delete), arg0, arg1)
+}
+
 // DeleteExpired mocks base method.
 func (m *MockTransaction) DeleteExpired(arg0 context.Context, arg1 time.Time) (int, error) {
 	m.ctrl.T.Helper()
@@ -339,6 +369,21 @@ func (m *MockReadWrite) EXPECT() *MockReadWriteMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockReadWrite) Delete(arg0 context.Context, arg1 *query.Params) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReadWriteMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReadWrite)(nil).Delete), arg0, arg1)
+}
+
 // DeleteExpired mocks base method.
 func (m *MockReadWrite) DeleteExpired(arg0 context.Context, arg1 time.Time) (int, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +57,10 @@ type ReadWrite interface {
 	// DeleteExpired deletes all paths segments that are expired, using now as a reference.
 	// Returns the number of deleted segments.
 	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+	// Delete deletes all path segments matching the parameters specified. If
+	// params is nil, all path segments are deleted. Returns the number of
+	// deleted segments.
+	Delete(context.Context, *query.Params) (int, error)
 	// InsertNextQuery inserts or updates the timestamp nextQuery for the given
 	// src-dst pair and policy. Returns true if an insert/update happened or
 	// false if the stored timestamp is already newer.
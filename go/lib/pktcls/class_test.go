@@ -75,6 +75,45 @@ func TestClassMapMarshalUnMarshal(t *testing.T) {
 				),
 			},
 		},
+		{
+			Name:     "IPv6 TCP ICMP",
+			FileName: "class_3",
+			Classes: pktcls.ClassMap{
+				"IPv6 SYN": pktcls.NewClass(
+					"IPv6 SYN",
+					pktcls.NewCondAllOf(
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("2001:db8::"),
+								Mask: net.CIDRMask(32, 128),
+							},
+						}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+						pktcls.NewCondTCP(&pktcls.TCPMatchFlags{Flags: 0x02, Mask: 0x12}),
+					),
+				),
+				"IPv6 misc": pktcls.NewClass(
+					"IPv6 misc",
+					pktcls.NewCondAnyOf(
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("fd00::"),
+								Mask: net.CIDRMask(8, 128),
+							},
+						}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 12345}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 58}),
+					),
+				),
+				"ping": pktcls.NewClass(
+					"ping",
+					pktcls.NewCondAllOf(
+						pktcls.NewCondICMP(&pktcls.ICMPMatchType{ICMPType: 128}),
+						pktcls.NewCondICMP(&pktcls.ICMPMatchCode{ICMPCode: 0}),
+					),
+				),
+			},
+		},
 		{
 			Name:     "nil ClassMap stays nil",
 			FileName: "class_2",
@@ -16,6 +16,7 @@
 package pktcls
 
 import (
+	"encoding/binary"
 	"fmt"
 	"strings"
 
@@ -226,6 +227,54 @@ func (c *CondIPv4) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+var _ Cond = (*CondIPv6)(nil)
+
+// CondIPv6 conditions return true if the embedded IPv6 predicate returns true.
+type CondIPv6 struct {
+	Predicate IPv6Predicate
+}
+
+func NewCondIPv6(p IPv6Predicate) *CondIPv6 {
+	return &CondIPv6{Predicate: p}
+}
+
+func (c *CondIPv6) Eval(v gopacket.Layer) bool {
+	if c.Predicate == nil || v == nil {
+		return false
+	}
+	if v.LayerType() != layers.LayerTypeIPv6 {
+		return false
+	}
+
+	p, ok := v.(*layers.IPv6)
+	if !ok {
+		return false
+	}
+
+	return c.Predicate.Eval(p)
+}
+
+func (c *CondIPv6) Type() string {
+	return TypeCondIPv6
+}
+
+func (c *CondIPv6) String() string {
+	if c.Predicate == nil {
+		return "<nil>"
+	}
+	return c.Predicate.String()
+}
+
+func (c *CondIPv6) MarshalJSON() ([]byte, error) {
+	return marshalInterface(c.Predicate)
+}
+
+func (c *CondIPv6) UnmarshalJSON(b []byte) error {
+	var err error
+	c.Predicate, err = unmarshalIPv6Predicate(b)
+	return err
+}
+
 var _ Cond = (*CondPorts)(nil)
 
 // CondPorts conditions return true if the embedded port predicate returns true.
@@ -243,19 +292,15 @@ func (c *CondPorts) Eval(v gopacket.Layer) bool {
 	}
 	// Port predicates are independent on particular L3 or L4 protocol.
 	// Here we extract the ports and pass them to the embedded predicate.
-	l3 := v.LayerType()
-	if l3 != layers.LayerTypeIPv4 {
-		return false
-	}
-	ipv4, ok := v.(*layers.IPv4)
+	l4, payload, ok := transportLayer(v)
 	if !ok {
 		return false
 	}
 
-	switch ipv4.NextLayerType() {
+	switch l4 {
 	case layers.LayerTypeUDP:
 		udp := &layers.UDP{}
-		err := udp.DecodeFromBytes(ipv4.LayerPayload(), gopacket.NilDecodeFeedback)
+		err := udp.DecodeFromBytes(payload, gopacket.NilDecodeFeedback)
 		if err != nil {
 			return false
 		}
@@ -265,7 +310,7 @@ func (c *CondPorts) Eval(v gopacket.Layer) bool {
 		})
 	case layers.LayerTypeTCP:
 		tcp := &layers.TCP{}
-		err := tcp.DecodeFromBytes(ipv4.LayerPayload(), gopacket.NilDecodeFeedback)
+		err := tcp.DecodeFromBytes(payload, gopacket.NilDecodeFeedback)
 		if err != nil {
 			return false
 		}
@@ -299,6 +344,167 @@ func (c *CondPorts) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+var _ Cond = (*CondTCP)(nil)
+
+// CondTCP conditions return true if the packet carries a TCP segment and the
+// embedded TCP predicate returns true. Both IPv4 and IPv6 packets are supported.
+type CondTCP struct {
+	Predicate TCPPredicate
+}
+
+func NewCondTCP(p TCPPredicate) *CondTCP {
+	return &CondTCP{Predicate: p}
+}
+
+func (c *CondTCP) Eval(v gopacket.Layer) bool {
+	if c.Predicate == nil || v == nil {
+		return false
+	}
+	l4, payload, ok := transportLayer(v)
+	if !ok || l4 != layers.LayerTypeTCP {
+		return false
+	}
+	tcp := &layers.TCP{}
+	if err := tcp.DecodeFromBytes(payload, gopacket.NilDecodeFeedback); err != nil {
+		return false
+	}
+	return c.Predicate.Eval(tcp)
+}
+
+func (c *CondTCP) Type() string {
+	return TypeCondTCP
+}
+
+func (c *CondTCP) String() string {
+	if c.Predicate == nil {
+		return "<nil>"
+	}
+	return c.Predicate.String()
+}
+
+func (c *CondTCP) MarshalJSON() ([]byte, error) {
+	return marshalInterface(c.Predicate)
+}
+
+func (c *CondTCP) UnmarshalJSON(b []byte) error {
+	var err error
+	c.Predicate, err = unmarshalTCPPredicate(b)
+	return err
+}
+
+var _ Cond = (*CondICMP)(nil)
+
+// CondICMP conditions return true if the packet carries an ICMPv4 or an ICMPv6
+// message and the embedded ICMP predicate returns true.
+type CondICMP struct {
+	Predicate ICMPPredicate
+}
+
+func NewCondICMP(p ICMPPredicate) *CondICMP {
+	return &CondICMP{Predicate: p}
+}
+
+func (c *CondICMP) Eval(v gopacket.Layer) bool {
+	if c.Predicate == nil || v == nil {
+		return false
+	}
+	l4, payload, ok := transportLayer(v)
+	if !ok {
+		return false
+	}
+
+	switch l4 {
+	case layers.LayerTypeICMPv4:
+		icmp := &layers.ICMPv4{}
+		err := icmp.DecodeFromBytes(payload, gopacket.NilDecodeFeedback)
+		if err != nil {
+			return false
+		}
+		return c.Predicate.Eval(&ICMP{
+			Type: icmp.TypeCode.Type(),
+			Code: icmp.TypeCode.Code(),
+		})
+	case layers.LayerTypeICMPv6:
+		icmp := &layers.ICMPv6{}
+		err := icmp.DecodeFromBytes(payload, gopacket.NilDecodeFeedback)
+		if err != nil {
+			return false
+		}
+		return c.Predicate.Eval(&ICMP{
+			Type: icmp.TypeCode.Type(),
+			Code: icmp.TypeCode.Code(),
+		})
+	default:
+		return false
+	}
+}
+
+func (c *CondICMP) Type() string {
+	return TypeCondICMP
+}
+
+func (c *CondICMP) String() string {
+	if c.Predicate == nil {
+		return "<nil>"
+	}
+	return c.Predicate.String()
+}
+
+func (c *CondICMP) MarshalJSON() ([]byte, error) {
+	return marshalInterface(c.Predicate)
+}
+
+func (c *CondICMP) UnmarshalJSON(b []byte) error {
+	var err error
+	c.Predicate, err = unmarshalICMPPredicate(b)
+	return err
+}
+
+// transportLayer returns the type and the raw bytes of the transport layer
+// carried by an IPv4 or IPv6 packet. IPv6 extension headers are skipped. The
+// last return value is false if v is not an IP packet or if the transport
+// header is not part of the packet, e.g., for non-initial fragments.
+func transportLayer(v gopacket.Layer) (gopacket.LayerType, []byte, bool) {
+	switch p := v.(type) {
+	case *layers.IPv4:
+		if p.FragOffset != 0 {
+			return 0, nil, false
+		}
+		return p.NextLayerType(), p.LayerPayload(), true
+	case *layers.IPv6:
+		next, payload := p.NextHeader, p.LayerPayload()
+		// The hop-by-hop options are decoded as part of the IPv6 layer.
+		if p.HopByHop != nil {
+			next = p.HopByHop.NextHeader
+		}
+		for {
+			switch next {
+			case layers.IPProtocolIPv6Routing, layers.IPProtocolIPv6Destination:
+				if len(payload) < 2 {
+					return 0, nil, false
+				}
+				l := (int(payload[1]) + 1) * 8
+				if len(payload) < l {
+					return 0, nil, false
+				}
+				next, payload = layers.IPProtocol(payload[0]), payload[l:]
+			case layers.IPProtocolIPv6Fragment:
+				if len(payload) < 8 {
+					return 0, nil, false
+				}
+				if binary.BigEndian.Uint16(payload[2:4])>>3 != 0 {
+					return 0, nil, false
+				}
+				next, payload = layers.IPProtocol(payload[0]), payload[8:]
+			default:
+				return next.LayerType(), payload, true
+			}
+		}
+	default:
+		return 0, nil, false
+	}
+}
+
 const typeCondClass = "CondClass"
 
 // CondClass conditions return true if the embedded traffic class returns true
@@ -207,6 +207,230 @@ func TestPortCond(t *testing.T) {
 	}
 }
 
+func TestIPv6Cond(t *testing.T) {
+	_, net6, _ := net.ParseCIDR("2001:db8::/32")
+	testCases := map[string]struct {
+		Cond    pktcls.Cond
+		Packet  gopacket.Layer
+		ExpEval bool
+	}{
+		"Match IPv6 source": {
+			Cond:    pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: net6}),
+			Packet:  &layers.IPv6{SrcIP: net.ParseIP("2001:db8::1"), DstIP: net.IPv6loopback},
+			ExpEval: true,
+		},
+		"Do not match IPv6 destination": {
+			Cond:    pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{Net: net6}),
+			Packet:  &layers.IPv6{SrcIP: net.ParseIP("2001:db8::1"), DstIP: net.IPv6loopback},
+			ExpEval: false,
+		},
+		"Match traffic class and flow label": {
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0xbeef}),
+			),
+			Packet:  &layers.IPv6{TrafficClass: 0xb8, FlowLabel: 0xbeef},
+			ExpEval: true,
+		},
+		"Match next header": {
+			Cond:    pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 17}),
+			Packet:  &layers.IPv6{NextHeader: layers.IPProtocolUDP},
+			ExpEval: true,
+		},
+		"IPv6 predicate on IPv4 packet": {
+			Cond:    pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 17}),
+			Packet:  &layers.IPv4{Protocol: layers.IPProtocolUDP},
+			ExpEval: false,
+		},
+		"IPv4 predicate on IPv6 packet": {
+			Cond:    pktcls.NewCondIPv4(&pktcls.IPv4MatchProtocol{Protocol: 17}),
+			Packet:  &layers.IPv6{NextHeader: layers.IPProtocolUDP},
+			ExpEval: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(tc.Packet))
+		})
+	}
+}
+
+func TestPortCondIPv6(t *testing.T) {
+	cond := pktcls.NewCondPorts(&pktcls.PortMatchDestination{MinPort: 53, MaxPort: 53})
+	udp := &layers.UDP{SrcPort: 4242, DstPort: 53}
+	testCases := map[string]struct {
+		NextHeader layers.IPProtocol
+		ExtHeader  []byte
+		ExpEval    bool
+	}{
+		"no extension headers": {
+			NextHeader: layers.IPProtocolUDP,
+			ExpEval:    true,
+		},
+		"destination options": {
+			NextHeader: layers.IPProtocolIPv6Destination,
+			// Next header UDP, followed by a PadN option.
+			ExtHeader: []byte{17, 0, 1, 4, 0, 0, 0, 0},
+			ExpEval:   true,
+		},
+		"first fragment": {
+			NextHeader: layers.IPProtocolIPv6Fragment,
+			// Next header UDP, offset 0 and more fragments flag set.
+			ExtHeader: []byte{17, 0, 0, 1, 0, 0, 0, 1},
+			ExpEval:   true,
+		},
+		"non-initial fragment": {
+			NextHeader: layers.IPProtocolIPv6Fragment,
+			// Next header UDP, offset 2.
+			ExtHeader: []byte{17, 0, 0, 0x10, 0, 0, 0, 1},
+			ExpEval:   false,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			pkt := createIPv6Packet(tc.NextHeader, gopacket.Payload(tc.ExtHeader), udp)
+			assert.Equal(t, tc.ExpEval, cond.Eval(pkt))
+		})
+	}
+}
+
+func TestTCPCond(t *testing.T) {
+	syn := &layers.TCP{SrcPort: 4242, DstPort: 80, SYN: true, DataOffset: 5}
+	synAck := &layers.TCP{SrcPort: 80, DstPort: 4242, SYN: true, ACK: true, DataOffset: 5}
+	testCases := map[string]struct {
+		Cond    pktcls.Cond
+		Packet  gopacket.Layer
+		ExpEval bool
+	}{
+		"Match SYN over IPv4": {
+			Cond:    pktcls.NewCondTCP(&pktcls.TCPMatchFlags{Flags: 0x02, Mask: 0xff}),
+			Packet:  createIPv4Packet(layers.IPProtocolTCP, syn),
+			ExpEval: true,
+		},
+		"Match SYN over IPv6": {
+			Cond:    pktcls.NewCondTCP(&pktcls.TCPMatchFlags{Flags: 0x02, Mask: 0xff}),
+			Packet:  createIPv6Packet(layers.IPProtocolTCP, syn),
+			ExpEval: true,
+		},
+		"Do not match SYN-ACK exactly": {
+			Cond:    pktcls.NewCondTCP(&pktcls.TCPMatchFlags{Flags: 0x02, Mask: 0xff}),
+			Packet:  createIPv6Packet(layers.IPProtocolTCP, synAck),
+			ExpEval: false,
+		},
+		"Match SYN-ACK with mask": {
+			Cond:    pktcls.NewCondTCP(&pktcls.TCPMatchFlags{Flags: 0x02, Mask: 0x02}),
+			Packet:  createIPv4Packet(layers.IPProtocolTCP, synAck),
+			ExpEval: true,
+		},
+		"Do not match UDP": {
+			Cond: pktcls.NewCondTCP(&pktcls.TCPMatchFlags{Flags: 0x00, Mask: 0x00}),
+			Packet: createIPv4Packet(layers.IPProtocolUDP,
+				&layers.UDP{SrcPort: 4242, DstPort: 80}),
+			ExpEval: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(tc.Packet))
+		})
+	}
+}
+
+func TestICMPCond(t *testing.T) {
+	echo4 := &layers.ICMPv4{
+		TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoRequest, 0),
+	}
+	unreach6 := &layers.ICMPv6{
+		TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypeDestinationUnreachable,
+			layers.ICMPv6CodePortUnreachable),
+	}
+	testCases := map[string]struct {
+		Cond    pktcls.Cond
+		Packet  gopacket.Layer
+		ExpEval bool
+	}{
+		"Match ICMPv4 type": {
+			Cond:    pktcls.NewCondICMP(&pktcls.ICMPMatchType{ICMPType: 8}),
+			Packet:  createIPv4Packet(layers.IPProtocolICMPv4, echo4),
+			ExpEval: true,
+		},
+		"Match ICMPv6 type and code": {
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondICMP(&pktcls.ICMPMatchType{ICMPType: 1}),
+				pktcls.NewCondICMP(&pktcls.ICMPMatchCode{ICMPCode: 4}),
+			),
+			Packet:  createIPv6Packet(layers.IPProtocolICMPv6, unreach6),
+			ExpEval: true,
+		},
+		"Do not match ICMPv6 code": {
+			Cond:    pktcls.NewCondICMP(&pktcls.ICMPMatchCode{ICMPCode: 0}),
+			Packet:  createIPv6Packet(layers.IPProtocolICMPv6, unreach6),
+			ExpEval: false,
+		},
+		"Do not match TCP": {
+			Cond: pktcls.NewCondICMP(&pktcls.ICMPMatchType{ICMPType: 0}),
+			Packet: createIPv4Packet(layers.IPProtocolTCP,
+				&layers.TCP{SrcPort: 4242, DstPort: 80, DataOffset: 5}),
+			ExpEval: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(tc.Packet))
+		})
+	}
+}
+
+func createIPv4Packet(proto layers.IPProtocol, l4 gopacket.SerializableLayer) gopacket.Layer {
+	ip := &layers.IPv4{
+		Version:  4,
+		IHL:      5,
+		TTL:      64,
+		SrcIP:    net.IP{192, 168, 14, 3},
+		DstIP:    net.IP{192, 168, 14, 2},
+		Protocol: proto,
+	}
+	input := gopacket.NewSerializeBuffer()
+	options := gopacket.SerializeOptions{FixLengths: true}
+	if err := gopacket.SerializeLayers(input, options, ip, l4); err != nil {
+		panic(err)
+	}
+	pkt := &layers.IPv4{}
+	pkt.DecodeFromBytes(input.Bytes(), gopacket.NilDecodeFeedback)
+	return pkt
+}
+
+func createIPv6Packet(next layers.IPProtocol, l ...gopacket.SerializableLayer) gopacket.Layer {
+	ip := &layers.IPv6{
+		Version:    6,
+		HopLimit:   64,
+		SrcIP:      net.ParseIP("2001:db8::1"),
+		DstIP:      net.ParseIP("2001:db8::2"),
+		NextHeader: next,
+	}
+	input := gopacket.NewSerializeBuffer()
+	options := gopacket.SerializeOptions{FixLengths: true}
+	l = append([]gopacket.SerializableLayer{ip}, l...)
+	if err := gopacket.SerializeLayers(input, options, l...); err != nil {
+		panic(err)
+	}
+	pkt := &layers.IPv6{}
+	pkt.DecodeFromBytes(input.Bytes(), gopacket.NilDecodeFeedback)
+	return pkt
+}
+
 func createUDPPacket(src, dst uint16) gopacket.Layer {
 	ip := &layers.IPv4{
 		Version:  4,
@@ -238,6 +462,7 @@ func createUDPPacket(src, dst uint16) gopacket.Layer {
 }
 
 func TestStringer(t *testing.T) {
+	_, net6, _ := net.ParseCIDR("2001:db8::/32")
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
 	tests := map[string]struct {
 		Cond pktcls.Cond
@@ -264,6 +489,20 @@ func TestStringer(t *testing.T) {
 				},
 			},
 		},
+		"ANY IPv6 TCP ICMP": {
+			Str: "any(src=2001:db8::/32,tc=0xb8,flowlabel=7,nexthdr=ICMPv6," +
+				"tcpflags=0x2,tcpflags=0x12/0x3f,icmptype=128,icmpcode=0)",
+			Cond: pktcls.CondAnyOf{
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: net6}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 7}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 58}),
+				pktcls.NewCondTCP(&pktcls.TCPMatchFlags{Flags: 0x2, Mask: 0xff}),
+				pktcls.NewCondTCP(&pktcls.TCPMatchFlags{Flags: 0x12, Mask: 0x3f}),
+				pktcls.NewCondICMP(&pktcls.ICMPMatchType{ICMPType: 128}),
+				pktcls.NewCondICMP(&pktcls.ICMPMatchCode{ICMPCode: 0}),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -20,14 +20,21 @@
 // true for a ClsPkt, that packet is considered to be part of that class.
 //
 // The following conditions are supported:
-// AnyOf, AllOf, Boolean true, Boolean false and IPv4. AnyOf returns true if at
-// least one subcondition returns true. AllOf returns true if all subconditions
-// return true.  AllOf or AnyOf without subconditions return true. Boolean
-// conditions always return their internal value. IPv4 conditions include
-// predicates that compare the analyzed packet to preset values. Supported IPv4
-// conditions currently include destination network match, source network match
-// and ToS/DSCP fields match. Multiple predicates can be checked by enumerating
-// them under AllOf or AnyOf.
+// AnyOf, AllOf, Boolean true, Boolean false, IPv4, IPv6, Ports, TCP and ICMP.
+// AnyOf returns true if at least one subcondition returns true. AllOf returns
+// true if all subconditions return true.  AllOf or AnyOf without subconditions
+// return true. Boolean conditions always return their internal value. IPv4 and
+// IPv6 conditions include predicates that compare the analyzed packet to preset
+// values. Supported IPv4 conditions currently include destination network
+// match, source network match, ToS/DSCP fields match and protocol match.
+// Supported IPv6 conditions include destination network match, source network
+// match, traffic class, flow label and next header match. IPv4 conditions never
+// match IPv6 packets and vice versa. Ports, TCP and ICMP conditions work for
+// both IPv4 and IPv6 packets; for IPv6, extension headers are skipped to find
+// the transport header. TCP conditions match the TCP flags, optionally under a
+// mask, and ICMP conditions match the type or code of ICMPv4 and ICMPv6
+// messages. Multiple predicates can be checked by enumerating them under AllOf
+// or AnyOf.
 //
 // The package contains support for JSON marshaling and unmarshaling of
 // classes. Due to the custom formatting of the JSON output, marshaling must be
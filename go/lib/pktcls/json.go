@@ -41,19 +41,30 @@ type Typer interface {
 // concrete type is unmarshaled.
 
 const (
-	TypeCondAllOf            = "CondAllOf"
-	TypeCondAnyOf            = "CondAnyOf"
-	TypeCondNot              = "CondNot"
-	TypeCondBool             = "CondBool"
-	TypeCondIPv4             = "CondIPv4"
-	TypeIPv4MatchSource      = "MatchSource"
-	TypeIPv4MatchDestination = "MatchDestination"
-	TypeIPv4MatchToS         = "MatchToS"
-	TypeIPv4MatchDSCP        = "MatchDSCP"
-	TypeIPv4MatchProtocol    = "MatchProtocol"
-	TypeCondPorts            = "CondPorts"
-	TypePortMatchSource      = "MatchSourcePort"
-	TypePortMatchDestination = "MatchDestinationPort"
+	TypeCondAllOf             = "CondAllOf"
+	TypeCondAnyOf             = "CondAnyOf"
+	TypeCondNot               = "CondNot"
+	TypeCondBool              = "CondBool"
+	TypeCondIPv4              = "CondIPv4"
+	TypeIPv4MatchSource       = "MatchSource"
+	TypeIPv4MatchDestination  = "MatchDestination"
+	TypeIPv4MatchToS          = "MatchToS"
+	TypeIPv4MatchDSCP         = "MatchDSCP"
+	TypeIPv4MatchProtocol     = "MatchProtocol"
+	TypeCondPorts             = "CondPorts"
+	TypePortMatchSource       = "MatchSourcePort"
+	TypePortMatchDestination  = "MatchDestinationPort"
+	TypeCondIPv6              = "CondIPv6"
+	TypeIPv6MatchSource       = "MatchSourceIPv6"
+	TypeIPv6MatchDestination  = "MatchDestinationIPv6"
+	TypeIPv6MatchTrafficClass = "MatchTrafficClass"
+	TypeIPv6MatchFlowLabel    = "MatchFlowLabel"
+	TypeIPv6MatchNextHeader   = "MatchNextHeader"
+	TypeCondTCP               = "CondTCP"
+	TypeTCPMatchFlags         = "MatchTCPFlags"
+	TypeCondICMP              = "CondICMP"
+	TypeICMPMatchType         = "MatchICMPType"
+	TypeICMPMatchCode         = "MatchICMPCode"
 )
 
 // generic container for marshaling custom data
@@ -132,6 +143,50 @@ func unmarshalInterface(b []byte) (Typer, error) {
 			var p PortMatchDestination
 			err := json.Unmarshal(*v, &p)
 			return &p, err
+		case TypeCondIPv6:
+			var c CondIPv6
+			err := json.Unmarshal(*v, &c)
+			return &c, err
+		case TypeIPv6MatchSource:
+			var p IPv6MatchSource
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchDestination:
+			var p IPv6MatchDestination
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchTrafficClass:
+			var p IPv6MatchTrafficClass
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchFlowLabel:
+			var p IPv6MatchFlowLabel
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchNextHeader:
+			var p IPv6MatchNextHeader
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeCondTCP:
+			var c CondTCP
+			err := json.Unmarshal(*v, &c)
+			return &c, err
+		case TypeTCPMatchFlags:
+			var p TCPMatchFlags
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeCondICMP:
+			var c CondICMP
+			err := json.Unmarshal(*v, &c)
+			return &c, err
+		case TypeICMPMatchType:
+			var p ICMPMatchType
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeICMPMatchCode:
+			var p ICMPMatchCode
+			err := json.Unmarshal(*v, &p)
+			return &p, err
 		default:
 			return nil, serrors.New("Unknown type", "type", k)
 		}
@@ -178,6 +233,45 @@ func unmarshalPortPredicate(b []byte) (PortPredicate, error) {
 	return p, nil
 }
 
+// unmarshalIPv6Predicate extracts an IPv6Predicate from a JSON encoding
+func unmarshalIPv6Predicate(b []byte) (IPv6Predicate, error) {
+	t, err := unmarshalInterface(b)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := t.(IPv6Predicate)
+	if !ok {
+		return nil, serrors.New("Unable to extract IPv6Predicate from interface")
+	}
+	return p, nil
+}
+
+// unmarshalTCPPredicate extracts a TCPPredicate from a JSON encoding
+func unmarshalTCPPredicate(b []byte) (TCPPredicate, error) {
+	t, err := unmarshalInterface(b)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := t.(TCPPredicate)
+	if !ok {
+		return nil, serrors.New("Unable to extract TCPPredicate from interface")
+	}
+	return p, nil
+}
+
+// unmarshalICMPPredicate extracts an ICMPPredicate from a JSON encoding
+func unmarshalICMPPredicate(b []byte) (ICMPPredicate, error) {
+	t, err := unmarshalInterface(b)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := t.(ICMPPredicate)
+	if !ok {
+		return nil, serrors.New("Unable to extract ICMPPredicate from interface")
+	}
+	return p, nil
+}
+
 // Special case slices because we only need them for Conds
 
 func marshalCondSlice(conds []Cond) ([]byte, error) {
@@ -187,6 +187,132 @@ func (l *classListener) EnterMatchDstPortRange(ctx *traffic_class.MatchDstPortRa
 	l.pushCond(NewCondPorts(dst))
 }
 
+func (l *classListener) EnterMatchSrc6(ctx *traffic_class.MatchSrc6Context) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	var err error
+	msrc := &IPv6MatchSource{}
+	msrc.Net, err = parseIPv6Net(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = err
+	}
+	l.pushCond(NewCondIPv6(msrc))
+}
+
+func (l *classListener) EnterMatchDst6(ctx *traffic_class.MatchDst6Context) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	var err error
+	mdst := &IPv6MatchDestination{}
+	mdst.Net, err = parseIPv6Net(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = err
+	}
+	l.pushCond(NewCondIPv6(mdst))
+}
+
+func (l *classListener) EnterMatchTC(ctx *traffic_class.MatchTCContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mtc := &IPv6MatchTrafficClass{}
+	tc, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("TC parsing failed!", err, "tc", ctx.GetStop().GetText())
+	}
+	mtc.TrafficClass = uint8(tc)
+	l.pushCond(NewCondIPv6(mtc))
+}
+
+func (l *classListener) EnterMatchFlowLabel(ctx *traffic_class.MatchFlowLabelContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mfl := &IPv6MatchFlowLabel{}
+	fl, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 20)
+	if err != nil {
+		l.err = serrors.WrapStr("FLOWLABEL parsing failed!", err,
+			"flowlabel", ctx.GetStop().GetText())
+	}
+	mfl.FlowLabel = uint32(fl)
+	l.pushCond(NewCondIPv6(mfl))
+}
+
+func (l *classListener) EnterMatchNextHdr(ctx *traffic_class.MatchNextHdrContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mnh := &IPv6MatchNextHeader{}
+	number, err := protocolNameToNumber(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = serrors.WrapStr("NEXTHDR parsing failed!", err,
+			"nexthdr", ctx.GetStop().GetText())
+	}
+	mnh.NextHeader = number
+	l.pushCond(NewCondIPv6(mnh))
+}
+
+func (l *classListener) EnterMatchTCPFlags(ctx *traffic_class.MatchTCPFlagsContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mflags := &TCPMatchFlags{Mask: 0xff}
+	flags, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("TCPFLAGS parsing failed!", err,
+			"tcpflags", ctx.GetStop().GetText())
+	}
+	mflags.Flags = uint8(flags)
+	l.pushCond(NewCondTCP(mflags))
+}
+
+func (l *classListener) EnterMatchTCPFlagsMask(ctx *traffic_class.MatchTCPFlagsMaskContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mflags := &TCPMatchFlags{}
+	// Both the flags and the mask can be lexed as either DIGITS or HEX_DIGITS.
+	var values []string
+	for _, child := range ctx.GetChildren() {
+		t, ok := child.(antlr.TerminalNode)
+		if !ok {
+			continue
+		}
+		switch t.GetSymbol().GetTokenType() {
+		case traffic_class.TrafficClassLexerDIGITS, traffic_class.TrafficClassLexerHEX_DIGITS:
+			values = append(values, t.GetText())
+		}
+	}
+	if len(values) != 2 {
+		l.err = serrors.New("TCPFLAGS parsing failed!", "tcpflags", ctx.GetText())
+		l.pushCond(NewCondTCP(mflags))
+		return
+	}
+	flags, err := strconv.ParseUint(values[0], 16, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("TCPFLAGS parsing failed!", err, "tcpflags", values[0])
+	}
+	mask, err := strconv.ParseUint(values[1], 16, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("TCPFLAGS parsing failed!", err, "mask", values[1])
+	}
+	mflags.Flags = uint8(flags)
+	mflags.Mask = uint8(mask)
+	l.pushCond(NewCondTCP(mflags))
+}
+
+func (l *classListener) EnterMatchICMPType(ctx *traffic_class.MatchICMPTypeContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mtype := &ICMPMatchType{}
+	t, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("ICMPTYPE parsing failed!", err,
+			"icmptype", ctx.GetStop().GetText())
+	}
+	mtype.ICMPType = uint8(t)
+	l.pushCond(NewCondICMP(mtype))
+}
+
+func (l *classListener) EnterMatchICMPCode(ctx *traffic_class.MatchICMPCodeContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mcode := &ICMPMatchCode{}
+	c, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("ICMPCODE parsing failed!", err,
+			"icmpcode", ctx.GetStop().GetText())
+	}
+	mcode.ICMPCode = uint8(c)
+	l.pushCond(NewCondICMP(mcode))
+}
+
 func (l *classListener) EnterCondCls(ctx *traffic_class.CondClsContext) {
 	l.pushCond(CondClass{TrafficClass: ctx.GetStop().GetText()})
 }
@@ -282,6 +408,18 @@ func buildTrafficClassParser(class string) *traffic_class.TrafficClassParser {
 	return parser
 }
 
+// parseIPv6Net parses an IPv6 network in CIDR notation.
+func parseIPv6Net(cidr string) (*net.IPNet, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, serrors.WrapStr("CIDR parsing failed!", err, "cidr", cidr)
+	}
+	if ip.To4() != nil {
+		return nil, serrors.New("CIDR is not an IPv6 network", "cidr", cidr)
+	}
+	return network, nil
+}
+
 // protocolNameToNumber converts protocol name (e.g. "TCP") to IP protcol
 // number. The function is case insensitive.
 func protocolNameToNumber(name string) (uint8, error) {
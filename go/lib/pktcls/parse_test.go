@@ -119,6 +119,81 @@ func TestTrafficClassValidation(t *testing.T) {
 			Class: "ANY(dscp=0x2,ALL(dst=12.12.12.0/24,dscp=0x2, NOT(src=2.2.2.0/28)))",
 			Valid: true,
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Valid: true,
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=::/0",
+			Valid: true,
+		},
+		{
+			Name:  "bad dst IPv6Cond",
+			Class: "dst=2001:db8::",
+			Valid: false,
+		},
+		{
+			Name:  "IPv4-mapped dst IPv6Cond",
+			Class: "dst=::ffff:10.0.0.0/104",
+			Valid: false,
+		},
+		{
+			Name:  "tc IPv6Cond",
+			Class: "tc=0xb8",
+			Valid: true,
+		},
+		{
+			Name:  "bad tc IPv6Cond",
+			Class: "tc=184",
+			Valid: false,
+		},
+		{
+			Name:  "flowlabel IPv6Cond",
+			Class: "flowlabel=1048575",
+			Valid: true,
+		},
+		{
+			Name:  "flowlabel IPv6Cond overflow",
+			Class: "flowlabel=1048576",
+			Valid: false,
+		},
+		{
+			Name:  "nexthdr IPv6Cond",
+			Class: "nexthdr=ICMPv6",
+			Valid: true,
+		},
+		{
+			Name:  "tcpflags",
+			Class: "tcpflags=0x2",
+			Valid: true,
+		},
+		{
+			Name:  "tcpflags with mask",
+			Class: "tcpflags=0x12/0x3f",
+			Valid: true,
+		},
+		{
+			Name:  "bad tcpflags mask",
+			Class: "tcpflags=0x12/0x",
+			Valid: false,
+		},
+		{
+			Name:  "icmptype",
+			Class: "icmptype=8",
+			Valid: true,
+		},
+		{
+			Name:  "icmpcode overflow",
+			Class: "icmpcode=256",
+			Valid: false,
+		},
+		{
+			Name:  "ALL IPv4 IPv6 TCP",
+			Class: "ALL(src=10.0.0.0/8,dst=2001:db8::/32,tcpflags=0x2/0x12)",
+			Valid: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -134,6 +209,7 @@ func TestTrafficClassValidation(t *testing.T) {
 }
 
 func TestTrafficClassTree(t *testing.T) {
+	_, net6, _ := net.ParseCIDR("2001:db8::/32")
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
 	testCases := []struct {
 		Name  string
@@ -257,6 +333,63 @@ func TestTrafficClassTree(t *testing.T) {
 			Class: "protocol=udp",
 			Tree:  pktcls.NewCondIPv4(&pktcls.IPv4MatchProtocol{Protocol: uint8(17)}),
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchSource{Net: net6},
+			),
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "DST=2001:db8::/32",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchDestination{Net: net6},
+			),
+		},
+		{
+			Name:  "tc IPv6Cond",
+			Class: "tc=0xb8",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+		},
+		{
+			Name:  "flowlabel IPv6Cond",
+			Class: "flowlabel=12345",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 12345}),
+		},
+		{
+			Name:  "nexthdr IPv6Cond",
+			Class: "nexthdr=ICMPv6",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 58}),
+		},
+		{
+			Name:  "tcpflags",
+			Class: "tcpflags=0x2",
+			Tree:  pktcls.NewCondTCP(&pktcls.TCPMatchFlags{Flags: 0x2, Mask: 0xff}),
+		},
+		{
+			Name:  "tcpflags with mask",
+			Class: "tcpflags=0x12/0x3f",
+			Tree:  pktcls.NewCondTCP(&pktcls.TCPMatchFlags{Flags: 0x12, Mask: 0x3f}),
+		},
+		{
+			Name:  "icmptype",
+			Class: "icmptype=8",
+			Tree:  pktcls.NewCondICMP(&pktcls.ICMPMatchType{ICMPType: 8}),
+		},
+		{
+			Name:  "icmpcode",
+			Class: "icmpcode=3",
+			Tree:  pktcls.NewCondICMP(&pktcls.ICMPMatchCode{ICMPCode: 3}),
+		},
+		{
+			Name:  "ANY IPv4 IPv6",
+			Class: "ANY(src=12.12.12.0/26,src=2001:db8::/32)",
+			Tree: pktcls.CondAnyOf{
+				pktcls.NewCondIPv4(&pktcls.IPv4MatchSource{Net: net}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: net6}),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
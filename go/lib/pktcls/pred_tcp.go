@@ -0,0 +1,114 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pktcls
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/gopacket/layers"
+)
+
+// TCP flag bits as they appear in the 14th byte of the TCP header.
+const (
+	TCPFlagFIN uint8 = 1 << iota
+	TCPFlagSYN
+	TCPFlagRST
+	TCPFlagPSH
+	TCPFlagACK
+	TCPFlagURG
+	TCPFlagECE
+	TCPFlagCWR
+)
+
+// TCPPredicate describes a single test on TCP header fields.
+type TCPPredicate interface {
+	// Eval returns true if the TCP segment matched the predicate
+	Eval(*layers.TCP) bool
+	Typer
+	fmt.Stringer
+}
+
+var _ TCPPredicate = (*TCPMatchFlags)(nil)
+
+// TCPMatchFlags checks whether the TCP flags selected by Mask are equal to the
+// corresponding bits in Flags. A Mask of 0xff requires all flags to match
+// exactly.
+type TCPMatchFlags struct {
+	Flags uint8
+	Mask  uint8
+}
+
+func (m *TCPMatchFlags) Type() string {
+	return TypeTCPMatchFlags
+}
+
+func (m *TCPMatchFlags) Eval(p *layers.TCP) bool {
+	return tcpFlags(p)&m.Mask == m.Flags&m.Mask
+}
+
+func (m *TCPMatchFlags) String() string {
+	if m.Mask == 0xff {
+		return fmt.Sprintf("tcpflags=%#x", m.Flags)
+	}
+	return fmt.Sprintf("tcpflags=%#x/%#x", m.Flags, m.Mask)
+}
+
+func (m *TCPMatchFlags) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Flags": fmt.Sprintf("%#x", m.Flags),
+			"Mask":  fmt.Sprintf("%#x", m.Mask),
+		},
+	)
+}
+
+func (m *TCPMatchFlags) UnmarshalJSON(b []byte) error {
+	// Format is 0x hex number in quoted string
+	flags, err := unmarshalUintField(b, TypeTCPMatchFlags, "Flags", 8)
+	if err != nil {
+		return err
+	}
+	mask, err := unmarshalUintField(b, TypeTCPMatchFlags, "Mask", 8)
+	if err != nil {
+		return err
+	}
+	m.Flags = uint8(flags)
+	m.Mask = uint8(mask)
+	return nil
+}
+
+// tcpFlags returns the flags of the TCP segment in wire format.
+func tcpFlags(p *layers.TCP) uint8 {
+	var flags uint8
+	for _, f := range []struct {
+		set bool
+		bit uint8
+	}{
+		{p.FIN, TCPFlagFIN},
+		{p.SYN, TCPFlagSYN},
+		{p.RST, TCPFlagRST},
+		{p.PSH, TCPFlagPSH},
+		{p.ACK, TCPFlagACK},
+		{p.URG, TCPFlagURG},
+		{p.ECE, TCPFlagECE},
+		{p.CWR, TCPFlagCWR},
+	} {
+		if f.set {
+			flags |= f.bit
+		}
+	}
+	return flags
+}
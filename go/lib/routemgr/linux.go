@@ -51,6 +51,12 @@ func (l *Linux) NewPublisher() control.Publisher {
 	return l.exportedRoutes.NewPublisher()
 }
 
+// NewConsumer creates a consumer for the routes that are exported to Linux.
+// It can be used to export the same routes to additional routing backends.
+func (l *Linux) NewConsumer() control.Consumer {
+	return l.exportedRoutes.NewConsumer()
+}
+
 func (l *Linux) Close() {
 	l.init()
 	close(l.closeChan)
@@ -114,6 +114,11 @@ type PathMetadata struct {
 	// Notes contains the notes added by ASes on the path, in the order of occurrence.
 	// Entry i is the note of AS i on the path.
 	Notes []string
+
+	// Quality is the quality that applications on the host observed on the
+	// path, as aggregated by the SCION daemon. It is nil if no quality is
+	// known.
+	Quality *PathQuality
 }
 
 func (pm *PathMetadata) Copy() *PathMetadata {
@@ -130,7 +135,27 @@ func (pm *PathMetadata) Copy() *PathMetadata {
 		LinkType:     append(pm.LinkType[:0:0], pm.LinkType...),
 		InternalHops: append(pm.InternalHops[:0:0], pm.InternalHops...),
 		Notes:        append(pm.Notes[:0:0], pm.Notes...),
+		Quality:      pm.Quality.Copy(),
+	}
+}
+
+// PathQuality is the quality that was observed on a path.
+type PathQuality struct {
+	// Latency is the smoothed round-trip latency. It is zero if no probe was
+	// ever received.
+	Latency time.Duration
+	// Loss is the smoothed fraction of lost probes, in the range [0, 1].
+	Loss float64
+	// LastReport is the point in time of the most recent report.
+	LastReport time.Time
+}
+
+func (pq *PathQuality) Copy() *PathQuality {
+	if pq == nil {
+		return nil
 	}
+	c := *pq
+	return &c
 }
 
 // LinkType describes the underlying network for inter-domain links.
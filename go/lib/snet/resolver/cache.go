@@ -0,0 +1,134 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+const (
+	// DefaultCacheTTL is the default time a resolved name is cached for.
+	DefaultCacheTTL = 5 * time.Minute
+	// DefaultCacheMaxEntries is the default maximum number of cached names.
+	DefaultCacheMaxEntries = 1024
+)
+
+// Cache caches the successful lookups of the underlying resolver. It is
+// intended for long-running processes, such as the SCION daemon, that
+// resolve the same names repeatedly. Failed lookups are not cached. If the
+// cache is full, the least recently used name is evicted.
+type Cache struct {
+	// Resolver is the underlying resolver.
+	Resolver Resolver
+	// TTL is the time a resolved name is cached for. If zero, DefaultCacheTTL
+	// is used.
+	TTL time.Duration
+	// MaxEntries is the maximum number of cached names. If zero,
+	// DefaultCacheMaxEntries is used.
+	MaxEntries int
+
+	mtx     sync.Mutex
+	entries map[string]*list.Element
+	// lru keeps the entries ordered by last use, the most recently used entry
+	// is at the front.
+	lru list.List
+}
+
+type cacheEntry struct {
+	name   string
+	addrs  []*snet.UDPAddr
+	expiry time.Time
+}
+
+// LookupHost returns the SCION addresses of the host.
+func (c *Cache) LookupHost(ctx context.Context, name string) ([]*snet.UDPAddr, error) {
+	key := strings.ToLower(name)
+	if addrs, ok := c.get(key, time.Now()); ok {
+		return addrs, nil
+	}
+
+	addrs, err := c.Resolver.LookupHost(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	ttl := c.TTL
+	if ttl == 0 {
+		ttl = DefaultCacheTTL
+	}
+	c.add(&cacheEntry{name: key, addrs: copyAddrs(addrs), expiry: time.Now().Add(ttl)})
+	return addrs, nil
+}
+
+// Len returns the number of cached names.
+func (c *Cache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return len(c.entries)
+}
+
+func (c *Cache) get(key string, now time.Time) ([]*snet.UDPAddr, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*cacheEntry)
+	if !now.Before(e.expiry) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return copyAddrs(e.addrs), true
+}
+
+func (c *Cache) add(e *cacheEntry) {
+	maxEntries := c.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheMaxEntries
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]*list.Element)
+	}
+	if elem, ok := c.entries[e.name]; ok {
+		elem.Value = e
+		c.lru.MoveToFront(elem)
+		return
+	}
+	for len(c.entries) >= maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).name)
+	}
+	c.entries[e.name] = c.lru.PushFront(e)
+}
+
+func copyAddrs(addrs []*snet.UDPAddr) []*snet.UDPAddr {
+	cp := make([]*snet.UDPAddr, 0, len(addrs))
+	for _, a := range addrs {
+		cp = append(cp, a.Copy())
+	}
+	return cp
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// DaemonAPIEnv is the environment variable that contains the address of the
+// REST API of the SCION daemon (host:port) that is used by Default.
+const DaemonAPIEnv = "SCION_DAEMON_API"
+
+// Daemon resolves names with the REST API of the SCION daemon, i.e., with the
+// /api/v1/hosts/{name} endpoint. The daemon caches the results of its own
+// resolvers, such that repeated lookups of short-lived processes, e.g., the
+// scion tools, do not query DNS every time.
+type Daemon struct {
+	// Address is the address of the REST API of the daemon (host:port). If
+	// empty, no name is found.
+	Address string
+	// Client is the HTTP client used for the lookups. If nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+}
+
+// LookupHost returns the SCION addresses of the host.
+func (d *Daemon) LookupHost(ctx context.Context, name string) ([]*snet.UDPAddr, error) {
+	if d.Address == "" {
+		return nil, serrors.WithCtx(ErrNotFound, "name", name)
+	}
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	u := url.URL{
+		Scheme: "http",
+		Host:   d.Address,
+		Path:   "/api/v1/hosts/" + name,
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, serrors.WrapStr("creating request", err, "name", name)
+	}
+	rep, err := client.Do(req)
+	if err != nil {
+		return nil, serrors.WrapStr("querying daemon", err, "name", name)
+	}
+	defer rep.Body.Close()
+	switch rep.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, serrors.WithCtx(ErrNotFound, "name", name)
+	default:
+		return nil, serrors.New("querying daemon", "name", name, "status", rep.Status)
+	}
+	var hosts struct {
+		Addresses []string `json:"addresses"`
+	}
+	if err := json.NewDecoder(rep.Body).Decode(&hosts); err != nil {
+		return nil, serrors.WrapStr("decoding daemon response", err, "name", name)
+	}
+	addrs := make([]*snet.UDPAddr, 0, len(hosts.Addresses))
+	for _, s := range hosts.Addresses {
+		a, err := parseAddr(s)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing daemon response", err, "name", name)
+		}
+		addrs = append(addrs, a)
+	}
+	if len(addrs) == 0 {
+		return nil, serrors.WithCtx(ErrNotFound, "name", name)
+	}
+	return addrs, nil
+}
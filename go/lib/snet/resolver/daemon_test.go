@@ -0,0 +1,96 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/snet/resolver"
+)
+
+func TestDaemonLookupHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/api/v1/hosts/") {
+		case "server":
+			fmt.Fprint(w, `{"addresses": ["1-ff00:0:110,10.0.0.1", "1-ff00:0:111,[f00d::1]"]}`)
+		case "malformed":
+			fmt.Fprint(w, `{"addresses": ["malformed"]}`)
+		case "failing":
+			http.Error(w, "internal", http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	address := strings.TrimPrefix(srv.URL, "http://")
+
+	testCases := map[string]struct {
+		Address      string
+		Name         string
+		Expected     []string
+		ErrAssertion assert.ErrorAssertionFunc
+		NotFound     bool
+	}{
+		"found": {
+			Address: address,
+			Name:    "server",
+			Expected: []string{
+				"1-ff00:0:110,10.0.0.1:0",
+				"1-ff00:0:111,[f00d::1]:0",
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"not found": {
+			Address:      address,
+			Name:         "unknown",
+			ErrAssertion: assert.Error,
+			NotFound:     true,
+		},
+		"malformed": {
+			Address:      address,
+			Name:         "malformed",
+			ErrAssertion: assert.Error,
+		},
+		"failing": {
+			Address:      address,
+			Name:         "failing",
+			ErrAssertion: assert.Error,
+		},
+		"no address": {
+			Name:         "server",
+			ErrAssertion: assert.Error,
+			NotFound:     true,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d := &resolver.Daemon{Address: tc.Address}
+			addrs, err := d.LookupHost(context.Background(), tc.Name)
+			tc.ErrAssertion(t, err)
+			assert.Equal(t, tc.NotFound, errors.Is(err, resolver.ErrNotFound))
+			assert.Equal(t, tc.Expected, addrStrings(addrs))
+		})
+	}
+}
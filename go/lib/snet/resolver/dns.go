@@ -0,0 +1,71 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// txtPrefix is the prefix of TXT records that contain a SCION address.
+const txtPrefix = "scion="
+
+// DNS resolves names with DNS TXT records of the form "scion=ISD-AS,IP",
+// e.g., "scion=1-ff00:0:110,[10.0.0.1]". TXT records without the prefix are
+// ignored, malformed records are logged and skipped.
+type DNS struct {
+	// Resolver is used to look up the TXT records. If nil,
+	// net.DefaultResolver is used.
+	Resolver *net.Resolver
+}
+
+// LookupHost returns the SCION addresses of the host.
+func (d *DNS) LookupHost(ctx context.Context, name string) ([]*snet.UDPAddr, error) {
+	r := d.Resolver
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	records, err := r.LookupTXT(ctx, name)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, serrors.WithCtx(ErrNotFound, "name", name)
+		}
+		return nil, serrors.WrapStr("looking up TXT records", err, "name", name)
+	}
+	var addrs []*snet.UDPAddr
+	for _, record := range records {
+		if !strings.HasPrefix(record, txtPrefix) {
+			continue
+		}
+		a, err := parseAddr(strings.TrimPrefix(record, txtPrefix))
+		if err != nil {
+			log.FromCtx(ctx).Debug("Ignoring malformed TXT record", "name", name,
+				"record", record, "err", err)
+			continue
+		}
+		addrs = append(addrs, a)
+	}
+	if len(addrs) == 0 {
+		return nil, serrors.WithCtx(ErrNotFound, "name", name)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/scionproto/scion/go/lib/snet/resolver"
+)
+
+func TestDNSLookupHost(t *testing.T) {
+	r := startDNSServer(t, map[string][]string{
+		"server.example.org.": {"scion=1-ff00:0:110,[10.0.0.1]"},
+		"mixed.example.org.": {
+			"v=spf1 -all",
+			"scion=1-ff00:0:110,10.0.0.1",
+			"scion=malformed",
+			"scion=1-ff00:0:111,[f00d::1]",
+		},
+		"other.example.org.": {"v=spf1 -all"},
+	})
+
+	testCases := map[string]struct {
+		Name         string
+		Expected     []string
+		ErrAssertion assert.ErrorAssertionFunc
+		NotFound     bool
+	}{
+		"single record": {
+			Name:         "server.example.org.",
+			Expected:     []string{"1-ff00:0:110,10.0.0.1:0"},
+			ErrAssertion: assert.NoError,
+		},
+		"mixed records": {
+			Name: "mixed.example.org.",
+			Expected: []string{
+				"1-ff00:0:110,10.0.0.1:0",
+				"1-ff00:0:111,[f00d::1]:0",
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"no scion record": {
+			Name:         "other.example.org.",
+			ErrAssertion: assert.Error,
+			NotFound:     true,
+		},
+		"nxdomain": {
+			Name:         "unknown.example.org.",
+			ErrAssertion: assert.Error,
+			NotFound:     true,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d := &resolver.DNS{Resolver: r}
+			addrs, err := d.LookupHost(context.Background(), tc.Name)
+			tc.ErrAssertion(t, err)
+			assert.Equal(t, tc.NotFound, errors.Is(err, resolver.ErrNotFound))
+			assert.Equal(t, tc.Expected, addrStrings(addrs))
+		})
+	}
+}
+
+// startDNSServer starts an in-process DNS server that answers TXT queries
+// with the given records, and returns a resolver that queries it.
+func startDNSServer(t *testing.T, records map[string][]string) *net.Resolver {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, src, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			rep, err := dnsReply(buf[:n], records)
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(rep, src)
+		}
+	}()
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", conn.LocalAddr().String())
+		},
+	}
+}
+
+func dnsReply(raw []byte, records map[string][]string) ([]byte, error) {
+	var p dnsmessage.Parser
+	hdr, err := p.Start(raw)
+	if err != nil {
+		return nil, err
+	}
+	q, err := p.Question()
+	if err != nil {
+		return nil, err
+	}
+	txts, ok := records[q.Name.String()]
+	rcode := dnsmessage.RCodeSuccess
+	if !ok {
+		rcode = dnsmessage.RCodeNameError
+	}
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID:            hdr.ID,
+		Response:      true,
+		Authoritative: true,
+		RCode:         rcode,
+	})
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(q); err != nil {
+		return nil, err
+	}
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+	if q.Type == dnsmessage.TypeTXT {
+		for _, txt := range txts {
+			err := b.TXTResource(
+				dnsmessage.ResourceHeader{Name: q.Name, Class: q.Class, TTL: 60},
+				dnsmessage.TXTResource{TXT: []string{txt}},
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b.Finish()
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// DefaultHostsFile is the default location of the SCION hosts file.
+const DefaultHostsFile = "/etc/scion/hosts"
+
+// Hosts resolves names with a hosts-style file. Each line contains a SCION
+// address followed by one or more names, e.g.:
+//
+//  # comment
+//  1-ff00:0:110,10.0.0.1     server.example.org server
+//  1-ff00:0:111,[f00d::1]    server6.example.org
+//
+// Names are matched case-insensitively. A name can appear on multiple lines,
+// in which case all addresses are returned in the order of the file. The file
+// is read on every lookup, i.e., changes are picked up immediately. A missing
+// file is treated as an empty file.
+type Hosts struct {
+	// Path is the path to the hosts file.
+	Path string
+}
+
+// LookupHost returns the SCION addresses of the host.
+func (h *Hosts) LookupHost(_ context.Context, name string) ([]*snet.UDPAddr, error) {
+	f, err := os.Open(h.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, serrors.WithCtx(ErrNotFound, "name", name)
+	}
+	if err != nil {
+		return nil, serrors.WrapStr("opening hosts file", err)
+	}
+	defer f.Close()
+	hosts, err := parseHosts(f)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing hosts file", err, "file", h.Path)
+	}
+	addrs, ok := hosts[strings.ToLower(name)]
+	if !ok {
+		return nil, serrors.WithCtx(ErrNotFound, "name", name)
+	}
+	return addrs, nil
+}
+
+func parseHosts(r io.Reader) (map[string][]*snet.UDPAddr, error) {
+	hosts := make(map[string][]*snet.UDPAddr)
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, serrors.New("missing host name", "line", lineNo)
+		}
+		a, err := parseAddr(fields[0])
+		if err != nil {
+			return nil, serrors.WithCtx(err, "line", lineNo)
+		}
+		for _, name := range fields[1:] {
+			name = strings.ToLower(name)
+			hosts[name] = append(hosts[name], a)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return hosts, nil
+}
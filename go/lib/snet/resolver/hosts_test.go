@@ -0,0 +1,91 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/snet/resolver"
+)
+
+func TestHostsLookupHost(t *testing.T) {
+	testCases := map[string]struct {
+		Path         string
+		Name         string
+		Expected     []string
+		ErrAssertion assert.ErrorAssertionFunc
+		NotFound     bool
+	}{
+		"ipv4": {
+			Path:         "testdata/hosts",
+			Name:         "server.example.org",
+			Expected:     []string{"1-ff00:0:110,10.0.0.1:0"},
+			ErrAssertion: assert.NoError,
+		},
+		"alias": {
+			Path:         "testdata/hosts",
+			Name:         "server",
+			Expected:     []string{"1-ff00:0:110,10.0.0.1:0"},
+			ErrAssertion: assert.NoError,
+		},
+		"ipv6": {
+			Path:         "testdata/hosts",
+			Name:         "server6.example.org",
+			Expected:     []string{"1-ff00:0:111,[f00d::1]:0"},
+			ErrAssertion: assert.NoError,
+		},
+		"multiple addresses, case insensitive": {
+			Path: "testdata/hosts",
+			Name: "MULTI.example.org",
+			Expected: []string{
+				"1-ff00:0:112,10.0.0.2:0",
+				"1-ff00:0:113,10.0.0.3:0",
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"unknown name": {
+			Path:         "testdata/hosts",
+			Name:         "unknown.example.org",
+			ErrAssertion: assert.Error,
+			NotFound:     true,
+		},
+		"missing file": {
+			Path:         "testdata/nonexistent",
+			Name:         "server.example.org",
+			ErrAssertion: assert.Error,
+			NotFound:     true,
+		},
+		"malformed file": {
+			Path:         "testdata/hosts_malformed",
+			Name:         "broken.example.org",
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			h := &resolver.Hosts{Path: tc.Path}
+			addrs, err := h.LookupHost(context.Background(), tc.Name)
+			tc.ErrAssertion(t, err)
+			assert.Equal(t, tc.NotFound, errors.Is(err, resolver.ErrNotFound))
+			assert.Equal(t, tc.Expected, addrStrings(addrs))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resolver resolves host names to SCION addresses.
+//
+// A SCION address of a host is written as ISD-AS,IP, e.g.,
+// 1-ff00:0:110,10.0.0.1 or 1-ff00:0:110,[f00d::1]. The following backends
+// are supported:
+//
+//  - Hosts: a hosts-style file, by default /etc/scion/hosts.
+//  - DNS: TXT records of the form "scion=ISD-AS,IP".
+//  - Daemon: the REST API of the SCION daemon, which caches the lookups.
+//  - Cache: caches the results of another resolver.
+//
+// Backends are combined with Chain.
+package resolver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// ErrNotFound indicates that the name has no SCION address.
+var ErrNotFound = serrors.New("no SCION address found")
+
+// Resolver resolves host names to SCION addresses.
+type Resolver interface {
+	// LookupHost returns the SCION addresses of the host. The port of the
+	// returned addresses is 0. If the host has no SCION address, an error
+	// wrapping ErrNotFound is returned.
+	LookupHost(ctx context.Context, name string) ([]*snet.UDPAddr, error)
+}
+
+// Chain queries the resolvers in order and returns the result of the first
+// resolver that knows the name.
+type Chain []Resolver
+
+// LookupHost returns the SCION addresses of the host.
+func (c Chain) LookupHost(ctx context.Context, name string) ([]*snet.UDPAddr, error) {
+	var errs serrors.List
+	for _, r := range c {
+		addrs, err := r.LookupHost(ctx, name)
+		if err == nil {
+			return addrs, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, serrors.Wrap(ErrNotFound, errs.ToError(), "name", name)
+	}
+	return nil, serrors.WithCtx(ErrNotFound, "name", name)
+}
+
+// Default returns the resolver that is used by the SCION tools. It consults
+// the default hosts file first, the daemon at the address in DaemonAPIEnv
+// second, and DNS last. If the environment variable is not set, the daemon is
+// skipped.
+func Default() Resolver {
+	return Chain{
+		&Hosts{Path: DefaultHostsFile},
+		&Daemon{Address: os.Getenv(DaemonAPIEnv)},
+		&DNS{},
+	}
+}
+
+// Local returns the resolver that consults the default hosts file first, and
+// DNS second. It is used by the daemon to answer the lookups of Daemon.
+func Local() Resolver {
+	return Chain{
+		&Hosts{Path: DefaultHostsFile},
+		&DNS{},
+	}
+}
+
+// ResolveUDPAddr converts the string to a SCION UDP address. The string is
+// either a SCION address as accepted by snet.ParseUDPAddr, or a host name
+// with an optional port, e.g., "example.org" or "example.org:8080". If the
+// host name resolves to multiple addresses, the first one is returned.
+func ResolveUDPAddr(ctx context.Context, r Resolver, s string) (*snet.UDPAddr, error) {
+	if a, err := snet.ParseUDPAddr(s); err == nil {
+		return a, nil
+	}
+	host, port := s, 0
+	if h, p, err := net.SplitHostPort(s); err == nil {
+		if port, err = strconv.Atoi(p); err != nil || port < 0 || port > 65535 {
+			return nil, serrors.New("invalid port", "port", p)
+		}
+		host = h
+	}
+	addrs, err := r.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	a := addrs[0]
+	return &snet.UDPAddr{
+		IA:   a.IA,
+		Host: &net.UDPAddr{IP: a.Host.IP, Port: port, Zone: a.Host.Zone},
+	}, nil
+}
+
+// ResolveIA converts the string to an ISD-AS. The string is either an ISD-AS
+// or a host name. If the host name resolves to multiple addresses, the ISD-AS
+// of the first one is returned.
+func ResolveIA(ctx context.Context, r Resolver, s string) (addr.IA, error) {
+	if ia, err := addr.IAFromString(s); err == nil {
+		return ia, nil
+	}
+	addrs, err := r.LookupHost(ctx, s)
+	if err != nil {
+		return addr.IA{}, err
+	}
+	return addrs[0].IA, nil
+}
+
+// parseAddr parses a SCION address without port, i.e., ISD-AS,IP or
+// ISD-AS,[IP]. In contrast to snet.ParseUDPAddr, the host part must be an IP
+// address.
+func parseAddr(s string) (*snet.UDPAddr, error) {
+	parts := strings.SplitN(s, ",", 2)
+	if len(parts) != 2 {
+		return nil, serrors.New("invalid address, expected ISD-AS,IP", "addr", s)
+	}
+	ia, err := addr.IAFromString(parts[0])
+	if err != nil {
+		return nil, serrors.WrapStr("parsing ISD-AS", err, "addr", s)
+	}
+	host := strings.TrimSuffix(strings.TrimPrefix(parts[1], "["), "]")
+	var zone string
+	if i := strings.LastIndex(host, "%"); i >= 0 {
+		host, zone = host[:i], host[i+1:]
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, serrors.New("invalid IP address", "addr", s)
+	}
+	return &snet.UDPAddr{IA: ia, Host: &net.UDPAddr{IP: ip, Zone: zone}}, nil
+}
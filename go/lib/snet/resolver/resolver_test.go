@@ -0,0 +1,283 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/resolver"
+	"github.com/scionproto/scion/go/lib/snet/resolver/mock_resolver"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestChainLookupHost(t *testing.T) {
+	server := []*snet.UDPAddr{mustParseAddr(t, "1-ff00:0:110,10.0.0.1")}
+	notFound := serrors.WithCtx(resolver.ErrNotFound, "name", "server")
+
+	testCases := map[string]struct {
+		Resolvers    func(ctrl *gomock.Controller) []resolver.Resolver
+		Expected     []string
+		ErrAssertion assert.ErrorAssertionFunc
+		NotFound     bool
+	}{
+		"empty": {
+			Resolvers:    func(ctrl *gomock.Controller) []resolver.Resolver { return nil },
+			ErrAssertion: assert.Error,
+			NotFound:     true,
+		},
+		"first resolver": {
+			Resolvers: func(ctrl *gomock.Controller) []resolver.Resolver {
+				r := mock_resolver.NewMockResolver(ctrl)
+				r.EXPECT().LookupHost(gomock.Any(), "server").Return(server, nil)
+				return []resolver.Resolver{r, mock_resolver.NewMockResolver(ctrl)}
+			},
+			Expected:     []string{"1-ff00:0:110,10.0.0.1:0"},
+			ErrAssertion: assert.NoError,
+		},
+		"fall through": {
+			Resolvers: func(ctrl *gomock.Controller) []resolver.Resolver {
+				r1 := mock_resolver.NewMockResolver(ctrl)
+				r1.EXPECT().LookupHost(gomock.Any(), "server").Return(nil, notFound)
+				r2 := mock_resolver.NewMockResolver(ctrl)
+				r2.EXPECT().LookupHost(gomock.Any(), "server").
+					Return(nil, serrors.New("test error"))
+				r3 := mock_resolver.NewMockResolver(ctrl)
+				r3.EXPECT().LookupHost(gomock.Any(), "server").Return(server, nil)
+				return []resolver.Resolver{r1, r2, r3}
+			},
+			Expected:     []string{"1-ff00:0:110,10.0.0.1:0"},
+			ErrAssertion: assert.NoError,
+		},
+		"not found": {
+			Resolvers: func(ctrl *gomock.Controller) []resolver.Resolver {
+				r := mock_resolver.NewMockResolver(ctrl)
+				r.EXPECT().LookupHost(gomock.Any(), "server").Return(nil, notFound).Times(2)
+				return []resolver.Resolver{r, r}
+			},
+			ErrAssertion: assert.Error,
+			NotFound:     true,
+		},
+		"not found with error": {
+			Resolvers: func(ctrl *gomock.Controller) []resolver.Resolver {
+				r1 := mock_resolver.NewMockResolver(ctrl)
+				r1.EXPECT().LookupHost(gomock.Any(), "server").
+					Return(nil, serrors.New("test error"))
+				r2 := mock_resolver.NewMockResolver(ctrl)
+				r2.EXPECT().LookupHost(gomock.Any(), "server").Return(nil, notFound)
+				return []resolver.Resolver{r1, r2}
+			},
+			ErrAssertion: assert.Error,
+			NotFound:     true,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := resolver.Chain(tc.Resolvers(ctrl))
+			addrs, err := c.LookupHost(context.Background(), "server")
+			tc.ErrAssertion(t, err)
+			assert.Equal(t, tc.NotFound, errors.Is(err, resolver.ErrNotFound))
+			assert.Equal(t, tc.Expected, addrStrings(addrs))
+		})
+	}
+}
+
+func TestResolveUDPAddr(t *testing.T) {
+	testCases := map[string]struct {
+		Input        string
+		Resolver     func(ctrl *gomock.Controller) resolver.Resolver
+		Expected     string
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"scion address": {
+			Input: "1-ff00:0:110,10.0.0.1:8080",
+			Resolver: func(ctrl *gomock.Controller) resolver.Resolver {
+				return mock_resolver.NewMockResolver(ctrl)
+			},
+			Expected:     "1-ff00:0:110,10.0.0.1:8080",
+			ErrAssertion: assert.NoError,
+		},
+		"name": {
+			Input: "server",
+			Resolver: func(ctrl *gomock.Controller) resolver.Resolver {
+				r := mock_resolver.NewMockResolver(ctrl)
+				r.EXPECT().LookupHost(gomock.Any(), "server").Return(
+					[]*snet.UDPAddr{
+						mustParseAddr(t, "1-ff00:0:110,10.0.0.1"),
+						mustParseAddr(t, "1-ff00:0:111,10.0.0.2"),
+					}, nil,
+				)
+				return r
+			},
+			Expected:     "1-ff00:0:110,10.0.0.1:0",
+			ErrAssertion: assert.NoError,
+		},
+		"name with port": {
+			Input: "server.example.org:8080",
+			Resolver: func(ctrl *gomock.Controller) resolver.Resolver {
+				r := mock_resolver.NewMockResolver(ctrl)
+				r.EXPECT().LookupHost(gomock.Any(), "server.example.org").Return(
+					[]*snet.UDPAddr{mustParseAddr(t, "1-ff00:0:110,[f00d::1]")}, nil,
+				)
+				return r
+			},
+			Expected:     "1-ff00:0:110,[f00d::1]:8080",
+			ErrAssertion: assert.NoError,
+		},
+		"invalid port": {
+			Input: "server:http",
+			Resolver: func(ctrl *gomock.Controller) resolver.Resolver {
+				return mock_resolver.NewMockResolver(ctrl)
+			},
+			ErrAssertion: assert.Error,
+		},
+		"lookup error": {
+			Input: "server",
+			Resolver: func(ctrl *gomock.Controller) resolver.Resolver {
+				r := mock_resolver.NewMockResolver(ctrl)
+				r.EXPECT().LookupHost(gomock.Any(), "server").
+					Return(nil, resolver.ErrNotFound)
+				return r
+			},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a, err := resolver.ResolveUDPAddr(context.Background(), tc.Resolver(ctrl), tc.Input)
+			tc.ErrAssertion(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.Expected, a.String())
+		})
+	}
+}
+
+func TestResolveIA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := mock_resolver.NewMockResolver(ctrl)
+	r.EXPECT().LookupHost(gomock.Any(), "server").Return(
+		[]*snet.UDPAddr{mustParseAddr(t, "1-ff00:0:111,10.0.0.1")}, nil,
+	)
+	ia, err := resolver.ResolveIA(context.Background(), r, "1-ff00:0:110")
+	require.NoError(t, err)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:110"), ia)
+	ia, err = resolver.ResolveIA(context.Background(), r, "server")
+	require.NoError(t, err)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:111"), ia)
+}
+
+func TestCacheLookupHost(t *testing.T) {
+	t.Run("cached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := []*snet.UDPAddr{mustParseAddr(t, "1-ff00:0:110,10.0.0.1")}
+		r := mock_resolver.NewMockResolver(ctrl)
+		r.EXPECT().LookupHost(gomock.Any(), "server").Return(server, nil)
+		c := &resolver.Cache{Resolver: r, TTL: time.Hour}
+		for i := 0; i < 3; i++ {
+			addrs, err := c.LookupHost(context.Background(), "server")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"1-ff00:0:110,10.0.0.1:0"}, addrStrings(addrs))
+			// Modifying the result must not affect the cache.
+			addrs[0].Host.Port = 42
+		}
+	})
+	t.Run("expired", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := []*snet.UDPAddr{mustParseAddr(t, "1-ff00:0:110,10.0.0.1")}
+		r := mock_resolver.NewMockResolver(ctrl)
+		r.EXPECT().LookupHost(gomock.Any(), "server").Return(server, nil).Times(2)
+		c := &resolver.Cache{Resolver: r, TTL: time.Nanosecond}
+		for i := 0; i < 2; i++ {
+			_, err := c.LookupHost(context.Background(), "server")
+			require.NoError(t, err)
+			time.Sleep(time.Millisecond)
+		}
+	})
+	t.Run("errors not cached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := []*snet.UDPAddr{mustParseAddr(t, "1-ff00:0:110,10.0.0.1")}
+		r := mock_resolver.NewMockResolver(ctrl)
+		r.EXPECT().LookupHost(gomock.Any(), "server").Return(nil, resolver.ErrNotFound)
+		r.EXPECT().LookupHost(gomock.Any(), "server").Return(server, nil)
+		c := &resolver.Cache{Resolver: r, TTL: time.Hour}
+		_, err := c.LookupHost(context.Background(), "server")
+		assert.Error(t, err)
+		_, err = c.LookupHost(context.Background(), "server")
+		assert.NoError(t, err)
+	})
+	t.Run("evicted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := []*snet.UDPAddr{mustParseAddr(t, "1-ff00:0:110,10.0.0.1")}
+		r := mock_resolver.NewMockResolver(ctrl)
+		r.EXPECT().LookupHost(gomock.Any(), "a").Return(server, nil).Times(2)
+		r.EXPECT().LookupHost(gomock.Any(), "b").Return(server, nil)
+		r.EXPECT().LookupHost(gomock.Any(), "c").Return(server, nil)
+		c := &resolver.Cache{Resolver: r, TTL: time.Hour, MaxEntries: 2}
+		// The lookup of c evicts a, the least recently used name.
+		for _, name := range []string{"a", "b", "b", "c", "b", "a"} {
+			_, err := c.LookupHost(context.Background(), name)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, c.Len(), 2)
+		}
+	})
+}
+
+func mustParseAddr(t *testing.T, s string) *snet.UDPAddr {
+	t.Helper()
+	a, err := snet.ParseUDPAddr(s)
+	require.NoError(t, err)
+	return a
+}
+
+func addrStrings(addrs []*snet.UDPAddr) []string {
+	if addrs == nil {
+		return nil
+	}
+	var s []string
+	for _, a := range addrs {
+		s = append(s, a.String())
+	}
+	return s
+}
@@ -312,6 +312,12 @@ func (c *acceptingConn) RemoteAddr() net.Addr {
 	return c.session.RemoteAddr()
 }
 
+// ConnectionState returns the state of the TLS handshake of the underlying
+// QUIC session.
+func (c *acceptingConn) ConnectionState() tls.ConnectionState {
+	return c.session.ConnectionState().TLS.ConnectionState
+}
+
 func (c *acceptingConn) Close() error {
 	// Prevent the stream from being accepted.
 	c.once.Do(func() {
@@ -345,6 +351,9 @@ type ConnDialer struct {
 	// demux the packets.
 	Conn net.PacketConn
 	// TLSConfig is the client's TLS configuration for starting QUIC connections.
+	// If the server name is not set, it is set to the ISD-AS of the remote
+	// address, such that the certificate of the server can be verified against
+	// it.
 	TLSConfig *tls.Config
 	// QUICConfig is the client's QUIC configuration.
 	QUICConfig *quic.Config
@@ -365,6 +374,9 @@ func (d ConnDialer) Dial(ctx context.Context, dst net.Addr) (net.Conn, error) {
 	for sleep := 2 * time.Millisecond; ctx.Err() == nil; sleep = sleep * 2 {
 		// Clone TLS config to avoid data races.
 		tlsConfig := d.TLSConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = computeServerName(dst)
+		}
 		// Clone QUIC config to avoid data races, if it exists.
 		var quicConfig *quic.Config
 		if d.QUICConfig != nil {
@@ -413,6 +425,19 @@ func computeAddressStr(address net.Addr) string {
 	return address.String()
 }
 
+// computeServerName returns the ISD-AS of the address, or an empty string if
+// the address is not a SCION address.
+func computeServerName(address net.Addr) string {
+	switch v := address.(type) {
+	case *snet.UDPAddr:
+		return v.IA.String()
+	case *snet.SVCAddr:
+		return v.IA.String()
+	default:
+		return ""
+	}
+}
+
 // acceptedConn is a net.Conn wrapper for a QUIC stream.
 type acceptedConn struct {
 	stream  quic.Stream
@@ -447,6 +472,12 @@ func (c *acceptedConn) RemoteAddr() net.Addr {
 	return c.session.RemoteAddr()
 }
 
+// ConnectionState returns the state of the TLS handshake of the underlying
+// QUIC session.
+func (c *acceptedConn) ConnectionState() tls.ConnectionState {
+	return c.session.ConnectionState().TLS.ConnectionState
+}
+
 func (c *acceptedConn) Close() error {
 	var errs []error
 	if err := c.stream.Close(); err != nil {
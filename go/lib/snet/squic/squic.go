@@ -33,11 +33,7 @@ const (
 	defPemPath = "gen-certs/tls.pem"
 )
 
-var (
-	// Don't verify the server's cert, as we are not using the TLS PKI.
-	cliTlsCfg = &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"SCION"}}
-	srvTlsCfg = &tls.Config{NextProtos: []string{"SCION"}}
-)
+var srvTlsCfg = &tls.Config{NextProtos: []string{"SCION"}}
 
 func Init(keyPath, pemPath string) error {
 	if keyPath == "" {
@@ -54,16 +50,25 @@ func Init(keyPath, pemPath string) error {
 	return nil
 }
 
-// Dial dials using QUIC over the SCION network.
+// Dial dials using QUIC over the SCION network. The server is authenticated
+// with the given TLS configuration, e.g., the client configuration of a
+// trust.TLSCryptoManager. If the server name is not set, the ISD-AS of the
+// remote address is used.
 func Dial(network *snet.SCIONNetwork, listen *net.UDPAddr, remote *snet.UDPAddr,
-	svc addr.HostSVC, quicConfig *quic.Config) (quic.Session, error) {
+	svc addr.HostSVC, tlsConfig *tls.Config, quicConfig *quic.Config) (quic.Session, error) {
 
+	if tlsConfig == nil {
+		return nil, serrors.New("squic: TLS configuration must not be nil")
+	}
 	sconn, err := sListen(network, listen, svc)
 	if err != nil {
 		return nil, err
 	}
-	// Use dummy hostname, as it's used for SNI, and we're not doing cert verification.
-	return quic.Dial(sconn, remote, "host:0", cliTlsCfg, quicConfig)
+	serverName := tlsConfig.ServerName
+	if serverName == "" {
+		serverName = computeServerName(remote)
+	}
+	return quic.Dial(sconn, remote, serverName, tlsConfig, quicConfig)
 }
 
 func Listen(network *snet.SCIONNetwork, listen *net.UDPAddr,
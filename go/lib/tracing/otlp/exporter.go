@@ -0,0 +1,116 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const defaultHTTPPath = "/v1/traces"
+
+// grpcExporter exports spans with OTLP/gRPC. The connection deliberately does
+// not use the tracing interceptors, exporting spans must not create new ones.
+type grpcExporter struct {
+	conn   *grpc.ClientConn
+	client coltracepb.TraceServiceClient
+}
+
+func newGRPCExporter(endpoint string) (*grpcExporter, error) {
+	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	if err != nil {
+		return nil, serrors.WrapStr("dialing collector", err, "endpoint", endpoint)
+	}
+	return &grpcExporter{
+		conn:   conn,
+		client: coltracepb.NewTraceServiceClient(conn),
+	}, nil
+}
+
+func (e *grpcExporter) Export(ctx context.Context,
+	req *coltracepb.ExportTraceServiceRequest) error {
+
+	_, err := e.client.Export(ctx, req)
+	return err
+}
+
+func (e *grpcExporter) Close() error {
+	return e.conn.Close()
+}
+
+// httpExporter exports spans with OTLP/HTTP using the binary protobuf
+// encoding.
+type httpExporter struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPExporter(endpoint string) (*httpExporter, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing endpoint", err, "endpoint", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = defaultHTTPPath
+	}
+	return &httpExporter{
+		url:    u.String(),
+		client: &http.Client{},
+	}, nil
+}
+
+func (e *httpExporter) Export(ctx context.Context,
+	req *coltracepb.ExportTraceServiceRequest) error {
+
+	raw, err := proto.Marshal(req)
+	if err != nil {
+		return serrors.WrapStr("marshaling request", err)
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(raw))
+	if err != nil {
+		return serrors.WrapStr("creating request", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := e.client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body such that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return serrors.New("collector rejected spans", "status", resp.Status)
+	}
+	return nil
+}
+
+func (e *httpExporter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,394 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlp implements a span reporter that exports finished spans to an
+// OpenTelemetry collector using the OTLP protocol.
+//
+// The reporter plugs into the jaeger tracer that is used throughout the code
+// base, so that instrumented code keeps using the opentracing API while the
+// spans are shipped to any OTLP capable backend, either over gRPC or over
+// HTTP with protobuf encoding.
+package otlp
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/uber/jaeger-client-go"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Protocol is the transport protocol used to export spans.
+type Protocol string
+
+const (
+	// GRPC exports spans with the OTLP/gRPC protocol.
+	GRPC Protocol = "grpc"
+	// HTTP exports spans with the OTLP/HTTP protocol using protobuf encoding.
+	HTTP Protocol = "http"
+)
+
+const (
+	// DefaultGRPCEndpoint is the default OTLP/gRPC collector endpoint.
+	DefaultGRPCEndpoint = "localhost:4317"
+	// DefaultHTTPEndpoint is the default OTLP/HTTP collector endpoint.
+	DefaultHTTPEndpoint = "localhost:4318"
+
+	defaultQueueSize     = 1000
+	defaultBatchSize     = 100
+	defaultFlushInterval = time.Second
+	defaultExportTimeout = 10 * time.Second
+
+	instrumentationName = "github.com/scionproto/scion/go/lib/tracing/otlp"
+)
+
+// Config is the configuration of the reporter.
+type Config struct {
+	// Endpoint is the address of the collector. For HTTP it can be a full
+	// URL, if no path is given the default /v1/traces path is used.
+	Endpoint string
+	// Protocol is the protocol used to export the spans.
+	Protocol Protocol
+	// ServiceName is reported as service.name resource attribute.
+	ServiceName string
+	// QueueSize is the maximum number of spans that are buffered. If the
+	// queue is full, new spans are dropped.
+	QueueSize int
+	// BatchSize is the maximum number of spans that are exported at once.
+	BatchSize int
+	// FlushInterval is the maximum time spans are kept in the buffer before
+	// they are exported.
+	FlushInterval time.Duration
+}
+
+func (c *Config) initDefaults() {
+	if c.Protocol == "" {
+		c.Protocol = GRPC
+	}
+	if c.Endpoint == "" {
+		c.Endpoint = DefaultGRPCEndpoint
+		if c.Protocol == HTTP {
+			c.Endpoint = DefaultHTTPEndpoint
+		}
+	}
+	if c.QueueSize == 0 {
+		c.QueueSize = defaultQueueSize
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.FlushInterval == 0 {
+		c.FlushInterval = defaultFlushInterval
+	}
+}
+
+// exporter sends a batch of spans to the collector.
+type exporter interface {
+	Export(context.Context, *coltracepb.ExportTraceServiceRequest) error
+	Close() error
+}
+
+var _ jaeger.Reporter = (*Reporter)(nil)
+
+// Reporter is a jaeger.Reporter that exports the reported spans to an
+// OpenTelemetry collector. Spans are converted on report and exported in
+// batches by a background goroutine.
+type Reporter struct {
+	cfg      Config
+	exporter exporter
+	resource *resourcepb.Resource
+
+	queue chan *tracepb.Span
+	// stop is closed by Close to signal the background export to flush and
+	// stop. The queue is never closed, such that a concurrent or later Report
+	// cannot panic.
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewReporter creates a new reporter and starts the background export. The
+// connection to the collector is established lazily, i.e., creating the
+// reporter does not fail if the collector is not reachable.
+func NewReporter(cfg Config) (*Reporter, error) {
+	cfg.initDefaults()
+	var exp exporter
+	var err error
+	switch cfg.Protocol {
+	case GRPC:
+		exp, err = newGRPCExporter(cfg.Endpoint)
+	case HTTP:
+		exp, err = newHTTPExporter(cfg.Endpoint)
+	default:
+		return nil, serrors.New("unsupported protocol", "protocol", cfg.Protocol)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r := &Reporter{
+		cfg:      cfg,
+		exporter: exp,
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{
+				keyValue("service.name", cfg.ServiceName),
+			},
+		},
+		queue: make(chan *tracepb.Span, cfg.QueueSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go func() {
+		defer log.HandlePanic()
+		r.run()
+	}()
+	return r, nil
+}
+
+// Report converts the span and queues it for export. If the queue is full,
+// the span is dropped. Spans that are reported after Close are dropped.
+func (r *Reporter) Report(span *jaeger.Span) {
+	select {
+	case <-r.stop:
+		return
+	default:
+	}
+	select {
+	case <-r.stop:
+	case r.queue <- convertSpan(span):
+	default:
+		log.Debug("Dropping span, export queue full", "operation", span.OperationName())
+	}
+}
+
+// Close flushes the queued spans and closes the connection to the collector.
+// Spans that are reported after Close are dropped.
+func (r *Reporter) Close() {
+	r.closeOnce.Do(func() {
+		close(r.stop)
+		<-r.done
+		if err := r.exporter.Close(); err != nil {
+			log.Info("Failed to close OTLP exporter", "err", err)
+		}
+	})
+}
+
+func (r *Reporter) run() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*tracepb.Span, 0, r.cfg.BatchSize)
+	for {
+		select {
+		case <-r.stop:
+			r.flush(batch)
+			return
+		case span := <-r.queue:
+			batch = append(batch, span)
+			if len(batch) >= r.cfg.BatchSize {
+				r.export(batch)
+				batch = make([]*tracepb.Span, 0, r.cfg.BatchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				r.export(batch)
+				batch = make([]*tracepb.Span, 0, r.cfg.BatchSize)
+			}
+		}
+	}
+}
+
+// flush exports the batch and the spans that are still queued.
+func (r *Reporter) flush(batch []*tracepb.Span) {
+	for {
+		select {
+		case span := <-r.queue:
+			batch = append(batch, span)
+			if len(batch) >= r.cfg.BatchSize {
+				r.export(batch)
+				batch = make([]*tracepb.Span, 0, r.cfg.BatchSize)
+			}
+		default:
+			r.export(batch)
+			return
+		}
+	}
+}
+
+func (r *Reporter) export(spans []*tracepb.Span) {
+	if len(spans) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultExportTimeout)
+	defer cancel()
+	req := &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{
+			{
+				Resource: r.resource,
+				InstrumentationLibrarySpans: []*tracepb.InstrumentationLibrarySpans{
+					{
+						InstrumentationLibrary: &commonpb.InstrumentationLibrary{
+							Name: instrumentationName,
+						},
+						Spans: spans,
+					},
+				},
+			},
+		},
+	}
+	if err := r.exporter.Export(ctx, req); err != nil {
+		log.Info("Failed to export spans", "spans", len(spans), "err", err)
+	}
+}
+
+// convertSpan converts a finished jaeger span to its OTLP representation.
+func convertSpan(span *jaeger.Span) *tracepb.Span {
+	sc := span.SpanContext()
+	start := span.StartTime()
+	s := &tracepb.Span{
+		TraceId:           traceID(sc.TraceID()),
+		SpanId:            spanID(sc.SpanID()),
+		Name:              span.OperationName(),
+		Kind:              tracepb.Span_SPAN_KIND_INTERNAL,
+		StartTimeUnixNano: uint64(start.UnixNano()),
+		EndTimeUnixNano:   uint64(start.Add(span.Duration()).UnixNano()),
+	}
+	if sc.ParentID() != 0 {
+		s.ParentSpanId = spanID(sc.ParentID())
+	}
+
+	var isError bool
+	var errMsg string
+	for k, v := range span.Tags() {
+		switch k {
+		case string(ext.SpanKind):
+			s.Kind = spanKind(v)
+			continue
+		case string(ext.Error):
+			isError, _ = v.(bool)
+		case "error.msg":
+			errMsg = fmt.Sprint(v)
+		}
+		s.Attributes = append(s.Attributes, keyValue(k, v))
+	}
+	if isError {
+		s.Status = &tracepb.Status{
+			Code:    tracepb.Status_STATUS_CODE_ERROR,
+			Message: errMsg,
+		}
+	}
+
+	for _, record := range span.Logs() {
+		event := &tracepb.Span_Event{
+			TimeUnixNano: uint64(record.Timestamp.UnixNano()),
+			Name:         "log",
+		}
+		for _, field := range record.Fields {
+			if field.Key() == "event" {
+				event.Name = fmt.Sprint(field.Value())
+				continue
+			}
+			event.Attributes = append(event.Attributes, keyValue(field.Key(), field.Value()))
+		}
+		s.Events = append(s.Events, event)
+	}
+
+	for _, ref := range span.References() {
+		refCtx, ok := ref.ReferencedContext.(jaeger.SpanContext)
+		if !ok || ref.Type != opentracing.FollowsFromRef {
+			continue
+		}
+		s.Links = append(s.Links, &tracepb.Span_Link{
+			TraceId: traceID(refCtx.TraceID()),
+			SpanId:  spanID(refCtx.SpanID()),
+		})
+	}
+	return s
+}
+
+func traceID(id jaeger.TraceID) []byte {
+	raw := make([]byte, 16)
+	binary.BigEndian.PutUint64(raw[:8], id.High)
+	binary.BigEndian.PutUint64(raw[8:], id.Low)
+	return raw
+}
+
+func spanID(id jaeger.SpanID) []byte {
+	raw := make([]byte, 8)
+	binary.BigEndian.PutUint64(raw, uint64(id))
+	return raw
+}
+
+func spanKind(v interface{}) tracepb.Span_SpanKind {
+	switch fmt.Sprint(v) {
+	case string(ext.SpanKindRPCClientEnum):
+		return tracepb.Span_SPAN_KIND_CLIENT
+	case string(ext.SpanKindRPCServerEnum):
+		return tracepb.Span_SPAN_KIND_SERVER
+	case string(ext.SpanKindProducerEnum):
+		return tracepb.Span_SPAN_KIND_PRODUCER
+	case string(ext.SpanKindConsumerEnum):
+		return tracepb.Span_SPAN_KIND_CONSUMER
+	default:
+		return tracepb.Span_SPAN_KIND_INTERNAL
+	}
+}
+
+func keyValue(k string, v interface{}) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: k, Value: anyValue(v)}
+}
+
+func anyValue(v interface{}) *commonpb.AnyValue {
+	switch v := v.(type) {
+	case string:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	case int:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case int8:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case int16:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case int32:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case int64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v}}
+	case uint8:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case uint16:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case uint32:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case float32:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: float64(v)}}
+	case float64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v}}
+	case []byte:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v}}
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: fmt.Sprint(v)}}
+	}
+}
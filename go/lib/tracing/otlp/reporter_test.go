@@ -0,0 +1,210 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-client-go"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/go/lib/tracing/otlp"
+)
+
+// collector is an in-process OTLP collector that records the received spans.
+type collector struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	mu       sync.Mutex
+	requests []*coltracepb.ExportTraceServiceRequest
+}
+
+func (c *collector) Export(_ context.Context,
+	req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	raw, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var req coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(raw, &req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	_, _ = c.Export(r.Context(), &req)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *collector) spans() []*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var spans []*tracepb.Span
+	for _, req := range c.requests {
+		for _, rs := range req.ResourceSpans {
+			for _, ils := range rs.InstrumentationLibrarySpans {
+				spans = append(spans, ils.Spans...)
+			}
+		}
+	}
+	return spans
+}
+
+func TestReporter(t *testing.T) {
+	testCases := map[string]func(t *testing.T, c *collector) otlp.Config{
+		"grpc": func(t *testing.T, c *collector) otlp.Config {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			server := grpc.NewServer()
+			coltracepb.RegisterTraceServiceServer(server, c)
+			go func() { _ = server.Serve(lis) }()
+			t.Cleanup(server.Stop)
+			return otlp.Config{
+				Endpoint: lis.Addr().String(),
+				Protocol: otlp.GRPC,
+			}
+		},
+		"http": func(t *testing.T, c *collector) otlp.Config {
+			server := httptest.NewServer(c)
+			t.Cleanup(server.Close)
+			return otlp.Config{
+				Endpoint: server.URL,
+				Protocol: otlp.HTTP,
+			}
+		},
+	}
+	for name, setup := range testCases {
+		name, setup := name, setup
+		t.Run(name, func(t *testing.T) {
+			c := &collector{}
+			cfg := setup(t, c)
+			cfg.ServiceName = "test"
+			cfg.FlushInterval = 10 * time.Millisecond
+			reporter, err := otlp.NewReporter(cfg)
+			require.NoError(t, err)
+
+			tracer, closer := jaeger.NewTracer("test",
+				jaeger.NewConstSampler(true),
+				reporter,
+				jaeger.TracerOptions.Gen128Bit(true),
+			)
+			parent := tracer.StartSpan("parent")
+			child := tracer.StartSpan("child", opentracing.ChildOf(parent.Context()),
+				ext.SpanKindRPCClient)
+			child.SetTag("scion.dst.isd_as", "1-ff00:0:110")
+			child.SetTag("attempt", 2)
+			ext.Error.Set(child, true)
+			child.SetTag("error.msg", "failed")
+			child.LogKV("event", "retry", "delay", "1s")
+			child.Finish()
+			parent.Finish()
+			require.NoError(t, closer.Close())
+
+			spans := c.spans()
+			require.Len(t, spans, 2)
+			byName := map[string]*tracepb.Span{}
+			for _, s := range spans {
+				byName[s.Name] = s
+			}
+			p, ch := byName["parent"], byName["child"]
+			require.NotNil(t, p)
+			require.NotNil(t, ch)
+
+			parentCtx := parent.Context().(jaeger.SpanContext)
+			assert.Len(t, p.TraceId, 16)
+			assert.Equal(t, p.TraceId, ch.TraceId)
+			assert.Equal(t, p.SpanId, ch.ParentSpanId)
+			assert.Empty(t, p.ParentSpanId)
+			assert.Equal(t, parentCtx.TraceID().High, binary.BigEndian.Uint64(p.TraceId[:8]))
+			assert.Equal(t, parentCtx.TraceID().Low, binary.BigEndian.Uint64(p.TraceId[8:]))
+
+			assert.Equal(t, tracepb.Span_SPAN_KIND_CLIENT, ch.Kind)
+			assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, ch.Status.Code)
+			assert.Equal(t, "failed", ch.Status.Message)
+			attrs := map[string]*commonpb.AnyValue{}
+			for _, kv := range ch.Attributes {
+				attrs[kv.Key] = kv.Value
+			}
+			assert.Equal(t, "1-ff00:0:110", attrs["scion.dst.isd_as"].GetStringValue())
+			assert.Equal(t, int64(2), attrs["attempt"].GetIntValue())
+			assert.NotContains(t, attrs, "span.kind")
+			require.Len(t, ch.Events, 1)
+			assert.Equal(t, "retry", ch.Events[0].Name)
+			assert.Greater(t, ch.EndTimeUnixNano, uint64(0))
+			assert.GreaterOrEqual(t, ch.EndTimeUnixNano, ch.StartTimeUnixNano)
+		})
+	}
+}
+
+func TestReporterUnreachableCollector(t *testing.T) {
+	// Reserve a port and close it again so that nothing is listening.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	reporter, err := otlp.NewReporter(otlp.Config{
+		Endpoint:      addr,
+		Protocol:      otlp.HTTP,
+		FlushInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	tracer, closer := jaeger.NewTracer("test", jaeger.NewConstSampler(true), reporter)
+	tracer.StartSpan("span").Finish()
+
+	done := make(chan error)
+	go func() { done <- closer.Close() }()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("close did not return")
+	}
+	// Spans that are reported after close are dropped.
+	assert.NotPanics(t, func() { tracer.StartSpan("late").Finish() })
+	reporter.Close()
+}
+
+func TestNewReporterUnknownProtocol(t *testing.T) {
+	_, err := otlp.NewReporter(otlp.Config{Protocol: "udp"})
+	assert.Error(t, err)
+}
@@ -15,8 +15,23 @@
 package tracing
 
 import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
+
+	"github.com/scionproto/scion/go/lib/addr"
+)
+
+// Span attribute keys for SCION specific information. They follow the
+// OpenTelemetry attribute naming conventions.
+const (
+	TagSrcIA           = "scion.src.isd_as"
+	TagDstIA           = "scion.dst.isd_as"
+	TagSegmentIDs      = "scion.segment.ids"
+	TagPathFingerprint = "scion.path.fingerprint"
 )
 
 // ResultLabel sets the operation result label on the span.
@@ -36,3 +51,28 @@ func Error(span opentracing.Span, err error) {
 func Component(span opentracing.Span, component string) {
 	ext.Component.Set(span, component)
 }
+
+// SrcIA sets the source ISD-AS attribute on the span.
+func SrcIA(span opentracing.Span, ia addr.IA) {
+	span.SetTag(TagSrcIA, ia.String())
+}
+
+// DstIA sets the destination ISD-AS attribute on the span.
+func DstIA(span opentracing.Span, ia addr.IA) {
+	span.SetTag(TagDstIA, ia.String())
+}
+
+// SegmentIDs sets the segment IDs attribute on the span. The IDs are hex
+// encoded and comma separated.
+func SegmentIDs(span opentracing.Span, ids ...[]byte) {
+	encoded := make([]string, 0, len(ids))
+	for _, id := range ids {
+		encoded = append(encoded, hex.EncodeToString(id))
+	}
+	span.SetTag(TagSegmentIDs, strings.Join(encoded, ","))
+}
+
+// PathFingerprint sets the path fingerprint attribute on the span.
+func PathFingerprint(span opentracing.Span, fingerprint fmt.Stringer) {
+	span.SetTag(TagPathFingerprint, fingerprint.String())
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber/jaeger-client-go"
+)
+
+const (
+	// TraceParentHeader is the W3C trace-context header that carries the
+	// trace ID, the parent span ID and the trace flags.
+	TraceParentHeader = "traceparent"
+	// BaggageHeader is the W3C baggage header.
+	BaggageHeader = "baggage"
+
+	traceParentVersion = "00"
+	flagSampled        = 0x01
+)
+
+var (
+	_ jaeger.Injector  = TraceContextPropagator{}
+	_ jaeger.Extractor = TraceContextPropagator{}
+)
+
+// TraceContextPropagator propagates span contexts according to the W3C
+// trace-context specification (https://www.w3.org/TR/trace-context/). It
+// supports the opentracing.TextMap and opentracing.HTTPHeaders carrier
+// formats, which makes it usable for both gRPC metadata and HTTP requests.
+// Baggage items are carried in the W3C baggage header.
+type TraceContextPropagator struct {
+	// Fallback, if set, is used to extract the span context if the carrier
+	// does not contain a traceparent header. This allows interoperating with
+	// peers that still use a different propagation format.
+	Fallback jaeger.Extractor
+}
+
+// Inject writes the span context into the carrier.
+func (TraceContextPropagator) Inject(sc jaeger.SpanContext, abstractCarrier interface{}) error {
+	carrier, ok := abstractCarrier.(opentracing.TextMapWriter)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+	var flags byte
+	if sc.IsSampled() {
+		flags |= flagSampled
+	}
+	traceID := sc.TraceID()
+	carrier.Set(TraceParentHeader, fmt.Sprintf("%s-%016x%016x-%016x-%02x",
+		traceParentVersion, traceID.High, traceID.Low, uint64(sc.SpanID()), flags))
+
+	var baggage []string
+	sc.ForeachBaggageItem(func(k, v string) bool {
+		baggage = append(baggage, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		return true
+	})
+	if len(baggage) > 0 {
+		carrier.Set(BaggageHeader, strings.Join(baggage, ","))
+	}
+	return nil
+}
+
+// Extract reads the span context from the carrier. If the carrier does not
+// contain a traceparent header, the fallback extractor is used. Without a
+// fallback, opentracing.ErrSpanContextNotFound is returned.
+func (p TraceContextPropagator) Extract(abstractCarrier interface{}) (jaeger.SpanContext, error) {
+	carrier, ok := abstractCarrier.(opentracing.TextMapReader)
+	if !ok {
+		return jaeger.SpanContext{}, opentracing.ErrInvalidCarrier
+	}
+	var traceParent, rawBaggage string
+	err := carrier.ForeachKey(func(k, v string) error {
+		switch strings.ToLower(k) {
+		case TraceParentHeader:
+			traceParent = v
+		case BaggageHeader:
+			rawBaggage = v
+		}
+		return nil
+	})
+	if err != nil {
+		return jaeger.SpanContext{}, err
+	}
+	if traceParent == "" {
+		if p.Fallback != nil {
+			return p.Fallback.Extract(abstractCarrier)
+		}
+		return jaeger.SpanContext{}, opentracing.ErrSpanContextNotFound
+	}
+	traceID, spanID, flags, err := parseTraceParent(traceParent)
+	if err != nil {
+		return jaeger.SpanContext{}, err
+	}
+	return jaeger.NewSpanContext(traceID, spanID, 0, flags&flagSampled != 0,
+		parseBaggage(rawBaggage)), nil
+}
+
+func parseTraceParent(v string) (jaeger.TraceID, jaeger.SpanID, byte, error) {
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	// Future versions may append fields, version 00 has exactly four.
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		(parts[0] == traceParentVersion && len(parts) != 4) {
+		return jaeger.TraceID{}, 0, 0, opentracing.ErrSpanContextCorrupted
+	}
+	rawTraceID, err := hex.DecodeString(parts[1])
+	if err != nil || len(rawTraceID) != 16 {
+		return jaeger.TraceID{}, 0, 0, opentracing.ErrSpanContextCorrupted
+	}
+	rawSpanID, err := hex.DecodeString(parts[2])
+	if err != nil || len(rawSpanID) != 8 {
+		return jaeger.TraceID{}, 0, 0, opentracing.ErrSpanContextCorrupted
+	}
+	rawFlags, err := hex.DecodeString(parts[3])
+	if err != nil || len(rawFlags) != 1 {
+		return jaeger.TraceID{}, 0, 0, opentracing.ErrSpanContextCorrupted
+	}
+	traceID := jaeger.TraceID{
+		High: binary.BigEndian.Uint64(rawTraceID[:8]),
+		Low:  binary.BigEndian.Uint64(rawTraceID[8:]),
+	}
+	spanID := jaeger.SpanID(binary.BigEndian.Uint64(rawSpanID))
+	if !traceID.IsValid() || spanID == 0 {
+		return jaeger.TraceID{}, 0, 0, opentracing.ErrSpanContextCorrupted
+	}
+	return traceID, spanID, rawFlags[0], nil
+}
+
+func parseBaggage(v string) map[string]string {
+	if v == "" {
+		return nil
+	}
+	baggage := make(map[string]string)
+	for _, member := range strings.Split(v, ",") {
+		// Properties (separated by ';') are not supported and dropped.
+		member = strings.SplitN(member, ";", 2)[0]
+		kv := strings.SplitN(strings.TrimSpace(member), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		k, err := url.QueryUnescape(strings.TrimSpace(kv[0]))
+		if err != nil || k == "" {
+			continue
+		}
+		val, err := url.QueryUnescape(strings.TrimSpace(kv[1]))
+		if err != nil {
+			continue
+		}
+		baggage[k] = val
+	}
+	return baggage
+}
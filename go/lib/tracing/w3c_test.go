@@ -0,0 +1,139 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-client-go"
+
+	"github.com/scionproto/scion/go/lib/tracing"
+)
+
+func TestTraceContextPropagatorInject(t *testing.T) {
+	sc := jaeger.NewSpanContext(
+		jaeger.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736},
+		jaeger.SpanID(0x00f067aa0ba902b7), 0, true, map[string]string{"key": "a b"},
+	)
+	carrier := opentracing.TextMapCarrier{}
+	require.NoError(t, tracing.TraceContextPropagator{}.Inject(sc, carrier))
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		carrier[tracing.TraceParentHeader])
+	assert.Equal(t, "key=a+b", carrier[tracing.BaggageHeader])
+}
+
+func TestTraceContextPropagatorExtract(t *testing.T) {
+	testCases := map[string]struct {
+		Headers   map[string]string
+		TraceID   jaeger.TraceID
+		SpanID    jaeger.SpanID
+		Sampled   bool
+		Baggage   map[string]string
+		Fallback  bool
+		ErrAssert assert.ErrorAssertionFunc
+		Err       error
+	}{
+		"sampled": {
+			Headers: map[string]string{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				"baggage":     "key=a+b;prop=1, other=c",
+			},
+			TraceID:   jaeger.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736},
+			SpanID:    0x00f067aa0ba902b7,
+			Sampled:   true,
+			Baggage:   map[string]string{"key": "a b", "other": "c"},
+			ErrAssert: assert.NoError,
+		},
+		"not sampled, canonical header": {
+			Headers: map[string]string{
+				"Traceparent": "00-0000000000000000a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			},
+			TraceID:   jaeger.TraceID{Low: 0xa3ce929d0e0e4736},
+			SpanID:    0x00f067aa0ba902b7,
+			ErrAssert: assert.NoError,
+		},
+		"fallback": {
+			Headers:   map[string]string{"uber-trace-id": "a3ce929d0e0e4736:b7:0:1"},
+			Fallback:  true,
+			TraceID:   jaeger.TraceID{Low: 0xa3ce929d0e0e4736},
+			SpanID:    0xb7,
+			Sampled:   true,
+			ErrAssert: assert.NoError,
+		},
+		"missing": {
+			Headers:   map[string]string{"uber-trace-id": "1:2:0:1"},
+			ErrAssert: assert.Error,
+			Err:       opentracing.ErrSpanContextNotFound,
+		},
+		"invalid version": {
+			Headers: map[string]string{
+				"traceparent": "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+			ErrAssert: assert.Error,
+			Err:       opentracing.ErrSpanContextCorrupted,
+		},
+		"zero trace ID": {
+			Headers: map[string]string{
+				"traceparent": "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			},
+			ErrAssert: assert.Error,
+			Err:       opentracing.ErrSpanContextCorrupted,
+		},
+		"short span ID": {
+			Headers: map[string]string{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba9-01",
+			},
+			ErrAssert: assert.Error,
+			Err:       opentracing.ErrSpanContextCorrupted,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			carrier := opentracing.HTTPHeadersCarrier(http.Header{})
+			for k, v := range tc.Headers {
+				http.Header(carrier)[k] = []string{v}
+			}
+			p := tracing.TraceContextPropagator{}
+			if tc.Fallback {
+				p.Fallback = jaeger.NewHTTPHeaderPropagator(
+					(&jaeger.HeadersConfig{}).ApplyDefaults(), *jaeger.NewNullMetrics())
+			}
+			sc, err := p.Extract(carrier)
+			tc.ErrAssert(t, err)
+			if err != nil {
+				assert.Equal(t, tc.Err, err)
+				return
+			}
+			assert.Equal(t, tc.TraceID, sc.TraceID())
+			assert.Equal(t, tc.SpanID, sc.SpanID())
+			assert.Equal(t, tc.Sampled, sc.IsSampled())
+			baggage := map[string]string{}
+			sc.ForeachBaggageItem(func(k, v string) bool {
+				baggage[k] = v
+				return true
+			})
+			if tc.Baggage == nil {
+				tc.Baggage = map[string]string{}
+			}
+			assert.Equal(t, tc.Baggage, baggage)
+		})
+	}
+}
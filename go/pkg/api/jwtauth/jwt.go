@@ -17,6 +17,7 @@
 package jwtauth
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -226,10 +227,21 @@ func (v *HTTPVerifier) AddAuthorization(handler http.Handler) http.Handler {
 		}
 
 		log.SafeDebug(v.Logger, "Authorization successful", "subject", token.Subject())
-		handler.ServeHTTP(rw, req)
+		ctx := context.WithValue(req.Context(), subjectKey{}, token.Subject())
+		handler.ServeHTTP(rw, req.WithContext(ctx))
 	})
 }
 
+type subjectKey struct{}
+
+// SubjectFromContext returns the subject of the JWT token that authorized the
+// request. It returns false if the request did not pass through the
+// authorization step of an HTTPVerifier.
+func SubjectFromContext(ctx context.Context) (string, bool) {
+	subject, ok := ctx.Value(subjectKey{}).(string)
+	return subject, ok
+}
+
 // Error models an error that can be sent in the respresentation of an OpenAPI
 // JSON error, as defined in the CA OpenAPI Specification.
 type Error struct {
@@ -20,6 +20,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -207,3 +208,26 @@ func TestNewHTTPClient(t *testing.T) {
 		})
 	}
 }
+
+func TestSubjectFromContext(t *testing.T) {
+	var subject string
+	var ok bool
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		subject, ok = jwtauth.SubjectFromContext(req.Context())
+	})
+	verifier := jwtauth.HTTPVerifier{Generator: keyFunc(serverKey, nil)}
+
+	src := &jwtauth.JWTTokenSource{Subject: "example", Generator: keyFunc(serverKey, nil)}
+	token, err := src.Token()
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token.String())
+	verifier.AddAuthorization(handler).ServeHTTP(httptest.NewRecorder(), req)
+	assert.True(t, ok)
+	assert.Equal(t, "example", subject)
+
+	// Requests that did not pass through the verifier have no subject.
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.False(t, ok)
+	assert.Empty(t, subject)
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/pkg/api/jwtauth"
+)
+
+// AuthorizedHandler wraps the API handler such that the requests that match
+// are only served if they are authorized by the verifier. If the verifier is
+// nil, the matching requests are rejected with a forbidden problem with the
+// given title. Other requests are served without authorization.
+func AuthorizedHandler(handler http.Handler, verifier *jwtauth.HTTPVerifier,
+	match func(*http.Request) bool, disabledTitle string) http.Handler {
+
+	authorized := handler
+	if verifier != nil {
+		authorized = verifier.AddAuthorization(handler)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !match(r) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		if verifier == nil {
+			forbidden(w, disabledTitle)
+			return
+		}
+		authorized.ServeHTTP(w, r)
+	})
+}
+
+// Audit logs the outcome of a management operation, i.e., of a request that
+// changes the state of the service, together with the authorized subject and
+// the remote address of the request.
+func Audit(r *http.Request, op string, err error, ctx ...interface{}) {
+	subject, _ := jwtauth.SubjectFromContext(r.Context())
+	fields := append([]interface{}{
+		"operation", op,
+		"auth_subject", subject,
+		"remote", r.RemoteAddr,
+	}, ctx...)
+	logger := log.FromCtx(r.Context())
+	if err != nil {
+		logger.Info("Audit: management operation failed", append(fields, "err", err)...)
+		return
+	}
+	logger.Info("Audit: management operation succeeded", fields...)
+}
+
+// forbidden writes the forbidden problem for disabled management operations.
+func forbidden(w http.ResponseWriter, title string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(http.StatusForbidden)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	// no point in catching error here, there is nothing we can do about it anymore.
+	enc.Encode(struct {
+		Detail string `json:"detail"`
+		Status int    `json:"status"`
+		Title  string `json:"title"`
+		Type   string `json:"type"`
+	}{
+		Detail: "no shared secret for the management API configured",
+		Status: http.StatusForbidden,
+		Title:  title,
+		Type:   Forbidden,
+	})
+}
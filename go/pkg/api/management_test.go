@@ -0,0 +1,94 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/pkg/api"
+	"github.com/scionproto/scion/go/pkg/api/jwtauth"
+)
+
+func TestAuthorizedHandler(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	generator := func() ([]byte, error) { return key, nil }
+	verifier := &jwtauth.HTTPVerifier{Generator: generator}
+
+	testCases := map[string]struct {
+		Verifier  *jwtauth.HTTPVerifier
+		Path      string
+		Authorize bool
+		Status    int
+		Subject   string
+	}{
+		"not matching": {
+			Path:   "/info",
+			Status: http.StatusOK,
+		},
+		"disabled": {
+			Path:      "/management/state",
+			Authorize: true,
+			Status:    http.StatusForbidden,
+		},
+		"missing token": {
+			Verifier: verifier,
+			Path:     "/management/state",
+			Status:   http.StatusInternalServerError,
+		},
+		"authorized": {
+			Verifier:  verifier,
+			Path:      "/management/state",
+			Authorize: true,
+			Status:    http.StatusOK,
+			Subject:   "operator",
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var subject string
+			handler := api.AuthorizedHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					subject, _ = jwtauth.SubjectFromContext(r.Context())
+				}),
+				tc.Verifier,
+				func(r *http.Request) bool { return r.URL.Path == "/management/state" },
+				"management disabled",
+			)
+			req := httptest.NewRequest(http.MethodPut, tc.Path, nil)
+			if tc.Authorize {
+				src := &jwtauth.JWTTokenSource{Subject: "operator", Generator: generator}
+				token, err := src.Token()
+				require.NoError(t, err)
+				req.Header.Set("Authorization", "Bearer "+token.String())
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+			assert.Equal(t, tc.Subject, subject)
+			if tc.Status == http.StatusForbidden {
+				assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+				assert.Contains(t, rr.Body.String(), `"title": "management disabled"`)
+				assert.Contains(t, rr.Body.String(), `"type": "/problems/forbidden"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+// ResolverHelp defines the help message for host name resolution.
+const ResolverHelp = `Host names are looked up in the hosts file /etc/scion/hosts first, with the
+SCION daemon whose API address (host:port) is set in SCION_DAEMON_API second,
+and as DNS TXT record of the form "scion=ISD-AS,IP" last. Each line of the
+hosts file contains a SCION address followed by one or more names:
+
+  1-ff00:0:110,[10.0.0.1]  server.example.org server
+`
@@ -0,0 +1,385 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bwtest implements a bandwidth test between a client and a server.
+//
+// The client starts a session by sending a request with the parameters of the
+// test in both directions. After the server accepted the session, the client
+// and the server send data packets at the requested rate for the requested
+// duration. Each data packet carries a sequence number and the time at which
+// it was sent, from which the receiver derives loss, reordering and the
+// variation of the one-way delay. At the end of the test, the client fetches
+// the statistics of the data that the server received.
+//
+// All messages are exchanged over a single datagram connection, e.g., a SCION
+// UDP connection. Control messages are retransmitted until they are answered.
+package bwtest
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// MaxPacketSize is the maximum size of the data packets.
+	MaxPacketSize = 8192
+	// ControlTimeout is the time the client waits for the answer to a control
+	// message.
+	ControlTimeout = 3 * time.Second
+
+	retryInterval = 250 * time.Millisecond
+	// drainTime is the time the client waits for in-flight data packets
+	// after the test before it requests the results.
+	drainTime = time.Second
+)
+
+// Parameters are the parameters of the test in one direction.
+type Parameters struct {
+	// Duration is the duration of the test.
+	Duration time.Duration
+	// PacketSize is the size of the data packets in bytes.
+	PacketSize int
+	// Rate is the target rate in bits per second.
+	Rate int64
+}
+
+// IsZero returns whether the parameters are zero, i.e., no data is sent.
+func (p Parameters) IsZero() bool {
+	return p == Parameters{}
+}
+
+// Validate validates the parameters.
+func (p Parameters) Validate() error {
+	if p.Duration <= 0 {
+		return serrors.New("duration must be positive", "duration", p.Duration)
+	}
+	if p.PacketSize < DataHeaderLen || p.PacketSize > MaxPacketSize {
+		return serrors.New("packet size out of range", "size", p.PacketSize,
+			"min", DataHeaderLen, "max", MaxPacketSize)
+	}
+	if p.Rate <= 0 {
+		return serrors.New("rate must be positive", "rate", p.Rate)
+	}
+	return nil
+}
+
+// interval returns the time between two data packets.
+func (p Parameters) interval() time.Duration {
+	return time.Duration(int64(p.PacketSize) * 8 * int64(time.Second) / p.Rate)
+}
+
+// Stats are the statistics of the test in one direction.
+type Stats struct {
+	// Sent is the number of sent data packets.
+	Sent int64
+	// Received is the number of received data packets.
+	Received int64
+	// Bytes is the number of received bytes.
+	Bytes int64
+	// Reordered is the number of packets that were received after a packet
+	// with a higher sequence number.
+	Reordered int64
+	// Jitter is the interarrival jitter as defined in RFC 3550.
+	Jitter time.Duration
+	// DelayVariation is the difference between the largest and the smallest
+	// one-way delay. It does not depend on the clock offset between the
+	// sender and the receiver.
+	DelayVariation time.Duration
+	// Duration is the duration of the test.
+	Duration time.Duration
+}
+
+// Loss returns the fraction of the sent packets that were not received.
+func (s Stats) Loss() float64 {
+	if s.Sent == 0 || s.Received >= s.Sent {
+		return 0
+	}
+	return float64(s.Sent-s.Received) / float64(s.Sent)
+}
+
+// Goodput returns the rate of the received data in bits per second.
+func (s Stats) Goodput() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Bytes*8) / s.Duration.Seconds()
+}
+
+// Result is the result of a test. The statistics of a direction are nil if no
+// data was sent in that direction.
+type Result struct {
+	// Up are the statistics of the client to server direction.
+	Up *Stats
+	// Down are the statistics of the server to client direction.
+	Down *Stats
+}
+
+// Run runs a test against the server at the remote address. The connection
+// must not be used by anything else while the test is running. At least one
+// of the directions must have non-zero parameters.
+func Run(ctx context.Context, conn net.PacketConn, remote net.Addr,
+	up, down Parameters) (Result, error) {
+
+	if up.IsZero() && down.IsZero() {
+		return Result{}, serrors.New("no direction to test")
+	}
+	for _, p := range []Parameters{up, down} {
+		if p.IsZero() {
+			continue
+		}
+		if err := p.Validate(); err != nil {
+			return Result{}, err
+		}
+	}
+	c := &client{
+		conn:    conn,
+		remote:  remote,
+		id:      rand.Uint32(),
+		control: make(chan []byte, 8),
+	}
+	readerDone := make(chan struct{})
+	go func() {
+		defer log.HandlePanic()
+		defer close(readerDone)
+		c.read()
+	}()
+	defer func() {
+		// Unblock the reader and reset the deadline for later users of the
+		// connection.
+		conn.SetReadDeadline(time.Now())
+		<-readerDone
+		conn.SetReadDeadline(time.Time{})
+	}()
+
+	req := request{ID: c.id, Up: up, Down: down}
+	rep, err := c.exchange(ctx, req.encode(), msgAccept, msgReject)
+	if err != nil {
+		return Result{}, serrors.WrapStr("starting session", err)
+	}
+	if rep[0] == msgReject {
+		return Result{}, serrors.New("session rejected by server")
+	}
+
+	start := time.Now()
+	var upSent int64
+	if !up.IsZero() {
+		upSent = send(ctx, conn, remote, c.id, up)
+	}
+	end := start.Add(maxDuration(up, down) + drainTime)
+	select {
+	case <-time.After(time.Until(end)):
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+
+	rep, err = c.exchange(ctx, control(msgResultRequest, c.id), msgResult)
+	if err != nil {
+		return Result{}, serrors.WrapStr("fetching result", err)
+	}
+	srvResult, err := decodeResult(rep)
+	if err != nil {
+		return Result{}, err
+	}
+	conn.SetReadDeadline(time.Now())
+	<-readerDone
+
+	var res Result
+	if !up.IsZero() {
+		s := srvResult.Up
+		s.Sent = upSent
+		s.Duration = up.Duration
+		res.Up = &s
+	}
+	if !down.IsZero() {
+		s := c.down.stats()
+		s.Sent = srvResult.DownSent
+		s.Duration = down.Duration
+		res.Down = &s
+	}
+	return res, nil
+}
+
+type client struct {
+	conn   net.PacketConn
+	remote net.Addr
+	id     uint32
+	// control receives the control messages of the session.
+	control chan []byte
+	// down accumulates the received data packets. It is only accessed by the
+	// reader until the reader is done.
+	down receiver
+}
+
+func (c *client) read() {
+	buf := make([]byte, MaxPacketSize)
+	for {
+		n, _, err := c.conn.ReadFrom(buf)
+		if err != nil {
+			if !isTimeout(err) {
+				log.Debug("Reading failed", "err", err)
+			}
+			return
+		}
+		now := time.Now()
+		typ, id, err := decodeHeader(buf[:n])
+		if err != nil || id != c.id {
+			continue
+		}
+		if typ == msgData {
+			seq, sent, err := decodeData(buf[:n])
+			if err != nil {
+				continue
+			}
+			c.down.add(seq, sent, now, n)
+			continue
+		}
+		msg := append([]byte(nil), buf[:n]...)
+		select {
+		case c.control <- msg:
+		default:
+		}
+	}
+}
+
+// exchange sends the message until one of the expected replies is received.
+func (c *client) exchange(ctx context.Context, msg []byte, expected ...byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, ControlTimeout)
+	defer cancel()
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := c.conn.WriteTo(msg, c.remote); err != nil {
+			return nil, serrors.WrapStr("sending control message", err)
+		}
+		for wait := true; wait; {
+			select {
+			case rep := <-c.control:
+				for _, typ := range expected {
+					if rep[0] == typ {
+						return rep, nil
+					}
+				}
+			case <-ticker.C:
+				wait = false
+			case <-ctx.Done():
+				return nil, serrors.WrapStr("no reply from server", ctx.Err())
+			}
+		}
+	}
+}
+
+// send sends data packets according to the parameters, and returns the number
+// of sent packets.
+func send(ctx context.Context, conn net.PacketConn, remote net.Addr, id uint32,
+	p Parameters) int64 {
+
+	buf := make([]byte, p.PacketSize)
+	interval := p.interval()
+	start := time.Now()
+	end := start.Add(p.Duration)
+	var seq uint64
+	for {
+		now := time.Now()
+		if !now.Before(end) || ctx.Err() != nil {
+			return int64(seq)
+		}
+		// Send all packets that are due, and sleep until the next one is.
+		if due := start.Add(time.Duration(seq) * interval); due.After(now) {
+			if due.After(end) {
+				due = end
+			}
+			select {
+			case <-time.After(due.Sub(now)):
+			case <-ctx.Done():
+			}
+			continue
+		}
+		encodeData(buf, id, seq, now)
+		if _, err := conn.WriteTo(buf, remote); err != nil {
+			log.Debug("Sending data packet failed", "seq", seq, "err", err)
+		}
+		seq++
+	}
+}
+
+// receiver accumulates the statistics of the received data packets.
+type receiver struct {
+	received  int64
+	bytes     int64
+	reordered int64
+	nextSeq   uint64
+	// jitter is the RFC 3550 jitter estimate in nanoseconds.
+	jitter     float64
+	lastDelay  time.Duration
+	minDelay   time.Duration
+	maxDelay   time.Duration
+	hasPackets bool
+}
+
+func (r *receiver) add(seq uint64, sent, now time.Time, size int) {
+	r.received++
+	r.bytes += int64(size)
+	if seq < r.nextSeq {
+		r.reordered++
+	} else {
+		r.nextSeq = seq + 1
+	}
+	// The delay includes the clock offset between sender and receiver, which
+	// cancels out in the jitter and the delay variation.
+	delay := now.Sub(sent)
+	if !r.hasPackets {
+		r.hasPackets = true
+		r.lastDelay, r.minDelay, r.maxDelay = delay, delay, delay
+		return
+	}
+	d := delay - r.lastDelay
+	if d < 0 {
+		d = -d
+	}
+	r.jitter += (float64(d) - r.jitter) / 16
+	r.lastDelay = delay
+	if delay < r.minDelay {
+		r.minDelay = delay
+	}
+	if delay > r.maxDelay {
+		r.maxDelay = delay
+	}
+}
+
+func (r *receiver) stats() Stats {
+	return Stats{
+		Received:       r.received,
+		Bytes:          r.bytes,
+		Reordered:      r.reordered,
+		Jitter:         time.Duration(r.jitter),
+		DelayVariation: r.maxDelay - r.minDelay,
+	}
+}
+
+func maxDuration(a, b Parameters) time.Duration {
+	if a.Duration > b.Duration {
+		return a.Duration
+	}
+	return b.Duration
+}
+
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
@@ -0,0 +1,170 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bwtest
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessages(t *testing.T) {
+	req := request{
+		ID:   42,
+		Up:   Parameters{Duration: time.Second, PacketSize: 1000, Rate: 1e6},
+		Down: Parameters{},
+	}
+	decodedReq, err := decodeRequest(req.encode())
+	require.NoError(t, err)
+	assert.Equal(t, req, decodedReq)
+
+	res := result{
+		ID: 42,
+		Up: Stats{
+			Received:       10,
+			Bytes:          10000,
+			Reordered:      1,
+			Jitter:         time.Millisecond,
+			DelayVariation: 2 * time.Millisecond,
+		},
+		DownSent: 12,
+	}
+	decodedRes, err := decodeResult(res.encode())
+	require.NoError(t, err)
+	assert.Equal(t, res, decodedRes)
+
+	_, err = decodeRequest(res.encode()[:10])
+	assert.Error(t, err)
+}
+
+func TestReceiver(t *testing.T) {
+	start := time.Now()
+	var r receiver
+	// Packet 2 is reordered, the delay alternates between 10ms and 14ms.
+	for i, seq := range []uint64{0, 1, 3, 2, 4} {
+		delay := 10 * time.Millisecond
+		if i%2 == 1 {
+			delay = 14 * time.Millisecond
+		}
+		sent := start.Add(time.Duration(seq) * time.Millisecond)
+		r.add(seq, sent, sent.Add(delay), 100)
+	}
+	s := r.stats()
+	assert.EqualValues(t, 5, s.Received)
+	assert.EqualValues(t, 500, s.Bytes)
+	assert.EqualValues(t, 1, s.Reordered)
+	assert.Equal(t, 4*time.Millisecond, s.DelayVariation)
+	assert.True(t, s.Jitter > 0 && s.Jitter < 4*time.Millisecond, s.Jitter)
+}
+
+func TestStats(t *testing.T) {
+	s := Stats{Sent: 100, Received: 75, Bytes: 125000, Duration: time.Second}
+	assert.Equal(t, 0.25, s.Loss())
+	assert.Equal(t, 1e6, s.Goodput())
+	assert.Equal(t, float64(0), Stats{Sent: 1, Received: 2}.Loss())
+}
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srvConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer srvConn.Close()
+	server := &Server{Conn: srvConn, MaxRate: 10e6}
+	srvDone := make(chan error, 1)
+	go func() {
+		srvDone <- server.Run(ctx)
+	}()
+
+	cliConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer cliConn.Close()
+
+	params := Parameters{Duration: 200 * time.Millisecond, PacketSize: 500, Rate: 1e6}
+	res, err := Run(ctx, cliConn, srvConn.LocalAddr(), params, params)
+	require.NoError(t, err)
+	for _, s := range []*Stats{res.Up, res.Down} {
+		require.NotNil(t, s)
+		// 1 Mbit/s with 500 byte packets is 250 packets per second.
+		assert.InDelta(t, 50, s.Sent, 5)
+		assert.Equal(t, s.Sent, s.Received)
+		assert.Equal(t, s.Received*500, s.Bytes)
+	}
+
+	t.Run("up only", func(t *testing.T) {
+		res, err := Run(ctx, cliConn, srvConn.LocalAddr(), params, Parameters{})
+		require.NoError(t, err)
+		assert.NotNil(t, res.Up)
+		assert.Nil(t, res.Down)
+	})
+	t.Run("rejected", func(t *testing.T) {
+		tooFast := Parameters{Duration: time.Second, PacketSize: 500, Rate: 100e6}
+		_, err := Run(ctx, cliConn, srvConn.LocalAddr(), tooFast, Parameters{})
+		assert.Error(t, err)
+	})
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, err := Run(ctx, cliConn, srvConn.LocalAddr(), Parameters{}, Parameters{})
+		assert.Error(t, err)
+		_, err = Run(ctx, cliConn, srvConn.LocalAddr(),
+			Parameters{Duration: time.Second, PacketSize: 1, Rate: 1e6}, Parameters{})
+		assert.Error(t, err)
+	})
+
+	cancel()
+	assert.NoError(t, <-srvDone)
+}
+
+func TestServerMaxSessions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srvConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer srvConn.Close()
+	server := &Server{Conn: srvConn, MaxSessions: 1}
+	srvDone := make(chan error, 1)
+	go func() {
+		srvDone <- server.Run(ctx)
+	}()
+
+	params := Parameters{Duration: 500 * time.Millisecond, PacketSize: 500, Rate: 1e6}
+	first := make(chan error, 1)
+	go func() {
+		cliConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		if err != nil {
+			first <- err
+			return
+		}
+		defer cliConn.Close()
+		_, err = Run(ctx, cliConn, srvConn.LocalAddr(), params, Parameters{})
+		first <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	cliConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer cliConn.Close()
+	_, err = Run(ctx, cliConn, srvConn.LocalAddr(), params, Parameters{})
+	assert.Error(t, err)
+	assert.NoError(t, <-first)
+
+	cancel()
+	assert.NoError(t, <-srvDone)
+}
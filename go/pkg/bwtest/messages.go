@@ -0,0 +1,171 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bwtest
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Message types. Every message starts with the type (1 byte) and the session
+// ID (4 bytes).
+const (
+	msgRequest byte = iota + 1
+	msgAccept
+	msgData
+	msgResultRequest
+	msgResult
+	msgReject
+)
+
+const (
+	msgHeaderLen = 5
+	paramsLen    = 20
+	requestLen   = msgHeaderLen + 2*paramsLen
+	// DataHeaderLen is the length of the header of the data packets. The data
+	// packets must be at least this large.
+	DataHeaderLen = msgHeaderLen + 16
+	statsLen      = 6 * 8
+	resultLen     = msgHeaderLen + statsLen + 8
+)
+
+// request starts a session. The parameters of a direction are zero if no data
+// is sent in that direction.
+type request struct {
+	ID uint32
+	// Up are the parameters of the client to server direction.
+	Up Parameters
+	// Down are the parameters of the server to client direction.
+	Down Parameters
+}
+
+func (r request) encode() []byte {
+	b := make([]byte, requestLen)
+	encodeHeader(b, msgRequest, r.ID)
+	encodeParams(b[msgHeaderLen:], r.Up)
+	encodeParams(b[msgHeaderLen+paramsLen:], r.Down)
+	return b
+}
+
+func decodeRequest(b []byte) (request, error) {
+	if len(b) < requestLen {
+		return request{}, serrors.New("request too short", "len", len(b))
+	}
+	return request{
+		ID:   binary.BigEndian.Uint32(b[1:]),
+		Up:   decodeParams(b[msgHeaderLen:]),
+		Down: decodeParams(b[msgHeaderLen+paramsLen:]),
+	}, nil
+}
+
+func encodeParams(b []byte, p Parameters) {
+	binary.BigEndian.PutUint64(b, uint64(p.Duration))
+	binary.BigEndian.PutUint32(b[8:], uint32(p.PacketSize))
+	binary.BigEndian.PutUint64(b[12:], uint64(p.Rate))
+}
+
+func decodeParams(b []byte) Parameters {
+	return Parameters{
+		Duration:   time.Duration(binary.BigEndian.Uint64(b)),
+		PacketSize: int(binary.BigEndian.Uint32(b[8:])),
+		Rate:       int64(binary.BigEndian.Uint64(b[12:])),
+	}
+}
+
+// encodeData encodes the data packet header into b, which must be at least
+// DataHeaderLen bytes long. The rest of b is left as is.
+func encodeData(b []byte, id uint32, seq uint64, sent time.Time) {
+	encodeHeader(b, msgData, id)
+	binary.BigEndian.PutUint64(b[msgHeaderLen:], seq)
+	binary.BigEndian.PutUint64(b[msgHeaderLen+8:], uint64(sent.UnixNano()))
+}
+
+func decodeData(b []byte) (uint64, time.Time, error) {
+	if len(b) < DataHeaderLen {
+		return 0, time.Time{}, serrors.New("data packet too short", "len", len(b))
+	}
+	seq := binary.BigEndian.Uint64(b[msgHeaderLen:])
+	sent := time.Unix(0, int64(binary.BigEndian.Uint64(b[msgHeaderLen+8:])))
+	return seq, sent, nil
+}
+
+// result reports the statistics that the server measured for the session.
+type result struct {
+	ID uint32
+	// Up are the statistics of the data received by the server. The number
+	// of sent packets is not known to the server and is always zero.
+	Up Stats
+	// DownSent is the number of packets sent by the server.
+	DownSent int64
+}
+
+func (r result) encode() []byte {
+	b := make([]byte, resultLen)
+	encodeHeader(b, msgResult, r.ID)
+	s := b[msgHeaderLen:]
+	for i, v := range []int64{
+		r.Up.Sent,
+		r.Up.Received,
+		r.Up.Bytes,
+		r.Up.Reordered,
+		int64(r.Up.Jitter),
+		int64(r.Up.DelayVariation),
+		r.DownSent,
+	} {
+		binary.BigEndian.PutUint64(s[8*i:], uint64(v))
+	}
+	return b
+}
+
+func decodeResult(b []byte) (result, error) {
+	if len(b) < resultLen {
+		return result{}, serrors.New("result too short", "len", len(b))
+	}
+	s := b[msgHeaderLen:]
+	v := func(i int) int64 { return int64(binary.BigEndian.Uint64(s[8*i:])) }
+	return result{
+		ID: binary.BigEndian.Uint32(b[1:]),
+		Up: Stats{
+			Sent:           v(0),
+			Received:       v(1),
+			Bytes:          v(2),
+			Reordered:      v(3),
+			Jitter:         time.Duration(v(4)),
+			DelayVariation: time.Duration(v(5)),
+		},
+		DownSent: v(6),
+	}, nil
+}
+
+// control encodes a message that only consists of the header.
+func control(typ byte, id uint32) []byte {
+	b := make([]byte, msgHeaderLen)
+	encodeHeader(b, typ, id)
+	return b
+}
+
+func encodeHeader(b []byte, typ byte, id uint32) {
+	b[0] = typ
+	binary.BigEndian.PutUint32(b[1:], id)
+}
+
+func decodeHeader(b []byte) (byte, uint32, error) {
+	if len(b) < msgHeaderLen {
+		return 0, 0, serrors.New("message too short", "len", len(b))
+	}
+	return b[0], binary.BigEndian.Uint32(b[1:]), nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bwtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// Report is the report of a test over one or several paths.
+type Report struct {
+	Remote string       `json:"remote"`
+	Paths  []PathReport `json:"paths"`
+}
+
+// PathReport is the report of the test over one path.
+type PathReport struct {
+	FullPath    snet.Path        `json:"-"`
+	Fingerprint string           `json:"fingerprint"`
+	Up          *DirectionReport `json:"up,omitempty"`
+	Down        *DirectionReport `json:"down,omitempty"`
+	Error       string           `json:"error,omitempty"`
+}
+
+// DirectionReport is the report of the test in one direction.
+type DirectionReport struct {
+	Sent           int64         `json:"sent"`
+	Received       int64         `json:"received"`
+	Bytes          int64         `json:"bytes"`
+	Reordered      int64         `json:"reordered"`
+	Loss           float64       `json:"loss"`
+	Goodput        float64       `json:"goodput_bps"`
+	Jitter         time.Duration `json:"jitter"`
+	DelayVariation time.Duration `json:"delay_variation"`
+}
+
+// NewPathReport creates the report of the test over the path. If err is not
+// nil, the report only contains the error.
+func NewPathReport(path snet.Path, res Result, err error) PathReport {
+	r := PathReport{FullPath: path}
+	if path != nil {
+		r.Fingerprint = snet.Fingerprint(path).String()
+	}
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.Up = newDirectionReport(res.Up)
+	r.Down = newDirectionReport(res.Down)
+	return r
+}
+
+func newDirectionReport(s *Stats) *DirectionReport {
+	if s == nil {
+		return nil
+	}
+	return &DirectionReport{
+		Sent:           s.Sent,
+		Received:       s.Received,
+		Bytes:          s.Bytes,
+		Reordered:      s.Reordered,
+		Loss:           s.Loss(),
+		Goodput:        s.Goodput(),
+		Jitter:         s.Jitter,
+		DelayVariation: s.DelayVariation,
+	}
+}
+
+// Human writes human readable output to the writer.
+func (r Report) Human(w io.Writer) {
+	fmt.Fprintf(w, "Bandwidth test to %s\n", r.Remote)
+	for i, p := range r.Paths {
+		fmt.Fprintf(w, "[%2d] %s\n", i, p.FullPath)
+		if p.Error != "" {
+			fmt.Fprintf(w, "     error: %s\n", p.Error)
+			continue
+		}
+		p.Up.human(w, "up")
+		p.Down.human(w, "down")
+	}
+}
+
+func (d *DirectionReport) human(w io.Writer, direction string) {
+	if d == nil {
+		return
+	}
+	fmt.Fprintf(w, "     %-4s goodput=%.2fMbit/s loss=%.2f%% (%d/%d) reordered=%d "+
+		"jitter=%s delay_variation=%s\n",
+		direction, d.Goodput/1e6, d.Loss*100, d.Sent-d.Received, d.Sent, d.Reordered,
+		d.Jitter.Round(time.Microsecond), d.DelayVariation.Round(time.Microsecond))
+}
+
+// JSON writes the report as machine readable json to the writer.
+func (r Report) JSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
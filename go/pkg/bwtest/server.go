@@ -0,0 +1,199 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bwtest
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// sessionGrace is the time after the end of the test for which a session is
+// kept, such that the client can fetch the result.
+const sessionGrace = 30 * time.Second
+
+// Server serves bandwidth tests.
+type Server struct {
+	// Conn is the connection the server receives the requests on.
+	Conn net.PacketConn
+	// MaxDuration is the maximum test duration the server accepts. If it is
+	// zero, the duration is not limited.
+	MaxDuration time.Duration
+	// MaxRate is the maximum rate in bits per second the server accepts per
+	// direction. If it is zero, the rate is not limited.
+	MaxRate int64
+	// MaxSessions is the maximum number of concurrent sessions the server
+	// accepts. Sessions are kept until the client had the chance to fetch the
+	// result. If it is zero, the number of sessions is not limited.
+	MaxSessions int
+}
+
+type sessionKey struct {
+	remote string
+	id     uint32
+}
+
+type session struct {
+	remote  net.Addr
+	up      receiver
+	expires time.Time
+	cancel  context.CancelFunc
+	// downSent is the number of data packets sent to the client. It is
+	// written by the sender, and must be accessed atomically.
+	downSent int64
+}
+
+// Run serves the requests until the context is done.
+func (s *Server) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		defer log.HandlePanic()
+		<-ctx.Done()
+		s.Conn.SetReadDeadline(time.Now())
+	}()
+
+	sessions := make(map[sessionKey]*session)
+	defer func() {
+		for _, sess := range sessions {
+			sess.cancel()
+		}
+	}()
+	buf := make([]byte, MaxPacketSize)
+	lastPurge := time.Now()
+	for {
+		n, remote, err := s.Conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return serrors.WrapStr("reading", err)
+		}
+		now := time.Now()
+		if now.Sub(lastPurge) > time.Second {
+			purge(sessions, now)
+			lastPurge = now
+		}
+		typ, id, err := decodeHeader(buf[:n])
+		if err != nil {
+			continue
+		}
+		key := sessionKey{remote: remote.String(), id: id}
+		sess := sessions[key]
+		switch typ {
+		case msgData:
+			if sess == nil {
+				continue
+			}
+			if seq, sent, err := decodeData(buf[:n]); err == nil {
+				sess.up.add(seq, sent, now, n)
+			}
+		case msgRequest:
+			if sess != nil {
+				// The accept message got lost.
+				s.reply(control(msgAccept, id), remote)
+				continue
+			}
+			req, err := decodeRequest(buf[:n])
+			if err != nil {
+				continue
+			}
+			if s.MaxSessions != 0 && len(sessions) >= s.MaxSessions {
+				purge(sessions, now)
+			}
+			if err := s.validate(req, len(sessions)); err != nil {
+				log.Info("Rejecting bandwidth test", "remote", remote, "err", err)
+				s.reply(control(msgReject, id), remote)
+				continue
+			}
+			sessions[key] = s.start(ctx, req, remote, now)
+			log.Info("Starting bandwidth test", "remote", remote, "id", id,
+				"up", req.Up, "down", req.Down)
+			s.reply(control(msgAccept, id), remote)
+		case msgResultRequest:
+			if sess == nil {
+				continue
+			}
+			s.reply(result{
+				ID:       id,
+				Up:       sess.up.stats(),
+				DownSent: atomic.LoadInt64(&sess.downSent),
+			}.encode(), remote)
+		}
+	}
+}
+
+func (s *Server) validate(req request, sessions int) error {
+	if req.Up.IsZero() && req.Down.IsZero() {
+		return serrors.New("no direction to test")
+	}
+	if s.MaxSessions != 0 && sessions >= s.MaxSessions {
+		return serrors.New("too many sessions", "sessions", sessions, "max", s.MaxSessions)
+	}
+	for _, p := range []Parameters{req.Up, req.Down} {
+		if p.IsZero() {
+			continue
+		}
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if s.MaxDuration != 0 && p.Duration > s.MaxDuration {
+			return serrors.New("duration too long", "duration", p.Duration,
+				"max", s.MaxDuration)
+		}
+		if s.MaxRate != 0 && p.Rate > s.MaxRate {
+			return serrors.New("rate too high", "rate", p.Rate, "max", s.MaxRate)
+		}
+	}
+	return nil
+}
+
+func (s *Server) start(ctx context.Context, req request, remote net.Addr,
+	now time.Time) *session {
+
+	ctx, cancel := context.WithCancel(ctx)
+	sess := &session{
+		remote:  remote,
+		expires: now.Add(maxDuration(req.Up, req.Down) + sessionGrace),
+		cancel:  cancel,
+	}
+	if !req.Down.IsZero() {
+		go func() {
+			defer log.HandlePanic()
+			sent := send(ctx, s.Conn, remote, req.ID, req.Down)
+			atomic.StoreInt64(&sess.downSent, sent)
+		}()
+	}
+	return sess
+}
+
+func (s *Server) reply(msg []byte, remote net.Addr) {
+	if _, err := s.Conn.WriteTo(msg, remote); err != nil {
+		log.Debug("Sending reply failed", "remote", remote, "err", err)
+	}
+}
+
+func purge(sessions map[sessionKey]*session, now time.Time) {
+	for key, sess := range sessions {
+		if now.After(sess.expires) {
+			sess.cancel()
+			delete(sessions, key)
+		}
+	}
+}
@@ -17,6 +17,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
@@ -26,6 +27,7 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/ifstate"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -41,6 +43,7 @@ import (
 
 type BeaconStore interface {
 	GetBeacons(context.Context, *beaconstorage.QueryParams) ([]beaconstorage.Beacon, error)
+	DeleteBeacons(context.Context, *beaconstorage.QueryParams) (int, error)
 }
 
 type Healther interface {
@@ -63,6 +66,31 @@ type TRCHealthData struct {
 	TRCID             cppki.TRCID
 }
 
+// PolicyReloader reloads the beacon policies and the static info
+// configuration, and reports the active ones.
+type PolicyReloader interface {
+	Reload(context.Context) error
+	Status() (PolicyStatusData, error)
+}
+
+// PolicyStatusData is used to extract the active beacon policies and static
+// info configuration.
+type PolicyStatusData struct {
+	Digest   []byte
+	LoadedAt time.Time
+	Policies []PolicyData
+	// StaticInfoFile is empty if static info is disabled.
+	StaticInfoFile   string
+	StaticInfoDigest []byte
+}
+
+// PolicyData is used to extract an active beacon policy.
+type PolicyData struct {
+	Type   beacon.PolicyType
+	File   string
+	Digest []byte
+}
+
 // Server implements the Control Service API.
 type Server struct {
 	SegmentsServer segapi.Server
@@ -76,6 +104,13 @@ type Server struct {
 	Topology       http.HandlerFunc
 	TrustDB        storage.TrustDB
 	Healther       Healther
+	Policies       PolicyReloader
+	Statistics     Statistics
+	// Segments, Interfaces and BeaconPolicies are only used by the management
+	// endpoints.
+	Segments       SegmentStore
+	Interfaces     *ifstate.Interfaces
+	BeaconPolicies PolicyUpdater
 }
 
 // UnpackBeaconUsages extracts the Usage's bits as snake case string constants for the API.
@@ -268,6 +303,62 @@ func sortFactory(sortParam *GetBeaconsParamsSort) (func(b []*Beacon), error) {
 	}
 }
 
+// GetBeaconPolicies gets the active beacon policies and static info
+// configuration.
+func (s *Server) GetBeaconPolicies(w http.ResponseWriter, r *http.Request) {
+	status, err := s.Policies.Status()
+	if err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error reading policy status",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	writeJSON(w, convertPolicyStatus(status))
+}
+
+func convertPolicyStatus(status PolicyStatusData) PolicyStatus {
+	rep := PolicyStatus{
+		Digest:   hex.EncodeToString(status.Digest),
+		LoadedAt: status.LoadedAt.UTC(),
+		Policies: make([]ActivePolicy, 0, len(status.Policies)),
+	}
+	for _, p := range status.Policies {
+		policy := ActivePolicy{
+			Type:   convertPolicyType(p.Type),
+			Digest: hex.EncodeToString(p.Digest),
+		}
+		if p.File != "" {
+			policy.File = api.StringRef(p.File)
+		}
+		rep.Policies = append(rep.Policies, policy)
+	}
+	if status.StaticInfoFile != "" {
+		rep.StaticInfo = &StaticInfoStatus{
+			File:   status.StaticInfoFile,
+			Digest: hex.EncodeToString(status.StaticInfoDigest),
+		}
+	}
+	return rep
+}
+
+func convertPolicyType(t beacon.PolicyType) PolicyType {
+	switch t {
+	case beacon.PropPolicy:
+		return PolicyTypePropagation
+	case beacon.UpRegPolicy:
+		return PolicyTypeUpRegistration
+	case beacon.DownRegPolicy:
+		return PolicyTypeDownRegistration
+	case beacon.CoreRegPolicy:
+		return PolicyTypeCoreRegistration
+	default:
+		return PolicyType(t)
+	}
+}
+
 // GetSegments gets the stored in the PathDB.
 func (s *Server) GetSegments(w http.ResponseWriter,
 	r *http.Request, params GetSegmentsParams) {
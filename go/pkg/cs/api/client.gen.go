@@ -90,6 +90,9 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetBeaconPolicies request
+	GetBeaconPolicies(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetBeacons request
 	GetBeacons(ctx context.Context, params *GetBeaconsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -122,6 +125,39 @@ type ClientInterface interface {
 
 	SetLogLevel(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TriggerOrigination request with any body
+	TriggerOriginationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TriggerOrigination(ctx context.Context, body TriggerOriginationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutBeaconPolicy request with any body
+	PutBeaconPolicyWithBody(ctx context.Context, policyType PolicyType, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TriggerPropagation request with any body
+	TriggerPropagationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TriggerPropagation(ctx context.Context, body TriggerPropagationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReloadBeaconPolicies request
+	ReloadBeaconPolicies(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteBeacons request
+	DeleteBeacons(ctx context.Context, params *DeleteBeaconsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportCertificateChain request with any body
+	ImportCertificateChainWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetInterfaceAdminState request with any body
+	SetInterfaceAdminStateWithBody(ctx context.Context, interfaceId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetInterfaceAdminState(ctx context.Context, interfaceId int, body SetInterfaceAdminStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteSegments request
+	DeleteSegments(ctx context.Context, params *DeleteSegmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportTrc request with any body
+	ImportTrcWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetSegments request
 	GetSegments(ctx context.Context, params *GetSegmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -137,6 +173,12 @@ type ClientInterface interface {
 	// GetSignerChain request
 	GetSignerChain(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetBeaconStatistics request
+	GetBeaconStatistics(ctx context.Context, params *GetBeaconStatisticsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSegmentLookupStatistics request
+	GetSegmentLookupStatistics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetTopology request
 	GetTopology(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -150,6 +192,18 @@ type ClientInterface interface {
 	GetTrcBlob(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) GetBeaconPolicies(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBeaconPoliciesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetBeacons(ctx context.Context, params *GetBeaconsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetBeaconsRequest(c.Server, params)
 	if err != nil {
@@ -282,6 +336,150 @@ func (c *Client) SetLogLevel(ctx context.Context, body SetLogLevelJSONRequestBod
 	return c.Client.Do(req)
 }
 
+func (c *Client) TriggerOriginationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTriggerOriginationRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TriggerOrigination(ctx context.Context, body TriggerOriginationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTriggerOriginationRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutBeaconPolicyWithBody(ctx context.Context, policyType PolicyType, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutBeaconPolicyRequestWithBody(c.Server, policyType, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TriggerPropagationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTriggerPropagationRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TriggerPropagation(ctx context.Context, body TriggerPropagationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTriggerPropagationRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReloadBeaconPolicies(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReloadBeaconPoliciesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteBeacons(ctx context.Context, params *DeleteBeaconsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteBeaconsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportCertificateChainWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportCertificateChainRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetInterfaceAdminStateWithBody(ctx context.Context, interfaceId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetInterfaceAdminStateRequestWithBody(c.Server, interfaceId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetInterfaceAdminState(ctx context.Context, interfaceId int, body SetInterfaceAdminStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetInterfaceAdminStateRequest(c.Server, interfaceId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteSegments(ctx context.Context, params *DeleteSegmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSegmentsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportTrcWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportTrcRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetSegments(ctx context.Context, params *GetSegmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetSegmentsRequest(c.Server, params)
 	if err != nil {
@@ -342,6 +540,30 @@ func (c *Client) GetSignerChain(ctx context.Context, reqEditors ...RequestEditor
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetBeaconStatistics(ctx context.Context, params *GetBeaconStatisticsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBeaconStatisticsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSegmentLookupStatistics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSegmentLookupStatisticsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetTopology(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetTopologyRequest(c.Server)
 	if err != nil {
@@ -390,6 +612,33 @@ func (c *Client) GetTrcBlob(ctx context.Context, isd int, base int, serial int,
 	return c.Client.Do(req)
 }
 
+// NewGetBeaconPoliciesRequest generates requests for GetBeaconPolicies
+func NewGetBeaconPoliciesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/beaconing/policies")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetBeaconsRequest generates requests for GetBeacons
 func NewGetBeaconsRequest(server string, params *GetBeaconsParams) (*http.Request, error) {
 	var err error
@@ -855,8 +1104,19 @@ func NewSetLogLevelRequestWithBody(server string, contentType string, body io.Re
 	return req, nil
 }
 
-// NewGetSegmentsRequest generates requests for GetSegments
-func NewGetSegmentsRequest(server string, params *GetSegmentsParams) (*http.Request, error) {
+// NewTriggerOriginationRequest calls the generic TriggerOrigination builder with application/json body
+func NewTriggerOriginationRequest(server string, body TriggerOriginationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTriggerOriginationRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewTriggerOriginationRequestWithBody generates requests for TriggerOrigination with any type of body
+func NewTriggerOriginationRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -864,7 +1124,7 @@ func NewGetSegmentsRequest(server string, params *GetSegmentsParams) (*http.Requ
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/segments")
+	operationPath := fmt.Sprintf("/management/beaconing/origination")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -874,57 +1134,23 @@ func NewGetSegmentsRequest(server string, params *GetSegmentsParams) (*http.Requ
 		return nil, err
 	}
 
-	queryValues := queryURL.Query()
-
-	if params.StartIsdAs != nil {
-
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_isd_as", runtime.ParamLocationQuery, *params.StartIsdAs); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
-
-	}
-
-	if params.EndIsdAs != nil {
-
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end_isd_as", runtime.ParamLocationQuery, *params.EndIsdAs); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
-
-	}
-
-	queryURL.RawQuery = queryValues.Encode()
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewGetSegmentRequest generates requests for GetSegment
-func NewGetSegmentRequest(server string, segmentId SegmentIDs) (*http.Request, error) {
+// NewPutBeaconPolicyRequestWithBody generates requests for PutBeaconPolicy with any type of body
+func NewPutBeaconPolicyRequestWithBody(server string, policyType PolicyType, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "policy-type", runtime.ParamLocationPath, policyType)
 	if err != nil {
 		return nil, err
 	}
@@ -934,7 +1160,7 @@ func NewGetSegmentRequest(server string, segmentId SegmentIDs) (*http.Request, e
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/segments/%s", pathParam0)
+	operationPath := fmt.Sprintf("/management/beaconing/policies/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -944,31 +1170,487 @@ func NewGetSegmentRequest(server string, segmentId SegmentIDs) (*http.Request, e
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewGetSegmentBlobRequest generates requests for GetSegmentBlob
-func NewGetSegmentBlobRequest(server string, segmentId SegmentIDs) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
+// NewTriggerPropagationRequest calls the generic TriggerPropagation builder with application/json body
+func NewTriggerPropagationRequest(server string, body TriggerPropagationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTriggerPropagationRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewTriggerPropagationRequestWithBody generates requests for TriggerPropagation with any type of body
+func NewTriggerPropagationRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/management/beaconing/propagation")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewReloadBeaconPoliciesRequest generates requests for ReloadBeaconPolicies
+func NewReloadBeaconPoliciesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/management/beaconing/reload")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteBeaconsRequest generates requests for DeleteBeacons
+func NewDeleteBeaconsRequest(server string, params *DeleteBeaconsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/management/beacons")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.SegmentId != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "segment_id", runtime.ParamLocationQuery, *params.SegmentId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.StartIsdAs != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_isd_as", runtime.ParamLocationQuery, *params.StartIsdAs); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.IngressInterface != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ingress_interface", runtime.ParamLocationQuery, *params.IngressInterface); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewImportCertificateChainRequestWithBody generates requests for ImportCertificateChain with any type of body
+func NewImportCertificateChainRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/management/certificates")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSetInterfaceAdminStateRequest calls the generic SetInterfaceAdminState builder with application/json body
+func NewSetInterfaceAdminStateRequest(server string, interfaceId int, body SetInterfaceAdminStateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetInterfaceAdminStateRequestWithBody(server, interfaceId, "application/json", bodyReader)
+}
+
+// NewSetInterfaceAdminStateRequestWithBody generates requests for SetInterfaceAdminState with any type of body
+func NewSetInterfaceAdminStateRequestWithBody(server string, interfaceId int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "interface-id", runtime.ParamLocationPath, interfaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/management/interfaces/%s/admin-state", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteSegmentsRequest generates requests for DeleteSegments
+func NewDeleteSegmentsRequest(server string, params *DeleteSegmentsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/management/segments")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.SegmentId != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "segment_id", runtime.ParamLocationQuery, *params.SegmentId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.StartIsdAs != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_isd_as", runtime.ParamLocationQuery, *params.StartIsdAs); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.EndIsdAs != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end_isd_as", runtime.ParamLocationQuery, *params.EndIsdAs); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewImportTrcRequestWithBody generates requests for ImportTrc with any type of body
+func NewImportTrcRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/management/trcs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSegmentsRequest generates requests for GetSegments
+func NewGetSegmentsRequest(server string, params *GetSegmentsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/segments")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.StartIsdAs != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_isd_as", runtime.ParamLocationQuery, *params.StartIsdAs); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.EndIsdAs != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end_isd_as", runtime.ParamLocationQuery, *params.EndIsdAs); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSegmentRequest generates requests for GetSegment
+func NewGetSegmentRequest(server string, segmentId SegmentIDs) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/segments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSegmentBlobRequest generates requests for GetSegmentBlob
+func NewGetSegmentBlobRequest(server string, segmentId SegmentIDs) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/segments/%s/blob", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSignerRequest generates requests for GetSigner
+func NewGetSignerRequest(server string) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/segments/%s/blob", pathParam0)
+	operationPath := fmt.Sprintf("/signer")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -986,8 +1668,8 @@ func NewGetSegmentBlobRequest(server string, segmentId SegmentIDs) (*http.Reques
 	return req, nil
 }
 
-// NewGetSignerRequest generates requests for GetSigner
-func NewGetSignerRequest(server string) (*http.Request, error) {
+// NewGetSignerChainRequest generates requests for GetSignerChain
+func NewGetSignerChainRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -995,7 +1677,7 @@ func NewGetSignerRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/signer")
+	operationPath := fmt.Sprintf("/signer/blob")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1013,8 +1695,8 @@ func NewGetSignerRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetSignerChainRequest generates requests for GetSignerChain
-func NewGetSignerChainRequest(server string) (*http.Request, error) {
+// NewGetBeaconStatisticsRequest generates requests for GetBeaconStatistics
+func NewGetBeaconStatisticsRequest(server string, params *GetBeaconStatisticsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1022,7 +1704,70 @@ func NewGetSignerChainRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/signer/blob")
+	operationPath := fmt.Sprintf("/statistics/beacons")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.StartIsdAs != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_isd_as", runtime.ParamLocationQuery, *params.StartIsdAs); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.IngressInterface != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ingress_interface", runtime.ParamLocationQuery, *params.IngressInterface); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSegmentLookupStatisticsRequest generates requests for GetSegmentLookupStatistics
+func NewGetSegmentLookupStatisticsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/statistics/segment-lookups")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1269,6 +2014,9 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// GetBeaconPolicies request
+	GetBeaconPoliciesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetBeaconPoliciesResponse, error)
+
 	// GetBeacons request
 	GetBeaconsWithResponse(ctx context.Context, params *GetBeaconsParams, reqEditors ...RequestEditorFn) (*GetBeaconsResponse, error)
 
@@ -1299,46 +2047,308 @@ type ClientWithResponsesInterface interface {
 	// SetLogLevel request with any body
 	SetLogLevelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
 
-	SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
+	SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
+
+	// TriggerOrigination request with any body
+	TriggerOriginationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TriggerOriginationResponse, error)
+
+	TriggerOriginationWithResponse(ctx context.Context, body TriggerOriginationJSONRequestBody, reqEditors ...RequestEditorFn) (*TriggerOriginationResponse, error)
+
+	// PutBeaconPolicy request with any body
+	PutBeaconPolicyWithBodyWithResponse(ctx context.Context, policyType PolicyType, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutBeaconPolicyResponse, error)
+
+	// TriggerPropagation request with any body
+	TriggerPropagationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TriggerPropagationResponse, error)
+
+	TriggerPropagationWithResponse(ctx context.Context, body TriggerPropagationJSONRequestBody, reqEditors ...RequestEditorFn) (*TriggerPropagationResponse, error)
+
+	// ReloadBeaconPolicies request
+	ReloadBeaconPoliciesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReloadBeaconPoliciesResponse, error)
+
+	// DeleteBeacons request
+	DeleteBeaconsWithResponse(ctx context.Context, params *DeleteBeaconsParams, reqEditors ...RequestEditorFn) (*DeleteBeaconsResponse, error)
+
+	// ImportCertificateChain request with any body
+	ImportCertificateChainWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportCertificateChainResponse, error)
+
+	// SetInterfaceAdminState request with any body
+	SetInterfaceAdminStateWithBodyWithResponse(ctx context.Context, interfaceId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetInterfaceAdminStateResponse, error)
+
+	SetInterfaceAdminStateWithResponse(ctx context.Context, interfaceId int, body SetInterfaceAdminStateJSONRequestBody, reqEditors ...RequestEditorFn) (*SetInterfaceAdminStateResponse, error)
+
+	// DeleteSegments request
+	DeleteSegmentsWithResponse(ctx context.Context, params *DeleteSegmentsParams, reqEditors ...RequestEditorFn) (*DeleteSegmentsResponse, error)
+
+	// ImportTrc request with any body
+	ImportTrcWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportTrcResponse, error)
+
+	// GetSegments request
+	GetSegmentsWithResponse(ctx context.Context, params *GetSegmentsParams, reqEditors ...RequestEditorFn) (*GetSegmentsResponse, error)
+
+	// GetSegment request
+	GetSegmentWithResponse(ctx context.Context, segmentId SegmentIDs, reqEditors ...RequestEditorFn) (*GetSegmentResponse, error)
+
+	// GetSegmentBlob request
+	GetSegmentBlobWithResponse(ctx context.Context, segmentId SegmentIDs, reqEditors ...RequestEditorFn) (*GetSegmentBlobResponse, error)
+
+	// GetSigner request
+	GetSignerWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerResponse, error)
+
+	// GetSignerChain request
+	GetSignerChainWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerChainResponse, error)
+
+	// GetBeaconStatistics request
+	GetBeaconStatisticsWithResponse(ctx context.Context, params *GetBeaconStatisticsParams, reqEditors ...RequestEditorFn) (*GetBeaconStatisticsResponse, error)
+
+	// GetSegmentLookupStatistics request
+	GetSegmentLookupStatisticsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSegmentLookupStatisticsResponse, error)
+
+	// GetTopology request
+	GetTopologyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTopologyResponse, error)
+
+	// GetTrcs request
+	GetTrcsWithResponse(ctx context.Context, params *GetTrcsParams, reqEditors ...RequestEditorFn) (*GetTrcsResponse, error)
+
+	// GetTrc request
+	GetTrcWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcResponse, error)
+
+	// GetTrcBlob request
+	GetTrcBlobWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcBlobResponse, error)
+}
+
+type GetBeaconPoliciesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PolicyStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBeaconPoliciesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBeaconPoliciesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBeaconsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Beacons *[]Beacon `json:"beacons,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBeaconsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBeaconsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CA
+	JSON400      *StandardError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCertificatesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ChainBrief
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCertificatesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCertificatesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCertificateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Chain
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCertificateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCertificateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCertificateBlobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCertificateBlobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCertificateBlobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *StandardError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// GetSegments request
-	GetSegmentsWithResponse(ctx context.Context, params *GetSegmentsParams, reqEditors ...RequestEditorFn) (*GetSegmentsResponse, error)
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthResponse
+	JSON400      *StandardError
+}
 
-	// GetSegment request
-	GetSegmentWithResponse(ctx context.Context, segmentId SegmentIDs, reqEditors ...RequestEditorFn) (*GetSegmentResponse, error)
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetSegmentBlob request
-	GetSegmentBlobWithResponse(ctx context.Context, segmentId SegmentIDs, reqEditors ...RequestEditorFn) (*GetSegmentBlobResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// GetSigner request
-	GetSignerWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerResponse, error)
+type GetInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *StandardError
+}
 
-	// GetSignerChain request
-	GetSignerChainWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerChainResponse, error)
+// Status returns HTTPResponse.Status
+func (r GetInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetTopology request
-	GetTopologyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTopologyResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// GetTrcs request
-	GetTrcsWithResponse(ctx context.Context, params *GetTrcsParams, reqEditors ...RequestEditorFn) (*GetTrcsResponse, error)
+type GetLogLevelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LogLevel
+	JSON400      *StandardError
+}
 
-	// GetTrc request
-	GetTrcWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcResponse, error)
+// Status returns HTTPResponse.Status
+func (r GetLogLevelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetTrcBlob request
-	GetTrcBlobWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcBlobResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLogLevelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
-type GetBeaconsResponse struct {
+type SetLogLevelResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *struct {
-		Beacons *[]Beacon `json:"beacons,omitempty"`
-	}
+	JSON200      *LogLevel
+	JSON400      *StandardError
 }
 
 // Status returns HTTPResponse.Status
-func (r GetBeaconsResponse) Status() string {
+func (r SetLogLevelResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1346,22 +2356,20 @@ func (r GetBeaconsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetBeaconsResponse) StatusCode() int {
+func (r SetLogLevelResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetCaResponse struct {
+type TriggerOriginationResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *CA
-	JSON400      *StandardError
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCaResponse) Status() string {
+func (r TriggerOriginationResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1369,21 +2377,20 @@ func (r GetCaResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCaResponse) StatusCode() int {
+func (r TriggerOriginationResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetCertificatesResponse struct {
+type PutBeaconPolicyResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *[]ChainBrief
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCertificatesResponse) Status() string {
+func (r PutBeaconPolicyResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1391,21 +2398,20 @@ func (r GetCertificatesResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCertificatesResponse) StatusCode() int {
+func (r PutBeaconPolicyResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetCertificateResponse struct {
+type TriggerPropagationResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Chain
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCertificateResponse) Status() string {
+func (r TriggerPropagationResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1413,20 +2419,21 @@ func (r GetCertificateResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCertificateResponse) StatusCode() int {
+func (r TriggerPropagationResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetCertificateBlobResponse struct {
+type ReloadBeaconPoliciesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON200      *PolicyStatus
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCertificateBlobResponse) Status() string {
+func (r ReloadBeaconPoliciesResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1434,21 +2441,21 @@ func (r GetCertificateBlobResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCertificateBlobResponse) StatusCode() int {
+func (r ReloadBeaconPoliciesResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetConfigResponse struct {
+type DeleteBeaconsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON400      *StandardError
+	JSON200      *DeleteResult
 }
 
 // Status returns HTTPResponse.Status
-func (r GetConfigResponse) Status() string {
+func (r DeleteBeaconsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1456,22 +2463,21 @@ func (r GetConfigResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetConfigResponse) StatusCode() int {
+func (r DeleteBeaconsResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetHealthResponse struct {
+type ImportCertificateChainResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *HealthResponse
-	JSON400      *StandardError
+	JSON200      *ImportResult
 }
 
 // Status returns HTTPResponse.Status
-func (r GetHealthResponse) Status() string {
+func (r ImportCertificateChainResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1479,21 +2485,20 @@ func (r GetHealthResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetHealthResponse) StatusCode() int {
+func (r ImportCertificateChainResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetInfoResponse struct {
+type SetInterfaceAdminStateResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON400      *StandardError
 }
 
 // Status returns HTTPResponse.Status
-func (r GetInfoResponse) Status() string {
+func (r SetInterfaceAdminStateResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1501,22 +2506,21 @@ func (r GetInfoResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetInfoResponse) StatusCode() int {
+func (r SetInterfaceAdminStateResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetLogLevelResponse struct {
+type DeleteSegmentsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *LogLevel
-	JSON400      *StandardError
+	JSON200      *DeleteResult
 }
 
 // Status returns HTTPResponse.Status
-func (r GetLogLevelResponse) Status() string {
+func (r DeleteSegmentsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1524,22 +2528,21 @@ func (r GetLogLevelResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetLogLevelResponse) StatusCode() int {
+func (r DeleteSegmentsResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type SetLogLevelResponse struct {
+type ImportTrcResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *LogLevel
-	JSON400      *StandardError
+	JSON200      *ImportResult
 }
 
 // Status returns HTTPResponse.Status
-func (r SetLogLevelResponse) Status() string {
+func (r ImportTrcResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1547,7 +2550,7 @@ func (r SetLogLevelResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r SetLogLevelResponse) StatusCode() int {
+func (r ImportTrcResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -1664,6 +2667,50 @@ func (r GetSignerChainResponse) StatusCode() int {
 	return 0
 }
 
+type GetBeaconStatisticsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BeaconStatistics
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBeaconStatisticsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBeaconStatisticsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSegmentLookupStatisticsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LookupStatistics
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSegmentLookupStatisticsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSegmentLookupStatisticsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetTopologyResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -1755,6 +2802,15 @@ func (r GetTrcBlobResponse) StatusCode() int {
 	return 0
 }
 
+// GetBeaconPoliciesWithResponse request returning *GetBeaconPoliciesResponse
+func (c *ClientWithResponses) GetBeaconPoliciesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetBeaconPoliciesResponse, error) {
+	rsp, err := c.GetBeaconPolicies(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBeaconPoliciesResponse(rsp)
+}
+
 // GetBeaconsWithResponse request returning *GetBeaconsResponse
 func (c *ClientWithResponses) GetBeaconsWithResponse(ctx context.Context, params *GetBeaconsParams, reqEditors ...RequestEditorFn) (*GetBeaconsResponse, error) {
 	rsp, err := c.GetBeacons(ctx, params, reqEditors...)
@@ -1815,42 +2871,147 @@ func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEdit
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetHealthResponse(rsp)
+	return ParseGetHealthResponse(rsp)
+}
+
+// GetInfoWithResponse request returning *GetInfoResponse
+func (c *ClientWithResponses) GetInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInfoResponse, error) {
+	rsp, err := c.GetInfo(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInfoResponse(rsp)
+}
+
+// GetLogLevelWithResponse request returning *GetLogLevelResponse
+func (c *ClientWithResponses) GetLogLevelWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogLevelResponse, error) {
+	rsp, err := c.GetLogLevel(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLogLevelResponse(rsp)
+}
+
+// SetLogLevelWithBodyWithResponse request with arbitrary body returning *SetLogLevelResponse
+func (c *ClientWithResponses) SetLogLevelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error) {
+	rsp, err := c.SetLogLevelWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLogLevelResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error) {
+	rsp, err := c.SetLogLevel(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLogLevelResponse(rsp)
+}
+
+// TriggerOriginationWithBodyWithResponse request with arbitrary body returning *TriggerOriginationResponse
+func (c *ClientWithResponses) TriggerOriginationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TriggerOriginationResponse, error) {
+	rsp, err := c.TriggerOriginationWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTriggerOriginationResponse(rsp)
+}
+
+func (c *ClientWithResponses) TriggerOriginationWithResponse(ctx context.Context, body TriggerOriginationJSONRequestBody, reqEditors ...RequestEditorFn) (*TriggerOriginationResponse, error) {
+	rsp, err := c.TriggerOrigination(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTriggerOriginationResponse(rsp)
+}
+
+// PutBeaconPolicyWithBodyWithResponse request with arbitrary body returning *PutBeaconPolicyResponse
+func (c *ClientWithResponses) PutBeaconPolicyWithBodyWithResponse(ctx context.Context, policyType PolicyType, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutBeaconPolicyResponse, error) {
+	rsp, err := c.PutBeaconPolicyWithBody(ctx, policyType, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutBeaconPolicyResponse(rsp)
+}
+
+// TriggerPropagationWithBodyWithResponse request with arbitrary body returning *TriggerPropagationResponse
+func (c *ClientWithResponses) TriggerPropagationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TriggerPropagationResponse, error) {
+	rsp, err := c.TriggerPropagationWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTriggerPropagationResponse(rsp)
+}
+
+func (c *ClientWithResponses) TriggerPropagationWithResponse(ctx context.Context, body TriggerPropagationJSONRequestBody, reqEditors ...RequestEditorFn) (*TriggerPropagationResponse, error) {
+	rsp, err := c.TriggerPropagation(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTriggerPropagationResponse(rsp)
+}
+
+// ReloadBeaconPoliciesWithResponse request returning *ReloadBeaconPoliciesResponse
+func (c *ClientWithResponses) ReloadBeaconPoliciesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReloadBeaconPoliciesResponse, error) {
+	rsp, err := c.ReloadBeaconPolicies(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReloadBeaconPoliciesResponse(rsp)
+}
+
+// DeleteBeaconsWithResponse request returning *DeleteBeaconsResponse
+func (c *ClientWithResponses) DeleteBeaconsWithResponse(ctx context.Context, params *DeleteBeaconsParams, reqEditors ...RequestEditorFn) (*DeleteBeaconsResponse, error) {
+	rsp, err := c.DeleteBeacons(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteBeaconsResponse(rsp)
+}
+
+// ImportCertificateChainWithBodyWithResponse request with arbitrary body returning *ImportCertificateChainResponse
+func (c *ClientWithResponses) ImportCertificateChainWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportCertificateChainResponse, error) {
+	rsp, err := c.ImportCertificateChainWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportCertificateChainResponse(rsp)
 }
 
-// GetInfoWithResponse request returning *GetInfoResponse
-func (c *ClientWithResponses) GetInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInfoResponse, error) {
-	rsp, err := c.GetInfo(ctx, reqEditors...)
+// SetInterfaceAdminStateWithBodyWithResponse request with arbitrary body returning *SetInterfaceAdminStateResponse
+func (c *ClientWithResponses) SetInterfaceAdminStateWithBodyWithResponse(ctx context.Context, interfaceId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetInterfaceAdminStateResponse, error) {
+	rsp, err := c.SetInterfaceAdminStateWithBody(ctx, interfaceId, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetInfoResponse(rsp)
+	return ParseSetInterfaceAdminStateResponse(rsp)
 }
 
-// GetLogLevelWithResponse request returning *GetLogLevelResponse
-func (c *ClientWithResponses) GetLogLevelWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogLevelResponse, error) {
-	rsp, err := c.GetLogLevel(ctx, reqEditors...)
+func (c *ClientWithResponses) SetInterfaceAdminStateWithResponse(ctx context.Context, interfaceId int, body SetInterfaceAdminStateJSONRequestBody, reqEditors ...RequestEditorFn) (*SetInterfaceAdminStateResponse, error) {
+	rsp, err := c.SetInterfaceAdminState(ctx, interfaceId, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetLogLevelResponse(rsp)
+	return ParseSetInterfaceAdminStateResponse(rsp)
 }
 
-// SetLogLevelWithBodyWithResponse request with arbitrary body returning *SetLogLevelResponse
-func (c *ClientWithResponses) SetLogLevelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error) {
-	rsp, err := c.SetLogLevelWithBody(ctx, contentType, body, reqEditors...)
+// DeleteSegmentsWithResponse request returning *DeleteSegmentsResponse
+func (c *ClientWithResponses) DeleteSegmentsWithResponse(ctx context.Context, params *DeleteSegmentsParams, reqEditors ...RequestEditorFn) (*DeleteSegmentsResponse, error) {
+	rsp, err := c.DeleteSegments(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseSetLogLevelResponse(rsp)
+	return ParseDeleteSegmentsResponse(rsp)
 }
 
-func (c *ClientWithResponses) SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error) {
-	rsp, err := c.SetLogLevel(ctx, body, reqEditors...)
+// ImportTrcWithBodyWithResponse request with arbitrary body returning *ImportTrcResponse
+func (c *ClientWithResponses) ImportTrcWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportTrcResponse, error) {
+	rsp, err := c.ImportTrcWithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseSetLogLevelResponse(rsp)
+	return ParseImportTrcResponse(rsp)
 }
 
 // GetSegmentsWithResponse request returning *GetSegmentsResponse
@@ -1898,6 +3059,24 @@ func (c *ClientWithResponses) GetSignerChainWithResponse(ctx context.Context, re
 	return ParseGetSignerChainResponse(rsp)
 }
 
+// GetBeaconStatisticsWithResponse request returning *GetBeaconStatisticsResponse
+func (c *ClientWithResponses) GetBeaconStatisticsWithResponse(ctx context.Context, params *GetBeaconStatisticsParams, reqEditors ...RequestEditorFn) (*GetBeaconStatisticsResponse, error) {
+	rsp, err := c.GetBeaconStatistics(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBeaconStatisticsResponse(rsp)
+}
+
+// GetSegmentLookupStatisticsWithResponse request returning *GetSegmentLookupStatisticsResponse
+func (c *ClientWithResponses) GetSegmentLookupStatisticsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSegmentLookupStatisticsResponse, error) {
+	rsp, err := c.GetSegmentLookupStatistics(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSegmentLookupStatisticsResponse(rsp)
+}
+
 // GetTopologyWithResponse request returning *GetTopologyResponse
 func (c *ClientWithResponses) GetTopologyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTopologyResponse, error) {
 	rsp, err := c.GetTopology(ctx, reqEditors...)
@@ -1934,6 +3113,32 @@ func (c *ClientWithResponses) GetTrcBlobWithResponse(ctx context.Context, isd in
 	return ParseGetTrcBlobResponse(rsp)
 }
 
+// ParseGetBeaconPoliciesResponse parses an HTTP response from a GetBeaconPoliciesWithResponse call
+func ParseGetBeaconPoliciesResponse(rsp *http.Response) (*GetBeaconPoliciesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBeaconPoliciesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PolicyStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetBeaconsResponse parses an HTTP response from a GetBeaconsWithResponse call
 func ParseGetBeaconsResponse(rsp *http.Response) (*GetBeaconsResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -2214,6 +3419,200 @@ func ParseSetLogLevelResponse(rsp *http.Response) (*SetLogLevelResponse, error)
 	return response, nil
 }
 
+// ParseTriggerOriginationResponse parses an HTTP response from a TriggerOriginationWithResponse call
+func ParseTriggerOriginationResponse(rsp *http.Response) (*TriggerOriginationResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TriggerOriginationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePutBeaconPolicyResponse parses an HTTP response from a PutBeaconPolicyWithResponse call
+func ParsePutBeaconPolicyResponse(rsp *http.Response) (*PutBeaconPolicyResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutBeaconPolicyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseTriggerPropagationResponse parses an HTTP response from a TriggerPropagationWithResponse call
+func ParseTriggerPropagationResponse(rsp *http.Response) (*TriggerPropagationResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TriggerPropagationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseReloadBeaconPoliciesResponse parses an HTTP response from a ReloadBeaconPoliciesWithResponse call
+func ParseReloadBeaconPoliciesResponse(rsp *http.Response) (*ReloadBeaconPoliciesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReloadBeaconPoliciesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PolicyStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteBeaconsResponse parses an HTTP response from a DeleteBeaconsWithResponse call
+func ParseDeleteBeaconsResponse(rsp *http.Response) (*DeleteBeaconsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteBeaconsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DeleteResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseImportCertificateChainResponse parses an HTTP response from a ImportCertificateChainWithResponse call
+func ParseImportCertificateChainResponse(rsp *http.Response) (*ImportCertificateChainResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportCertificateChainResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetInterfaceAdminStateResponse parses an HTTP response from a SetInterfaceAdminStateWithResponse call
+func ParseSetInterfaceAdminStateResponse(rsp *http.Response) (*SetInterfaceAdminStateResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetInterfaceAdminStateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseDeleteSegmentsResponse parses an HTTP response from a DeleteSegmentsWithResponse call
+func ParseDeleteSegmentsResponse(rsp *http.Response) (*DeleteSegmentsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteSegmentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DeleteResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseImportTrcResponse parses an HTTP response from a ImportTrcWithResponse call
+func ParseImportTrcResponse(rsp *http.Response) (*ImportTrcResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportTrcResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetSegmentsResponse parses an HTTP response from a GetSegmentsWithResponse call
 func ParseGetSegmentsResponse(rsp *http.Response) (*GetSegmentsResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -2341,6 +3740,58 @@ func ParseGetSignerChainResponse(rsp *http.Response) (*GetSignerChainResponse, e
 	return response, nil
 }
 
+// ParseGetBeaconStatisticsResponse parses an HTTP response from a GetBeaconStatisticsWithResponse call
+func ParseGetBeaconStatisticsResponse(rsp *http.Response) (*GetBeaconStatisticsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBeaconStatisticsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BeaconStatistics
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSegmentLookupStatisticsResponse parses an HTTP response from a GetSegmentLookupStatisticsWithResponse call
+func ParseGetSegmentLookupStatisticsResponse(rsp *http.Response) (*GetSegmentLookupStatisticsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSegmentLookupStatisticsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LookupStatistics
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetTopologyResponse parses an HTTP response from a GetTopologyWithResponse call
 func ParseGetTopologyResponse(rsp *http.Response) (*GetTopologyResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -0,0 +1,449 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/ifstate"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	api "github.com/scionproto/scion/go/pkg/api"
+	"github.com/scionproto/scion/go/pkg/api/jwtauth"
+	beaconstorage "github.com/scionproto/scion/go/pkg/storage/beacon"
+	"github.com/scionproto/scion/go/pkg/trust"
+)
+
+// maxBodySize is the maximum size of request bodies accepted by the
+// management endpoints.
+const maxBodySize = 1 << 20
+
+// SegmentStore deletes path segments.
+type SegmentStore interface {
+	Delete(context.Context, *query.Params) (int, error)
+}
+
+// PolicyUpdater replaces beacon policies at runtime.
+type PolicyUpdater interface {
+	UpdatePolicy(context.Context, beacon.Policy) error
+}
+
+// ManagementHandler wraps the API handler such that the management endpoints
+// below the base URL are only served for requests that are authorized by the
+// verifier. If the verifier is nil, the management endpoints are disabled.
+func ManagementHandler(handler http.Handler, baseURL string,
+	verifier *jwtauth.HTTPVerifier) http.Handler {
+
+	prefix := baseURL + "/management/"
+	return api.AuthorizedHandler(handler, verifier, func(r *http.Request) bool {
+		return strings.HasPrefix(r.URL.Path, prefix)
+	}, "management endpoints disabled")
+}
+
+// DeleteSegments deletes the path segments matching the filter from the path
+// database.
+func (s *Server) DeleteSegments(w http.ResponseWriter, r *http.Request,
+	params DeleteSegmentsParams) {
+
+	q := query.Params{}
+	var errs serrors.List
+	if params.SegmentId != nil {
+		ids, err := parseSegmentIDFilter(*params.SegmentId)
+		if err != nil {
+			errs = append(errs, serrors.WrapStr("parsing segment_id", err))
+		}
+		q.SegIDs = ids
+	}
+	if params.StartIsdAs != nil {
+		if ia, err := parseIAFilter(*params.StartIsdAs); err == nil {
+			q.StartsAt = []addr.IA{ia}
+		} else {
+			errs = append(errs, serrors.WrapStr("parsing start_isd_as", err))
+		}
+	}
+	if params.EndIsdAs != nil {
+		if ia, err := parseIAFilter(*params.EndIsdAs); err == nil {
+			q.EndsAt = []addr.IA{ia}
+		} else {
+			errs = append(errs, serrors.WrapStr("parsing end_isd_as", err))
+		}
+	}
+	if params.SegmentId == nil && params.StartIsdAs == nil && params.EndIsdAs == nil {
+		errs = append(errs, serrors.New("at least one filter must be specified"))
+	}
+	if err := errs.ToError(); err != nil {
+		badRequest(w, r, "delete_segments", "malformed query parameters", err)
+		return
+	}
+	deleted, err := s.Segments.Delete(r.Context(), &q)
+	if err != nil {
+		internalError(w, r, "delete_segments", "error deleting segments", err)
+		return
+	}
+	api.Audit(r, "delete_segments", nil, "params", q, "deleted", deleted)
+	writeJSON(w, DeleteResult{Deleted: deleted})
+}
+
+// DeleteBeacons deletes the beacons matching the filter from the beacon
+// database.
+func (s *Server) DeleteBeacons(w http.ResponseWriter, r *http.Request,
+	params DeleteBeaconsParams) {
+
+	q := beaconstorage.QueryParams{}
+	var errs serrors.List
+	if params.SegmentId != nil {
+		ids, err := parseSegmentIDFilter(*params.SegmentId)
+		if err != nil {
+			errs = append(errs, serrors.WrapStr("parsing segment_id", err))
+		}
+		q.SegIDs = ids
+	}
+	if params.StartIsdAs != nil {
+		if ia, err := parseIAFilter(*params.StartIsdAs); err == nil {
+			q.StartsAt = []addr.IA{ia}
+		} else {
+			errs = append(errs, serrors.WrapStr("parsing start_isd_as", err))
+		}
+	}
+	if params.IngressInterface != nil {
+		if *params.IngressInterface < 0 || *params.IngressInterface > 65535 {
+			errs = append(errs, serrors.New(
+				"value for parameter out of range",
+				"ingress_interface",
+				*params.IngressInterface,
+			))
+		}
+		q.IngressInterfaces = []uint16{uint16(*params.IngressInterface)}
+	}
+	if params.SegmentId == nil && params.StartIsdAs == nil && params.IngressInterface == nil {
+		errs = append(errs, serrors.New("at least one filter must be specified"))
+	}
+	if err := errs.ToError(); err != nil {
+		badRequest(w, r, "delete_beacons", "malformed query parameters", err)
+		return
+	}
+	deleted, err := s.Beacons.DeleteBeacons(r.Context(), &q)
+	if err != nil {
+		internalError(w, r, "delete_beacons", "error deleting beacons", err)
+		return
+	}
+	api.Audit(r, "delete_beacons", nil, "params", q, "deleted", deleted)
+	writeJSON(w, DeleteResult{Deleted: deleted})
+}
+
+// TriggerOrigination triggers the origination of beacons on the specified
+// interfaces.
+func (s *Server) TriggerOrigination(w http.ResponseWriter, r *http.Request) {
+	s.trigger(w, r, "trigger_origination", func(intf *ifstate.Interface) {
+		intf.Originate(time.Time{})
+	})
+}
+
+// TriggerPropagation triggers the propagation of beacons on the specified
+// interfaces.
+func (s *Server) TriggerPropagation(w http.ResponseWriter, r *http.Request) {
+	s.trigger(w, r, "trigger_propagation", func(intf *ifstate.Interface) {
+		intf.Propagate(time.Time{})
+	})
+}
+
+// trigger resets the timestamp of the last origination or propagation on the
+// selected interfaces, such that the beaconing tasks consider them overdue
+// in their next run.
+func (s *Server) trigger(w http.ResponseWriter, r *http.Request, op string,
+	reset func(*ifstate.Interface)) {
+
+	var body InterfaceSelection
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).
+		Decode(&body); err != nil {
+
+		badRequest(w, r, op, "malformed request body", err)
+		return
+	}
+	if len(body.Interfaces) == 0 {
+		badRequest(w, r, op, "malformed request body", serrors.New("no interfaces specified"))
+		return
+	}
+	intfs := make([]*ifstate.Interface, 0, len(body.Interfaces))
+	var errs serrors.List
+	for _, id := range body.Interfaces {
+		var intf *ifstate.Interface
+		if id > 0 && id <= 65535 {
+			intf = s.Interfaces.Get(uint16(id))
+		}
+		if intf == nil {
+			errs = append(errs, serrors.New("unknown interface", "interface", id))
+			continue
+		}
+		intfs = append(intfs, intf)
+	}
+	if err := errs.ToError(); err != nil {
+		badRequest(w, r, op, "invalid interfaces", err)
+		return
+	}
+	for _, intf := range intfs {
+		reset(intf)
+	}
+	api.Audit(r, op, nil, "interfaces", body.Interfaces)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PutBeaconPolicy replaces the beacon policy of the given type.
+func (s *Server) PutBeaconPolicy(w http.ResponseWriter, r *http.Request,
+	policyType PolicyType) {
+
+	var t beacon.PolicyType
+	switch policyType {
+	case PolicyTypePropagation:
+		t = beacon.PropPolicy
+	case PolicyTypeUpRegistration:
+		t = beacon.UpRegPolicy
+	case PolicyTypeDownRegistration:
+		t = beacon.DownRegPolicy
+	case PolicyTypeCoreRegistration:
+		t = beacon.CoreRegPolicy
+	default:
+		badRequest(w, r, "put_beacon_policy", "malformed path parameter",
+			serrors.New("unknown policy type", "policy_type", policyType))
+		return
+	}
+	raw, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		badRequest(w, r, "put_beacon_policy", "error reading request body", err)
+		return
+	}
+	policy, err := beacon.ParsePolicyYaml(raw, t)
+	if err != nil {
+		badRequest(w, r, "put_beacon_policy", "malformed policy", err)
+		return
+	}
+	if err := s.BeaconPolicies.UpdatePolicy(r.Context(), *policy); err != nil {
+		badRequest(w, r, "put_beacon_policy", "error updating policy", err)
+		return
+	}
+	api.Audit(r, "put_beacon_policy", nil, "policy_type", t)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReloadBeaconPolicies reloads the beacon policies and the static info
+// configuration from their files.
+func (s *Server) ReloadBeaconPolicies(w http.ResponseWriter, r *http.Request) {
+	if err := s.Policies.Reload(r.Context()); err != nil {
+		badRequest(w, r, "reload_beacon_policies", "error reloading policies", err)
+		return
+	}
+	status, err := s.Policies.Status()
+	if err != nil {
+		internalError(w, r, "reload_beacon_policies", "error reading policy status", err)
+		return
+	}
+	rep := convertPolicyStatus(status)
+	api.Audit(r, "reload_beacon_policies", nil, "digest", rep.Digest)
+	writeJSON(w, rep)
+}
+
+// SetInterfaceAdminState sets the administrative state of the interface.
+func (s *Server) SetInterfaceAdminState(w http.ResponseWriter, r *http.Request,
+	interfaceId int) {
+
+	var body SetAdminStateRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).
+		Decode(&body); err != nil {
+
+		badRequest(w, r, "set_interface_admin_state", "malformed request body", err)
+		return
+	}
+	var state ifstate.AdminState
+	switch body.State {
+	case AdminStateUp:
+		state = ifstate.AdminUp
+	case AdminStateDrained:
+		state = ifstate.AdminDrained
+	default:
+		badRequest(w, r, "set_interface_admin_state", "malformed request body",
+			serrors.New("unknown administrative state", "state", body.State))
+		return
+	}
+	var gracePeriod time.Duration
+	if body.GracePeriod != nil {
+		var err error
+		if gracePeriod, err = util.ParseDuration(*body.GracePeriod); err != nil {
+			badRequest(w, r, "set_interface_admin_state", "malformed grace period", err)
+			return
+		}
+		if gracePeriod < 0 {
+			badRequest(w, r, "set_interface_admin_state", "malformed grace period",
+				serrors.New("grace period must not be negative", "grace_period", gracePeriod))
+			return
+		}
+	}
+	var intf *ifstate.Interface
+	if interfaceId > 0 && interfaceId <= 65535 {
+		intf = s.Interfaces.Get(uint16(interfaceId))
+	}
+	if intf == nil {
+		badRequest(w, r, "set_interface_admin_state", "invalid interface",
+			serrors.New("unknown interface", "interface", interfaceId))
+		return
+	}
+	intf.SetAdminState(state, gracePeriod)
+	api.Audit(r, "set_interface_admin_state", nil, "interface", interfaceId, "state", state,
+		"grace_period", gracePeriod)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ImportTrc verifies the TRC and inserts it in the trust database.
+func (s *Server) ImportTrc(w http.ResponseWriter, r *http.Request) {
+	raw, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		badRequest(w, r, "import_trc", "error reading request body", err)
+		return
+	}
+	if block, _ := pem.Decode(raw); block != nil && block.Type == "TRC" {
+		raw = block.Bytes
+	}
+	trc, err := cppki.DecodeSignedTRC(raw)
+	if err != nil {
+		badRequest(w, r, "import_trc", "malformed TRC", err)
+		return
+	}
+	inserted, err := trust.ImportTRC(r.Context(), trc, s.TrustDB)
+	if err != nil {
+		badRequest(w, r, "import_trc", "error importing TRC", err)
+		return
+	}
+	api.Audit(r, "import_trc", nil, "id", trc.TRC.ID, "inserted", inserted)
+	writeJSON(w, ImportResult{Inserted: inserted})
+}
+
+// ImportCertificateChain verifies the certificate chain and inserts it in the
+// trust database.
+func (s *Server) ImportCertificateChain(w http.ResponseWriter, r *http.Request) {
+	raw, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		badRequest(w, r, "import_certificate_chain", "error reading request body", err)
+		return
+	}
+	chain, err := cppki.ParsePEMCerts(raw)
+	if err == nil && len(chain) == 0 {
+		err = serrors.New("no certificates found")
+	}
+	if err != nil {
+		badRequest(w, r, "import_certificate_chain", "malformed certificate chain", err)
+		return
+	}
+	inserted, err := trust.ImportChain(r.Context(), chain, s.TrustDB)
+	if err != nil {
+		badRequest(w, r, "import_certificate_chain", "error importing certificate chain", err)
+		return
+	}
+	api.Audit(r, "import_certificate_chain", nil, "subject", chain[0].Subject,
+		"inserted", inserted)
+	writeJSON(w, ImportResult{Inserted: inserted})
+}
+
+// parseSegmentIDFilter decodes the segment IDs of a filter for deletion. The
+// segment IDs are matched by prefix, thus empty IDs that would match all
+// entries are rejected.
+func parseSegmentIDFilter(ids SegmentIDs) ([][]byte, error) {
+	if len(ids) == 0 {
+		return nil, serrors.New("no segment ID")
+	}
+	b, err := decodeSegmentIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range b {
+		if len(id) == 0 {
+			return nil, serrors.New("empty segment ID")
+		}
+	}
+	return b, nil
+}
+
+// parseIAFilter parses the ISD-AS of a filter for deletion. Zero and wildcard
+// ISD-AS are rejected, because they do not restrict the entries to a single
+// AS.
+func parseIAFilter(raw IsdAs) (addr.IA, error) {
+	ia, err := addr.IAFromString(string(raw))
+	if err != nil {
+		return addr.IA{}, err
+	}
+	if ia.IsWildcard() {
+		return addr.IA{}, serrors.New("wildcard ISD-AS not allowed", "isd_as", ia)
+	}
+	return ia, nil
+}
+
+func decodeSegmentIDs(ids SegmentIDs) ([][]byte, error) {
+	b := make([][]byte, 0, len(ids))
+	for _, segID := range ids {
+		id, err := hex.DecodeString(string(segID))
+		if err != nil {
+			return nil, serrors.WrapStr("decoding segment ID", err, "id", segID)
+		}
+		b = append(b, id)
+	}
+	return b, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(v); err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// badRequest audits the failed operation and writes a bad request problem.
+func badRequest(w http.ResponseWriter, r *http.Request, op, title string, err error) {
+	api.Audit(r, op, err)
+	Error(w, Problem{
+		Detail: api.StringRef(err.Error()),
+		Status: http.StatusBadRequest,
+		Title:  title,
+		Type:   api.StringRef(api.BadRequest),
+	})
+}
+
+// internalError audits the failed operation and writes an internal error
+// problem.
+func internalError(w http.ResponseWriter, r *http.Request, op, title string, err error) {
+	api.Audit(r, op, err)
+	Error(w, Problem{
+		Detail: api.StringRef(err.Error()),
+		Status: http.StatusInternalServerError,
+		Title:  title,
+		Type:   api.StringRef(api.InternalError),
+	})
+}
@@ -0,0 +1,430 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	beaconlib "github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/ifstate"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/api/jwtauth"
+	"github.com/scionproto/scion/go/pkg/cs/api"
+	"github.com/scionproto/scion/go/pkg/cs/api/mock_api"
+	"github.com/scionproto/scion/go/pkg/storage/beacon"
+)
+
+func TestManagementHandler(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	generator := func() ([]byte, error) { return key, nil }
+	verifier := &jwtauth.HTTPVerifier{Generator: generator}
+
+	testCases := map[string]struct {
+		Verifier  *jwtauth.HTTPVerifier
+		Authorize bool
+		Status    int
+	}{
+		"disabled": {
+			Authorize: true,
+			Status:    http.StatusForbidden,
+		},
+		"missing token": {
+			Verifier: verifier,
+			Status:   http.StatusInternalServerError,
+		},
+		"authorized": {
+			Verifier:  verifier,
+			Authorize: true,
+			Status:    http.StatusOK,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			segs := mock_api.NewMockSegmentStore(ctrl)
+			segs.EXPECT().Delete(gomock.Any(), gomock.Any()).MaxTimes(1).Return(0, nil)
+			handler := api.ManagementHandler(
+				api.HandlerFromMuxWithBaseURL(&api.Server{Segments: segs}, nil, "/api/v1"),
+				"/api/v1",
+				tc.Verifier,
+			)
+			req := httptest.NewRequest(http.MethodDelete,
+				"/api/v1/management/segments?start_isd_as=1-ff00:0:110", nil)
+			if tc.Authorize {
+				src := &jwtauth.JWTTokenSource{Subject: "operator", Generator: generator}
+				token, err := src.Token()
+				require.NoError(t, err)
+				req.Header.Set("Authorization", "Bearer "+token.String())
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestManagementAPI(t *testing.T) {
+	testCases := map[string]struct {
+		Server  func(t *testing.T, ctrl *gomock.Controller) *api.Server
+		Method  string
+		URL     string
+		Body    string
+		Status  int
+		Content string
+	}{
+		"delete segments": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				segs := mock_api.NewMockSegmentStore(ctrl)
+				segs.EXPECT().Delete(gomock.Any(), &query.Params{
+					StartsAt: []addr.IA{xtest.MustParseIA("1-ff00:0:110")},
+					EndsAt:   []addr.IA{xtest.MustParseIA("2-ff00:0:210")},
+				}).Return(3, nil)
+				return &api.Server{Segments: segs}
+			},
+			Method: http.MethodDelete,
+			URL:    "/management/segments?start_isd_as=1-ff00:0:110&end_isd_as=2-ff00:0:210",
+			Status: http.StatusOK,
+			Content: `{
+    "deleted": 3
+}
+`,
+		},
+		"delete segments without filter": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Segments: mock_api.NewMockSegmentStore(ctrl)}
+			},
+			Method: http.MethodDelete,
+			URL:    "/management/segments",
+			Status: http.StatusBadRequest,
+		},
+		"delete segments malformed ID": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Segments: mock_api.NewMockSegmentStore(ctrl)}
+			},
+			Method: http.MethodDelete,
+			URL:    "/management/segments?segment_id=xyz",
+			Status: http.StatusBadRequest,
+		},
+		"delete segments zero ISD-AS": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Segments: mock_api.NewMockSegmentStore(ctrl)}
+			},
+			Method: http.MethodDelete,
+			URL:    "/management/segments?end_isd_as=0-0",
+			Status: http.StatusBadRequest,
+		},
+		"delete beacons": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				bs := mock_api.NewMockBeaconStore(ctrl)
+				bs.EXPECT().DeleteBeacons(gomock.Any(), &beacon.QueryParams{
+					SegIDs:            [][]byte{{0xde, 0xad}},
+					IngressInterfaces: []uint16{2},
+				}).Return(1, nil)
+				return &api.Server{Beacons: bs}
+			},
+			Method: http.MethodDelete,
+			URL:    "/management/beacons?segment_id=dead&ingress_interface=2",
+			Status: http.StatusOK,
+			Content: `{
+    "deleted": 1
+}
+`,
+		},
+		"delete beacons without filter": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Beacons: mock_api.NewMockBeaconStore(ctrl)}
+			},
+			Method: http.MethodDelete,
+			URL:    "/management/beacons",
+			Status: http.StatusBadRequest,
+		},
+		"delete beacons zero start ISD-AS": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Beacons: mock_api.NewMockBeaconStore(ctrl)}
+			},
+			Method: http.MethodDelete,
+			URL:    "/management/beacons?start_isd_as=0-0",
+			Status: http.StatusBadRequest,
+		},
+		"delete beacons wildcard start ISD-AS": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Beacons: mock_api.NewMockBeaconStore(ctrl)}
+			},
+			Method: http.MethodDelete,
+			URL:    "/management/beacons?start_isd_as=1-0",
+			Status: http.StatusBadRequest,
+		},
+		"delete beacons empty segment ID": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Beacons: mock_api.NewMockBeaconStore(ctrl)}
+			},
+			Method: http.MethodDelete,
+			URL:    "/management/beacons?segment_id=",
+			Status: http.StatusBadRequest,
+		},
+		"trigger unknown interface": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Interfaces: ifstate.NewInterfaces(nil, ifstate.Config{})}
+			},
+			Method: http.MethodPost,
+			URL:    "/management/beaconing/origination",
+			Body:   `{"interfaces": [1]}`,
+			Status: http.StatusBadRequest,
+		},
+		"trigger without interfaces": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Interfaces: ifstate.NewInterfaces(nil, ifstate.Config{})}
+			},
+			Method: http.MethodPost,
+			URL:    "/management/beaconing/propagation",
+			Body:   `{"interfaces": []}`,
+			Status: http.StatusBadRequest,
+		},
+		"set admin state unknown interface": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Interfaces: ifstate.NewInterfaces(nil, ifstate.Config{})}
+			},
+			Method: http.MethodPut,
+			URL:    "/management/interfaces/1/admin-state",
+			Body:   `{"state": "drained"}`,
+			Status: http.StatusBadRequest,
+		},
+		"set unknown admin state": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Interfaces: ifstate.NewInterfaces(
+					map[uint16]ifstate.InterfaceInfo{1: {ID: 1}}, ifstate.Config{})}
+			},
+			Method: http.MethodPut,
+			URL:    "/management/interfaces/1/admin-state",
+			Body:   `{"state": "down"}`,
+			Status: http.StatusBadRequest,
+		},
+		"put policy": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				pu := mock_api.NewMockPolicyUpdater(ctrl)
+				pu.EXPECT().UpdatePolicy(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, p beaconlib.Policy) error {
+						assert.Equal(t, beaconlib.UpRegPolicy, p.Type)
+						assert.Equal(t, 5, p.BestSetSize)
+						return nil
+					},
+				)
+				return &api.Server{BeaconPolicies: pu}
+			},
+			Method: http.MethodPut,
+			URL:    "/management/beaconing/policies/up_registration",
+			Body:   "BestSetSize: 5\n",
+			Status: http.StatusNoContent,
+		},
+		"put malformed policy": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{BeaconPolicies: mock_api.NewMockPolicyUpdater(ctrl)}
+			},
+			Method: http.MethodPut,
+			URL:    "/management/beaconing/policies/propagation",
+			Body:   "BestSetSize: [",
+			Status: http.StatusBadRequest,
+		},
+		"reload policies": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				pr := mock_api.NewMockPolicyReloader(ctrl)
+				pr.EXPECT().Reload(gomock.Any()).Return(nil)
+				pr.EXPECT().Status().Return(api.PolicyStatusData{
+					Digest:   []byte{0xab, 0xcd},
+					LoadedAt: time.Date(2021, 11, 25, 12, 0, 0, 0, time.UTC),
+					Policies: []api.PolicyData{
+						{Type: beaconlib.PropPolicy, File: "prop.yml", Digest: []byte{0x01}},
+						{Type: beaconlib.CoreRegPolicy, Digest: []byte{0x02}},
+					},
+					StaticInfoFile:   "static.json",
+					StaticInfoDigest: []byte{0x03},
+				}, nil)
+				return &api.Server{Policies: pr}
+			},
+			Method: http.MethodPost,
+			URL:    "/management/beaconing/reload",
+			Status: http.StatusOK,
+			Content: `{
+    "digest": "abcd",
+    "loaded_at": "2021-11-25T12:00:00Z",
+    "policies": [
+        {
+            "digest": "01",
+            "file": "prop.yml",
+            "type": "propagation"
+        },
+        {
+            "digest": "02",
+            "type": "core_registration"
+        }
+    ],
+    "static_info": {
+        "digest": "03",
+        "file": "static.json"
+    }
+}
+`,
+		},
+		"reload invalid policies": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				pr := mock_api.NewMockPolicyReloader(ctrl)
+				pr.EXPECT().Reload(gomock.Any()).Return(serrors.New("invalid policy"))
+				return &api.Server{Policies: pr}
+			},
+			Method: http.MethodPost,
+			URL:    "/management/beaconing/reload",
+			Status: http.StatusBadRequest,
+		},
+		"get policies": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				pr := mock_api.NewMockPolicyReloader(ctrl)
+				pr.EXPECT().Status().Return(api.PolicyStatusData{
+					Digest:   []byte{0xab, 0xcd},
+					LoadedAt: time.Date(2021, 11, 25, 12, 0, 0, 0, time.UTC),
+				}, nil)
+				return &api.Server{Policies: pr}
+			},
+			Method: http.MethodGet,
+			URL:    "/beaconing/policies",
+			Status: http.StatusOK,
+			Content: `{
+    "digest": "abcd",
+    "loaded_at": "2021-11-25T12:00:00Z",
+    "policies": []
+}
+`,
+		},
+		"import malformed TRC": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{}
+			},
+			Method: http.MethodPost,
+			URL:    "/management/trcs",
+			Body:   "garbage",
+			Status: http.StatusBadRequest,
+		},
+		"import empty certificate chain": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{}
+			},
+			Method: http.MethodPost,
+			URL:    "/management/certificates",
+			Status: http.StatusBadRequest,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := httptest.NewRequest(tc.Method, tc.URL, strings.NewReader(tc.Body))
+			rr := httptest.NewRecorder()
+			api.Handler(tc.Server(t, ctrl)).ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode, rr.Body.String())
+			if tc.Content != "" {
+				assert.Equal(t, tc.Content, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestManagementTrigger(t *testing.T) {
+	intfs := ifstate.NewInterfaces(map[uint16]ifstate.InterfaceInfo{
+		1: {ID: 1},
+		2: {ID: 2},
+	}, ifstate.Config{})
+	now := time.Now()
+	for _, intf := range intfs.All() {
+		intf.Originate(now)
+		intf.Propagate(now)
+	}
+	handler := api.Handler(&api.Server{Interfaces: intfs})
+
+	req := httptest.NewRequest(http.MethodPost, "/management/beaconing/origination",
+		strings.NewReader(`{"interfaces": [1]}`))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	assert.True(t, intfs.Get(1).LastOriginate().IsZero())
+	assert.Equal(t, now, intfs.Get(2).LastOriginate())
+	assert.Equal(t, now, intfs.Get(1).LastPropagate())
+
+	req = httptest.NewRequest(http.MethodPost, "/management/beaconing/propagation",
+		strings.NewReader(`{"interfaces": [2]}`))
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	assert.True(t, intfs.Get(2).LastPropagate().IsZero())
+	assert.Equal(t, now, intfs.Get(1).LastPropagate())
+}
+
+func TestManagementSetAdminState(t *testing.T) {
+	intfs := ifstate.NewInterfaces(map[uint16]ifstate.InterfaceInfo{
+		1: {ID: 1},
+		2: {ID: 2},
+	}, ifstate.Config{})
+	handler := api.Handler(&api.Server{Interfaces: intfs})
+
+	req := httptest.NewRequest(http.MethodPut, "/management/interfaces/1/admin-state",
+		strings.NewReader(`{"state": "drained"}`))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	assert.True(t, intfs.Get(1).Drained())
+	assert.True(t, intfs.Get(1).DrainCompleted(time.Now()))
+	assert.False(t, intfs.Get(2).Drained())
+
+	req = httptest.NewRequest(http.MethodPut, "/management/interfaces/1/admin-state",
+		strings.NewReader(`{"state": "up"}`))
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	assert.False(t, intfs.Get(1).Drained())
+
+	req = httptest.NewRequest(http.MethodPut, "/management/interfaces/2/admin-state",
+		strings.NewReader(`{"state": "drained", "grace_period": "1h"}`))
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	assert.True(t, intfs.Get(2).Drained())
+	assert.False(t, intfs.Get(2).DrainCompleted(time.Now()))
+	assert.True(t, intfs.Get(2).DrainCompleted(time.Now().Add(time.Hour)))
+
+	for _, gracePeriod := range []string{"soon", "-1m"} {
+		req = httptest.NewRequest(http.MethodPut, "/management/interfaces/1/admin-state",
+			strings.NewReader(`{"state": "drained", "grace_period": "`+gracePeriod+`"}`))
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode, gracePeriod)
+		assert.False(t, intfs.Get(1).Drained(), gracePeriod)
+	}
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/go/pkg/cs/api (interfaces: BeaconStore,Healther)
+// Source: github.com/scionproto/scion/go/pkg/cs/api (interfaces: BeaconStore,Healther,PolicyReloader,PolicyUpdater,SegmentStore,Statistics)
 
 // Package mock_api is a generated GoMock package.
 package mock_api
@@ -7,10 +7,14 @@ package mock_api
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	beacon "github.com/scionproto/scion/go/cs/beacon"
+	stats "github.com/scionproto/scion/go/cs/stats"
+	query "github.com/scionproto/scion/go/lib/pathdb/query"
 	api "github.com/scionproto/scion/go/pkg/cs/api"
-	beacon "github.com/scionproto/scion/go/pkg/storage/beacon"
+	beacon0 "github.com/scionproto/scion/go/pkg/storage/beacon"
 )
 
 // MockBeaconStore is a mock of BeaconStore interface.
@@ -36,11 +40,26 @@ func (m *MockBeaconStore) EXPECT() *MockBeaconStoreMockRecorder {
 	return m.recorder
 }
 
+// DeleteBeacons mocks base method.
+func (m *MockBeaconStore) DeleteBeacons(arg0 context.Context, arg1 *beacon0.QueryParams) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBeacons", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBeacons indicates an expected call of DeleteBeacons.
+func (mr *MockBeaconStoreMockRecorder) DeleteBeacons(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBeacons", reflect.TypeOf((*MockBeaconStore)(nil).DeleteBeacons), arg0, arg1)
+}
+
 // GetBeacons mocks base method.
-func (m *MockBeaconStore) GetBeacons(arg0 context.Context, arg1 *beacon.QueryParams) ([]beacon.Beacon, error) {
+func (m *MockBeaconStore) GetBeacons(arg0 context.Context, arg1 *beacon0.QueryParams) ([]beacon0.Beacon, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBeacons", arg0, arg1)
-	ret0, _ := ret[0].([]beacon.Beacon)
+	ret0, _ := ret[0].([]beacon0.Beacon)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -101,3 +120,195 @@ func (mr *MockHealtherMockRecorder) GetTRCHealth(arg0 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTRCHealth", reflect.TypeOf((*MockHealther)(nil).GetTRCHealth), arg0)
 }
+
+// MockPolicyReloader is a mock of PolicyReloader interface.
+type MockPolicyReloader struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyReloaderMockRecorder
+}
+
+// MockPolicyReloaderMockRecorder is the mock recorder for MockPolicyReloader.
+type MockPolicyReloaderMockRecorder struct {
+	mock *MockPolicyReloader
+}
+
+// NewMockPolicyReloader creates a new mock instance.
+func NewMockPolicyReloader(ctrl *gomock.Controller) *MockPolicyReloader {
+	mock := &MockPolicyReloader{ctrl: ctrl}
+	mock.recorder = &MockPolicyReloaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyReloader) EXPECT() *MockPolicyReloaderMockRecorder {
+	return m.recorder
+}
+
+// Reload mocks base method.
+func (m *MockPolicyReloader) Reload(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockPolicyReloaderMockRecorder) Reload(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockPolicyReloader)(nil).Reload), arg0)
+}
+
+// Status mocks base method.
+func (m *MockPolicyReloader) Status() (api.PolicyStatusData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(api.PolicyStatusData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockPolicyReloaderMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockPolicyReloader)(nil).Status))
+}
+
+// MockPolicyUpdater is a mock of PolicyUpdater interface.
+type MockPolicyUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyUpdaterMockRecorder
+}
+
+// MockPolicyUpdaterMockRecorder is the mock recorder for MockPolicyUpdater.
+type MockPolicyUpdaterMockRecorder struct {
+	mock *MockPolicyUpdater
+}
+
+// NewMockPolicyUpdater creates a new mock instance.
+func NewMockPolicyUpdater(ctrl *gomock.Controller) *MockPolicyUpdater {
+	mock := &MockPolicyUpdater{ctrl: ctrl}
+	mock.recorder = &MockPolicyUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyUpdater) EXPECT() *MockPolicyUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdatePolicy mocks base method.
+func (m *MockPolicyUpdater) UpdatePolicy(arg0 context.Context, arg1 beacon.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePolicy indicates an expected call of UpdatePolicy.
+func (mr *MockPolicyUpdaterMockRecorder) UpdatePolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicy", reflect.TypeOf((*MockPolicyUpdater)(nil).UpdatePolicy), arg0, arg1)
+}
+
+// MockSegmentStore is a mock of SegmentStore interface.
+type MockSegmentStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSegmentStoreMockRecorder
+}
+
+// MockSegmentStoreMockRecorder is the mock recorder for MockSegmentStore.
+type MockSegmentStoreMockRecorder struct {
+	mock *MockSegmentStore
+}
+
+// NewMockSegmentStore creates a new mock instance.
+func NewMockSegmentStore(ctrl *gomock.Controller) *MockSegmentStore {
+	mock := &MockSegmentStore{ctrl: ctrl}
+	mock.recorder = &MockSegmentStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSegmentStore) EXPECT() *MockSegmentStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSegmentStore) Delete(arg0 context.Context, arg1 *query.Params) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSegmentStoreMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSegmentStore)(nil).Delete), arg0, arg1)
+}
+
+// MockStatistics is a mock of Statistics interface.
+type MockStatistics struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatisticsMockRecorder
+}
+
+// MockStatisticsMockRecorder is the mock recorder for MockStatistics.
+type MockStatisticsMockRecorder struct {
+	mock *MockStatistics
+}
+
+// NewMockStatistics creates a new mock instance.
+func NewMockStatistics(ctrl *gomock.Controller) *MockStatistics {
+	mock := &MockStatistics{ctrl: ctrl}
+	mock.recorder = &MockStatisticsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatistics) EXPECT() *MockStatisticsMockRecorder {
+	return m.recorder
+}
+
+// Beacons mocks base method.
+func (m *MockStatistics) Beacons() []stats.BeaconStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Beacons")
+	ret0, _ := ret[0].([]stats.BeaconStats)
+	return ret0
+}
+
+// Beacons indicates an expected call of Beacons.
+func (mr *MockStatisticsMockRecorder) Beacons() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Beacons", reflect.TypeOf((*MockStatistics)(nil).Beacons))
+}
+
+// Lookups mocks base method.
+func (m *MockStatistics) Lookups() []stats.LookupStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookups")
+	ret0, _ := ret[0].([]stats.LookupStats)
+	return ret0
+}
+
+// Lookups indicates an expected call of Lookups.
+func (mr *MockStatisticsMockRecorder) Lookups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookups", reflect.TypeOf((*MockStatistics)(nil).Lookups))
+}
+
+// WindowDuration mocks base method.
+func (m *MockStatistics) WindowDuration() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WindowDuration")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// WindowDuration indicates an expected call of WindowDuration.
+func (mr *MockStatisticsMockRecorder) WindowDuration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WindowDuration", reflect.TypeOf((*MockStatistics)(nil).WindowDuration))
+}
@@ -13,6 +13,9 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get the active beacon policies
+	// (GET /beaconing/policies)
+	GetBeaconPolicies(w http.ResponseWriter, r *http.Request)
 	// List the SCION beacons
 	// (GET /beacons)
 	GetBeacons(w http.ResponseWriter, r *http.Request, params GetBeaconsParams)
@@ -43,6 +46,33 @@ type ServerInterface interface {
 	// Set logging level
 	// (PUT /log/level)
 	SetLogLevel(w http.ResponseWriter, r *http.Request)
+	// Trigger beacon origination
+	// (POST /management/beaconing/origination)
+	TriggerOrigination(w http.ResponseWriter, r *http.Request)
+	// Replace a beacon policy
+	// (PUT /management/beaconing/policies/{policy-type})
+	PutBeaconPolicy(w http.ResponseWriter, r *http.Request, policyType PolicyType)
+	// Trigger beacon propagation
+	// (POST /management/beaconing/propagation)
+	TriggerPropagation(w http.ResponseWriter, r *http.Request)
+	// Reload the beacon policies
+	// (POST /management/beaconing/reload)
+	ReloadBeaconPolicies(w http.ResponseWriter, r *http.Request)
+	// Delete SCION beacons
+	// (DELETE /management/beacons)
+	DeleteBeacons(w http.ResponseWriter, r *http.Request, params DeleteBeaconsParams)
+	// Import a certificate chain
+	// (POST /management/certificates)
+	ImportCertificateChain(w http.ResponseWriter, r *http.Request)
+	// Set the administrative state of an interface
+	// (PUT /management/interfaces/{interface-id}/admin-state)
+	SetInterfaceAdminState(w http.ResponseWriter, r *http.Request, interfaceId int)
+	// Delete SCION path segments
+	// (DELETE /management/segments)
+	DeleteSegments(w http.ResponseWriter, r *http.Request, params DeleteSegmentsParams)
+	// Import a TRC
+	// (POST /management/trcs)
+	ImportTrc(w http.ResponseWriter, r *http.Request)
 	// List the SCION path segments
 	// (GET /segments)
 	GetSegments(w http.ResponseWriter, r *http.Request, params GetSegmentsParams)
@@ -58,6 +88,12 @@ type ServerInterface interface {
 	// Get the certificate chain blob
 	// (GET /signer/blob)
 	GetSignerChain(w http.ResponseWriter, r *http.Request)
+	// Get the beacon statistics
+	// (GET /statistics/beacons)
+	GetBeaconStatistics(w http.ResponseWriter, r *http.Request, params GetBeaconStatisticsParams)
+	// Get the segment lookup statistics
+	// (GET /statistics/segment-lookups)
+	GetSegmentLookupStatistics(w http.ResponseWriter, r *http.Request)
 	// Prints the contents of the AS topology file.
 	// (GET /topology)
 	GetTopology(w http.ResponseWriter, r *http.Request)
@@ -80,6 +116,21 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
 
+// GetBeaconPolicies operation middleware
+func (siw *ServerInterfaceWrapper) GetBeaconPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBeaconPolicies(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetBeacons operation middleware
 func (siw *ServerInterfaceWrapper) GetBeacons(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -372,6 +423,239 @@ func (siw *ServerInterfaceWrapper) SetLogLevel(w http.ResponseWriter, r *http.Re
 	handler(w, r.WithContext(ctx))
 }
 
+// TriggerOrigination operation middleware
+func (siw *ServerInterfaceWrapper) TriggerOrigination(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TriggerOrigination(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutBeaconPolicy operation middleware
+func (siw *ServerInterfaceWrapper) PutBeaconPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "policy-type" -------------
+	var policyType PolicyType
+
+	err = runtime.BindStyledParameter("simple", false, "policy-type", chi.URLParam(r, "policy-type"), &policyType)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter policy-type: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutBeaconPolicy(w, r, policyType)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// TriggerPropagation operation middleware
+func (siw *ServerInterfaceWrapper) TriggerPropagation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TriggerPropagation(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ReloadBeaconPolicies operation middleware
+func (siw *ServerInterfaceWrapper) ReloadBeaconPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReloadBeaconPolicies(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteBeacons operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBeacons(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteBeaconsParams
+
+	// ------------- Optional query parameter "segment_id" -------------
+	if paramValue := r.URL.Query().Get("segment_id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", false, false, "segment_id", r.URL.Query(), &params.SegmentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter segment_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "start_isd_as" -------------
+	if paramValue := r.URL.Query().Get("start_isd_as"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "start_isd_as", r.URL.Query(), &params.StartIsdAs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter start_isd_as: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "ingress_interface" -------------
+	if paramValue := r.URL.Query().Get("ingress_interface"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "ingress_interface", r.URL.Query(), &params.IngressInterface)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter ingress_interface: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBeacons(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ImportCertificateChain operation middleware
+func (siw *ServerInterfaceWrapper) ImportCertificateChain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportCertificateChain(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// SetInterfaceAdminState operation middleware
+func (siw *ServerInterfaceWrapper) SetInterfaceAdminState(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "interface-id" -------------
+	var interfaceId int
+
+	err = runtime.BindStyledParameter("simple", false, "interface-id", chi.URLParam(r, "interface-id"), &interfaceId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter interface-id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetInterfaceAdminState(w, r, interfaceId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteSegments operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSegments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteSegmentsParams
+
+	// ------------- Optional query parameter "segment_id" -------------
+	if paramValue := r.URL.Query().Get("segment_id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", false, false, "segment_id", r.URL.Query(), &params.SegmentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter segment_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "start_isd_as" -------------
+	if paramValue := r.URL.Query().Get("start_isd_as"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "start_isd_as", r.URL.Query(), &params.StartIsdAs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter start_isd_as: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "end_isd_as" -------------
+	if paramValue := r.URL.Query().Get("end_isd_as"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "end_isd_as", r.URL.Query(), &params.EndIsdAs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter end_isd_as: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSegments(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ImportTrc operation middleware
+func (siw *ServerInterfaceWrapper) ImportTrc(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportTrc(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetSegments operation middleware
 func (siw *ServerInterfaceWrapper) GetSegments(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -496,6 +780,63 @@ func (siw *ServerInterfaceWrapper) GetSignerChain(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
+// GetBeaconStatistics operation middleware
+func (siw *ServerInterfaceWrapper) GetBeaconStatistics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBeaconStatisticsParams
+
+	// ------------- Optional query parameter "start_isd_as" -------------
+	if paramValue := r.URL.Query().Get("start_isd_as"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "start_isd_as", r.URL.Query(), &params.StartIsdAs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter start_isd_as: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "ingress_interface" -------------
+	if paramValue := r.URL.Query().Get("ingress_interface"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "ingress_interface", r.URL.Query(), &params.IngressInterface)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter ingress_interface: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBeaconStatistics(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetSegmentLookupStatistics operation middleware
+func (siw *ServerInterfaceWrapper) GetSegmentLookupStatistics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSegmentLookupStatistics(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetTopology operation middleware
 func (siw *ServerInterfaceWrapper) GetTopology(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -678,6 +1019,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		HandlerMiddlewares: options.Middlewares,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/beaconing/policies", wrapper.GetBeaconPolicies)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/beacons", wrapper.GetBeacons)
 	})
@@ -708,6 +1052,33 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/log/level", wrapper.SetLogLevel)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/management/beaconing/origination", wrapper.TriggerOrigination)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/management/beaconing/policies/{policy-type}", wrapper.PutBeaconPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/management/beaconing/propagation", wrapper.TriggerPropagation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/management/beaconing/reload", wrapper.ReloadBeaconPolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/management/beacons", wrapper.DeleteBeacons)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/management/certificates", wrapper.ImportCertificateChain)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/management/interfaces/{interface-id}/admin-state", wrapper.SetInterfaceAdminState)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/management/segments", wrapper.DeleteSegments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/management/trcs", wrapper.ImportTrc)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/segments", wrapper.GetSegments)
 	})
@@ -723,6 +1094,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/signer/blob", wrapper.GetSignerChain)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/statistics/beacons", wrapper.GetBeaconStatistics)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/statistics/segment-lookups", wrapper.GetSegmentLookupStatistics)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/topology", wrapper.GetTopology)
 	})
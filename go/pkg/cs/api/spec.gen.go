@@ -18,79 +18,127 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xcX3PbNrb/KhjuPrSzkiwr9rbWzH2QZafVbJN4LHV3pnWuApFHIhoIYAHQtq6vvvsd",
-	"ACQFkqBE2Uma3mmnDxYFHpw/P5x/OMpTEPJ1whkwJYPhUyBAJpxJMB8ucXQLv6cglf4UcqaAmT9xklAS",
-	"YkU4O/lNcqafyTCGNdZ//V3AMhgGfzvZkT6x38qTqcIswiK6FoKLYLvddoIIZChIookFQ70nEtmm+tvs",
-	"RcMO4NDuhSl9twyGvx7YC1ZrzfC28xQkgicgFLGCEbYSIOWcMAViiUPQD8t8TOwSVCxBfIlUDGhhuOgF",
-	"nUBtEgiGgV6xAhFsO0Eq8crusI8vK8fPdq2WUctLBETB8NecRMfD4/tiS774DUIVbPUToqh+NB1P3r1F",
-	"CVZxV1q5UciZVCINtUQZ25pJZ3vNKbB0bTZO5gJWRCph7Bp0gog/sOqzkAuoPtO6xSv7yeFoRCl/gAjZ",
-	"/ZARzFGbVIKwVYUhax0F62OUqGnkm/5EpNKWwtnmC2dz6eyOhcCboBOkjPyewsTuqEQK204wHunNy4gJ",
-	"Qaj5PaYkImpziLd/5+u2nSDhlIQH37ixqzTeU2vbQ+coW7Z7Y/4RNnMStXzxX7CZXNXAl29eI1rI0alo",
-	"oo7JTjDWaltq/wB1RUZEKsJWKZExRHOG12ZNDRNERnN8EAQTGY1kVQeYrrgB9iNeJwYU1+Or6ciHvJeo",
-	"rhMcD4eKuj26KCR3yHvEq7HunDtH/cj1aT5LxZiwuo2IlCmIQ2K5Zm4P3NJbjfDLOGiQKtRst5LtUhDN",
-	"Sk3Ag7Y2b1szt9NGFYqt178YReZ41lTnEHa0aPSBwmfpcnJVPlVLfP4K989w0AmWXKyxCoZBDI/d7Hjt",
-	"M90kAqYfGT5rp3IcQ/jR4zmwwofNBuHHK73QJBYKE1oP7aMoIvpPTBFhlnViI/pOOB9fubMqU3uL1yY3",
-	"iAFTFaNQc1CmZQyBJFkxEAjfY0LxgoJvBwE4y6bKe9ya52jJhaWPlpjQVMBhnqXCKpUtsjK9qoqszCNl",
-	"NDrWAg6afrQij3ORPbjJzaGTtkLtN45ddczdUXwtALSYa7RbjfS2RnadflXVXNvTMuWJ4PoNWddtnjG4",
-	"hE2m0CoNsVjdVvKKlyq+0HjGtJvnpes1FhuHY7sYYRY5zDeo5TZL7+vqiQu17eM3U26V3+xll00Q9yQs",
-	"zFU5Z3XueOLx0m52XsD8bODLvI/KF6oONI+45VQ7k+QGax1nKXXMEx/7lm7JO552l8t+f9gfnp72dfqE",
-	"lQKh8fbfd3fRP7rf/Iq7y3734v3TaedsO/z2abAtP/r2f/W6vztudDK96o6mB3znT3z1E9wDrWuT5o8r",
-	"8OerFWErZL/uFOVABIt0ZXSy1OkGmHrtvetusm8qLFR0a8n6ssSbIjGunlNM2JySJSiyLps++G4Q99d9",
-	"eXDXCg3v9oIvKKw9YaYpaqA4XWOGBOBI+28EjwnFzGAayQRCHeKQ4kjFRCIehqkQwHZ1Y2I3RCrGChGJ",
-	"YqDJMqX6DcpNbHRX6dO8IveAcGTOEWco5g96cSJ4CBD10H8EUQoYIgxdsxUlMjZvFfxpjwlsRRiAkB2U",
-	"yhRTukGMKyRToiAyKxhnSEEYMxJiqn3JR4g5jUBYj6JXa/Yo+R+IyuFmzBkDW1wqbpz0AktAWuMR4qny",
-	"wZMwqTDz1dsj9PPtBAlYgtWaVVOOdWmUU2i5UbsdBL1VDy02Jn6wFcJoKbA9uwUxgbhAMl10dbVsLeaY",
-	"Z5NAD73BG7QAlEqIKgYSnCu7KZHFS4RZ/ngqQkAhjyqR+SRbeBIWOuuaE/U3xT8C6+qj1NWG6xrtda32",
-	"iqwqFaRbaGZ/lC8rdRYD+nE2u8ljhOYMrYCBwNr+i41hmwuyIgxJEPcgskC7D8Il2c77rzrBGj+StfYb",
-	"5xcXnWBNmP102u/7fHXm0OoIkDEXGpxFhKsb5o8GfR7XfmZ7Ezn7QEu4xCnVNsQLnqrhgmL2Mei0wb7t",
-	"TNBN9RC4+kCc6QUWfaY/96gcvd2TCCI0upn00Lsk4RmY3ZNkvRdh6Pb1uPvd9/3vOogY78SAqBgEEhDy",
-	"9RpYZN9d6JIyZ9QoXOsr4YQp/TW2PrJbmCPiYaoPn92HcYFWlC+MSax8RV5XMnO7w3PEEWnKrywUffEh",
-	"bx7W4gM8JiRrfQ2fdgxEWIE5vT44xDxp39nSuZAnoWzRn7As26qVYqnmaaLZitozqp9LhddJ21d8teiO",
-	"SMfVVoWnTCveFmaRbx2oSzOJG6p8YNH8yD7SsUoGtrJJcyWpMs/zk5gJU0L1qc8xSoWFmr8olY2CCpmO",
-	"q4aC41pL4Nm6r3UFFmfn0dlZdLArkL1/IJ8tdml/fkoWWhOWNXdP920tg/qBm5qCvQ4rLOdhucN5RJes",
-	"7D3KqLEbot0SRNbWay82WeNEe9vZ7RjlvZ2ypxz0B4Nu/7TbP5v1L4bnF8NXr35x7bD/6IuwRQ90dju2",
-	"ijXL2XwlcAjzBAThkSf/uB3bHApLpEQqlU2fiNQhx7yK7KsdI5k+LBQrkMoIGWLGuLpjeSJWItK7c1C5",
-	"4JwCZrXjUPI+FbsVEvtlcVuPnCnBKdLpPuR9HKei9Z6O0j1X3TXlj8v6MqvRGqS51jjkbIuazLd7lg/m",
-	"5VyCpbTnL4KVwJFxwEtMqH5YKut2KyttniyHLJyaSYS8FzrTXQu02lh+cZXuFddtzJe80fcX6PICnV2g",
-	"8QANXuv/L8bo6gr1r9BghM6/Q6MLdHWNvr82X52j169Q/wKd9tHVqXtwZIJDiLplP1aVenY79jiLVMVc",
-	"EJ0A3cMcyyNuuIqgVHVM5g7u05Aqwc93DdPeIXyaPrZz6bETs+NTY5l557hq13Egds1ux8++GcgErjNf",
-	"i6ntGLGQLXOhC+k5S9cLG3/2Zw1ERi0aZBIEwdRH9FV9ef3oBZ0SU1V6FfX7YrojNE845avNwaZw9cV/",
-	"OxArK4xxNcdLVZHsZQFR01zAkguoET19JtFqf323Q8cRwVFmLnEWJuva3G6zFl29nL6ZFMWVze7yOJbV",
-	"sEE9wuXV7ehmos8iCGlp9Xv93qnWCU+A4YQEw+BVr98b2MZmbExwYq/azd8rUA2N9h032XJb7GIB6CPj",
-	"DywvUMOMozzMoFkMSIBMqZI6MdCV6JJQBWLXxzB5LxpNO4jUhjd0emGGACpjHOhyg7IivYMwpShlJmko",
-	"Rgek4U2ASgWDSPNBJFpAjO8JFzknYYzZCiL0QFRsqH/AlH4wm34wHm2O1QeUYIHXoECYDr2Gr0kfJlEw",
-	"DH4AdZnpT+s0X2hmXCpZopEyawbzZc6m1RCOIiO45ouwkKYRoAdCoxCLSKJv+t+iBVdxgYvJ9MowOZo6",
-	"3bFyTlnpYxPNwu8pCO2h7YVYtd5oNwhUBPmqfG9s96iY4LCjG7nZckPsxH7H6KYOpvxtnTNTal7NCGXd",
-	"EqrR+EAo1fYrzOuK3m4k5r1fJ8UYTzttVEeCDk8jkTKzAz8b9SEil6OibffP8/NX507jru8LCbXkPi/z",
-	"EVboISZhXLOOMYU5AD00WaKUSTAuIGtYmfaiQtphan+p6wKd6GeHzPS2YiwRZgiWSwgVIktzsv5riamE",
-	"D7Xi57R7etodnM9OB8NBf3je750PfmnAbH4qS/po58LrtrHnLJdZwAqLiGpz8aVbzZkbOgH2g6bea2AO",
-	"U1riq+giGrl9VU+Vp//EYNp3iiMB2o9D1qAWCnERgUDfYBkCMz3yReECv23iSFN/IUsjpQRZpAr0fjlc",
-	"rD/XKNGsWdMbxKSAPrh+5YNtj8o8PmT+z+3pWwexJEKae7oyOkqVoNeJcaH8ElbbVnlJVSLp9rwq/rDy",
-	"+r65vgJk7zvlScxBv3/UCGYlndxF5iNm6+r1wtabfviv09dYhbFGVyna9zTRs73CZN3efxw3V5pf53k4",
-	"mjA7gJFNlfbsWKm9ZGhMS7RJ8UrH30x7wXv92kmInfymFsLHOHih4fa2kkY+8aZpGIKUy5Sidzk/jpZ9",
-	"BAsOT5wJ37JWJrv2BjJXF0ZF41HPUUyYJB9JrpddZ6VFBph5frpBONQVXX0iqSEvvGN7EkNfXmj9RA+9",
-	"ToV2iGsuoHPHOAOzOMFSIqwTM0XClGKRXWUQ6593gc0wvePxjmVMFvENYR2ck1T10AhlXjDnp7iJMd5Y",
-	"R0idk9wxV2edSthQMRCRZf368z0mWcfP9L3qyHP1X8sgvanBs/O1Tx5P28TAWoB5qYtsOd5TDBHWvWGj",
-	"76uj+Y93e01ez8Pr4RN+8mSWdkm0bTzsP0DDBqb6wGa6gaFssvAwqhtArcvPHWhyrgK3zrbNhJYuNh/7",
-	"fDG8Du7is1ltUvKrw02jVY9DzcmC8sUzoAMs5JG9Cri5foMWGwUSaVrPA9Wl5uKrBtZjN4F1d0lopQnV",
-	"1f9dXv8weYvG17ezyevJeDS7Nk/v2GjqAqnX690x88312yvP6r2kxqNjSAUtIG3M9efBtWW3AdycLclq",
-	"b0JoVxw0uYJHdZLQbBq/FvWKYPmFsr8bQZiy4yazd29+QlbQNKtjNRpLeSBfr4sEeTdH6j3aNwKkrvmd",
-	"Ud7yhRLClLPVrpMGjxCmCqL6fG5N2dlw6md03JUhWp899sy9foKkPCLFIJos7eTaI5/GNfbIm8NNCJ3Y",
-	"Kc4/Fz4vsSShq1yU4BU4hUqlSrBDk1I2opby1UkxINukqmK29jMirNjji+lSez5aGQKu6agTJKlHKdOK",
-	"Ugz9Sx5tvog+8tFld/9dZN7+v7LStI2VNJKz6Z3W1zDuyE/TZczxlzCYRQjsxUJlCApNmEwgtCwQFpF7",
-	"EqWY7liwiZwu1JEdxYYI3RN48Lr8aS7tkZcmvpGsL3/VMQOxJgxTtIepQc7UoJGp0oDXcSx9kSK6NKV3",
-	"RBldaSGWkNr7eitqD7fOYc0eVU7ryVP2V6uS2nlqfuu8G4yv773v2NRPDTwmlEdQtPU9pdCO0WcXQ85Q",
-	"n5l63BikS2Ig/1kr7+KfBPD47foEZEP29nVVLIcnN9sjr11Z7tmxsS7fBz9/9f3ng2CLGv1mNPsRTa9/",
-	"eHP9dpbVykaLN1jF+TRqubj2vBG0Au1XXV438duI0mIGtykpz6Z0P6fPsDt86eKbeG9gRlPkdlTyHyhp",
-	"PbmFT9fOqmaTpE2XNla7z+3FmbZb+eA3dNysBsdZm/Cv7tenKdqOblcpZ+6u6TgVs3mf8UAVe/wR/axM",
-	"gmKgaDRFuV72N7aUCFsUVdn4uvVzMzOtfsu5QmO3g2aLHMBhbCawjp6Aa7jo7KF3iZ2lpBs7zDa7HReF",
-	"WuaYzUyUVIDNtaKZsXH45gz8vbWZlr5drK7fM5bKkqJk8PwwsPYbehuWtSMMvu6LwmKi+Ij6Jtt2QcEY",
-	"6hM0CAsYanpNXkCE8oTI6InIaNtdPC2whG1XPtmB3m3L7K8J2g0RYCbCVvcsFizNKd3eIecCIWWaWsB2",
-	"RE9b07TKakfVN1/9OWuc2e3Yh7rZ7bj36QKP3uRZ+DqmxGgCWV5m5MmHLjdstdGIvtY3fX8h8JmJ2Ox2",
-	"nOVBv/w2enj32+ifb2bXD5NK1rRbFXgh+onzo4KiB6v2RxH3ORZSQYNhECuVDE9OnmIu1Xb4lHChtuZn",
-	"KYJoR23/qRQuVWVCkIeYmsfmX3wTla9f9c/OB/pMvi/YqP3y6x7ERplulwBqfp2vuL/xVS2Dg3pjcR+1",
-	"8c3NvyZojZUBkEPOKqZObGyyIDS6mSB4LH6PaIllyYnLVZY0eZhikZmuki5Pzj3g7vdlHqrZndb2/fb/",
-	"AgAA//8pLjfFslIAAA==",
+	"H4sIAAAAAAAC/+x9/3PbNrL4v4LR3Q/XOUmWnbhN/JnPD4qctHrXNB7Lvc67Js+ByJWEmgJ4AGhH5+f/",
+	"/c0CIAmSoETZTure5eZmGlMgsNhdLPY7b3uRWKeCA9eqd3Lbk6BSwRWYP17R+Bz+mYHS+FckuAZu/knT",
+	"NGER1Uzwg9+U4PhMRStYU/zXnyUseie9Px2UUx/YX9XBTFMeUxm/llLI3t3dXb8Xg4okS3Gy3gmuSaRb",
+	"FH91L+K840izazgTCYs2+HcqRQpSMwtszJYO0OqEP8AnAjwSMcRk9sN4cHT8LbFjiVgQvQKSmhmHvX4P",
+	"PtF1mkDvpPdy8eLbePTi8MWL59F38bfHL+nRAigdRcfHNB4dHtNn88XzxeH8aD6avzg6iuLD4/jb6PB4",
+	"PlqMRnT0otfv6U2KMyktGV/27vq9BUugCd8bloAHBmGKJIIitAsp1kMy1fhIrJnWEBNmQY5hQbNEe+9k",
+	"CuLqFg5ARwcqQhohpujS0Gu4WSch4OyD7cSzqL/AkUgaJBOTEPdOfrWv93MifOj3NNMGCks0MgcaCe6h",
+	"2gEg5r9BpBGAcbxmfKapNmAAz9Y4b5birJIyDnFlWhzNlJbUTK/wPaQn5YRxDXJBIxiG9jlewqssugLd",
+	"5KBIZDzAQD9l6zlInNxuQhHGDRHmZp4K0p8XKyIUS5C4ZIjqUx4lmULQszQFSeYi43HOkG5icrECklCl",
+	"3QOyosr87r/y8a9TvvhYpfzxurnzGr0SpJbdsIdVi5kCDEszugQSI6rZPDMcFKLeKzPWyIYkebfonfy6",
+	"Qw7Acg0cX61TgfGlBKUuCzKGkGeGlJSuQuwB6FEhU3RpV9gGl93Hz3ZsHWluin4Axg8NnHhonU2m734i",
+	"KdWrgbL7JshHWmYR7siBXaIRTwFTmkWqyaR2rPkn07DuuKFyxtdcy0154HtUSmr+vmE8Fjcd5Leb6Bc7",
+	"vo4jN02/ANTHQ/FyTjAJEbBriPOjtYW36ltoYCYnb5VXTj3ODTA21QYGM9oeOHvWFKESiBIShe58g68x",
+	"6R88hLQTAUqBE0D6giUa5KUEqhxRaRwzBIYmZ5XdNRl6l5TSK6rJDUjEMqKy2EhFGDNQ+NhCUBEjtz2m",
+	"4stEiLR3cngXoMvDT6qqLHgUFJ5U6cucTZoLXLC1vT2tnLQbu6Gq4CxcYSHkmureSS+mGgaarSF0M+BO",
+	"L2MpUrXtDsC3FaH+Ulxokt+wEBPkLE6gvvE5RDRTQJgmNyJLYrKi10AiCeYlSnD5CjpGIXRwuAlqOROc",
+	"B1kc4cuRbAfXD1l3jLSjvcRH6AQXWzh8FtqDBJUleufBOTfDJnhHKXxNQQKRXb8OzrtMR2JdYy5SvIAM",
+	"XmoenU7tLH/Xk8WB86s0lfoSzwndOeVUxePmnVKZIXSmPDKUqGtIjn7PXU0eF/so2yqFc4lxw/TKPFB0",
+	"bXQqqcl4RiiPCasf5C2S2tyeVSXuUsLSaWuCo0Ynbnj9WSQk1J95imtF+0sScQMxsesRcy8Htb3Kdb7f",
+	"lWl3cVcu+iOzJgN1i8+9xf2LyzJHv5dx9s8MpnZFLTO46/cm44DaCVJfXtOExUxvdsH293zcXb+XFpbQ",
+	"bqUdx6vMEmoX62cFPd0bl1ewuWRxxxf/BpvpaZPP3ayNSYt99GuY+BBgsAmibYGmJ4QsQKUZX2ZMrSC+",
+	"5HQN3sVZ8sRep7UKLk2WAl8sVe3Xk9PZOMR5D0Fdv7c/O9TQHcBFsXNv+sD2GqB7585DP/ElcIhSK8p4",
+	"k0ZMqQzkrm35ZO7OuJW3WtnPQdCyqwjB7rS3V5LBIrDBnbQ2b1syd8NGnRU7j38wF5nj2UCdN7FvOyI+",
+	"SHQvXE5Pq6dqQY+f0dFz6msqK/g0cMdrG+mmMXB8BLJcrTyVkxVEVwHJQTXdTTaIrk5xoNG9NWVJUxEZ",
+	"F9o7YdyCzmpqdS8EVy6saloWtTrNCmiiVyRCCKpzGUIQxZYcJKHXlCV0nrToclSFVKdz85wshLTzkwVl",
+	"SSZhN8xKU52pLgZj1lR7nERyc/QtBTxu+sFueZJvOcA3OTlajSa8c8sZ30gA3OaalKMJLmv2jlpPHc2N",
+	"NU8hAQ1WLw0wkfl1q7LshhDgWjKo6soBVbku090CHposLDg1dZMTBIm2+mksXpvAm00HDJ9c6fFxozpr",
+	"0fa4hfXm+/NOwTQOaF+3zdZrKjcexHaw0WFL4FvQcu6c3030rAq0bYPXIbcOr3vZBxPkNYsKjquJiiZ0",
+	"Im2CVLG6Swdk0Ih+iIHimSa+s8vt5Iwijp1TayXSEPjTdSqkbjs2jCuQwXPzywr0CuzZZGYOtK7NtMbu",
+	"zt8ckjc0UUAYj81N4FwfzI6iiQQab0gqQQHXHoLnQiRAeXPHOUThg8YdMDtO2jRH18y3W1toqEKuE/cb",
+	"mZ5WJcWvh/2jD94JXNNPbI2W1rfHx8+O+7014/bvwxAvrBl3Nslh/Vw28FBAV+Hd3KoWi9IcDB8ry1GV",
+	"q/1wsFiMRiejk8PDUa/fS6nWIHG///P+ffzXwV9+pYPFaPDyw+1h//ndyTe3R3fVR9/8L477s6cDTGen",
+	"g/Fsx8X/o1j+CNeQNGmQ5I9rgk8sl4wvif25X9iyMcyzpTkNC4GPTRzrg39Xul92+d9x2pCJ86MQV1m6",
+	"zQWcmBHd7dn6jF/MBZwDutX5oECi+yiXIe6dIDuFN9LEj3UZFoHLgMfQrY0j3Yr7eMXMzCCbcztWRCmR",
+	"6RVwbeRREdAEqfqofRhPikDZdsMUDMl58bOVXPApAogNhFrS6MrwIVszbZzSdLmUYF2NVJGPZp5aAMg7",
+	"ZIdbtrDV0ekRovTQjh7Vn2corrZ7F3UmeckeVXBCl12NF0tiebv2/WgFFP0q3+zg2Qqz4mPBoSRzkHvb",
+	"AtfGbrlM2AIMx1Wk5XdHq9F6pHaKk9ocH1qXnxWK18Oj59docyQJoTbEW4QTUNcyWEK8RUa7wXjXgi0z",
+	"GbCHPkeo3UbQL6neEjHAcLyyARInB/Cd7nIg329nOVzJX2hRill0ae6PLjI4mvKFaNGRHT19THgQb4/P",
+	"5zTcSr8W/jLJAZ7r13fg9u/tCPbdDH4iAUEogo7fMynmCaxDVlqL5U5W2Zpygsoi2tAEPqUJ5WZ9olKI",
+	"0M1AtCB6xRQRUZRJCbwMaKV2Qad6KrKCJF1kCb6RCOOf8EchepeIdxobQ0BwshI3ODiVAoX/kPwimdbA",
+	"CePkNV8mTK3MWwV8aLUCXzIO5lrJVEaTZGOCUSpjeDvgCC440RCtOItoghS9gpVIYrxqcDYcjeAl7F/1",
+	"9JGJ4NwpeloYQ3lOFZj4UkxEpkNYZ1xpykOBwDH5+XxKJCzAYs2iKVfZbFpDgeVW7PYJDJdDDOegDc+X",
+	"hJKFpFYWF5NJIiRR2XyAAXdLMY88yDDkLd2QOZicmRqBpBAu/4Gp4iWX76FEJiMgKAxrmTZu4EFU4Gxg",
+	"FMM/aXEFfIAnaICEM7IkHljsFVImk2xQYGa7p6UmylZAfri4OMuNXISMLIGDpF60V0i2ZNzqWdI5O7ax",
+	"cGVvx6Nn/dLAOH750jcvRkF1wJ3UJgeoFVpNqjTRm4T5vZk+N8x/5ludadpJOZeG1Tvp0bnI9Mk8ofyq",
+	"1+/C+zY6lGzqh8DHBxE82eTcZ9LvPmkPb9cMb+Xx2XRI3qWpcMzsnyQrvRgn528mg+9ejL7ro1XMFOHA",
+	"jGktIRLrNfDYvjsHEkMOqEE44isVjGv8mVoZOSjIEYsow8Nn1+FCkmUi5oYkdn+Fb61C5m6HZ48j0uYg",
+	"sqwY0oYqyuheaRdefgRIeZlKsKFYY0+Lq0sON72Tw6PyIHjqLFwD1y7fAtcPXqR5alTj6oJPKXMX4slt",
+	"RyVlJfYwFNHPFNBLOoSvLMg2qGHU6CxFsOLugOJzpek67fpKKFRRTtL3sVWDyWElmKBVqPY7whZuxy1B",
+	"IODx5Z5hxn2RDHxpHZI1t4V5XrNTqjkZIZn98DwGg/9aMoOHhgLiRsTo3rhvBI3mz4/j58/jnUEj9/4O",
+	"j1GxSvfzU6FQ1c/WurTqNQ9cKPmkaTZSHjNk6H3TlaiXGFNMUmWR4L2+T7qBzRHOE1Ag3hPE/LVdubU1",
+	"HiwTCUrceDB4rLcrYQivLcFhW77yDHSZsuylyVeptJQ0gssUJBNt2Wu41s2KRQX/K2daE5f37CWRmaRE",
+	"zZKEWPsIJJoK71BNyFSZHm7ToZnKZxiSU6upKLzGR1UvSu9ZyMFgtc6dKeElCoK3MFR96AZJCIICbQCl",
+	"+6Vxe9g3Ic8mvqm6jKo5InvkGVQv2Cqp7IKkHGKiAMqq2Tb0jLrSxfmE5NHxKpaPRkdHg9HhYPT8YvTy",
+	"5PjlybNn/+jsa9Ay6pBFcnE+mZ4Ww/nlDuY7n1imoYpomSltjR9m8tvNq8S+2i88OgkeKW02GVHOhX7P",
+	"5xCYZPie7w6zVC7oGt2KHYf34idvCK6lSAga65BHwr2AWpB5KkUozds7f1zFlxlN1qBMYtgufaQIDIRW",
+	"r/puHrGU5Qu72XZUtFSgqRe2tNWqqAI9E/P60BQY7UK3gWRL/cl+vqxGiKOZVl7sihMFkeCxKlw/EYrv",
+	"0v5WxWTVeP+3YfO5ZIrCh0aVshpMDEuJGES5QVmCDyuhp3JkLY/COQi8kAtrqY+ZlTlG9cytB8eQg6fB",
+	"z3yr6HMvXpJXL8nzl2RyRI7e4P9fTsjpKRmdkqMxOf6OjF+S09fkxWvz0zF584yMXpLDETk99OWqSmkE",
+	"8aCqCdZ3fXE+CdwlmV4JybS5my6p2sPVW6j1ddXO+DYfZ6oKQ4byHLvfF4+TKOZlFZbb7IfQWAXeO614",
+	"s+zQ/i/OJ/dOvXMbbgLfsEq6ATI9bUKBXtJLbrTbCj8ftiRmdEjfUCAZTUKT7k4awhX6FaDq89XQH7KK",
+	"vE2LVCRiudmZdVV/8e8ei1URxoW+pAtd29nD9CWccw4LIaEx6eE9J63h1Vuh723BQ2a+Y6dFNbF5d+fS",
+	"CJq+0rNp4Tmz9nGu5jgHZa+pALlf0B+IZxGksnONhqPhIeJEpMBpylDhH46GRzb5YmVIcGANIMaXB35U",
+	"awkBJeR7p7rbq7a4U2h7HEksdoQDTd1V+YoEX1Hwa2NtyI5qW5uQpWb6mxVwuLZ5QhvztoQyllck6Uxj",
+	"C7wNIJ3l2+xXS56PRqNHq3WuRFwDpc7h0NsQaXW8FQznMf3rnuDYt0KQmFwjzIc0WuvQMLvz03sUD5MY",
+	"WZEuFZ4J+0vvA75+4BUqBvnIZBaWDF6pWkMiXnFxw3OHduSYPNdcDMe4ID6aIui5tm7Yit5l6lf6zeIV",
+	"wzemcKNWOUpebfLa6r6JbWfcmCllfZNjMJuWgHAwReawotdMyBySaEX5EuKynuYjTZKPZtGP5pK8pPoj",
+	"Samka9Ag1RY+VeaY5gNNWW3NLjW7LBNP8jIsg6E81yQy9nSUZDGQG5bEEZWxIn8ZfUPmQq8KUTOdnRog",
+	"xzMvmtaaWTIyWVC9k94/M5B46dsk5roTsBtzFnpjfX9vbbSpqLoxVPPqRy0hym0bX0iDmfK30UpPEvOq",
+	"m8hFVxLkxht0rMw98lay7jpFrz+EcVJUDnfDRr0KeXdZJYvrpZQhMELVZSVErXmEo5CWEfJlGd87ymfr",
+	"z6pTx5DCHIAhmS5Ixp0byAW4TDhSY6gZTBVj3tXAHjITC8MKeMoJLBYQafR24cn6/wuaKPjYcLccDg4P",
+	"B0fHF4dHJ0ejk+PR8PjoHy08m5/KCj66aQXByv64LKrDXC0ZJ0gujACV/iOTkuyXbQ5bgKNJUoGriDqa",
+	"fYf8LK2ZtIJIvCoVuIC21ETIGCT5C1URcBNTnxci8Js2iHD2B4I01rYsG3C9nF2sPC8rr60Uu6ZJBuSj",
+	"L1c+2nCqyu8HJ//8HICFS/KRyiQmV7mj4nsKCjEhdXiH9VhSbqVXpvQDUTV5WHt9WyuBgsk+PFBLeYwW",
+	"Ak0T9C6o0YbrB9ZURyvkrsptb3Sd519a17FFMy5Zr67shNWSNiUnop5+07jCJ/RzqpeTcWh7syyKQClM",
+	"PXqXw+NhOTRhAeGB1/CnipVp6VAlJtXBoGgyHnqIidL0iuV4KX25HTRAJ/mTTa5iNqrIWvTC93yLYhjS",
+	"C62cGJI3mUSBuBYS+u+54GAGp1RhICqlUrMoS6h0qQ/MldUXF5teVWB8zx2Qxf1GTHlCmukhGRMnBXN4",
+	"iswNLdwNiTrJe+7jrF+7Nmz7CWtI4t+YnGJjDMbT3uQ8H/8NDTKoGtxbX3v0+7TLHdi4YB4qIjvWMxWF",
+	"n01p2Cr7mtz8+4u9NqkXgHX3CT+4NUMHLL7b6TZoLGCsD2qyITlx1aC7ubqFqdGjUTJNDlXPd91Y/1RH",
+	"EZuX6j6YvXauEqJZo7r1yfFNK1X345qDeSLm92CdPBhGFTl7/ZbMNxoUwbnux1SvEIonzVifBimsB3nY",
+	"rdRnB/i/V6+/n/5EJq/PL6ZvppPxxWvz9D0fz3xGGg6H77n55fVPp4HRW6eajPeZqteBpQ25/jh8bcFt",
+	"YW7j1NyqENoRO0mu4ZM+SBPXQaFx6xWX5RfS/s4k49qmp168e/tjLa6L3FjRA8V6XSjIZeFs8Gif2aJM",
+	"v/y6GqMkNBF8WXrS4BNEmYa4WZDcQLarxv2MgrtWNRyix5ZC30dQym29awVfdiWfHnn5saFHHm9o49Cp",
+	"LV78Y/HnK6pY5COXpKa3W2Go1KwEW2ShVCvXJmJ5UNSFtqGqKCn9jBxWrPHFcImSL6nVvjZw1O+lWQAp",
+	"sxpSzPyvRLz5IvjIK3b99cub+e7fikqzLlRCTl5TTpewBq69GJ+tCCkS3VIRrJOVbLl01ffeC36HQcH9",
+	"agM/UdHFH3y/bz6HHxfhWNAgs6IxYz5GyLoBjEG+sk9ZUbPnTeqAqVakV/nTbemdt/3Pw6aB2v+7u7vd",
+	"LPk80NrOQ7228GNg4in4zRCEZ18SBGSpkqEJ8Nj4Zyx/xUxhTUpcd+jlfOzClqJC/PzMlLNuOzd5yPPg",
+	"1vxrM8D7zpi8ThzWW+ukCY2g0XKzqH2y5omtfLqodID2j1TZke+/x29/JPaSI64VcCUwLhah287OzeEm",
+	"H2xoBMZ3TpPEgeZ52IqWjialwXUcNkni5FV9bN5dQ2mBLrYrgDTvlWrCqxnXLPEj8/nUS8p484SeZX58",
+	"ftPJGPNocW97rNLZ+kNXobCh66Rqh70CpWegZ+xfcEKO3/NJnohePDwcjd7zN8YlefKeE/KWfvpBpMrW",
+	"bZyQF8ZI3apR3UOI2O0RaRnyq/jYQ3zkh5hWj/D+ssMrhO5053ov3PvO9brStt65+ZjOd2611W3HO/es",
+	"Ugf+xO9cD9ivd+4j3LnVJgD7nRuby9V+ZM7N74E71uWK7epFYYsbEIUma2idR5RlxnH95mU6xeqUTRlf",
+	"xlVMzYQhVd++bIFiqmy9nQOSR7jqqfFXkOohydPSKs27rdAuT7BHDkwUNMr1NcgbVzvt0p98NAzJzAX3",
+	"Z9Pvf/j5rCWtqvjAgNE2bJJF80RbjD+dNLoCZ2Xa3+93UitY/+PcccEzVE3u231cvS6IgYIJ8zyQ8Fdk",
+	"BrjjpEGWeZ8OnLz7xJCMNUnAWH68GL3OTOv38k5s8qxdvWNKnVecWe+QrYVrszj0SzhLddi0ODAXOOXY",
+	"nCJLkqKqdXpqhui847sipnb8k81MSRMRQ5E/E0xJsfPYqqiOX9spa1iR4HpjC2WFXPfu+v9pmYRPIGnu",
+	"c0YRK81JA5Kh2YX0SSXhPFlJ+cSSsZ0cbctO2ial60k5YYXq7yDZYtMS/TJmu6qkg1+cT4yYZFoVx952",
+	"0DS9RqyFYEtDCzHekM+2RagXHJy4IHI3S+G+YdHy2YPDontM9VAD//FkRqUza5cEhKIP61eh0V29slgm",
+	"dGuWxLaDW9rXB7fFv03ehKmfHxSV+kE35GxHrX3Fgh+Sn0Sb217I+mdumh0KMAHfuTz9EnJjWaRUKYj7",
+	"pMyMds5AtIB0jkLUKJy9xEWtJQLLHZR57wM0xdZCOh+n6eZtC8GZ/SwPNTYMxP+vukvU0/ATPHPwdmAV",
+	"PZsOLUWmQaKOdgNJ0pRXM4yUusm8FggNxdKv/gu4Ln1ibvVd7tPit7v/cs9PN4YaXtzXxRL8fp8C/VWu",
+	"dJcrsz2aaHSUNNWerB0tOb93zw57zgx9JGtuVnZu3decK4At7bknaoQF+95+edvpAuSaoU66C7KjHLKj",
+	"VsgqXaH2g+tp2U8lBr7KrD+oAVURXR1lpJZRN/MJq+m7WENYIkCVHZ8XutEogtR98JcL85OTXWhjob/Y",
+	"lEFswFQY4M82RIs/XYO0oaHcWmPa+JpiiEApjPPYKgYjafMA7txVVrTZZhcyekxz7OJ84qyhf/w2vnn3",
+	"2/jbtxevb6Y126kc9cexmQwVv1pJ97eSsBfLlpPoqyhdCsur2km4vHz/snI812AdnLVei2TKVQqRdhZH",
+	"zK5ZnNGkBMGmpqPVQmwzaojJNYObYBJrVyWnRW94KmrDFqCetsawT9/HvQuDakWRFU4dPt0aoe1Xp3tU",
+	"O60Ht+5fnYqEvKf221ZFa/Dm2tuOTcggD+j4VaO8BPTe6URhlV8xw/KfVYvNNx7KRG2gri0f/WnVYOxu",
+	"ENud87oVGgVWbK002sZ+4XqiPx4LdtDnzsYXP5DZ6+/fvv7pwqlsBov4fTAHSk23C7zR68S0T7pgqA3e",
+	"Vi4t+pi2lRm4TqefU2bYFb50OREL1pSPZ8SPOuSfaEA8+SkrA+vmdd0428rQLXbvW12Ir9UOfksNocVg",
+	"GbP6Ws/3GGUoexfglZ02d3afyudXrR9DN5ZCKEmqCFswXp/CfvnMmhHe49pXu1KQuz6uXk207vw9sIzH",
+	"/tyjwSg8PxkZGDe7WmcVWWvBz7+3tKzympb/h/eu+jfPOGmQO3CD2DGVHrhPruVLLgvmdVjbur6UI3It",
+	"c+B9k3FPeVP/llyr3HEfSnyI1Ck/UOd/cxAHFmcmlwFM5geTLcr6jcpnDW3qbDG8+WHDLapx4yuXn7U8",
+	"r7ZWsFDXp0KVX4PMotpeaFX1tNc6tU3ZK9qrfkZ0FGv8HvXjbgcF949nJMfL9kLy3Ou+3eXnGtRbLfzC",
+	"uNnPhdBk4qdbWxcc0Ghlbo29O062NBbCrzDZdrjJxjaPNKlRbnB+9Ew4QAON7Ucjk40Pt+AQrmW/kJHq",
+	"aEk2+/r0+iGHVuDDXY3vEdZClU+3MU/RFHoP75tbFr9jhYR6hIL8gg1xvhYdFfn4gKn4lqn4bjC/xcjP",
+	"3UDd2p7Mdx19E22s3WKf2ODN7lI6yyztDocdX14Nzokb7DbpYec5LbK6zfrsC2tFGL4Ih4WGj2cWVWMk",
+	"e/DXPg6wNibLnWC5aYzOMOsLa+W+zp11vnLglwqohiOXj2u9FzMGePXO9KG/znkhk0nvpLfSOj05OLhd",
+	"CaXvTm4xInhnviwgGQpqg6pVEXcvOnLixxzNY/OZKln7+dno+fERnskPBRiNb7tcg9xoE4uRkBh9WYtw",
+	"WKbupA0k8WybbXJ29rcpRn4MA3nTWcQ0J5sYLchUesGn4otDdjKnnPhQOaUpAFQRhfVg8vrulJ8ICcxq",
+	"xwRmLbREZ7isRZynP1QSTBvqlGMcVeR7uc9E/CuvdKPkv365QJtMmsa1V8Dt1328Qri8iA5iolbU/Aci",
+	"CdoD3Asf3324+78BAB5rJ6HumgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,160 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/stats"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	api "github.com/scionproto/scion/go/pkg/api"
+)
+
+// Statistics provides the rolling beacon and segment lookup statistics.
+type Statistics interface {
+	Beacons() []stats.BeaconStats
+	Lookups() []stats.LookupStats
+	WindowDuration() time.Duration
+}
+
+// GetBeaconStatistics gets the statistics of the received beacons.
+func (s *Server) GetBeaconStatistics(w http.ResponseWriter, r *http.Request,
+	params GetBeaconStatisticsParams) {
+
+	var start addr.IA
+	var errs serrors.List
+	if params.StartIsdAs != nil {
+		ia, err := addr.IAFromString(string(*params.StartIsdAs))
+		if err != nil {
+			errs = append(errs, serrors.WrapStr("parsing start_isd_as", err))
+		}
+		start = ia
+	}
+	if params.IngressInterface != nil {
+		if *params.IngressInterface < 0 || *params.IngressInterface > 65535 {
+			errs = append(errs, serrors.New(
+				"value for parameter out of range",
+				"ingress_interface",
+				*params.IngressInterface,
+			))
+		}
+	}
+	if err := errs.ToError(); err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "malformed query parameters",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+
+	rep := BeaconStatistics{
+		Window:  StatisticsWindow(s.Statistics.WindowDuration() / time.Second),
+		Beacons: []BeaconStatisticsEntry{},
+	}
+	for _, b := range s.Statistics.Beacons() {
+		if !matchesIA(start, b.Origin) {
+			continue
+		}
+		if params.IngressInterface != nil && *params.IngressInterface != int(b.Ingress) {
+			continue
+		}
+		rep.Beacons = append(rep.Beacons, convertBeaconStats(b))
+	}
+	writeJSON(w, rep)
+}
+
+// GetSegmentLookupStatistics gets the statistics of the served segment
+// lookups.
+func (s *Server) GetSegmentLookupStatistics(w http.ResponseWriter, r *http.Request) {
+	lookups := s.Statistics.Lookups()
+	rep := LookupStatistics{
+		Window:  StatisticsWindow(s.Statistics.WindowDuration() / time.Second),
+		Lookups: make([]LookupStatisticsEntry, 0, len(lookups)),
+	}
+	for _, l := range lookups {
+		rep.Lookups = append(rep.Lookups, LookupStatisticsEntry{
+			Requester:   l.Requester,
+			Requests:    int(l.Requests),
+			Results:     ResultCounts{AdditionalProperties: convertCounts(l.Results)},
+			Segments:    int(l.Segments),
+			LastRequest: l.LastRequest.UTC(),
+		})
+	}
+	writeJSON(w, rep)
+}
+
+func convertBeaconStats(b stats.BeaconStats) BeaconStatisticsEntry {
+	entry := BeaconStatisticsEntry{
+		StartIsdAs:       IsdAs(b.Origin.String()),
+		IngressInterface: int(b.Ingress),
+		Received:         int(b.Received),
+		Results:          ResultCounts{AdditionalProperties: convertCounts(b.Results)},
+		FilterReasons: BeaconStatisticsEntry_FilterReasons{
+			AdditionalProperties: convertCounts(b.FilterReasons),
+		},
+		Ages:      make([]AgeBucket, 0, len(b.Ages)),
+		LoopDrops: int(b.LoopDrops),
+		Selection: make([]SelectionStatistics, 0, len(b.Selection)),
+	}
+	for i, c := range b.Ages {
+		le := "+Inf"
+		if i < len(stats.AgeBuckets) {
+			le = util.FmtDuration(stats.AgeBuckets[i])
+		}
+		entry.Ages = append(entry.Ages, AgeBucket{Le: le, Count: int(c)})
+	}
+	if !b.LastReceived.IsZero() {
+		t := b.LastReceived.UTC()
+		entry.LastReceived = &t
+	}
+	if !b.Newest.IsZero() {
+		t := b.Newest.UTC()
+		entry.Newest = &t
+	}
+	for policy, sel := range b.Selection {
+		entry.Selection = append(entry.Selection, SelectionStatistics{
+			Policy:     convertPolicyType(policy),
+			Candidates: int(sel.Candidates),
+			Selected:   int(sel.Selected),
+		})
+	}
+	sort.Slice(entry.Selection, func(i, j int) bool {
+		return entry.Selection[i].Policy < entry.Selection[j].Policy
+	})
+	return entry
+}
+
+func convertCounts(counts map[string]uint64) map[string]int {
+	m := make(map[string]int, len(counts))
+	for k, c := range counts {
+		m[k] = int(c)
+	}
+	return m
+}
+
+// matchesIA checks whether ia matches the pattern. The pattern can contain
+// wildcards (0) both for the ISD and AS identifier.
+func matchesIA(pattern, ia addr.IA) bool {
+	if pattern.I != 0 && pattern.I != ia.I {
+		return false
+	}
+	return pattern.A == 0 || pattern.A == ia.A
+}
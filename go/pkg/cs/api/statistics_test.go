@@ -0,0 +1,197 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	beaconlib "github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/stats"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/cs/api"
+	"github.com/scionproto/scion/go/pkg/cs/api/mock_api"
+)
+
+func TestStatisticsAPI(t *testing.T) {
+	now := time.Date(2021, 11, 25, 12, 0, 0, 0, time.UTC)
+	beacons := []stats.BeaconStats{
+		{
+			BeaconKey: stats.BeaconKey{
+				Origin:  xtest.MustParseIA("1-ff00:0:110"),
+				Ingress: 1,
+			},
+			Received:      2,
+			Results:       map[string]uint64{"ok_new": 1, "err_prefilter": 1},
+			FilterReasons: map[string]uint64{"isd_loop": 1},
+			Ages:          []uint64{1, 0, 0, 0, 1, 0},
+			LastReceived:  now,
+			Newest:        now.Add(-30 * time.Second),
+			LoopDrops:     3,
+			Selection: map[beaconlib.PolicyType]stats.SelectionStats{
+				beaconlib.PropPolicy:  {Candidates: 2, Selected: 1},
+				beaconlib.UpRegPolicy: {Candidates: 2, Selected: 2},
+			},
+		},
+		{
+			BeaconKey: stats.BeaconKey{
+				Origin:  xtest.MustParseIA("2-ff00:0:210"),
+				Ingress: 2,
+			},
+			Received:      1,
+			Results:       map[string]uint64{"ok_new": 1},
+			FilterReasons: map[string]uint64{},
+			Ages:          []uint64{0, 0, 0, 0, 0, 1},
+			LastReceived:  now,
+			Newest:        now.Add(-7 * time.Hour),
+			Selection:     map[beaconlib.PolicyType]stats.SelectionStats{},
+		},
+	}
+	testCases := map[string]struct {
+		URL     string
+		Status  int
+		Content string
+	}{
+		"beacons": {
+			URL:    "/statistics/beacons?start_isd_as=1-0",
+			Status: http.StatusOK,
+			Content: `{
+    "beacons": [
+        {
+            "ages": [
+                {
+                    "count": 1,
+                    "le": "1m"
+                },
+                {
+                    "count": 0,
+                    "le": "5m"
+                },
+                {
+                    "count": 0,
+                    "le": "15m"
+                },
+                {
+                    "count": 0,
+                    "le": "1h"
+                },
+                {
+                    "count": 1,
+                    "le": "6h"
+                },
+                {
+                    "count": 0,
+                    "le": "+Inf"
+                }
+            ],
+            "filter_reasons": {
+                "isd_loop": 1
+            },
+            "ingress_interface": 1,
+            "last_received": "2021-11-25T12:00:00Z",
+            "loop_drops": 3,
+            "newest": "2021-11-25T11:59:30Z",
+            "received": 2,
+            "results": {
+                "err_prefilter": 1,
+                "ok_new": 1
+            },
+            "selection": [
+                {
+                    "candidates": 2,
+                    "policy": "propagation",
+                    "selected": 1
+                },
+                {
+                    "candidates": 2,
+                    "policy": "up_registration",
+                    "selected": 2
+                }
+            ],
+            "start_isd_as": "1-ff00:0:110"
+        }
+    ],
+    "window": 3600
+}
+`,
+		},
+		"beacons by ingress interface": {
+			URL:    "/statistics/beacons?ingress_interface=3",
+			Status: http.StatusOK,
+			Content: `{
+    "beacons": [],
+    "window": 3600
+}
+`,
+		},
+		"beacons malformed start": {
+			URL:    "/statistics/beacons?start_isd_as=garbage",
+			Status: http.StatusBadRequest,
+		},
+		"segment lookups": {
+			URL:    "/statistics/segment-lookups",
+			Status: http.StatusOK,
+			Content: `{
+    "lookups": [
+        {
+            "last_request": "2021-11-25T12:00:00Z",
+            "requester": "1-ff00:0:111",
+            "requests": 3,
+            "results": {
+                "err_timeout": 1,
+                "ok_success": 2
+            },
+            "segments": 5
+        }
+    ],
+    "window": 3600
+}
+`,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			statistics := mock_api.NewMockStatistics(ctrl)
+			statistics.EXPECT().WindowDuration().Return(time.Hour).AnyTimes()
+			statistics.EXPECT().Beacons().Return(beacons).AnyTimes()
+			statistics.EXPECT().Lookups().Return([]stats.LookupStats{
+				{
+					Requester:   "1-ff00:0:111",
+					Requests:    3,
+					Results:     map[string]uint64{"ok_success": 2, "err_timeout": 1},
+					Segments:    5,
+					LastRequest: now,
+				},
+			}).AnyTimes()
+
+			req := httptest.NewRequest(http.MethodGet, tc.URL, nil)
+			rr := httptest.NewRecorder()
+			api.Handler(&api.Server{Statistics: statistics}).ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Code, rr.Body.String())
+			if tc.Content != "" {
+				assert.Equal(t, tc.Content, rr.Body.String())
+			}
+		})
+	}
+}
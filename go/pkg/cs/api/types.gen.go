@@ -11,6 +11,13 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Defines values for AdminState.
+const (
+	AdminStateDrained AdminState = "drained"
+
+	AdminStateUp AdminState = "up"
+)
+
 // Defines values for BeaconUsage.
 const (
 	BeaconUsageCoreRegistration BeaconUsage = "core_registration"
@@ -31,6 +38,17 @@ const (
 	LogLevelLevelInfo LogLevelLevel = "info"
 )
 
+// Defines values for PolicyType.
+const (
+	PolicyTypeCoreRegistration PolicyType = "core_registration"
+
+	PolicyTypeDownRegistration PolicyType = "down_registration"
+
+	PolicyTypePropagation PolicyType = "propagation"
+
+	PolicyTypeUpRegistration PolicyType = "up_registration"
+)
+
 // Defines values for Status.
 const (
 	StatusDegraded Status = "degraded"
@@ -40,6 +58,28 @@ const (
 	StatusPassing Status = "passing"
 )
 
+// ActivePolicy defines model for ActivePolicy.
+type ActivePolicy struct {
+	// Hex encoded SHA-256 digest of the policy.
+	Digest string `json:"digest"`
+
+	// File the policy is loaded from. It is omitted if the default policy is used.
+	File *string    `json:"file,omitempty"`
+	Type PolicyType `json:"type"`
+}
+
+// AdminState defines model for AdminState.
+type AdminState string
+
+// AgeBucket defines model for AgeBucket.
+type AgeBucket struct {
+	// Number of beacons in the bucket.
+	Count int `json:"count"`
+
+	// Inclusive upper bound of the bucket. The last bucket has the upper bound `+Inf`.
+	Le string `json:"le"`
+}
+
 // Beacon defines model for Beacon.
 type Beacon struct {
 	// Embedded struct due to allOf(#/components/schemas/Segment)
@@ -50,6 +90,48 @@ type Beacon struct {
 	Usages           BeaconUsages `json:"usages"`
 }
 
+// BeaconStatistics defines model for BeaconStatistics.
+type BeaconStatistics struct {
+	Beacons []BeaconStatisticsEntry `json:"beacons"`
+
+	// Duration in seconds that is covered by the statistics.
+	Window StatisticsWindow `json:"window"`
+}
+
+// BeaconStatisticsEntry defines model for BeaconStatisticsEntry.
+type BeaconStatisticsEntry struct {
+	// Distribution of the beacon age at reception. The buckets are sorted by their upper bound.
+	Ages []AgeBucket `json:"ages"`
+
+	// Number of beacons that were rejected by the beacon policies by reason.
+	FilterReasons BeaconStatisticsEntry_FilterReasons `json:"filter_reasons"`
+
+	// Ingress interface of the beacons.
+	IngressInterface int `json:"ingress_interface"`
+
+	// Time the last beacon was received.
+	LastReceived *time.Time `json:"last_received,omitempty"`
+
+	// Number of times a beacon was not propagated on an egress interface because it would have created a loop.
+	LoopDrops int `json:"loop_drops"`
+
+	// Creation time of the newest received beacon.
+	Newest *time.Time `json:"newest,omitempty"`
+
+	// Number of received beacons.
+	Received int          `json:"received"`
+	Results  ResultCounts `json:"results"`
+
+	// Outcome of the beacon selection by policy.
+	Selection  []SelectionStatistics `json:"selection"`
+	StartIsdAs IsdAs                 `json:"start_isd_as"`
+}
+
+// Number of beacons that were rejected by the beacon policies by reason.
+type BeaconStatisticsEntry_FilterReasons struct {
+	AdditionalProperties map[string]int `json:"-"`
+}
+
 // BeaconUsage defines model for BeaconUsage.
 type BeaconUsage string
 
@@ -110,6 +192,12 @@ type CheckData struct {
 	AdditionalProperties map[string]interface{} `json:"-"`
 }
 
+// DeleteResult defines model for DeleteResult.
+type DeleteResult struct {
+	// Number of deleted entries.
+	Deleted int `json:"deleted"`
+}
+
 // Health defines model for Health.
 type Health struct {
 	// List of health checks.
@@ -128,6 +216,18 @@ type Hop struct {
 	IsdAs     IsdAs `json:"isd_as"`
 }
 
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	// Whether the imported object was inserted. False indicates that it was already present.
+	Inserted bool `json:"inserted"`
+}
+
+// InterfaceSelection defines model for InterfaceSelection.
+type InterfaceSelection struct {
+	// Interface IDs.
+	Interfaces []int `json:"interfaces"`
+}
+
 // IsdAs defines model for IsdAs.
 type IsdAs string
 
@@ -140,11 +240,49 @@ type LogLevel struct {
 // Logging level
 type LogLevelLevel string
 
+// LookupStatistics defines model for LookupStatistics.
+type LookupStatistics struct {
+	Lookups []LookupStatisticsEntry `json:"lookups"`
+
+	// Duration in seconds that is covered by the statistics.
+	Window StatisticsWindow `json:"window"`
+}
+
+// LookupStatisticsEntry defines model for LookupStatisticsEntry.
+type LookupStatisticsEntry struct {
+	// Time of the last lookup.
+	LastRequest time.Time `json:"last_request"`
+
+	// ISD-AS of authenticated requesters, address otherwise. Requesters that exceed the tracking limit are aggregated as `other`.
+	Requester string `json:"requester"`
+
+	// Number of lookups.
+	Requests int          `json:"requests"`
+	Results  ResultCounts `json:"results"`
+
+	// Number of returned segments.
+	Segments int `json:"segments"`
+}
+
 // Policy defines model for Policy.
 type Policy struct {
 	ChainLifetime string `json:"chain_lifetime"`
 }
 
+// PolicyStatus defines model for PolicyStatus.
+type PolicyStatus struct {
+	// Hex encoded SHA-256 digest over all active policies and the static info configuration.
+	Digest string `json:"digest"`
+
+	// Time the files were last loaded.
+	LoadedAt   time.Time         `json:"loaded_at"`
+	Policies   []ActivePolicy    `json:"policies"`
+	StaticInfo *StaticInfoStatus `json:"static_info,omitempty"`
+}
+
+// PolicyType defines model for PolicyType.
+type PolicyType string
+
 // Problem defines model for Problem.
 type Problem struct {
 	// A human readable explanation specific to this occurrence of the problem that is helpful to locate the problem and give advice on how to proceed. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
@@ -163,6 +301,11 @@ type Problem struct {
 	Type *string `json:"type,omitempty"`
 }
 
+// ResultCounts defines model for ResultCounts.
+type ResultCounts struct {
+	AdditionalProperties map[string]int `json:"-"`
+}
+
 // Segment defines model for Segment.
 type Segment struct {
 	Expiration  time.Time `json:"expiration"`
@@ -188,6 +331,23 @@ type SegmentID string
 // SegmentIDs defines model for SegmentIDs.
 type SegmentIDs []SegmentID
 
+// SelectionStatistics defines model for SelectionStatistics.
+type SelectionStatistics struct {
+	// Number of times a beacon was a selection candidate.
+	Candidates int        `json:"candidates"`
+	Policy     PolicyType `json:"policy"`
+
+	// Number of times a beacon was selected.
+	Selected int `json:"selected"`
+}
+
+// SetAdminStateRequest defines model for SetAdminStateRequest.
+type SetAdminStateRequest struct {
+	// Time for which segments over a drained interface are still registered. Only used if the state is drained. Defaults to 0s.
+	GracePeriod *string    `json:"grace_period,omitempty"`
+	State       AdminState `json:"state"`
+}
+
 // Signer defines model for Signer.
 type Signer struct {
 	AsCertificate Certificate `json:"as_certificate"`
@@ -207,6 +367,18 @@ type StandardError struct {
 	Error string `json:"error"`
 }
 
+// StaticInfoStatus defines model for StaticInfoStatus.
+type StaticInfoStatus struct {
+	// Hex encoded SHA-256 digest of the configuration.
+	Digest string `json:"digest"`
+
+	// File the configuration is loaded from.
+	File string `json:"file"`
+}
+
+// Duration in seconds that is covered by the statistics.
+type StatisticsWindow int
+
 // Status defines model for Status.
 type Status string
 
@@ -290,6 +462,39 @@ type GetCertificatesParams struct {
 // SetLogLevelJSONBody defines parameters for SetLogLevel.
 type SetLogLevelJSONBody LogLevel
 
+// TriggerOriginationJSONBody defines parameters for TriggerOrigination.
+type TriggerOriginationJSONBody InterfaceSelection
+
+// TriggerPropagationJSONBody defines parameters for TriggerPropagation.
+type TriggerPropagationJSONBody InterfaceSelection
+
+// DeleteBeaconsParams defines parameters for DeleteBeacons.
+type DeleteBeaconsParams struct {
+	// Identifiers of the beacons to delete. Identifiers that are shorter than a full segment ID are treated as prefix.
+	SegmentId *SegmentIDs `json:"segment_id,omitempty"`
+
+	// Start ISD-AS of beacons. The address can include wildcards (0) both for the ISD and AS identifier.
+	StartIsdAs *IsdAs `json:"start_isd_as,omitempty"`
+
+	// Ingress interface id.
+	IngressInterface *int `json:"ingress_interface,omitempty"`
+}
+
+// SetInterfaceAdminStateJSONBody defines parameters for SetInterfaceAdminState.
+type SetInterfaceAdminStateJSONBody SetAdminStateRequest
+
+// DeleteSegmentsParams defines parameters for DeleteSegments.
+type DeleteSegmentsParams struct {
+	// Identifiers of the segments to delete.
+	SegmentId *SegmentIDs `json:"segment_id,omitempty"`
+
+	// Start ISD-AS of segments.
+	StartIsdAs *IsdAs `json:"start_isd_as,omitempty"`
+
+	// Terminal ISD-AS of segments.
+	EndIsdAs *IsdAs `json:"end_isd_as,omitempty"`
+}
+
 // GetSegmentsParams defines parameters for GetSegments.
 type GetSegmentsParams struct {
 	// Start ISD-AS of segment.
@@ -299,6 +504,15 @@ type GetSegmentsParams struct {
 	EndIsdAs *IsdAs `json:"end_isd_as,omitempty"`
 }
 
+// GetBeaconStatisticsParams defines parameters for GetBeaconStatistics.
+type GetBeaconStatisticsParams struct {
+	// Start ISD-AS of beacons. The address can include wildcards (0) both for the ISD and AS identifier.
+	StartIsdAs *IsdAs `json:"start_isd_as,omitempty"`
+
+	// Ingress interface id.
+	IngressInterface *int `json:"ingress_interface,omitempty"`
+}
+
 // GetTrcsParams defines parameters for GetTrcs.
 type GetTrcsParams struct {
 	Isd *[]int `json:"isd,omitempty"`
@@ -308,6 +522,68 @@ type GetTrcsParams struct {
 // SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
 type SetLogLevelJSONRequestBody SetLogLevelJSONBody
 
+// TriggerOriginationJSONRequestBody defines body for TriggerOrigination for application/json ContentType.
+type TriggerOriginationJSONRequestBody TriggerOriginationJSONBody
+
+// TriggerPropagationJSONRequestBody defines body for TriggerPropagation for application/json ContentType.
+type TriggerPropagationJSONRequestBody TriggerPropagationJSONBody
+
+// SetInterfaceAdminStateJSONRequestBody defines body for SetInterfaceAdminState for application/json ContentType.
+type SetInterfaceAdminStateJSONRequestBody SetInterfaceAdminStateJSONBody
+
+// Getter for additional properties for BeaconStatisticsEntry_FilterReasons. Returns the specified
+// element and whether it was found
+func (a BeaconStatisticsEntry_FilterReasons) Get(fieldName string) (value int, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for BeaconStatisticsEntry_FilterReasons
+func (a *BeaconStatisticsEntry_FilterReasons) Set(fieldName string, value int) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]int)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for BeaconStatisticsEntry_FilterReasons to handle AdditionalProperties
+func (a *BeaconStatisticsEntry_FilterReasons) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]int)
+		for fieldName, fieldBuf := range object {
+			var fieldVal int
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error unmarshaling field %s", fieldName))
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for BeaconStatisticsEntry_FilterReasons to handle AdditionalProperties
+func (a BeaconStatisticsEntry_FilterReasons) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '%s'", fieldName))
+		}
+	}
+	return json.Marshal(object)
+}
+
 // Getter for additional properties for CheckData. Returns the specified
 // element and whether it was found
 func (a CheckData) Get(fieldName string) (value interface{}, found bool) {
@@ -361,6 +637,59 @@ func (a CheckData) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 
+// Getter for additional properties for ResultCounts. Returns the specified
+// element and whether it was found
+func (a ResultCounts) Get(fieldName string) (value int, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for ResultCounts
+func (a *ResultCounts) Set(fieldName string, value int) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]int)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for ResultCounts to handle AdditionalProperties
+func (a *ResultCounts) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]int)
+		for fieldName, fieldBuf := range object {
+			var fieldVal int
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error unmarshaling field %s", fieldName))
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for ResultCounts to handle AdditionalProperties
+func (a ResultCounts) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '%s'", fieldName))
+		}
+	}
+	return json.Marshal(object)
+}
+
 // Getter for additional properties for Topology. Returns the specified
 // element and whether it was found
 func (a Topology) Get(fieldName string) (value interface{}, found bool) {
@@ -15,6 +15,8 @@
 package cs
 
 import (
+	"time"
+
 	"google.golang.org/grpc"
 
 	beaconinggrpc "github.com/scionproto/scion/go/cs/beaconing/grpc"
@@ -23,12 +25,23 @@ import (
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/serrors"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
 	"github.com/scionproto/scion/go/pkg/hiddenpath"
 	hpgrpc "github.com/scionproto/scion/go/pkg/hiddenpath/grpc"
 	hspb "github.com/scionproto/scion/go/pkg/proto/hidden_segment"
 )
 
+const (
+	// hiddenPathGroupUpdateInterval is the interval at which the hidden path
+	// group configurations are fetched from the group owners.
+	hiddenPathGroupUpdateInterval = 5 * time.Minute
+	// hiddenPathGroupUpdateTimeout is the timeout for fetching all group
+	// configurations.
+	hiddenPathGroupUpdateTimeout = 30 * time.Second
+)
+
 // HiddenPathConfigurator can be used to configure the hidden path servers.
 type HiddenPathConfigurator struct {
 	LocalIA           addr.IA
@@ -39,24 +52,87 @@ type HiddenPathConfigurator struct {
 	FetcherConfig     segreq.FetcherConfig
 	IntraASTCPServer  *grpc.Server
 	InterASQUICServer *grpc.Server
+	// GroupStore persists the accepted versions of the groups that are owned
+	// by remote ASes, such that they cannot be rolled back across restarts.
+	// If nil, the versions are not persisted.
+	GroupStore hiddenpath.GroupStore
 }
 
 // Setup sets up the hidden paths servers using the configuration at the given
 // location. An empty location will not enable any hidden path behavior. It
 // returns the configuration for the hidden segment writer. The return value can
 // be nil if this AS isn't a writer.
+//
+// The owner of a group serves the signed group configuration to the group
+// members. The members periodically fetch the configuration of the groups they
+// don't own from the respective owner and update their view of the groups.
+//
+// Group updates can change the roles of the local AS. The servers cannot be
+// registered once the gRPC servers are running, thus the lookup and
+// registration servers are always registered and check the roles of the
+// local AS in the latest version of the groups for every request.
 func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationCfg, error) {
 	if location == "" {
 		return nil, nil
 	}
-	groups, regPolicy, err := hiddenpath.LoadConfiguration(location)
+	loaded, regPolicy, err := hiddenpath.LoadConfiguration(location)
 	if err != nil {
 		return nil, err
 	}
-	roles := groups.Roles(c.LocalIA)
-	if roles.None() {
+	if len(loaded) == 0 {
 		return nil, nil
 	}
+	groups := hiddenpath.NewSyncGroups(loaded)
+	if c.GroupStore != nil {
+		stored, err := c.GroupStore.Load()
+		if err != nil {
+			return nil, serrors.WrapStr("loading stored hidden path groups", err)
+		}
+		for _, id := range groups.Restore(stored, c.LocalIA) {
+			group, _ := groups.Group(id)
+			log.Info("Restored hidden path group", "group_id", id, "version", group.Version)
+		}
+	}
+	roles := groups.Groups().Roles(c.LocalIA)
+	log.Info("Hidden path roles", "roles", roles)
+	if roles.Owner {
+		log.Info("Starting hidden path group server")
+		hspb.RegisterHiddenPathGroupServiceServer(c.InterASQUICServer, &hpgrpc.GroupServer{
+			Groups:   groups,
+			LocalIA:  c.LocalIA,
+			Signer:   c.Signer,
+			Verifier: c.Verifier,
+		})
+	}
+	if c.ownsAll(loaded) {
+		log.Info("Not starting hidden path group updater, all groups are owned locally")
+	} else {
+		log.Info("Starting hidden path group updater")
+		current := roles
+		periodic.Start(
+			&hiddenpath.GroupUpdater{
+				LocalIA: c.LocalIA,
+				Groups:  groups,
+				Store:   c.GroupStore,
+				OnUpdate: func(updated hiddenpath.Groups) {
+					if r := updated.Roles(c.LocalIA); r != current {
+						log.Info("Hidden path roles changed", "from", current, "to", r)
+						current = r
+					}
+				},
+				Fetcher: hpgrpc.GroupFetcher{
+					Dialer:   c.Dialer,
+					Signer:   c.Signer,
+					Verifier: c.Verifier,
+					Resolver: hiddenpath.ControlServiceResolver{
+						Router: segreq.NewRouter(c.FetcherConfig),
+					},
+				},
+			},
+			hiddenPathGroupUpdateInterval,
+			hiddenPathGroupUpdateTimeout,
+		)
+	}
 	log.Info("Starting hidden path forward server")
 	hspb.RegisterHiddenSegmentLookupServiceServer(c.IntraASTCPServer, &hpgrpc.SegmentServer{
 		Lookup: hiddenpath.ForwardServer{
@@ -78,30 +154,30 @@ func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationC
 			},
 		},
 	})
-	if roles.Registry {
-		log.Info("Starting hidden path authoritative and registration server")
-		hspb.RegisterAuthoritativeHiddenSegmentLookupServiceServer(c.InterASQUICServer,
-			&hpgrpc.AuthoritativeSegmentServer{
-				Lookup:   c.localAuthServer(groups),
-				Verifier: c.Verifier,
-			})
-		hspb.RegisterHiddenSegmentRegistrationServiceServer(c.InterASQUICServer,
-			&hpgrpc.RegistrationServer{
-				Registry: hiddenpath.RegistryServer{
-					Groups: groups,
-					DB: &hiddenpath.Storer{
-						DB: c.PathDB,
-					},
-					Verifier: hiddenpath.VerifierAdapter{
-						Verifier: c.Verifier,
-					},
-					LocalIA: c.LocalIA,
+	log.Info("Starting hidden path authoritative and registration server")
+	hspb.RegisterAuthoritativeHiddenSegmentLookupServiceServer(c.InterASQUICServer,
+		&hpgrpc.AuthoritativeSegmentServer{
+			Lookup:   c.localAuthServer(groups),
+			Verifier: c.Verifier,
+		})
+	hspb.RegisterHiddenSegmentRegistrationServiceServer(c.InterASQUICServer,
+		&hpgrpc.RegistrationServer{
+			Registry: hiddenpath.RegistryServer{
+				Groups: groups,
+				DB: &hiddenpath.Storer{
+					DB: c.PathDB,
 				},
-				Verifier: c.Verifier,
+				Verifier: hiddenpath.VerifierAdapter{
+					Verifier: c.Verifier,
+				},
+				LocalIA: c.LocalIA,
 			},
-		)
-	}
-	if !roles.Writer {
+			Verifier: c.Verifier,
+		},
+	)
+	// The registration policy determines the groups for which segments are
+	// registered, the registries check that the local AS is a writer.
+	if !roles.Writer && len(regPolicy) == 0 {
 		return nil, nil
 	}
 	log.Info("Using hidden path beacon writer")
@@ -116,14 +192,22 @@ func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationC
 			RegularRegistration: beaconinggrpc.Registrar{Dialer: c.Dialer},
 			Signer:              c.Signer,
 		},
+		Groups: groups,
 	}, nil
 }
 
-func (c HiddenPathConfigurator) localAuthServer(groups hiddenpath.Groups) hiddenpath.Lookuper {
-	roles := groups.Roles(c.LocalIA)
-	if !roles.Registry {
-		return nil
+func (c HiddenPathConfigurator) ownsAll(groups hiddenpath.Groups) bool {
+	for _, group := range groups {
+		if !group.Owner.Equal(c.LocalIA) {
+			return false
+		}
 	}
+	return true
+}
+
+// localAuthServer returns the authoritative server of the local AS. It checks
+// that the local AS is a registry of the requested groups.
+func (c HiddenPathConfigurator) localAuthServer(groups *hiddenpath.SyncGroups) hiddenpath.Lookuper {
 	return hiddenpath.AuthoritativeServer{
 		Groups: groups,
 		DB: &hiddenpath.Storer{
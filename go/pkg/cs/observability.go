@@ -60,15 +60,19 @@ type Metrics struct {
 	BeaconDBQueriesTotal                   *prometheus.CounterVec
 	BeaconingOriginatedTotal               *prometheus.CounterVec
 	BeaconingPropagatedTotal               *prometheus.CounterVec
+	BeaconingPolicyReloadsTotal            *prometheus.CounterVec
 	BeaconingPropagatorInternalErrorsTotal *prometheus.CounterVec
 	BeaconingReceivedTotal                 *prometheus.CounterVec
 	BeaconingRegisteredTotal               *prometheus.CounterVec
 	BeaconingRegistrarInternalErrorsTotal  *prometheus.CounterVec
 	DiscoveryRequestsTotal                 *prometheus.CounterVec
+	GRPCThrottledRequestsTotal             *prometheus.CounterVec
+	GRPCUnauthenticatedRequestsTotal       *prometheus.CounterVec
 	PathDBQueriesTotal                     *prometheus.CounterVec
 	RenewalServerRequestsTotal             *prometheus.CounterVec
 	RenewalHandledRequestsTotal            *prometheus.CounterVec
 	RenewalRegisteredHandlers              *prometheus.GaugeVec
+	SegmentLookupCacheTotal                *prometheus.CounterVec
 	SegmentLookupRequestsTotal             *prometheus.CounterVec
 	SegmentLookupSegmentsSentTotal         *prometheus.CounterVec
 	SegmentRegistrationsTotal              *prometheus.CounterVec
@@ -100,6 +104,13 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"egress_interface", prom.LabelResult},
 		),
+		BeaconingPolicyReloadsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_beaconing_policy_reloads_total",
+				Help: "Total number of beacon policy and static info reloads.",
+			},
+			[]string{prom.LabelResult},
+		),
 		BeaconingPropagatedTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "control_beaconing_propagated_beacons_total",
@@ -142,6 +153,21 @@ func NewMetrics() *Metrics {
 			},
 			discovery.Topology{}.RequestsLabels(),
 		),
+		GRPCThrottledRequestsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_grpc_throttled_requests_total",
+				Help: "Total number of gRPC requests rejected by admission control.",
+			},
+			[]string{"method", "reason"},
+		),
+		GRPCUnauthenticatedRequestsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_grpc_unauthenticated_requests_total",
+				Help: "Total number of gRPC requests rejected because the peer is not " +
+					"authenticated.",
+			},
+			[]string{"method"},
+		),
 		PathDBQueriesTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "pathdb_queries_total",
@@ -171,6 +197,13 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"type"},
 		),
+		SegmentLookupCacheTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_segment_lookup_cache_total",
+				Help: "Total number of forwarded segment lookups by cache result.",
+			},
+			[]string{prom.LabelResult},
+		),
 		SegmentLookupRequestsTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "control_segment_lookup_requests_total",
@@ -15,8 +15,19 @@
 package cs
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
 	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/beaconing"
 	"github.com/scionproto/scion/go/cs/config"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/serrors"
 )
 
@@ -61,3 +72,211 @@ func loadPolicy(fn string, t beacon.PolicyType) (beacon.Policy, error) {
 	policy.InitDefaults()
 	return policy, nil
 }
+
+// PolicyStore is the beacon store that applies the beacon policies.
+type PolicyStore interface {
+	// UpdatePolicies replaces the given policies atomically.
+	UpdatePolicies(ctx context.Context, policies ...beacon.Policy) error
+	// Policies returns the active policies.
+	Policies() []beacon.Policy
+}
+
+// PolicyManager loads the beacon policies and the static info configuration
+// from their files and applies them to the running control service. The files
+// can be reloaded at runtime. If any of them is invalid, the reload is rejected
+// and the active configuration is kept.
+type PolicyManager struct {
+	// Core indicates whether the control service is in a core AS.
+	Core bool
+	// Files are the beacon policy files.
+	Files config.Policies
+	// StaticInfoFile is the static info configuration file.
+	StaticInfoFile string
+	// Store is the beacon store that applies the policies.
+	Store PolicyStore
+	// Reloads counts the reloads by result. If nil, no metrics are reported.
+	Reloads metrics.Counter
+
+	mu         sync.RWMutex
+	staticInfo *beaconing.StaticInfoCfg
+	loadedAt   time.Time
+}
+
+// PolicyStatus describes the active beacon policies and static info
+// configuration.
+type PolicyStatus struct {
+	// Digest is the digest over all active policies and the static info
+	// configuration.
+	Digest []byte
+	// LoadedAt is the time the files were last loaded.
+	LoadedAt time.Time
+	// Policies are the active beacon policies.
+	Policies []PolicyInfo
+	// StaticInfo is the active static info configuration. It is nil if static
+	// info is disabled.
+	StaticInfo *StaticInfoStatus
+}
+
+// PolicyInfo describes an active beacon policy.
+type PolicyInfo struct {
+	Type beacon.PolicyType
+	// File is the file the policy is loaded from. It is empty if the default
+	// policy is used.
+	File string
+	// Digest is the digest of the policy, see beacon.Policy.Digest.
+	Digest []byte
+}
+
+// StaticInfoStatus describes the active static info configuration.
+type StaticInfoStatus struct {
+	File string
+	// Digest is the SHA-256 digest of the JSON encoding of the configuration.
+	Digest []byte
+}
+
+// Init loads the static info configuration. The beacon policies must already
+// be applied to the store. If the static info configuration cannot be loaded,
+// static info is disabled.
+func (m *PolicyManager) Init() {
+	staticInfo, err := beaconing.ParseStaticInfoCfg(m.StaticInfoFile)
+	if err != nil {
+		log.Info("No static info file found. Static info settings disabled.", "err", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.staticInfo = staticInfo
+	m.loadedAt = time.Now()
+}
+
+// Reload loads and validates the beacon policies and the static info
+// configuration, and swaps them into the running control service. A missing
+// static info file disables static info.
+func (m *PolicyManager) Reload(ctx context.Context) error {
+	err := m.reload(ctx)
+	if m.Reloads != nil {
+		result := prom.Success
+		if err != nil {
+			result = prom.ErrValidate
+		}
+		m.Reloads.With(prom.LabelResult, result).Add(1)
+	}
+	logger := log.FromCtx(ctx)
+	if err != nil {
+		logger.Info("Rejected reload of beacon policies", "err", err)
+		return err
+	}
+	logger.Info("Reloaded beacon policies and static info configuration")
+	return nil
+}
+
+func (m *PolicyManager) reload(ctx context.Context) error {
+	var policies []beacon.Policy
+	if m.Core {
+		p, err := LoadCorePolicies(m.Files)
+		if err != nil {
+			return err
+		}
+		p.InitDefaults()
+		policies = []beacon.Policy{p.Prop, p.CoreReg}
+	} else {
+		p, err := LoadNonCorePolicies(m.Files)
+		if err != nil {
+			return err
+		}
+		p.InitDefaults()
+		policies = []beacon.Policy{p.Prop, p.UpReg, p.DownReg}
+	}
+	var staticInfo *beaconing.StaticInfoCfg
+	if _, err := os.Stat(m.StaticInfoFile); err == nil {
+		if staticInfo, err = beaconing.ParseStaticInfoCfg(m.StaticInfoFile); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.Store.UpdatePolicies(ctx, policies...); err != nil {
+		return err
+	}
+	m.staticInfo = staticInfo
+	m.loadedAt = time.Now()
+	return nil
+}
+
+// Run reloads the files whenever a value is received on the reload channel,
+// until the context is canceled.
+func (m *PolicyManager) Run(ctx context.Context, reload <-chan struct{}) {
+	for {
+		select {
+		case <-reload:
+			// The error is logged by Reload.
+			_ = m.Reload(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// StaticInfo returns the active static info configuration. It returns nil if
+// static info is disabled.
+func (m *PolicyManager) StaticInfo() *beaconing.StaticInfoCfg {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.staticInfo
+}
+
+// AllowIsdLoop indicates whether the active propagation policy allows ISD
+// loops. Propagation policies allow ISD loops, unless the filter disables them.
+// If no propagation policy is active, ISD loops are not allowed, as for a
+// propagator without AllowIsdLoop.
+func (m *PolicyManager) AllowIsdLoop() bool {
+	for _, p := range m.Store.Policies() {
+		if p.Type == beacon.PropPolicy && p.Filter.AllowIsdLoop != nil {
+			return *p.Filter.AllowIsdLoop
+		}
+	}
+	return false
+}
+
+// Status returns the status of the active policies and static info
+// configuration.
+func (m *PolicyManager) Status() (PolicyStatus, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	files := map[beacon.PolicyType]string{
+		beacon.PropPolicy:    m.Files.Propagation,
+		beacon.CoreRegPolicy: m.Files.CoreRegistration,
+		beacon.UpRegPolicy:   m.Files.UpRegistration,
+		beacon.DownRegPolicy: m.Files.DownRegistration,
+	}
+	status := PolicyStatus{LoadedAt: m.loadedAt}
+	total := sha256.New()
+	for _, p := range m.Store.Policies() {
+		digest, err := p.Digest()
+		if err != nil {
+			return PolicyStatus{}, serrors.WrapStr("computing policy digest", err,
+				"type", p.Type)
+		}
+		total.Write(digest)
+		status.Policies = append(status.Policies, PolicyInfo{
+			Type:   p.Type,
+			File:   files[p.Type],
+			Digest: digest,
+		})
+	}
+	if m.staticInfo != nil {
+		raw, err := json.Marshal(m.staticInfo)
+		if err != nil {
+			return PolicyStatus{}, serrors.WrapStr("encoding static info", err)
+		}
+		digest := sha256.Sum256(raw)
+		total.Write(digest[:])
+		status.StaticInfo = &StaticInfoStatus{
+			File:   m.StaticInfoFile,
+			Digest: digest[:],
+		}
+	}
+	status.Digest = total.Sum(nil)
+	return status, nil
+}
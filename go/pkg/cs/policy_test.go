@@ -0,0 +1,111 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cs_test
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/config"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/pkg/cs"
+)
+
+func TestPolicyManagerReload(t *testing.T) {
+	dir := t.TempDir()
+	propFile := filepath.Join(dir, "prop.yml")
+	staticInfoFile := filepath.Join(dir, "static.json")
+	write := func(file, content string) {
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+	}
+
+	write(propFile, "BestSetSize: 5\nFilter:\n  AllowIsdLoop: false\n")
+	store := &policyStore{}
+	reloads := metrics.NewTestCounter()
+	m := &cs.PolicyManager{
+		Core:           true,
+		Files:          config.Policies{Propagation: propFile},
+		StaticInfoFile: staticInfoFile,
+		Store:          store,
+		Reloads:        reloads,
+	}
+	m.Init()
+	assert.Nil(t, m.StaticInfo())
+	// Without propagation policy, ISD loops are not allowed.
+	assert.False(t, m.AllowIsdLoop())
+
+	require.NoError(t, m.Reload(context.Background()))
+	require.Len(t, store.policies, 2)
+	assert.Equal(t, 5, store.policies[0].BestSetSize)
+	assert.Equal(t, beacon.CoreRegPolicy, store.policies[1].Type)
+	assert.False(t, m.AllowIsdLoop())
+	initial, err := m.Status()
+	require.NoError(t, err)
+	assert.Nil(t, initial.StaticInfo)
+	require.Len(t, initial.Policies, 2)
+	assert.Equal(t, propFile, initial.Policies[0].File)
+	assert.Empty(t, initial.Policies[1].File)
+
+	write(propFile, "BestSetSize: 7\n")
+	write(staticInfoFile, `{"Note": "hello"}`)
+	require.NoError(t, m.Reload(context.Background()))
+	assert.Equal(t, 7, store.policies[0].BestSetSize)
+	// The propagation policy allows ISD loops by default.
+	assert.True(t, m.AllowIsdLoop())
+	require.NotNil(t, m.StaticInfo())
+	assert.Equal(t, "hello", m.StaticInfo().Note)
+	updated, err := m.Status()
+	require.NoError(t, err)
+	require.NotNil(t, updated.StaticInfo)
+	assert.Equal(t, staticInfoFile, updated.StaticInfo.File)
+	assert.NotEqual(t, initial.Digest, updated.Digest)
+
+	// Invalid files are rejected and the active configuration is kept.
+	write(propFile, "BestSetSize: [")
+	assert.Error(t, m.Reload(context.Background()))
+	write(propFile, "BestSetSize: 9\n")
+	write(staticInfoFile, "{")
+	assert.Error(t, m.Reload(context.Background()))
+	assert.Equal(t, 7, store.policies[0].BestSetSize)
+	assert.Equal(t, "hello", m.StaticInfo().Note)
+	rejected, err := m.Status()
+	require.NoError(t, err)
+	assert.Equal(t, updated, rejected)
+
+	assert.Equal(t, float64(2),
+		metrics.CounterValue(reloads.With(prom.LabelResult, prom.Success)))
+	assert.Equal(t, float64(2),
+		metrics.CounterValue(reloads.With(prom.LabelResult, prom.ErrValidate)))
+}
+
+type policyStore struct {
+	policies []beacon.Policy
+}
+
+func (s *policyStore) UpdatePolicies(_ context.Context, policies ...beacon.Policy) error {
+	s.policies = policies
+	return nil
+}
+
+func (s *policyStore) Policies() []beacon.Policy {
+	return s.policies
+}
@@ -70,7 +70,12 @@ type TasksConfig struct {
 	// registration is used instead.
 	HiddenPathRegistrationCfg *HiddenPathRegistrationCfg
 
-	AllowIsdLoop bool
+	// AllowIsdLoop indicates whether ISD loops are allowed when propagating
+	// beacons. If nil, ISD loops are not allowed.
+	AllowIsdLoop func() bool
+	// Stats records the beacons that are not propagated because of loops. If
+	// nil, nothing is recorded.
+	Stats beaconing.StatsRecorder
 }
 
 // Originator starts a periodic beacon origination task. For non-core ASes, no
@@ -109,6 +114,7 @@ func (t *TasksConfig) Propagator() *periodic.Runner {
 		AllInterfaces:         t.AllInterfaces,
 		PropagationInterfaces: t.PropagationInterfaces,
 		AllowIsdLoop:          t.AllowIsdLoop,
+		Stats:                 t.Stats,
 		Tick:                  beaconing.NewTick(t.PropagationInterval),
 	}
 	if t.Metrics != nil {
@@ -168,6 +174,7 @@ func (t *TasksConfig) segmentWriter(segType seg.Type,
 				Router:     t.HiddenPathRegistrationCfg.Router,
 				Discoverer: t.HiddenPathRegistrationCfg.Discoverer,
 			},
+			Groups: t.HiddenPathRegistrationCfg.Groups,
 		}
 	default:
 		writer = &beaconing.RemoteWriter{
@@ -272,6 +279,8 @@ type HiddenPathRegistrationCfg struct {
 	Router     snet.Router
 	Discoverer hiddenpath.Discoverer
 	RPC        hiddenpath.Register
+	// Groups provides the latest version of the hidden path groups.
+	Groups hiddenpath.GroupProvider
 }
 
 // Store is the interface to interact with the beacon store.
@@ -292,9 +301,13 @@ type Store interface {
 	SegmentsToRegister(ctx context.Context, segType seg.Type) ([]beacon.Beacon, error)
 	// InsertBeacon adds a verified beacon to the store, ignoring revocations.
 	InsertBeacon(ctx context.Context, beacon beacon.Beacon) (beacon.InsertStats, error)
-	// UpdatePolicy updates the policy. Beacons that are filtered by all
-	// policies after the update are removed.
+	// UpdatePolicy replaces the policy of the same type. The new policy
+	// applies to all beacons that are inserted after the update.
 	UpdatePolicy(ctx context.Context, policy beacon.Policy) error
+	// UpdatePolicies replaces the given policies atomically.
+	UpdatePolicies(ctx context.Context, policies ...beacon.Policy) error
+	// Policies returns the active policies.
+	Policies() []beacon.Policy
 	// MaxExpTime returns the segment maximum expiration time for the given policy.
 	MaxExpTime(policyType beacon.PolicyType) uint8
 }
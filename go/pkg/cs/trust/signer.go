@@ -16,6 +16,8 @@ package trust
 
 import (
 	"context"
+	"crypto/tls"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/serrors"
 	cryptopb "github.com/scionproto/scion/go/pkg/proto/crypto"
@@ -54,3 +56,26 @@ func (s RenewingSigner) SignCMS(ctx context.Context, msg []byte) ([]byte, error)
 	}
 	return signer.SignCMS(ctx, msg)
 }
+
+// LoadX509KeyPair returns the AS certificate chain and key of the latest
+// available Signer as a TLS certificate.
+func (s RenewingSigner) LoadX509KeyPair() (*tls.Certificate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	signer, err := s.SignerGen.Generate(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("failed to generate signer", err)
+	}
+	if len(signer.Chain) == 0 {
+		return nil, serrors.New("signer without certificate chain")
+	}
+	certs := make([][]byte, 0, len(signer.Chain))
+	for _, cert := range signer.Chain {
+		certs = append(certs, cert.Raw)
+	}
+	return &tls.Certificate{
+		Certificate: certs,
+		PrivateKey:  signer.PrivateKey,
+		Leaf:        signer.Chain[0],
+	}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/cs/trust"
+	"github.com/scionproto/scion/go/pkg/cs/trust/mock_trust"
+	libtrust "github.com/scionproto/scion/go/pkg/trust"
+)
+
+func TestRenewingSignerLoadX509KeyPair(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	chain := []*x509.Certificate{{Raw: []byte("as")}, {Raw: []byte("ca")}}
+
+	testCases := map[string]struct {
+		Signer    libtrust.Signer
+		GenErr    error
+		AssertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Signer:    libtrust.Signer{PrivateKey: key, Chain: chain},
+			AssertErr: assert.NoError,
+		},
+		"generation fails": {
+			GenErr:    serrors.New("internal"),
+			AssertErr: assert.Error,
+		},
+		"no chain": {
+			Signer:    libtrust.Signer{PrivateKey: key},
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gen := mock_trust.NewMockSignerGen(ctrl)
+			gen.EXPECT().Generate(gomock.Any()).Return(tc.Signer, tc.GenErr)
+			cert, err := trust.RenewingSigner{SignerGen: gen}.LoadX509KeyPair()
+			tc.AssertErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, [][]byte{[]byte("as"), []byte("ca")}, cert.Certificate)
+			assert.Equal(t, key, cert.PrivateKey)
+			assert.Equal(t, chain[0], cert.Leaf)
+		})
+	}
+}
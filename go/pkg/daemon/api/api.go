@@ -17,6 +17,7 @@ package api
 import (
 	"net/http"
 
+	"github.com/scionproto/scion/go/lib/snet/resolver"
 	cppkiapi "github.com/scionproto/scion/go/pkg/api/cppki/api"
 	segapi "github.com/scionproto/scion/go/pkg/api/segments/api"
 )
@@ -28,6 +29,13 @@ type Server struct {
 	Config         http.HandlerFunc
 	Info           http.HandlerFunc
 	LogLevel       http.HandlerFunc
+	Paths          PathFetcher
+	// Prober is used to probe paths if requested. If it is nil, probing is not
+	// supported.
+	Prober PathProber
+	// Hosts is used to resolve host names. If it is nil, host name resolution
+	// is not supported.
+	Hosts resolver.Resolver
 }
 
 // GetConfig is an indirection to the http handler.
@@ -0,0 +1,86 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/resolver"
+	api "github.com/scionproto/scion/go/pkg/api"
+)
+
+// GetHost resolves the host name to its SCION addresses.
+func (s *Server) GetHost(w http.ResponseWriter, r *http.Request, name string) {
+	if s.Hosts == nil {
+		Error(w, Problem{
+			Detail: api.StringRef("host name resolution not supported"),
+			Status: http.StatusInternalServerError,
+			Title:  "error resolving host name",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	addrs, err := s.Hosts.LookupHost(r.Context(), name)
+	if errors.Is(err, resolver.ErrNotFound) {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusNotFound,
+			Title:  "host name has no SCION address",
+			Type:   api.StringRef(api.NotFound),
+		})
+		return
+	}
+	if err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error resolving host name",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	rep := HostAddresses{Addresses: make([]string, 0, len(addrs))}
+	for _, a := range addrs {
+		rep.Addresses = append(rep.Addresses, hostAddrToAPI(a))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// hostAddrToAPI formats the address as ISD-AS,IP, the IP of IPv6 addresses is
+// enclosed in brackets.
+func hostAddrToAPI(a *snet.UDPAddr) string {
+	ip := a.Host.IP.String()
+	if a.Host.Zone != "" {
+		ip += "%" + a.Host.Zone
+	}
+	if a.Host.IP.To4() == nil {
+		ip = "[" + ip + "]"
+	}
+	return fmt.Sprintf("%s,%s", a.IA, ip)
+}
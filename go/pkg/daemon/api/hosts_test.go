@@ -0,0 +1,102 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/resolver"
+	"github.com/scionproto/scion/go/lib/snet/resolver/mock_resolver"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/daemon/api"
+)
+
+func TestGetHost(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+
+	testCases := map[string]struct {
+		Server    func(t *testing.T, ctrl *gomock.Controller) *api.Server
+		Status    int
+		Addresses []string
+	}{
+		"not supported": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{}
+			},
+			Status: http.StatusInternalServerError,
+		},
+		"not found": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				r := mock_resolver.NewMockResolver(ctrl)
+				r.EXPECT().LookupHost(gomock.Any(), "server").Return(nil,
+					serrors.WithCtx(resolver.ErrNotFound, "name", "server"))
+				return &api.Server{Hosts: r}
+			},
+			Status: http.StatusNotFound,
+		},
+		"resolver error": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				r := mock_resolver.NewMockResolver(ctrl)
+				r.EXPECT().LookupHost(gomock.Any(), "server").Return(nil,
+					serrors.New("internal"))
+				return &api.Server{Hosts: r}
+			},
+			Status: http.StatusInternalServerError,
+		},
+		"valid": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				r := mock_resolver.NewMockResolver(ctrl)
+				r.EXPECT().LookupHost(gomock.Any(), "server").Return(
+					[]*snet.UDPAddr{
+						{IA: ia, Host: &net.UDPAddr{IP: net.IP{10, 0, 0, 1}}},
+						{IA: ia, Host: &net.UDPAddr{IP: net.ParseIP("f00d::1")}},
+					}, nil,
+				)
+				return &api.Server{Hosts: r}
+			},
+			Status:    http.StatusOK,
+			Addresses: []string{"1-ff00:0:110,10.0.0.1", "1-ff00:0:110,[f00d::1]"},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := httptest.NewRequest(http.MethodGet, "/hosts/server", nil)
+			rr := httptest.NewRecorder()
+			api.Handler(tc.Server(t, ctrl)).ServeHTTP(rr, req)
+			require.Equal(t, tc.Status, rr.Result().StatusCode, rr.Body.String())
+			if tc.Status != http.StatusOK {
+				return
+			}
+			var rep api.HostAddresses
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rep))
+			assert.Equal(t, tc.Addresses, rep.Addresses)
+		})
+	}
+}
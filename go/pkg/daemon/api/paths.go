@@ -0,0 +1,196 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	api "github.com/scionproto/scion/go/pkg/api"
+	"github.com/scionproto/scion/go/pkg/app/path"
+	"github.com/scionproto/scion/go/pkg/pathprobe"
+)
+
+// probeTimeout is the time the paths are probed for before the paths that did
+// not respond are reported as timed out.
+const probeTimeout = 2 * time.Second
+
+// PathFetcher fetches the paths between two ASes.
+type PathFetcher interface {
+	GetPaths(ctx context.Context, src, dst addr.IA, refresh bool) ([]snet.Path, error)
+}
+
+// PathProber determines the liveness of paths. The returned map is keyed with
+// pathprobe.PathKey.
+type PathProber interface {
+	GetStatuses(ctx context.Context, dst addr.IA,
+		paths []snet.Path) (map[string]pathprobe.Status, error)
+}
+
+// GetPaths lists the paths to the destination ISD-AS, optionally filtered by a
+// path policy or sequence, and probes them if requested.
+func (s *Server) GetPaths(w http.ResponseWriter, r *http.Request, isdAs IsdAs,
+	params GetPathsParams) {
+
+	dst, err := addr.IAFromString(string(isdAs))
+	if err != nil {
+		badRequest(w, "malformed path parameter", serrors.WrapStr("parsing isd-as", err))
+		return
+	}
+	var filters []func([]snet.Path) []snet.Path
+	if params.Policy != nil {
+		var policy pathpol.Policy
+		if err := json.Unmarshal([]byte(*params.Policy), &policy); err != nil {
+			badRequest(w, "malformed query parameters", serrors.WrapStr("parsing policy", err))
+			return
+		}
+		filters = append(filters, policy.Filter)
+	}
+	if params.Sequence != nil {
+		seq, err := pathpol.NewSequence(*params.Sequence)
+		if err != nil {
+			badRequest(w, "malformed query parameters", serrors.WrapStr("parsing sequence", err))
+			return
+		}
+		filters = append(filters, seq.Eval)
+	}
+	probe := params.Probe != nil && *params.Probe
+	if probe && s.Prober == nil {
+		badRequest(w, "malformed query parameters", serrors.New("probing not supported"))
+		return
+	}
+
+	refresh := params.Refresh != nil && *params.Refresh
+	paths, err := s.Paths.GetPaths(r.Context(), addr.IA{}, dst, refresh)
+	if err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error fetching paths",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	for _, filter := range filters {
+		paths = filter(paths)
+	}
+	path.Sort(paths)
+
+	var statuses map[string]pathprobe.Status
+	if probe {
+		ctx, cancel := context.WithTimeout(r.Context(), probeTimeout)
+		defer cancel()
+		statuses, err = s.Prober.GetStatuses(ctx, dst, pathprobe.FilterEmptyPaths(paths))
+		if err != nil {
+			Error(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusInternalServerError,
+				Title:  "error probing paths",
+				Type:   api.StringRef(api.InternalError),
+			})
+			return
+		}
+	}
+
+	rep := Paths{Paths: make([]Path, 0, len(paths))}
+	for _, p := range paths {
+		rp := pathToAPI(p)
+		if status, ok := statuses[pathprobe.PathKey(p)]; ok {
+			rp.Liveness = &PathLiveness{
+				Status: PathLivenessStatus(strings.ToLower(string(status.Status))),
+			}
+			if status.AdditionalInfo != "" {
+				rp.Liveness.Info = api.StringRef(status.AdditionalInfo)
+			}
+			if status.LocalIP != nil {
+				rp.Liveness.LocalIp = api.StringRef(status.LocalIP.String())
+			}
+		}
+		rep.Paths = append(rep.Paths, rp)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+func pathToAPI(p snet.Path) Path {
+	meta := p.Metadata()
+	if meta == nil {
+		meta = &snet.PathMetadata{}
+	}
+	fingerprint := "local"
+	if len(meta.Interfaces) > 0 {
+		fingerprint = snet.Fingerprint(p).String()
+	}
+	rp := Path{
+		Fingerprint: fingerprint,
+		Hops:        make([]Hop, 0, len(meta.Interfaces)),
+		Expiry:      meta.Expiry,
+		Mtu:         int(meta.MTU),
+		Latency:     make([]int64, 0, len(meta.Latency)),
+		Bandwidth:   make([]int64, 0, len(meta.Bandwidth)),
+	}
+	if nh := p.UnderlayNextHop(); nh != nil {
+		rp.NextHop = api.StringRef(nh.String())
+	}
+	for _, intf := range meta.Interfaces {
+		rp.Hops = append(rp.Hops, Hop{Interface: int(intf.ID), IsdAs: IsdAs(intf.IA.String())})
+	}
+	for _, l := range meta.Latency {
+		if l < 0 {
+			rp.Latency = append(rp.Latency, -1)
+			continue
+		}
+		rp.Latency = append(rp.Latency, l.Microseconds())
+	}
+	for _, b := range meta.Bandwidth {
+		rp.Bandwidth = append(rp.Bandwidth, int64(b))
+	}
+	return rp
+}
+
+func badRequest(w http.ResponseWriter, title string, err error) {
+	Error(w, Problem{
+		Detail: api.StringRef(err.Error()),
+		Status: http.StatusBadRequest,
+		Title:  title,
+		Type:   api.StringRef(api.BadRequest),
+	})
+}
+
+// Error creates an detailed error response.
+func Error(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	// no point in catching error here, there is nothing we can do about it anymore.
+	enc.Encode(p)
+}
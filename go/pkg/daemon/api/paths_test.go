@@ -0,0 +1,228 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/daemon/api"
+	"github.com/scionproto/scion/go/pkg/daemon/api/mock_api"
+	"github.com/scionproto/scion/go/pkg/pathprobe"
+)
+
+func TestGetPaths(t *testing.T) {
+	dst := xtest.MustParseIA("1-ff00:0:110")
+	expiry := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	newPath := func(raw byte, ifID common.IFIDType, via string) snet.Path {
+		return snetpath.Path{
+			Dst:     dst,
+			SPath:   spath.Path{Raw: []byte{raw}},
+			NextHop: &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30041},
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: xtest.MustParseIA(via), ID: ifID},
+					{IA: dst, ID: 2},
+				},
+				MTU:       1472,
+				Expiry:    expiry,
+				Latency:   []time.Duration{time.Duration(ifID) * time.Millisecond},
+				Bandwidth: []uint64{uint64(ifID) * 1000},
+			},
+		}
+	}
+	// The handler sorts the paths in place, every test case gets its own copy.
+	newPaths := func() []snet.Path {
+		return []snet.Path{
+			newPath(2, 3, "1-ff00:0:112"),
+			newPath(1, 1, "1-ff00:0:111"),
+		}
+	}
+	paths := newPaths()
+
+	testCases := map[string]struct {
+		Server    func(t *testing.T, ctrl *gomock.Controller) *api.Server
+		ISDAS     string
+		Query     url.Values
+		Status    int
+		Assertion func(t *testing.T, rep api.Paths)
+	}{
+		"invalid isd-as": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Paths: mock_api.NewMockPathFetcher(ctrl)}
+			},
+			ISDAS:  "1-ff00",
+			Status: http.StatusBadRequest,
+		},
+		"malformed policy": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Paths: mock_api.NewMockPathFetcher(ctrl)}
+			},
+			ISDAS:  "1-ff00:0:110",
+			Query:  url.Values{"policy": {`{"acl": 1}`}},
+			Status: http.StatusBadRequest,
+		},
+		"malformed sequence": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Paths: mock_api.NewMockPathFetcher(ctrl)}
+			},
+			ISDAS:  "1-ff00:0:110",
+			Query:  url.Values{"sequence": {"1-ff00:0:110#x"}},
+			Status: http.StatusBadRequest,
+		},
+		"probe without prober": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				return &api.Server{Paths: mock_api.NewMockPathFetcher(ctrl)}
+			},
+			ISDAS:  "1-ff00:0:110",
+			Query:  url.Values{"probe": {"true"}},
+			Status: http.StatusBadRequest,
+		},
+		"fetch error": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				f := mock_api.NewMockPathFetcher(ctrl)
+				f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, dst, false).
+					Return(nil, serrors.New("test error"))
+				return &api.Server{Paths: f}
+			},
+			ISDAS:  "1-ff00:0:110",
+			Status: http.StatusInternalServerError,
+		},
+		"all paths": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				f := mock_api.NewMockPathFetcher(ctrl)
+				f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, dst, true).Return(newPaths(), nil)
+				return &api.Server{Paths: f}
+			},
+			ISDAS:  "1-ff00:0:110",
+			Query:  url.Values{"refresh": {"true"}},
+			Status: http.StatusOK,
+			Assertion: func(t *testing.T, rep api.Paths) {
+				require.Len(t, rep.Paths, 2)
+				p := rep.Paths[0]
+				assert.Equal(t, snet.Fingerprint(paths[1]).String(), p.Fingerprint)
+				assert.Equal(t, []api.Hop{
+					{IsdAs: "1-ff00:0:111", Interface: 1},
+					{IsdAs: "1-ff00:0:110", Interface: 2},
+				}, p.Hops)
+				assert.Equal(t, 1472, p.Mtu)
+				assert.Equal(t, expiry, p.Expiry.UTC())
+				assert.Equal(t, []int64{1000}, p.Latency, "latency in microseconds")
+				assert.Equal(t, []int64{1000}, p.Bandwidth)
+				require.NotNil(t, p.NextHop)
+				assert.Equal(t, "127.0.0.1:30041", *p.NextHop)
+				assert.Nil(t, p.Liveness)
+			},
+		},
+		"sequence": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				f := mock_api.NewMockPathFetcher(ctrl)
+				f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, dst, false).Return(newPaths(), nil)
+				return &api.Server{Paths: f}
+			},
+			ISDAS:  "1-ff00:0:110",
+			Query:  url.Values{"sequence": {"1-ff00:0:112 1-ff00:0:110"}},
+			Status: http.StatusOK,
+			Assertion: func(t *testing.T, rep api.Paths) {
+				require.Len(t, rep.Paths, 1)
+				assert.Equal(t, api.IsdAs("1-ff00:0:112"), rep.Paths[0].Hops[0].IsdAs)
+			},
+		},
+		"policy": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				f := mock_api.NewMockPathFetcher(ctrl)
+				f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, dst, false).Return(newPaths(), nil)
+				return &api.Server{Paths: f}
+			},
+			ISDAS:  "1-ff00:0:110",
+			Query:  url.Values{"policy": {`{"acl": ["- 1-ff00:0:112", "+"]}`}},
+			Status: http.StatusOK,
+			Assertion: func(t *testing.T, rep api.Paths) {
+				require.Len(t, rep.Paths, 1)
+				assert.Equal(t, api.IsdAs("1-ff00:0:111"), rep.Paths[0].Hops[0].IsdAs)
+			},
+		},
+		"probe": {
+			Server: func(t *testing.T, ctrl *gomock.Controller) *api.Server {
+				f := mock_api.NewMockPathFetcher(ctrl)
+				f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, dst, false).Return(newPaths(), nil)
+				p := mock_api.NewMockPathProber(ctrl)
+				p.EXPECT().GetStatuses(gomock.Any(), dst, gomock.Any()).Return(
+					map[string]pathprobe.Status{
+						pathprobe.PathKey(paths[1]): {
+							Status:  pathprobe.StatusAlive,
+							LocalIP: net.IP{127, 0, 0, 1},
+						},
+						pathprobe.PathKey(paths[0]): {
+							Status:         pathprobe.StatusSCMP,
+							AdditionalInfo: "external interface down",
+						},
+					}, nil,
+				)
+				return &api.Server{Paths: f, Prober: p}
+			},
+			ISDAS:  "1-ff00:0:110",
+			Query:  url.Values{"probe": {"true"}},
+			Status: http.StatusOK,
+			Assertion: func(t *testing.T, rep api.Paths) {
+				require.Len(t, rep.Paths, 2)
+				require.NotNil(t, rep.Paths[0].Liveness)
+				assert.Equal(t, api.PathLivenessStatusAlive, rep.Paths[0].Liveness.Status)
+				require.NotNil(t, rep.Paths[0].Liveness.LocalIp)
+				assert.Equal(t, "127.0.0.1", *rep.Paths[0].Liveness.LocalIp)
+				require.NotNil(t, rep.Paths[1].Liveness)
+				assert.Equal(t, api.PathLivenessStatusScmp, rep.Paths[1].Liveness.Status)
+				require.NotNil(t, rep.Paths[1].Liveness.Info)
+				assert.Equal(t, "external interface down", *rep.Paths[1].Liveness.Info)
+			},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := url.URL{Path: "/paths/" + tc.ISDAS, RawQuery: tc.Query.Encode()}
+			req := httptest.NewRequest(http.MethodGet, u.String(), nil)
+			rr := httptest.NewRecorder()
+			api.Handler(tc.Server(t, ctrl)).ServeHTTP(rr, req)
+			require.Equal(t, tc.Status, rr.Result().StatusCode, rr.Body.String())
+			if tc.Assertion == nil {
+				return
+			}
+			var rep api.Paths
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rep))
+			tc.Assertion(t, rep)
+		})
+	}
+}
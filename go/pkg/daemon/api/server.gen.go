@@ -25,6 +25,9 @@ type ServerInterface interface {
 	// Prints the TOML configuration file.
 	// (GET /config)
 	GetConfig(w http.ResponseWriter, r *http.Request)
+	// Resolve a host name to SCION addresses
+	// (GET /hosts/{name})
+	GetHost(w http.ResponseWriter, r *http.Request, name string)
 	// Basic information page about the control service process.
 	// (GET /info)
 	GetInfo(w http.ResponseWriter, r *http.Request)
@@ -34,6 +37,9 @@ type ServerInterface interface {
 	// Set logging level
 	// (PUT /log/level)
 	SetLogLevel(w http.ResponseWriter, r *http.Request)
+	// List the SCION paths to a destination
+	// (GET /paths/{isd-as})
+	GetPaths(w http.ResponseWriter, r *http.Request, isdAs IsdAs, params GetPathsParams)
 	// List the SCION path segments
 	// (GET /segments)
 	GetSegments(w http.ResponseWriter, r *http.Request, params GetSegmentsParams)
@@ -182,6 +188,32 @@ func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Requ
 	handler(w, r.WithContext(ctx))
 }
 
+// GetHost operation middleware
+func (siw *ServerInterfaceWrapper) GetHost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameter("simple", false, "name", chi.URLParam(r, "name"), &name)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter name: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHost(w, r, name)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetInfo operation middleware
 func (siw *ServerInterfaceWrapper) GetInfo(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -227,6 +259,79 @@ func (siw *ServerInterfaceWrapper) SetLogLevel(w http.ResponseWriter, r *http.Re
 	handler(w, r.WithContext(ctx))
 }
 
+// GetPaths operation middleware
+func (siw *ServerInterfaceWrapper) GetPaths(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd-as" -------------
+	var isdAs IsdAs
+
+	err = runtime.BindStyledParameter("simple", false, "isd-as", chi.URLParam(r, "isd-as"), &isdAs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter isd-as: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPathsParams
+
+	// ------------- Optional query parameter "policy" -------------
+	if paramValue := r.URL.Query().Get("policy"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "policy", r.URL.Query(), &params.Policy)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter policy: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sequence" -------------
+	if paramValue := r.URL.Query().Get("sequence"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sequence", r.URL.Query(), &params.Sequence)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter sequence: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "refresh" -------------
+	if paramValue := r.URL.Query().Get("refresh"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "refresh", r.URL.Query(), &params.Refresh)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter refresh: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "probe" -------------
+	if paramValue := r.URL.Query().Get("probe"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "probe", r.URL.Query(), &params.Probe)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter probe: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaths(w, r, isdAs, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetSegments operation middleware
 func (siw *ServerInterfaceWrapper) GetSegments(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -500,6 +605,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/config", wrapper.GetConfig)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/hosts/{name}", wrapper.GetHost)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/info", wrapper.GetInfo)
 	})
@@ -509,6 +617,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/log/level", wrapper.SetLogLevel)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/paths/{isd-as}", wrapper.GetPaths)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/segments", wrapper.GetSegments)
 	})
@@ -18,56 +18,76 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaWXPjNhL+K11MHjYV6vKxifUmy5qJKnO4LCVbldjrgsiWiBkQYADQY61X/30LAEXx",
-	"kkV5kuxka6fmwSKBRuPrr4E++OQFIk4ER66VN3zyJKpEcIX2xyUJb/C3FJU2vwLBNXL7J0kSRgOiqeC9",
-	"D0pw80wFEcbE/PW1xKU39L7q7UT33FvVm2nCQyLDiZRCepvNxvdCVIGkiRHmDc2aILNFzdtsopE7Rqnp",
-	"0qyL5mciRWKeOF1DqjTlq5SqCMN7TmI7Rq8T9Iae0pLylbfxParCeyftOS2nKhwpM1yliw8Y6PuPuL4n",
-	"bCXMRHwkccKM2Mn4ajby/PoqxWk0PIiJG/0jrqdXZvYDYTSken1o3s/bcQYngxmVGHrDX5uwyHdeEN+w",
-	"vZrqd76nqba7LcAPRZvl+xd2ptnBOCKU121ElUpRHtpW0cw7LI+aVcFjK8LfarBnV4FRu9XeLiU1qtQ2",
-	"eNDWdrYzczs0qlRsPf6zWUTDHR120BUEF1C0eEDwIiynV2WvWpLzU9I/I57vLYWMifaGXoSPncy9njPd",
-	"NERuHlk9a175g0gaTMY1yiUJsKTE2Uk+3wxYoTz68KiiuXW/3YIF/K6JjkDhKkauIRJJE1hObgmqQWe5",
-	"7PeH/eFg0Pd8LyFaozSn6D9vb8NvO3/7lXSW/c7F3dPAP9sMv3k62ZQfffNvM+7rAqbT2VVnNDsA5Bux",
-	"eoMPyOposu3j8qH+RqxWlK/AvfY95GlsDypcpCuLydKcPWgvhTu/sMPsTUWFCrZO7F0DZtdSLBjGDdcF",
-	"akIbNB1BlMaEg0QSkgVDwMeEEW6vOlAJBoZwoAXoiCoQQZBKiTxAEEvQEULiFgQdEQ1UQYQsWabMzGDC",
-	"MrU4ivAQVvQBgYQP1AjhEIlPZnAiRYAYduEfkmqNHCiHCV8xqiI7K9dvKSQgX1GOKJUPqUoJY2vgQoNK",
-	"qcbQjuCCg8Yg4jQgDJQmHzESLESprDQz2qjH6L8w7HpFA4wF5xjY7WsBIdFkQRSCpjGGIFLdxA/KlSbc",
-	"uVQV3p9upiBxiQ41B9OWbMqCk6O8F10fsLvqwmINJAwNrwgsJXHOkwuTICSodNFJjG9ZixXMs06wC2/J",
-	"GhYIqcKwYiAphHaLUpVPotzpJ1IZIAQixDJUvWxgL8gx61hKf6XFR+Qdw+WOMVzHotdx6OVnXCppJ0em",
-	"MarQRKeqDuo8QvhhPr8GN8BqBivkKImx/2Jt1RaSrigHhfIBpSXF8xQu7e28f+p7MXmksXHc84sL34sp",
-	"d78G/X7TYZmdKHUGqEhIQ844JnJd8xtrmP826WcorT/+xMkDocys2WQQ98DscElSZmxIFiLVwwUj/KPn",
-	"t+F+yulvKbJ11QmKeIDgZoBjn43CH3UBtwcaYgij62kX3ieJyMhc9CR3elEON6/Gne++73/nA7WnE0eq",
-	"I5QgMRBxjDx0cxcmwNsqagE3eCWCcm1eE3dGdnJzhCJIjfO5dbiQsGJiYU3i9pfRrWLmds5zhItUwz7n",
-	"L1sqNt0PM3fl1u8HfEyoJM5yTzsFQqLRem8THSKRuJBCY3wwSjDBSE4hj0hJ1vboPJwtOJVdDMmI0vdp",
-	"YtQK2ytqnitN4qTtlKbIcCfEL6JV0SlDpRDqzMbT9+8gKQY8B6LEbMd7Ym7k4f2RWd2xICNf6aghqrHP",
-	"t56YbabE6kHTwag0kfr+s2JJG5kXxfhFGHKNawH6i7GvxeiLs/Pw7Cw8GKNn8w8ElPkq7f2nZKGY8qmb",
-	"NHhuaeXVHa5ckKiza/u4bHo7GmJUiqwO+0se19bhLab+JYS/v4DLCzi7gPEJnLwy/y/GcHUF/Ss4GcH5",
-	"dzC6gKsJfD+xr87h1Sn0L2DQh6tB0SgqIQGGnbJtqvDPb8b1nZNUR0JSc6g/4D3JSkKtbJM7WhXsQMjf",
-	"S1TJHk2FnoM+Pr8Z/071FuuPhbLKbpt+E4xl5QtOOr8ZH/LH+c34xbWHbMN15WvnRDtFHGXLWpjk4J6n",
-	"8cJVNp4/CakKW2TdCiUlrEnoaX14Pev2/JJSVXkV+JvOqd2mfy4wpbxvLvQ9WeqKgt5J/+Sk0x90+mfz",
-	"/sXw/GJ4evpL0T2fvaaNzAUuhcSa0MELhVbgKazgF7ZQwGS7Y0hQUhHWQdlssvS9dkZug+jR9TSP/9wF",
-	"dEUwdqwqxQTusRlv3AmlcnL63X53YPAQCXKSUG/onXb73RNX8Igs/L1C6ck+WKFuuLCp0i6ItimPZmsg",
-	"gfHLeuVKufCcSISPXHziWUh9y038LQWzeRQNsAsm+5KoUqYhINzEzkvKNEqXebl6ShdepdJE2rGQ6N9y",
-	"wdEOTohSQCAhUtMgZURmQbaJ9WmMQDR8imgQOaV3Ot7yTEmjnz14gCigPEl1F0awEIIh4Vt98hxBC5Co",
-	"U8mBMHbLi5j5IHFFZMhQqSyioTIzuvlt0iBLhO6tMZyhvo33pqE39F6jHhfxN4aRJEZtrtzhr08eNej/",
-	"lqI0p6Orze8KYu0aB3kk1CzNgnBPdEleO49oFkgYK8nKpmXQepvNnV9ulpz0+0d1SVpdf4Vic+0OrPdO",
-	"LL9FQx3W3qBnzyqYpV/fHtfO2dbXGpSZckfMUjPHJf0lV6zr6nuarAxxvCBJPlLvzkwteXjvyQ7t0HCz",
-	"19lf454F7GFEbN2NQ1aBPszqPaQ2J9CONFutvOIxq2WKbVmetwc+m14HV2myWa2i/sXxZq9Vj2NNb8HE",
-	"4gXUQR4Ic5ISBdeTt7BYa1RgZL2MVJdGiy+aWI+dBOPOkrJKDNIx/y4nr6fvYDy5mU9fTcej+cQ+veWj",
-	"WZFI3W73lts3k3dXDaOfFTUeHSPKa0Fpa66/Dq+dunvILfiSrgo0rnPNjThoco2PupewrGtbu/Xyy7K2",
-	"q1kaBKjUMmXwfrt4AdwmrHJVeoXvC8poXEvKtSuEzt+/fQNuo6kTb+IrWzzMIRGxCScdJttYdB8iU9dQ",
-	"+mvhcUkUDcBszQQ0BoOErBBstTmvCheiUtc+UmovSkysenmvbh9UeZvvD7yK8jX+NCyNp7FKP7KGke8l",
-	"aQMoswooVv6lCNd/Ch7bLmpx/d1NsPmfstKsjZUMk7M6Zoukr1783JPkNeV2qim5s2M1kdr2R5CHMJpV",
-	"y8Ew5SrBwKlAeUgfaJgStlPBBQ4mMQTXlMYQHih+6jbFDrPtbmtBQ8UoVqusmS+WjcXp6tcDTVlQpch8",
-	"dKpWaVSijCknDJ5R6mSr1MlepUql7uNU+lOStlK/4oi0LSY6iAzhG5ja/XIzuAZtC86aPap4a+8p+6tV",
-	"Cld4anAiu08E6ms/5zZ1r8HHhIkQveGSMIV+U+i9U/TFwXehvWH7P2vLdEUt5f/QTG+78aZzu94LKgQY",
-	"3S82Qj7cw2rPvHZpYMOKe/PA5+jXnO399SjYIie8Hs1/gNnk9dvJu3mWm1kUr4mOtn25cjLXMMNrRdov",
-	"Op3bp+8+lmoZtIhkGNGodCZ8LlOl4UYIDeNimuQiCyRBZOKAPZHO8dXsLry3+hDG1j4QxmB+M86jowwN",
-	"DIFypZHY2rH9fKWgt+CoGv1kbnbfzkHqxeRSLJDf0w3fJVUaiVtfMEef92VXg/Pm3xFBRbbsgqE1VPfz",
-	"Q/OchkbensqE4XGPqvCJqnDTWTwtiMJNRz253tum5ZG7j9p7CmtzGbQqpjmy7D9Hn+1H5gwpyzQbbCd0",
-	"0FqmA6ud1KZW6B8ZWMxvxk2sm9+Mu79Pip4R7GX8OuZe30ey7d2+verNHe+u+L3sa13O/T8DXxhXzG/G",
-	"WXDwy4fRp/cfRn9/O598mlZiid0or5Gi1Zjh82m6t0rrvl942HIhlcwbepHWybDXe4qE0pvhUyKk3vRI",
-	"QnsPA/shiaTmvLaImSHlT0ztJ6v28cb3zNTy69PB4PzEuOZdrk2V/2NbP7F9eXx0H4wu1pk3ZIGAvZ+3",
-	"vQdXbqmn85MHlGttM1aJzH5rrEVz8loNZY+UNr6+/nFq8mPLx6JuFufN3eY/AQAA//9BuTFdyDYAAA==",
+	"H4sIAAAAAAAC/+xbe3MbN5L/KqjJ/nFbGT4lxRar7g+akmNe/FCJzN7VWjoVOGiSiGeACYCRzNPxu181",
+	"MDOcp0jKseNcrZIqkxhMo9H49QPdzUcvkFEsBQijvdGjp0DHUmiwX15Rdg2/J6ANfgukMCDsRxrHIQ+o",
+	"4VL0ftNS4JgO1hBR/PQ3BUtv5P3Q25Huuae6NzNUMKrYpVJSedvt1vcY6EDxGIl5I1yTqHRRfJq+iHQn",
+	"oAxf4rqAX2MlYxxxvDKuDRerhOs1sDtBIzvHbGLwRp42iouVt/U9rtkd1fu4nGo21jhdJ4vfIDB3n2Bz",
+	"R8OVxBfhM43iEMleTi5mY8+vr1J8jbO9MnGzf4HN9ALfvqchZ9xs9r33j2weygllxhUwb/SxSRb5zgvk",
+	"G7ZXY/3W9ww3drcF8ZPimeX7l/ZN3MFkTbmonxHXOgG1b1vFY97J8qi3KvLISPgZBy27CpDtg/b2SnFY",
+	"Nmxw71nbt90xHyaNKhQPnv/FKOLM8+uiKxAuSNHKgwTPkuX0oqxVS3p2Qvun1PO9pVQRNd7IW8PnTqpe",
+	"Tx3dlIHAIVC71XZa+UbGDUcmDKglDaDExOkwfx8nrEAdbTyq0szUb7dgQX5X1KyJhlUEwpC1jJuE9UZq",
+	"M2ZMgU7Nc3kjtPiIG4jsh7JxnU2mH96TdCaRS2LWQNZSG8KF/YwCJ9PZRWc886dXXc8vnMugs1z2+6P+",
+	"aDDo+4N+F/8bNMk5HaBK0TqqdmwWtl/iCyxn1PLVbRKEE/DosYU3z/diagwo3PF/39ywHzv/9pF2lv3O",
+	"+e3jwD/djv7+ONyWh/7+vzjvbwVwOSHsQdRbuXoL9xDWTyPMhssH8FauVlysiHvseyCSyFpsWCQrC46l",
+	"xGHrHW+L0k+fVFioCNeRvW2QGQKszuWCCvbAmVnXOX2VPUJo/LLgpqfJAswDgCCBFBqCxPB7IDmcczzF",
+	"1Ky75FfxScgHQe5pmIAmVOFUZpWVkQdu1qRfgtfHQd/++X3/1H649Xcozu0AF+anU69JN8ugQ8IxV5v6",
+	"vi5x3EYuxPAISkwXLQ6jBjo4o+ncl1ysQMWKC1Nf4fXuYZG6Txgofg+MLJWM7HBBdtwQo+g9KA26rHZn",
+	"sDwPzgHO4CXtL0/ZeTBgLwM4Dc7oORvCYnFKzyEYLk7ZT8GADdgZHSxeLPvLk2WfDhbDoIn/tYwbrMN0",
+	"x07GCyOLTUk++ZE8ZQHR0DYcSUgNiKDhTN66B4i0iAdKagikYH803jqDMuBOEG2dgT8YfjnaQn4PAvRe",
+	"yaAavs3mbn0vMkldHO/oZx4lER6C0BHXGsGaCF6CEwprsTEVtAxOXzR6LgGfzd1axvXFfhUMVEg3Va+w",
+	"5EobspCKgSJKJgYUkaKEhYL5Hb5w7uDF6GTQ7w/3GqqiAqVwzFXWSWUHF79gpgo+41KwjpEdEIw495Gx",
+	"1Wj73hYOqBoALGVdLGPGOH6kIeHCAQJPgS5kYqwQtKEm0d0m7QplQMM7HjeZ/4CGZHqVC9usqSEPVJME",
+	"dW0pFYmVXHCxapFv03KOE1ws8yYU4YhTeQQyQQnrIIo930uckni3NTLViNnRLIj7GnQSWgSmHJag0Cjz",
+	"BmHH2fBBdgSJ7A0oHMlKLKWJkYRi+Gm4sEfXzKWSixCihtskGMob/PeYrJOICqKAMroIgcDnOKRuBaJj",
+	"CDAexbXNmmsigyBRCkSwczNuQXfuXJM1hPEyCfENRI2B0iwqGFmh0aPsniMRQdbyASfHSgYArEv+U3Fj",
+	"QKA1uBSrkOu1fSvnDyEFYsUFgNI+SXRCw3BDhDREJ9ykoBOo2RCsBUd8akM/wVqGDJS21HC2BTX/H2Bl",
+	"ZE6kEBA4dyoJo4YuqAbrWhlx2KvfwYU2VATQJN5fr6dEwRKc1JyYshBMO8XLpNwqXZ9Ad9VFz0UZQ6RS",
+	"slTUxdY5MUWkIjpZdKwxNbJIgCDLXfKObsgCnGqWD0hJmRpjrvOX0hBay0QFQALJoCyqXjqxF+Qy69hA",
+	"7wcjP4HooJ3p4MHZsIN1nPRyZ5Qo3skl87QdKAt1vgbyZj6/Sk2W5YysQICiZufgpeIrLogGdQ/KguJp",
+	"CJf2dtY/8b3IuS1vdHZ+7nsRF+7boN9v9KBOW+sI0GupEJxRRNWmpjf2YP5s0M9AWX38VdB7ykNcs/0e",
+	"hDtc0iTEM7TuY7QIqfjk+YdgPxH89wTCTVUJivIgUoSbDH02SffZFOR2zxkwMr6adsmHOJYpmIua5KwX",
+	"F+T69aTz4mX/hY/hKNdEADdrdP8QyCgCwdy7C8BQNmXUChzlFUsMeK3ZtTaykx8Hk0GCyufWEVKRVSgX",
+	"9kjc/lK4VY75MOU5QkWafVwGxaZb08zdyOv+AfJbRClgfPLSkAXdXxJCH5BMdCy7FFNItblLYmSLHc4o",
+	"jmtDo/jQV5oSRzsiflFaFZ5SqdRSAXExH7IniZTuuCUlB4LdHZn0PVbIIFZNN+i3djzTxHQz5Wi9yTBq",
+	"Q5W5+6JUE/MqZPyiGHKOa/m7Z8u+lsJbnJ6x01O2N4WXvr8nzZKvcrj+lE4o4mLqXho8tbT26gpXrlfU",
+	"0ZUNV5IMOEwi0Jqu9utLnu2pi7dYGShJ+OU5eXVOTs/JZEiGr/H/8wm5uCD9CzIck7MXZHxOLi7Jy0v7",
+	"6Iy8PiH9czLok4tB8VB0TANgnfLZVMU/v57Ud04Ts5aKo1G/hzuq4fCzyRWtKuxAqj+KVOk8mupAe3V8",
+	"fj35g8oxVh8LVZfdNv0mMZaZLyjp/HqyTx/n15NnlybSDdeZr9mJwxiZXtS5wMvBnUiiBagSngct6fYD",
+	"kvIaFKdhE9GT+vR6Ut7zS0xV6VXE32Sndpv+RwEp5X0Lae7o0lQY9Ib94bDTH3T6p/P++ejsfHRy8s+D",
+	"k5BIcwFLqaBGdPBMohXxFFbwC1soyCTbMYlBccnqQtlu/ZYMSxZEj6+mefznHNAFhcihqhQTuGGcj+oE",
+	"Sjs6Li+y9T0Zg6Ax90beSbffHXr+LtvQK1Sm7MAKGhK3b7l2uR135THhhtDAJh1rha00c0MVEJd2dCH1",
+	"jcD4W8nQ3qN4AF2Cty/lcicBFRg7L3loQLmbl6sydMnrRGGkHUkF/o2QAuzkmGpNKImpMjxIQqrSIJun",
+	"uWvMHa15sHZM73i8ESmTyJ81PIRqwkWcmC4Zk4WUIVCR8ZPfEYwkCkyiBKFheCOKMvOJghVVLNxlCLlK",
+	"Dx2/4zXIAqF7gweH0Lfx3pR5I+9nMJOi/PFgFI3AgNLe6OOjx1H6vydgk3+udL+rlx3WV5BHQs3UrBDu",
+	"qCnRO0wjmgnSMCzRSl9LRettt7d+uZdi2O8f1URxkPsr1KLrabKt34Rv2VCmtR709EkG0+vXj8d1e2T5",
+	"tQZmpsIBs9Tr4S79JVWs8+p7hq4QOF4Qx5+4d4uvljS892indjjbtir7z9CygDVG1ObdBEkL1PtR3QJq",
+	"tEA70GRceUUza1QCh6I87x74YnjtXaXpzGoF9+8ON62nehxqeotQLp4BHRCYXLPW9urynavPEKT1PFC9",
+	"Qi6+a2B97sQQdZY8rMQgHfx7dfnz9D2ZXF7Pp6+nk/H80o7eiPGsCKRut3sj7JPL9xcNs58kNRkfQ8o7",
+	"ANL2uP46uHbstoBbiiVfFWBcx5qbsffIMaXYi8O0qavm9XJnWdvVLAkC0BpLHB+yxQvCbZJVzkqv0H5Y",
+	"lsaV4sK4ROj8w7u3xG00ceQxvoJuUSQywnDSyWQttdG9R9Sbdt9wDVqG95D2oBCcjMERN5pUOlVcfGcn",
+	"cE1CKT8BI0mcZWPtapYh0gMT9HSA2MlGlTa+TZ1STS7ez8j8v+Y21apYXoTFdpwbz77273lbzo1HXIW8",
+	"SwryxcWT2MV8AQ3WLmldO3LsIqrblPL+32S7LudgXW2gmw50pXIdKzVrZP95yhJVYfM1nVm5a6oJovXe",
+	"o+zoug6qp9/SDszTtR2o1lQTIcuos1ydfVvr9B6ZUagWidMxykMEWFkvW/SmIuGCauI0nWpmdktss1VT",
+	"YRug/lqW6hXVPCh1EMR0BYU2gsp90RV2tW61X6Fc9fLesjZR5W1pX1Gv8jW+mSzRB4aV/rmajHwvThqE",
+	"MqsIxdJ/Jdnmm8gj6/orrr+zjNv/V6c0O+SUEMk2OdN75Jp1qN7uT8pAU7+P3jXT2VotGc+y6mah98ON",
+	"rsAWM20vmMtfRGAotiw4L+7oVZM0djq1D0ksQx5siA3l1zImsQLXYUY0ykJgvme6a5DhOovmgPmOx7QL",
+	"CZ0M0GDtyHJNgjUEn1xKSINImxaQDhAaykqzTU3fXbPNHqd+UZBHmnZqbe9t9uvuqJ59xyikaMqcXRVk",
+	"mwZO/zH78J44o+n6LwptiNnUetSnu+QD1sLdSdoMnaaG62X6snsPAySX56rW9R9vPBqEN96IfLzxOqQg",
+	"kcGN55Mb78cb73br+Y0ZIUfdeyrO8euBVjOIqruIqEmzfNmcJ3axY/vk5Id+YRfD/g9Df0D6pDg46P/Q",
+	"b9lRttZxe3oNyOtSgV6XSomacKENUBvcJhoh7sJUIoXrYmziQYGlVGIh76dY0lCDX8+91RFmNcnkSu66",
+	"RWKpTGoJMsVsY8Oq4nFMfM2g1in8Eym+OOuBq1jC7p9/s/0zwldrYdwFqSV0zd1M0bdUewgLriztOrSe",
+	"LAP4fh9WL7C3FBKa6ge6qYCQtqIqYzGNznE8q7YckKnQMQSOBS4Yv+csoWH2XKfJqUgqIK7xERi55/DQ",
+	"6Gxm2W73+JuZ5Sr9GYVcNjZANDqdqhUqNzJ8oa+Zg4q4cIFCG1PDjKlhK1OldorjWPomhYFST8wRpQHr",
+	"aNA0NyC1+/1WCRq4LehqOlTR1t5j+umgMkFh1P06KW9Dra/9lNrUtQY7iCWD3JE0BF47Rp8dfBVaaFCE",
+	"ZuMyOtxC/qv6qmzjramXouiKV+Xud5uF3d8ndTjyDis1NKzYWmt4Cn7NFYW/HgQPqDtcjedvyOzy53eX",
+	"7+dp/t9KEWOBlJVKwaDhDe8g0H7XJYM2fttQalRwQCQTUgPapMTnKtGGXEtpyKR4KXORhb3oTmcXLZHO",
+	"8R0T2MjsfpsTbnzsVcCGpDw6yq/cxfuGbZEu8J1dOWp6MsfdH6Yg9YYFz2/y0/t/PZbpApo+7/vuOMgb",
+	"zI4IKtJlsRUcD6r75UmmHIZIr6X6hTjucc0ww7TtLB4XVMO2ox9df9f2QJPbBu2W4u1cBQcVbB1Y2u3o",
+	"kz1vW7+RJm7wMKKDg2k6YR1Gtand7msGFtiW2lRAuZ50/5hkcwqw5+HrGL/eBrLMt2euHn28c/Gt6Du4",
+	"ZeBfCHxmXDG/nqTBwT9/Gz98+G3807v55cO0EkvsZnmNEK3GDF8O09ZOgK2fVm4dFhIVeiNvbUw86vUe",
+	"sQK3HT1iKmzbozHv3Q9ss7LiaK+txHBK+WdMNtVuh7HeIlXl8clgcDZE1bzNuanifyKjtJcTf0Ep0wyv",
+	"04Y0ENDdHQjSwkH9On95D2pj7I1VQWh/z5YXHCuX12ooeyS1ydXVL1O8H1s8Fnmzcj6UWHMVo0Audqm9",
+	"o3jbVVt3JdoCybWtsm5vt/83ACwAVh3tSQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -16,6 +16,17 @@ const (
 	LogLevelLevelInfo LogLevelLevel = "info"
 )
 
+// Defines values for PathLivenessStatus.
+const (
+	PathLivenessStatusAlive PathLivenessStatus = "alive"
+
+	PathLivenessStatusScmp PathLivenessStatus = "scmp"
+
+	PathLivenessStatusTimeout PathLivenessStatus = "timeout"
+
+	PathLivenessStatusUnknown PathLivenessStatus = "unknown"
+)
+
 // Certificate defines model for Certificate.
 type Certificate struct {
 	DistinguishedName string       `json:"distinguished_name"`
@@ -48,6 +59,11 @@ type Hop struct {
 	IsdAs     IsdAs `json:"isd_as"`
 }
 
+// HostAddresses defines model for HostAddresses.
+type HostAddresses struct {
+	Addresses []string `json:"addresses"`
+}
+
 // IsdAs defines model for IsdAs.
 type IsdAs string
 
@@ -60,6 +76,49 @@ type LogLevel struct {
 // Logging level
 type LogLevelLevel string
 
+// Path defines model for Path.
+type Path struct {
+	// Bandwidth in Kbit/s between consecutive interfaces of the path. Unknown values are indicated with 0.
+	Bandwidth []int64 `json:"bandwidth"`
+
+	// Expiration time of the path.
+	Expiry time.Time `json:"expiry"`
+
+	// Fingerprint of the path, derived from the interfaces it traverses.
+	Fingerprint string `json:"fingerprint"`
+
+	// Interfaces traversed by the path.
+	Hops []Hop `json:"hops"`
+
+	// Latency in microseconds between consecutive interfaces of the path. Unknown values are indicated with -1.
+	Latency  []int64       `json:"latency"`
+	Liveness *PathLiveness `json:"liveness,omitempty"`
+
+	// Maximum transmission unit of the path in bytes.
+	Mtu int `json:"mtu"`
+
+	// Underlay address of the first border router on the path.
+	NextHop *string `json:"next_hop,omitempty"`
+}
+
+// PathLiveness defines model for PathLiveness.
+type PathLiveness struct {
+	// Additional information about the status.
+	Info *string `json:"info,omitempty"`
+
+	// Local IP address that was used for probing.
+	LocalIp *string            `json:"local_ip,omitempty"`
+	Status  PathLivenessStatus `json:"status"`
+}
+
+// PathLivenessStatus defines model for PathLiveness.Status.
+type PathLivenessStatus string
+
+// Paths defines model for Paths.
+type Paths struct {
+	Paths []Path `json:"paths"`
+}
+
 // Problem defines model for Problem.
 type Problem struct {
 	// A human readable explanation specific to this occurrence of the problem that is helpful to locate the problem and give advice on how to proceed. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
@@ -152,6 +211,21 @@ type GetCertificatesParams struct {
 // SetLogLevelJSONBody defines parameters for SetLogLevel.
 type SetLogLevelJSONBody LogLevel
 
+// GetPathsParams defines parameters for GetPaths.
+type GetPathsParams struct {
+	// Path policy in the JSON format used by the path policy configuration files. Only paths that satisfy the policy are returned.
+	Policy *string `json:"policy,omitempty"`
+
+	// Hop predicate sequence. Only paths that match the sequence are returned.
+	Sequence *string `json:"sequence,omitempty"`
+
+	// Fetch fresh path segments instead of using cached ones.
+	Refresh *bool `json:"refresh,omitempty"`
+
+	// Probe the paths and report their liveness.
+	Probe *bool `json:"probe,omitempty"`
+}
+
 // GetSegmentsParams defines parameters for GetSegments.
 type GetSegmentsParams struct {
 	// Start ISD-AS of segment.
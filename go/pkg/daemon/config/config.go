@@ -20,6 +20,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/daemon"
 	"github.com/scionproto/scion/go/lib/env"
@@ -33,6 +34,18 @@ import (
 
 var (
 	DefaultQueryInterval = 5 * time.Minute
+
+	DefaultPrefetchInterval        = 30 * time.Second
+	DefaultPrefetchWindow          = 10 * time.Minute
+	DefaultPrefetchMinRequests     = 3
+	DefaultPrefetchMaxDestinations = 100
+	DefaultPrefetchRefreshBefore   = 5 * time.Minute
+
+	DefaultDisjointnessBonus = 2.0
+
+	DefaultPathQualityHalfLife = time.Minute
+	DefaultPathQualityMaxAge   = 10 * time.Minute
+	DefaultPathQualityMaxPaths = 10000
 )
 
 var _ config.Config = (*Config)(nil)
@@ -122,6 +135,14 @@ type SDConfig struct {
 	// If HiddenPathGroups begins with http:// or https://, it will be fetched
 	// over the network from the specified URL instead.
 	HiddenPathGroups string `toml:"hidden_path_groups,omitempty"`
+	// Prefetch is the configuration of the path prefetching.
+	Prefetch PrefetchConfig `toml:"prefetch,omitempty"`
+	// PathDiversity is the configuration of the diversity-aware path
+	// enumeration.
+	PathDiversity PathDiversityConfig `toml:"path_diversity,omitempty"`
+	// PathQuality is the configuration of the store for the path quality
+	// reported by applications.
+	PathQuality PathQualityConfig `toml:"path_quality,omitempty"`
 }
 
 func (cfg *SDConfig) InitDefaults() {
@@ -131,19 +152,212 @@ func (cfg *SDConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	config.InitAll(&cfg.Prefetch, &cfg.PathDiversity, &cfg.PathQuality)
 }
 
 func (cfg *SDConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("QueryInterval must not be zero")
 	}
-	return nil
+	return config.ValidateAll(&cfg.Prefetch, &cfg.PathDiversity, &cfg.PathQuality)
 }
 
 func (cfg *SDConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, sdSample)
+	config.WriteSample(dst, path, ctx, &cfg.Prefetch, &cfg.PathDiversity, &cfg.PathQuality)
 }
 
 func (cfg *SDConfig) ConfigName() string {
 	return "sd"
 }
+
+var _ config.Config = (*PrefetchConfig)(nil)
+
+// PrefetchConfig is the configuration of the path prefetching. The daemon
+// tracks how often paths to a destination are requested. The paths to popular
+// destinations are periodically looked up in the background, such that the
+// segments are refetched before they expire and requests are served from the
+// cache. Prefetching is disabled by default, because the background lookups
+// add load on the control services even if the paths are not requested again.
+type PrefetchConfig struct {
+	// Enable enables the prefetching for popular destinations.
+	Enable bool `toml:"enable,omitempty"`
+	// Interval is the interval in which the paths to popular destinations are
+	// looked up.
+	Interval util.DurWrap `toml:"interval,omitempty"`
+	// Window is the time window in which requests to a destination are
+	// counted.
+	Window util.DurWrap `toml:"window,omitempty"`
+	// MinRequests is the number of requests within the window that make a
+	// destination popular.
+	MinRequests int `toml:"min_requests,omitempty"`
+	// MaxDestinations is the maximum number of destinations that are tracked.
+	MaxDestinations int `toml:"max_destinations,omitempty"`
+	// RefreshBefore is the time before the expiration of a path to a popular
+	// destination at which the segments are refetched.
+	RefreshBefore util.DurWrap `toml:"refresh_before,omitempty"`
+	// Destinations is the list of ISD-AS whose paths are fetched at startup.
+	Destinations []string `toml:"destinations,omitempty"`
+}
+
+func (cfg *PrefetchConfig) InitDefaults() {
+	if cfg.Interval.Duration == 0 {
+		cfg.Interval.Duration = DefaultPrefetchInterval
+	}
+	if cfg.Window.Duration == 0 {
+		cfg.Window.Duration = DefaultPrefetchWindow
+	}
+	if cfg.MinRequests == 0 {
+		cfg.MinRequests = DefaultPrefetchMinRequests
+	}
+	if cfg.MaxDestinations == 0 {
+		cfg.MaxDestinations = DefaultPrefetchMaxDestinations
+	}
+	if cfg.RefreshBefore.Duration == 0 {
+		cfg.RefreshBefore.Duration = DefaultPrefetchRefreshBefore
+	}
+}
+
+func (cfg *PrefetchConfig) Validate() error {
+	if cfg.Interval.Duration <= 0 {
+		return serrors.New("interval must be positive", "interval", cfg.Interval)
+	}
+	if cfg.Window.Duration <= 0 {
+		return serrors.New("window must be positive", "window", cfg.Window)
+	}
+	if cfg.MinRequests < 1 {
+		return serrors.New("min_requests must be positive", "min_requests", cfg.MinRequests)
+	}
+	if cfg.MaxDestinations < 1 {
+		return serrors.New("max_destinations must be positive",
+			"max_destinations", cfg.MaxDestinations)
+	}
+	if cfg.RefreshBefore.Duration < 0 {
+		return serrors.New("refresh_before must not be negative",
+			"refresh_before", cfg.RefreshBefore)
+	}
+	if _, err := cfg.DestinationIAs(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cfg *PrefetchConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, prefetchSample)
+}
+
+func (cfg *PrefetchConfig) ConfigName() string {
+	return "prefetch"
+}
+
+// DestinationIAs parses the destinations whose paths are fetched at startup.
+func (cfg *PrefetchConfig) DestinationIAs() ([]addr.IA, error) {
+	ias := make([]addr.IA, 0, len(cfg.Destinations))
+	for _, d := range cfg.Destinations {
+		ia, err := addr.IAFromString(d)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing destination", err, "destination", d)
+		}
+		ias = append(ias, ia)
+	}
+	return ias, nil
+}
+
+var _ config.Config = (*PathDiversityConfig)(nil)
+
+// PathDiversityConfig is the configuration of the diversity-aware path
+// enumeration. If enabled, the daemon only returns a small set of paths per
+// destination AS, selected among the paths with the fewest AS hops such that
+// they share as few links as possible.
+type PathDiversityConfig struct {
+	// MaxPaths is the maximum number of paths per destination AS. If zero, all
+	// paths are returned.
+	MaxPaths int `toml:"max_paths,omitempty"`
+	// MaxCandidates is the maximum number of paths that are considered for the
+	// selection. If zero, the default of the path combinator is used.
+	MaxCandidates int `toml:"max_candidates,omitempty"`
+	// DisjointnessBonus is the number of AS hops a path may be longer than
+	// another path, and still be preferred if it does not share any links with
+	// the already selected paths.
+	DisjointnessBonus float64 `toml:"disjointness_bonus,omitempty"`
+}
+
+func (cfg *PathDiversityConfig) InitDefaults() {
+	if cfg.DisjointnessBonus == 0 {
+		cfg.DisjointnessBonus = DefaultDisjointnessBonus
+	}
+}
+
+func (cfg *PathDiversityConfig) Validate() error {
+	if cfg.MaxPaths < 0 {
+		return serrors.New("max_paths must not be negative", "max_paths", cfg.MaxPaths)
+	}
+	if cfg.MaxCandidates < 0 {
+		return serrors.New("max_candidates must not be negative",
+			"max_candidates", cfg.MaxCandidates)
+	}
+	if cfg.DisjointnessBonus < 0 {
+		return serrors.New("disjointness_bonus must not be negative",
+			"disjointness_bonus", cfg.DisjointnessBonus)
+	}
+	return nil
+}
+
+func (cfg *PathDiversityConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, pathDiversitySample)
+}
+
+func (cfg *PathDiversityConfig) ConfigName() string {
+	return "path_diversity"
+}
+
+var _ config.Config = (*PathQualityConfig)(nil)
+
+// PathQualityConfig is the configuration of the store for the path quality
+// that applications report to the daemon. The reports are aggregated per path,
+// and the weight of older reports decays exponentially. The aggregated quality
+// is added to the paths in path replies.
+type PathQualityConfig struct {
+	// Disable disables the path quality reports.
+	Disable bool `toml:"disable,omitempty"`
+	// HalfLife is the time after which the weight of a report is halved.
+	HalfLife util.DurWrap `toml:"half_life,omitempty"`
+	// MaxAge is the time after the most recent report after which the quality
+	// of a path is forgotten.
+	MaxAge util.DurWrap `toml:"max_age,omitempty"`
+	// MaxPaths is the maximum number of paths whose quality is tracked.
+	MaxPaths int `toml:"max_paths,omitempty"`
+}
+
+func (cfg *PathQualityConfig) InitDefaults() {
+	if cfg.HalfLife.Duration == 0 {
+		cfg.HalfLife.Duration = DefaultPathQualityHalfLife
+	}
+	if cfg.MaxAge.Duration == 0 {
+		cfg.MaxAge.Duration = DefaultPathQualityMaxAge
+	}
+	if cfg.MaxPaths == 0 {
+		cfg.MaxPaths = DefaultPathQualityMaxPaths
+	}
+}
+
+func (cfg *PathQualityConfig) Validate() error {
+	if cfg.HalfLife.Duration <= 0 {
+		return serrors.New("half_life must be positive", "half_life", cfg.HalfLife)
+	}
+	if cfg.MaxAge.Duration <= 0 {
+		return serrors.New("max_age must be positive", "max_age", cfg.MaxAge)
+	}
+	if cfg.MaxPaths < 1 {
+		return serrors.New("max_paths must be positive", "max_paths", cfg.MaxPaths)
+	}
+	return nil
+}
+
+func (cfg *PathQualityConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, pathQualitySample)
+}
+
+func (cfg *PathQualityConfig) ConfigName() string {
+	return "path_quality"
+}
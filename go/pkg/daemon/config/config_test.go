@@ -49,6 +49,11 @@ func InitTestConfig(cfg *Config) {
 func InitTestSDConfig(cfg *SDConfig) {
 	cfg.Address = "garbage"
 	cfg.DisableSegVerification = true
+	cfg.Prefetch.Enable = true
+	cfg.Prefetch.MinRequests = 42
+	cfg.PathDiversity.MaxPaths = 42
+	cfg.PathQuality.Disable = true
+	cfg.PathQuality.MaxPaths = 42
 }
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
@@ -64,4 +69,18 @@ func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	assert.Equal(t, daemon.DefaultAPIAddress, cfg.Address)
 	assert.False(t, cfg.DisableSegVerification)
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.False(t, cfg.Prefetch.Enable)
+	assert.Equal(t, DefaultPrefetchInterval, cfg.Prefetch.Interval.Duration)
+	assert.Equal(t, DefaultPrefetchWindow, cfg.Prefetch.Window.Duration)
+	assert.Equal(t, DefaultPrefetchMinRequests, cfg.Prefetch.MinRequests)
+	assert.Equal(t, DefaultPrefetchMaxDestinations, cfg.Prefetch.MaxDestinations)
+	assert.Equal(t, DefaultPrefetchRefreshBefore, cfg.Prefetch.RefreshBefore.Duration)
+	assert.Empty(t, cfg.Prefetch.Destinations)
+	assert.Zero(t, cfg.PathDiversity.MaxPaths)
+	assert.Zero(t, cfg.PathDiversity.MaxCandidates)
+	assert.Equal(t, DefaultDisjointnessBonus, cfg.PathDiversity.DisjointnessBonus)
+	assert.False(t, cfg.PathQuality.Disable)
+	assert.Equal(t, DefaultPathQualityHalfLife, cfg.PathQuality.HalfLife.Duration)
+	assert.Equal(t, DefaultPathQualityMaxAge, cfg.PathQuality.MaxAge.Duration)
+	assert.Equal(t, DefaultPathQualityMaxPaths, cfg.PathQuality.MaxPaths)
 }
@@ -30,3 +30,61 @@ query_interval = "5m"
 # The configuration containing hidden path groups. (default "")
 hidden_path_groups =  ""
 `
+
+const prefetchSample = `
+# Enable the prefetching of paths for popular destinations. (default false)
+enable = false
+
+# The interval in which the paths to popular destinations are looked up in the
+# background. (default 30s)
+interval = "30s"
+
+# The time window in which requests to a destination are counted. (default 10m)
+window = "10m"
+
+# The number of requests within the window that make a destination popular.
+# (default 3)
+min_requests = 3
+
+# The maximum number of destinations that are tracked. (default 100)
+max_destinations = 100
+
+# The time before the expiration of a path to a popular destination at which
+# the segments are refetched. (default 5m)
+refresh_before = "5m"
+
+# The ISD-AS whose paths are fetched at startup. (default [])
+destinations = []
+`
+
+const pathDiversitySample = `
+# The maximum number of paths that are returned per destination AS. If set, the
+# paths are selected among the paths with the fewest AS hops such that they
+# share as few links as possible. If 0, all paths are returned. (default 0)
+max_paths = 0
+
+# The maximum number of paths that are considered for the selection. If 0, the
+# path combinator default is used. (default 0)
+max_candidates = 0
+
+# The number of AS hops a path may be longer than another path, and still be
+# preferred if it does not share any links with the already selected paths.
+# (default 2)
+disjointness_bonus = 2.0
+`
+
+const pathQualitySample = `
+# Disable the path quality reports of applications. (default false)
+disable = false
+
+# The time after which the weight of a path quality report is halved.
+# (default 1m)
+half_life = "1m"
+
+# The time after the most recent report after which the quality of a path is
+# forgotten. (default 10m)
+max_age = "10m"
+
+# The maximum number of paths whose quality is tracked. (default 10000)
+max_paths = 10000
+`
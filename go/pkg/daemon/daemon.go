@@ -35,6 +35,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/pkg/daemon/fetcher"
 	"github.com/scionproto/scion/go/pkg/daemon/internal/servers"
+	"github.com/scionproto/scion/go/pkg/daemon/quality"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
 	"github.com/scionproto/scion/go/pkg/trust"
 	trustgrpc "github.com/scionproto/scion/go/pkg/trust/grpc"
@@ -112,6 +113,9 @@ type ServerConfig struct {
 	RevCache revcache.RevCache
 	Engine   trust.Engine
 	Topology servers.Topology
+	// Quality is the store for the reported path quality. If nil, path quality
+	// reports are disabled.
+	Quality *quality.Store
 }
 
 // NewServer constructs a daemon API server.
@@ -123,6 +127,7 @@ func NewServer(cfg ServerConfig) *servers.DaemonServer {
 		Fetcher:     cfg.Fetcher,
 		ASInspector: cfg.Engine.Inspector,
 		RevCache:    cfg.RevCache,
+		Quality:     cfg.Quality,
 		Metrics: servers.Metrics{
 			PathsRequests: servers.RequestMetrics{
 				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
@@ -200,6 +205,21 @@ func NewServer(cfg ServerConfig) *servers.DaemonServer {
 					Buckets:   prom.DefaultLatencyBuckets,
 				}, servers.LatencyLabels),
 			},
+			PathQualityReports: servers.RequestMetrics{
+				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+					Namespace: "sd",
+					Subsystem: "path_quality",
+					Name:      "reports_total",
+					Help:      "The amount of path quality reports received.",
+				}, servers.PathQualityReportsLabels),
+				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+					Namespace: "sd",
+					Subsystem: "path_quality",
+					Name:      "report_duration_seconds",
+					Help:      "Time to handle path quality reports.",
+					Buckets:   prom.DefaultLatencyBuckets,
+				}, servers.LatencyLabels),
+			},
 		},
 	}
 }
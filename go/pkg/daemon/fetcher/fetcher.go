@@ -19,13 +19,17 @@ package fetcher
 import (
 	"context"
 	"net"
+	"sync/atomic"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
 	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -46,8 +50,9 @@ type Fetcher interface {
 }
 
 type fetcher struct {
-	pather segfetcher.Pather
-	config config.SDConfig
+	pather  segfetcher.Pather
+	config  config.SDConfig
+	metrics Metrics
 }
 
 type FetcherConfig struct {
@@ -65,6 +70,15 @@ type FetcherConfig struct {
 	Verifier infra.Verifier
 	RevCache revcache.RevCache
 	Cfg      config.SDConfig
+	Metrics  Metrics
+}
+
+// Metrics are the metrics of the fetcher. Each field may be nil.
+type Metrics struct {
+	// Lookups counts the path lookups, except the ones issued by the
+	// Prefetcher. The result label is "hit" if all segments were served from
+	// the path database, and "miss" if segments had to be fetched.
+	Lookups metrics.Counter
 }
 
 func NewFetcher(cfg FetcherConfig) Fetcher {
@@ -77,11 +91,13 @@ func NewFetcher(cfg FetcherConfig) Fetcher {
 			Fetcher: &segfetcher.Fetcher{
 				QueryInterval: cfg.Cfg.QueryInterval.Duration,
 				PathDB:        cfg.PathDB,
-				Resolver: segfetcher.NewResolver(
-					cfg.PathDB,
-					cfg.RevCache,
-					neverLocal{},
-				),
+				Resolver: missObserver{
+					Resolver: segfetcher.NewResolver(
+						cfg.PathDB,
+						cfg.RevCache,
+						neverLocal{},
+					),
+				},
 				ReplyHandler: &seghandler.Handler{
 					Verifier: &seghandler.DefaultVerifier{Verifier: cfg.Verifier},
 					Storage: &seghandler.DefaultStorage{
@@ -100,8 +116,23 @@ func NewFetcher(cfg FetcherConfig) Fetcher {
 				Core:      cfg.Core,
 				Inspector: cfg.Inspector,
 			},
+			Diversity: diversityConfig(cfg.Cfg.PathDiversity),
 		},
-		config: cfg.Cfg,
+		config:  cfg.Cfg,
+		metrics: cfg.Metrics,
+	}
+}
+
+// diversityConfig returns the configuration of the diversity-aware path
+// enumeration, or nil if it is disabled.
+func diversityConfig(cfg config.PathDiversityConfig) *combinator.DiversityConfig {
+	if cfg.MaxPaths == 0 {
+		return nil
+	}
+	return &combinator.DiversityConfig{
+		MaxPaths:          cfg.MaxPaths,
+		MaxCandidates:     cfg.MaxCandidates,
+		DisjointnessBonus: cfg.DisjointnessBonus,
 	}
 }
 
@@ -119,7 +150,15 @@ func (f *fetcher) GetPaths(ctx context.Context, src, dst addr.IA,
 	if !src.IsZero() && !src.Equal(local) {
 		return nil, serrors.New("bad source AS", "src", src)
 	}
-	return f.pather.GetPaths(ctx, dst, refresh)
+	l := lookupFromCtx(ctx)
+	if l != nil {
+		// The lookup is observed by the caller, i.e., the Prefetcher.
+		return f.pather.GetPaths(ctx, dst, refresh)
+	}
+	ctx, l = withLookup(ctx)
+	paths, err := f.pather.GetPaths(ctx, dst, refresh)
+	metrics.CounterInc(metrics.CounterWith(f.metrics.Lookups, prom.LabelResult, l.result(err)))
+	return paths, err
 }
 
 type dstProvider struct {
@@ -129,6 +168,61 @@ func (r *dstProvider) Dst(_ context.Context, _ segfetcher.Request) (net.Addr, er
 	return addr.SvcCS, nil
 }
 
+// missObserver marks the lookup in the context as a miss if segments need to be
+// fetched from a remote server.
+type missObserver struct {
+	segfetcher.Resolver
+}
+
+func (r missObserver) Resolve(ctx context.Context, reqs segfetcher.Requests,
+	refresh bool) (segfetcher.Segments, segfetcher.Requests, error) {
+
+	segs, fetchReqs, err := r.Resolver.Resolve(ctx, reqs, refresh)
+	if l := lookupFromCtx(ctx); l != nil && len(fetchReqs) > 0 {
+		l.markMiss()
+	}
+	return segs, fetchReqs, err
+}
+
+const (
+	resultHit  = "hit"
+	resultMiss = "miss"
+)
+
+type lookupKey struct{}
+
+// lookup records whether the segments of a path lookup were served from the
+// path database.
+type lookup struct {
+	miss int32
+}
+
+func withLookup(ctx context.Context) (context.Context, *lookup) {
+	l := &lookup{}
+	return context.WithValue(ctx, lookupKey{}, l), l
+}
+
+func lookupFromCtx(ctx context.Context) *lookup {
+	l, _ := ctx.Value(lookupKey{}).(*lookup)
+	return l
+}
+
+func (l *lookup) markMiss() {
+	atomic.StoreInt32(&l.miss, 1)
+}
+
+// result returns the result label of the lookup.
+func (l *lookup) result(err error) string {
+	switch {
+	case err != nil:
+		return prom.ErrNotClassified
+	case atomic.LoadInt32(&l.miss) != 0:
+		return resultMiss
+	default:
+		return resultHit
+	}
+}
+
 type neverLocal struct{}
 
 func (neverLocal) IsSegLocal(_ segfetcher.Request) bool {
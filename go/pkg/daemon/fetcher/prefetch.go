@@ -0,0 +1,189 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// prefetchTimeout is the timeout for a single path lookup of the prefetcher.
+const prefetchTimeout = 10 * time.Second
+
+// PrefetchMetrics are the metrics of the prefetcher. Each field may be nil.
+type PrefetchMetrics struct {
+	// Prefetches counts the path lookups issued by the prefetcher. The result
+	// label is "hit" if all segments were still cached, and "miss" if
+	// segments were refetched, either because the cached segments were
+	// outdated or because paths were about to expire.
+	Prefetches metrics.Counter
+	// PopularDestinations is the number of destinations that are currently
+	// considered popular.
+	PopularDestinations metrics.Gauge
+}
+
+// Prefetcher is a Fetcher that tracks how often paths to a destination are
+// requested. When run periodically, it looks up the paths to the popular
+// destinations in the background. The underlying fetcher refetches the
+// segments once the query interval has passed. Additionally, the segments are
+// refetched if one of the paths expires within RefreshBefore, such that the
+// requests for popular destinations are served from the path database.
+type Prefetcher struct {
+	// Fetcher is the fetcher that serves the requests.
+	Fetcher Fetcher
+	// Window is the time window in which requests to a destination are
+	// counted.
+	Window time.Duration
+	// MinRequests is the number of requests within the window that make a
+	// destination popular.
+	MinRequests int
+	// MaxDestinations is the maximum number of tracked destinations. If the
+	// limit is reached, the least recently requested destination is evicted.
+	MaxDestinations int
+	// RefreshBefore is the time before the expiration of a path at which the
+	// segments to the destination are refetched. If zero, the segments are
+	// not refetched because of their expiration.
+	RefreshBefore time.Duration
+	Metrics       PrefetchMetrics
+
+	mtx sync.Mutex
+	// requests contains the times of the most recent requests per destination,
+	// at most MinRequests entries in ascending order.
+	requests map[addr.IA][]time.Time
+}
+
+// GetPaths records the request and gets the paths from the underlying fetcher.
+func (p *Prefetcher) GetPaths(ctx context.Context, src, dst addr.IA,
+	refresh bool) ([]snet.Path, error) {
+
+	p.record(dst, time.Now())
+	return p.Fetcher.GetPaths(ctx, src, dst, refresh)
+}
+
+// Name returns the task name.
+func (p *Prefetcher) Name() string {
+	return "sd_path_prefetcher"
+}
+
+// Run looks up the paths to all popular destinations.
+func (p *Prefetcher) Run(ctx context.Context) {
+	dsts := p.popular(time.Now())
+	metrics.GaugeSet(p.Metrics.PopularDestinations, float64(len(dsts)))
+	p.fetch(ctx, dsts)
+}
+
+// Warmup looks up the paths to the given destinations. It does not count as
+// request for the destinations.
+func (p *Prefetcher) Warmup(ctx context.Context, dsts []addr.IA) {
+	p.fetch(ctx, dsts)
+}
+
+func (p *Prefetcher) fetch(ctx context.Context, dsts []addr.IA) {
+	var wg sync.WaitGroup
+	wg.Add(len(dsts))
+	for _, dst := range dsts {
+		dst := dst
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, prefetchTimeout)
+			defer cancel()
+			ctx, l := withLookup(ctx)
+			paths, err := p.Fetcher.GetPaths(ctx, addr.IA{}, dst, false)
+			if err == nil && p.expiresSoon(paths, time.Now()) {
+				_, err = p.Fetcher.GetPaths(ctx, addr.IA{}, dst, true)
+			}
+			if err != nil {
+				log.FromCtx(ctx).Debug("Prefetching paths failed", "dst", dst, "err", err)
+			}
+			metrics.CounterInc(metrics.CounterWith(p.Metrics.Prefetches,
+				prom.LabelResult, l.result(err)))
+		}()
+	}
+	wg.Wait()
+}
+
+// expiresSoon indicates whether one of the paths expires within
+// RefreshBefore.
+func (p *Prefetcher) expiresSoon(paths []snet.Path, now time.Time) bool {
+	if p.RefreshBefore == 0 {
+		return false
+	}
+	for _, path := range paths {
+		meta := path.Metadata()
+		if meta != nil && meta.Expiry.Before(now.Add(p.RefreshBefore)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Prefetcher) record(dst addr.IA, now time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.requests == nil {
+		p.requests = make(map[addr.IA][]time.Time)
+	}
+	times, ok := p.requests[dst]
+	if !ok && len(p.requests) >= p.MaxDestinations {
+		p.evictLocked()
+	}
+	times = append(times, now)
+	if len(times) > p.MinRequests {
+		times = times[len(times)-p.MinRequests:]
+	}
+	p.requests[dst] = times
+}
+
+// evictLocked removes the least recently requested destination.
+func (p *Prefetcher) evictLocked() {
+	var oldest addr.IA
+	var oldestTime time.Time
+	for dst, times := range p.requests {
+		last := times[len(times)-1]
+		if oldestTime.IsZero() || last.Before(oldestTime) {
+			oldest, oldestTime = dst, last
+		}
+	}
+	delete(p.requests, oldest)
+}
+
+// popular returns the destinations with at least MinRequests requests in the
+// window. Destinations that have not been requested within the window are
+// no longer tracked.
+func (p *Prefetcher) popular(now time.Time) []addr.IA {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var dsts []addr.IA
+	for dst, times := range p.requests {
+		if now.Sub(times[len(times)-1]) > p.Window {
+			delete(p.requests, dst)
+			continue
+		}
+		if len(times) >= p.MinRequests && now.Sub(times[0]) <= p.Window {
+			dsts = append(dsts, dst)
+		}
+	}
+	return dsts
+}
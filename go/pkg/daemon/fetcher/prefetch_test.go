@@ -0,0 +1,165 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/daemon/fetcher"
+	"github.com/scionproto/scion/go/pkg/daemon/fetcher/mock_fetcher"
+)
+
+func TestPrefetcher(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+
+	testCases := map[string]struct {
+		Window          time.Duration
+		MaxDestinations int
+		Requests        []addr.IA
+		Popular         []addr.IA
+	}{
+		"no requests": {
+			Window:          time.Hour,
+			MaxDestinations: 10,
+		},
+		"popular destination": {
+			Window:          time.Hour,
+			MaxDestinations: 10,
+			Requests:        []addr.IA{ia110, ia111, ia110, ia112, ia110, ia111},
+			Popular:         []addr.IA{ia110},
+		},
+		"requests outside window": {
+			Window:          time.Nanosecond,
+			MaxDestinations: 10,
+			Requests:        []addr.IA{ia110, ia110, ia110},
+		},
+		"evicted destination": {
+			Window:          time.Hour,
+			MaxDestinations: 1,
+			Requests:        []addr.IA{ia110, ia110, ia111, ia111, ia111},
+			Popular:         []addr.IA{ia111},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := mock_fetcher.NewMockFetcher(ctrl)
+			for _, dst := range tc.Requests {
+				f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, dst, false)
+			}
+			for _, dst := range tc.Popular {
+				f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, dst, false).DoAndReturn(
+					func(ctx context.Context, _, _ addr.IA, _ bool) ([]snet.Path, error) {
+						_, ok := ctx.Deadline()
+						assert.True(t, ok, "deadline must be set")
+						return nil, nil
+					},
+				)
+			}
+			popular := metrics.NewTestGauge()
+			p := &fetcher.Prefetcher{
+				Fetcher:         f,
+				Window:          tc.Window,
+				MinRequests:     3,
+				MaxDestinations: tc.MaxDestinations,
+				Metrics: fetcher.PrefetchMetrics{
+					PopularDestinations: popular,
+				},
+			}
+			for _, dst := range tc.Requests {
+				_, err := p.GetPaths(context.Background(), addr.IA{}, dst, false)
+				require.NoError(t, err)
+			}
+			p.Run(context.Background())
+			assert.Equal(t, float64(len(tc.Popular)), metrics.GaugeValue(popular))
+		})
+	}
+}
+
+func TestPrefetcherWarmup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, ia110, false)
+	f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, ia111, false).
+		Return(nil, serrors.New("test error"))
+
+	prefetches := metrics.NewTestCounter()
+	p := &fetcher.Prefetcher{
+		Fetcher:         f,
+		Window:          time.Hour,
+		MinRequests:     1,
+		MaxDestinations: 10,
+		Metrics:         fetcher.PrefetchMetrics{Prefetches: prefetches},
+	}
+	p.Warmup(context.Background(), []addr.IA{ia110, ia111})
+	assert.Equal(t, float64(1), metrics.CounterValue(
+		prefetches.With(prom.LabelResult, "hit")))
+	assert.Equal(t, float64(1), metrics.CounterValue(
+		prefetches.With(prom.LabelResult, prom.ErrNotClassified)))
+
+	// Warmup does not count as request, i.e., no destination is popular.
+	p.Run(context.Background())
+}
+
+func TestPrefetcherRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	expiring := path.Path{Meta: snet.PathMetadata{Expiry: time.Now().Add(time.Minute)}}
+	valid := path.Path{Meta: snet.PathMetadata{Expiry: time.Now().Add(time.Hour)}}
+
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	gomock.InOrder(
+		f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, ia110, false).
+			Return([]snet.Path{valid, expiring}, nil),
+		f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, ia110, true),
+	)
+	f.EXPECT().GetPaths(gomock.Any(), addr.IA{}, ia111, false).
+		Return([]snet.Path{valid}, nil)
+
+	p := &fetcher.Prefetcher{
+		Fetcher:         f,
+		Window:          time.Hour,
+		MinRequests:     1,
+		MaxDestinations: 10,
+		RefreshBefore:   5 * time.Minute,
+	}
+	p.Warmup(context.Background(), []addr.IA{ia110, ia111})
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"time"
 
 	durationpb "github.com/golang/protobuf/ptypes/duration"
@@ -36,6 +37,7 @@ import (
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/daemon/fetcher"
+	"github.com/scionproto/scion/go/pkg/daemon/quality"
 	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
 	"github.com/scionproto/scion/go/pkg/trust"
 	"github.com/scionproto/scion/go/proto"
@@ -55,6 +57,9 @@ type DaemonServer struct {
 	Fetcher     fetcher.Fetcher
 	RevCache    revcache.RevCache
 	ASInspector trust.Inspector
+	// Quality is the store for the reported path quality. If nil, reports are
+	// rejected and paths are not annotated with their quality.
+	Quality *quality.Store
 
 	Metrics Metrics
 
@@ -96,12 +101,47 @@ func (s *DaemonServer) paths(ctx context.Context,
 		return nil, err
 	}
 	reply := &sdpb.PathsResponse{}
-	for _, p := range paths {
-		reply.Paths = append(reply.Paths, pathToPB(p))
+	for _, p := range s.withQuality(paths, req.OrderByQuality) {
+		pb := pathToPB(p.path)
+		if p.known {
+			pb.Quality = qualityToPB(p.quality)
+		}
+		reply.Paths = append(reply.Paths, pb)
 	}
 	return reply, nil
 }
 
+type pathWithQuality struct {
+	path    snet.Path
+	quality quality.Quality
+	known   bool
+}
+
+// withQuality looks up the reported quality of the paths. If order is set, the
+// paths are ordered by their quality, best first, and the paths without
+// reported quality last.
+func (s *DaemonServer) withQuality(paths []snet.Path, order bool) []pathWithQuality {
+	result := make([]pathWithQuality, 0, len(paths))
+	now := time.Now()
+	for _, p := range paths {
+		pq := pathWithQuality{path: p}
+		if s.Quality != nil {
+			pq.quality, pq.known = s.Quality.Get(now, snet.Fingerprint(p))
+		}
+		result = append(result, pq)
+	}
+	if order {
+		sort.SliceStable(result, func(i, j int) bool {
+			a, b := result[i], result[j]
+			if a.known != b.known {
+				return a.known
+			}
+			return a.known && a.quality.Better(b.quality)
+		})
+	}
+	return result
+}
+
 func (s *DaemonServer) fetchPaths(ctx context.Context, group *singleflight.Group, src, dst addr.IA,
 	refresh bool) ([]snet.Path, error) {
 
@@ -128,9 +168,7 @@ func pathToPB(path snet.Path) *sdpb.Path {
 
 	latency := make([]*durationpb.Duration, len(meta.Latency))
 	for i, v := range meta.Latency {
-		seconds := int64(v / time.Second)
-		nanos := int32(v - time.Duration(seconds)*time.Second)
-		latency[i] = &durationpb.Duration{Seconds: seconds, Nanos: nanos}
+		latency[i] = durationToPB(v)
 	}
 	geo := make([]*sdpb.GeoCoordinates, len(meta.Geo))
 	for i, v := range meta.Geo {
@@ -168,6 +206,20 @@ func pathToPB(path snet.Path) *sdpb.Path {
 
 }
 
+func qualityToPB(q quality.Quality) *sdpb.PathQuality {
+	return &sdpb.PathQuality{
+		Latency:    durationToPB(q.Latency),
+		Loss:       q.Loss,
+		LastReport: &timestamppb.Timestamp{Seconds: q.LastReport.Unix()},
+	}
+}
+
+func durationToPB(d time.Duration) *durationpb.Duration {
+	seconds := int64(d / time.Second)
+	nanos := int32(d - time.Duration(seconds)*time.Second)
+	return &durationpb.Duration{Seconds: seconds, Nanos: nanos}
+}
+
 func linkTypeToPB(lt snet.LinkType) sdpb.LinkType {
 	switch lt {
 	case snet.LinkTypeDirect:
@@ -330,3 +382,38 @@ func (s *DaemonServer) notifyInterfaceDown(ctx context.Context,
 	}
 	return &sdpb.NotifyInterfaceDownResponse{}, nil
 }
+
+// ReportPathQuality adds the reported path quality to the store.
+func (s *DaemonServer) ReportPathQuality(ctx context.Context,
+	req *sdpb.ReportPathQualityRequest) (*sdpb.ReportPathQualityResponse, error) {
+
+	start := time.Now()
+	response, err := s.reportPathQuality(ctx, req)
+	s.Metrics.PathQualityReports.inc(
+		reqLabels{Result: errToMetricResult(err)},
+		time.Since(start).Seconds(),
+	)
+	return response, unwrapMetricsError(err)
+}
+
+func (s *DaemonServer) reportPathQuality(ctx context.Context,
+	req *sdpb.ReportPathQualityRequest) (*sdpb.ReportPathQualityResponse, error) {
+
+	if s.Quality == nil {
+		return nil, serrors.New("path quality reports are disabled")
+	}
+	reports := make([]quality.Report, 0, len(req.Reports))
+	for _, r := range req.Reports {
+		reports = append(reports, quality.Report{
+			Fingerprint: snet.PathFingerprint(r.Fingerprint),
+			Latency:     r.Latency.AsDuration(),
+			Sent:        int(r.Sent),
+			Received:    int(r.Received),
+		})
+	}
+	if err := s.Quality.Add(time.Now(), reports...); err != nil {
+		log.FromCtx(ctx).Debug("Ignoring invalid path quality reports", "err", err)
+		return nil, serrors.WrapStr("adding path quality reports", err)
+	}
+	return &sdpb.ReportPathQualityResponse{}, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/go/pkg/daemon/internal/servers"
+	"github.com/scionproto/scion/go/pkg/daemon/quality"
+	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
+)
+
+func TestPathsQuality(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	newPath := func(ifID common.IFIDType) snet.Path {
+		return path.Path{
+			Dst: ia110,
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: ia111, ID: ifID},
+					{IA: ia110, ID: ifID},
+				},
+			},
+		}
+	}
+	paths := []snet.Path{newPath(1), newPath(2), newPath(3)}
+	report := func(p snet.Path, latency time.Duration, received int) *sdpb.PathQualityReport {
+		return &sdpb.PathQualityReport{
+			Fingerprint: []byte(snet.Fingerprint(p)),
+			Latency:     ptypes.DurationProto(latency),
+			Sent:        10,
+			Received:    uint32(received),
+		}
+	}
+
+	testCases := map[string]struct {
+		Order           bool
+		ExpectedIDs     []uint64
+		ExpectedLatency []time.Duration
+		ExpectedLoss    []float64
+	}{
+		"annotated": {
+			ExpectedIDs:     []uint64{1, 2, 3},
+			ExpectedLatency: []time.Duration{0, 20 * time.Millisecond, 10 * time.Millisecond},
+			ExpectedLoss:    []float64{0, 0, 0.5},
+		},
+		"ordered": {
+			Order:           true,
+			ExpectedIDs:     []uint64{2, 3, 1},
+			ExpectedLatency: []time.Duration{20 * time.Millisecond, 10 * time.Millisecond, 0},
+			ExpectedLoss:    []float64{0, 0.5, 0},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := mock_fetcher.NewMockFetcher(ctrl)
+			f.EXPECT().GetPaths(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(paths, nil)
+			s := &servers.DaemonServer{
+				Fetcher: f,
+				Quality: &quality.Store{HalfLife: time.Minute, MaxAge: time.Hour, MaxPaths: 10},
+			}
+			_, err := s.ReportPathQuality(context.Background(),
+				&sdpb.ReportPathQualityRequest{
+					Reports: []*sdpb.PathQualityReport{
+						report(paths[1], 20*time.Millisecond, 10),
+						report(paths[2], 10*time.Millisecond, 5),
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			rep, err := s.Paths(context.Background(), &sdpb.PathsRequest{
+				DestinationIsdAs: uint64(ia110.IAInt()),
+				OrderByQuality:   tc.Order,
+			})
+			require.NoError(t, err)
+			require.Len(t, rep.Paths, len(paths))
+			for i, p := range rep.Paths {
+				assert.Equal(t, tc.ExpectedIDs[i], p.Interfaces[0].Id)
+				assert.Equal(t, tc.ExpectedLatency[i], p.Quality.GetLatency().AsDuration())
+				assert.Equal(t, tc.ExpectedLoss[i], p.Quality.GetLoss())
+			}
+			assert.Nil(t, rep.Paths[index(rep.Paths, 1)].Quality)
+		})
+	}
+}
+
+func TestReportPathQualityDisabled(t *testing.T) {
+	s := &servers.DaemonServer{}
+	_, err := s.ReportPathQuality(context.Background(), &sdpb.ReportPathQualityRequest{})
+	assert.Error(t, err)
+}
+
+// index returns the index of the path that starts with the given interface.
+func index(paths []*sdpb.Path, ifID uint64) int {
+	for i, p := range paths {
+		if p.Interfaces[0].Id == ifID {
+			return i
+		}
+	}
+	return -1
+}
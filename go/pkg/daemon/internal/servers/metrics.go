@@ -28,6 +28,7 @@ var (
 	InterfacesRequestsLabels         = []string{prom.LabelResult}
 	ServicesRequestsLabels           = []string{prom.LabelResult}
 	InterfaceDownNotificationsLabels = []string{prom.LabelResult, prom.LabelSrc}
+	PathQualityReportsLabels         = []string{prom.LabelResult}
 	LatencyLabels                    = []string{prom.LabelResult}
 )
 
@@ -39,6 +40,7 @@ type Metrics struct {
 	InterfacesRequests         RequestMetrics
 	ServicesRequests           RequestMetrics
 	InterfaceDownNotifications RequestMetrics
+	PathQualityReports         RequestMetrics
 }
 
 // RequestMetrics contains the metrics for a given request.
@@ -0,0 +1,212 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quality implements the store for the path quality that the
+// applications on a host report to the SCION daemon.
+//
+// The reports are aggregated per path fingerprint. Each report is weighted by
+// the number of probes it covers, and the weight of older reports decays
+// exponentially with the configured half-life. Thus, the aggregated quality
+// follows changes on the path, while single reports with few probes have
+// little influence.
+package quality
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// Report is a measurement of the quality of a path.
+type Report struct {
+	// Fingerprint identifies the path.
+	Fingerprint snet.PathFingerprint
+	// Latency is the average round-trip latency of the received probes. It is
+	// ignored if no probe was received.
+	Latency time.Duration
+	// Sent is the number of probes that were sent on the path.
+	Sent int
+	// Received is the number of probes for which a reply was received.
+	Received int
+}
+
+// Validate checks that the report is well-formed.
+func (r Report) Validate() error {
+	if r.Fingerprint == "" {
+		return serrors.New("fingerprint not set")
+	}
+	if r.Sent <= 0 {
+		return serrors.New("sent must be positive", "sent", r.Sent)
+	}
+	if r.Received < 0 || r.Received > r.Sent {
+		return serrors.New("received must be in [0, sent]",
+			"sent", r.Sent, "received", r.Received)
+	}
+	if r.Latency < 0 {
+		return serrors.New("latency must not be negative", "latency", r.Latency)
+	}
+	return nil
+}
+
+// Quality is the aggregated quality of a path.
+type Quality struct {
+	// Latency is the smoothed round-trip latency. It is zero if no probe was
+	// ever received.
+	Latency time.Duration
+	// Loss is the smoothed fraction of lost probes, in the range [0, 1].
+	Loss float64
+	// LastReport is the point in time of the most recent report.
+	LastReport time.Time
+}
+
+// Better indicates whether quality q is better than quality o. The quality
+// with the lower loss, rounded to whole percents, is better. If the loss is
+// equal, the quality with the lower latency is better.
+func (q Quality) Better(o Quality) bool {
+	ql, ol := math.Round(q.Loss*100), math.Round(o.Loss*100)
+	if ql != ol {
+		return ql < ol
+	}
+	return q.Latency < o.Latency
+}
+
+// Store aggregates the reported path quality. It is safe for concurrent use.
+type Store struct {
+	// HalfLife is the time after which the weight of a report is halved.
+	HalfLife time.Duration
+	// MaxAge is the time after the most recent report after which the quality
+	// of a path is forgotten.
+	MaxAge time.Duration
+	// MaxPaths is the maximum number of tracked paths. If the limit is reached,
+	// the path with the oldest report is evicted.
+	MaxPaths int
+
+	mtx     sync.Mutex
+	entries map[snet.PathFingerprint]*entry
+}
+
+// entry contains the decayed sums of the reports for a path.
+type entry struct {
+	sent       float64
+	lost       float64
+	received   float64
+	latencySum float64
+	lastReport time.Time
+}
+
+// Add adds the reports to the store. Invalid reports are rejected, the valid
+// reports of the same call are still added.
+func (s *Store) Add(now time.Time, reports ...Report) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.entries == nil {
+		s.entries = make(map[snet.PathFingerprint]*entry)
+	}
+	var errs serrors.List
+	for _, r := range reports {
+		if err := r.Validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		e, ok := s.entries[r.Fingerprint]
+		if !ok {
+			if len(s.entries) >= s.MaxPaths {
+				s.evictLocked(now)
+			}
+			e = &entry{lastReport: now}
+			s.entries[r.Fingerprint] = e
+		}
+		e.add(r, now, s.HalfLife)
+	}
+	return errs.ToError()
+}
+
+// Get returns the quality of the path with the given fingerprint. The boolean
+// is false if no quality is known for the path.
+func (s *Store) Get(now time.Time, fingerprint snet.PathFingerprint) (Quality, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	e, ok := s.entries[fingerprint]
+	if !ok {
+		return Quality{}, false
+	}
+	if s.expired(e, now) {
+		delete(s.entries, fingerprint)
+		return Quality{}, false
+	}
+	return e.quality(), true
+}
+
+// Len returns the number of tracked paths.
+func (s *Store) Len() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return len(s.entries)
+}
+
+// evictLocked removes all expired entries. If none is expired, the entry with
+// the oldest report is removed.
+func (s *Store) evictLocked(now time.Time) {
+	var oldest snet.PathFingerprint
+	var oldestReport time.Time
+	evicted := false
+	for fp, e := range s.entries {
+		if s.expired(e, now) {
+			delete(s.entries, fp)
+			evicted = true
+			continue
+		}
+		if oldestReport.IsZero() || e.lastReport.Before(oldestReport) {
+			oldest, oldestReport = fp, e.lastReport
+		}
+	}
+	if !evicted {
+		delete(s.entries, oldest)
+	}
+}
+
+func (s *Store) expired(e *entry, now time.Time) bool {
+	return now.Sub(e.lastReport) > s.MaxAge
+}
+
+func (e *entry) add(r Report, now time.Time, halfLife time.Duration) {
+	if elapsed := now.Sub(e.lastReport); elapsed > 0 {
+		decay := math.Exp2(-float64(elapsed) / float64(halfLife))
+		e.sent *= decay
+		e.lost *= decay
+		e.received *= decay
+		e.latencySum *= decay
+		e.lastReport = now
+	}
+	e.sent += float64(r.Sent)
+	e.lost += float64(r.Sent - r.Received)
+	e.received += float64(r.Received)
+	e.latencySum += float64(r.Received) * float64(r.Latency)
+}
+
+func (e *entry) quality() Quality {
+	q := Quality{
+		Loss:       e.lost / e.sent,
+		LastReport: e.lastReport,
+	}
+	if e.received > 0 {
+		q.Latency = time.Duration(e.latencySum / e.received)
+	}
+	return q
+}
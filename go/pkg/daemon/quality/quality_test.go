@@ -0,0 +1,179 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quality_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/daemon/quality"
+)
+
+func TestStoreGet(t *testing.T) {
+	start := time.Now()
+	testCases := map[string]struct {
+		Reports  []quality.Report
+		At       time.Duration
+		Expected quality.Quality
+		Known    bool
+	}{
+		"unknown": {
+			Reports: []quality.Report{
+				{Fingerprint: "b", Latency: time.Millisecond, Sent: 1, Received: 1},
+			},
+		},
+		"single report": {
+			Reports: []quality.Report{
+				{Fingerprint: "a", Latency: 10 * time.Millisecond, Sent: 4, Received: 3},
+			},
+			Expected: quality.Quality{
+				Latency:    10 * time.Millisecond,
+				Loss:       0.25,
+				LastReport: start,
+			},
+			Known: true,
+		},
+		"weighted by probes": {
+			Reports: []quality.Report{
+				{Fingerprint: "a", Latency: 10 * time.Millisecond, Sent: 3, Received: 3},
+				{Fingerprint: "a", Latency: 30 * time.Millisecond, Sent: 2, Received: 1},
+			},
+			Expected: quality.Quality{
+				Latency:    15 * time.Millisecond,
+				Loss:       0.2,
+				LastReport: start,
+			},
+			Known: true,
+		},
+		"all lost": {
+			Reports: []quality.Report{
+				{Fingerprint: "a", Latency: 10 * time.Millisecond, Sent: 2},
+			},
+			Expected: quality.Quality{Loss: 1, LastReport: start},
+			Known:    true,
+		},
+		"expired": {
+			Reports: []quality.Report{
+				{Fingerprint: "a", Latency: 10 * time.Millisecond, Sent: 1, Received: 1},
+			},
+			At: time.Hour + time.Second,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			s := &quality.Store{HalfLife: time.Minute, MaxAge: time.Hour, MaxPaths: 10}
+			assert.NoError(t, s.Add(start, tc.Reports...))
+			q, ok := s.Get(start.Add(tc.At), "a")
+			assert.Equal(t, tc.Known, ok)
+			assert.Equal(t, tc.Expected, q)
+		})
+	}
+}
+
+func TestStoreDecay(t *testing.T) {
+	start := time.Now()
+	s := &quality.Store{HalfLife: time.Minute, MaxAge: time.Hour, MaxPaths: 10}
+	assert.NoError(t, s.Add(start,
+		quality.Report{Fingerprint: "a", Latency: 10 * time.Millisecond, Sent: 2, Received: 2}))
+	// After one half-life, the old report has the same weight as a new report
+	// with half the probes.
+	now := start.Add(time.Minute)
+	assert.NoError(t, s.Add(now,
+		quality.Report{Fingerprint: "a", Latency: 40 * time.Millisecond, Sent: 1}))
+	q, ok := s.Get(now, "a")
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Millisecond, q.Latency)
+	assert.InDelta(t, 0.5, q.Loss, 1e-9)
+	assert.Equal(t, now, q.LastReport)
+
+	assert.NoError(t, s.Add(now,
+		quality.Report{Fingerprint: "a", Latency: 40 * time.Millisecond, Sent: 2, Received: 2}))
+	q, _ = s.Get(now, "a")
+	assert.Equal(t, 30*time.Millisecond, q.Latency)
+	assert.InDelta(t, 0.25, q.Loss, 1e-9)
+}
+
+func TestStoreAddInvalid(t *testing.T) {
+	start := time.Now()
+	s := &quality.Store{HalfLife: time.Minute, MaxAge: time.Hour, MaxPaths: 10}
+	err := s.Add(start,
+		quality.Report{Latency: time.Millisecond, Sent: 1, Received: 1},
+		quality.Report{Fingerprint: "a", Sent: 0},
+		quality.Report{Fingerprint: "a", Sent: 1, Received: 2},
+		quality.Report{Fingerprint: "a", Latency: -1, Sent: 1, Received: 1},
+		quality.Report{Fingerprint: "b", Latency: time.Millisecond, Sent: 1, Received: 1},
+	)
+	assert.Error(t, err)
+	_, ok := s.Get(start, "a")
+	assert.False(t, ok)
+	_, ok = s.Get(start, "b")
+	assert.True(t, ok)
+}
+
+func TestStoreEviction(t *testing.T) {
+	start := time.Now()
+	s := &quality.Store{HalfLife: time.Minute, MaxAge: time.Hour, MaxPaths: 2}
+	report := func(fp string) quality.Report {
+		return quality.Report{Fingerprint: snet.PathFingerprint(fp), Sent: 1, Received: 1}
+	}
+	assert.NoError(t, s.Add(start, report("a")))
+	assert.NoError(t, s.Add(start.Add(time.Second), report("b")))
+	assert.NoError(t, s.Add(start.Add(2*time.Second), report("a")))
+	// The limit is reached, b has the oldest report.
+	assert.NoError(t, s.Add(start.Add(3*time.Second), report("c")))
+	assert.Equal(t, 2, s.Len())
+	_, ok := s.Get(start.Add(3*time.Second), "b")
+	assert.False(t, ok)
+	_, ok = s.Get(start.Add(3*time.Second), "a")
+	assert.True(t, ok)
+}
+
+func TestQualityBetter(t *testing.T) {
+	testCases := map[string]struct {
+		A, B     quality.Quality
+		Expected bool
+	}{
+		"lower loss": {
+			A:        quality.Quality{Loss: 0.1, Latency: time.Second},
+			B:        quality.Quality{Loss: 0.2, Latency: time.Millisecond},
+			Expected: true,
+		},
+		"higher loss": {
+			A: quality.Quality{Loss: 0.5},
+			B: quality.Quality{Loss: 0},
+		},
+		"similar loss lower latency": {
+			A:        quality.Quality{Loss: 0.101, Latency: time.Millisecond},
+			B:        quality.Quality{Loss: 0.1, Latency: time.Second},
+			Expected: true,
+		},
+		"equal": {
+			A: quality.Quality{Loss: 0.1, Latency: time.Millisecond},
+			B: quality.Quality{Loss: 0.1, Latency: time.Millisecond},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.Expected, tc.A.Better(tc.B))
+		})
+	}
+}
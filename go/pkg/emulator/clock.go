@@ -0,0 +1,137 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Clock is the time source of the emulated network. It drives beaconing and
+// the link latency.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// AfterFunc calls f after the duration d has elapsed. The returned
+	// function cancels the call, if it has not yet happened.
+	AfterFunc(d time.Duration, f func()) (cancel func())
+}
+
+// RealClock returns a clock that uses the wall clock.
+func RealClock() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) func() {
+	t := time.AfterFunc(d, f)
+	return func() { t.Stop() }
+}
+
+// SimClock is a simulated clock. Time only passes when Advance is called. The
+// scheduled functions are called synchronously by Advance, in the order of
+// their deadline.
+type SimClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	events eventQueue
+	seq    uint64
+}
+
+// NewSimClock returns a simulated clock that starts at the given time.
+func NewSimClock(start time.Time) *SimClock {
+	return &SimClock{now: start}
+}
+
+// Now returns the current simulated time.
+func (c *SimClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc schedules f to be called by Advance once the simulated time
+// reaches Now() + d.
+func (c *SimClock) AfterFunc(d time.Duration, f func()) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	e := &event{deadline: c.now.Add(d), seq: c.seq, f: f}
+	heap.Push(&c.events, e)
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		e.f = nil
+	}
+}
+
+// Advance moves the simulated time forward by d and calls all functions that
+// are due, including the ones that are scheduled by the called functions
+// themselves.
+func (c *SimClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	for len(c.events) > 0 && !c.events[0].deadline.After(target) {
+		e := heap.Pop(&c.events).(*event)
+		if e.deadline.After(c.now) {
+			c.now = e.deadline
+		}
+		f := e.f
+		if f == nil {
+			continue
+		}
+		c.mu.Unlock()
+		f()
+		c.mu.Lock()
+	}
+	c.now = target
+	c.mu.Unlock()
+}
+
+type event struct {
+	deadline time.Time
+	seq      uint64
+	f        func()
+}
+
+// eventQueue is a min-heap of events ordered by deadline. Events with the same
+// deadline are ordered by the time they were scheduled.
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].deadline.Equal(q[j].deadline) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].deadline.Before(q[j].deadline)
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*event)) }
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,230 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator
+
+import (
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/underlay/conn"
+	"github.com/scionproto/scion/go/pkg/router"
+)
+
+// queueSize is the number of packets a connection buffers before it drops
+// incoming packets, similar to the receive buffer of a socket.
+const queueSize = 1024
+
+type packet struct {
+	data []byte
+	from *net.UDPAddr
+}
+
+// memConn is an in-memory packet connection. Written packets are handed to the
+// send function, received packets are queued until they are read. It
+// implements net.PacketConn.
+type memConn struct {
+	local *net.UDPAddr
+	// send transmits a packet to dst. dst is nil for point-to-point
+	// connections. send takes ownership of data.
+	send  func(data []byte, src, dst *net.UDPAddr)
+	queue chan packet
+
+	closeOnce sync.Once
+	closed    chan struct{}
+	onClose   func()
+
+	mu              sync.Mutex
+	readDeadline    time.Time
+	deadlineChanged chan struct{}
+}
+
+func newMemConn(local *net.UDPAddr, send func(data []byte, src, dst *net.UDPAddr)) *memConn {
+	return &memConn{
+		local:           local,
+		send:            send,
+		queue:           make(chan packet, queueSize),
+		closed:          make(chan struct{}),
+		deadlineChanged: make(chan struct{}),
+	}
+}
+
+// deliver queues a received packet. The packet is dropped if the queue is full
+// or the connection is closed.
+func (c *memConn) deliver(data []byte, from *net.UDPAddr) {
+	select {
+	case <-c.closed:
+		return
+	default:
+	}
+	select {
+	case c.queue <- packet{data: data, from: from}:
+	default:
+	}
+}
+
+func (c *memConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	p, err := c.read()
+	if err != nil {
+		return 0, nil, err
+	}
+	return copy(b, p.data), p.from, nil
+}
+
+func (c *memConn) read() (packet, error) {
+	for {
+		c.mu.Lock()
+		deadline, changed := c.readDeadline, c.deadlineChanged
+		c.mu.Unlock()
+
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if !deadline.IsZero() {
+			d := time.Until(deadline)
+			if d <= 0 {
+				return packet{}, os.ErrDeadlineExceeded
+			}
+			timer = time.NewTimer(d)
+			timeout = timer.C
+		}
+		p, retry, err := c.wait(timeout, changed)
+		if timer != nil {
+			timer.Stop()
+		}
+		if !retry {
+			return p, err
+		}
+	}
+}
+
+// wait waits for a packet, the connection to be closed, the timeout, or the
+// deadline to change. In the latter case, retry is set.
+func (c *memConn) wait(timeout <-chan time.Time,
+	changed <-chan struct{}) (p packet, retry bool, err error) {
+
+	select {
+	case p := <-c.queue:
+		return p, false, nil
+	case <-c.closed:
+		return packet{}, false, net.ErrClosed
+	case <-timeout:
+		return packet{}, false, os.ErrDeadlineExceeded
+	case <-changed:
+		return packet{}, true, nil
+	}
+}
+
+func (c *memConn) WriteTo(b []byte, dst net.Addr) (int, error) {
+	select {
+	case <-c.closed:
+		return 0, net.ErrClosed
+	default:
+	}
+	var udpDst *net.UDPAddr
+	if dst != nil {
+		a, ok := dst.(*net.UDPAddr)
+		if !ok {
+			return 0, &net.AddrError{Err: "unsupported address type", Addr: dst.String()}
+		}
+		udpDst = a
+	}
+	c.send(append([]byte(nil), b...), c.local, udpDst)
+	return len(b), nil
+}
+
+func (c *memConn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		if c.onClose != nil {
+			c.onClose()
+		}
+	})
+	return nil
+}
+
+func (c *memConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *memConn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+func (c *memConn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readDeadline = t
+	close(c.deadlineChanged)
+	c.deadlineChanged = make(chan struct{})
+	return nil
+}
+
+// SetWriteDeadline is a no-op, writes never block.
+func (c *memConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+var _ router.BatchConn = batchConn{}
+
+// batchConn adapts a memConn to the router.BatchConn interface.
+type batchConn struct {
+	*memConn
+}
+
+// ReadBatch blocks until at least one packet is available and then reads as
+// many queued packets as fit into msgs.
+//
+// The router keeps reading from its connections after it is stopped, and
+// retries immediately if reading fails. Thus, ReadBatch blocks forever once
+// the connection is closed.
+func (c batchConn) ReadBatch(msgs conn.Messages) (int, error) {
+	var p packet
+	select {
+	case p = <-c.queue:
+	case <-c.closed:
+		select {}
+	}
+	n := 0
+	for {
+		msgs[n].N = copy(msgs[n].Buffers[0], p.data)
+		msgs[n].Addr = p.from
+		n++
+		if n == len(msgs) {
+			return n, nil
+		}
+		select {
+		case p = <-c.queue:
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (c batchConn) WriteTo(b []byte, dst *net.UDPAddr) (int, error) {
+	if dst == nil {
+		return c.memConn.WriteTo(b, nil)
+	}
+	return c.memConn.WriteTo(b, dst)
+}
+
+func (c batchConn) WriteBatch(msgs conn.Messages, _ int) (int, error) {
+	for i, msg := range msgs {
+		if _, err := c.memConn.WriteTo(msg.Buffers[0], msg.Addr); err != nil {
+			return i, err
+		}
+	}
+	return len(msgs), nil
+}
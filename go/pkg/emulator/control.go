@@ -0,0 +1,230 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/beaconing"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+)
+
+// controlStub stands in for the control service of an AS. It floods beacons
+// and answers the segment requests of the daemon, such that the data plane
+// and the daemon have paths to work with. It does not run any of the control
+// service logic: the beaconing tasks, the beacon store and its policies, the
+// segment registration and the segment request handlers are not used.
+//
+// Beacons are propagated as soon as they are received, on all eligible
+// interfaces. They are exchanged over the emulated links, the segment
+// registrations and lookups are direct calls to the stubs of the core ASes.
+type controlStub struct {
+	ia          addr.IA
+	core        bool
+	network     *Network
+	extender    *beaconing.DefaultExtender
+	links       map[uint16]*linkEnd
+	linkTypes   map[uint16]topology.LinkType
+	db          pathdb.DB
+	maxCoreHops int
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// originate originates beacons on all core and child interfaces of a core AS.
+func (c *controlStub) originate() {
+	if !c.core {
+		return
+	}
+	for _, ifID := range c.interfaces(topology.Core, topology.Child) {
+		c.mu.Lock()
+		segID := uint16(c.rand.Uint32())
+		c.mu.Unlock()
+		pseg, err := seg.CreateSegment(c.network.clock.Now(), segID)
+		if err != nil {
+			log.Error("Creating beacon failed", "isd_as", c.ia, "err", err)
+			continue
+		}
+		c.extendAndSend(beacon.Beacon{Segment: pseg}, ifID, nil)
+	}
+}
+
+// handleBeacon handles a beacon received on the given interface.
+func (c *controlStub) handleBeacon(ingress uint16, pb *cppb.PathSegment) {
+	pseg, err := seg.BeaconFromPB(pb)
+	if err != nil {
+		log.Info("Ignoring invalid beacon", "isd_as", c.ia, "err", err)
+		return
+	}
+	b := beacon.Beacon{Segment: pseg, InIfId: ingress}
+	if err := beacon.FilterLoop(b, c.ia, false); err != nil {
+		return
+	}
+	switch c.linkTypes[ingress] {
+	case topology.Core:
+		if !c.core {
+			return
+		}
+		c.register(b, nil, seg.TypeCore)
+		if len(pseg.ASEntries) >= c.maxCoreHops {
+			return
+		}
+		for _, egress := range c.interfaces(topology.Core) {
+			if egress != ingress {
+				c.propagate(pb, ingress, egress, nil)
+			}
+		}
+	case topology.Parent:
+		peers := c.interfaces(topology.Peer)
+		c.register(b, peers, seg.TypeUp, seg.TypeDown)
+		for _, egress := range c.interfaces(topology.Child) {
+			c.propagate(pb, ingress, egress, peers)
+		}
+	}
+}
+
+// register terminates the beacon and registers the resulting segment. Up
+// segments are registered locally, core and down segments at the core AS
+// that originated the beacon.
+func (c *controlStub) register(b beacon.Beacon, peers []uint16, types ...seg.Type) {
+	if err := c.extender.Extend(context.Background(), b.Segment, b.InIfId, 0,
+		peers); err != nil {
+
+		log.Error("Terminating beacon failed", "isd_as", c.ia, "err", err)
+		return
+	}
+	for _, t := range types {
+		cs := c
+		if t == seg.TypeDown {
+			if cs = c.network.control(b.Segment.FirstIA()); cs == nil {
+				continue
+			}
+		}
+		meta := &seg.Meta{Segment: b.Segment, Type: t}
+		if _, err := cs.db.Insert(context.Background(), meta); err != nil {
+			log.Error("Registering segment failed", "isd_as", c.ia, "err", err)
+		}
+	}
+}
+
+// propagate extends a copy of the beacon and sends it on the egress
+// interface, unless this would create a loop.
+func (c *controlStub) propagate(pb *cppb.PathSegment, ingress, egress uint16,
+	peers []uint16) {
+
+	pseg, err := seg.BeaconFromPB(pb)
+	if err != nil {
+		log.Error("Copying beacon failed", "isd_as", c.ia, "err", err)
+		return
+	}
+	b := beacon.Beacon{Segment: pseg, InIfId: ingress}
+	if err := beacon.FilterLoop(b, c.links[egress].remote().intf.IA, false); err != nil {
+		return
+	}
+	c.extendAndSend(b, egress, peers)
+}
+
+func (c *controlStub) extendAndSend(b beacon.Beacon, egress uint16, peers []uint16) {
+	if err := c.extender.Extend(context.Background(), b.Segment, b.InIfId, egress,
+		peers); err != nil {
+
+		log.Error("Extending beacon failed", "isd_as", c.ia, "egress_interface", egress,
+			"err", err)
+		return
+	}
+	end := c.links[egress]
+	end.link.sendBeacon(end, seg.PathSegmentToPB(b.Segment))
+}
+
+// interfaces returns the sorted IDs of the interfaces with the given link
+// types.
+func (c *controlStub) interfaces(types ...topology.LinkType) []uint16 {
+	var ifIDs []uint16
+	for ifID, t := range c.linkTypes {
+		for _, want := range types {
+			if t == want {
+				ifIDs = append(ifIDs, ifID)
+			}
+		}
+	}
+	sort.Slice(ifIDs, func(i, j int) bool { return ifIDs[i] < ifIDs[j] })
+	return ifIDs
+}
+
+// Segments answers a segment request of the daemon. Up segments are served
+// from the local database, core and down segments are looked up at the core
+// ASes that are responsible for them. It implements segfetcher.RPC.
+func (c *controlStub) Segments(ctx context.Context, req segfetcher.Request,
+	server net.Addr) (segfetcher.SegmentsReply, error) {
+
+	var segs []*seg.Meta
+	switch req.SegType {
+	case seg.TypeUp:
+		res, err := c.db.Get(ctx, &query.Params{
+			StartsAt: []addr.IA{req.Dst},
+			EndsAt:   []addr.IA{req.Src},
+			SegTypes: []seg.Type{seg.TypeUp},
+		})
+		if err != nil {
+			return segfetcher.SegmentsReply{}, err
+		}
+		segs = appendMetas(segs, res)
+	case seg.TypeCore, seg.TypeDown:
+		for _, core := range c.network.cores(req.Src) {
+			params := &query.Params{
+				StartsAt: []addr.IA{req.Dst},
+				EndsAt:   []addr.IA{core.ia},
+				SegTypes: []seg.Type{seg.TypeCore},
+			}
+			if req.SegType == seg.TypeDown {
+				params = &query.Params{
+					StartsAt: []addr.IA{core.ia},
+					EndsAt:   []addr.IA{req.Dst},
+					SegTypes: []seg.Type{seg.TypeDown},
+				}
+			}
+			res, err := core.db.Get(ctx, params)
+			if err != nil {
+				return segfetcher.SegmentsReply{}, err
+			}
+			segs = appendMetas(segs, res)
+		}
+	default:
+		return segfetcher.SegmentsReply{}, serrors.New("unsupported segment type",
+			"type", req.SegType)
+	}
+	return segfetcher.SegmentsReply{Segments: segs, Peer: server}, nil
+}
+
+func appendMetas(metas []*seg.Meta, res query.Results) []*seg.Meta {
+	for _, r := range res {
+		metas = append(metas, &seg.Meta{Segment: r.Seg, Type: r.Type})
+	}
+	return metas
+}
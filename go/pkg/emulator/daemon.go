@@ -0,0 +1,158 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/scrypto/signed"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/daemon/fetcher"
+	cryptopb "github.com/scionproto/scion/go/pkg/proto/crypto"
+	"github.com/scionproto/scion/go/pkg/trust"
+)
+
+// pathsTimeout is the timeout of path requests without deadline.
+const pathsTimeout = 10 * time.Second
+
+var _ daemon.Connector = (*Daemon)(nil)
+
+// Daemon is the emulated daemon of an AS. It looks up paths with the path
+// fetcher of the real daemon, which requests the segments from the
+// control-plane stub of the AS. The segments are not verified.
+type Daemon struct {
+	as      *AS
+	fetcher fetcher.Fetcher
+}
+
+// LocalIA returns the ISD-AS of the AS.
+func (d *Daemon) LocalIA(_ context.Context) (addr.IA, error) {
+	return d.as.IA(), nil
+}
+
+// Paths returns the paths from src to dst. src must be zero or the local
+// ISD-AS.
+func (d *Daemon) Paths(ctx context.Context, dst, src addr.IA,
+	f daemon.PathReqFlags) ([]snet.Path, error) {
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pathsTimeout)
+		defer cancel()
+	}
+	return d.fetcher.GetPaths(ctx, src, dst, f.Refresh)
+}
+
+// ASInfo returns information about the AS. The MTU is only known for the local
+// AS.
+func (d *Daemon) ASInfo(_ context.Context, ia addr.IA) (daemon.ASInfo, error) {
+	if ia.IsZero() {
+		ia = d.as.IA()
+	}
+	info := daemon.ASInfo{IA: ia}
+	if ia.Equal(d.as.IA()) {
+		info.MTU = d.as.mtu()
+	}
+	return info, nil
+}
+
+// IFInfo returns the underlay addresses of the border router that owns the
+// interfaces. All interfaces are owned by the same border router.
+func (d *Daemon) IFInfo(_ context.Context,
+	ifs []common.IFIDType) (map[common.IFIDType]*net.UDPAddr, error) {
+
+	if len(ifs) == 0 {
+		for ifID := range d.as.control.links {
+			ifs = append(ifs, common.IFIDType(ifID))
+		}
+	}
+	m := make(map[common.IFIDType]*net.UDPAddr)
+	for _, ifID := range ifs {
+		if a := d.as.UnderlayNextHop(uint16(ifID)); a != nil {
+			m[ifID] = a
+		}
+	}
+	return m, nil
+}
+
+// SVCInfo returns no services. The control-plane stub is not reachable
+// over the data plane.
+func (d *Daemon) SVCInfo(_ context.Context,
+	_ []addr.HostSVC) (map[addr.HostSVC]string, error) {
+
+	return map[addr.HostSVC]string{}, nil
+}
+
+// RevNotification ignores the revocation.
+func (d *Daemon) RevNotification(_ context.Context, _ *path_mgmt.RevInfo) error {
+	return nil
+}
+
+// ReportPathQuality ignores the reports.
+func (d *Daemon) ReportPathQuality(_ context.Context, _ []daemon.PathQualityReport) error {
+	return nil
+}
+
+// Close does nothing, the daemon is closed together with the network.
+func (d *Daemon) Close(_ context.Context) error {
+	return nil
+}
+
+// inspector provides the core attributes of the emulated ASes. All core ASes
+// hold all attributes.
+type inspector struct {
+	network *Network
+}
+
+func (i inspector) ByAttributes(_ context.Context, isd addr.ISD,
+	_ trust.Attribute) ([]addr.IA, error) {
+
+	var ias []addr.IA
+	for _, c := range i.network.cores(addr.IA{I: isd}) {
+		ias = append(ias, c.ia)
+	}
+	return ias, nil
+}
+
+func (i inspector) HasAttributes(_ context.Context, ia addr.IA,
+	_ trust.Attribute) (bool, error) {
+
+	as, ok := i.network.ases[ia]
+	return ok && as.cfg.Core, nil
+}
+
+// acceptAllVerifier accepts all path segments without verifying them.
+type acceptAllVerifier struct{}
+
+func (acceptAllVerifier) Verify(ctx context.Context, signedMsg *cryptopb.SignedMessage,
+	associatedData ...[]byte) (*signed.Message, error) {
+
+	return nil, nil
+}
+
+func (v acceptAllVerifier) WithServer(net.Addr) infra.Verifier {
+	return v
+}
+
+func (v acceptAllVerifier) WithIA(addr.IA) infra.Verifier {
+	return v
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package emulator emulates a SCION network with an arbitrary topology inside a
+// single process. It is intended for integration tests that exercise the data
+// plane, path lookup and end-to-end traffic with go test, without containers.
+// The control-plane logic is not covered, see Control plane.
+//
+// Every AS of the network consists of a border router, a control-plane stub
+// and a daemon. Hosts can be added to the ASes, and applications on the hosts
+// communicate over SCION with snet:
+//
+//  clock := emulator.NewSimClock(time.Now())
+//  n, err := emulator.New(emulator.Config{ASes: ases, Links: links, Clock: clock})
+//  ...
+//  n.Start(ctx)
+//  clock.Advance(0) // Originate the first beacons.
+//  host, err := n.AS(ia).NewHost(net.IPv4(10, 0, 0, 1))
+//  paths, err := host.Daemon().Paths(ctx, dst, addr.IA{}, daemon.PathReqFlags{})
+//
+// Border router
+//
+// The border routers run the real data plane. The interfaces of the border
+// router are connected to in-memory links that have a configurable latency and
+// loss. The hosts of an AS and its border router are connected by an in-memory
+// AS-internal network without latency.
+//
+// Control plane
+//
+// The ASes do not run a control service. A stub floods beacons to provide the
+// paths: the stubs of the core ASes originate beacons in every beacon interval,
+// and received beacons are extended with the real beacon extender and
+// propagated immediately on all eligible interfaces. Beacons are exchanged over
+// the links, thus they are subject to the latency and loss of the links. The
+// segment registrations and the segment requests of the daemons are direct
+// calls between the stubs.
+//
+// None of the control service logic runs: the beaconing tasks (originator,
+// propagator and registrar), the beacon store and the beacon policies, the
+// segment registration and segment request handlers, and the trust material
+// are not used. Tests of these components must not rely on the emulator.
+//
+// Daemon
+//
+// The daemons look up paths with the path fetcher of the real daemon. The
+// segments are not verified.
+//
+// Clock
+//
+// The beaconing and the link latency are driven by the clock of the network.
+// With a simulated clock, time only passes when the test advances it.
+// Links without latency deliver packets immediately. Note that the border
+// routers and the daemons use the wall clock to check the expiration of hop
+// fields and segments.
+//
+// Limitations
+//
+// The border router does not support peering paths yet. The dispatcher of the
+// hosts only delivers UDP packets, SCMP messages are dropped.
+package emulator
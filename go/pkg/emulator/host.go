@@ -0,0 +1,227 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+const (
+	// firstEphemeralPort is the first port that is assigned to sockets that
+	// are registered without a port.
+	firstEphemeralPort = 32768
+	lastEphemeralPort  = 65535
+)
+
+// internalNetwork is the emulated AS-internal network. It delivers packets
+// based on their underlay destination address.
+type internalNetwork struct {
+	mu        sync.RWMutex
+	receivers map[string]receiver
+}
+
+type receiver interface {
+	deliver(data []byte, from *net.UDPAddr)
+}
+
+func newInternalNetwork() *internalNetwork {
+	return &internalNetwork{receivers: make(map[string]receiver)}
+}
+
+func (n *internalNetwork) attach(a *net.UDPAddr, r receiver) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.receivers[a.String()]; ok {
+		return serrors.New("address in use", "addr", a)
+	}
+	n.receivers[a.String()] = r
+	return nil
+}
+
+// forward delivers the packet to the receiver attached at dst. The packet is
+// dropped if there is none.
+func (n *internalNetwork) forward(data []byte, src, dst *net.UDPAddr) {
+	if dst == nil {
+		return
+	}
+	n.mu.RLock()
+	r, ok := n.receivers[dst.String()]
+	n.mu.RUnlock()
+	if ok {
+		r.deliver(data, src)
+	}
+}
+
+// Host is an end host in an emulated AS. Applications on the host use the
+// dispatcher and the daemon of the host to communicate over SCION, e.g.:
+//
+//  network := &snet.SCIONNetwork{LocalIA: host.IA(), Dispatcher: host.Dispatcher()}
+//  conn, err := network.Listen(ctx, "udp", &net.UDPAddr{IP: host.IP()}, addr.SvcNone)
+//
+// The dispatcher of the host only delivers UDP packets, SCMP messages are
+// dropped.
+type Host struct {
+	as *AS
+	ip net.IP
+
+	mu       sync.Mutex
+	sockets  map[uint16]*memConn
+	nextPort uint16
+}
+
+func newHost(as *AS, ip net.IP) (*Host, error) {
+	h := &Host{
+		as:       as,
+		ip:       ip,
+		sockets:  make(map[uint16]*memConn),
+		nextPort: firstEphemeralPort,
+	}
+	a := &net.UDPAddr{IP: ip, Port: topology.EndhostPort}
+	if err := as.internal.attach(a, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// IA returns the ISD-AS of the host.
+func (h *Host) IA() addr.IA {
+	return h.as.IA()
+}
+
+// IP returns the IP address of the host.
+func (h *Host) IP() net.IP {
+	return h.ip
+}
+
+// Daemon returns the daemon of the AS of the host.
+func (h *Host) Daemon() daemon.Connector {
+	return h.as.Daemon()
+}
+
+// Dispatcher returns the dispatcher of the host.
+func (h *Host) Dispatcher() snet.PacketDispatcherService {
+	return hostDispatcher{host: h}
+}
+
+// deliver dispatches a packet that is received from the internal network to
+// the socket that is registered for its UDP destination port.
+func (h *Host) deliver(data []byte, from *net.UDPAddr) {
+	var (
+		scion slayers.SCION
+		hbh   slayers.HopByHopExtnSkipper
+		e2e   slayers.EndToEndExtnSkipper
+		udp   slayers.UDP
+	)
+	parser := gopacket.NewDecodingLayerParser(slayers.LayerTypeSCION, &scion, &hbh, &e2e, &udp)
+	parser.IgnoreUnsupported = true
+	decoded := make([]gopacket.LayerType, 0, 4)
+	if err := parser.DecodeLayers(data, &decoded); err != nil {
+		return
+	}
+	if len(decoded) == 0 || decoded[len(decoded)-1] != slayers.LayerTypeSCIONUDP {
+		return
+	}
+	h.mu.Lock()
+	s, ok := h.sockets[udp.DstPort]
+	h.mu.Unlock()
+	if ok {
+		s.deliver(data, from)
+	}
+}
+
+func (h *Host) register(ia addr.IA, a *net.UDPAddr,
+	svc addr.HostSVC) (*memConn, uint16, error) {
+
+	if !ia.Equal(h.IA()) {
+		return nil, 0, serrors.New("registration for remote AS", "isd_as", ia)
+	}
+	if a == nil || !a.IP.Equal(h.ip) {
+		return nil, 0, serrors.New("registration for foreign address", "addr", a)
+	}
+	if svc != addr.SvcNone {
+		return nil, 0, serrors.New("service registration not supported", "svc", svc)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	port, err := h.allocatePort(uint16(a.Port))
+	if err != nil {
+		return nil, 0, err
+	}
+	// Like with the real dispatcher, the packets are sent from the underlay
+	// address of the dispatcher.
+	underlay := &net.UDPAddr{IP: h.ip, Port: topology.EndhostPort}
+	local := &net.UDPAddr{IP: h.ip, Port: int(port)}
+	s := newMemConn(local, func(data []byte, _, dst *net.UDPAddr) {
+		h.as.internal.forward(data, underlay, dst)
+	})
+	s.onClose = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.sockets, port)
+	}
+	h.sockets[port] = s
+	return s, port, nil
+}
+
+// allocatePort returns the requested port if it is free. If the requested port
+// is zero, a free ephemeral port is returned.
+func (h *Host) allocatePort(port uint16) (uint16, error) {
+	if port != 0 {
+		if _, ok := h.sockets[port]; ok {
+			return 0, serrors.New("port in use", "port", port)
+		}
+		return port, nil
+	}
+	for i := 0; i <= lastEphemeralPort-firstEphemeralPort; i++ {
+		port, h.nextPort = h.nextPort, h.nextPort+1
+		if h.nextPort == 0 {
+			h.nextPort = firstEphemeralPort
+		}
+		if _, ok := h.sockets[port]; !ok {
+			return port, nil
+		}
+	}
+	return 0, serrors.New("no free port")
+}
+
+// hostDispatcher registers sockets with the dispatcher of a host.
+type hostDispatcher struct {
+	host *Host
+}
+
+func (d hostDispatcher) Register(ctx context.Context, ia addr.IA, registration *net.UDPAddr,
+	svc addr.HostSVC) (snet.PacketConn, uint16, error) {
+
+	conn, port, err := d.host.register(ia, registration, svc)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &snet.SCIONPacketConn{
+		Conn:        conn,
+		SCMPHandler: snet.DefaultSCMPHandler{},
+	}, port, nil
+}
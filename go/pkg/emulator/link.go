@@ -0,0 +1,120 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator
+
+import (
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+)
+
+// Link is an emulated inter-AS link. Both the data-plane packets and the
+// beacons that are sent over the link are subject to its latency and loss.
+type Link struct {
+	clock Clock
+	ends  [2]*linkEnd
+
+	mu      sync.Mutex
+	latency time.Duration
+	loss    float64
+	rand    *rand.Rand
+}
+
+// linkEnd is the attachment point of a link in an AS.
+type linkEnd struct {
+	link *Link
+	intf Interface
+	// addr is the underlay address of the link end.
+	addr *net.UDPAddr
+	// conn is the connection of the border router.
+	conn *memConn
+	// control is the control-plane stub of the AS.
+	control *controlStub
+}
+
+func newLink(cfg LinkConfig, clock Clock, seed int64, id int) *Link {
+	l := &Link{
+		clock:   clock,
+		latency: cfg.Latency,
+		loss:    cfg.Loss,
+		rand:    rand.New(rand.NewSource(seed)),
+	}
+	for i, intf := range []Interface{cfg.A, cfg.B} {
+		end := &linkEnd{
+			link: l,
+			intf: intf,
+			addr: &net.UDPAddr{
+				IP:   net.IPv4(127, 1, byte(id>>8), byte(id)),
+				Port: 50000 + i,
+			},
+		}
+		remote := 1 - i
+		end.conn = newMemConn(end.addr, func(data []byte, src, _ *net.UDPAddr) {
+			l.transmit(func() { l.ends[remote].conn.deliver(data, src) })
+		})
+		l.ends[i] = end
+	}
+	return l
+}
+
+// SetLatency sets the one-way latency of the link.
+func (l *Link) SetLatency(latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.latency = latency
+}
+
+// SetLoss sets the probability in the range [0, 1] with which a packet or
+// beacon is dropped.
+func (l *Link) SetLoss(loss float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.loss = loss
+}
+
+// remote returns the other end of the link.
+func (e *linkEnd) remote() *linkEnd {
+	if e == e.link.ends[0] {
+		return e.link.ends[1]
+	}
+	return e.link.ends[0]
+}
+
+// sendBeacon sends the beacon from the given end of the link to the control
+// service at the other end.
+func (l *Link) sendBeacon(from *linkEnd, pb *cppb.PathSegment) {
+	to := from.remote()
+	l.transmit(func() { to.control.handleBeacon(to.intf.ID, pb) })
+}
+
+// transmit calls deliver after the link latency, unless the transmission is
+// lost. Without latency, deliver is called immediately.
+func (l *Link) transmit(deliver func()) {
+	l.mu.Lock()
+	latency := l.latency
+	lost := l.loss > 0 && l.rand.Float64() < l.loss
+	l.mu.Unlock()
+
+	switch {
+	case lost:
+	case latency == 0:
+		deliver()
+	default:
+		l.clock.AfterFunc(latency, deliver)
+	}
+}
@@ -0,0 +1,520 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io"
+	mrand "math/rand"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/beaconing"
+	"github.com/scionproto/scion/go/cs/ifstate"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/revcache/memrevcache"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/scrypto/signed"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/pkg/daemon/config"
+	"github.com/scionproto/scion/go/pkg/daemon/fetcher"
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/control"
+	sqlitepathdb "github.com/scionproto/scion/go/pkg/storage/path/sqlite"
+	"github.com/scionproto/scion/go/pkg/trust"
+)
+
+const (
+	// DefaultMTU is the default MTU of ASes and links.
+	DefaultMTU = 1472
+	// DefaultBeaconInterval is the default interval in which the core ASes
+	// originate beacons.
+	DefaultBeaconInterval = 5 * time.Second
+	// DefaultMaxCoreHops is the default maximum number of AS entries in core
+	// segments.
+	DefaultMaxCoreHops = 4
+)
+
+var (
+	// routerAddr is the underlay address of the border router in every AS.
+	routerAddr = &net.UDPAddr{IP: net.IPv4(127, 0, 0, 254), Port: 30042}
+	// csAddr is the address that the border routers use for the control
+	// service. The control-plane stub does not receive packets.
+	csAddr = &net.UDPAddr{IP: net.IPv4(127, 0, 0, 253), Port: 30252}
+)
+
+// The metrics of the border router are registered with the default
+// prometheus registry, thus all border routers share them.
+var (
+	routerMetricsOnce sync.Once
+	routerMetrics     *router.Metrics
+)
+
+// Interface identifies an interface of an AS.
+type Interface struct {
+	IA addr.IA
+	ID uint16
+}
+
+// ASConfig describes an AS of the emulated network.
+type ASConfig struct {
+	IA   addr.IA
+	Core bool
+	// MTU is the MTU of the AS. If zero, DefaultMTU is used.
+	MTU uint16
+}
+
+// LinkConfig describes a link between two ASes.
+type LinkConfig struct {
+	A, B Interface
+	// Type is the link type of interface A, i.e., the relation of B to A. A
+	// value of topology.Child means that B is a child of A.
+	Type topology.LinkType
+	// MTU is the MTU of the link. If zero, DefaultMTU is used.
+	MTU uint16
+	// Latency is the one-way latency of the link.
+	Latency time.Duration
+	// Loss is the probability in the range [0, 1] with which a packet or beacon
+	// is dropped.
+	Loss float64
+}
+
+// Config describes an emulated network.
+type Config struct {
+	ASes  []ASConfig
+	Links []LinkConfig
+	// Clock drives the beaconing and the link latency. If nil, the wall clock
+	// is used.
+	Clock Clock
+	// BeaconInterval is the interval in which the core ASes originate beacons.
+	// If zero, DefaultBeaconInterval is used.
+	BeaconInterval time.Duration
+	// MaxCoreHops is the maximum number of AS entries in core segments. If
+	// zero, DefaultMaxCoreHops is used.
+	MaxCoreHops int
+	// Seed seeds the randomness of the link loss and the segment IDs.
+	Seed int64
+}
+
+// Network is an emulated SCION network that runs in a single process. Every AS
+// consists of a border router, a control-plane stub and a daemon. The border
+// routers run the real data plane on in-memory connections. The control-plane
+// stubs flood beacons with the real beacon extender, but do not run the control
+// service logic. The daemons look up paths with the real path fetcher.
+//
+// The border routers and the daemons use the wall clock to check the
+// expiration of hop fields and path segments. Thus, a simulated clock must not
+// deviate from the wall clock by more than the lifetime of the segments.
+type Network struct {
+	clock          Clock
+	beaconInterval time.Duration
+	ases           map[addr.IA]*AS
+	links          map[Interface]*Link
+
+	mu           sync.Mutex
+	cancel       context.CancelFunc
+	cancelBeacon func()
+	closers      []io.Closer
+}
+
+// New creates the emulated network. The network does not run until Start is
+// called.
+func New(cfg Config) (*Network, error) {
+	n := &Network{
+		clock:          cfg.Clock,
+		beaconInterval: cfg.BeaconInterval,
+		ases:           make(map[addr.IA]*AS),
+		links:          make(map[Interface]*Link),
+	}
+	if n.clock == nil {
+		n.clock = RealClock()
+	}
+	if n.beaconInterval == 0 {
+		n.beaconInterval = DefaultBeaconInterval
+	}
+	maxCoreHops := cfg.MaxCoreHops
+	if maxCoreHops == 0 {
+		maxCoreHops = DefaultMaxCoreHops
+	}
+	rnd := mrand.New(mrand.NewSource(cfg.Seed))
+
+	for _, c := range cfg.ASes {
+		if _, ok := n.ases[c.IA]; ok {
+			return nil, serrors.New("duplicate AS", "isd_as", c.IA)
+		}
+		if c.IA.IsWildcard() {
+			return nil, serrors.New("wildcard AS", "isd_as", c.IA)
+		}
+		n.ases[c.IA] = &AS{
+			network:  n,
+			cfg:      c,
+			internal: newInternalNetwork(),
+			ifInfos:  make(map[uint16]ifstate.InterfaceInfo),
+			control: &controlStub{
+				ia:          c.IA,
+				core:        c.Core,
+				network:     n,
+				links:       make(map[uint16]*linkEnd),
+				linkTypes:   make(map[uint16]topology.LinkType),
+				maxCoreHops: maxCoreHops,
+				rand:        mrand.New(mrand.NewSource(rnd.Int63())),
+			},
+		}
+	}
+	for i, c := range cfg.Links {
+		if err := n.addLink(c, i, rnd.Int63()); err != nil {
+			n.Close()
+			return nil, err
+		}
+	}
+	for _, as := range n.ases {
+		if err := as.init(); err != nil {
+			n.Close()
+			return nil, serrors.WrapStr("initializing AS", err, "isd_as", as.IA())
+		}
+	}
+	return n, nil
+}
+
+func (n *Network) addLink(c LinkConfig, id int, seed int64) error {
+	reverse, err := reverseLinkType(c.Type)
+	if err != nil {
+		return err
+	}
+	if c.MTU == 0 {
+		c.MTU = DefaultMTU
+	}
+	l := newLink(c, n.clock, seed, id)
+	for i, t := range []topology.LinkType{c.Type, reverse} {
+		end, remote := l.ends[i], l.ends[1-i]
+		as, ok := n.ases[end.intf.IA]
+		if !ok {
+			return serrors.New("link to unknown AS", "isd_as", end.intf.IA)
+		}
+		if end.intf.ID == 0 {
+			return serrors.New("interface ID must not be zero", "isd_as", end.intf.IA)
+		}
+		if _, ok := n.links[end.intf]; ok {
+			return serrors.New("duplicate interface", "isd_as", end.intf.IA,
+				"interface", end.intf.ID)
+		}
+		if t == topology.Core && !as.cfg.Core {
+			return serrors.New("core link to non-core AS", "isd_as", end.intf.IA)
+		}
+		n.links[end.intf] = l
+		end.control = as.control
+		as.control.links[end.intf.ID] = end
+		as.control.linkTypes[end.intf.ID] = t
+		as.ifInfos[end.intf.ID] = ifstate.InterfaceInfo{
+			ID:       end.intf.ID,
+			IA:       remote.intf.IA,
+			LinkType: t,
+			RemoteID: remote.intf.ID,
+			MTU:      c.MTU,
+		}
+	}
+	return nil
+}
+
+func reverseLinkType(t topology.LinkType) (topology.LinkType, error) {
+	switch t {
+	case topology.Core, topology.Peer:
+		return t, nil
+	case topology.Child:
+		return topology.Parent, nil
+	case topology.Parent:
+		return topology.Child, nil
+	default:
+		return 0, serrors.New("unsupported link type", "type", t)
+	}
+}
+
+// Start starts the border routers and the beaconing. The network runs until
+// the context is canceled or Close is called. With a simulated clock, the
+// first beacons are originated when the clock is advanced.
+func (n *Network) Start(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.cancel != nil {
+		return serrors.New("already started")
+	}
+	ctx, n.cancel = context.WithCancel(ctx)
+	for _, as := range n.ases {
+		go func(as *AS) {
+			defer log.HandlePanic()
+			if err := as.router.Run(ctx); err != nil {
+				log.Error("Border router failed", "isd_as", as.IA(), "err", err)
+			}
+		}(as)
+	}
+	n.cancelBeacon = n.clock.AfterFunc(0, n.beacon)
+	return nil
+}
+
+// beacon originates beacons in all core ASes, and schedules the next
+// origination.
+func (n *Network) beacon() {
+	n.mu.Lock()
+	if n.cancelBeacon == nil {
+		n.mu.Unlock()
+		return
+	}
+	n.cancelBeacon = n.clock.AfterFunc(n.beaconInterval, n.beacon)
+	n.mu.Unlock()
+	for _, as := range n.sortedASes() {
+		as.control.originate()
+	}
+}
+
+// Close stops the network and releases its resources.
+func (n *Network) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.cancel != nil {
+		n.cancel()
+	}
+	if n.cancelBeacon != nil {
+		n.cancelBeacon()
+		n.cancelBeacon = nil
+	}
+	for _, l := range n.links {
+		for _, end := range l.ends {
+			end.conn.Close()
+		}
+	}
+	for _, c := range n.closers {
+		c.Close()
+	}
+	n.closers = nil
+	return nil
+}
+
+// AS returns the AS with the given ISD-AS, or nil if it does not exist.
+func (n *Network) AS(ia addr.IA) *AS {
+	return n.ases[ia]
+}
+
+// Link returns the link that is attached to the interface, or nil if it does
+// not exist.
+func (n *Network) Link(intf Interface) *Link {
+	return n.links[intf]
+}
+
+func (n *Network) control(ia addr.IA) *controlStub {
+	if as, ok := n.ases[ia]; ok {
+		return as.control
+	}
+	return nil
+}
+
+// cores returns the control-plane stubs of the core ASes that match the ISD-AS,
+// which can be a wildcard.
+func (n *Network) cores(ia addr.IA) []*controlStub {
+	var cores []*controlStub
+	for _, as := range n.sortedASes() {
+		if !as.cfg.Core || as.IA().I != ia.I || (ia.A != 0 && as.IA().A != ia.A) {
+			continue
+		}
+		cores = append(cores, as.control)
+	}
+	return cores
+}
+
+func (n *Network) sortedASes() []*AS {
+	ases := make([]*AS, 0, len(n.ases))
+	for _, as := range n.ases {
+		ases = append(ases, as)
+	}
+	sort.Slice(ases, func(i, j int) bool {
+		return ases[i].IA().IAInt() < ases[j].IA().IAInt()
+	})
+	return ases
+}
+
+func (n *Network) newPathDB() (pathdb.DB, error) {
+	db, err := sqlitepathdb.New("file::memory:")
+	if err != nil {
+		return nil, err
+	}
+	n.closers = append(n.closers, db)
+	return db, nil
+}
+
+// AS is an AS of the emulated network.
+type AS struct {
+	network  *Network
+	cfg      ASConfig
+	internal *internalNetwork
+	ifInfos  map[uint16]ifstate.InterfaceInfo
+	router   *router.DataPlane
+	control  *controlStub
+	daemon   *Daemon
+}
+
+// init sets up the border router, the control-plane stub and the daemon of the
+// AS. It must be called after all links are added.
+func (as *AS) init() error {
+	masterKey := make([]byte, 16)
+	if _, err := rand.Read(masterKey); err != nil {
+		return err
+	}
+	if err := as.initRouter(masterKey); err != nil {
+		return serrors.WrapStr("initializing border router", err)
+	}
+	if err := as.initControl(masterKey); err != nil {
+		return serrors.WrapStr("initializing control-plane stub", err)
+	}
+	return as.initDaemon()
+}
+
+func (as *AS) initRouter(masterKey []byte) error {
+	routerMetricsOnce.Do(func() { routerMetrics = router.NewMetrics() })
+	dp := &router.DataPlane{Metrics: routerMetrics}
+	if err := dp.SetIA(as.IA()); err != nil {
+		return err
+	}
+	if err := dp.SetKey(control.DeriveHFMacKey(masterKey)); err != nil {
+		return err
+	}
+	conn := newMemConn(routerAddr, as.internal.forward)
+	if err := as.internal.attach(routerAddr, conn); err != nil {
+		return err
+	}
+	as.network.closers = append(as.network.closers, conn)
+	if err := dp.AddInternalInterface(batchConn{conn}, routerAddr.IP); err != nil {
+		return err
+	}
+	if err := dp.AddSvc(addr.SvcCS, csAddr); err != nil {
+		return err
+	}
+	for ifID, end := range as.control.links {
+		if err := dp.AddExternalInterface(ifID, batchConn{end.conn}); err != nil {
+			return err
+		}
+		if err := dp.AddNeighborIA(ifID, end.remote().intf.IA); err != nil {
+			return err
+		}
+		if err := dp.AddLinkType(ifID, as.control.linkTypes[ifID]); err != nil {
+			return err
+		}
+	}
+	as.router = dp
+	return nil
+}
+
+func (as *AS) initControl(masterKey []byte) error {
+	mac, err := scrypto.HFMacFactory(masterKey)
+	if err != nil {
+		return err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	db, err := as.network.newPathDB()
+	if err != nil {
+		return err
+	}
+	as.control.db = db
+	as.control.extender = &beaconing.DefaultExtender{
+		IA: as.IA(),
+		Signer: trust.Signer{
+			PrivateKey:   key,
+			Algorithm:    signed.ECDSAWithSHA256,
+			IA:           as.IA(),
+			TRCID:        cppki.TRCID{ISD: as.IA().I, Base: 1, Serial: 1},
+			SubjectKeyID: []byte(as.IA().String()),
+			Expiration:   time.Now().Add(365 * 24 * time.Hour),
+		},
+		MAC:        mac,
+		Intfs:      ifstate.NewInterfaces(as.ifInfos, ifstate.Config{}),
+		MTU:        as.mtu(),
+		MaxExpTime: func() uint8 { return beacon.DefaultMaxExpTime },
+		StaticInfo: func() *beaconing.StaticInfoCfg { return nil },
+	}
+	return nil
+}
+
+func (as *AS) initDaemon() error {
+	db, err := as.network.newPathDB()
+	if err != nil {
+		return err
+	}
+	var cfg config.SDConfig
+	cfg.InitDefaults()
+	as.daemon = &Daemon{
+		as: as,
+		fetcher: fetcher.NewFetcher(fetcher.FetcherConfig{
+			IA:         as.IA(),
+			MTU:        as.mtu(),
+			Core:       as.cfg.Core,
+			NextHopper: as,
+			RPC:        as.control,
+			PathDB:     db,
+			Inspector:  inspector{network: as.network},
+			Verifier:   acceptAllVerifier{},
+			RevCache:   memrevcache.New(),
+			Cfg:        cfg,
+		}),
+	}
+	return nil
+}
+
+// IA returns the ISD-AS of the AS.
+func (as *AS) IA() addr.IA {
+	return as.cfg.IA
+}
+
+// Daemon returns the daemon of the AS.
+func (as *AS) Daemon() daemon.Connector {
+	return as.daemon
+}
+
+// NewHost adds a host with the given IP address to the AS. The addresses
+// 127.0.0.253 and 127.0.0.254 are reserved for the control service and the
+// border router.
+func (as *AS) NewHost(ip net.IP) (*Host, error) {
+	if ip.Equal(routerAddr.IP) || ip.Equal(csAddr.IP) {
+		return nil, serrors.New("reserved address", "ip", ip)
+	}
+	return newHost(as, ip)
+}
+
+// UnderlayNextHop returns the underlay address of the border router that owns
+// the interface, or nil if the interface does not exist.
+func (as *AS) UnderlayNextHop(ifID uint16) *net.UDPAddr {
+	if _, ok := as.control.links[ifID]; !ok {
+		return nil
+	}
+	return &net.UDPAddr{IP: routerAddr.IP, Port: routerAddr.Port}
+}
+
+func (as *AS) mtu() uint16 {
+	if as.cfg.MTU == 0 {
+		return DefaultMTU
+	}
+	return as.cfg.MTU
+}
@@ -0,0 +1,258 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/emulator"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+	ia120 = xtest.MustParseIA("1-ff00:0:120")
+	ia121 = xtest.MustParseIA("1-ff00:0:121")
+)
+
+// testConfig returns a network with two core ASes, 1-ff00:0:110 and
+// 1-ff00:0:120, that each have one child, 1-ff00:0:111 and 1-ff00:0:121. The
+// children are connected by a peering link.
+func testConfig(clock emulator.Clock) emulator.Config {
+	return emulator.Config{
+		ASes: []emulator.ASConfig{
+			{IA: ia110, Core: true},
+			{IA: ia111},
+			{IA: ia120, Core: true},
+			{IA: ia121},
+		},
+		Links: []emulator.LinkConfig{
+			{
+				A:    emulator.Interface{IA: ia110, ID: 1},
+				B:    emulator.Interface{IA: ia120, ID: 1},
+				Type: topology.Core,
+			},
+			{
+				A:    emulator.Interface{IA: ia110, ID: 2},
+				B:    emulator.Interface{IA: ia111, ID: 1},
+				Type: topology.Child,
+			},
+			{
+				A:    emulator.Interface{IA: ia120, ID: 2},
+				B:    emulator.Interface{IA: ia121, ID: 1},
+				Type: topology.Child,
+			},
+			{
+				A:    emulator.Interface{IA: ia111, ID: 2},
+				B:    emulator.Interface{IA: ia121, ID: 2},
+				Type: topology.Peer,
+			},
+		},
+		Clock: clock,
+		Seed:  42,
+	}
+}
+
+func TestNetworkPaths(t *testing.T) {
+	clock := emulator.NewSimClock(time.Now())
+	n := startNetwork(t, testConfig(clock))
+	clock.Advance(0)
+
+	testCases := map[string]struct {
+		Src      addr.IA
+		Dst      addr.IA
+		Expected [][]snet.PathInterface
+	}{
+		"up": {
+			Src: ia111,
+			Dst: ia110,
+			Expected: [][]snet.PathInterface{
+				{{IA: ia111, ID: 1}, {IA: ia110, ID: 2}},
+			},
+		},
+		"core": {
+			Src: ia110,
+			Dst: ia120,
+			Expected: [][]snet.PathInterface{
+				{{IA: ia110, ID: 1}, {IA: ia120, ID: 1}},
+			},
+		},
+		"down": {
+			Src: ia120,
+			Dst: ia121,
+			Expected: [][]snet.PathInterface{
+				{{IA: ia120, ID: 2}, {IA: ia121, ID: 1}},
+			},
+		},
+		"up core down and peering": {
+			Src: ia111,
+			Dst: ia121,
+			Expected: [][]snet.PathInterface{
+				{{IA: ia111, ID: 2}, {IA: ia121, ID: 2}},
+				{
+					{IA: ia111, ID: 1}, {IA: ia110, ID: 2},
+					{IA: ia110, ID: 1}, {IA: ia120, ID: 1},
+					{IA: ia120, ID: 2}, {IA: ia121, ID: 1},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			paths, err := n.AS(tc.Src).Daemon().Paths(context.Background(), tc.Dst, addr.IA{},
+				daemon.PathReqFlags{})
+			require.NoError(t, err)
+			var actual [][]snet.PathInterface
+			for _, p := range paths {
+				actual = append(actual, p.Metadata().Interfaces)
+			}
+			assert.ElementsMatch(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestNetworkBeaconLatency(t *testing.T) {
+	clock := emulator.NewSimClock(time.Now())
+	cfg := testConfig(clock)
+	cfg.Links[0].Latency = time.Second
+	n := startNetwork(t, cfg)
+
+	paths := func() []snet.Path {
+		paths, err := n.AS(ia110).Daemon().Paths(context.Background(), ia120, addr.IA{},
+			daemon.PathReqFlags{Refresh: true})
+		require.NoError(t, err)
+		return paths
+	}
+	clock.Advance(0)
+	assert.Empty(t, paths())
+	clock.Advance(time.Second)
+	assert.Len(t, paths(), 1)
+}
+
+func TestNetworkTraffic(t *testing.T) {
+	clock := emulator.NewSimClock(time.Now())
+	n := startNetwork(t, testConfig(clock))
+	clock.Advance(0)
+
+	client := newConn(t, n.AS(ia111), net.IPv4(10, 0, 0, 1))
+	server := newConn(t, n.AS(ia121), net.IPv4(10, 0, 0, 2))
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			l, from, err := server.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			server.WriteTo(buf[:l], from)
+		}
+	}()
+
+	paths, err := n.AS(ia111).Daemon().Paths(context.Background(), ia121, addr.IA{},
+		daemon.PathReqFlags{})
+	require.NoError(t, err)
+	// The border router does not support peering paths yet, thus use the path
+	// over the core.
+	var path snet.Path
+	for _, p := range paths {
+		if len(p.Metadata().Interfaces) == 6 {
+			path = p
+		}
+	}
+	require.NotNil(t, path)
+	dst := &snet.UDPAddr{
+		IA:      ia121,
+		Host:    server.LocalAddr().(*net.UDPAddr),
+		Path:    path.Path(),
+		NextHop: path.UnderlayNextHop(),
+	}
+
+	t.Run("echo", func(t *testing.T) {
+		_, err := client.WriteTo([]byte("hello"), dst)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", readString(t, client))
+	})
+	t.Run("latency", func(t *testing.T) {
+		link := n.Link(emulator.Interface{IA: ia110, ID: 1})
+		link.SetLatency(10 * time.Millisecond)
+		defer link.SetLatency(0)
+
+		_, err := client.WriteTo([]byte("slow"), dst)
+		require.NoError(t, err)
+		expectTimeout(t, client)
+		// The request is delivered after 10ms, the reply after 20ms.
+		clock.Advance(10 * time.Millisecond)
+		expectTimeout(t, client)
+		clock.Advance(10 * time.Millisecond)
+		assert.Equal(t, "slow", readString(t, client))
+	})
+	t.Run("loss", func(t *testing.T) {
+		link := n.Link(emulator.Interface{IA: ia120, ID: 2})
+		link.SetLoss(1)
+		defer link.SetLoss(0)
+
+		_, err := client.WriteTo([]byte("lost"), dst)
+		require.NoError(t, err)
+		expectTimeout(t, client)
+	})
+}
+
+func startNetwork(t *testing.T, cfg emulator.Config) *emulator.Network {
+	n, err := emulator.New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { n.Close() })
+	require.NoError(t, n.Start(context.Background()))
+	return n
+}
+
+func newConn(t *testing.T, as *emulator.AS, ip net.IP) *snet.Conn {
+	host, err := as.NewHost(ip)
+	require.NoError(t, err)
+	network := &snet.SCIONNetwork{LocalIA: host.IA(), Dispatcher: host.Dispatcher()}
+	conn, err := network.Listen(context.Background(), "udp", &net.UDPAddr{IP: ip},
+		addr.SvcNone)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readString(t *testing.T, conn *snet.Conn) string {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, 1500)
+	l, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	return string(buf[:l])
+}
+
+func expectTimeout(t *testing.T, conn *snet.Conn) {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+	_, _, err := conn.ReadFrom(make([]byte, 1500))
+	assert.True(t, errors.Is(err, os.ErrDeadlineExceeded), "err: %v", err)
+}
@@ -0,0 +1,28 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bgp implements a minimal BGP-4 speaker (RFC 4271) that is embedded
+// in the gateway.
+//
+// The speaker exchanges routes with a set of statically configured peers. It
+// exports the prefixes learned from remote gateways to the peers, and it
+// imports the prefixes announced by the peers such that the gateway can
+// advertise them to remote gateways, subject to the redistribute-bgp rules of
+// the routing policy.
+//
+// The speaker supports the IPv4 and IPv6 unicast address families
+// (RFC 4760), 4-octet AS numbers (RFC 6793) and communities (RFC 1997). It
+// does not run a decision process: imported prefixes are not installed, and
+// prefixes learned from one peer are never announced to another peer.
+package bgp
@@ -0,0 +1,630 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	headerLen     = 19
+	maxMessageLen = 4096
+	version       = 4
+	// asTrans is the AS number that is put into the 2-octet AS field of the
+	// OPEN message if the local AS number does not fit (RFC 6793).
+	asTrans = 23456
+)
+
+// MessageType is the type of a BGP message.
+type MessageType uint8
+
+// BGP message types (RFC 4271).
+const (
+	TypeOpen         MessageType = 1
+	TypeUpdate       MessageType = 2
+	TypeNotification MessageType = 3
+	TypeKeepalive    MessageType = 4
+)
+
+func (t MessageType) String() string {
+	switch t {
+	case TypeOpen:
+		return "OPEN"
+	case TypeUpdate:
+		return "UPDATE"
+	case TypeNotification:
+		return "NOTIFICATION"
+	case TypeKeepalive:
+		return "KEEPALIVE"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", uint8(t))
+	}
+}
+
+// Message is a BGP message.
+type Message interface {
+	Type() MessageType
+	encode() ([]byte, error)
+}
+
+// Family is an address family identifier / subsequent address family
+// identifier pair.
+type Family struct {
+	AFI  uint16
+	SAFI uint8
+}
+
+// Supported address families.
+var (
+	IPv4Unicast = Family{AFI: 1, SAFI: 1}
+	IPv6Unicast = Family{AFI: 2, SAFI: 1}
+)
+
+func (f Family) String() string {
+	switch f {
+	case IPv4Unicast:
+		return "ipv4-unicast"
+	case IPv6Unicast:
+		return "ipv6-unicast"
+	default:
+		return fmt.Sprintf("afi=%d,safi=%d", f.AFI, f.SAFI)
+	}
+}
+
+// familyOf returns the address family of the prefix.
+func familyOf(prefix *net.IPNet) Family {
+	if prefix.IP.To4() != nil {
+		return IPv4Unicast
+	}
+	return IPv6Unicast
+}
+
+// Capability codes.
+const (
+	capMultiprotocol = 1
+	capFourOctetAS   = 65
+)
+
+// Open is the BGP OPEN message. The speaker always announces the 4-octet AS
+// number capability and a multiprotocol capability for every family in
+// Families.
+type Open struct {
+	// AS is the (4-octet) AS number of the sender.
+	AS uint32
+	// HoldTime is the proposed hold time in seconds.
+	HoldTime uint16
+	// RouterID is the BGP identifier of the sender. It must be an IPv4
+	// address.
+	RouterID net.IP
+	// Families are the address families announced in the multiprotocol
+	// capabilities.
+	Families []Family
+	// FourOctetAS indicates whether the 4-octet AS number capability is
+	// present. It is only set when decoding.
+	FourOctetAS bool
+}
+
+func (o *Open) Type() MessageType {
+	return TypeOpen
+}
+
+func (o *Open) encode() ([]byte, error) {
+	id := o.RouterID.To4()
+	if id == nil {
+		return nil, serrors.New("router ID must be an IPv4 address", "router_id", o.RouterID)
+	}
+	var caps bytes.Buffer
+	for _, f := range o.Families {
+		caps.Write([]byte{capMultiprotocol, 4})
+		binary.Write(&caps, binary.BigEndian, f.AFI)
+		caps.Write([]byte{0, f.SAFI})
+	}
+	caps.Write([]byte{capFourOctetAS, 4})
+	binary.Write(&caps, binary.BigEndian, o.AS)
+
+	myAS := uint16(asTrans)
+	if o.AS <= 0xffff {
+		myAS = uint16(o.AS)
+	}
+	var b bytes.Buffer
+	b.WriteByte(version)
+	binary.Write(&b, binary.BigEndian, myAS)
+	binary.Write(&b, binary.BigEndian, o.HoldTime)
+	b.Write(id)
+	// A single capabilities optional parameter.
+	b.WriteByte(byte(caps.Len() + 2))
+	b.Write([]byte{2, byte(caps.Len())})
+	b.Write(caps.Bytes())
+	return b.Bytes(), nil
+}
+
+func decodeOpen(b []byte) (*Open, error) {
+	if len(b) < 10 {
+		return nil, serrors.New("OPEN message too short", "len", len(b))
+	}
+	if b[0] != version {
+		return nil, serrors.New("unsupported BGP version", "version", b[0])
+	}
+	o := &Open{
+		AS:       uint32(binary.BigEndian.Uint16(b[1:3])),
+		HoldTime: binary.BigEndian.Uint16(b[3:5]),
+		RouterID: net.IP(append([]byte(nil), b[5:9]...)),
+	}
+	params := b[10:]
+	if len(params) != int(b[9]) {
+		return nil, serrors.New("invalid optional parameters length",
+			"expected", b[9], "actual", len(params))
+	}
+	for len(params) > 0 {
+		if len(params) < 2 || len(params) < 2+int(params[1]) {
+			return nil, serrors.New("truncated optional parameter")
+		}
+		pType, pValue := params[0], params[2:2+int(params[1])]
+		params = params[2+int(params[1]):]
+		if pType != 2 {
+			continue
+		}
+		for len(pValue) > 0 {
+			if len(pValue) < 2 || len(pValue) < 2+int(pValue[1]) {
+				return nil, serrors.New("truncated capability")
+			}
+			code, value := pValue[0], pValue[2:2+int(pValue[1])]
+			pValue = pValue[2+int(pValue[1]):]
+			switch {
+			case code == capMultiprotocol && len(value) == 4:
+				o.Families = append(o.Families, Family{
+					AFI:  binary.BigEndian.Uint16(value[0:2]),
+					SAFI: value[3],
+				})
+			case code == capFourOctetAS && len(value) == 4:
+				o.AS = binary.BigEndian.Uint32(value)
+				o.FourOctetAS = true
+			}
+		}
+	}
+	return o, nil
+}
+
+// Keepalive is the BGP KEEPALIVE message.
+type Keepalive struct{}
+
+func (Keepalive) Type() MessageType {
+	return TypeKeepalive
+}
+
+func (Keepalive) encode() ([]byte, error) {
+	return nil, nil
+}
+
+// Notification error codes (RFC 4271, Section 4.5).
+const (
+	ErrMessageHeader uint8 = 1
+	ErrOpenMessage   uint8 = 2
+	ErrUpdateMessage uint8 = 3
+	ErrHoldTimer     uint8 = 4
+	ErrFSM           uint8 = 5
+	ErrCease         uint8 = 6
+)
+
+// Notification is the BGP NOTIFICATION message. It implements the error
+// interface such that it can be returned when the session is torn down.
+type Notification struct {
+	Code    uint8
+	Subcode uint8
+	Data    []byte
+}
+
+func (n *Notification) Type() MessageType {
+	return TypeNotification
+}
+
+func (n *Notification) Error() string {
+	return fmt.Sprintf("BGP notification (code=%d, subcode=%d)", n.Code, n.Subcode)
+}
+
+func (n *Notification) encode() ([]byte, error) {
+	return append([]byte{n.Code, n.Subcode}, n.Data...), nil
+}
+
+func decodeNotification(b []byte) (*Notification, error) {
+	if len(b) < 2 {
+		return nil, serrors.New("NOTIFICATION message too short", "len", len(b))
+	}
+	return &Notification{Code: b[0], Subcode: b[1], Data: append([]byte(nil), b[2:]...)}, nil
+}
+
+// Origin values of the ORIGIN path attribute.
+const (
+	OriginIGP        uint8 = 0
+	OriginEGP        uint8 = 1
+	OriginIncomplete uint8 = 2
+)
+
+// Path attribute type codes.
+const (
+	attrOrigin      = 1
+	attrASPath      = 2
+	attrNextHop     = 3
+	attrLocalPref   = 5
+	attrCommunities = 8
+	attrMPReach     = 14
+	attrMPUnreach   = 15
+)
+
+// Path attribute flags.
+const (
+	flagOptional   = 0x80
+	flagTransitive = 0x40
+	flagExtended   = 0x10
+)
+
+// Update is the BGP UPDATE message. All prefixes of a single message must be
+// of the same address family. IPv4 prefixes are carried in the classic NLRI
+// and withdrawn routes fields, IPv6 prefixes in the multiprotocol attributes
+// (RFC 4760).
+type Update struct {
+	// Withdrawn are the prefixes that are withdrawn.
+	Withdrawn []*net.IPNet
+	// NLRI are the prefixes that are announced with the path attributes
+	// below.
+	NLRI []*net.IPNet
+	// Origin is the value of the ORIGIN attribute.
+	Origin uint8
+	// ASPath is the AS_PATH attribute as a single AS_SEQUENCE of 4-octet AS
+	// numbers. When decoding, all segments are flattened into the list.
+	ASPath []uint32
+	// NextHop is the next hop of the announced prefixes.
+	NextHop net.IP
+	// LocalPref is the value of the LOCAL_PREF attribute. It is only
+	// exchanged with internal peers. If zero, the attribute is omitted.
+	LocalPref uint32
+	// Communities are the values of the COMMUNITIES attribute (RFC 1997).
+	Communities []uint32
+}
+
+func (u *Update) Type() MessageType {
+	return TypeUpdate
+}
+
+func (u *Update) family() (Family, error) {
+	var family *Family
+	for _, p := range append(append([]*net.IPNet(nil), u.Withdrawn...), u.NLRI...) {
+		f := familyOf(p)
+		if family != nil && *family != f {
+			return Family{}, serrors.New("UPDATE mixes address families")
+		}
+		family = &f
+	}
+	if family == nil {
+		return IPv4Unicast, nil
+	}
+	return *family, nil
+}
+
+func (u *Update) encode() ([]byte, error) {
+	family, err := u.family()
+	if err != nil {
+		return nil, err
+	}
+	var attrs bytes.Buffer
+	if len(u.NLRI) > 0 {
+		writeAttr(&attrs, flagTransitive, attrOrigin, []byte{u.Origin})
+
+		var path bytes.Buffer
+		if len(u.ASPath) > 0 {
+			if len(u.ASPath) > 255 {
+				return nil, serrors.New("AS path too long", "len", len(u.ASPath))
+			}
+			// Segment type AS_SEQUENCE.
+			path.Write([]byte{2, byte(len(u.ASPath))})
+			for _, as := range u.ASPath {
+				binary.Write(&path, binary.BigEndian, as)
+			}
+		}
+		writeAttr(&attrs, flagTransitive, attrASPath, path.Bytes())
+
+		if family == IPv4Unicast {
+			nh := u.NextHop.To4()
+			if nh == nil {
+				return nil, serrors.New("IPv4 NLRI require an IPv4 next hop",
+					"next_hop", u.NextHop)
+			}
+			writeAttr(&attrs, flagTransitive, attrNextHop, nh)
+		}
+		if u.LocalPref != 0 {
+			var lp [4]byte
+			binary.BigEndian.PutUint32(lp[:], u.LocalPref)
+			writeAttr(&attrs, flagTransitive, attrLocalPref, lp[:])
+		}
+		if len(u.Communities) > 0 {
+			var c bytes.Buffer
+			for _, community := range u.Communities {
+				binary.Write(&c, binary.BigEndian, community)
+			}
+			writeAttr(&attrs, flagOptional|flagTransitive, attrCommunities, c.Bytes())
+		}
+		if family == IPv6Unicast {
+			nh := u.NextHop.To16()
+			if nh == nil || u.NextHop.To4() != nil {
+				return nil, serrors.New("IPv6 NLRI require an IPv6 next hop",
+					"next_hop", u.NextHop)
+			}
+			var mp bytes.Buffer
+			binary.Write(&mp, binary.BigEndian, family.AFI)
+			mp.Write([]byte{family.SAFI, byte(len(nh))})
+			mp.Write(nh)
+			// Reserved.
+			mp.WriteByte(0)
+			writePrefixes(&mp, u.NLRI)
+			writeAttr(&attrs, flagOptional, attrMPReach, mp.Bytes())
+		}
+	}
+	if family == IPv6Unicast && len(u.Withdrawn) > 0 {
+		var mp bytes.Buffer
+		binary.Write(&mp, binary.BigEndian, family.AFI)
+		mp.WriteByte(family.SAFI)
+		writePrefixes(&mp, u.Withdrawn)
+		writeAttr(&attrs, flagOptional, attrMPUnreach, mp.Bytes())
+	}
+
+	var withdrawn bytes.Buffer
+	var nlri bytes.Buffer
+	if family == IPv4Unicast {
+		writePrefixes(&withdrawn, u.Withdrawn)
+		writePrefixes(&nlri, u.NLRI)
+	}
+	var b bytes.Buffer
+	binary.Write(&b, binary.BigEndian, uint16(withdrawn.Len()))
+	b.Write(withdrawn.Bytes())
+	binary.Write(&b, binary.BigEndian, uint16(attrs.Len()))
+	b.Write(attrs.Bytes())
+	b.Write(nlri.Bytes())
+	return b.Bytes(), nil
+}
+
+func writeAttr(b *bytes.Buffer, flags, code uint8, value []byte) {
+	if len(value) > 255 {
+		b.Write([]byte{flags | flagExtended, code})
+		binary.Write(b, binary.BigEndian, uint16(len(value)))
+	} else {
+		b.Write([]byte{flags, code, byte(len(value))})
+	}
+	b.Write(value)
+}
+
+func writePrefixes(b *bytes.Buffer, prefixes []*net.IPNet) {
+	for _, p := range prefixes {
+		ones, _ := p.Mask.Size()
+		ip := p.IP.To4()
+		if ip == nil {
+			ip = p.IP.To16()
+		}
+		b.WriteByte(byte(ones))
+		b.Write(ip[:(ones+7)/8])
+	}
+}
+
+func decodeUpdate(b []byte) (*Update, error) {
+	u := &Update{}
+	if len(b) < 2 {
+		return nil, serrors.New("UPDATE message too short", "len", len(b))
+	}
+	wLen := int(binary.BigEndian.Uint16(b))
+	b = b[2:]
+	if len(b) < wLen+2 {
+		return nil, serrors.New("invalid withdrawn routes length", "len", wLen)
+	}
+	var err error
+	if u.Withdrawn, err = decodePrefixes(b[:wLen], IPv4Unicast); err != nil {
+		return nil, err
+	}
+	b = b[wLen:]
+	aLen := int(binary.BigEndian.Uint16(b))
+	b = b[2:]
+	if len(b) < aLen {
+		return nil, serrors.New("invalid path attribute length", "len", aLen)
+	}
+	attrs, nlri := b[:aLen], b[aLen:]
+	if u.NLRI, err = decodePrefixes(nlri, IPv4Unicast); err != nil {
+		return nil, err
+	}
+	for len(attrs) > 0 {
+		if len(attrs) < 3 {
+			return nil, serrors.New("truncated path attribute")
+		}
+		flags, code := attrs[0], attrs[1]
+		var value []byte
+		if flags&flagExtended != 0 {
+			if len(attrs) < 4 {
+				return nil, serrors.New("truncated path attribute")
+			}
+			l := int(binary.BigEndian.Uint16(attrs[2:4]))
+			if len(attrs) < 4+l {
+				return nil, serrors.New("truncated path attribute", "code", code)
+			}
+			value, attrs = attrs[4:4+l], attrs[4+l:]
+		} else {
+			l := int(attrs[2])
+			if len(attrs) < 3+l {
+				return nil, serrors.New("truncated path attribute", "code", code)
+			}
+			value, attrs = attrs[3:3+l], attrs[3+l:]
+		}
+		if err := u.decodeAttr(code, value); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+func (u *Update) decodeAttr(code uint8, value []byte) error {
+	switch code {
+	case attrOrigin:
+		if len(value) != 1 {
+			return serrors.New("invalid ORIGIN attribute", "len", len(value))
+		}
+		u.Origin = value[0]
+	case attrASPath:
+		for len(value) > 0 {
+			if len(value) < 2 || len(value) < 2+4*int(value[1]) {
+				return serrors.New("truncated AS_PATH segment")
+			}
+			n := int(value[1])
+			for i := 0; i < n; i++ {
+				u.ASPath = append(u.ASPath, binary.BigEndian.Uint32(value[2+4*i:]))
+			}
+			value = value[2+4*n:]
+		}
+	case attrNextHop:
+		if len(value) != 4 {
+			return serrors.New("invalid NEXT_HOP attribute", "len", len(value))
+		}
+		u.NextHop = net.IP(append([]byte(nil), value...))
+	case attrLocalPref:
+		if len(value) != 4 {
+			return serrors.New("invalid LOCAL_PREF attribute", "len", len(value))
+		}
+		u.LocalPref = binary.BigEndian.Uint32(value)
+	case attrCommunities:
+		if len(value)%4 != 0 {
+			return serrors.New("invalid COMMUNITIES attribute", "len", len(value))
+		}
+		for i := 0; i < len(value); i += 4 {
+			u.Communities = append(u.Communities, binary.BigEndian.Uint32(value[i:]))
+		}
+	case attrMPReach:
+		if len(value) < 5 || len(value) < 5+int(value[3]) {
+			return serrors.New("truncated MP_REACH_NLRI attribute")
+		}
+		family := Family{AFI: binary.BigEndian.Uint16(value[0:2]), SAFI: value[2]}
+		if family != IPv6Unicast {
+			// Unsupported families are ignored.
+			return nil
+		}
+		nhLen := int(value[3])
+		// A next hop of 32 bytes carries a global and a link-local address.
+		if nhLen != 16 && nhLen != 32 {
+			return serrors.New("invalid IPv6 next hop length", "len", nhLen)
+		}
+		u.NextHop = net.IP(append([]byte(nil), value[4:20]...))
+		prefixes, err := decodePrefixes(value[5+nhLen:], family)
+		if err != nil {
+			return err
+		}
+		u.NLRI = append(u.NLRI, prefixes...)
+	case attrMPUnreach:
+		if len(value) < 3 {
+			return serrors.New("truncated MP_UNREACH_NLRI attribute")
+		}
+		family := Family{AFI: binary.BigEndian.Uint16(value[0:2]), SAFI: value[2]}
+		if family != IPv6Unicast {
+			return nil
+		}
+		prefixes, err := decodePrefixes(value[3:], family)
+		if err != nil {
+			return err
+		}
+		u.Withdrawn = append(u.Withdrawn, prefixes...)
+	}
+	return nil
+}
+
+func decodePrefixes(b []byte, family Family) ([]*net.IPNet, error) {
+	bits := 8 * net.IPv4len
+	if family == IPv6Unicast {
+		bits = 8 * net.IPv6len
+	}
+	var prefixes []*net.IPNet
+	for len(b) > 0 {
+		ones := int(b[0])
+		n := (ones + 7) / 8
+		if ones > bits || len(b) < 1+n {
+			return nil, serrors.New("invalid prefix", "family", family, "len", ones)
+		}
+		ip := make(net.IP, bits/8)
+		copy(ip, b[1:1+n])
+		mask := net.CIDRMask(ones, bits)
+		prefixes = append(prefixes, &net.IPNet{IP: ip.Mask(mask), Mask: mask})
+		b = b[1+n:]
+	}
+	return prefixes, nil
+}
+
+// WriteMessage encodes the message and writes it to w.
+func WriteMessage(w io.Writer, msg Message) error {
+	body, err := msg.encode()
+	if err != nil {
+		return serrors.WrapStr("encoding message", err, "type", msg.Type())
+	}
+	if headerLen+len(body) > maxMessageLen {
+		return serrors.New("message too long", "type", msg.Type(), "len", headerLen+len(body))
+	}
+	b := make([]byte, headerLen, headerLen+len(body))
+	for i := 0; i < 16; i++ {
+		b[i] = 0xff
+	}
+	binary.BigEndian.PutUint16(b[16:18], uint16(headerLen+len(body)))
+	b[18] = byte(msg.Type())
+	_, err = w.Write(append(b, body...))
+	return err
+}
+
+// ReadMessage reads and decodes the next message from r. Malformed messages
+// result in an error that wraps the Notification to send to the peer.
+func ReadMessage(r io.Reader) (Message, error) {
+	var hdr [headerLen]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	for _, m := range hdr[:16] {
+		if m != 0xff {
+			return nil, &Notification{Code: ErrMessageHeader, Subcode: 1}
+		}
+	}
+	l := int(binary.BigEndian.Uint16(hdr[16:18]))
+	if l < headerLen || l > maxMessageLen {
+		return nil, &Notification{Code: ErrMessageHeader, Subcode: 2, Data: hdr[16:18]}
+	}
+	body := make([]byte, l-headerLen)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	switch t := MessageType(hdr[18]); t {
+	case TypeOpen:
+		o, err := decodeOpen(body)
+		if err != nil {
+			return nil, serrors.Wrap(&Notification{Code: ErrOpenMessage}, err)
+		}
+		return o, nil
+	case TypeUpdate:
+		u, err := decodeUpdate(body)
+		if err != nil {
+			return nil, serrors.Wrap(&Notification{Code: ErrUpdateMessage}, err)
+		}
+		return u, nil
+	case TypeNotification:
+		return decodeNotification(body)
+	case TypeKeepalive:
+		return Keepalive{}, nil
+	default:
+		return nil, &Notification{Code: ErrMessageHeader, Subcode: 3, Data: []byte{byte(t)}}
+	}
+}
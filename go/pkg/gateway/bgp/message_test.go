@@ -0,0 +1,201 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/pkg/gateway/bgp"
+)
+
+func TestMessageRoundTrip(t *testing.T) {
+	testCases := map[string]struct {
+		Message bgp.Message
+	}{
+		"open": {
+			Message: &bgp.Open{
+				AS:          64512,
+				HoldTime:    90,
+				RouterID:    net.IP{192, 0, 2, 1},
+				Families:    []bgp.Family{bgp.IPv4Unicast, bgp.IPv6Unicast},
+				FourOctetAS: true,
+			},
+		},
+		"open 4-octet AS": {
+			Message: &bgp.Open{
+				AS:          4200000000,
+				HoldTime:    0,
+				RouterID:    net.IP{192, 0, 2, 1},
+				Families:    []bgp.Family{bgp.IPv4Unicast},
+				FourOctetAS: true,
+			},
+		},
+		"keepalive": {
+			Message: bgp.Keepalive{},
+		},
+		"notification": {
+			Message: &bgp.Notification{Code: bgp.ErrCease, Subcode: 2, Data: []byte{1, 2}},
+		},
+		"update IPv4": {
+			Message: &bgp.Update{
+				NLRI:        []*net.IPNet{cidr(t, "10.1.0.0/16"), cidr(t, "192.0.2.128/25")},
+				Origin:      bgp.OriginIGP,
+				ASPath:      []uint32{64512, 4200000000},
+				NextHop:     net.IP{192, 0, 2, 1},
+				Communities: []uint32{64512<<16 | 100},
+			},
+		},
+		"update IPv4 internal": {
+			Message: &bgp.Update{
+				NLRI:      []*net.IPNet{cidr(t, "10.1.0.0/16")},
+				Origin:    bgp.OriginIGP,
+				NextHop:   net.IP{192, 0, 2, 1},
+				LocalPref: 100,
+			},
+		},
+		"update IPv6": {
+			Message: &bgp.Update{
+				NLRI:    []*net.IPNet{cidr(t, "2001:db8:1::/48"), cidr(t, "::/0")},
+				Origin:  bgp.OriginIncomplete,
+				ASPath:  []uint32{64512},
+				NextHop: net.ParseIP("2001:db8::1"),
+			},
+		},
+		"withdraw IPv4": {
+			Message: &bgp.Update{
+				Withdrawn: []*net.IPNet{cidr(t, "10.1.0.0/16")},
+			},
+		},
+		"withdraw IPv6": {
+			Message: &bgp.Update{
+				Withdrawn: []*net.IPNet{cidr(t, "2001:db8:1::/48")},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			require.NoError(t, bgp.WriteMessage(&buf, tc.Message))
+			msg, err := bgp.ReadMessage(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Message, msg)
+			assert.Zero(t, buf.Len())
+		})
+	}
+}
+
+func TestWriteMessageErrors(t *testing.T) {
+	testCases := map[string]struct {
+		Message bgp.Message
+	}{
+		"open without router ID": {
+			Message: &bgp.Open{AS: 64512},
+		},
+		"mixed families": {
+			Message: &bgp.Update{
+				NLRI:    []*net.IPNet{cidr(t, "10.1.0.0/16"), cidr(t, "2001:db8::/32")},
+				NextHop: net.IP{192, 0, 2, 1},
+			},
+		},
+		"IPv4 NLRI with IPv6 next hop": {
+			Message: &bgp.Update{
+				NLRI:    []*net.IPNet{cidr(t, "10.1.0.0/16")},
+				NextHop: net.ParseIP("2001:db8::1"),
+			},
+		},
+		"IPv6 NLRI with IPv4 next hop": {
+			Message: &bgp.Update{
+				NLRI:    []*net.IPNet{cidr(t, "2001:db8::/32")},
+				NextHop: net.IP{192, 0, 2, 1},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			assert.Error(t, bgp.WriteMessage(&buf, tc.Message))
+			assert.Zero(t, buf.Len())
+		})
+	}
+}
+
+func TestReadMessageErrors(t *testing.T) {
+	marker := bytes.Repeat([]byte{0xff}, 16)
+	testCases := map[string]struct {
+		Input []byte
+		Code  uint8
+	}{
+		"bad marker": {
+			Input: append(make([]byte, 16), 0, 19, 4),
+			Code:  bgp.ErrMessageHeader,
+		},
+		"bad length": {
+			Input: append(append([]byte{}, marker...), 0, 18, 4),
+			Code:  bgp.ErrMessageHeader,
+		},
+		"bad type": {
+			Input: append(append([]byte{}, marker...), 0, 19, 9),
+			Code:  bgp.ErrMessageHeader,
+		},
+		"bad version": {
+			Input: append(append([]byte{}, marker...), 0, 29, 1,
+				3, 0, 1, 0, 90, 192, 0, 2, 1, 0),
+			Code: bgp.ErrOpenMessage,
+		},
+		"truncated prefix": {
+			Input: append(append([]byte{}, marker...), 0, 25, 2,
+				0, 0, 0, 0, 24, 10),
+			Code: bgp.ErrUpdateMessage,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := bgp.ReadMessage(bytes.NewReader(tc.Input))
+			var n *bgp.Notification
+			require.True(t, errors.As(err, &n), err)
+			assert.Equal(t, tc.Code, n.Code)
+		})
+	}
+}
+
+func TestParseCommunity(t *testing.T) {
+	c, err := bgp.ParseCommunity("64512:100")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(64512<<16|100), c)
+	assert.Equal(t, "64512:100", bgp.FormatCommunity(c))
+
+	for _, input := range []string{"", "64512", "70000:1", "1:70000", "a:b", "1:2:3"} {
+		_, err := bgp.ParseCommunity(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func cidr(t *testing.T, s string) *net.IPNet {
+	_, network, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return network
+}
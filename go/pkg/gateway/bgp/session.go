@@ -0,0 +1,235 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// supportedFamilies are the address families the speaker announces.
+var supportedFamilies = []Family{IPv4Unicast, IPv6Unicast}
+
+// session is a single BGP session with a peer. The session is established by
+// open. Afterwards, readLoop and writeLoop run concurrently until one of them
+// fails.
+type session struct {
+	conn     net.Conn
+	peer     Peer
+	localAS  uint32
+	routerID net.IP
+	// holdTime is the proposed hold time before open and the negotiated hold
+	// time afterwards. A negotiated hold time of zero disables keepalives.
+	holdTime time.Duration
+	// families are the negotiated address families.
+	families []Family
+
+	mtx   sync.Mutex
+	queue []Message
+	// notify is signaled when messages are added to the queue.
+	notify chan struct{}
+}
+
+// open exchanges the OPEN and KEEPALIVE messages with the peer.
+func (s *session) open() error {
+	err := s.write(&Open{
+		AS:       s.localAS,
+		HoldTime: uint16(s.holdTime / time.Second),
+		RouterID: s.routerID,
+		Families: supportedFamilies,
+	})
+	if err != nil {
+		return serrors.WrapStr("sending OPEN", err)
+	}
+	s.conn.SetReadDeadline(time.Now().Add(s.holdTime))
+	msg, err := ReadMessage(s.conn)
+	if err != nil {
+		return serrors.WrapStr("receiving OPEN", err)
+	}
+	o, ok := msg.(*Open)
+	if !ok {
+		return serrors.Wrap(&Notification{Code: ErrFSM},
+			serrors.New("unexpected message", "type", msg.Type()))
+	}
+	if err := s.negotiate(o); err != nil {
+		return err
+	}
+	if err := s.write(Keepalive{}); err != nil {
+		return serrors.WrapStr("sending KEEPALIVE", err)
+	}
+	s.conn.SetReadDeadline(s.deadline())
+	msg, err = ReadMessage(s.conn)
+	if err != nil {
+		return serrors.WrapStr("receiving KEEPALIVE", err)
+	}
+	if _, ok := msg.(Keepalive); !ok {
+		return serrors.Wrap(&Notification{Code: ErrFSM},
+			serrors.New("unexpected message", "type", msg.Type()))
+	}
+	return nil
+}
+
+// negotiate checks the OPEN message of the peer and determines the session
+// parameters.
+func (s *session) negotiate(o *Open) error {
+	if !o.FourOctetAS {
+		// Unsupported capability.
+		return serrors.Wrap(&Notification{Code: ErrOpenMessage, Subcode: 7},
+			serrors.New("peer does not support 4-octet AS numbers"))
+	}
+	if o.AS != s.peer.AS {
+		// Bad peer AS.
+		return serrors.Wrap(&Notification{Code: ErrOpenMessage, Subcode: 2},
+			serrors.New("unexpected peer AS", "expected", s.peer.AS, "actual", o.AS))
+	}
+	peerHold := time.Duration(o.HoldTime) * time.Second
+	if peerHold != 0 && peerHold < minHoldTime {
+		// Unacceptable hold time.
+		return serrors.Wrap(&Notification{Code: ErrOpenMessage, Subcode: 6},
+			serrors.New("unacceptable hold time", "hold_time", peerHold))
+	}
+	if peerHold < s.holdTime {
+		s.holdTime = peerHold
+	}
+	// Without multiprotocol capabilities, only IPv4 unicast is supported.
+	peerFamilies := o.Families
+	if len(peerFamilies) == 0 {
+		peerFamilies = []Family{IPv4Unicast}
+	}
+	for _, f := range supportedFamilies {
+		for _, pf := range peerFamilies {
+			if f == pf {
+				s.families = append(s.families, f)
+			}
+		}
+	}
+	return nil
+}
+
+// supports indicates whether the address family was negotiated.
+func (s *session) supports(family Family) bool {
+	for _, f := range s.families {
+		if f == family {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *session) deadline() time.Time {
+	if s.holdTime == 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(s.holdTime)
+}
+
+// enqueue queues the message for sending. Nil messages are ignored.
+func (s *session) enqueue(msg Message) {
+	if msg == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.queue = append(s.queue, msg)
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *session) dequeue() []Message {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	q := s.queue
+	s.queue = nil
+	return q
+}
+
+// writeLoop sends the queued messages and the periodic keepalives.
+func (s *session) writeLoop(ctx context.Context) error {
+	var keepalive <-chan time.Time
+	if s.holdTime != 0 {
+		ticker := time.NewTicker(s.holdTime / 3)
+		defer ticker.Stop()
+		keepalive = ticker.C
+	}
+	w := bufio.NewWriter(s.conn)
+	for {
+		select {
+		case <-s.notify:
+			for _, msg := range s.dequeue() {
+				if err := WriteMessage(w, msg); err != nil {
+					return err
+				}
+			}
+		case <-keepalive:
+			if err := WriteMessage(w, Keepalive{}); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// readLoop reads messages from the peer until an error occurs. UPDATE
+// messages are passed to the handler.
+func (s *session) readLoop(handler func(*session, *Update)) error {
+	r := bufio.NewReader(s.conn)
+	for {
+		s.conn.SetReadDeadline(s.deadline())
+		msg, err := ReadMessage(r)
+		if err != nil {
+			if serrors.IsTimeout(err) {
+				return &Notification{Code: ErrHoldTimer}
+			}
+			return err
+		}
+		switch m := msg.(type) {
+		case *Update:
+			handler(s, m)
+		case Keepalive:
+		case *Notification:
+			// The peer closed the session, it must not receive a notification.
+			return serrors.New("peer sent notification", "code", m.Code, "subcode", m.Subcode)
+		default:
+			return serrors.Wrap(&Notification{Code: ErrFSM},
+				serrors.New("unexpected message", "type", msg.Type()))
+		}
+	}
+}
+
+func (s *session) write(msg Message) error {
+	s.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	defer s.conn.SetWriteDeadline(time.Time{})
+	return WriteMessage(s.conn, msg)
+}
+
+// close sends the notification (if not nil) and closes the connection.
+func (s *session) close(n *Notification) {
+	if n != nil {
+		s.conn.SetWriteDeadline(time.Now().Add(time.Second))
+		WriteMessage(s.conn, n)
+	}
+	s.conn.Close()
+}
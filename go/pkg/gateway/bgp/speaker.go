@@ -0,0 +1,568 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+const (
+	// DefaultPort is the well-known BGP port.
+	DefaultPort = 179
+	// DefaultHoldTime is the hold time proposed to peers if none is
+	// configured.
+	DefaultHoldTime = 90 * time.Second
+	// DefaultConnectRetry is the time between connection attempts to a peer.
+	DefaultConnectRetry = 5 * time.Second
+	// DefaultLocalPref is the LOCAL_PREF of routes announced to internal peers
+	// if none is configured.
+	DefaultLocalPref = 100
+	// minHoldTime is the smallest non-zero hold time that is accepted.
+	minHoldTime = 3 * time.Second
+)
+
+// Peer is the configuration of a BGP neighbor.
+type Peer struct {
+	// Address is the address of the peer. If no port is specified, the
+	// default BGP port is used.
+	Address string
+	// AS is the AS number of the peer. If it is equal to the local AS number,
+	// the session is an iBGP session.
+	AS uint32
+	// Passive indicates that the speaker does not connect to the peer but
+	// waits for the peer to connect.
+	Passive bool
+}
+
+// Config is the configuration of the BGP speaker.
+type Config struct {
+	// LocalAS is the AS number of the speaker.
+	LocalAS uint32
+	// RouterID is the BGP identifier of the speaker. It must be an IPv4
+	// address.
+	RouterID net.IP
+	// ListenAddr is the TCP address on which the speaker accepts connections
+	// from passive peers. If empty, the speaker only initiates connections.
+	ListenAddr string
+	// Peers are the BGP neighbors of the speaker.
+	Peers []Peer
+	// Communities are attached to all exported routes.
+	Communities []uint32
+	// NextHopIPv4 is the next hop of exported IPv4 routes. If not set, the
+	// local address of the session is used, if it is an IPv4 address.
+	NextHopIPv4 net.IP
+	// NextHopIPv6 is the next hop of exported IPv6 routes. If not set, the
+	// local address of the session is used, if it is an IPv6 address.
+	NextHopIPv6 net.IP
+	// LocalPref is the LOCAL_PREF attribute of the routes announced to
+	// internal peers. If zero, DefaultLocalPref is used.
+	LocalPref uint32
+	// HoldTime is the hold time proposed to the peers. If zero,
+	// DefaultHoldTime is used.
+	HoldTime time.Duration
+	// ConnectRetry is the time between connection attempts. If zero,
+	// DefaultConnectRetry is used.
+	ConnectRetry time.Duration
+}
+
+// Validate checks that the configuration is complete and initializes the
+// defaults.
+func (cfg *Config) Validate() error {
+	if cfg.LocalAS == 0 {
+		return serrors.New("local AS not set")
+	}
+	if cfg.RouterID.To4() == nil {
+		return serrors.New("router ID must be an IPv4 address", "router_id", cfg.RouterID)
+	}
+	if cfg.HoldTime == 0 {
+		cfg.HoldTime = DefaultHoldTime
+	}
+	if cfg.HoldTime < minHoldTime || cfg.HoldTime > 0xffff*time.Second {
+		return serrors.New("invalid hold time", "hold_time", cfg.HoldTime)
+	}
+	if cfg.ConnectRetry == 0 {
+		cfg.ConnectRetry = DefaultConnectRetry
+	}
+	if cfg.LocalPref == 0 {
+		cfg.LocalPref = DefaultLocalPref
+	}
+	for i, p := range cfg.Peers {
+		if p.AS == 0 {
+			return serrors.New("peer AS not set", "peer", p.Address)
+		}
+		if _, err := peerAddr(p.Address); err != nil {
+			return err
+		}
+		for _, o := range cfg.Peers[:i] {
+			if o.Address == p.Address {
+				return serrors.New("duplicate peer", "peer", p.Address)
+			}
+		}
+	}
+	return nil
+}
+
+// peerAddr resolves the address of a peer, applying the default port if
+// necessary.
+func peerAddr(address string) (*net.TCPAddr, error) {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, strconv.Itoa(DefaultPort))
+	}
+	a, err := net.ResolveTCPAddr("tcp", address)
+	if err != nil {
+		return nil, serrors.WrapStr("resolving peer address", err, "peer", address)
+	}
+	return a, nil
+}
+
+// ParseCommunity parses a community in the "<AS>:<value>" notation.
+func ParseCommunity(s string) (uint32, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return 0, serrors.New("community must be in <AS>:<value> notation", "input", s)
+	}
+	as, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return 0, serrors.WrapStr("parsing community AS", err, "input", s)
+	}
+	v, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return 0, serrors.WrapStr("parsing community value", err, "input", s)
+	}
+	return uint32(as<<16 | v), nil
+}
+
+// FormatCommunity formats a community in the "<AS>:<value>" notation.
+func FormatCommunity(c uint32) string {
+	return fmt.Sprintf("%d:%d", c>>16, c&0xffff)
+}
+
+// Speaker is an embedded BGP speaker. It exports the routes received from
+// ExportedRoutes to all established peers and keeps the prefixes announced by
+// the peers, such that they can be advertised to remote gateways.
+//
+// The speaker does not install any of the learned routes and does not
+// re-announce routes learned from one peer to another peer.
+type Speaker struct {
+	// Config is the configuration of the speaker.
+	Config Config
+	// ExportedRoutes is used to create a consumer for the routes that are
+	// exported to the peers. If nil, no routes are exported.
+	ExportedRoutes control.ConsumerFactory
+	// Listener is used to accept connections from the peers. If nil, and
+	// Config.ListenAddr is set, the speaker listens on Config.ListenAddr.
+	Listener net.Listener
+
+	mtx sync.Mutex
+	// sessions are the established sessions keyed by peer address.
+	sessions map[string]*session
+	// learned are the prefixes learned from each peer.
+	learned map[string]map[string]*net.IPNet
+	// exported are the exported prefixes. The value is a reference count,
+	// because the same prefix can be published with different next hops.
+	exported map[string]*exportedEntry
+}
+
+type exportedEntry struct {
+	prefix   *net.IPNet
+	refCount int
+}
+
+// Run runs the speaker until the context is canceled.
+func (s *Speaker) Run(ctx context.Context) error {
+	if err := s.Config.Validate(); err != nil {
+		return serrors.WrapStr("validating BGP configuration", err)
+	}
+	s.mtx.Lock()
+	s.sessions = make(map[string]*session)
+	s.learned = make(map[string]map[string]*net.IPNet)
+	s.exported = make(map[string]*exportedEntry)
+	s.mtx.Unlock()
+
+	var wg sync.WaitGroup
+	listener := s.Listener
+	if listener == nil && s.Config.ListenAddr != "" {
+		var err error
+		listener, err = net.Listen("tcp", s.Config.ListenAddr)
+		if err != nil {
+			return serrors.WrapStr("listening for BGP connections", err,
+				"addr", s.Config.ListenAddr)
+		}
+	}
+	if listener != nil {
+		log.FromCtx(ctx).Info("BGP speaker listening", "addr", listener.Addr())
+		wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			s.accept(ctx, listener)
+		}()
+		go func() {
+			defer log.HandlePanic()
+			<-ctx.Done()
+			listener.Close()
+		}()
+	}
+	for _, p := range s.Config.Peers {
+		if p.Passive {
+			continue
+		}
+		p := p
+		wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			s.connect(ctx, p)
+		}()
+	}
+	if s.ExportedRoutes != nil {
+		consumer := s.ExportedRoutes.NewConsumer()
+	Loop:
+		for {
+			select {
+			case update, ok := <-consumer.Updates():
+				if !ok {
+					// The route database was closed.
+					break Loop
+				}
+				s.export(update)
+			case <-ctx.Done():
+				consumer.Close()
+				break Loop
+			}
+		}
+	}
+	<-ctx.Done()
+	wg.Wait()
+	return nil
+}
+
+// Prefixes returns the deduplicated prefixes that are announced by the
+// established peers.
+func (s *Speaker) Prefixes() []*net.IPNet {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	union := make(map[string]*net.IPNet)
+	for _, prefixes := range s.learned {
+		for k, p := range prefixes {
+			union[k] = p
+		}
+	}
+	return sortedPrefixes(union)
+}
+
+// Diagnostics contains diagnostic information about the speaker.
+type Diagnostics struct {
+	// Peers are the addresses of the established peers.
+	Peers []string `json:"peers"`
+	// Learned are the prefixes learned from the peers.
+	Learned []string `json:"learned"`
+	// Exported are the prefixes exported to the peers.
+	Exported []string `json:"exported"`
+}
+
+// Diagnostics returns a snapshot of the diagnostic information.
+func (s *Speaker) Diagnostics() Diagnostics {
+	d := Diagnostics{Peers: []string{}, Learned: []string{}, Exported: []string{}}
+	for _, p := range s.Prefixes() {
+		d.Learned = append(d.Learned, p.String())
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for peer := range s.sessions {
+		d.Peers = append(d.Peers, peer)
+	}
+	sort.Strings(d.Peers)
+	exported := make(map[string]*net.IPNet, len(s.exported))
+	for k, e := range s.exported {
+		exported[k] = e.prefix
+	}
+	for _, p := range sortedPrefixes(exported) {
+		d.Exported = append(d.Exported, p.String())
+	}
+	return d
+}
+
+func (s *Speaker) accept(ctx context.Context, listener net.Listener) {
+	logger := log.FromCtx(ctx)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Error("Accepting BGP connection failed", "err", err)
+			}
+			return
+		}
+		peer, ok := s.lookupPeer(conn.RemoteAddr())
+		if !ok {
+			logger.Info("Rejecting BGP connection from unknown peer",
+				"remote", conn.RemoteAddr())
+			conn.Close()
+			continue
+		}
+		go func() {
+			defer log.HandlePanic()
+			if err := s.runSession(ctx, conn, peer); err != nil {
+				logger.Info("BGP session closed", "peer", peer.Address, "err", err)
+			}
+		}()
+	}
+}
+
+func (s *Speaker) lookupPeer(remote net.Addr) (Peer, bool) {
+	tcpAddr, ok := remote.(*net.TCPAddr)
+	if !ok {
+		return Peer{}, false
+	}
+	for _, p := range s.Config.Peers {
+		a, err := peerAddr(p.Address)
+		if err == nil && a.IP.Equal(tcpAddr.IP) {
+			return p, true
+		}
+	}
+	return Peer{}, false
+}
+
+func (s *Speaker) connect(ctx context.Context, peer Peer) {
+	logger := log.FromCtx(ctx)
+	var dialer net.Dialer
+	for {
+		a, err := peerAddr(peer.Address)
+		if err == nil {
+			var conn net.Conn
+			conn, err = dialer.DialContext(ctx, "tcp", a.String())
+			if err == nil {
+				err = s.runSession(ctx, conn, peer)
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Info("BGP session to peer failed", "peer", peer.Address, "err", err)
+		select {
+		case <-time.After(s.Config.ConnectRetry):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runSession runs the BGP session on the connection until it fails or the
+// context is canceled. The connection is closed when the function returns.
+func (s *Speaker) runSession(ctx context.Context, conn net.Conn, peer Peer) error {
+	sess := &session{
+		conn:     conn,
+		peer:     peer,
+		localAS:  s.Config.LocalAS,
+		routerID: s.Config.RouterID,
+		holdTime: s.Config.HoldTime,
+		notify:   make(chan struct{}, 1),
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		defer log.HandlePanic()
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	if err := sess.open(); err != nil {
+		sess.close(notification(err))
+		return err
+	}
+	if err := s.register(sess); err != nil {
+		sess.close(&Notification{Code: ErrCease, Subcode: 7})
+		return err
+	}
+	defer s.unregister(sess)
+	log.FromCtx(ctx).Info("BGP session established", "peer", peer.Address,
+		"families", sess.families)
+
+	errs := make(chan error, 2)
+	go func() {
+		defer log.HandlePanic()
+		errs <- sess.writeLoop(ctx)
+	}()
+	go func() {
+		defer log.HandlePanic()
+		errs <- sess.readLoop(s.handleUpdate)
+	}()
+	err := <-errs
+	if ctx.Err() != nil {
+		sess.close(&Notification{Code: ErrCease, Subcode: 2})
+		return nil
+	}
+	sess.close(notification(err))
+	return err
+}
+
+// notification extracts the notification that is sent to the peer from the
+// error that caused the session to fail.
+func notification(err error) *Notification {
+	var n *Notification
+	if errors.As(err, &n) {
+		return n
+	}
+	return nil
+}
+
+// register adds the session to the established sessions and queues the
+// announcements of all exported prefixes.
+func (s *Speaker) register(sess *session) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.sessions[sess.peer.Address]; ok {
+		return serrors.New("session with peer already established", "peer", sess.peer.Address)
+	}
+	s.sessions[sess.peer.Address] = sess
+	s.learned[sess.peer.Address] = make(map[string]*net.IPNet)
+	exported := make(map[string]*net.IPNet, len(s.exported))
+	for k, e := range s.exported {
+		exported[k] = e.prefix
+	}
+	for _, p := range sortedPrefixes(exported) {
+		sess.enqueue(s.announcement(sess, p))
+	}
+	return nil
+}
+
+func (s *Speaker) unregister(sess *session) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.sessions[sess.peer.Address] != sess {
+		return
+	}
+	delete(s.sessions, sess.peer.Address)
+	delete(s.learned, sess.peer.Address)
+}
+
+// handleUpdate applies an UPDATE message received from the peer.
+func (s *Speaker) handleUpdate(sess *session, u *Update) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	learned, ok := s.learned[sess.peer.Address]
+	if !ok {
+		return
+	}
+	for _, p := range u.Withdrawn {
+		delete(learned, p.String())
+	}
+	// Routes that already traversed the local AS are unusable. They replace
+	// the previously learned routes, so they are treated as withdrawn.
+	loop := false
+	for _, as := range u.ASPath {
+		if as == s.Config.LocalAS {
+			loop = true
+			break
+		}
+	}
+	for _, p := range u.NLRI {
+		if loop {
+			delete(learned, p.String())
+			continue
+		}
+		learned[p.String()] = p
+	}
+}
+
+// export processes an update of the exported routes.
+func (s *Speaker) export(update control.RouteUpdate) {
+	if update.Prefix == nil {
+		return
+	}
+	key := update.Prefix.String()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	e, ok := s.exported[key]
+	switch {
+	case update.IsAdd && ok:
+		e.refCount++
+		return
+	case update.IsAdd:
+		s.exported[key] = &exportedEntry{prefix: update.Prefix, refCount: 1}
+		for _, sess := range s.sessions {
+			sess.enqueue(s.announcement(sess, update.Prefix))
+		}
+	case ok:
+		e.refCount--
+		if e.refCount > 0 {
+			return
+		}
+		delete(s.exported, key)
+		for _, sess := range s.sessions {
+			if sess.supports(familyOf(update.Prefix)) {
+				sess.enqueue(&Update{Withdrawn: []*net.IPNet{update.Prefix}})
+			}
+		}
+	}
+}
+
+// announcement creates the UPDATE message that announces the prefix to the
+// peer of the session. It returns nil if the prefix cannot be announced to the
+// peer.
+func (s *Speaker) announcement(sess *session, prefix *net.IPNet) Message {
+	family := familyOf(prefix)
+	if !sess.supports(family) {
+		return nil
+	}
+	nextHop := s.Config.NextHopIPv4
+	if family == IPv6Unicast {
+		nextHop = s.Config.NextHopIPv6
+	}
+	if nextHop == nil {
+		local := sess.conn.LocalAddr().(*net.TCPAddr).IP
+		if familyOf(&net.IPNet{IP: local}) == family {
+			nextHop = local
+		}
+	}
+	if nextHop == nil {
+		return nil
+	}
+	u := &Update{
+		NLRI:        []*net.IPNet{prefix},
+		Origin:      OriginIGP,
+		NextHop:     nextHop,
+		Communities: s.Config.Communities,
+	}
+	if sess.peer.AS != s.Config.LocalAS {
+		u.ASPath = []uint32{s.Config.LocalAS}
+	} else {
+		u.LocalPref = s.Config.LocalPref
+	}
+	return u
+}
+
+func sortedPrefixes(prefixes map[string]*net.IPNet) []*net.IPNet {
+	result := make([]*net.IPNet, 0, len(prefixes))
+	for _, p := range prefixes {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
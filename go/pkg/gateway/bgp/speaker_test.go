@@ -0,0 +1,306 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/routemgr"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/bgp"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+const (
+	localAS = 64512
+	peerAS  = 64513
+)
+
+func TestSpeakerExportImport(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	db := &routemgr.RouteDB{}
+	go db.Run()
+	defer db.Close()
+	pub := db.NewPublisher()
+	remoteIA := xtest.MustParseIA("1-ff00:0:110")
+	pub.AddRoute(control.Route{
+		Prefix:  cidr(t, "192.0.2.0/24"),
+		NextHop: net.IP{10, 0, 0, 1},
+		IA:      remoteIA,
+	})
+
+	community := uint32(localAS<<16 | 100)
+	speaker := &bgp.Speaker{
+		Config: bgp.Config{
+			LocalAS:      localAS,
+			RouterID:     net.IP{192, 0, 2, 1},
+			Peers:        []bgp.Peer{{Address: listener.Addr().String(), AS: peerAS}},
+			Communities:  []uint32{community},
+			NextHopIPv6:  net.ParseIP("2001:db8::1"),
+			ConnectRetry: 100 * time.Millisecond,
+		},
+		ExportedRoutes: db,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, speaker.Run(ctx))
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// Establish the session.
+	msg, err := bgp.ReadMessage(conn)
+	require.NoError(t, err)
+	open, ok := msg.(*bgp.Open)
+	require.True(t, ok, msg)
+	assert.Equal(t, uint32(localAS), open.AS)
+	assert.Equal(t, uint16(90), open.HoldTime)
+	assert.True(t, open.FourOctetAS)
+	assert.ElementsMatch(t, []bgp.Family{bgp.IPv4Unicast, bgp.IPv6Unicast}, open.Families)
+	require.NoError(t, bgp.WriteMessage(conn, &bgp.Open{
+		AS:       peerAS,
+		HoldTime: 3,
+		RouterID: net.IP{192, 0, 2, 2},
+		Families: []bgp.Family{bgp.IPv4Unicast, bgp.IPv6Unicast},
+	}))
+	require.NoError(t, bgp.WriteMessage(conn, bgp.Keepalive{}))
+	msg, err = bgp.ReadMessage(conn)
+	require.NoError(t, err)
+	assert.Equal(t, bgp.Keepalive{}, msg)
+
+	t.Run("export existing route", func(t *testing.T) {
+		u := readUpdate(t, conn)
+		assert.Equal(t, &bgp.Update{
+			NLRI:        []*net.IPNet{cidr(t, "192.0.2.0/24")},
+			Origin:      bgp.OriginIGP,
+			ASPath:      []uint32{localAS},
+			NextHop:     net.IP{127, 0, 0, 1},
+			Communities: []uint32{community},
+		}, u)
+	})
+	t.Run("export new IPv6 route", func(t *testing.T) {
+		pub.AddRoute(control.Route{
+			Prefix:  cidr(t, "2001:db8:2::/48"),
+			NextHop: net.ParseIP("fd00::1"),
+			IA:      remoteIA,
+		})
+		u := readUpdate(t, conn)
+		assert.Equal(t, []*net.IPNet{cidr(t, "2001:db8:2::/48")}, u.NLRI)
+		assert.Equal(t, net.ParseIP("2001:db8::1"), u.NextHop)
+	})
+	t.Run("withdraw route", func(t *testing.T) {
+		pub.DeleteRoute(control.Route{
+			Prefix:  cidr(t, "192.0.2.0/24"),
+			NextHop: net.IP{10, 0, 0, 1},
+			IA:      remoteIA,
+		})
+		u := readUpdate(t, conn)
+		assert.Equal(t, &bgp.Update{Withdrawn: []*net.IPNet{cidr(t, "192.0.2.0/24")}}, u)
+	})
+	t.Run("import routes", func(t *testing.T) {
+		require.NoError(t, bgp.WriteMessage(conn, &bgp.Update{
+			NLRI:    []*net.IPNet{cidr(t, "10.1.0.0/16"), cidr(t, "10.2.0.0/16")},
+			ASPath:  []uint32{peerAS},
+			NextHop: net.IP{127, 0, 0, 2},
+		}))
+		// Routes that contain the local AS in the path are discarded.
+		require.NoError(t, bgp.WriteMessage(conn, &bgp.Update{
+			NLRI:    []*net.IPNet{cidr(t, "10.9.0.0/16")},
+			ASPath:  []uint32{peerAS, localAS},
+			NextHop: net.IP{127, 0, 0, 2},
+		}))
+		require.NoError(t, bgp.WriteMessage(conn, &bgp.Update{
+			NLRI:    []*net.IPNet{cidr(t, "2001:db8:1::/48")},
+			ASPath:  []uint32{peerAS},
+			NextHop: net.ParseIP("2001:db8::2"),
+		}))
+		expected := []*net.IPNet{
+			cidr(t, "10.1.0.0/16"),
+			cidr(t, "10.2.0.0/16"),
+			cidr(t, "2001:db8:1::/48"),
+		}
+		assert.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual(expected, speaker.Prefixes())
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, bgp.WriteMessage(conn, &bgp.Update{
+			Withdrawn: []*net.IPNet{cidr(t, "10.2.0.0/16")},
+		}))
+		expected = []*net.IPNet{cidr(t, "10.1.0.0/16"), cidr(t, "2001:db8:1::/48")}
+		assert.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual(expected, speaker.Prefixes())
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("looped route", func(t *testing.T) {
+		// A route that now contains the local AS in the path replaces the
+		// previously learned route, which is thus withdrawn.
+		require.NoError(t, bgp.WriteMessage(conn, &bgp.Update{
+			NLRI:    []*net.IPNet{cidr(t, "10.1.0.0/16")},
+			ASPath:  []uint32{peerAS, localAS},
+			NextHop: net.IP{127, 0, 0, 2},
+		}))
+		expected := []*net.IPNet{cidr(t, "2001:db8:1::/48")}
+		assert.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual(expected, speaker.Prefixes())
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("session closed", func(t *testing.T) {
+		conn.Close()
+		assert.Eventually(t, func() bool {
+			return len(speaker.Prefixes()) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestSpeakerBadPeerAS(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	speaker := &bgp.Speaker{
+		Config: bgp.Config{
+			LocalAS:  localAS,
+			RouterID: net.IP{192, 0, 2, 1},
+			Peers:    []bgp.Peer{{Address: listener.Addr().String(), AS: peerAS}},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, speaker.Run(ctx))
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	_, err = bgp.ReadMessage(conn)
+	require.NoError(t, err)
+	require.NoError(t, bgp.WriteMessage(conn, &bgp.Open{
+		AS:       peerAS + 1,
+		HoldTime: 90,
+		RouterID: net.IP{192, 0, 2, 2},
+	}))
+	msg, err := bgp.ReadMessage(conn)
+	require.NoError(t, err)
+	assert.Equal(t, &bgp.Notification{Code: bgp.ErrOpenMessage, Subcode: 2}, msg)
+}
+
+func TestSpeakerPassivePeer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	db := &routemgr.RouteDB{}
+	go db.Run()
+	defer db.Close()
+	db.NewPublisher().AddRoute(control.Route{
+		Prefix:  cidr(t, "192.0.2.0/24"),
+		NextHop: net.IP{10, 0, 0, 1},
+		IA:      xtest.MustParseIA("1-ff00:0:110"),
+	})
+
+	speaker := &bgp.Speaker{
+		Config: bgp.Config{
+			LocalAS:  localAS,
+			RouterID: net.IP{192, 0, 2, 1},
+			Peers:    []bgp.Peer{{Address: "127.0.0.1", AS: localAS, Passive: true}},
+		},
+		Listener:       listener,
+		ExportedRoutes: db,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, speaker.Run(ctx))
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	require.NoError(t, bgp.WriteMessage(conn, &bgp.Open{
+		AS:       localAS,
+		HoldTime: 90,
+		RouterID: net.IP{192, 0, 2, 2},
+		Families: []bgp.Family{bgp.IPv4Unicast},
+	}))
+	msg, err := bgp.ReadMessage(conn)
+	require.NoError(t, err)
+	require.IsType(t, &bgp.Open{}, msg)
+	require.NoError(t, bgp.WriteMessage(conn, bgp.Keepalive{}))
+	msg, err = bgp.ReadMessage(conn)
+	require.NoError(t, err)
+	assert.Equal(t, bgp.Keepalive{}, msg)
+
+	// iBGP routes are announced without AS path, but with LOCAL_PREF.
+	assert.Equal(t, &bgp.Update{
+		NLRI:      []*net.IPNet{cidr(t, "192.0.2.0/24")},
+		Origin:    bgp.OriginIGP,
+		NextHop:   net.IP{127, 0, 0, 1},
+		LocalPref: bgp.DefaultLocalPref,
+	}, readUpdate(t, conn))
+
+	// iBGP routes are accepted without AS path.
+	require.NoError(t, bgp.WriteMessage(conn, &bgp.Update{
+		NLRI:    []*net.IPNet{cidr(t, "10.1.0.0/16")},
+		NextHop: net.IP{127, 0, 0, 2},
+	}))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]*net.IPNet{cidr(t, "10.1.0.0/16")}, speaker.Prefixes())
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"127.0.0.1"}, speaker.Diagnostics().Peers)
+}
+
+// readUpdate reads the next UPDATE message, skipping keepalives.
+func readUpdate(t *testing.T, conn net.Conn) *bgp.Update {
+	for {
+		msg, err := bgp.ReadMessage(conn)
+		require.NoError(t, err)
+		if u, ok := msg.(*bgp.Update); ok {
+			return u
+		}
+		require.Equal(t, bgp.Keepalive{}, msg)
+	}
+}
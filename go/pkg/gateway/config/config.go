@@ -18,8 +18,14 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/pkg/gateway/bgp"
+	"github.com/scionproto/scion/go/pkg/gateway/encryption"
+	"github.com/scionproto/scion/go/pkg/gateway/ha"
 )
 
 // Defaults.
@@ -33,6 +39,8 @@ const (
 
 	DefaultTunnelName           = "sig"
 	DefaultTunnelRoutingTableID = 11
+
+	DefaultTunnelTrustDB = "/share/data/gateway.trust.db"
 )
 
 // Gateway holds the gateway specific configuration.
@@ -101,6 +109,218 @@ func (cfg *Tunnel) ConfigName() string {
 	return "tunnel"
 }
 
+// TunnelEncryption holds the configuration for encrypting the traffic between
+// gateways.
+type TunnelEncryption struct {
+	config.NoDefaulter
+
+	// ConfigDir is the directory that contains the TRCs (in certs/) and the AS
+	// certificate chain and key (in crypto/as/). If empty, the gateway does not
+	// support encryption. If set, the certificate chain is also used to
+	// authenticate the QUIC connections to remote gateways.
+	ConfigDir string `toml:"config_dir,omitempty"`
+	// TrustDB is the connection string of the trust database.
+	TrustDB string `toml:"trust_db,omitempty"`
+	// RekeyInterval is the interval after which new session keys are
+	// established.
+	RekeyInterval util.DurWrap `toml:"rekey_interval,omitempty"`
+	// AllowUnauthenticatedPeers indicates that remote gateways that cannot be
+	// authenticated with their AS certificate chain are accepted on the QUIC
+	// connections.
+	AllowUnauthenticatedPeers bool `toml:"allow_unauthenticated_peers,omitempty"`
+}
+
+func (cfg *TunnelEncryption) Validate() error {
+	if cfg.TrustDB == "" {
+		cfg.TrustDB = DefaultTunnelTrustDB
+	}
+	if cfg.RekeyInterval.Duration == 0 {
+		cfg.RekeyInterval.Duration = encryption.DefaultRekeyInterval
+	}
+	if cfg.RekeyInterval.Duration < time.Minute {
+		return serrors.New("rekey_interval must be at least 1m",
+			"rekey_interval", cfg.RekeyInterval)
+	}
+	return nil
+}
+
+func (cfg *TunnelEncryption) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, tunnelEncryptionSample)
+}
+
+func (cfg *TunnelEncryption) ConfigName() string {
+	return "tunnel_encryption"
+}
+
+// BGP holds the configuration of the embedded BGP speaker.
+type BGP struct {
+	config.NoDefaulter
+
+	// LocalAS is the AS number of the BGP speaker. If zero, the BGP speaker is
+	// disabled.
+	LocalAS uint32 `toml:"local_as,omitempty"`
+	// RouterID is the BGP identifier of the speaker. It must be an IPv4
+	// address.
+	RouterID net.IP `toml:"router_id,omitempty"`
+	// ListenAddr is the address on which the speaker accepts connections from
+	// passive peers.
+	ListenAddr string `toml:"listen_addr,omitempty"`
+	// Peers are the BGP neighbors.
+	Peers []BGPPeer `toml:"peers,omitempty"`
+	// Communities are attached to the routes that are exported to the peers.
+	// The communities are in the "<AS>:<value>" notation.
+	Communities []string `toml:"communities,omitempty"`
+	// NextHopIPv4 is the next hop of the exported IPv4 routes.
+	NextHopIPv4 net.IP `toml:"next_hop_ipv4,omitempty"`
+	// NextHopIPv6 is the next hop of the exported IPv6 routes.
+	NextHopIPv6 net.IP `toml:"next_hop_ipv6,omitempty"`
+	// LocalPref is the LOCAL_PREF attribute of the routes that are exported
+	// to internal peers.
+	LocalPref uint32 `toml:"local_pref,omitempty"`
+	// HoldTime is the hold time proposed to the peers.
+	HoldTime util.DurWrap `toml:"hold_time,omitempty"`
+}
+
+// BGPPeer holds the configuration of a BGP neighbor.
+type BGPPeer struct {
+	// Address is the address of the peer. If the port is not specified, the
+	// default BGP port is used.
+	Address string `toml:"address,omitempty"`
+	// AS is the AS number of the peer.
+	AS uint32 `toml:"as,omitempty"`
+	// Passive indicates that the speaker waits for the peer to connect.
+	Passive bool `toml:"passive,omitempty"`
+}
+
+// Enabled indicates whether the BGP speaker is enabled.
+func (cfg *BGP) Enabled() bool {
+	return cfg.LocalAS != 0
+}
+
+func (cfg *BGP) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.HoldTime.Duration == 0 {
+		cfg.HoldTime.Duration = bgp.DefaultHoldTime
+	}
+	if cfg.LocalPref == 0 {
+		cfg.LocalPref = bgp.DefaultLocalPref
+	}
+	speakerCfg, err := cfg.SpeakerConfig()
+	if err != nil {
+		return err
+	}
+	return speakerCfg.Validate()
+}
+
+// SpeakerConfig returns the configuration of the BGP speaker.
+func (cfg *BGP) SpeakerConfig() (*bgp.Config, error) {
+	speakerCfg := &bgp.Config{
+		LocalAS:     cfg.LocalAS,
+		RouterID:    cfg.RouterID,
+		ListenAddr:  cfg.ListenAddr,
+		NextHopIPv4: cfg.NextHopIPv4,
+		NextHopIPv6: cfg.NextHopIPv6,
+		LocalPref:   cfg.LocalPref,
+		HoldTime:    cfg.HoldTime.Duration,
+	}
+	for _, p := range cfg.Peers {
+		speakerCfg.Peers = append(speakerCfg.Peers, bgp.Peer{
+			Address: p.Address,
+			AS:      p.AS,
+			Passive: p.Passive,
+		})
+	}
+	for _, c := range cfg.Communities {
+		community, err := bgp.ParseCommunity(c)
+		if err != nil {
+			return nil, err
+		}
+		speakerCfg.Communities = append(speakerCfg.Communities, community)
+	}
+	return speakerCfg, nil
+}
+
+func (cfg *BGP) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, bgpSample)
+}
+
+func (cfg *BGP) ConfigName() string {
+	return "bgp"
+}
+
+// HA holds the configuration of the active/standby high availability group.
+type HA struct {
+	config.NoDefaulter
+
+	// ID identifies the gateway within the group. If empty, the gateway ID is
+	// used.
+	ID string `toml:"id,omitempty"`
+	// Priority is the election priority of the gateway. The gateway with the
+	// highest priority is preferred as leader.
+	Priority uint8 `toml:"priority,omitempty"`
+	// ListenAddr is the UDP address on which heartbeats are received.
+	ListenAddr string `toml:"listen_addr,omitempty"`
+	// Peers are the UDP heartbeat addresses of the other gateways in the
+	// group. If empty, high availability is disabled.
+	Peers []string `toml:"peers,omitempty"`
+	// HeartbeatInterval is the interval between heartbeats.
+	HeartbeatInterval util.DurWrap `toml:"heartbeat_interval,omitempty"`
+	// DeadInterval is the time after which a peer is considered dead if no
+	// heartbeat was received.
+	DeadInterval util.DurWrap `toml:"dead_interval,omitempty"`
+}
+
+// Enabled indicates whether high availability is enabled.
+func (cfg *HA) Enabled() bool {
+	return len(cfg.Peers) != 0
+}
+
+func (cfg *HA) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.ListenAddr == "" {
+		return serrors.New("listen_addr must be set")
+	}
+	if cfg.HeartbeatInterval.Duration == 0 {
+		cfg.HeartbeatInterval.Duration = ha.DefaultHeartbeatInterval
+	}
+	if cfg.DeadInterval.Duration == 0 {
+		cfg.DeadInterval.Duration = ha.DefaultDeadInterval
+	}
+	// If no ID is set, the gateway ID is used. It is not known here, thus,
+	// validate with a placeholder.
+	electorCfg := cfg.ElectorConfig("gateway")
+	return electorCfg.Validate()
+}
+
+// ElectorConfig returns the configuration of the leader election. The
+// gatewayID is used if no explicit ID is configured.
+func (cfg *HA) ElectorConfig(gatewayID string) ha.Config {
+	id := cfg.ID
+	if id == "" {
+		id = gatewayID
+	}
+	return ha.Config{
+		ID:                id,
+		Priority:          cfg.Priority,
+		ListenAddr:        cfg.ListenAddr,
+		Peers:             cfg.Peers,
+		HeartbeatInterval: cfg.HeartbeatInterval.Duration,
+		DeadInterval:      cfg.DeadInterval.Duration,
+	}
+}
+
+func (cfg *HA) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, haSample)
+}
+
+func (cfg *HA) ConfigName() string {
+	return "ha"
+}
+
 // DefaultAddress determines the default address. If port is not specified, or
 // is zero, it is set to the default port. If the input is garbage, the output
 // is garbage as well.
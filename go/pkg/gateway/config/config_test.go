@@ -16,11 +16,14 @@ package config_test
 
 import (
 	"bytes"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/pelletier/go-toml"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/gateway/config"
 	"github.com/scionproto/scion/go/pkg/gateway/config/configtest"
 )
@@ -47,6 +50,151 @@ func TestTunnelSample(t *testing.T) {
 	configtest.CheckTunnel(t, &cfg)
 }
 
+func TestTunnelEncryptionSample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg config.TunnelEncryption
+	cfg.Sample(&sample, nil, nil)
+
+	configtest.InitTunnelEncryption(&cfg)
+	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
+	assert.NoError(t, err)
+	configtest.CheckTunnelEncryption(t, &cfg)
+}
+
+func TestBGPSample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg config.BGP
+	cfg.Sample(&sample, nil, nil)
+
+	configtest.InitBGP(&cfg)
+	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
+	assert.NoError(t, err)
+	configtest.CheckBGP(t, &cfg)
+}
+
+func TestBGPValidate(t *testing.T) {
+	valid := func() config.BGP {
+		return config.BGP{
+			LocalAS:     64512,
+			RouterID:    net.ParseIP("192.0.2.100"),
+			Peers:       []config.BGPPeer{{Address: "192.0.2.1", AS: 64513}},
+			Communities: []string{"64512:100"},
+		}
+	}
+	testCases := map[string]struct {
+		Modify              func(cfg *config.BGP)
+		AssertErr           assert.ErrorAssertionFunc
+		ExpectedCommunities []uint32
+	}{
+		"disabled": {
+			Modify:    func(cfg *config.BGP) { *cfg = config.BGP{} },
+			AssertErr: assert.NoError,
+		},
+		"valid": {
+			Modify:              func(*config.BGP) {},
+			AssertErr:           assert.NoError,
+			ExpectedCommunities: []uint32{64512<<16 | 100},
+		},
+		"missing router ID": {
+			Modify:    func(cfg *config.BGP) { cfg.RouterID = nil },
+			AssertErr: assert.Error,
+		},
+		"invalid community": {
+			Modify:    func(cfg *config.BGP) { cfg.Communities = []string{"100"} },
+			AssertErr: assert.Error,
+		},
+		"missing peer AS": {
+			Modify:    func(cfg *config.BGP) { cfg.Peers[0].AS = 0 },
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := valid()
+			tc.Modify(&cfg)
+			err := cfg.Validate()
+			tc.AssertErr(t, err)
+			if err != nil || !cfg.Enabled() {
+				return
+			}
+			speakerCfg, err := cfg.SpeakerConfig()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedCommunities, speakerCfg.Communities)
+		})
+	}
+}
+
+func TestHASample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg config.HA
+	cfg.Sample(&sample, nil, nil)
+
+	configtest.InitHA(&cfg)
+	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
+	assert.NoError(t, err)
+	configtest.CheckHA(t, &cfg)
+}
+
+func TestHAValidate(t *testing.T) {
+	valid := func() config.HA {
+		return config.HA{
+			ListenAddr: "192.0.2.10:30956",
+			Peers:      []string{"192.0.2.11:30956"},
+		}
+	}
+	testCases := map[string]struct {
+		Modify     func(cfg *config.HA)
+		AssertErr  assert.ErrorAssertionFunc
+		ExpectedID string
+	}{
+		"disabled": {
+			Modify:    func(cfg *config.HA) { *cfg = config.HA{} },
+			AssertErr: assert.NoError,
+		},
+		"valid": {
+			Modify:     func(*config.HA) {},
+			AssertErr:  assert.NoError,
+			ExpectedID: "gw",
+		},
+		"explicit ID": {
+			Modify:     func(cfg *config.HA) { cfg.ID = "gw-a" },
+			AssertErr:  assert.NoError,
+			ExpectedID: "gw-a",
+		},
+		"missing listen address": {
+			Modify:    func(cfg *config.HA) { cfg.ListenAddr = "" },
+			AssertErr: assert.Error,
+		},
+		"invalid peer": {
+			Modify:    func(cfg *config.HA) { cfg.Peers = []string{"192.0.2.11"} },
+			AssertErr: assert.Error,
+		},
+		"dead interval too short": {
+			Modify: func(cfg *config.HA) {
+				cfg.HeartbeatInterval = util.DurWrap{Duration: time.Second}
+				cfg.DeadInterval = util.DurWrap{Duration: time.Second}
+			},
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := valid()
+			tc.Modify(&cfg)
+			err := cfg.Validate()
+			tc.AssertErr(t, err)
+			if err != nil || !cfg.Enabled() {
+				return
+			}
+			assert.Equal(t, tc.ExpectedID, cfg.ElectorConfig("gw").ID)
+		})
+	}
+}
+
 func TestDefaultAddress(t *testing.T) {
 	testCases := map[string]struct {
 		Input    string
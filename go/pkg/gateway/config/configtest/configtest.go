@@ -15,11 +15,15 @@
 package configtest
 
 import (
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/go/pkg/gateway/config"
+	"github.com/scionproto/scion/go/pkg/gateway/encryption"
+	"github.com/scionproto/scion/go/pkg/gateway/ha"
 )
 
 func InitGateway(cfg *config.Gateway) {}
@@ -38,3 +42,38 @@ func InitTunnel(cfg *config.Tunnel) {}
 func CheckTunnel(t *testing.T, cfg *config.Tunnel) {
 	assert.Equal(t, config.DefaultTunnelName, cfg.Name)
 }
+
+func InitTunnelEncryption(cfg *config.TunnelEncryption) {}
+
+func CheckTunnelEncryption(t *testing.T, cfg *config.TunnelEncryption) {
+	assert.Empty(t, cfg.ConfigDir)
+	assert.Equal(t, config.DefaultTunnelTrustDB, cfg.TrustDB)
+	assert.Equal(t, encryption.DefaultRekeyInterval, cfg.RekeyInterval.Duration)
+	assert.False(t, cfg.AllowUnauthenticatedPeers)
+}
+
+func InitBGP(cfg *config.BGP) {}
+
+func CheckBGP(t *testing.T, cfg *config.BGP) {
+	assert.False(t, cfg.Enabled())
+	assert.Equal(t, net.ParseIP("192.0.2.100"), cfg.RouterID)
+	assert.Empty(t, cfg.ListenAddr)
+	assert.Equal(t, []config.BGPPeer{{Address: "192.0.2.1", AS: 64513}}, cfg.Peers)
+	assert.Equal(t, []string{"64512:100"}, cfg.Communities)
+	assert.Equal(t, net.ParseIP("192.0.2.100"), cfg.NextHopIPv4)
+	assert.Equal(t, net.ParseIP("2001:db8::2:1"), cfg.NextHopIPv6)
+	assert.Equal(t, uint32(100), cfg.LocalPref)
+	assert.Equal(t, 90*time.Second, cfg.HoldTime.Duration)
+}
+
+func InitHA(cfg *config.HA) {}
+
+func CheckHA(t *testing.T, cfg *config.HA) {
+	assert.False(t, cfg.Enabled())
+	assert.Equal(t, "gateway-a", cfg.ID)
+	assert.Equal(t, uint8(100), cfg.Priority)
+	assert.Equal(t, "192.0.2.10:30956", cfg.ListenAddr)
+	assert.Empty(t, cfg.Peers)
+	assert.Equal(t, ha.DefaultHeartbeatInterval, cfg.HeartbeatInterval.Duration)
+	assert.Equal(t, ha.DefaultDeadInterval, cfg.DeadInterval.Duration)
+}
@@ -69,3 +69,99 @@ src_ipv4 = "192.0.2.100"
 # (default "")
 src_ipv6 = "2001:db8::2:1"
 `
+
+const tunnelEncryptionSample = `
+# The directory that contains the TRCs (in certs/) and the AS certificate chain
+# and key (in crypto/as/) that are used to authenticate the key exchange and
+# the QUIC connections with remote gateways and control services. If empty,
+# the gateway neither initiates nor answers key exchanges, sessions that
+# require encryption drop their traffic, and remote gateways are not
+# authenticated. The gateway then presents a self-signed certificate that
+# control services do not authenticate, and it can only use their discovery
+# service. To migrate, set config_dir to a directory with the AS certificate
+# chain and key of the gateway, e.g., the config directory of the control
+# service. (default "")
+config_dir = ""
+
+# The connection string of the database that caches the certificate chains of
+# remote ASes. (default "/share/data/gateway.trust.db")
+trust_db = "/share/data/gateway.trust.db"
+
+# The interval after which the keys of encrypted sessions are replaced. Must be
+# at least 1m. (default "10m")
+rekey_interval = "10m"
+
+# Accept remote gateways that cannot be authenticated with their AS certificate
+# chain on the QUIC connections. This is meant for migrating from
+# unauthenticated connections and only applies if config_dir is set.
+# (default false)
+allow_unauthenticated_peers = false
+`
+
+const bgpSample = `
+# The AS number of the embedded BGP speaker. The speaker exports the prefixes
+# learned from remote gateways to the BGP peers, and imports the prefixes that
+# are redistributed to remote gateways according to the redistribute-bgp rules
+# of the IP routing policy. If zero, the BGP speaker is disabled. (default 0)
+local_as = 0
+
+# The BGP identifier of the speaker. It must be an IPv4 address. (default "")
+router_id = "192.0.2.100"
+
+# The address on which the speaker accepts connections from passive peers. If
+# empty, the speaker only connects to the peers. (default "")
+listen_addr = ""
+
+# The BGP neighbors. If no port is specified in the address, the default port
+# 179 is used. If passive is set, the speaker waits for the peer to connect.
+# (default [])
+peers = [
+    { address = "192.0.2.1", as = 64513, passive = false },
+]
+
+# The communities that are attached to the exported routes. (default [])
+communities = ["64512:100"]
+
+# The next hop of exported IPv4 routes. If empty, the local address of the BGP
+# session is used. (default "")
+next_hop_ipv4 = "192.0.2.100"
+
+# The next hop of exported IPv6 routes. If empty, the local address of the BGP
+# session is used. (default "")
+next_hop_ipv6 = "2001:db8::2:1"
+
+# The LOCAL_PREF attribute of the routes that are exported to internal peers,
+# i.e., peers in the local AS. (default 100)
+local_pref = 100
+
+# The hold time that is proposed to the peers. (default "90s")
+hold_time = "90s"
+`
+
+const haSample = `
+# The ID of the gateway within the high availability group. It must be unique
+# within the group. If empty, the gateway ID is used. (default "")
+id = "gateway-a"
+
+# The election priority of the gateway. The live gateway with the highest
+# priority becomes the leader, unless another gateway is already the leader.
+# (default 0)
+priority = 100
+
+# The UDP address on which heartbeats from the other gateways in the group are
+# received. (default "")
+listen_addr = "192.0.2.10:30956"
+
+# The UDP heartbeat addresses of the other gateways in the group. The leader
+# advertises its prefixes to remote gateways as active, the other gateways
+# advertise them as standby. If empty, high availability is disabled.
+# (default [])
+peers = []
+
+# The interval between heartbeats. (default "500ms")
+heartbeat_interval = "500ms"
+
+# The time after which a gateway is considered dead, if no heartbeat was
+# received from it. (default "2s")
+dead_interval = "2s"
+`
@@ -132,3 +132,12 @@ func (n *ConfigPublisher) RoutingPolicy() *routing.Policy {
 	}
 	return n.routingPolicy.Copy()
 }
+
+// EncryptionRequired reports whether the last published session policies
+// require encryption for the traffic with the remote AS.
+func (n *ConfigPublisher) EncryptionRequired(ia addr.IA) bool {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+
+	return n.sessionPolicies.EncryptionRequired(ia)
+}
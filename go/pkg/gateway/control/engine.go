@@ -33,6 +33,7 @@ import (
 	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
+	gatewaypb "github.com/scionproto/scion/go/pkg/proto/gateway"
 	"github.com/scionproto/scion/go/pkg/worker"
 )
 
@@ -70,6 +71,10 @@ type Engine struct {
 	// DataplaneSessionFactory is used to construct dataplane sessions.
 	DataplaneSessionFactory DataplaneSessionFactory
 
+	// TunnelKeyExchangerFactory is used to construct the key exchangers for
+	// encrypted sessions. If nil, all sessions are unencrypted.
+	TunnelKeyExchangerFactory TunnelKeyExchangerFactory
+
 	// Metrics are the metrics which are modified during the operation of the engine.
 	// If empty, no metrics are reported.
 	Metrics EngineMetrics
@@ -287,6 +292,10 @@ func (e *Engine) initWorkers(ctx context.Context) error {
 			config.IA,
 			config.Gateway.Data,
 		)
+		var keyExchanger TunnelKeyExchanger
+		if e.TunnelKeyExchangerFactory != nil {
+			keyExchanger = e.TunnelKeyExchangerFactory.New(config, dataplaneSession)
+		}
 		remoteIA := config.IA
 		pathMonitorRegistration := e.PathMonitor.Register(
 			ctx,
@@ -317,12 +326,13 @@ func (e *Engine) initWorkers(ctx context.Context) error {
 		}
 
 		sessionMonitor := &SessionMonitor{
-			ID:        config.ID,
-			RemoteIA:  remoteIA,
-			ProbeAddr: config.Gateway.Probe,
-			Events:    sessionMonitorEvents,
-			Paths:     pathMonitorRegistration,
-			ProbeConn: probeConn,
+			ID:           config.ID,
+			RemoteIA:     remoteIA,
+			ProbeAddr:    config.Gateway.Probe,
+			Events:       sessionMonitorEvents,
+			Paths:        pathMonitorRegistration,
+			ProbeConn:    probeConn,
+			KeyExchanger: keyExchanger,
 			Metrics: SessionMonitorMetrics{
 				Probes: metrics.CounterWith(
 					e.Metrics.SessionMonitorMetrics.Probes, labels...),
@@ -454,6 +464,28 @@ type DataplaneSessionFactory interface {
 	New(sessID uint8, policyID int, remoteIA addr.IA, remoteAddr net.Addr) DataplaneSession
 }
 
+// TunnelKeyExchanger establishes and refreshes the keys that protect the traffic
+// of a session. The key exchange messages are sent over the probe channel.
+type TunnelKeyExchanger interface {
+	// Request returns the key exchange request that should be sent to the
+	// remote gateway. It returns nil if no key exchange is due.
+	Request(ctx context.Context) (*gatewaypb.KeyExchangeRequest, error)
+	// HandleResponse processes the key exchange response of the remote
+	// gateway.
+	HandleResponse(ctx context.Context, rep *gatewaypb.KeyExchangeResponse) error
+	// Reset forces a new key exchange.
+	Reset()
+}
+
+// TunnelKeyExchangerFactory is used to construct the key exchanger of a
+// session.
+type TunnelKeyExchangerFactory interface {
+	// New returns the key exchanger for the session and sets up the dataplane
+	// session to protect its traffic. It returns nil if the session is not
+	// encrypted.
+	New(config *SessionConfig, dataplaneSession DataplaneSession) TunnelKeyExchanger
+}
+
 // PathMonitor is used to construct registrations for path discovery.
 type PathMonitor interface {
 	Register(ctx context.Context, ia addr.IA, policies *policies.Policies,
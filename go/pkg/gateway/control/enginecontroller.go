@@ -217,6 +217,10 @@ type DefaultEngineFactory struct {
 	// DataplaneSessionFactory is used to construct dataplane sessions.
 	DataplaneSessionFactory DataplaneSessionFactory
 
+	// TunnelKeyExchangerFactory is used to construct the key exchangers for
+	// encrypted sessions. If nil, all sessions are unencrypted.
+	TunnelKeyExchangerFactory TunnelKeyExchangerFactory
+
 	// Metrics contains the metrics that will be modified during engine operation. If empty, no
 	// metrics are reported.
 	Metrics EngineMetrics
@@ -230,13 +234,14 @@ func (f *DefaultEngineFactory) New(table RoutingTable,
 		// The new forwarding engine uses a completely fresh routing table
 		// for the data-plane, built based on the data collected in the new
 		// session configurations.
-		RoutingTable:            table,
-		RoutingTableIndices:     routingTableIndices,
-		PathMonitor:             f.PathMonitor,
-		ProbeConnFactory:        f.ProbeConnFactory,
-		DeviceManager:           f.DeviceManager,
-		DataplaneSessionFactory: f.DataplaneSessionFactory,
-		Metrics:                 f.Metrics,
+		RoutingTable:              table,
+		RoutingTableIndices:       routingTableIndices,
+		PathMonitor:               f.PathMonitor,
+		ProbeConnFactory:          f.ProbeConnFactory,
+		DeviceManager:             f.DeviceManager,
+		DataplaneSessionFactory:   f.DataplaneSessionFactory,
+		TunnelKeyExchangerFactory: f.TunnelKeyExchangerFactory,
+		Metrics:                   f.Metrics,
 	}
 }
 
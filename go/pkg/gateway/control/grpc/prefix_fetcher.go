@@ -33,10 +33,12 @@ type PrefixFetcher struct {
 	Dialer grpc.Dialer
 }
 
-func (f PrefixFetcher) Prefixes(ctx context.Context, gateway *net.UDPAddr) ([]*net.IPNet, error) {
+func (f PrefixFetcher) Prefixes(ctx context.Context,
+	gateway *net.UDPAddr) ([]*net.IPNet, bool, error) {
+
 	paths := f.Pather.Get().Paths
 	if len(paths) == 0 {
-		return nil, serrors.New("no path available")
+		return nil, false, serrors.New("no path available")
 	}
 	conn, err := f.Dialer.Dial(ctx, &snet.UDPAddr{
 		IA:      f.Remote,
@@ -45,13 +47,13 @@ func (f PrefixFetcher) Prefixes(ctx context.Context, gateway *net.UDPAddr) ([]*n
 		Host:    gateway,
 	})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	defer conn.Close()
 	client := gpb.NewIPPrefixesServiceClient(conn)
 	rep, err := client.Prefixes(ctx, &gpb.PrefixesRequest{}, grpc.RetryProfile...)
 	if err != nil {
-		return nil, serrors.WrapStr("receiving IP prefixes", err)
+		return nil, false, serrors.WrapStr("receiving IP prefixes", err)
 	}
 	prefixes := make([]*net.IPNet, 0, len(rep.Prefixes))
 	for _, pb := range rep.Prefixes {
@@ -64,5 +66,5 @@ func (f PrefixFetcher) Prefixes(ctx context.Context, gateway *net.UDPAddr) ([]*n
 			Mask: mask,
 		})
 	}
-	return prefixes, nil
+	return prefixes, rep.Standby, nil
 }
@@ -33,12 +33,22 @@ type Advertiser interface {
 	AdvertiseList(from, to addr.IA) []*net.IPNet
 }
 
+// LeaderChecker reports whether the local gateway is the leader of its high
+// availability group.
+type LeaderChecker interface {
+	Leader() bool
+}
+
 // IPPrefixServer serves IP prefix requests.
 type IPPrefixServer struct {
 	// LocalIA is the IA of the local AS.
 	LocalIA addr.IA
 	// Advertiser is the advertiser used to get the list of prefixes to advertise.
 	Advertiser Advertiser
+	// HA reports the high availability role of the local gateway. If the
+	// local gateway is not the leader, the prefixes are marked as standby. If
+	// nil, the local gateway is never standby.
+	HA LeaderChecker
 	// PrefixesAdvertised reports the number of IP prefixes advertised. If nil, no  metrics are
 	// reported.
 	PrefixesAdvertised metrics.Gauge
@@ -72,6 +82,7 @@ func (s IPPrefixServer) Prefixes(ctx context.Context,
 	}
 	return &gpb.PrefixesResponse{
 		Prefixes: pb,
+		Standby:  s.HA != nil && !s.HA.Leader(),
 	}, nil
 }
 
@@ -39,8 +39,10 @@ func TestIPPrefixServerPrefixes(t *testing.T) {
 	testCases := map[string]struct {
 		Advertiser   func(t *testing.T, ctrl *gomock.Controller) grpc.Advertiser
 		Request      func() (context.Context, *gpb.PrefixesRequest)
+		HA           grpc.LeaderChecker
 		ErrAssertion assert.ErrorAssertionFunc
 		Expected     []*net.IPNet
+		Standby      bool
 	}{
 		"valid": {
 			Advertiser: func(t *testing.T, ctrl *gomock.Controller) grpc.Advertiser {
@@ -59,6 +61,39 @@ func TestIPPrefixServerPrefixes(t *testing.T) {
 			Expected:     networksList(t, "127.0.0.0/24,127.0.1.0/24,::/64"),
 			ErrAssertion: assert.NoError,
 		},
+		"leader": {
+			Advertiser: func(t *testing.T, ctrl *gomock.Controller) grpc.Advertiser {
+				a := mock_grpc.NewMockAdvertiser(ctrl)
+				a.EXPECT().AdvertiseList(local, remote).Return(networksList(t, "127.0.0.0/24"))
+				return a
+			},
+			Request: func() (context.Context, *gpb.PrefixesRequest) {
+				ctx := peer.NewContext(context.Background(),
+					&peer.Peer{Addr: &snet.UDPAddr{IA: remote}},
+				)
+				return ctx, &gpb.PrefixesRequest{}
+			},
+			HA:           leaderChecker(true),
+			Expected:     networksList(t, "127.0.0.0/24"),
+			ErrAssertion: assert.NoError,
+		},
+		"standby": {
+			Advertiser: func(t *testing.T, ctrl *gomock.Controller) grpc.Advertiser {
+				a := mock_grpc.NewMockAdvertiser(ctrl)
+				a.EXPECT().AdvertiseList(local, remote).Return(networksList(t, "127.0.0.0/24"))
+				return a
+			},
+			Request: func() (context.Context, *gpb.PrefixesRequest) {
+				ctx := peer.NewContext(context.Background(),
+					&peer.Peer{Addr: &snet.UDPAddr{IA: remote}},
+				)
+				return ctx, &gpb.PrefixesRequest{}
+			},
+			HA:           leaderChecker(false),
+			Expected:     networksList(t, "127.0.0.0/24"),
+			Standby:      true,
+			ErrAssertion: assert.NoError,
+		},
 		"unknown": {
 			Advertiser: func(t *testing.T, ctrl *gomock.Controller) grpc.Advertiser {
 				a := mock_grpc.NewMockAdvertiser(ctrl)
@@ -119,6 +154,7 @@ func TestIPPrefixServerPrefixes(t *testing.T) {
 			s := grpc.IPPrefixServer{
 				LocalIA:    local,
 				Advertiser: tc.Advertiser(t, ctrl),
+				HA:         tc.HA,
 			}
 			rep, err := s.Prefixes(tc.Request())
 			tc.ErrAssertion(t, err)
@@ -134,10 +170,17 @@ func TestIPPrefixServerPrefixes(t *testing.T) {
 				got = append(got, prefix)
 			}
 			assert.ElementsMatch(t, tc.Expected, got)
+			assert.Equal(t, tc.Standby, rep.Standby)
 		})
 	}
 }
 
+type leaderChecker bool
+
+func (l leaderChecker) Leader() bool {
+	return bool(l)
+}
+
 func networksList(t *testing.T, networks string) []*net.IPNet {
 	var prefixes []*net.IPNet
 	for _, network := range strings.Split(networks, ",") {
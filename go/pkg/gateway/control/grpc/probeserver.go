@@ -17,19 +17,47 @@ package grpc
 import (
 	"context"
 	"net"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	gpb "github.com/scionproto/scion/go/pkg/proto/gateway"
 )
 
-// ProbeDispatcher handles incoming gateway protocol messages.
-// Currently, it only supports probe requests, and immediately replies to them.
+const (
+	// keyExchangeTimeout is the maximum time spent on handling a key exchange
+	// request.
+	keyExchangeTimeout = 5 * time.Second
+	// DefaultMaxKeyExchanges is the default maximum number of key exchange
+	// requests that are handled concurrently.
+	DefaultMaxKeyExchanges = 16
+)
+
+// KeyExchangeHandler handles the key exchange requests of remote gateways.
+type KeyExchangeHandler interface {
+	HandleKeyExchange(ctx context.Context, src *snet.UDPAddr,
+		req *gpb.KeyExchangeRequest) (*gpb.KeyExchangeResponse, error)
+}
+
+// ProbeDispatcher handles incoming gateway protocol messages. Probe requests
+// are immediately replied to. Key exchange requests are handled asynchronously,
+// because verifying them might require fetching crypto material.
 type ProbeDispatcher struct {
+	// KeyExchangeHandler handles key exchange requests. If nil, key exchange
+	// requests are not answered and the remote gateway falls back to
+	// unencrypted traffic.
+	KeyExchangeHandler KeyExchangeHandler
+	// MaxKeyExchanges is the maximum number of key exchange requests that are
+	// handled concurrently. Requests that exceed the limit are dropped. If
+	// zero, DefaultMaxKeyExchanges is used.
+	MaxKeyExchanges int
+
+	keyExchanges chan struct{}
 }
 
 // Listen handles the received control requests.
@@ -38,6 +66,11 @@ func (d *ProbeDispatcher) Listen(ctx context.Context, conn net.PacketConn) error
 	logger.Info("ProbeDispatcher: starting")
 	defer logger.Info("ProbeDispatcher: stopped")
 
+	maxKeyExchanges := d.MaxKeyExchanges
+	if maxKeyExchanges == 0 {
+		maxKeyExchanges = DefaultMaxKeyExchanges
+	}
+	d.keyExchanges = make(chan struct{}, maxKeyExchanges)
 	buf := make([]byte, common.SupportedMTU)
 	for {
 		select {
@@ -55,14 +88,16 @@ func (d *ProbeDispatcher) Listen(ctx context.Context, conn net.PacketConn) error
 				// are recoverable.
 				continue
 			}
-			if err = d.dispatch(conn, buf[:n], addr); err != nil {
+			if err = d.dispatch(ctx, conn, buf[:n], addr); err != nil {
 				logger.Info("ProbeDispatcher: Error dispatching", "addr", addr, "err", err)
 			}
 		}
 	}
 }
 
-func (d *ProbeDispatcher) dispatch(conn net.PacketConn, raw []byte, addr net.Addr) error {
+func (d *ProbeDispatcher) dispatch(ctx context.Context, conn net.PacketConn, raw []byte,
+	addr net.Addr) error {
+
 	var ctrl gpb.ControlRequest
 	if err := proto.Unmarshal(raw, &ctrl); err != nil {
 		return err
@@ -83,7 +118,53 @@ func (d *ProbeDispatcher) dispatch(conn net.PacketConn, raw []byte, addr net.Add
 		}
 		_, err = conn.WriteTo(packed, addr)
 		return err
+	case *gpb.ControlRequest_KeyExchange:
+		if d.KeyExchangeHandler == nil {
+			return serrors.New("key exchange not supported")
+		}
+		src, ok := addr.(*snet.UDPAddr)
+		if !ok {
+			return serrors.New("unexpected address type", "type", common.TypeOf(addr))
+		}
+		// Key exchange requests are not authenticated before they are handled.
+		// Bound the number of requests that are handled at the same time.
+		select {
+		case d.keyExchanges <- struct{}{}:
+		default:
+			return serrors.New("too many concurrent key exchanges", "addr", src)
+		}
+		go func() {
+			defer log.HandlePanic()
+			defer func() { <-d.keyExchanges }()
+			if err := d.handleKeyExchange(ctx, conn, src.Copy(), c.KeyExchange); err != nil {
+				log.FromCtx(ctx).Info("ProbeDispatcher: Error handling key exchange",
+					"addr", src, "err", err)
+			}
+		}()
+		return nil
 	default:
 		return serrors.New("unexpected control request", "type", common.TypeOf(ctrl.Request))
 	}
 }
+
+func (d *ProbeDispatcher) handleKeyExchange(ctx context.Context, conn net.PacketConn,
+	src *snet.UDPAddr, req *gpb.KeyExchangeRequest) error {
+
+	ctx, cancel := context.WithTimeout(ctx, keyExchangeTimeout)
+	defer cancel()
+	rep, err := d.KeyExchangeHandler.HandleKeyExchange(ctx, src, req)
+	if err != nil {
+		return err
+	}
+	packed, err := proto.Marshal(&gpb.ControlResponse{
+		Response: &gpb.ControlResponse_KeyExchange{
+			KeyExchange: rep,
+		},
+	})
+	if err != nil {
+		return serrors.WrapStr("packing key exchange response", err,
+			"session_id", req.SessionId)
+	}
+	_, err = conn.WriteTo(packed, src)
+	return err
+}
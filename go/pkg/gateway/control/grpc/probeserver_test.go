@@ -98,3 +98,66 @@ func TestControlDispatcher(t *testing.T) {
 	<-done
 
 }
+
+// blockingHandler blocks key exchanges until it is released.
+type blockingHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h blockingHandler) HandleKeyExchange(ctx context.Context, _ *snet.UDPAddr,
+	_ *gpb.KeyExchangeRequest) (*gpb.KeyExchangeResponse, error) {
+
+	h.started <- struct{}{}
+	<-h.release
+	return nil, serrors.New("not implemented")
+}
+
+func TestControlDispatcherKeyExchangeLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := &snet.UDPAddr{IA: xtest.MustParseIA("1-ff00:0:110")}
+	request, err := proto.Marshal(&gpb.ControlRequest{
+		Request: &gpb.ControlRequest_KeyExchange{
+			KeyExchange: &gpb.KeyExchangeRequest{SessionId: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	conn := mock_net.NewMockPacketConn(ctrl)
+	conn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+		func(buf []byte) (int, net.Addr, error) {
+			return copy(buf, request), src, nil
+		},
+	).Times(3)
+	allReceived := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	conn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+		func(buf []byte) (int, net.Addr, error) {
+			close(allReceived)
+			<-ctx.Done()
+			return 0, nil, serrors.New("closed")
+		},
+	)
+
+	handler := blockingHandler{
+		started: make(chan struct{}, 3),
+		release: make(chan struct{}),
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d := &grpc.ProbeDispatcher{KeyExchangeHandler: handler, MaxKeyExchanges: 2}
+		assert.NoError(t, d.Listen(ctx, conn))
+	}()
+
+	<-allReceived
+	<-handler.started
+	<-handler.started
+	cancel()
+	<-done
+	close(handler.release)
+	// The request that exceeds the limit is dropped.
+	assert.Len(t, handler.started, 0)
+}
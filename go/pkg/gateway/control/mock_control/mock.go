@@ -522,12 +522,13 @@ func (mr *MockPrefixFetcherMockRecorder) Close() *gomock.Call {
 }
 
 // Prefixes mocks base method.
-func (m *MockPrefixFetcher) Prefixes(arg0 context.Context, arg1 *net.UDPAddr) ([]*net.IPNet, error) {
+func (m *MockPrefixFetcher) Prefixes(arg0 context.Context, arg1 *net.UDPAddr) ([]*net.IPNet, bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Prefixes", arg0, arg1)
 	ret0, _ := ret[0].([]*net.IPNet)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Prefixes indicates an expected call of Prefixes.
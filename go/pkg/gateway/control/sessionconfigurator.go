@@ -31,6 +31,7 @@ import (
 	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/gateway/encryption"
 	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/go/pkg/worker"
 )
@@ -61,6 +62,8 @@ type SessionConfig struct {
 	// Prefixes contains the network prefixes that are reachable through this
 	// session.
 	Prefixes []*net.IPNet
+	// Encryption is the encryption mode of the session.
+	Encryption encryption.Mode
 }
 
 // SessionConfigurator builds session configurations from the static traffic
@@ -214,6 +217,7 @@ func diffSessionPolicies(a, b SessionPolicies) bool {
 func diffSessionPolicy(a, b SessionPolicy) bool {
 	if a.TrafficMatcher.String() != b.TrafficMatcher.String() ||
 		a.PathCount != b.PathCount ||
+		a.Encryption != b.Encryption ||
 		// no better way than comparing pointers here:
 		a.PerfPolicy != b.PerfPolicy ||
 		prefixesKey(a.Prefixes) != prefixesKey(b.Prefixes) {
@@ -303,7 +307,7 @@ func buildSessionConfigs(sessionPolicies SessionPolicies,
 		if !ok {
 			continue
 		}
-		for _, entry := range gateways {
+		for _, entry := range preferLeaders(gateways) {
 			pathPol := PathPolicyWithAllowedInterfaces(
 				sessionPolicy.PathPolicy,
 				sessionPolicy.IA,
@@ -319,6 +323,7 @@ func buildSessionConfigs(sessionPolicies SessionPolicies,
 				PathCount:      sessionPolicy.PathCount,
 				Gateway:        entry.Gateway,
 				Prefixes:       mergePrefixes(sessionPolicy.Prefixes, entry.Prefixes),
+				Encryption:     sessionPolicy.Encryption,
 			})
 			sessID++
 		}
@@ -326,6 +331,19 @@ func buildSessionConfigs(sessionPolicies SessionPolicies,
 	return result, nil
 }
 
+// preferLeaders returns the gateways such that gateways that are not in
+// standby come first. The session IDs are assigned in this order, and the
+// routing table uses the sessions in the order of their IDs. Hence, traffic is
+// sent to the leader of a high availability group, and only fails over to a
+// standby gateway if no leader session is healthy.
+func preferLeaders(gateways []RemoteGateway) []RemoteGateway {
+	sorted := append([]RemoteGateway(nil), gateways...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return !sorted[i].Gateway.Standby && sorted[j].Gateway.Standby
+	})
+	return sorted
+}
+
 // PathPolicyWithAllowedInterfaces constructs a path policy that only accepts
 // path that match the policy and that enter the remote AS on one of the
 // allowed interfaces. An empty list of allowed interfaces indicates that
@@ -299,6 +299,66 @@ func TestBuildSessionConfigs(t *testing.T) {
 				},
 			},
 		},
+		"standby gateway last": {
+			SessionPolicies: control.SessionPolicies{
+				{
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					ID:             42,
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     dummyPerfPolicy{},
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      1,
+				},
+			},
+			RoutingUpdate: control.RemoteGateways{
+				Gateways: map[addr.IA][]control.RemoteGateway{
+					xtest.MustParseIA("1-ff00:0:110"): {
+						{
+							Gateway: control.Gateway{
+								Probe:   mustParseUDPAddr(t, "10.0.1.1:25"),
+								Standby: true,
+							},
+							Prefixes: xtest.MustParseCIDRs(t, "10.1.0.0/24"),
+						},
+						{
+							Gateway: control.Gateway{
+								Probe: mustParseUDPAddr(t, "10.0.1.2:25"),
+							},
+							Prefixes: xtest.MustParseCIDRs(t, "10.1.0.0/24"),
+						},
+					},
+				},
+			},
+			Expected: []*control.SessionConfig{
+				{
+					ID:             0,
+					PolicyID:       42,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     dummyPerfPolicy{},
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      control.DefaultPathCount,
+					Prefixes:       xtest.MustParseCIDRs(t, "10.1.0.0/24"),
+					Gateway: control.Gateway{
+						Probe: mustParseUDPAddr(t, "10.0.1.2:25"),
+					},
+				},
+				{
+					ID:             1,
+					PolicyID:       42,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     dummyPerfPolicy{},
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      control.DefaultPathCount,
+					Prefixes:       xtest.MustParseCIDRs(t, "10.1.0.0/24"),
+					Gateway: control.Gateway{
+						Probe:   mustParseUDPAddr(t, "10.0.1.1:25"),
+						Standby: true,
+					},
+				},
+			},
+		},
 		"complex": {
 			SessionPolicies: control.SessionPolicies{
 				{
@@ -96,6 +96,10 @@ type SessionMonitor struct {
 	// Metrics are the metrics which are modified during the operation of the
 	// monitor. If empty no metrics are reported.
 	Metrics SessionMonitorMetrics
+	// KeyExchanger establishes the keys of an encrypted session. The key
+	// exchange messages are sent alongside the probes. If nil, no key exchange
+	// is done.
+	KeyExchanger TunnelKeyExchanger
 
 	// stateMtx protects the state from concurrent access.
 	stateMtx sync.RWMutex
@@ -216,6 +220,34 @@ func (m *SessionMonitor) sendProbe(ctx context.Context) {
 		return
 	}
 	safeInc(m.Metrics.Probes)
+	m.sendKeyExchange(ctx, remote)
+}
+
+func (m *SessionMonitor) sendKeyExchange(ctx context.Context, remote net.Addr) {
+	if m.KeyExchanger == nil {
+		return
+	}
+	logger := log.FromCtx(ctx)
+	req, err := m.KeyExchanger.Request(ctx)
+	if err != nil {
+		logger.Error("Error creating key exchange request", "err", err)
+		return
+	}
+	if req == nil {
+		return
+	}
+	raw, err := proto.Marshal(&gatewaypb.ControlRequest{
+		Request: &gatewaypb.ControlRequest_KeyExchange{
+			KeyExchange: req,
+		},
+	})
+	if err != nil {
+		logger.Error("Error marshaling key exchange request", "err", err)
+		return
+	}
+	if _, err := m.ProbeConn.WriteTo(raw, remote); err != nil {
+		logger.Error("Error sending key exchange request", "err", err)
+	}
 }
 
 func (m *SessionMonitor) handleProbeReply(ctx context.Context) {
@@ -248,6 +280,10 @@ func (m *SessionMonitor) handleExpiration(ctx context.Context) {
 
 	m.state = EventDown
 	metrics.GaugeSet(m.Metrics.IsHealthy, 0)
+	// The remote gateway might have been restarted and lost its keys.
+	if m.KeyExchanger != nil {
+		m.KeyExchanger.Reset()
+	}
 
 	select {
 	case <-m.workerBase.GetDoneChan():
@@ -273,17 +309,20 @@ func (m *SessionMonitor) drainConn(ctx context.Context) {
 			logger.Error("Reading from probe conn", "err", err)
 			continue
 		}
-		if err := m.handlePkt(buf[:n]); err != nil {
+		if err := m.handlePkt(ctx, buf[:n]); err != nil {
 			logger.Error("Handling probe reply", "err", err)
 		}
 	}
 }
 
-func (m *SessionMonitor) handlePkt(raw []byte) error {
+func (m *SessionMonitor) handlePkt(ctx context.Context, raw []byte) error {
 	var ctrl gatewaypb.ControlResponse
 	if err := proto.Unmarshal(raw, &ctrl); err != nil {
 		return serrors.WrapStr("parsing control response", err)
 	}
+	if rep, ok := ctrl.Response.(*gatewaypb.ControlResponse_KeyExchange); ok {
+		return m.handleKeyExchange(ctx, rep.KeyExchange)
+	}
 	probe, ok := ctrl.Response.(*gatewaypb.ControlResponse_Probe)
 	if !ok {
 		return serrors.New("unexpected control response", "type", common.TypeOf(ctrl.Response))
@@ -296,3 +335,20 @@ func (m *SessionMonitor) handlePkt(raw []byte) error {
 	m.receivedProbe <- struct{}{}
 	return nil
 }
+
+func (m *SessionMonitor) handleKeyExchange(ctx context.Context,
+	rep *gatewaypb.KeyExchangeResponse) error {
+
+	if m.KeyExchanger == nil {
+		return serrors.New("unexpected key exchange response")
+	}
+	if rep.SessionId != uint32(m.ID) {
+		return serrors.New("unexpected session ID in key exchange response",
+			"response_id", rep.SessionId, "expected_id", m.ID)
+	}
+	if err := m.KeyExchanger.HandleResponse(ctx, rep); err != nil {
+		return serrors.WrapStr("handling key exchange response", err)
+	}
+	log.FromCtx(ctx).Debug("Established session keys", "session_id", m.ID)
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/gateway/encryption"
 	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
 )
 
@@ -46,8 +47,9 @@ type LegacySessionPolicyAdapter struct{}
 func (LegacySessionPolicyAdapter) Parse(ctx context.Context, raw []byte) (SessionPolicies, error) {
 	type JSONFormat struct {
 		ASes map[addr.IA]struct {
-			Nets      []string
-			PathCount int
+			Nets       []string
+			PathCount  int
+			Encryption encryption.Mode
 		}
 		ConfigVersion uint64
 	}
@@ -73,6 +75,7 @@ func (LegacySessionPolicyAdapter) Parse(ctx context.Context, raw []byte) (Sessio
 			PathPolicy:     DefaultPathPolicy,
 			PathCount:      pathCount,
 			Prefixes:       prefixes,
+			Encryption:     asEntry.Encryption,
 		})
 	}
 	return policies, nil
@@ -117,6 +120,17 @@ func LoadSessionPolicies(ctx context.Context, file string,
 	return p, nil
 }
 
+// EncryptionRequired reports whether any session policy towards the remote AS
+// requires encryption.
+func (p SessionPolicies) EncryptionRequired(ia addr.IA) bool {
+	for _, s := range p {
+		if s.IA.Equal(ia) && s.Encryption == encryption.Required {
+			return true
+		}
+	}
+	return false
+}
+
 // RemoteIAs returns all IAs that are in the session policies.
 func (p SessionPolicies) RemoteIAs() []addr.IA {
 	// if p == nil {
@@ -151,7 +165,8 @@ func (p SessionPolicies) Copy() SessionPolicies {
 // - a performance policy,
 // - a path count,
 // - a remote IA,
-// - a set of prefixes.
+// - a set of prefixes,
+// - an encryption mode.
 type SessionPolicy struct {
 	// IA is the ISD-AS number of the remote AS.
 	IA addr.IA
@@ -173,6 +188,10 @@ type SessionPolicy struct {
 	// Prefixes contains the network prefixes that are reachable through this
 	// session.
 	Prefixes []*net.IPNet
+	// Encryption specifies whether the traffic of this session is encrypted.
+	// If encryption is required for any session towards a remote AS,
+	// unencrypted traffic from that AS is dropped as well.
+	Encryption encryption.Mode
 }
 
 // Copy creates a deep copy.
@@ -186,6 +205,7 @@ func (sp SessionPolicy) Copy() SessionPolicy {
 		PathPolicy: copyPathPolicy(sp.PathPolicy),
 		PathCount:  sp.PathCount,
 		Prefixes:   copyPrefixes(sp.Prefixes),
+		Encryption: sp.Encryption,
 	}
 }
 
@@ -61,13 +61,19 @@ type Gateway struct {
 	Data *net.UDPAddr
 	// Interfaces are the last-hop SCION interfaces that should be preferred.
 	Interfaces []uint64
+	// Standby indicates that the remote gateway is a standby member of a high
+	// availability group. Sessions to standby gateways are only used if no
+	// session to a leader is available. The flag is reported by the remote
+	// gateway together with its prefixes.
+	Standby bool
 }
 
 func (g Gateway) Equal(other Gateway) bool {
 	return g.Control.String() == other.Control.String() &&
 		g.Probe.String() == other.Probe.String() &&
 		g.Data.String() == other.Data.String() &&
-		interfacesKey(g.Interfaces) == interfacesKey(other.Interfaces)
+		interfacesKey(g.Interfaces) == interfacesKey(other.Interfaces) &&
+		g.Standby == other.Standby
 }
 
 func interfacesKey(interfaces []uint64) string {
@@ -147,6 +153,7 @@ type gatewayDiagnostics struct {
 	ProbeAddr  string    `json:"probe_address"`
 	Interfaces []uint64  `json:"interfaces"`
 	Prefixes   []string  `json:"prefixes"`
+	Standby    bool      `json:"standby"`
 	Timestamp  time.Time `json:"timestamp"`
 }
 
@@ -266,6 +273,7 @@ func (w *GatewayWatcher) diagnostics() (remoteDiagnostics, error) {
 			ProbeAddr:  watcher.gateway.Probe.String(),
 			Interfaces: interfaces,
 			Prefixes:   watcher.prefixes,
+			Standby:    watcher.standby,
 			Timestamp:  watcher.timestamp,
 		}
 	}
@@ -295,7 +303,9 @@ type PrefixConsumer interface {
 
 // PrefixFetcher fetches the IP prefixes from a remote gateway.
 type PrefixFetcher interface {
-	Prefixes(ctx context.Context, gateway *net.UDPAddr) ([]*net.IPNet, error)
+	// Prefixes fetches the IP prefixes and reports whether the remote gateway
+	// is a standby member of a high availability group.
+	Prefixes(ctx context.Context, gateway *net.UDPAddr) ([]*net.IPNet, bool, error)
 	Close() error
 }
 
@@ -355,6 +365,9 @@ type prefixWatcher struct {
 	stateMtx sync.RWMutex
 	// state of last fetched prefixes
 	prefixes []string
+	// standby indicates whether the gateway reported to be standby in the last
+	// fetch.
+	standby bool
 	// timestamp of last fetched prefixes
 	timestamp time.Time
 }
@@ -405,19 +418,23 @@ func (w *prefixWatcher) run(ctx context.Context) {
 
 	logger := log.FromCtx(ctx)
 	logger.Debug("Fetching IP prefixes from remote gateway")
-	prefixes, err := w.fetcher.Prefixes(ctx, w.gateway.Control)
+	prefixes, standby, err := w.fetcher.Prefixes(ctx, w.gateway.Control)
 	if err != nil {
 		logger.Debug("Failed to fetch IP prefixes from remote gateway", "err", err)
 		return
 	}
-	logger.Debug("Fetched prefixes successfully", "prefixes", fmtPrefixes(prefixes))
+	logger.Debug("Fetched prefixes successfully", "prefixes", fmtPrefixes(prefixes),
+		"standby", standby)
 
 	snapshot := fmtPrefixes(prefixes)
-	w.Consumer.Prefixes(w.remote, w.gateway, prefixes)
+	gateway := w.gateway
+	gateway.Standby = standby
+	w.Consumer.Prefixes(w.remote, gateway, prefixes)
 
 	w.stateMtx.Lock()
 	defer w.stateMtx.Unlock()
 	w.prefixes = snapshot
+	w.standby = standby
 	w.timestamp = time.Now()
 }
 
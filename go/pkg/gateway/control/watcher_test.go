@@ -56,9 +56,9 @@ func TestGatewayWatcherRun(t *testing.T) {
 	)
 
 	fetcher.EXPECT().Prefixes(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
-		func(_ interface{}, g *net.UDPAddr) ([]*net.IPNet, error) {
+		func(_ interface{}, g *net.UDPAddr) ([]*net.IPNet, bool, error) {
 			fetcherCounts.With("gateway", g.String()).Add(1)
-			return nil, serrors.New("error")
+			return nil, false, serrors.New("error")
 		},
 	)
 
@@ -131,15 +131,16 @@ func TestPrefixWatcherRun(t *testing.T) {
 	fetcher.EXPECT().Close().AnyTimes().Return(nil)
 
 	// Initial error to check consumer is not called on error.
-	fetcher.EXPECT().Prefixes(gomock.Any(), gateway.Control).Return(nil, serrors.New("internal"))
+	fetcher.EXPECT().Prefixes(gomock.Any(), gateway.Control).Return(
+		nil, false, serrors.New("internal"))
 
 	// First successful result has one more subnet, to check that consumer is
 	// called with the up to date list.
 	first := []*net.IPNet{cidr(t, "127.0.0.0/24"), cidr(t, "127.0.1.0/24"), cidr(t, "::/64")}
 	fetcher.EXPECT().Prefixes(gomock.Any(), gateway.Control).DoAndReturn(
-		func(_, _ interface{}) ([]*net.IPNet, error) {
+		func(_, _ interface{}) ([]*net.IPNet, bool, error) {
 			fetcherCounts.Add(1)
-			return first, nil
+			return first, false, nil
 		},
 	)
 	consumer.EXPECT().Prefixes(gomock.Any(), gateway, first).Do(func(_, _, _ interface{}) {
@@ -148,12 +149,16 @@ func TestPrefixWatcherRun(t *testing.T) {
 
 	afterwards := []*net.IPNet{cidr(t, "127.0.0.0/24"), cidr(t, "::/64")}
 	fetcher.EXPECT().Prefixes(gomock.Any(), gateway.Control).AnyTimes().DoAndReturn(
-		func(_, _ interface{}) ([]*net.IPNet, error) {
+		func(_, _ interface{}) ([]*net.IPNet, bool, error) {
 			fetcherCounts.Add(1)
-			return afterwards, nil
+			return afterwards, true, nil
 		},
 	)
-	consumer.EXPECT().Prefixes(gomock.Any(), gateway, afterwards).AnyTimes().Do(
+	// The standby flag reported by the remote gateway is passed on to the
+	// consumer.
+	standby := gateway
+	standby.Standby = true
+	consumer.EXPECT().Prefixes(gomock.Any(), standby, afterwards).AnyTimes().Do(
 		func(_, _, _ interface{}) {
 			consumerCounts.Add(1)
 		},
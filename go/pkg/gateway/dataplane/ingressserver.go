@@ -21,6 +21,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/ringbuf"
@@ -33,6 +34,9 @@ import (
 const (
 	// workerCleanupInterval is the interval between worker cleanup rounds.
 	workerCleanupInterval = 60 * time.Second
+	// sealedFrameVersion is the version of frames that are protected with
+	// the keys established with the remote gateway.
+	sealedFrameVersion = 1
 )
 
 type ReadConn interface {
@@ -61,6 +65,17 @@ type IngressMetrics struct {
 	ReceiveExternalError metrics.Counter
 }
 
+// FrameOpener opens protected frames.
+type FrameOpener interface {
+	// Open authenticates and decrypts a protected frame received from the
+	// remote AS. The returned frame may share the buffer of the protected
+	// frame.
+	Open(ia addr.IA, sealed []byte) ([]byte, error)
+	// AllowPlaintext reports whether unprotected frames from the remote AS are
+	// accepted.
+	AllowPlaintext(ia addr.IA) bool
+}
+
 // IngressServer reads new encapsulated packets, classifies the packet by
 // source ISD-AS -> source host Addr -> Sess ID and hands it off to the
 // appropriate Worker, starting a new one if none currently exists.
@@ -68,6 +83,9 @@ type IngressServer struct {
 	Conn          ReadConn
 	DeviceManager control.DeviceManager
 	Metrics       IngressMetrics
+	// Opener opens protected frames. If nil, only unprotected frames are
+	// accepted.
+	Opener FrameOpener
 
 	workers map[string]*worker
 }
@@ -102,6 +120,32 @@ func (d *IngressServer) read(ctx context.Context) error {
 						return serrors.New("frame too short",
 							"expected", sigHdrSize, "actual", read)
 					}
+					if frame.raw[0] == sealedFrameVersion && d.Opener != nil {
+						plain, err := d.Opener.Open(v.IA, frame.raw[:read])
+						if err != nil {
+							metrics.CounterInc(metrics.CounterWith(d.Metrics.FramesDiscarded,
+								"remote_isd_as", v.IA.String(), "reason", "unauthenticated"))
+							frame.Release()
+							frames[i] = nil
+							continue
+						}
+						read = copy(frame.raw, plain)
+						if read < sigHdrSize || frame.raw[0] != 0 {
+							metrics.CounterInc(metrics.CounterWith(d.Metrics.FramesDiscarded,
+								"remote_isd_as", v.IA.String(), "reason", "invalid"))
+							frame.Release()
+							frames[i] = nil
+							continue
+						}
+					} else if frame.raw[0] == 0 && d.Opener != nil &&
+						!d.Opener.AllowPlaintext(v.IA) {
+
+						metrics.CounterInc(metrics.CounterWith(d.Metrics.FramesDiscarded,
+							"remote_isd_as", v.IA.String(), "reason", "unencrypted"))
+						frame.Release()
+						frames[i] = nil
+						continue
+					}
 					if frame.raw[0] != 0 {
 						metrics.CounterInc(metrics.CounterWith(d.Metrics.FramesDiscarded,
 							"remote_isd_as", v.IA.String(), "reason", "invalid"))
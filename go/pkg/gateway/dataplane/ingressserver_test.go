@@ -0,0 +1,121 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+// scriptedConn returns the scripted frames in order.
+type scriptedConn struct {
+	frames []scriptedFrame
+}
+
+type scriptedFrame struct {
+	raw []byte
+	src *snet.UDPAddr
+}
+
+func (c *scriptedConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	f := c.frames[0]
+	c.frames = c.frames[1:]
+	return copy(b, f.raw), f.src, nil
+}
+
+// fakeOpener opens frames that are prefixed with a 4 byte header. Frames with
+// 0xff as third byte fail authentication.
+type fakeOpener struct {
+	plaintext map[addr.IA]bool
+}
+
+func (o fakeOpener) Open(_ addr.IA, sealed []byte) ([]byte, error) {
+	if len(sealed) < 4 || sealed[2] == 0xff {
+		return nil, serrors.New("authentication failed")
+	}
+	return sealed[4:], nil
+}
+
+func (o fakeOpener) AllowPlaintext(ia addr.IA) bool {
+	return o.plaintext[ia]
+}
+
+type noDeviceManager struct{}
+
+func (noDeviceManager) Get(context.Context, addr.IA) (control.DeviceHandle, error) {
+	return nil, serrors.New("no device")
+}
+
+func TestIngressServerOpen(t *testing.T) {
+	strict := xtest.MustParseIA("1-ff00:0:110")
+	lenient := xtest.MustParseIA("1-ff00:0:111")
+	src := func(ia addr.IA) *snet.UDPAddr {
+		return &snet.UDPAddr{IA: ia, Host: &net.UDPAddr{IP: net.IP{192, 168, 1, 1}}}
+	}
+	plain := make([]byte, hdrLen+4)
+	plain[sessPos] = 1
+	sealed := func(third byte, frame []byte) []byte {
+		return append([]byte{sealedFrameVersion, 1, third, 0}, frame...)
+	}
+	invalidVersion := append([]byte(nil), plain...)
+	invalidVersion[versionPos] = 2
+
+	conn := &scriptedConn{frames: []scriptedFrame{
+		{raw: sealed(0, plain), src: src(strict)},
+		{raw: sealed(0xff, plain), src: src(strict)},
+		{raw: plain, src: src(strict)},
+		{raw: plain, src: src(lenient)},
+		{raw: sealed(0, invalidVersion), src: src(lenient)},
+		// The short frame terminates the server.
+		{raw: []byte{0}, src: src(lenient)},
+	}}
+	framesRecv := metrics.NewTestCounter()
+	framesDiscarded := metrics.NewTestCounter()
+	server := &IngressServer{
+		Conn:          conn,
+		DeviceManager: noDeviceManager{},
+		Metrics: IngressMetrics{
+			FramesRecv:      framesRecv,
+			FramesDiscarded: framesDiscarded,
+		},
+		Opener: fakeOpener{plaintext: map[addr.IA]bool{lenient: true}},
+	}
+	require.Error(t, server.Run(context.Background()))
+
+	recv := func(ia addr.IA) float64 {
+		return metrics.CounterValue(framesRecv.With("remote_isd_as", ia.String()))
+	}
+	discarded := func(ia addr.IA, reason string) float64 {
+		return metrics.CounterValue(framesDiscarded.With("remote_isd_as", ia.String(),
+			"reason", reason))
+	}
+	assert.Equal(t, float64(1), recv(strict), "sealed frame")
+	assert.Equal(t, float64(1), discarded(strict, "unauthenticated"))
+	assert.Equal(t, float64(1), discarded(strict, "unencrypted"))
+	assert.Equal(t, float64(1), recv(lenient), "allowed plaintext frame")
+	// The opened frame with the invalid version and the short frame.
+	assert.Equal(t, float64(2), discarded(lenient, "invalid"))
+}
@@ -40,17 +40,23 @@ type sender struct {
 	path               snet.Path
 	pathFingerprint    snet.PathFingerprint
 	metrics            SessionMetrics
+	sealer             FrameSealer
+	// sealed is the buffer for sealed frames.
+	sealed []byte
 }
 
 func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 	gatewayAddr net.UDPAddr, pathStatsPublisher PathStatsPublisher,
-	metrics SessionMetrics) (*sender, error) {
+	metrics SessionMetrics, sealer FrameSealer) (*sender, error) {
 
 	// MTU must account for the size of the SCION header.
 	localAddr := conn.LocalAddr().(*net.UDPAddr)
 	addrLen := addr.IABytes*2 + len(localAddr.IP) + len(gatewayAddr.IP)
 	pathLen := len(path.Path().Raw)
 	mtu := int(path.Metadata().MTU) - slayers.CmnHdrLen - addrLen - pathLen - udpHdrLen
+	if sealer != nil {
+		mtu -= sealer.Overhead()
+	}
 	if mtu < minMTU {
 		return nil, serrors.New("insufficient MTU", "mtu", mtu, "minMTU", minMTU)
 	}
@@ -68,6 +74,10 @@ func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 		path:               path,
 		pathFingerprint:    snet.Fingerprint(path),
 		metrics:            metrics,
+		sealer:             sealer,
+	}
+	if sealer != nil {
+		c.sealed = make([]byte, 0, mtu+sealer.Overhead())
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -97,6 +107,13 @@ func (c *sender) run() {
 			// Sender was closed and all the buffered frames were sent.
 			break
 		}
+		if c.sealer != nil {
+			var err error
+			if frame, err = c.sealer.Seal(c.sealed[:0], frame); err != nil {
+				increaseCounterMetric(c.metrics.SendExternalErrors, 1)
+				continue
+			}
+		}
 		_, err := c.conn.WriteTo(frame, c.address)
 		if err != nil {
 			increaseCounterMetric(c.metrics.SendExternalErrors, 1)
@@ -22,10 +22,15 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/mocks/net/mock_net"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
 )
 
 func expectFrames(conn *mock_net.MockPacketConn) *gomock.Call {
@@ -107,7 +112,8 @@ func TestSender(t *testing.T) {
 				IP:   net.IP{192, 168, 1, 2},
 				Port: 30041,
 			}
-			c, err := newSender(1, conn, createMockPath(ctrl, 256), addr, nil, SessionMetrics{})
+			c, err := newSender(1, conn, createMockPath(ctrl, 256), addr, nil, SessionMetrics{},
+				nil)
 			require.NoError(t, err)
 			defer c.Close()
 			if test.ExpFrames != 0 {
@@ -125,3 +131,94 @@ func TestSender(t *testing.T) {
 		})
 	}
 }
+
+// fakeSealer prefixes the frames with a fixed header.
+type fakeSealer struct {
+	err error
+}
+
+var fakeSealHdr = []byte{0xee, 0xee, 0xee, 0xee}
+
+func (s fakeSealer) Seal(dst, frame []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return append(append(dst, fakeSealHdr...), frame...), nil
+}
+
+func (s fakeSealer) Overhead() int {
+	return len(fakeSealHdr)
+}
+
+func TestSenderSeal(t *testing.T) {
+	gwAddr := net.UDPAddr{IP: net.IP{192, 168, 1, 2}, Port: 30041}
+	buf := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		&layers.IPv4{Version: 4, SrcIP: net.IP{10, 0, 0, 2}, DstIP: net.IP{10, 0, 0, 1}},
+		gopacket.Payload([]byte{1, 2, 3}))
+	require.NoError(t, err)
+	pkt := buf.Bytes()
+
+	t.Run("sealed frames", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		conn := mock_net.NewMockPacketConn(ctrl)
+		conn.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{192, 168, 1, 1}}).AnyTimes()
+		written := make(chan []byte, 1)
+		conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(f []byte, _ interface{}) (int, error) {
+				written <- append([]byte(nil), f...)
+				return len(f), nil
+			})
+
+		c, err := newSender(1, conn, createMockPath(ctrl, 256), gwAddr, nil, SessionMetrics{},
+			fakeSealer{})
+		require.NoError(t, err)
+		defer c.Close()
+
+		c.Write(pkt)
+		select {
+		case f := <-written:
+			require.Greater(t, len(f), len(fakeSealHdr)+hdrLen)
+			assert.Equal(t, fakeSealHdr, f[:len(fakeSealHdr)])
+			// The sealed frame is a regular frame of the session.
+			assert.Equal(t, byte(0), f[len(fakeSealHdr)])
+			assert.Equal(t, byte(1), f[len(fakeSealHdr)+1])
+		case <-time.After(time.Second):
+			t.Fatal("no frame written")
+		}
+	})
+
+	t.Run("seal error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		conn := mock_net.NewMockPacketConn(ctrl)
+		conn.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{192, 168, 1, 1}}).AnyTimes()
+		sendErrors := metrics.NewTestCounter()
+
+		c, err := newSender(1, conn, createMockPath(ctrl, 256), gwAddr, nil,
+			SessionMetrics{SendExternalErrors: sendErrors},
+			fakeSealer{err: serrors.New("no key")})
+		require.NoError(t, err)
+		defer c.Close()
+		c.Write(pkt)
+		waitForFrames()
+		assert.Equal(t, float64(1), metrics.CounterValue(sendErrors))
+	})
+
+	t.Run("insufficient MTU", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		conn := mock_net.NewMockPacketConn(ctrl)
+		conn.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{192, 168, 1, 1}}).AnyTimes()
+		// The MTU is just enough without sealing.
+		mtu := uint16(minMTU + slayers.CmnHdrLen + 2*addr.IABytes + 2*net.IPv4len + udpHdrLen)
+		plain, err := newSender(1, conn, createMockPath(ctrl, mtu), gwAddr, nil,
+			SessionMetrics{}, nil)
+		require.NoError(t, err)
+		plain.Close()
+		_, err = newSender(1, conn, createMockPath(ctrl, mtu), gwAddr, nil, SessionMetrics{},
+			fakeSealer{})
+		assert.Error(t, err)
+	})
+}
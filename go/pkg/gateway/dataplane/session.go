@@ -53,6 +53,17 @@ type SessionMetrics struct {
 	SendExternalErrors metrics.Counter
 }
 
+// FrameSealer protects the frames of a session.
+type FrameSealer interface {
+	// Seal protects the frame and appends the result to dst. The returned
+	// slice is either the extended dst or, if the frame is sent unprotected,
+	// the frame itself. dst must not overlap with the frame.
+	Seal(dst, frame []byte) ([]byte, error)
+	// Overhead returns the maximum number of bytes that sealing adds to a
+	// frame.
+	Overhead() int
+}
+
 type Session struct {
 	SessionID          uint8
 	GatewayAddr        net.UDPAddr
@@ -63,6 +74,18 @@ type Session struct {
 	mutex sync.Mutex
 	// senders is a list of currently used senders.
 	senders []*sender
+	// sealer protects the frames of the session. If nil, frames are sent
+	// unprotected.
+	sealer FrameSealer
+}
+
+// SetSealer sets the sealer that protects the frames of the session. It only
+// applies to paths that are set afterwards, it should thus be called before
+// the first call to SetPaths.
+func (s *Session) SetSealer(sealer FrameSealer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sealer = sealer
 }
 
 // Close signals that the session should close up its internal Connections. Close returns as
@@ -138,6 +161,7 @@ func (s *Session) SetPaths(paths []snet.Path) error {
 			s.GatewayAddr,
 			s.PathStatsPublisher,
 			s.Metrics,
+			s.sealer,
 		)
 		if err != nil {
 			// Collect newly created senders to avoid go routine leak.
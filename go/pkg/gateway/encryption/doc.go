@@ -0,0 +1,50 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package encryption protects the traffic between two gateways.
+//
+// Keys are established per session with an ephemeral X25519 key exchange over
+// the probe channel. The initiator is the gateway that owns the session, i.e.,
+// the gateway that sends the frames. Both the request and the response are
+// signed with the AS key of the respective gateway and verified with the
+// certificate chains of the remote AS. The shared secret is expanded with
+// HKDF-SHA256 into an AES-256-GCM key that protects the frames sent by the
+// initiator. Traffic in the opposite direction is protected by the session of
+// the remote gateway.
+//
+// Sealed frames use version 1 of the frame format. They wrap a complete
+// version 0 frame:
+//
+//   0                   1                   2                   3
+//   0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |    Version    |   Session ID  |            Reserved           |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |                             Key ID                            |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |                        Sequence number                        |
+//  |                                                               |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |                  Encrypted frame + 16B tag ...                |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// The header is authenticated as additional data. The sequence number is part
+// of the nonce and is checked against a sliding replay window by the receiver.
+//
+// Keys are periodically replaced. The receiver keeps the previous key of a
+// session such that frames that are in flight during a rekey are not lost.
+// Sessions can be configured to require encryption, or to only use it if the
+// remote gateway supports it. In the latter case, traffic is sent unencrypted
+// to gateways that do not answer key exchange requests.
+package encryption
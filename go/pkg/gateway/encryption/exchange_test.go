@@ -0,0 +1,203 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto/signed"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/encryption"
+	cryptopb "github.com/scionproto/scion/go/pkg/proto/crypto"
+)
+
+func TestKeyExchange(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+
+	localIA, remoteIA := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:111")
+	localSigner, remoteSigner := newTestSigner(t), newTestSigner(t)
+	verifier := testVerifier{
+		localIA:  localSigner.Public(),
+		remoteIA: remoteSigner.Public(),
+	}
+	src := &snet.UDPAddr{IA: localIA, Host: &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30856}}
+	frame := []byte("some frame payload")
+
+	testCases := map[string]struct {
+		Mode     encryption.Mode
+		Required bool
+	}{
+		"optional": {
+			Mode: encryption.Optional,
+		},
+		"required": {
+			Mode:     encryption.Required,
+			Required: true,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			initiator := &encryption.Initiator{
+				SessionID: 2,
+				LocalIA:   localIA,
+				RemoteIA:  remoteIA,
+				Mode:      tc.Mode,
+				Signer:    localSigner,
+				Verifier:  verifier,
+			}
+			responder := &encryption.Responder{
+				LocalIA:  remoteIA,
+				Signer:   remoteSigner,
+				Verifier: verifier,
+				Required: func(addr.IA) bool { return tc.Required },
+			}
+			assert.Equal(t, !tc.Required, responder.AllowPlaintext(localIA))
+
+			// Before the key exchange, frames are sent unencrypted or dropped.
+			sealed, err := initiator.Seal(nil, frame)
+			if tc.Mode == encryption.Required {
+				assert.ErrorIs(t, err, encryption.ErrNoKey)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, frame, sealed)
+			}
+
+			req, err := initiator.Request(ctx)
+			require.NoError(t, err)
+			require.NotNil(t, req)
+			rep, err := responder.HandleKeyExchange(ctx, src, req)
+			require.NoError(t, err)
+			// A retransmitted request is answered with the same response.
+			retransmitted, err := responder.HandleKeyExchange(ctx, src, req)
+			require.NoError(t, err)
+			assert.Equal(t, rep, retransmitted)
+			require.NoError(t, initiator.HandleResponse(ctx, rep))
+
+			// No new key exchange is due after the key is established.
+			req, err = initiator.Request(ctx)
+			require.NoError(t, err)
+			assert.Nil(t, req)
+
+			sealed, err = initiator.Seal(nil, frame)
+			require.NoError(t, err)
+			assert.Len(t, sealed, len(frame)+encryption.Overhead)
+			plain, err := responder.Open(localIA, sealed)
+			require.NoError(t, err)
+			assert.Equal(t, frame, plain)
+
+			// The key is bound to the remote ISD-AS.
+			sealed, err = initiator.Seal(nil, frame)
+			require.NoError(t, err)
+			_, err = responder.Open(remoteIA, sealed)
+			assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+		})
+	}
+}
+
+func TestKeyExchangeVerification(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+
+	localIA, remoteIA := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:111")
+	localSigner, remoteSigner := newTestSigner(t), newTestSigner(t)
+	verifier := testVerifier{
+		localIA:  localSigner.Public(),
+		remoteIA: remoteSigner.Public(),
+	}
+
+	initiator := &encryption.Initiator{
+		SessionID: 1,
+		LocalIA:   localIA,
+		RemoteIA:  remoteIA,
+		Mode:      encryption.Optional,
+		Signer:    localSigner,
+		Verifier:  verifier,
+	}
+	req, err := initiator.Request(ctx)
+	require.NoError(t, err)
+
+	// The request claims to originate from the remote AS, but is signed by the
+	// local AS.
+	src := &snet.UDPAddr{IA: remoteIA, Host: &net.UDPAddr{IP: net.IP{127, 0, 0, 1}}}
+	responder := &encryption.Responder{
+		LocalIA:  remoteIA,
+		Signer:   remoteSigner,
+		Verifier: verifier,
+	}
+	_, err = responder.HandleKeyExchange(ctx, src, req)
+	assert.Error(t, err)
+
+	// A response signed by the wrong AS is rejected.
+	src.IA = localIA
+	responder.Signer = localSigner
+	rep, err := responder.HandleKeyExchange(ctx, src, req)
+	require.NoError(t, err)
+	assert.Error(t, initiator.HandleResponse(ctx, rep))
+	sealed, err := initiator.Seal(nil, []byte("frame"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("frame"), sealed)
+}
+
+type testSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func newTestSigner(t *testing.T) testSigner {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return testSigner{key: key}
+}
+
+func (s testSigner) Sign(_ context.Context, msg []byte,
+	associatedData ...[]byte) (*cryptopb.SignedMessage, error) {
+
+	hdr := signed.Header{
+		SignatureAlgorithm: signed.ECDSAWithSHA256,
+		Timestamp:          time.Now(),
+	}
+	return signed.Sign(hdr, msg, s.key, associatedData...)
+}
+
+func (s testSigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+type testVerifier map[addr.IA]crypto.PublicKey
+
+func (v testVerifier) Verify(_ context.Context, ia addr.IA,
+	signedMsg *cryptopb.SignedMessage) (*signed.Message, error) {
+
+	key, ok := v[ia]
+	if !ok {
+		return nil, serrors.New("unknown ISD-AS", "isd_as", ia)
+	}
+	return signed.Verify(signedMsg, key)
+}
@@ -0,0 +1,210 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// Version is the frame version of sealed frames. Unprotected frames use
+	// version 0.
+	Version = 1
+	// HdrLen is the length of the header of a sealed frame.
+	HdrLen = 16
+	// Overhead is the number of bytes a sealed frame is longer than the frame
+	// it protects.
+	Overhead = HdrLen + tagLen
+
+	tagLen  = 16
+	keyLen  = 32
+	saltLen = 4
+	// replayWindowSize is the number of sequence numbers the replay window
+	// tracks. Frames of a session are sent on multiple paths, the window must
+	// thus be large enough to cover reordering between paths.
+	replayWindowSize = 4096
+)
+
+var (
+	// ErrReplayed indicates that the frame was already received, or that it is
+	// too old to be checked against the replay window.
+	ErrReplayed = serrors.New("replayed frame")
+	// ErrUnknownKey indicates that the frame is sealed with a key that is not
+	// known.
+	ErrUnknownKey = serrors.New("unknown key")
+)
+
+// frameKey is the key material that protects the frames of a session.
+type frameKey struct {
+	id   uint32
+	aead cipher.AEAD
+	salt [saltLen]byte
+}
+
+func newFrameKey(id uint32, material []byte) (*frameKey, error) {
+	if len(material) != keyLen+saltLen {
+		return nil, serrors.New("invalid key material length", "expected", keyLen+saltLen,
+			"actual", len(material))
+	}
+	block, err := aes.NewCipher(material[:keyLen])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	k := &frameKey{id: id, aead: aead}
+	copy(k.salt[:], material[keyLen:])
+	return k, nil
+}
+
+func (k *frameKey) nonce(seq uint64) []byte {
+	var nonce [12]byte
+	copy(nonce[:saltLen], k.salt[:])
+	binary.BigEndian.PutUint64(nonce[saltLen:], seq)
+	return nonce[:]
+}
+
+// sealer seals frames with a key. It is safe for concurrent use.
+type sealer struct {
+	sessionID uint8
+	key       *frameKey
+	// seq is the last used sequence number. Sequence numbers start at 1.
+	seq uint64
+}
+
+// seal appends the sealed frame to dst and returns the resulting slice. dst
+// must not overlap with the frame.
+func (s *sealer) seal(dst, frame []byte) []byte {
+	seq := atomic.AddUint64(&s.seq, 1)
+	var hdr [HdrLen]byte
+	hdr[0] = Version
+	hdr[1] = s.sessionID
+	binary.BigEndian.PutUint32(hdr[4:8], s.key.id)
+	binary.BigEndian.PutUint64(hdr[8:16], seq)
+	dst = append(dst, hdr[:]...)
+	return s.key.aead.Seal(dst, s.key.nonce(seq), frame, dst[len(dst)-HdrLen:])
+}
+
+// opener opens frames sealed with a key. It is safe for concurrent use.
+type opener struct {
+	key *frameKey
+
+	mtx    sync.Mutex
+	window replayWindow
+}
+
+// open authenticates and decrypts the sealed frame. The plaintext frame is
+// written to the sealed buffer, starting at offset HdrLen, and the
+// corresponding slice is returned.
+func (o *opener) open(sealed []byte) ([]byte, error) {
+	if len(sealed) < Overhead {
+		return nil, serrors.New("sealed frame too short", "min", Overhead, "actual", len(sealed))
+	}
+	seq := binary.BigEndian.Uint64(sealed[8:16])
+	o.mtx.Lock()
+	ok := o.window.check(seq)
+	o.mtx.Unlock()
+	if !ok {
+		return nil, ErrReplayed
+	}
+	hdr, ciphertext := sealed[:HdrLen], sealed[HdrLen:]
+	plain, err := o.key.aead.Open(ciphertext[:0], o.key.nonce(seq), ciphertext, hdr)
+	if err != nil {
+		return nil, serrors.WrapStr("authenticating frame", err)
+	}
+	// Only update the window for authenticated frames, otherwise forged frames
+	// could be used to advance it.
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if !o.window.update(seq) {
+		return nil, ErrReplayed
+	}
+	return plain, nil
+}
+
+// replayWindow is a sliding window over the received sequence numbers.
+type replayWindow struct {
+	// top is the highest sequence number that was received.
+	top uint64
+	// bitmap records received sequence numbers. Sequence number s is recorded
+	// at bit s % replayWindowSize.
+	bitmap [replayWindowSize / 64]uint64
+}
+
+// check returns whether a frame with the sequence number is acceptable.
+func (w *replayWindow) check(seq uint64) bool {
+	switch {
+	case seq == 0:
+		return false
+	case seq > w.top:
+		return true
+	case w.top-seq >= replayWindowSize:
+		return false
+	default:
+		return !w.isSet(seq)
+	}
+}
+
+// update records the sequence number. It returns false if the sequence number
+// is not acceptable.
+func (w *replayWindow) update(seq uint64) bool {
+	if !w.check(seq) {
+		return false
+	}
+	if seq > w.top {
+		diff := seq - w.top
+		if diff >= replayWindowSize {
+			w.bitmap = [replayWindowSize / 64]uint64{}
+		} else {
+			for s := w.top + 1; s < seq; s++ {
+				w.clear(s)
+			}
+		}
+		w.top = seq
+	}
+	w.set(seq)
+	return true
+}
+
+func (w *replayWindow) isSet(seq uint64) bool {
+	bit := seq % replayWindowSize
+	return w.bitmap[bit/64]&(1<<(bit%64)) != 0
+}
+
+func (w *replayWindow) set(seq uint64) {
+	bit := seq % replayWindowSize
+	w.bitmap[bit/64] |= 1 << (bit % 64)
+}
+
+func (w *replayWindow) clear(seq uint64) {
+	bit := seq % replayWindowSize
+	w.bitmap[bit/64] &^= 1 << (bit % 64)
+}
+
+// keyID extracts the key ID from a sealed frame.
+func keyID(sealed []byte) (uint32, error) {
+	if len(sealed) < HdrLen || sealed[0] != Version {
+		return 0, serrors.New("not a sealed frame")
+	}
+	return binary.BigEndian.Uint32(sealed[4:8]), nil
+}
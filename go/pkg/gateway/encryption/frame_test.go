@@ -0,0 +1,104 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealOpen(t *testing.T) {
+	key, err := newFrameKey(42, bytes.Repeat([]byte{0x11}, keyLen+saltLen))
+	require.NoError(t, err)
+	s := &sealer{sessionID: 3, key: key}
+	frame := []byte("some frame payload")
+
+	testCases := map[string]struct {
+		Modify    func(sealed []byte)
+		AssertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Modify:    func(sealed []byte) {},
+			AssertErr: assert.NoError,
+		},
+		"modified header": {
+			Modify:    func(sealed []byte) { sealed[1] ^= 0xff },
+			AssertErr: assert.Error,
+		},
+		"modified ciphertext": {
+			Modify:    func(sealed []byte) { sealed[HdrLen] ^= 0xff },
+			AssertErr: assert.Error,
+		},
+		"modified tag": {
+			Modify:    func(sealed []byte) { sealed[len(sealed)-1] ^= 0xff },
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			sealed := s.seal(nil, frame)
+			assert.Len(t, sealed, len(frame)+Overhead)
+			id, err := keyID(sealed)
+			require.NoError(t, err)
+			assert.Equal(t, uint32(42), id)
+
+			tc.Modify(sealed)
+			o := &opener{key: key}
+			plain, err := o.open(sealed)
+			tc.AssertErr(t, err)
+			if err == nil {
+				assert.Equal(t, frame, plain)
+			}
+		})
+	}
+}
+
+func TestOpenReplay(t *testing.T) {
+	key, err := newFrameKey(1, bytes.Repeat([]byte{0x22}, keyLen+saltLen))
+	require.NoError(t, err)
+	s := &sealer{key: key}
+	o := &opener{key: key}
+
+	first := s.seal(nil, []byte("first"))
+	second := s.seal(nil, []byte("second"))
+	replay := append([]byte{}, second...)
+
+	_, err = o.open(second)
+	require.NoError(t, err)
+	// Reordered frames are accepted.
+	_, err = o.open(first)
+	require.NoError(t, err)
+	_, err = o.open(replay)
+	assert.ErrorIs(t, err, ErrReplayed)
+}
+
+func TestReplayWindow(t *testing.T) {
+	var w replayWindow
+	assert.False(t, w.update(0), "zero")
+	assert.True(t, w.update(1))
+	assert.False(t, w.update(1), "duplicate")
+	assert.True(t, w.update(replayWindowSize+1))
+	assert.False(t, w.update(1), "outside window")
+	assert.True(t, w.update(2))
+	assert.True(t, w.update(replayWindowSize))
+	assert.True(t, w.update(3*replayWindowSize))
+	assert.False(t, w.check(2*replayWindowSize), "outside window")
+	assert.True(t, w.check(2*replayWindowSize+1))
+}
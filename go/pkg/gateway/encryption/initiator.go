@@ -0,0 +1,269 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	gatewaypb "github.com/scionproto/scion/go/pkg/proto/gateway"
+)
+
+const (
+	// DefaultRekeyInterval is the default interval after which new keys are
+	// established.
+	DefaultRekeyInterval = 10 * time.Minute
+
+	minRetryInterval = time.Second
+	maxRetryInterval = time.Minute
+)
+
+// ErrNoKey indicates that a frame cannot be sent, because encryption is
+// required and no key is established yet.
+var ErrNoKey = serrors.New("no key established")
+
+// Initiator establishes the keys for a session and seals the frames of the
+// session. It is safe for concurrent use.
+type Initiator struct {
+	// SessionID is the ID of the session.
+	SessionID uint8
+	// LocalIA is the ISD-AS of the local gateway.
+	LocalIA addr.IA
+	// RemoteIA is the ISD-AS of the remote gateway.
+	RemoteIA addr.IA
+	// Mode is the encryption mode of the session. With Disabled, no key
+	// exchange is done and frames are not sealed.
+	Mode Mode
+	// Signer signs the key exchange requests.
+	Signer Signer
+	// Verifier verifies the key exchange responses.
+	Verifier Verifier
+	// RekeyInterval is the interval after which new keys are established. If
+	// zero, DefaultRekeyInterval is used.
+	RekeyInterval time.Duration
+
+	// sealer holds the *sealer that seals the frames. It is nil as long as no
+	// key is established. It is published atomically such that sealing frames
+	// does not contend with the key exchange. It is only written with mtx
+	// held.
+	sealer atomic.Value
+
+	mtx sync.Mutex
+	// pending is the key exchange that is awaiting a response.
+	pending *pendingExchange
+	// nextAttempt is the earliest time a new key exchange is started while
+	// the remote gateway does not answer.
+	nextAttempt time.Time
+	// retryInterval is the current back-off interval.
+	retryInterval time.Duration
+	// established is the time the current key was established.
+	established time.Time
+}
+
+type pendingExchange struct {
+	keyID   uint32
+	key     ephemeralKey
+	request *gatewaypb.KeyExchangeRequest
+}
+
+// Request returns the key exchange request that should be sent to the remote
+// gateway. It returns nil if no key exchange is due.
+func (i *Initiator) Request(ctx context.Context) (*gatewaypb.KeyExchangeRequest, error) {
+	if i.Mode == Disabled {
+		return nil, nil
+	}
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	now := time.Now()
+	if now.Before(i.nextAttempt) {
+		return nil, nil
+	}
+	if i.pending == nil && i.currentSealer() != nil &&
+		now.Sub(i.established) < i.rekeyInterval() {
+
+		return nil, nil
+	}
+	// Back off as long as the remote gateway does not answer, it might not
+	// support encryption.
+	if i.retryInterval == 0 {
+		i.retryInterval = minRetryInterval
+	}
+	i.nextAttempt = now.Add(i.retryInterval)
+	i.retryInterval *= 2
+	if i.retryInterval > maxRetryInterval {
+		i.retryInterval = maxRetryInterval
+	}
+	pending, err := i.newExchange(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i.pending = pending
+	return pending.request, nil
+}
+
+func (i *Initiator) newExchange(ctx context.Context) (*pendingExchange, error) {
+	if i.Signer == nil {
+		return nil, serrors.New("no signer configured")
+	}
+	key, err := newEphemeralKey()
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := i.newKeyID()
+	if err != nil {
+		return nil, err
+	}
+	signedMsg, err := signBody(ctx, i.Signer, &gatewaypb.KeyExchangeBody{
+		IsdAs:     uint64(i.LocalIA.IAInt()),
+		SessionId: uint32(i.SessionID),
+		KeyId:     keyID,
+		PublicKey: key.public,
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("signing key exchange request", err)
+	}
+	return &pendingExchange{
+		keyID: keyID,
+		key:   key,
+		request: &gatewaypb.KeyExchangeRequest{
+			SessionId:     uint32(i.SessionID),
+			SignedMessage: signedMsg,
+		},
+	}, nil
+}
+
+// newKeyID draws a random key ID. Random IDs make collisions between the
+// sessions of different gateways in the same AS unlikely. The responder
+// rejects key IDs that are already in use.
+func (i *Initiator) newKeyID() (uint32, error) {
+	var raw [4]byte
+	current := i.currentSealer()
+	for {
+		if _, err := rand.Read(raw[:]); err != nil {
+			return 0, serrors.WrapStr("generating key ID", err)
+		}
+		id := binary.BigEndian.Uint32(raw[:])
+		if current == nil || id != current.key.id {
+			return id, nil
+		}
+	}
+}
+
+// HandleResponse processes the key exchange response of the remote gateway.
+// If the response is valid, the established key is used for all subsequent
+// frames.
+func (i *Initiator) HandleResponse(ctx context.Context,
+	rep *gatewaypb.KeyExchangeResponse) error {
+
+	i.mtx.Lock()
+	pending := i.pending
+	i.mtx.Unlock()
+	if pending == nil {
+		return serrors.New("unexpected key exchange response")
+	}
+	body, _, err := verifyBody(ctx, i.Verifier, i.RemoteIA, i.SessionID, rep.SignedMessage)
+	if err != nil {
+		return err
+	}
+	if body.KeyId != pending.keyID || !bytes.Equal(body.PeerPublicKey, pending.key.public) {
+		return serrors.New("key exchange response does not match request",
+			"key_id", body.KeyId, "expected_key_id", pending.keyID)
+	}
+	key, err := deriveFrameKey(pending.key.private, body.PublicKey, exchangeParams{
+		initiatorIA:  i.LocalIA,
+		responderIA:  i.RemoteIA,
+		sessionID:    i.SessionID,
+		keyID:        pending.keyID,
+		initiatorPub: pending.key.public,
+		responderPub: body.PublicKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	if i.pending != pending {
+		// A newer exchange was started concurrently, the remote gateway will
+		// answer that one.
+		return nil
+	}
+	i.pending = nil
+	i.nextAttempt = time.Time{}
+	i.retryInterval = 0
+	i.established = time.Now()
+	i.sealer.Store(&sealer{sessionID: i.SessionID, key: key})
+	return nil
+}
+
+// Reset forces a new key exchange. The current key is used until the new key
+// is established. Reset should be called if the remote gateway might have lost
+// its keys, e.g., because it was unreachable for some time.
+func (i *Initiator) Reset() {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	i.pending = nil
+	i.nextAttempt = time.Time{}
+	i.retryInterval = 0
+	i.established = time.Time{}
+}
+
+// Seal seals the frame and appends the result to dst. If no key is
+// established yet and the mode is Optional, the frame is returned unmodified.
+// If the mode is Required, ErrNoKey is returned. dst must not overlap with the
+// frame.
+func (i *Initiator) Seal(dst, frame []byte) ([]byte, error) {
+	if i.Mode == Disabled {
+		return frame, nil
+	}
+	s := i.currentSealer()
+	if s == nil {
+		if i.Mode == Required {
+			return nil, ErrNoKey
+		}
+		return frame, nil
+	}
+	return s.seal(dst, frame), nil
+}
+
+// Overhead returns the number of bytes sealing adds to a frame.
+func (i *Initiator) Overhead() int {
+	if i.Mode == Disabled {
+		return 0
+	}
+	return Overhead
+}
+
+// currentSealer returns the sealer of the established key, or nil if no key is
+// established yet.
+func (i *Initiator) currentSealer() *sealer {
+	s, _ := i.sealer.Load().(*sealer)
+	return s
+}
+
+func (i *Initiator) rekeyInterval() time.Duration {
+	if i.RekeyInterval == 0 {
+		return DefaultRekeyInterval
+	}
+	return i.RekeyInterval
+}
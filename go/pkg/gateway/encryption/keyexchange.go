@@ -0,0 +1,172 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto/signed"
+	"github.com/scionproto/scion/go/lib/serrors"
+	cryptopb "github.com/scionproto/scion/go/pkg/proto/crypto"
+	gatewaypb "github.com/scionproto/scion/go/pkg/proto/gateway"
+	"github.com/scionproto/scion/go/pkg/trust"
+)
+
+const (
+	// maxMessageAge is the maximum age of a key exchange message.
+	maxMessageAge = time.Minute
+	// maxClockSkew is the maximum time a key exchange message may be
+	// timestamped in the future.
+	maxClockSkew = 5 * time.Second
+)
+
+var kdfInfo = []byte("scion gateway frame key v1")
+
+// Signer signs key exchange messages with the AS key. It is implemented by
+// trust.Signer.
+type Signer interface {
+	Sign(ctx context.Context, msg []byte,
+		associatedData ...[]byte) (*cryptopb.SignedMessage, error)
+}
+
+// Verifier verifies key exchange messages. It must only accept messages that
+// are signed by the given ISD-AS.
+type Verifier interface {
+	Verify(ctx context.Context, ia addr.IA,
+		signedMsg *cryptopb.SignedMessage) (*signed.Message, error)
+}
+
+// TrustVerifier verifies key exchange messages with the certificate chains
+// provided by the trust engine.
+type TrustVerifier struct {
+	Verifier trust.Verifier
+}
+
+// Verify verifies the message and checks that it is signed by the given
+// ISD-AS.
+func (v TrustVerifier) Verify(ctx context.Context, ia addr.IA,
+	signedMsg *cryptopb.SignedMessage) (*signed.Message, error) {
+
+	verifier := v.Verifier
+	verifier.BoundIA = ia
+	return verifier.Verify(ctx, signedMsg)
+}
+
+// ephemeralKey is an X25519 key pair that is used for a single key exchange.
+type ephemeralKey struct {
+	private []byte
+	public  []byte
+}
+
+func newEphemeralKey() (ephemeralKey, error) {
+	private := make([]byte, curve25519.ScalarSize)
+	if _, err := rand.Read(private); err != nil {
+		return ephemeralKey{}, serrors.WrapStr("generating private key", err)
+	}
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	if err != nil {
+		return ephemeralKey{}, serrors.WrapStr("computing public key", err)
+	}
+	return ephemeralKey{private: private, public: public}, nil
+}
+
+// exchangeParams are the parameters of a key exchange that are bound into the
+// derived key.
+type exchangeParams struct {
+	initiatorIA  addr.IA
+	responderIA  addr.IA
+	sessionID    uint8
+	keyID        uint32
+	initiatorPub []byte
+	responderPub []byte
+}
+
+// deriveFrameKey derives the key that protects the frames of the initiator.
+func deriveFrameKey(private, peerPub []byte, p exchangeParams) (*frameKey, error) {
+	shared, err := curve25519.X25519(private, peerPub)
+	if err != nil {
+		return nil, serrors.WrapStr("computing shared secret", err)
+	}
+	salt := append(append([]byte{}, p.initiatorPub...), p.responderPub...)
+	info := make([]byte, len(kdfInfo)+21)
+	n := copy(info, kdfInfo)
+	binary.BigEndian.PutUint64(info[n:], uint64(p.initiatorIA.IAInt()))
+	binary.BigEndian.PutUint64(info[n+8:], uint64(p.responderIA.IAInt()))
+	info[n+16] = p.sessionID
+	binary.BigEndian.PutUint32(info[n+17:], p.keyID)
+
+	material := make([]byte, keyLen+saltLen)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, salt, info), material); err != nil {
+		return nil, serrors.WrapStr("expanding key", err)
+	}
+	return newFrameKey(p.keyID, material)
+}
+
+func signBody(ctx context.Context, signer Signer,
+	body *gatewaypb.KeyExchangeBody) (*cryptopb.SignedMessage, error) {
+
+	raw, err := proto.Marshal(body)
+	if err != nil {
+		return nil, serrors.WrapStr("packing key exchange body", err)
+	}
+	return signer.Sign(ctx, raw)
+}
+
+// verifyBody verifies the signed key exchange message and checks that it was
+// created recently by the given ISD-AS for the given session.
+func verifyBody(ctx context.Context, verifier Verifier, ia addr.IA, sessionID uint8,
+	signedMsg *cryptopb.SignedMessage) (*gatewaypb.KeyExchangeBody, time.Time, error) {
+
+	if signedMsg == nil {
+		return nil, time.Time{}, serrors.New("missing signed message")
+	}
+	msg, err := verifier.Verify(ctx, ia, signedMsg)
+	if err != nil {
+		return nil, time.Time{}, serrors.WrapStr("verifying key exchange message", err)
+	}
+	now := time.Now()
+	ts := msg.Header.Timestamp
+	if ts.Before(now.Add(-maxMessageAge)) || ts.After(now.Add(maxClockSkew)) {
+		return nil, time.Time{}, serrors.New("key exchange message not fresh",
+			"timestamp", ts, "now", now)
+	}
+	var body gatewaypb.KeyExchangeBody
+	if err := proto.Unmarshal(msg.Body, &body); err != nil {
+		return nil, time.Time{}, serrors.WrapStr("parsing key exchange body", err)
+	}
+	if signer := addr.IAInt(body.IsdAs).IA(); !signer.Equal(ia) {
+		return nil, time.Time{}, serrors.New("ISD-AS mismatch",
+			"expected", ia, "actual", signer)
+	}
+	if body.SessionId != uint32(sessionID) {
+		return nil, time.Time{}, serrors.New("session ID mismatch",
+			"expected", sessionID, "actual", body.SessionId)
+	}
+	if len(body.PublicKey) != curve25519.PointSize {
+		return nil, time.Time{}, serrors.New("invalid public key length",
+			"expected", curve25519.PointSize, "actual", len(body.PublicKey))
+	}
+	return &body, ts, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Mode is the encryption mode of a session.
+type Mode int
+
+const (
+	// Disabled sessions are never encrypted.
+	Disabled Mode = iota
+	// Optional sessions are encrypted if the remote gateway supports it.
+	// Until keys are established, traffic is sent unencrypted.
+	Optional
+	// Required sessions only carry encrypted traffic. Until keys are
+	// established, traffic is dropped. Unencrypted traffic from the remote AS
+	// is dropped as well.
+	Required
+)
+
+// ParseMode parses the string representation of a mode. The empty string is
+// parsed as Disabled.
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "", "disabled":
+		return Disabled, nil
+	case "optional":
+		return Optional, nil
+	case "required":
+		return Required, nil
+	default:
+		return Disabled, serrors.New("unknown encryption mode", "mode", s)
+	}
+}
+
+func (m Mode) String() string {
+	switch m {
+	case Disabled:
+		return "disabled"
+	case Optional:
+		return "optional"
+	case Required:
+		return "required"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m Mode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *Mode) UnmarshalText(b []byte) error {
+	parsed, err := ParseMode(string(b))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	gatewaypb "github.com/scionproto/scion/go/pkg/proto/gateway"
+)
+
+// DefaultKeyLifetime is the default time after which keys of a remote session
+// that was not rekeyed are removed.
+const DefaultKeyLifetime = 3 * DefaultRekeyInterval
+
+// Responder answers the key exchange requests of remote gateways and opens the
+// frames that are sealed with the established keys. It is safe for concurrent
+// use.
+type Responder struct {
+	// LocalIA is the ISD-AS of the local gateway.
+	LocalIA addr.IA
+	// Signer signs the key exchange responses.
+	Signer Signer
+	// Verifier verifies the key exchange requests.
+	Verifier Verifier
+	// Required reports whether traffic from the remote AS must be encrypted.
+	// If nil, unencrypted traffic is accepted from all remote ASes.
+	Required func(addr.IA) bool
+	// KeyLifetime is the time after which keys of a remote session that was
+	// not rekeyed are removed. If zero, DefaultKeyLifetime is used.
+	KeyLifetime time.Duration
+
+	mtx sync.RWMutex
+	// sessions contains the state per remote session, indexed by the remote
+	// probe address and session ID.
+	sessions map[string]*remoteSession
+	// keys contains the keys of all remote sessions, indexed by the remote
+	// ISD-AS and key ID.
+	keys map[remoteKey]*opener
+}
+
+type remoteKey struct {
+	ia    addr.IA
+	keyID uint32
+}
+
+type remoteSession struct {
+	ia addr.IA
+	// timestamp is the signing time of the last accepted request.
+	timestamp time.Time
+	// publicKey is the initiator public key of the last accepted request.
+	publicKey []byte
+	// response is the response to the last accepted request. It is sent again
+	// if the request is retransmitted.
+	response *gatewaypb.KeyExchangeResponse
+	// keyIDs contains the current and the previous key ID of the session.
+	keyIDs []uint32
+	// updated is the time the last request was accepted.
+	updated time.Time
+}
+
+// HandleKeyExchange handles the key exchange request received from src. The
+// established key is used to open frames immediately.
+func (r *Responder) HandleKeyExchange(ctx context.Context, src *snet.UDPAddr,
+	req *gatewaypb.KeyExchangeRequest) (*gatewaypb.KeyExchangeResponse, error) {
+
+	if r.Signer == nil || r.Verifier == nil {
+		return nil, serrors.New("key exchange not configured")
+	}
+	if req.SessionId > 255 {
+		return nil, serrors.New("invalid session ID", "session_id", req.SessionId)
+	}
+	sessionID := uint8(req.SessionId)
+	body, ts, err := verifyBody(ctx, r.Verifier, src.IA, sessionID, req.SignedMessage)
+	if err != nil {
+		return nil, err
+	}
+	sessionKey := fmt.Sprintf("%s/%s/%d", src.IA, src.Host, sessionID)
+
+	r.mtx.RLock()
+	s, ok := r.sessions[sessionKey]
+	if ok && bytes.Equal(s.publicKey, body.PublicKey) {
+		// The response was lost, answer the retransmitted request again.
+		response := s.response
+		r.mtx.RUnlock()
+		return response, nil
+	}
+	if ok && !ts.After(s.timestamp) {
+		r.mtx.RUnlock()
+		return nil, serrors.New("key exchange request is outdated",
+			"timestamp", ts, "last_timestamp", s.timestamp)
+	}
+	r.mtx.RUnlock()
+
+	key, err := newEphemeralKey()
+	if err != nil {
+		return nil, err
+	}
+	signedMsg, err := signBody(ctx, r.Signer, &gatewaypb.KeyExchangeBody{
+		IsdAs:         uint64(r.LocalIA.IAInt()),
+		SessionId:     uint32(sessionID),
+		KeyId:         body.KeyId,
+		PublicKey:     key.public,
+		PeerPublicKey: body.PublicKey,
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("signing key exchange response", err)
+	}
+	frameKey, err := deriveFrameKey(key.private, body.PublicKey, exchangeParams{
+		initiatorIA:  src.IA,
+		responderIA:  r.LocalIA,
+		sessionID:    sessionID,
+		keyID:        body.KeyId,
+		initiatorPub: body.PublicKey,
+		responderPub: key.public,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := &gatewaypb.KeyExchangeResponse{
+		SessionId:     uint32(sessionID),
+		SignedMessage: signedMsg,
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.sessions == nil {
+		r.sessions = make(map[string]*remoteSession)
+		r.keys = make(map[remoteKey]*opener)
+	}
+	r.cleanup(time.Now())
+	s, ok = r.sessions[sessionKey]
+	if ok && !ts.After(s.timestamp) {
+		return nil, serrors.New("key exchange request is outdated",
+			"timestamp", ts, "last_timestamp", s.timestamp)
+	}
+	if !ok {
+		s = &remoteSession{ia: src.IA}
+		r.sessions[sessionKey] = s
+	}
+	rk := remoteKey{ia: src.IA, keyID: body.KeyId}
+	if _, ok := r.keys[rk]; ok && !containsKeyID(s.keyIDs, body.KeyId) {
+		return nil, serrors.New("key ID already in use", "key_id", body.KeyId)
+	}
+	r.keys[rk] = &opener{key: frameKey}
+	if !containsKeyID(s.keyIDs, body.KeyId) {
+		s.keyIDs = append(s.keyIDs, body.KeyId)
+	}
+	// Keep the previous key such that frames that are in flight can still be
+	// opened.
+	for len(s.keyIDs) > 2 {
+		delete(r.keys, remoteKey{ia: src.IA, keyID: s.keyIDs[0]})
+		s.keyIDs = s.keyIDs[1:]
+	}
+	s.timestamp = ts
+	s.publicKey = body.PublicKey
+	s.response = response
+	s.updated = time.Now()
+	return response, nil
+}
+
+// cleanup removes the sessions that were not rekeyed within the key lifetime.
+// The caller must hold the write lock.
+func (r *Responder) cleanup(now time.Time) {
+	lifetime := r.KeyLifetime
+	if lifetime == 0 {
+		lifetime = DefaultKeyLifetime
+	}
+	for k, s := range r.sessions {
+		if now.Sub(s.updated) < lifetime {
+			continue
+		}
+		for _, id := range s.keyIDs {
+			delete(r.keys, remoteKey{ia: s.ia, keyID: id})
+		}
+		delete(r.sessions, k)
+	}
+}
+
+// Open authenticates and decrypts a sealed frame received from the remote AS.
+// The plaintext frame is returned, it shares the underlying buffer with the
+// sealed frame.
+func (r *Responder) Open(ia addr.IA, sealed []byte) ([]byte, error) {
+	id, err := keyID(sealed)
+	if err != nil {
+		return nil, err
+	}
+	r.mtx.RLock()
+	o, ok := r.keys[remoteKey{ia: ia, keyID: id}]
+	r.mtx.RUnlock()
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return o.open(sealed)
+}
+
+// AllowPlaintext reports whether unencrypted frames from the remote AS are
+// accepted.
+func (r *Responder) AllowPlaintext(ia addr.IA) bool {
+	return r.Required == nil || !r.Required(ia)
+}
+
+func containsKeyID(ids []uint32, id uint32) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -16,6 +16,7 @@ package gateway
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"net"
 	"net/http"
@@ -42,10 +43,13 @@ import (
 	"github.com/scionproto/scion/go/lib/sock/reliable/reconnect"
 	"github.com/scionproto/scion/go/lib/svc"
 	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/pkg/gateway/bgp"
 	"github.com/scionproto/scion/go/pkg/gateway/config"
 	"github.com/scionproto/scion/go/pkg/gateway/control"
 	controlgrpc "github.com/scionproto/scion/go/pkg/gateway/control/grpc"
 	"github.com/scionproto/scion/go/pkg/gateway/dataplane"
+	"github.com/scionproto/scion/go/pkg/gateway/encryption"
+	"github.com/scionproto/scion/go/pkg/gateway/ha"
 	"github.com/scionproto/scion/go/pkg/gateway/pathhealth"
 	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/go/pkg/gateway/routing"
@@ -53,6 +57,7 @@ import (
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
 	gatewaypb "github.com/scionproto/scion/go/pkg/proto/gateway"
 	"github.com/scionproto/scion/go/pkg/service"
+	"github.com/scionproto/scion/go/pkg/trust"
 )
 
 const (
@@ -94,6 +99,41 @@ func (dpf DataplaneSessionFactory) New(id uint8, policyID int,
 	return sess
 }
 
+// TunnelKeyExchangerFactory constructs the key exchangers of encrypted
+// sessions.
+type TunnelKeyExchangerFactory struct {
+	LocalIA       addr.IA
+	Signer        encryption.Signer
+	Verifier      encryption.Verifier
+	RekeyInterval time.Duration
+}
+
+// sealingSession is a dataplane session that supports protecting its frames.
+type sealingSession interface {
+	SetSealer(dataplane.FrameSealer)
+}
+
+func (f TunnelKeyExchangerFactory) New(config *control.SessionConfig,
+	session control.DataplaneSession) control.TunnelKeyExchanger {
+
+	if config.Encryption == encryption.Disabled {
+		return nil
+	}
+	initiator := &encryption.Initiator{
+		SessionID:     config.ID,
+		LocalIA:       f.LocalIA,
+		RemoteIA:      config.IA,
+		Mode:          config.Encryption,
+		Signer:        f.Signer,
+		Verifier:      f.Verifier,
+		RekeyInterval: f.RekeyInterval,
+	}
+	if s, ok := session.(sealingSession); ok {
+		s.SetSealer(initiator)
+	}
+	return initiator
+}
+
 type PacketConnFactory struct {
 	Network *snet.SCIONNetwork
 	Addr    *net.UDPAddr
@@ -151,14 +191,29 @@ func (ignoreSCMP) Handle(pkt *snet.Packet) error {
 	return nil
 }
 
+// LearnedPrefixes provides the prefixes that are learned from an external
+// routing protocol.
+type LearnedPrefixes interface {
+	Prefixes() []*net.IPNet
+}
+
 // SelectAdvertisedRoutes computes the networks that should be advertised
 // depending on the state of the last published routing policy file.
 type SelectAdvertisedRoutes struct {
 	ConfigPublisher *control.ConfigPublisher
+	// BGP provides the prefixes learned via BGP. The prefixes are advertised
+	// subject to the redistribute-bgp rules of the routing policy. If nil, only
+	// the statically configured prefixes are advertised.
+	BGP LearnedPrefixes
 }
 
 func (a *SelectAdvertisedRoutes) AdvertiseList(from, to addr.IA) []*net.IPNet {
-	return routing.AdvertiseList(a.ConfigPublisher.RoutingPolicy(), from, to)
+	pol := a.ConfigPublisher.RoutingPolicy()
+	nets := routing.AdvertiseList(pol, from, to)
+	if a.BGP != nil {
+		nets = append(nets, routing.RedistributedBGP(pol, from, to, a.BGP.Prefixes())...)
+	}
+	return nets
 }
 
 type RoutingPolicyPublisherAdapter struct {
@@ -230,6 +285,33 @@ type Gateway struct {
 
 	// Metrics are the metrics exported by the gateway.
 	Metrics *Metrics
+
+	// TunnelKeySigner signs the key exchange messages of encrypted sessions
+	// with the AS key. If nil, the gateway does not answer key exchange
+	// requests and cannot establish encrypted sessions.
+	TunnelKeySigner encryption.Signer
+	// TunnelKeyVerifier verifies the key exchange messages of remote
+	// gateways.
+	TunnelKeyVerifier encryption.Verifier
+	// TunnelRekeyInterval is the interval after which the keys of encrypted
+	// sessions are replaced. If zero, the default is used.
+	TunnelRekeyInterval time.Duration
+
+	// ControlTLS authenticates the QUIC connections to remote gateways with
+	// the AS certificate chain. If nil, throwaway self-signed certificates are
+	// used and remote gateways are not authenticated.
+	ControlTLS *trust.TLSCryptoManager
+
+	// BGP is the configuration of the embedded BGP speaker. The speaker
+	// exports the prefixes learned from remote gateways to the BGP peers and
+	// imports the prefixes that are redistributed to remote gateways. If nil,
+	// the speaker is disabled.
+	BGP *bgp.Config
+
+	// HA is the configuration of the active/standby high availability group.
+	// Only the leader of the group advertises its prefixes as active to the
+	// remote gateways. If nil, the gateway is always active.
+	HA *ha.Config
 }
 
 func (g *Gateway) Run(ctx context.Context) error {
@@ -274,6 +356,47 @@ func (g *Gateway) Run(ctx context.Context) error {
 
 	routePublisherFactory := createRouteManager(ctx, deviceManager)
 
+	var bgpSpeaker *bgp.Speaker
+	if g.BGP != nil {
+		bgpSpeaker = &bgp.Speaker{
+			Config:         *g.BGP,
+			ExportedRoutes: routePublisherFactory,
+		}
+		if err := bgpSpeaker.Config.Validate(); err != nil {
+			return serrors.WrapStr("validating BGP configuration", err)
+		}
+		go func() {
+			defer log.HandlePanic()
+			if err := bgpSpeaker.Run(ctx); err != nil {
+				panic(err)
+			}
+		}()
+		logger.Debug("BGP speaker started")
+	}
+
+	var elector *ha.Elector
+	if g.HA != nil {
+		elector = &ha.Elector{
+			Config: *g.HA,
+		}
+		if g.Metrics != nil {
+			elector.Metrics = ha.Metrics{
+				Leader:      metrics.NewPromGauge(g.Metrics.HALeader),
+				RoleChanges: metrics.NewPromCounter(g.Metrics.HARoleChangesTotal),
+			}
+		}
+		if err := elector.Config.Validate(); err != nil {
+			return serrors.WrapStr("validating high availability configuration", err)
+		}
+		go func() {
+			defer log.HandlePanic()
+			if err := elector.Run(ctx); err != nil {
+				panic(err)
+			}
+		}()
+		logger.Debug("High availability election started")
+	}
+
 	// *************************************************************************
 	// Initialize base SCION network information: IA + Dispatcher connectivity
 	// *************************************************************************
@@ -443,10 +566,17 @@ func (g *Gateway) Run(ctx context.Context) error {
 	// The server listener is needed to handle prefix fetching requests.
 	// ***********************************************************************************
 
-	// Generate throwaway self-signed TLS certificates. These DO NOT PROVIDE ANY SECURITY.
-	ephemeralTLSConfig, err := infraenv.GenerateTLSConfig()
-	if err != nil {
-		return serrors.WrapStr("unable to generate TLS config", err)
+	var serverTLSConfig, clientTLSConfig *tls.Config
+	if g.ControlTLS != nil {
+		serverTLSConfig = g.ControlTLS.ServerTLSConfig()
+		clientTLSConfig = g.ControlTLS.ClientTLSConfig()
+	} else {
+		// Generate throwaway self-signed TLS certificates. These DO NOT PROVIDE ANY SECURITY.
+		ephemeralTLSConfig, err := infraenv.GenerateTLSConfig()
+		if err != nil {
+			return serrors.WrapStr("unable to generate TLS config", err)
+		}
+		serverTLSConfig, clientTLSConfig = ephemeralTLSConfig, ephemeralTLSConfig
 	}
 
 	// scionNetwork is the network for all SCION connections, with the exception of the QUIC server
@@ -482,7 +612,7 @@ func (g *Gateway) Run(ctx context.Context) error {
 
 	quicClientDialer := &squic.ConnDialer{
 		Conn:      clientConn,
-		TLSConfig: ephemeralTLSConfig,
+		TLSConfig: clientTLSConfig,
 	}
 
 	// remoteMonitor subscribes to the list of known remote ASes, and launches workers that
@@ -553,7 +683,7 @@ func (g *Gateway) Run(ctx context.Context) error {
 	logger.Info("QUIC server connection initialized",
 		"local_addr", serverConn.LocalAddr())
 
-	internalQUICServerListener, err := quic.Listen(serverConn, ephemeralTLSConfig, nil)
+	internalQUICServerListener, err := quic.Listen(serverConn, serverTLSConfig, nil)
 	if err != nil {
 		return serrors.WrapStr("unable to initializer server QUIC listener", err)
 	}
@@ -564,17 +694,27 @@ func (g *Gateway) Run(ctx context.Context) error {
 	if g.Metrics != nil {
 		paMetric = metrics.NewPromGauge(g.Metrics.PrefixesAdvertised)
 	}
-	discoveryServer := grpc.NewServer(libgrpc.UnaryServerInterceptor())
-	gatewaypb.RegisterIPPrefixesServiceServer(
-		discoveryServer,
-		controlgrpc.IPPrefixServer{
-			LocalIA: localIA,
-			Advertiser: &SelectAdvertisedRoutes{
-				ConfigPublisher: configPublisher,
-			},
-			PrefixesAdvertised: paMetric,
-		},
-	)
+	advertiser := &SelectAdvertisedRoutes{
+		ConfigPublisher: configPublisher,
+	}
+	if bgpSpeaker != nil {
+		advertiser.BGP = bgpSpeaker
+	}
+	prefixServer := controlgrpc.IPPrefixServer{
+		LocalIA:            localIA,
+		Advertiser:         advertiser,
+		PrefixesAdvertised: paMetric,
+	}
+	if elector != nil {
+		prefixServer.HA = elector
+	}
+	discoveryServerOpts := []grpc.ServerOption{libgrpc.UnaryServerInterceptor()}
+	if g.ControlTLS != nil {
+		discoveryServerOpts = append(discoveryServerOpts,
+			grpc.Creds(libgrpc.PeerCredentials{Verifier: g.ControlTLS}))
+	}
+	discoveryServer := grpc.NewServer(discoveryServerOpts...)
+	gatewaypb.RegisterIPPrefixesServiceServer(discoveryServer, prefixServer)
 
 	go func() {
 		defer log.HandlePanic()
@@ -594,7 +734,18 @@ func (g *Gateway) Run(ctx context.Context) error {
 	if err != nil {
 		return serrors.WrapStr("creating server probe conn", err)
 	}
+	// The tunnel key responder establishes the keys of sessions that remote
+	// gateways want to encrypt, and opens the protected frames on ingress.
+	tunnelKeyResponder := &encryption.Responder{
+		LocalIA:  localIA,
+		Signer:   g.TunnelKeySigner,
+		Verifier: g.TunnelKeyVerifier,
+		Required: configPublisher.EncryptionRequired,
+	}
 	probeServer := controlgrpc.ProbeDispatcher{}
+	if g.TunnelKeySigner != nil && g.TunnelKeyVerifier != nil {
+		probeServer.KeyExchangeHandler = tunnelKeyResponder
+	}
 	probeServerCtx, probeServerCancel := context.WithCancel(context.Background())
 	defer probeServerCancel()
 	go func() {
@@ -606,7 +757,7 @@ func (g *Gateway) Run(ctx context.Context) error {
 
 	// Start dataplane ingress
 	if err := StartIngress(ctx, scionNetwork, g.DataServerAddr, deviceManager,
-		g.Metrics); err != nil {
+		g.Metrics, tunnelKeyResponder); err != nil {
 
 		return err
 	}
@@ -658,6 +809,12 @@ func (g *Gateway) Run(ctx context.Context) error {
 				},
 				Metrics: CreateSessionMetrics(g.Metrics),
 			},
+			TunnelKeyExchangerFactory: TunnelKeyExchangerFactory{
+				LocalIA:       localIA,
+				Signer:        g.TunnelKeySigner,
+				Verifier:      g.TunnelKeyVerifier,
+				RekeyInterval: g.TunnelRekeyInterval,
+			},
 			Metrics: CreateEngineMetrics(g.Metrics),
 		},
 		RoutePublisherFactory: routePublisherFactory,
@@ -693,7 +850,27 @@ func (g *Gateway) Run(ctx context.Context) error {
 	}
 	g.HTTPEndpoints["diagnostics/sgrp"] = service.StatusPage{
 		Info:    "SGRP diagnostics",
-		Handler: g.diagnosticsSGRP(routePublisherFactory, configPublisher),
+		Handler: g.diagnosticsSGRP(routePublisherFactory, configPublisher, advertiser.BGP),
+	}
+	if bgpSpeaker != nil {
+		g.HTTPEndpoints["diagnostics/bgp"] = service.StatusPage{
+			Info: "BGP speaker diagnostics",
+			Handler: func(w http.ResponseWriter, _ *http.Request) {
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "    ")
+				enc.Encode(bgpSpeaker.Diagnostics())
+			},
+		}
+	}
+	if elector != nil {
+		g.HTTPEndpoints["diagnostics/ha"] = service.StatusPage{
+			Info: "high availability diagnostics",
+			Handler: func(w http.ResponseWriter, _ *http.Request) {
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "    ")
+				enc.Encode(elector.Diagnostics())
+			},
+		}
 	}
 
 	// XXX(scrye): Use an empty file here because the server often doesn't have
@@ -714,6 +891,7 @@ func (g *Gateway) Run(ctx context.Context) error {
 func (g *Gateway) diagnosticsSGRP(
 	routePublisherFactory control.PublisherFactory,
 	pub *control.ConfigPublisher,
+	learned LearnedPrefixes,
 ) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, _ *http.Request) {
@@ -723,15 +901,22 @@ func (g *Gateway) diagnosticsSGRP(
 			} `json:"advertise"`
 			Learned struct {
 				Dynamic []string `json:"dynamic"`
+				BGP     []string `json:"bgp"`
 			} `json:"learned"`
 		}
 		// Avoid null in json output.
 		d.Advertise.Static = []string{}
 		d.Learned.Dynamic = []string{}
+		d.Learned.BGP = []string{}
 
 		for _, s := range routing.StaticAdvertised(pub.RoutingPolicy()) {
 			d.Advertise.Static = append(d.Advertise.Static, s.String())
 		}
+		if learned != nil {
+			for _, s := range learned.Prefixes() {
+				d.Learned.BGP = append(d.Learned.BGP, s.String())
+			}
+		}
 		if p, ok := routePublisherFactory.(interface{ Diagnostics() control.Diagnostics }); ok {
 			for _, r := range p.Diagnostics().Routes {
 				d.Learned.Dynamic = append(d.Learned.Dynamic, r.Prefix.String())
@@ -777,7 +962,7 @@ func CreateIngressMetrics(m *Metrics) dataplane.IngressMetrics {
 }
 
 func StartIngress(ctx context.Context, scionNetwork *snet.SCIONNetwork, dataAddr *net.UDPAddr,
-	deviceManager control.DeviceManager, metrics *Metrics) error {
+	deviceManager control.DeviceManager, metrics *Metrics, opener dataplane.FrameOpener) error {
 
 	logger := log.FromCtx(ctx)
 	dataplaneServerConn, err := scionNetwork.Listen(
@@ -794,6 +979,7 @@ func StartIngress(ctx context.Context, scionNetwork *snet.SCIONNetwork, dataAddr
 		Conn:          dataplaneServerConn,
 		DeviceManager: deviceManager,
 		Metrics:       ingressMetrics,
+		Opener:        opener,
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -832,7 +1018,7 @@ func CreateEngineMetrics(m *Metrics) control.EngineMetrics {
 }
 
 func createRouteManager(ctx context.Context,
-	deviceManager control.DeviceManager) control.PublisherFactory {
+	deviceManager control.DeviceManager) *routemgr.Linux {
 
 	linux := &routemgr.Linux{DeviceManager: deviceManager}
 	go func() {
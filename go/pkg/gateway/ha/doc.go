@@ -0,0 +1,41 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ha implements active/standby high availability for gateways in the
+// same AS.
+//
+// The gateways of a high availability group periodically send heartbeats to
+// each other over UDP on the local network. Every heartbeat carries the ID and
+// the priority of the sender, and whether the sender currently considers
+// itself the leader. A gateway is considered dead, if no heartbeat was
+// received within the dead interval.
+//
+// The leader is elected as follows:
+//
+//  - If one or more live gateways claim leadership, the claimant with the
+//    highest priority remains the leader. Ties are broken by the lowest ID.
+//    Thus, a recovering gateway with higher priority does not preempt a
+//    healthy leader.
+//  - Otherwise, the live gateway with the highest priority becomes the leader,
+//    again breaking ties by the lowest ID.
+//
+// After startup, a gateway waits for one dead interval before it takes part
+// in the election, such that it learns about an existing leader first.
+//
+// The leader advertises its prefixes to remote gateways. The standby gateways
+// keep their sessions and path health state warm and advertise their prefixes
+// marked as standby. Remote gateways prefer sessions to the leader, and fail
+// over to the standby sessions as soon as the roles change or the leader
+// sessions become unhealthy.
+package ha
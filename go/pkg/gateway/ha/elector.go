@@ -0,0 +1,402 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// DefaultHeartbeatInterval is the default interval between heartbeats.
+	DefaultHeartbeatInterval = 500 * time.Millisecond
+	// DefaultDeadInterval is the default time after which a gateway is
+	// considered dead if no heartbeat was received.
+	DefaultDeadInterval = 2 * time.Second
+	// maxIDLength is the maximum length of a gateway ID.
+	maxIDLength = 255
+)
+
+// Role is the role of a gateway in a high availability group.
+type Role int
+
+const (
+	// Standby gateways keep their sessions warm but are not preferred by the
+	// remote gateways.
+	Standby Role = iota
+	// Leader is the gateway that is preferred by the remote gateways.
+	Leader
+)
+
+func (r Role) String() string {
+	switch r {
+	case Standby:
+		return "standby"
+	case Leader:
+		return "leader"
+	default:
+		return "unknown"
+	}
+}
+
+// Config is the configuration of the high availability group.
+type Config struct {
+	// ID identifies the local gateway. It must be unique within the group.
+	ID string
+	// Priority is the election priority of the local gateway. Gateways with
+	// higher priority are preferred as leader.
+	Priority uint8
+	// ListenAddr is the UDP address on which heartbeats are received.
+	ListenAddr string
+	// Peers are the UDP addresses of the other gateways in the group.
+	Peers []string
+	// HeartbeatInterval is the interval between heartbeats. If zero,
+	// DefaultHeartbeatInterval is used.
+	HeartbeatInterval time.Duration
+	// DeadInterval is the time after which a peer is considered dead if no
+	// heartbeat was received. If zero, DefaultDeadInterval is used.
+	DeadInterval time.Duration
+}
+
+// Validate checks that the configuration is complete and initializes the
+// defaults.
+func (cfg *Config) Validate() error {
+	if cfg.ID == "" {
+		return serrors.New("ID not set")
+	}
+	if len(cfg.ID) > maxIDLength {
+		return serrors.New("ID too long", "length", len(cfg.ID), "max", maxIDLength)
+	}
+	if len(cfg.Peers) == 0 {
+		return serrors.New("no peers configured")
+	}
+	for _, p := range cfg.Peers {
+		if _, err := net.ResolveUDPAddr("udp", p); err != nil {
+			return serrors.WrapStr("resolving peer address", err, "peer", p)
+		}
+	}
+	if cfg.HeartbeatInterval == 0 {
+		cfg.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if cfg.DeadInterval == 0 {
+		cfg.DeadInterval = DefaultDeadInterval
+	}
+	if cfg.DeadInterval <= cfg.HeartbeatInterval {
+		return serrors.New("dead interval must be larger than heartbeat interval",
+			"dead_interval", cfg.DeadInterval, "heartbeat_interval", cfg.HeartbeatInterval)
+	}
+	return nil
+}
+
+// Metrics are the metrics reported by the elector.
+type Metrics struct {
+	// Leader is set to 1 if the local gateway is the leader, and 0 otherwise.
+	Leader metrics.Gauge
+	// RoleChanges counts the role changes of the local gateway.
+	RoleChanges metrics.Counter
+}
+
+// Elector elects the leader of a high availability group by exchanging
+// heartbeats with the peers.
+type Elector struct {
+	// Config is the configuration of the group.
+	Config Config
+	// Conn is used to send and receive heartbeats. If nil, the elector
+	// listens on Config.ListenAddr.
+	Conn net.PacketConn
+	// Metrics are the metrics reported by the elector. If not set, no
+	// metrics are reported.
+	Metrics Metrics
+
+	mtx sync.Mutex
+	// role is the current role of the local gateway.
+	role Role
+	// since is the time of the last role change.
+	since time.Time
+	// started is the time the elector was started.
+	started time.Time
+	// peers is the state of the peers keyed by ID.
+	peers map[string]*peerState
+	// addrs are the configured peer addresses.
+	addrs map[string]struct{}
+}
+
+type peerState struct {
+	addr     string
+	priority uint8
+	leader   bool
+	lastSeen time.Time
+}
+
+// Run runs the elector until the context is canceled.
+func (e *Elector) Run(ctx context.Context) error {
+	if err := e.Config.Validate(); err != nil {
+		return serrors.WrapStr("validating high availability configuration", err)
+	}
+	peers := make([]*net.UDPAddr, 0, len(e.Config.Peers))
+	addrs := make(map[string]struct{}, len(e.Config.Peers))
+	for _, p := range e.Config.Peers {
+		a, err := net.ResolveUDPAddr("udp", p)
+		if err != nil {
+			return serrors.WrapStr("resolving peer address", err, "peer", p)
+		}
+		peers = append(peers, a)
+		addrs[a.String()] = struct{}{}
+	}
+	conn := e.Conn
+	if conn == nil {
+		if e.Config.ListenAddr == "" {
+			return serrors.New("listen address not set")
+		}
+		var err error
+		if conn, err = net.ListenPacket("udp", e.Config.ListenAddr); err != nil {
+			return serrors.WrapStr("listening for heartbeats", err)
+		}
+		defer conn.Close()
+	}
+	// Clear the deadline that a previous run might have left behind.
+	conn.SetReadDeadline(time.Time{})
+
+	now := time.Now()
+	e.mtx.Lock()
+	e.role = Standby
+	e.since = now
+	e.started = now
+	e.peers = make(map[string]*peerState)
+	e.addrs = addrs
+	e.mtx.Unlock()
+	metrics.GaugeSet(e.Metrics.Leader, 0)
+
+	logger := log.FromCtx(ctx)
+	logger.Info("Starting high availability election", "id", e.Config.ID,
+		"priority", e.Config.Priority, "peers", e.Config.Peers)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer log.HandlePanic()
+		defer wg.Done()
+		e.receive(ctx, conn)
+	}()
+	defer wg.Wait()
+	// Unblock the receiver once the elector is stopped.
+	defer conn.SetReadDeadline(time.Now())
+
+	ticker := time.NewTicker(e.Config.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		e.evaluate(ctx, time.Now())
+		e.send(ctx, conn, peers)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Role returns the current role of the local gateway.
+func (e *Elector) Role() Role {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return e.role
+}
+
+// Leader indicates whether the local gateway is the leader.
+func (e *Elector) Leader() bool {
+	return e.Role() == Leader
+}
+
+// Diagnostics is the diagnostic state of the elector.
+type Diagnostics struct {
+	ID       string            `json:"id"`
+	Priority uint8             `json:"priority"`
+	Role     string            `json:"role"`
+	Since    time.Time         `json:"since"`
+	Peers    []PeerDiagnostics `json:"peers"`
+}
+
+// PeerDiagnostics is the diagnostic state of a peer.
+type PeerDiagnostics struct {
+	ID       string    `json:"id"`
+	Address  string    `json:"address"`
+	Priority uint8     `json:"priority"`
+	Leader   bool      `json:"leader"`
+	Alive    bool      `json:"alive"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Diagnostics returns the diagnostic state of the elector.
+func (e *Elector) Diagnostics() Diagnostics {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	d := Diagnostics{
+		ID:       e.Config.ID,
+		Priority: e.Config.Priority,
+		Role:     e.role.String(),
+		Since:    e.since,
+		Peers:    []PeerDiagnostics{},
+	}
+	now := time.Now()
+	for id, p := range e.peers {
+		d.Peers = append(d.Peers, PeerDiagnostics{
+			ID:       id,
+			Address:  p.addr,
+			Priority: p.priority,
+			Leader:   p.leader,
+			Alive:    e.alive(p, now),
+			LastSeen: p.lastSeen,
+		})
+	}
+	sort.Slice(d.Peers, func(i, j int) bool { return d.Peers[i].ID < d.Peers[j].ID })
+	return d
+}
+
+func (e *Elector) receive(ctx context.Context, conn net.PacketConn) {
+	logger := log.FromCtx(ctx)
+	buf := make([]byte, heartbeatHdrLen+maxIDLength)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.Info("Error receiving heartbeat", "err", err)
+			if serrors.IsTimeout(err) {
+				continue
+			}
+			return
+		}
+		hb, err := decodeHeartbeat(buf[:n])
+		if err != nil {
+			logger.Debug("Ignoring invalid heartbeat", "from", from, "err", err)
+			continue
+		}
+		if !e.handle(ctx, from.String(), hb) {
+			continue
+		}
+		e.evaluate(ctx, time.Now())
+	}
+}
+
+// handle records the heartbeat. It returns false if the heartbeat is ignored.
+func (e *Elector) handle(ctx context.Context, from string, hb heartbeat) bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if _, ok := e.addrs[from]; !ok {
+		log.FromCtx(ctx).Debug("Ignoring heartbeat from unknown peer", "from", from)
+		return false
+	}
+	if hb.ID == e.Config.ID {
+		log.FromCtx(ctx).Info("Ignoring heartbeat with duplicate ID", "from", from, "id", hb.ID)
+		return false
+	}
+	e.peers[hb.ID] = &peerState{
+		addr:     from,
+		priority: hb.Priority,
+		leader:   hb.Leader,
+		lastSeen: time.Now(),
+	}
+	return true
+}
+
+func (e *Elector) send(ctx context.Context, conn net.PacketConn, peers []*net.UDPAddr) {
+	hb := heartbeat{
+		ID:       e.Config.ID,
+		Priority: e.Config.Priority,
+		Leader:   e.Leader(),
+	}
+	raw := hb.encode()
+	for _, p := range peers {
+		if _, err := conn.WriteTo(raw, p); err != nil {
+			log.FromCtx(ctx).Debug("Error sending heartbeat", "peer", p, "err", err)
+		}
+	}
+}
+
+// evaluate runs the election and updates the local role.
+func (e *Elector) evaluate(ctx context.Context, now time.Time) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	// Wait for the heartbeats of the peers before taking part in the
+	// election.
+	if now.Sub(e.started) < e.Config.DeadInterval {
+		return
+	}
+	self := candidate{id: e.Config.ID, priority: e.Config.Priority}
+	var claimants, live []candidate
+	if e.role == Leader {
+		claimants = append(claimants, self)
+	}
+	live = append(live, self)
+	for id, p := range e.peers {
+		if !e.alive(p, now) {
+			continue
+		}
+		c := candidate{id: id, priority: p.priority}
+		live = append(live, c)
+		if p.leader {
+			claimants = append(claimants, c)
+		}
+	}
+	candidates := claimants
+	if len(candidates) == 0 {
+		candidates = live
+	}
+	role := Standby
+	if best(candidates) == self {
+		role = Leader
+	}
+	if role == e.role {
+		return
+	}
+	log.FromCtx(ctx).Info("High availability role changed", "from", e.role, "to", role)
+	e.role = role
+	e.since = now
+	metrics.CounterInc(e.Metrics.RoleChanges)
+	if role == Leader {
+		metrics.GaugeSet(e.Metrics.Leader, 1)
+	} else {
+		metrics.GaugeSet(e.Metrics.Leader, 0)
+	}
+}
+
+func (e *Elector) alive(p *peerState, now time.Time) bool {
+	return now.Sub(p.lastSeen) < e.Config.DeadInterval
+}
+
+type candidate struct {
+	id       string
+	priority uint8
+}
+
+// best returns the candidate with the highest priority. Ties are broken by the
+// lowest ID.
+func best(candidates []candidate) candidate {
+	b := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.priority > b.priority || (c.priority == b.priority && c.id < b.id) {
+			b = c
+		}
+	}
+	return b
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/pkg/gateway/ha"
+)
+
+const (
+	heartbeatInterval = 10 * time.Millisecond
+	deadInterval      = 100 * time.Millisecond
+	waitFor           = 2 * time.Second
+)
+
+func TestConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Config       ha.Config
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Config:       ha.Config{ID: "gw1", Peers: []string{"127.0.0.1:30100"}},
+			ErrAssertion: assert.NoError,
+		},
+		"no ID": {
+			Config:       ha.Config{Peers: []string{"127.0.0.1:30100"}},
+			ErrAssertion: assert.Error,
+		},
+		"no peers": {
+			Config:       ha.Config{ID: "gw1"},
+			ErrAssertion: assert.Error,
+		},
+		"invalid peer": {
+			Config:       ha.Config{ID: "gw1", Peers: []string{"127.0.0.1"}},
+			ErrAssertion: assert.Error,
+		},
+		"dead interval too short": {
+			Config: ha.Config{
+				ID:                "gw1",
+				Peers:             []string{"127.0.0.1:30100"},
+				HeartbeatInterval: time.Second,
+				DeadInterval:      time.Second,
+			},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tc.ErrAssertion(t, tc.Config.Validate())
+		})
+	}
+}
+
+func TestElector(t *testing.T) {
+	conn1, conn2 := listen(t), listen(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	leader := metrics.NewTestGauge()
+	gw1 := newElector("gw1", 200, conn1, conn2)
+	gw1.Metrics.Leader = leader
+	gw2 := newElector("gw2", 100, conn2, conn1)
+
+	stop1 := run(t, gw1)
+	stop2 := run(t, gw2)
+	defer stop2()
+
+	t.Run("higher priority is elected", func(t *testing.T) {
+		assert.Eventually(t, func() bool {
+			return gw1.Role() == ha.Leader && gw2.Role() == ha.Standby
+		}, waitFor, heartbeatInterval)
+		assert.Equal(t, float64(1), metrics.GaugeValue(leader))
+		// The standby learns about the leader with the next heartbeat.
+		assert.Eventually(t, func() bool {
+			d := gw2.Diagnostics()
+			return d.Role == "standby" && len(d.Peers) == 1 && d.Peers[0].ID == "gw1" &&
+				d.Peers[0].Leader && d.Peers[0].Alive
+		}, waitFor, heartbeatInterval)
+	})
+	t.Run("standby takes over", func(t *testing.T) {
+		stop1()
+		assert.Eventually(t, func() bool {
+			return gw2.Leader()
+		}, waitFor, heartbeatInterval)
+	})
+	t.Run("recovered gateway does not preempt", func(t *testing.T) {
+		gw1 = newElector("gw1", 200, conn1, conn2)
+		stop1 = run(t, gw1)
+		defer stop1()
+		// Wait until the recovered gateway takes part in the election.
+		assert.Eventually(t, func() bool {
+			d := gw1.Diagnostics()
+			return len(d.Peers) == 1 && d.Peers[0].Leader
+		}, waitFor, heartbeatInterval)
+		time.Sleep(2 * deadInterval)
+		assert.Equal(t, ha.Standby, gw1.Role())
+		assert.Equal(t, ha.Leader, gw2.Role())
+	})
+}
+
+func TestElectorTieBreak(t *testing.T) {
+	conn1, conn2 := listen(t), listen(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	gwA := newElector("gw-a", 100, conn1, conn2)
+	gwB := newElector("gw-b", 100, conn2, conn1)
+	defer run(t, gwA)()
+	defer run(t, gwB)()
+
+	assert.Eventually(t, func() bool {
+		return gwA.Role() == ha.Leader && gwB.Role() == ha.Standby
+	}, waitFor, heartbeatInterval)
+}
+
+func listen(t *testing.T) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return conn
+}
+
+func newElector(id string, priority uint8, conn, peer net.PacketConn) *ha.Elector {
+	return &ha.Elector{
+		Config: ha.Config{
+			ID:                id,
+			Priority:          priority,
+			Peers:             []string{peer.LocalAddr().String()},
+			HeartbeatInterval: heartbeatInterval,
+			DeadInterval:      deadInterval,
+		},
+		Conn: conn,
+	}
+}
+
+// run runs the elector and returns a function that stops it.
+func run(t *testing.T, e *ha.Elector) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, e.Run(ctx))
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"bytes"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	heartbeatVersion = 1
+	// heartbeatHdrLen is the length of the fixed part of the heartbeat.
+	heartbeatHdrLen = 8
+	flagLeader      = 0x01
+)
+
+// heartbeatMagic identifies heartbeat messages.
+var heartbeatMagic = []byte("SGHA")
+
+// heartbeat is the message that the members of a high availability group
+// periodically send to each other.
+//
+// The wire format is:
+//
+//  0                   1                   2                   3
+//  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |                        Magic ("SGHA")                         |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |    Version    |     Flags     |   Priority    |   ID Length   |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |                          ID (variable)                        |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+type heartbeat struct {
+	// ID identifies the sender.
+	ID string
+	// Priority is the election priority of the sender.
+	Priority uint8
+	// Leader indicates that the sender considers itself the leader.
+	Leader bool
+}
+
+func (h heartbeat) encode() []byte {
+	b := make([]byte, heartbeatHdrLen, heartbeatHdrLen+len(h.ID))
+	copy(b, heartbeatMagic)
+	b[4] = heartbeatVersion
+	if h.Leader {
+		b[5] |= flagLeader
+	}
+	b[6] = h.Priority
+	b[7] = uint8(len(h.ID))
+	return append(b, h.ID...)
+}
+
+func decodeHeartbeat(b []byte) (heartbeat, error) {
+	if len(b) < heartbeatHdrLen {
+		return heartbeat{}, serrors.New("heartbeat too short", "length", len(b))
+	}
+	if !bytes.Equal(b[:4], heartbeatMagic) {
+		return heartbeat{}, serrors.New("invalid heartbeat magic")
+	}
+	if b[4] != heartbeatVersion {
+		return heartbeat{}, serrors.New("unsupported heartbeat version", "version", b[4])
+	}
+	idLen := int(b[7])
+	if idLen == 0 || len(b) != heartbeatHdrLen+idLen {
+		return heartbeat{}, serrors.New("invalid heartbeat ID length",
+			"id_length", idLen, "length", len(b))
+	}
+	return heartbeat{
+		ID:       string(b[heartbeatHdrLen:]),
+		Priority: b[6],
+		Leader:   b[5]&flagLeader != 0,
+	}, nil
+}
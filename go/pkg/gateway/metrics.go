@@ -176,6 +176,19 @@ var (
 		Help:   "Total number of rejected IP prefixes (incoming).",
 		Labels: []string{"isd_as", "remote_isd_as"},
 	}
+
+	// High Availability Metrics
+
+	HALeaderMeta = MetricMeta{
+		Name:   "gateway_ha_leader",
+		Help:   "Whether the gateway is the leader of its high availability group (1) or not (0).",
+		Labels: []string{"isd_as"},
+	}
+	HARoleChangesTotalMeta = MetricMeta{
+		Name:   "gateway_ha_role_changes_total",
+		Help:   "Total number of high availability role changes.",
+		Labels: []string{"isd_as"},
+	}
 )
 
 type MetricMeta struct {
@@ -241,6 +254,10 @@ type Metrics struct {
 	PrefixesAccepted   *prometheus.GaugeVec
 	PrefixesRejected   *prometheus.GaugeVec
 
+	// High Availability Metrics
+	HALeader           *prometheus.GaugeVec
+	HARoleChangesTotal *prometheus.CounterVec
+
 	// SessionMonitor Metrics
 	SessionProbes       *prometheus.CounterVec
 	SessionProbeReplies *prometheus.CounterVec
@@ -319,6 +336,10 @@ func NewMetrics(ia addr.IA) *Metrics {
 			NewGaugeVec().MustCurryWith(labels),
 		PrefixesRejected: PrefixesRejectedMeta.
 			NewGaugeVec().MustCurryWith(labels),
+		HALeader: HALeaderMeta.
+			NewGaugeVec().MustCurryWith(labels),
+		HARoleChangesTotal: HARoleChangesTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
 		SCIONNetworkMetrics:    snetmetrics.NewSCIONNetworkMetrics(),
 		SCMPErrors:             scionPacketConnMetrics.SCMPErrors,
 		SCIONPacketConnMetrics: scionPacketConnMetrics,
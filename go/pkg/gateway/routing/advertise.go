@@ -32,6 +32,20 @@ func AllowedPrefixesBGP(pol *Policy, from, to addr.IA) []*net.IPNet {
 	return extractList(pol, from, to, RedistributeBGP)
 }
 
+// RedistributedBGP returns the prefixes learned via BGP that are allowed to be
+// redistributed for the given policy and ISD-ASes. A learned prefix is allowed
+// if it is a subset of one of the prefixes returned by AllowedPrefixesBGP.
+func RedistributedBGP(pol *Policy, from, to addr.IA, learned []*net.IPNet) []*net.IPNet {
+	allowed := AllowedNetworkMatcher{Allowed: AllowedPrefixesBGP(pol, from, to)}
+	nets := []*net.IPNet{}
+	for _, prefix := range learned {
+		if allowed.Match(prefix) {
+			nets = append(nets, prefix)
+		}
+	}
+	return nets
+}
+
 func extractList(pol *Policy, from, to addr.IA, action Action) []*net.IPNet {
 	if pol == nil {
 		return []*net.IPNet{}
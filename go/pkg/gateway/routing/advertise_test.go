@@ -80,6 +80,37 @@ func TestRedistributeBGPList(t *testing.T) {
 	assert.Empty(t, routing.AllowedPrefixesBGP(&policy, to, from))
 }
 
+func TestRedistributedBGP(t *testing.T) {
+	from := addr.IA{I: 1}
+	to := addr.IA{I: 2}
+	learned := []*net.IPNet{
+		{IP: net.ParseIP("10.0.1.0").To4(), Mask: net.CIDRMask(24, 32)},
+		{IP: net.ParseIP("10.1.0.0").To4(), Mask: net.CIDRMask(16, 32)},
+		{IP: net.ParseIP("10.0.0.0").To4(), Mask: net.CIDRMask(8, 32)},
+		{IP: net.ParseIP("2001:db8:1::"), Mask: net.CIDRMask(48, 128)},
+	}
+
+	policy := routing.Policy{DefaultAction: routing.Reject}
+	assert.Empty(t, routing.RedistributedBGP(nil, from, to, learned))
+	assert.Empty(t, routing.RedistributedBGP(&policy, from, to, learned))
+
+	policy.Rules = append(policy.Rules, routing.Rule{
+		Action:  routing.RedistributeBGP,
+		From:    routing.NewIAMatcher(t, "1-0"),
+		To:      routing.NewIAMatcher(t, "2-0"),
+		Network: routing.NewNetworkMatcher(t, "10.0.0.0/16,2001:db8::/32"),
+	})
+	policy.Rules = append(policy.Rules, routing.Rule{
+		Action:  routing.Advertise,
+		From:    routing.NewIAMatcher(t, "1-0"),
+		To:      routing.NewIAMatcher(t, "2-0"),
+		Network: routing.NewNetworkMatcher(t, "10.1.0.0/16"),
+	})
+	assert.Equal(t, []*net.IPNet{learned[0], learned[3]},
+		routing.RedistributedBGP(&policy, from, to, learned))
+	assert.Empty(t, routing.RedistributedBGP(&policy, to, from, learned))
+}
+
 func TestStaticAdvertiseList(t *testing.T) {
 	policy := routing.Policy{DefaultAction: routing.Reject}
 
@@ -0,0 +1,88 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+)
+
+// Authorizer restricts the RPCs that peers which are not authenticated, see
+// PeerIA, can issue to a gRPC server. Authenticated peers can issue all RPCs.
+//
+// Rejected requests fail with the Unauthenticated code.
+type Authorizer struct {
+	// Unauthenticated are the RPC methods that any peer can issue, keyed by
+	// the full method name, e.g., "/proto.discovery.v1.DiscoveryService/Gateways".
+	Unauthenticated map[string]bool
+	// Bootstrap are the RPC methods that peers of ISDs for which no TRC is
+	// available can issue in addition to the Unauthenticated methods, see
+	// IsBootstrapPeer.
+	Bootstrap map[string]bool
+	// Rejected counts the rejected requests. The label is "method".
+	Rejected metrics.Counter
+}
+
+// UnaryServerInterceptor returns the server option that authorizes unary
+// RPCs.
+func (a *Authorizer) UnaryServerInterceptor() grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	})
+}
+
+// StreamServerInterceptor returns the server option that authorizes streaming
+// RPCs.
+func (a *Authorizer) StreamServerInterceptor() grpc.ServerOption {
+	return grpc.ChainStreamInterceptor(func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	})
+}
+
+func (a *Authorizer) authorize(ctx context.Context, method string) error {
+	if _, ok := PeerIA(ctx); ok {
+		return nil
+	}
+	if a.Unauthenticated[method] || (a.Bootstrap[method] && IsBootstrapPeer(ctx)) {
+		return nil
+	}
+	if a.Rejected != nil {
+		a.Rejected.With("method", method).Add(1)
+	}
+	return status.Error(codes.Unauthenticated, "peer not authenticated")
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/xtest"
+	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+)
+
+const sayHello = "/helloworld.Greeter/SayHello"
+
+func TestAuthorizer(t *testing.T) {
+	authenticated := libgrpc.PeerAuthInfo{IA: xtest.MustParseIA("1-ff00:0:110")}
+	bootstrap := libgrpc.BootstrapAuthInfo{}
+
+	testCases := map[string]struct {
+		AuthInfo        credentials.AuthInfo
+		Unauthenticated map[string]bool
+		Bootstrap       map[string]bool
+		Authorized      bool
+	}{
+		"authenticated": {
+			AuthInfo:   authenticated,
+			Authorized: true,
+		},
+		"unauthenticated": {},
+		"unauthenticated method": {
+			Unauthenticated: map[string]bool{sayHello: true},
+			Authorized:      true,
+		},
+		"bootstrap method": {
+			AuthInfo:   bootstrap,
+			Bootstrap:  map[string]bool{sayHello: true},
+			Authorized: true,
+		},
+		"bootstrap method unauthenticated": {
+			Bootstrap: map[string]bool{sayHello: true},
+		},
+		"bootstrap other method": {
+			AuthInfo:  bootstrap,
+			Bootstrap: map[string]bool{"/other.Service/Method": true},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rejected := metrics.NewTestCounter()
+			authorizer := &libgrpc.Authorizer{
+				Unauthenticated: tc.Unauthenticated,
+				Bootstrap:       tc.Bootstrap,
+				Rejected:        rejected,
+			}
+
+			lis, err := net.Listen("tcp4", "127.0.0.1:0")
+			require.NoError(t, err)
+			s := grpc.NewServer(
+				grpc.Creds(authInfoCredentials{info: tc.AuthInfo}),
+				authorizer.UnaryServerInterceptor(),
+			)
+			helloworldpb.RegisterGreeterServer(s, &helloworldpb.UnimplementedGreeterServer{})
+			go func() { _ = s.Serve(lis) }()
+			t.Cleanup(s.Stop)
+
+			conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+			require.NoError(t, err)
+			t.Cleanup(func() { conn.Close() })
+
+			_, err = sayHelloTo(helloworldpb.NewGreeterClient(conn))
+			if tc.Authorized {
+				assert.Equal(t, codes.Unimplemented, status.Code(err))
+				return
+			}
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			assert.Equal(t, float64(1), metrics.CounterValue(rejected.With("method", sayHello)))
+		})
+	}
+}
+
+// authInfoCredentials are server credentials that expose the given
+// authentication information without doing any handshake.
+type authInfoCredentials struct {
+	credentials.TransportCredentials
+	info credentials.AuthInfo
+}
+
+func (c authInfoCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo,
+	error) {
+
+	return conn, c.info, nil
+}
+
+func (c authInfoCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{}
+}
+
+func sayHelloTo(client helloworldpb.GreeterClient) (metadata.MD, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var trailer metadata.MD
+	_, err := client.SayHello(ctx, &helloworldpb.HelloRequest{Name: "scion"},
+		grpc.Trailer(&trailer))
+	return trailer, err
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-client-go"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/tracing"
+	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+)
+
+func TestInterceptorsW3CPropagation(t *testing.T) {
+	collector := &collector{}
+	collectorLis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	collectorServer := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(collectorServer, collector)
+	go func() { collectorServer.Serve(collectorLis) }()
+	defer collectorServer.Stop()
+
+	cfg := env.Tracing{
+		Enabled:      true,
+		Debug:        true,
+		Exporter:     env.TracingExporterOTLP,
+		OTLPEndpoint: collectorLis.Addr().String(),
+		Propagation:  env.TracingPropagationW3C,
+	}
+	cfg.InitDefaults()
+	tracer, closer, err := cfg.NewTracer("test")
+	require.NoError(t, err)
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	lis, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	greeter := &tracingServer{}
+	s := grpc.NewServer(libgrpc.UnaryServerInterceptor())
+	helloworldpb.RegisterGreeterServer(s, greeter)
+	go func() { s.Serve(lis) }()
+	defer s.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, lis.Addr().String(),
+		grpc.WithInsecure(),
+		libgrpc.UnaryClientInterceptor(),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	span, ctx := tracing.CtxWith(ctx, "client")
+	_, err = helloworldpb.NewGreeterClient(conn).SayHello(ctx,
+		&helloworldpb.HelloRequest{Name: "scion"})
+	require.NoError(t, err)
+	span.Finish()
+	require.NoError(t, closer.Close())
+
+	traceID := span.Context().(jaeger.SpanContext).TraceID()
+	assert.Len(t, greeter.traceParent, 1)
+	assert.Equal(t, traceID, greeter.traceID)
+
+	// Client root span, client RPC span and server RPC span.
+	spans := collector.spans()
+	assert.Len(t, spans, 3)
+	for _, s := range spans {
+		assert.Equal(t, traceID.String(), jaeger.TraceID{
+			High: binary.BigEndian.Uint64(s.TraceId[:8]),
+			Low:  binary.BigEndian.Uint64(s.TraceId[8:]),
+		}.String())
+	}
+}
+
+type tracingServer struct {
+	helloworldpb.UnimplementedGreeterServer
+
+	traceParent []string
+	traceID     jaeger.TraceID
+}
+
+func (s *tracingServer) SayHello(ctx context.Context,
+	in *helloworldpb.HelloRequest) (*helloworldpb.HelloReply, error) {
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.traceParent = md.Get(tracing.TraceParentHeader)
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		s.traceID = span.Context().(jaeger.SpanContext).TraceID()
+	}
+	return &helloworldpb.HelloReply{Message: "Hello " + in.GetName()}, nil
+}
+
+type collector struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	mu       sync.Mutex
+	requests []*coltracepb.ExportTraceServiceRequest
+}
+
+func (c *collector) Export(_ context.Context,
+	req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func (c *collector) spans() []*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var spans []*tracepb.Span
+	for _, req := range c.requests {
+		for _, rs := range req.ResourceSpans {
+			for _, ils := range rs.InstrumentationLibrarySpans {
+				spans = append(spans, ils.Spans...)
+			}
+		}
+	}
+	return spans
+}
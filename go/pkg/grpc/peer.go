@@ -0,0 +1,153 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// PeerVerifier determines the ISD-AS of a peer from the certificate chain that
+// the peer presented in the TLS handshake.
+type PeerVerifier interface {
+	PeerIA(chain []*x509.Certificate) (addr.IA, error)
+}
+
+// BootstrapVerifier is implemented by PeerVerifiers that accept peers of ISDs
+// for which no TRC is available, see BootstrapAuthInfo.
+type BootstrapVerifier interface {
+	IsBootstrapPeer(chain []*x509.Certificate) bool
+}
+
+// PeerAuthInfo is the authentication information of a peer that presented a
+// valid AS certificate chain in the TLS handshake.
+type PeerAuthInfo struct {
+	credentials.CommonAuthInfo
+	// IA is the ISD-AS of the peer.
+	IA addr.IA
+}
+
+// AuthType returns the type of the authentication information.
+func (PeerAuthInfo) AuthType() string {
+	return "scion-cppki"
+}
+
+// BootstrapAuthInfo is the authentication information of a peer that presented
+// an AS certificate chain of an ISD for which no TRC is available. The peer is
+// not authenticated.
+type BootstrapAuthInfo struct {
+	credentials.CommonAuthInfo
+}
+
+// AuthType returns the type of the authentication information.
+func (BootstrapAuthInfo) AuthType() string {
+	return "scion-cppki-bootstrap"
+}
+
+// IsBootstrapPeer indicates whether the peer that issued the RPC presented an
+// AS certificate chain of an ISD for which no TRC is available.
+func IsBootstrapPeer(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	_, ok = p.AuthInfo.(BootstrapAuthInfo)
+	return ok
+}
+
+// PeerIA returns the authenticated ISD-AS of the peer that issued the RPC. The
+// second return value is false, if the peer is not authenticated.
+func PeerIA(ctx context.Context) (addr.IA, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return addr.IA{}, false
+	}
+	info, ok := p.AuthInfo.(PeerAuthInfo)
+	if !ok {
+		return addr.IA{}, false
+	}
+	return info.IA, true
+}
+
+// PeerCredentials are server transport credentials that expose the ISD-AS of
+// authenticated peers to the RPC handlers, see PeerIA. The TLS handshake
+// itself is done by the transport, e.g., QUIC. The connections are required to
+// expose the state of the handshake with a ConnectionState method. Peers that
+// cannot be authenticated are served without authentication information, or
+// with BootstrapAuthInfo if the verifier accepts them in bootstrap mode.
+type PeerCredentials struct {
+	Verifier PeerVerifier
+}
+
+// ServerHandshake determines the ISD-AS of the peer on the connection.
+func (c PeerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo,
+	error) {
+
+	stater, ok := conn.(interface{ ConnectionState() tls.ConnectionState })
+	if !ok {
+		return conn, nil, nil
+	}
+	chain := stater.ConnectionState().PeerCertificates
+	ia, err := c.Verifier.PeerIA(chain)
+	if err != nil {
+		if v, ok := c.Verifier.(BootstrapVerifier); ok && v.IsBootstrapPeer(chain) {
+			log.Debug("Serving bootstrap peer", "remote", conn.RemoteAddr(), "err", err)
+			return conn, BootstrapAuthInfo{
+				CommonAuthInfo: credentials.CommonAuthInfo{
+					SecurityLevel: credentials.PrivacyAndIntegrity,
+				},
+			}, nil
+		}
+		log.Debug("Serving unauthenticated peer", "remote", conn.RemoteAddr(), "err", err)
+		return conn, nil, nil
+	}
+	return conn, PeerAuthInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{
+			SecurityLevel: credentials.PrivacyAndIntegrity,
+		},
+		IA: ia,
+	}, nil
+}
+
+// ClientHandshake is not supported.
+func (c PeerCredentials) ClientHandshake(context.Context, string,
+	net.Conn) (net.Conn, credentials.AuthInfo, error) {
+
+	return nil, nil, serrors.New("client handshake not supported")
+}
+
+// Info returns the protocol information.
+func (c PeerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+// Clone returns a copy of the credentials.
+func (c PeerCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+// OverrideServerName is not supported.
+func (c PeerCredentials) OverrideServerName(string) error {
+	return serrors.New("overriding server name not supported")
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+)
+
+func TestPeerCredentials(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "peer"}}
+
+	testCases := map[string]struct {
+		Conn          net.Conn
+		Verifier      libgrpc.PeerVerifier
+		Authenticated bool
+		Bootstrap     bool
+	}{
+		"authenticated": {
+			Conn: tlsConn{state: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+			}},
+			Verifier: verifierFunc(func(chain []*x509.Certificate) (addr.IA, error) {
+				assert.Equal(t, []*x509.Certificate{cert}, chain)
+				return ia, nil
+			}),
+			Authenticated: true,
+		},
+		"verification fails": {
+			Conn: tlsConn{},
+			Verifier: verifierFunc(func([]*x509.Certificate) (addr.IA, error) {
+				return addr.IA{}, serrors.New("no peer certificate")
+			}),
+		},
+		"bootstrap": {
+			Conn: tlsConn{state: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+			}},
+			Verifier:  bootstrapVerifier{bootstrap: true},
+			Bootstrap: true,
+		},
+		"verification fails without bootstrap": {
+			Conn:     tlsConn{},
+			Verifier: bootstrapVerifier{},
+		},
+		"no TLS state": {
+			Conn: &net.TCPConn{},
+			Verifier: verifierFunc(func([]*x509.Certificate) (addr.IA, error) {
+				panic("must not be called")
+			}),
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			creds := libgrpc.PeerCredentials{Verifier: tc.Verifier}
+			conn, info, err := creds.ServerHandshake(tc.Conn)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Conn, conn)
+
+			ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+			peerIA, ok := libgrpc.PeerIA(ctx)
+			assert.Equal(t, tc.Authenticated, ok)
+			if tc.Authenticated {
+				assert.Equal(t, ia, peerIA)
+			}
+			assert.Equal(t, tc.Bootstrap, libgrpc.IsBootstrapPeer(ctx))
+		})
+	}
+}
+
+type verifierFunc func(chain []*x509.Certificate) (addr.IA, error)
+
+func (f verifierFunc) PeerIA(chain []*x509.Certificate) (addr.IA, error) {
+	return f(chain)
+}
+
+type bootstrapVerifier struct {
+	bootstrap bool
+}
+
+func (v bootstrapVerifier) PeerIA([]*x509.Certificate) (addr.IA, error) {
+	return addr.IA{}, serrors.New("unknown TRC")
+}
+
+func (v bootstrapVerifier) IsBootstrapPeer([]*x509.Certificate) bool {
+	return v.bootstrap
+}
+
+type tlsConn struct {
+	net.Conn
+	state tls.ConnectionState
+}
+
+func (c tlsConn) ConnectionState() tls.ConnectionState {
+	return c.state
+}
+
+func (c tlsConn) RemoteAddr() net.Addr {
+	return &net.UDPAddr{}
+}
@@ -0,0 +1,339 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+const (
+	// RetryPushbackKey is the trailer key of the retry hint of throttled
+	// requests. The value is the delay in milliseconds after which the request
+	// should be retried. The key is understood by the retry logic of gRPC
+	// clients.
+	RetryPushbackKey = "grpc-retry-pushback-ms"
+
+	// ThrottledRateLimit is the reason label of requests that exceeded the
+	// rate limit of the peer.
+	ThrottledRateLimit = "rate_limit"
+	// ThrottledQueueFull is the reason label of requests that were rejected
+	// because the queue of waiting requests was full.
+	ThrottledQueueFull = "queue_full"
+
+	// queueFullRetryDelay is the retry hint for requests that are rejected
+	// because the queue is full.
+	queueFullRetryDelay = time.Second
+	// bucketCleanupInterval is the interval in which idle token buckets are
+	// removed.
+	bucketCleanupInterval = time.Minute
+)
+
+// Limits are the admission limits of an RPC method.
+type Limits struct {
+	// PeerRate is the number of requests per second that a single peer can
+	// issue. If zero, the requests are not rate limited.
+	PeerRate float64
+	// PeerBurst is the number of requests that a single peer can issue at
+	// once. If zero, it is set to the rate, but at least one.
+	PeerBurst int
+	// MaxConcurrent is the number of requests that are served concurrently.
+	// If zero, the concurrency is not limited.
+	MaxConcurrent int
+	// MaxQueued is the number of requests that wait for being served, if
+	// MaxConcurrent requests are in service. Further requests are rejected.
+	MaxQueued int
+}
+
+func (l Limits) burst() float64 {
+	if l.PeerBurst > 0 {
+		return float64(l.PeerBurst)
+	}
+	return math.Max(1, l.PeerRate)
+}
+
+// RateLimiter applies admission control to the RPCs served by a gRPC server.
+// Each peer is rate limited with a token bucket per RPC method. Peers are
+// identified by their authenticated ISD-AS if available, see PeerIA, and by
+// their address otherwise. The number of concurrently served requests is
+// capped per RPC method, with a bounded queue of waiting requests.
+//
+// Throttled requests fail with the ResourceExhausted code and a retry hint
+// in the RetryPushbackKey trailer.
+type RateLimiter struct {
+	// Default are the limits for the RPC methods that are not in Methods.
+	Default Limits
+	// Methods are the limits per RPC method, keyed by the full method name,
+	// e.g., "/proto.control_plane.v1.SegmentLookupService/Segments".
+	Methods map[string]Limits
+	// Throttled counts the throttled requests. The labels are "method" and
+	// "reason".
+	Throttled metrics.Counter
+
+	mtx         sync.Mutex
+	buckets     map[bucketKey]*tokenBucket
+	queues      map[string]*admissionQueue
+	lastCleanup time.Time
+}
+
+// UnaryServerInterceptor returns the server option that applies the limits to
+// unary RPCs.
+func (l *RateLimiter) UnaryServerInterceptor() grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+
+		release, err := l.admit(ctx, info.FullMethod, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return handler(ctx, req)
+	})
+}
+
+// StreamServerInterceptor returns the server option that applies the limits
+// to streaming RPCs. The limits apply to opening the stream.
+func (l *RateLimiter) StreamServerInterceptor() grpc.ServerOption {
+	return grpc.ChainStreamInterceptor(func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+
+		release, err := l.admit(ss.Context(), info.FullMethod, time.Now())
+		if err != nil {
+			return err
+		}
+		defer release()
+		return handler(srv, ss)
+	})
+}
+
+// admit admits the request or returns the error that the request is answered
+// with. The returned function must be called after the request is served.
+func (l *RateLimiter) admit(ctx context.Context, method string,
+	now time.Time) (func(), error) {
+
+	limits, ok := l.Methods[method]
+	if !ok {
+		limits = l.Default
+	}
+	if limits.PeerRate > 0 {
+		if wait := l.take(bucketKey{peer: PeerKey(ctx), method: method}, limits, now); wait > 0 {
+			return nil, l.throttle(ctx, method, ThrottledRateLimit, wait)
+		}
+	}
+	if limits.MaxConcurrent <= 0 {
+		return func() {}, nil
+	}
+	q := l.queue(method, limits)
+	if err := q.acquire(ctx, limits.MaxQueued); err != nil {
+		if err == errQueueFull {
+			return nil, l.throttle(ctx, method, ThrottledQueueFull, queueFullRetryDelay)
+		}
+		return nil, status.FromContextError(err).Err()
+	}
+	return q.release, nil
+}
+
+// take takes a token from the bucket of the peer. It returns the time until
+// the next token is available, if the bucket is empty.
+func (l *RateLimiter) take(key bucketKey, limits Limits, now time.Time) time.Duration {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.buckets == nil {
+		l.buckets = make(map[bucketKey]*tokenBucket)
+		l.lastCleanup = now
+	}
+	if now.Sub(l.lastCleanup) > bucketCleanupInterval {
+		l.cleanupLocked(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{
+			rate:   limits.PeerRate,
+			burst:  limits.burst(),
+			tokens: limits.burst(),
+			last:   now,
+		}
+		l.buckets[key] = b
+	}
+	return b.take(now)
+}
+
+// cleanupLocked removes the buckets that are full, i.e., that are not
+// distinguishable from a fresh bucket.
+func (l *RateLimiter) cleanupLocked(now time.Time) {
+	for key, b := range l.buckets {
+		if b.full(now) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastCleanup = now
+}
+
+func (l *RateLimiter) queue(method string, limits Limits) *admissionQueue {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.queues == nil {
+		l.queues = make(map[string]*admissionQueue)
+	}
+	q, ok := l.queues[method]
+	if !ok {
+		q = &admissionQueue{slots: make(chan struct{}, limits.MaxConcurrent)}
+		l.queues[method] = q
+	}
+	return q
+}
+
+func (l *RateLimiter) throttle(ctx context.Context, method, reason string,
+	retry time.Duration) error {
+
+	if l.Throttled != nil {
+		l.Throttled.With("method", method, "reason", reason).Add(1)
+	}
+	ms := int64(math.Ceil(float64(retry) / float64(time.Millisecond)))
+	// The trailer can only be set if the request arrived through a gRPC
+	// server; the error is ignored otherwise.
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(RetryPushbackKey, strconv.FormatInt(ms, 10)))
+	return status.Errorf(codes.ResourceExhausted, "%s exceeded, retry after %dms", reason, ms)
+}
+
+// PeerKey identifies the peer that issued the RPC. It is the ISD-AS of
+// authenticated peers, and the address of the peer otherwise. If the peer is
+// unknown, the empty string is returned.
+func PeerKey(ctx context.Context) string {
+	if ia, ok := PeerIA(ctx); ok {
+		return ia.String()
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	switch a := p.Addr.(type) {
+	case *snet.UDPAddr:
+		return a.IA.String() + "," + a.Host.IP.String()
+	case *net.TCPAddr:
+		return a.IP.String()
+	case *net.UDPAddr:
+		return a.IP.String()
+	default:
+		return a.String()
+	}
+}
+
+type bucketKey struct {
+	peer   string
+	method string
+}
+
+// tokenBucket is a token bucket that is refilled at a constant rate.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// take takes a token from the bucket. If the bucket is empty, it returns the
+// time until the next token is available.
+func (b *tokenBucket) take(now time.Time) time.Duration {
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+func (b *tokenBucket) full(now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*b.rate >= b.burst
+}
+
+type queueFullError struct{}
+
+func (queueFullError) Error() string { return "queue full" }
+
+var errQueueFull error = queueFullError{}
+
+// admissionQueue limits the number of concurrently served requests. Requests
+// that exceed the limit wait in a bounded queue.
+type admissionQueue struct {
+	slots chan struct{}
+
+	mtx    sync.Mutex
+	queued int
+}
+
+// acquire acquires a slot. If no slot is free, it waits until a slot is freed
+// or the context is done. If maxQueued requests are already waiting, it
+// returns errQueueFull immediately.
+func (q *admissionQueue) acquire(ctx context.Context, maxQueued int) error {
+	select {
+	case q.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	q.mtx.Lock()
+	if q.queued >= maxQueued {
+		q.mtx.Unlock()
+		return errQueueFull
+	}
+	q.queued++
+	q.mtx.Unlock()
+	defer func() {
+		q.mtx.Lock()
+		q.queued--
+		q.mtx.Unlock()
+	}()
+
+	select {
+	case q.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *admissionQueue) release() {
+	<-q.slots
+}
@@ -0,0 +1,167 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+)
+
+func TestRateLimiterPeerRate(t *testing.T) {
+	testCases := map[string]struct {
+		Default   libgrpc.Limits
+		Methods   map[string]libgrpc.Limits
+		Admitted  int
+		Throttled bool
+	}{
+		"unlimited": {
+			Admitted: 5,
+		},
+		"default limit": {
+			Default:   libgrpc.Limits{PeerRate: 0.001, PeerBurst: 3},
+			Admitted:  3,
+			Throttled: true,
+		},
+		"burst defaults to one": {
+			Default:   libgrpc.Limits{PeerRate: 0.001},
+			Admitted:  1,
+			Throttled: true,
+		},
+		"method overrides default": {
+			Default: libgrpc.Limits{PeerRate: 0.001},
+			Methods: map[string]libgrpc.Limits{
+				sayHello: {PeerRate: 0.001, PeerBurst: 2},
+			},
+			Admitted:  2,
+			Throttled: true,
+		},
+		"other method": {
+			Methods: map[string]libgrpc.Limits{
+				"/other.Service/Method": {PeerRate: 0.001},
+			},
+			Admitted: 5,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			throttled := metrics.NewTestCounter()
+			limiter := &libgrpc.RateLimiter{
+				Default:   tc.Default,
+				Methods:   tc.Methods,
+				Throttled: throttled,
+			}
+			client := startGreeter(t, limiter, &helloworldpb.UnimplementedGreeterServer{})
+
+			for i := 0; i < tc.Admitted; i++ {
+				_, err := sayHelloTo(client)
+				assert.Equal(t, codes.Unimplemented, status.Code(err))
+			}
+			trailer, err := sayHelloTo(client)
+			if !tc.Throttled {
+				assert.Equal(t, codes.Unimplemented, status.Code(err))
+				return
+			}
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			require.Len(t, trailer.Get(libgrpc.RetryPushbackKey), 1)
+			retry, err := strconv.Atoi(trailer.Get(libgrpc.RetryPushbackKey)[0])
+			require.NoError(t, err)
+			assert.Greater(t, retry, 0)
+			assert.Equal(t, float64(1), metrics.CounterValue(
+				throttled.With("method", sayHello, "reason", libgrpc.ThrottledRateLimit)))
+		})
+	}
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	throttled := metrics.NewTestCounter()
+	limiter := &libgrpc.RateLimiter{
+		Default:   libgrpc.Limits{MaxConcurrent: 1, MaxQueued: 1},
+		Throttled: throttled,
+	}
+	greeter := &blockingGreeter{
+		started: make(chan struct{}, 2),
+		done:    make(chan struct{}),
+	}
+	client := startGreeter(t, limiter, greeter)
+
+	// The first request is served, the second request is queued.
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := sayHelloTo(client)
+			errs <- err
+		}()
+	}
+	<-greeter.started
+	// Give the second request time to enter the queue.
+	time.Sleep(100 * time.Millisecond)
+
+	trailer, err := sayHelloTo(client)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"1000"}, trailer.Get(libgrpc.RetryPushbackKey))
+	assert.Equal(t, float64(1), metrics.CounterValue(
+		throttled.With("method", sayHello, "reason", libgrpc.ThrottledQueueFull)))
+
+	close(greeter.done)
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, <-errs)
+	}
+	assert.Len(t, greeter.started, 1)
+}
+
+func startGreeter(t *testing.T, limiter *libgrpc.RateLimiter,
+	greeter helloworldpb.GreeterServer) helloworldpb.GreeterClient {
+
+	lis, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer(limiter.UnaryServerInterceptor())
+	helloworldpb.RegisterGreeterServer(s, greeter)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return helloworldpb.NewGreeterClient(conn)
+}
+
+type blockingGreeter struct {
+	helloworldpb.UnimplementedGreeterServer
+	started chan struct{}
+	done    chan struct{}
+}
+
+func (g *blockingGreeter) SayHello(ctx context.Context,
+	in *helloworldpb.HelloRequest) (*helloworldpb.HelloReply, error) {
+
+	g.started <- struct{}{}
+	<-g.done
+	return &helloworldpb.HelloReply{Message: "Hello " + in.GetName()}, nil
+}
@@ -35,8 +35,8 @@ type SegmentRequest struct {
 
 // AuthoritativeServer serves segments from the database.
 type AuthoritativeServer struct {
-	// Groups is the current set of groups.
-	Groups map[GroupID]*Group
+	// Groups provides the current set of groups.
+	Groups GroupProvider
 	// DB is used to read hidden segments.
 	DB Store
 	// LocalIA is the ISD-AS this server is run in.
@@ -52,7 +52,7 @@ func (s AuthoritativeServer) Segments(ctx context.Context,
 		return nil, serrors.New("no group IDs provided")
 	}
 	for _, id := range req.GroupIDs {
-		group, ok := s.Groups[id]
+		group, ok := s.Groups.Group(id)
 		if !ok {
 			return nil, serrors.New("request for unknown group", "group_id", id)
 		}
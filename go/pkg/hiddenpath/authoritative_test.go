@@ -35,7 +35,7 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 		request   hiddenpath.SegmentRequest
 		local     addr.IA
 		db        func(ctrl *gomock.Controller) hiddenpath.Store
-		groups    func() map[hiddenpath.GroupID]*hiddenpath.Group
+		groups    func() hiddenpath.Groups
 		want      []*seg.Meta
 		assertErr assert.ErrorAssertionFunc
 	}{
@@ -48,8 +48,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 			db: func(ctrl *gomock.Controller) hiddenpath.Store {
 				return nil
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: xtest.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:13"): {}},
@@ -72,8 +72,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 			db: func(ctrl *gomock.Controller) hiddenpath.Store {
 				return nil
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: xtest.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:13"): {}},
@@ -96,8 +96,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 			db: func(ctrl *gomock.Controller) hiddenpath.Store {
 				return nil
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: xtest.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:13"): {}},
@@ -125,8 +125,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 			db: func(ctrl *gomock.Controller) hiddenpath.Store {
 				return nil
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: xtest.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:13"): {}},
@@ -160,8 +160,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 					}).Return(nil, serrors.New("test error"))
 				return db
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: xtest.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:13"): {}},
@@ -195,8 +195,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 					}).Return([]*seg.Meta{{Type: seg.TypeDown}}, nil)
 				return db
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: xtest.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:13"): {}},
@@ -65,6 +65,10 @@ type BeaconWriter struct {
 	RegistrationPolicy RegistrationPolicy
 	// AddressResolver is used to resolve remote ASes.
 	AddressResolver AddressResolver
+	// Groups optionally provides the latest version of the groups. If set,
+	// it takes precedence over the groups in the registration policy, such
+	// that distributed group updates are taken into account.
+	Groups GroupProvider
 }
 
 // Write iterates the segments channel and for each of the segments: it extends
@@ -96,7 +100,7 @@ func (w *BeaconWriter) Write(ctx context.Context, segments []beacon.Beacon,
 			metrics.CounterInc(w.InternalErrors)
 			continue
 		}
-		for id, addrs := range remoteRegistries(regPolicy) {
+		for id, addrs := range remoteRegistries(regPolicy, w.Groups) {
 			for _, a := range addrs {
 				expected++
 				rw := remoteWriter{
@@ -241,9 +245,16 @@ func (l writerLabels) WithResult(result string) writerLabels {
 	return l
 }
 
-func remoteRegistries(regPolicy InterfacePolicy) map[GroupID][]addr.IA {
+func remoteRegistries(regPolicy InterfacePolicy,
+	groups GroupProvider) map[GroupID][]addr.IA {
+
 	remotes := make(map[GroupID][]addr.IA)
 	for id, group := range regPolicy.Groups {
+		if groups != nil {
+			if latest, ok := groups.Group(id); ok {
+				group = latest
+			}
+		}
 		for registry := range group.Registries {
 			remotes[id] = append(remotes[id], registry)
 		}
@@ -77,6 +77,29 @@ func (r LookupResolver) Resolve(ctx context.Context, ia addr.IA) (net.Addr, erro
 	})
 }
 
+// ControlServiceResolver resolves the address of the control service in an
+// IA.
+type ControlServiceResolver struct {
+	Router snet.Router
+}
+
+// Resolve resolves the control service in the remote IA.
+func (r ControlServiceResolver) Resolve(ctx context.Context, ia addr.IA) (net.Addr, error) {
+	path, err := r.Router.Route(ctx, ia)
+	if err != nil {
+		return nil, serrors.WrapStr("looking up path", err)
+	}
+	if path == nil {
+		return nil, serrors.New("no path found to remote", "isd_as", ia)
+	}
+	return &snet.SVCAddr{
+		IA:      ia,
+		NextHop: path.UnderlayNextHop(),
+		Path:    path.Path(),
+		SVC:     addr.SvcCS,
+	}, nil
+}
+
 func resolve(ctx context.Context, ia addr.IA, discoverer Discoverer, router snet.Router,
 	extractAddr func(Servers) (*net.UDPAddr, error)) (net.Addr, error) {
 
@@ -0,0 +1,223 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// SyncGroups is a set of hidden path groups that can be updated concurrently.
+// Groups are never modified in place; an update replaces the group, such that
+// callers can safely keep using a group they obtained earlier.
+type SyncGroups struct {
+	mtx    sync.RWMutex
+	groups Groups
+}
+
+// NewSyncGroups creates a new set that is initialized with the given groups.
+func NewSyncGroups(groups Groups) *SyncGroups {
+	s := &SyncGroups{groups: make(Groups, len(groups))}
+	for id, group := range groups {
+		s.groups[id] = group
+	}
+	return s
+}
+
+// Group returns the current version of the group with the given ID.
+func (s *SyncGroups) Group(id GroupID) (*Group, bool) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	group, ok := s.groups[id]
+	return group, ok
+}
+
+// Groups returns a snapshot of the current groups.
+func (s *SyncGroups) Groups() Groups {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	groups := make(Groups, len(s.groups))
+	for id, group := range s.groups {
+		groups[id] = group
+	}
+	return groups
+}
+
+// Update replaces the known group with the given one. It returns true if the
+// group changed. Updates for unknown groups, updates that change the owner,
+// and updates with a lower version than the known one (rollbacks) are
+// rejected. An update with the same version must have the same content.
+func (s *SyncGroups) Update(group *Group) (bool, error) {
+	if err := group.Validate(); err != nil {
+		return false, serrors.WrapStr("validating group", err)
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	current, ok := s.groups[group.ID]
+	if !ok {
+		return false, serrors.New("unknown group", "group_id", group.ID)
+	}
+	if !current.Owner.Equal(group.Owner) {
+		return false, serrors.New("owner mismatch", "group_id", group.ID,
+			"expected", current.Owner, "actual", group.Owner)
+	}
+	switch {
+	case group.Version < current.Version:
+		return false, serrors.New("rollback of group version", "group_id", group.ID,
+			"current", current.Version, "received", group.Version)
+	case group.Version == current.Version:
+		if !current.Equal(group) {
+			return false, serrors.New("group changed without version increase",
+				"group_id", group.ID, "version", group.Version)
+		}
+		return false, nil
+	}
+	s.groups[group.ID] = group
+	return true, nil
+}
+
+// Restore applies the stored groups that are not owned by the local AS, such
+// that group versions that were accepted before a restart are not rolled back
+// by an outdated local configuration. Stored groups that are older than the
+// known ones are ignored. It returns the IDs of the restored groups.
+func (s *SyncGroups) Restore(stored Groups, localIA addr.IA) []GroupID {
+	var restored []GroupID
+	for id, group := range stored {
+		// The local configuration is authoritative for the owned groups.
+		if group.Owner.Equal(localIA) {
+			continue
+		}
+		if updated, err := s.Update(group); err == nil && updated {
+			restored = append(restored, id)
+		}
+	}
+	return restored
+}
+
+// GroupStore persists the accepted versions of the groups.
+type GroupStore interface {
+	// Load loads the stored groups. If no groups were stored yet, it returns
+	// no groups and no error.
+	Load() (Groups, error)
+	// Store replaces the stored groups.
+	Store(groups Groups) error
+}
+
+// FileGroupStore stores the groups in a YAML file, in the same format as the
+// hidden path configuration.
+type FileGroupStore struct {
+	// Path is the path of the file.
+	Path string
+}
+
+// Load loads the groups from the file.
+func (s FileGroupStore) Load() (Groups, error) {
+	if _, err := os.Stat(s.Path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return LoadHiddenPathGroups(s.Path)
+}
+
+// Store writes the groups to the file.
+func (s FileGroupStore) Store(groups Groups) error {
+	raw, err := yaml.Marshal(groups)
+	if err != nil {
+		return serrors.WrapStr("marshaling groups", err)
+	}
+	if err := util.WriteFile(s.Path, raw, 0644); err != nil {
+		return serrors.WrapStr("writing groups", err, "file", s.Path)
+	}
+	return nil
+}
+
+// GroupFetcher fetches the signed group configuration from the owner of the
+// group.
+type GroupFetcher interface {
+	// Group fetches and verifies the configuration of the group with the
+	// given ID from the owner.
+	Group(ctx context.Context, id GroupID, owner addr.IA) (*Group, error)
+}
+
+var _ periodic.Task = (*GroupUpdater)(nil)
+
+// GroupUpdater periodically fetches the configurations of the groups that
+// are not owned by the local AS and updates the group set.
+type GroupUpdater struct {
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+	// Groups is the set of groups that is updated.
+	Groups *SyncGroups
+	// Fetcher is used to fetch the group configurations from their owners.
+	Fetcher GroupFetcher
+	// Store optionally persists the groups after they were updated.
+	Store GroupStore
+	// OnUpdate is optionally called with the current groups after they were
+	// updated.
+	OnUpdate func(Groups)
+}
+
+// Name returns the tasks name.
+func (u *GroupUpdater) Name() string {
+	return "control_hiddenpath_group_updater"
+}
+
+// Run fetches the configuration of all groups that are owned by remote ASes.
+func (u *GroupUpdater) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	changed := false
+	for id, group := range u.Groups.Groups() {
+		if group.Owner.Equal(u.LocalIA) {
+			continue
+		}
+		fetched, err := u.Fetcher.Group(ctx, id, group.Owner)
+		if err != nil {
+			logger.Info("Failed to fetch hidden path group", "group_id", id,
+				"owner", group.Owner, "err", err)
+			continue
+		}
+		updated, err := u.Groups.Update(fetched)
+		if err != nil {
+			logger.Info("Rejected hidden path group update", "group_id", id,
+				"owner", group.Owner, "err", err)
+			continue
+		}
+		if updated {
+			logger.Info("Updated hidden path group", "group_id", id,
+				"from_version", group.Version, "to_version", fetched.Version)
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	groups := u.Groups.Groups()
+	if u.Store != nil {
+		if err := u.Store.Store(groups); err != nil {
+			logger.Info("Failed to store hidden path groups", "err", err)
+		}
+	}
+	if u.OnUpdate != nil {
+		u.OnUpdate(groups)
+	}
+}
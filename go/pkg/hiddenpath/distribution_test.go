@@ -0,0 +1,247 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/hiddenpath"
+	"github.com/scionproto/scion/go/pkg/hiddenpath/mock_hiddenpath"
+)
+
+func TestSyncGroupsUpdate(t *testing.T) {
+	testCases := map[string]struct {
+		update       func() *hiddenpath.Group
+		want         func() *hiddenpath.Group
+		updated      bool
+		assertErr    assert.ErrorAssertionFunc
+		unknownGroup bool
+	}{
+		"newer version": {
+			update: func() *hiddenpath.Group {
+				g := distGroup(3)
+				g.Readers[xtest.MustParseIA("1-ff00:0:115")] = struct{}{}
+				return g
+			},
+			want: func() *hiddenpath.Group {
+				g := distGroup(3)
+				g.Readers[xtest.MustParseIA("1-ff00:0:115")] = struct{}{}
+				return g
+			},
+			updated:   true,
+			assertErr: assert.NoError,
+		},
+		"same version": {
+			update:    func() *hiddenpath.Group { return distGroup(2) },
+			want:      func() *hiddenpath.Group { return distGroup(2) },
+			assertErr: assert.NoError,
+		},
+		"same version different content": {
+			update: func() *hiddenpath.Group {
+				g := distGroup(2)
+				g.Readers[xtest.MustParseIA("1-ff00:0:115")] = struct{}{}
+				return g
+			},
+			want:      func() *hiddenpath.Group { return distGroup(2) },
+			assertErr: assert.Error,
+		},
+		"rollback": {
+			update:    func() *hiddenpath.Group { return distGroup(1) },
+			want:      func() *hiddenpath.Group { return distGroup(2) },
+			assertErr: assert.Error,
+		},
+		"owner change": {
+			update: func() *hiddenpath.Group {
+				g := distGroup(3)
+				g.Owner = xtest.MustParseIA("2-ff00:0:110")
+				return g
+			},
+			want:      func() *hiddenpath.Group { return distGroup(2) },
+			assertErr: assert.Error,
+		},
+		"invalid": {
+			update: func() *hiddenpath.Group {
+				g := distGroup(3)
+				g.Writers = nil
+				return g
+			},
+			want:      func() *hiddenpath.Group { return distGroup(2) },
+			assertErr: assert.Error,
+		},
+		"unknown group": {
+			update: func() *hiddenpath.Group {
+				g := distGroup(3)
+				g.ID.Suffix = 0x42
+				return g
+			},
+			want:         func() *hiddenpath.Group { return distGroup(2) },
+			assertErr:    assert.Error,
+			unknownGroup: true,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			current := distGroup(2)
+			groups := hiddenpath.NewSyncGroups(hiddenpath.Groups{current.ID: current})
+
+			update := tc.update()
+			updated, err := groups.Update(update)
+			tc.assertErr(t, err)
+			assert.Equal(t, tc.updated, updated)
+
+			got, ok := groups.Group(current.ID)
+			assert.True(t, ok)
+			assert.True(t, tc.want().Equal(got), "got %v", got)
+			_, ok = groups.Group(update.ID)
+			assert.Equal(t, !tc.unknownGroup, ok)
+		})
+	}
+}
+
+func TestGroupUpdaterRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	local := xtest.MustParseIA("1-ff00:0:111")
+	remote := distGroup(2)
+	owned := distGroup(1)
+	owned.ID = hiddenpath.GroupID{OwnerAS: local.A, Suffix: 0x1}
+	owned.Owner = local
+	failing := distGroup(1)
+	failing.ID.Suffix = 0x2
+
+	groups := hiddenpath.NewSyncGroups(hiddenpath.Groups{
+		remote.ID:  remote,
+		owned.ID:   owned,
+		failing.ID: failing,
+	})
+	fetcher := mock_hiddenpath.NewMockGroupFetcher(ctrl)
+	fetcher.EXPECT().Group(gomock.Any(), remote.ID, remote.Owner).Return(distGroup(3), nil)
+	fetcher.EXPECT().Group(gomock.Any(), failing.ID, failing.Owner).
+		Return(nil, serrors.New("test error"))
+
+	dir, cleanF := xtest.MustTempDir("", "hiddenpath")
+	defer cleanF()
+	store := hiddenpath.FileGroupStore{Path: filepath.Join(dir, "groups.yml")}
+	var notified hiddenpath.Groups
+	updater := &hiddenpath.GroupUpdater{
+		LocalIA:  local,
+		Groups:   groups,
+		Fetcher:  fetcher,
+		Store:    store,
+		OnUpdate: func(groups hiddenpath.Groups) { notified = groups },
+	}
+	updater.Run(context.Background())
+
+	got := groups.Groups()
+	assert.Equal(t, uint64(3), got[remote.ID].Version)
+	assert.Equal(t, owned, got[owned.ID])
+	assert.Equal(t, failing, got[failing.ID])
+	assert.Equal(t, got, notified)
+	stored, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), stored[remote.ID].Version)
+
+	// Without changes, the groups are neither stored nor reported.
+	notified = nil
+	fetcher.EXPECT().Group(gomock.Any(), remote.ID, remote.Owner).Return(distGroup(3), nil)
+	fetcher.EXPECT().Group(gomock.Any(), failing.ID, failing.Owner).
+		Return(nil, serrors.New("test error"))
+	updater.Run(context.Background())
+	assert.Nil(t, notified)
+}
+
+func TestSyncGroupsRestore(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:111")
+	newer := distGroup(2)
+	older := distGroup(3)
+	older.ID.Suffix = 0x1
+	owned := distGroup(1)
+	owned.ID = hiddenpath.GroupID{OwnerAS: local.A, Suffix: 0x1}
+	owned.Owner = local
+	groups := hiddenpath.NewSyncGroups(hiddenpath.Groups{
+		newer.ID: newer,
+		older.ID: older,
+		owned.ID: owned,
+	})
+
+	storedNewer := distGroup(3)
+	storedOlder := distGroup(2)
+	storedOlder.ID = older.ID
+	storedOwned := distGroup(2)
+	storedOwned.ID, storedOwned.Owner = owned.ID, owned.Owner
+	unknown := distGroup(1)
+	unknown.ID.Suffix = 0x2
+	restored := groups.Restore(hiddenpath.Groups{
+		storedNewer.ID: storedNewer,
+		storedOlder.ID: storedOlder,
+		storedOwned.ID: storedOwned,
+		unknown.ID:     unknown,
+	}, local)
+
+	assert.Equal(t, []hiddenpath.GroupID{newer.ID}, restored)
+	got := groups.Groups()
+	assert.Len(t, got, 3)
+	assert.Equal(t, storedNewer, got[newer.ID])
+	assert.Equal(t, older, got[older.ID])
+	assert.Equal(t, owned, got[owned.ID])
+}
+
+func TestFileGroupStore(t *testing.T) {
+	dir, cleanF := xtest.MustTempDir("", "hiddenpath")
+	defer cleanF()
+	store := hiddenpath.FileGroupStore{Path: filepath.Join(dir, "groups.yml")}
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+
+	groups := hiddenpath.Groups{distGroup(3).ID: distGroup(3)}
+	require.NoError(t, store.Store(groups))
+	loaded, err = store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, groups, loaded)
+}
+
+func distGroup(version uint64) *hiddenpath.Group {
+	return &hiddenpath.Group{
+		ID: hiddenpath.GroupID{
+			OwnerAS: xtest.MustParseAS("ff00:0:110"),
+			Suffix:  0x69b5,
+		},
+		Owner:   xtest.MustParseIA("1-ff00:0:110"),
+		Version: version,
+		Writers: map[addr.IA]struct{}{
+			xtest.MustParseIA("1-ff00:0:111"): {},
+		},
+		Readers: map[addr.IA]struct{}{
+			xtest.MustParseIA("1-ff00:0:112"): {},
+		},
+		Registries: map[addr.IA]struct{}{
+			xtest.MustParseIA("1-ff00:0:113"): {},
+		},
+	}
+}
@@ -83,6 +83,10 @@ type Group struct {
 	// responsible for maintaining the hidden path group configuration and
 	// distributing it to all entities that require it.
 	Owner addr.IA
+	// Version is the version of the group configuration. The owner increases
+	// the version with every change of the configuration, which allows the
+	// members to detect and reject outdated configurations.
+	Version uint64
 	// Writers contains all ASes in the group that are allowed to register hidden
 	// paths.
 	Writers map[addr.IA]struct{}
@@ -116,6 +120,19 @@ func (g *Group) Validate() error {
 	return nil
 }
 
+// Equal indicates whether the two groups are equal.
+func (g *Group) Equal(other *Group) bool {
+	if g == nil || other == nil {
+		return g == other
+	}
+	return g.ID == other.ID &&
+		g.Owner.Equal(other.Owner) &&
+		g.Version == other.Version &&
+		iaSetEqual(g.Writers, other.Writers) &&
+		iaSetEqual(g.Readers, other.Readers) &&
+		iaSetEqual(g.Registries, other.Registries)
+}
+
 func (g *Group) GetRegistries() []addr.IA {
 	var ret []addr.IA
 	for k := range g.Registries {
@@ -137,9 +154,22 @@ func (r Roles) None() bool {
 	return !r.Owner && !r.Registry && !r.Reader && !r.Writer
 }
 
+// GroupProvider provides hidden path groups by their ID.
+type GroupProvider interface {
+	// Group returns the group with the given ID. The boolean indicates whether
+	// the group is known.
+	Group(id GroupID) (*Group, bool)
+}
+
 // Groups is a list of hidden path groups.
 type Groups map[GroupID]*Group
 
+// Group returns the group with the given ID.
+func (g Groups) Group(id GroupID) (*Group, bool) {
+	group, ok := g[id]
+	return group, ok
+}
+
 // Validate validates all groups in the map.
 func (g Groups) Validate() error {
 	for _, group := range g {
@@ -214,6 +244,7 @@ func (g Groups) Roles(ia addr.IA) Roles {
 
 type groupInfo struct {
 	Owner      string   `yaml:"owner,omitempty"`
+	Version    uint64   `yaml:"version,omitempty"`
 	Writers    []string `yaml:"writers,omitempty"`
 	Readers    []string `yaml:"readers,omitempty"`
 	Registries []string `yaml:"registries,omitempty"`
@@ -245,6 +276,7 @@ func parseGroups(groups map[string]*groupInfo) (Groups, error) {
 		result[id] = &Group{
 			ID:         id,
 			Owner:      owner,
+			Version:    rawGroup.Version,
 			Writers:    writers,
 			Readers:    readers,
 			Registries: registries,
@@ -258,6 +290,7 @@ func marshalGroups(groups Groups) map[string]*groupInfo {
 	for id, group := range groups {
 		result[id.String()] = &groupInfo{
 			Owner:      group.Owner.String(),
+			Version:    group.Version,
 			Writers:    iaSetToStrings(group.Writers),
 			Readers:    iaSetToStrings(group.Readers),
 			Registries: iaSetToStrings(group.Registries),
@@ -276,6 +309,18 @@ func iaSetToStrings(ias map[addr.IA]struct{}) []string {
 	return result
 }
 
+func iaSetEqual(a, b map[addr.IA]struct{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for ia := range a {
+		if _, ok := b[ia]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func stringsToIASet(rawIAs []string) (map[addr.IA]struct{}, error) {
 	result := make(map[addr.IA]struct{})
 	for _, rawIA := range rawIAs {
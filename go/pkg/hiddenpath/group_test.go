@@ -60,7 +60,8 @@ func TestNewGroup(t *testing.T) {
 						OwnerAS: xtest.MustParseAS("ff00:0:110"),
 						Suffix:  0x69b5,
 					},
-					Owner: xtest.MustParseIA("1-ff00:0:110"),
+					Owner:   xtest.MustParseIA("1-ff00:0:110"),
+					Version: 3,
 					Writers: map[addr.IA]struct{}{
 						xtest.MustParseIA("1-ff00:0:111"): {},
 						xtest.MustParseIA("1-ff00:0:112"): {},
@@ -0,0 +1,202 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+	"github.com/scionproto/scion/go/pkg/hiddenpath"
+	hspb "github.com/scionproto/scion/go/pkg/proto/hidden_segment"
+)
+
+// GroupServer serves the signed configuration of the hidden path groups that
+// are owned by the local AS.
+type GroupServer struct {
+	// Groups provides the groups.
+	Groups hiddenpath.GroupProvider
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+	// Signer signs the group configurations.
+	Signer Signer
+	// Verifier verifies the requests.
+	Verifier infra.Verifier
+}
+
+// HiddenPathGroup handles the gRPC hidden path group request. Only members of
+// the group are allowed to fetch its configuration.
+func (s GroupServer) HiddenPathGroup(ctx context.Context,
+	req *hspb.HiddenPathGroupRequest) (*hspb.HiddenPathGroupResponse, error) {
+
+	logger := log.FromCtx(ctx)
+
+	p, peerIA, err := getPeer(ctx)
+	if err != nil {
+		logger.Debug("Failed to extract peer", "err", err)
+		return nil, err
+	}
+	msg, err := s.Verifier.WithIA(peerIA).WithServer(p).Verify(ctx, req.SignedRequest)
+	if err != nil {
+		logger.Debug("Failed to verify signature", "err", err)
+		return nil, status.Error(codes.Unauthenticated, "verifying signature")
+	}
+	var reqBody hspb.HiddenPathGroupRequestBody
+	if err := proto.Unmarshal(msg.Body, &reqBody); err != nil {
+		logger.Debug("Failed to parse body", "err", err)
+		return nil, status.Error(codes.InvalidArgument, "parsing body")
+	}
+	id := hiddenpath.GroupIDFromUint64(reqBody.GroupId)
+	group, ok := s.Groups.Group(id)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "unknown group")
+	}
+	if !group.Owner.Equal(s.LocalIA) {
+		return nil, status.Error(codes.FailedPrecondition, "not owner of group")
+	}
+	if !isMember(peerIA, group) {
+		logger.Debug("Rejected group request from non-member", "group_id", id, "peer", peerIA)
+		return nil, status.Error(codes.PermissionDenied, "not member of group")
+	}
+	rawGroup, err := proto.Marshal(groupToPB(group))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "packing group")
+	}
+	signedGroup, err := s.Signer.Sign(ctx, rawGroup)
+	if err != nil {
+		logger.Info("Failed to sign group", "group_id", id, "err", err)
+		return nil, status.Error(codes.Internal, "signing group")
+	}
+	return &hspb.HiddenPathGroupResponse{SignedGroup: signedGroup}, nil
+}
+
+// GroupFetcher fetches the signed configuration of hidden path groups from
+// the group owners.
+type GroupFetcher struct {
+	// Dialer dials a new gRPC connection.
+	Dialer libgrpc.Dialer
+	// Signer signs the requests.
+	Signer Signer
+	// Verifier verifies the signed group configurations.
+	Verifier infra.Verifier
+	// Resolver resolves the address of the owner control service.
+	Resolver hiddenpath.AddressResolver
+}
+
+// Group fetches the configuration of the group from the owner. The
+// configuration must be signed by the owner AS.
+func (f GroupFetcher) Group(ctx context.Context, id hiddenpath.GroupID,
+	owner addr.IA) (*hiddenpath.Group, error) {
+
+	remote, err := f.Resolver.Resolve(ctx, owner)
+	if err != nil {
+		return nil, serrors.WrapStr("resolving owner", err, "owner", owner)
+	}
+	conn, err := f.Dialer.Dial(ctx, remote)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	rawBody, err := proto.Marshal(&hspb.HiddenPathGroupRequestBody{GroupId: id.ToUint64()})
+	if err != nil {
+		return nil, err
+	}
+	signedReq, err := f.Signer.Sign(ctx, rawBody)
+	if err != nil {
+		return nil, serrors.WrapStr("signing request", err)
+	}
+	client := hspb.NewHiddenPathGroupServiceClient(conn)
+	rep, err := client.HiddenPathGroup(ctx, &hspb.HiddenPathGroupRequest{
+		SignedRequest: signedReq,
+	}, libgrpc.RetryProfile...)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := f.Verifier.WithIA(owner).WithServer(remote).Verify(ctx, rep.SignedGroup)
+	if err != nil {
+		return nil, serrors.WrapStr("verifying group", err)
+	}
+	var pb hspb.HiddenPathGroup
+	if err := proto.Unmarshal(msg.Body, &pb); err != nil {
+		return nil, serrors.WrapStr("parsing group", err)
+	}
+	group := groupFromPB(&pb)
+	if group.ID != id {
+		return nil, serrors.New("group ID mismatch", "expected", id, "actual", group.ID)
+	}
+	if !group.Owner.Equal(owner) {
+		return nil, serrors.New("owner mismatch", "expected", owner, "actual", group.Owner)
+	}
+	if err := group.Validate(); err != nil {
+		return nil, serrors.WrapStr("validating group", err)
+	}
+	return group, nil
+}
+
+func isMember(ia addr.IA, group *hiddenpath.Group) bool {
+	_, writer := group.Writers[ia]
+	_, reader := group.Readers[ia]
+	_, registry := group.Registries[ia]
+	return writer || reader || registry
+}
+
+func groupToPB(group *hiddenpath.Group) *hspb.HiddenPathGroup {
+	return &hspb.HiddenPathGroup{
+		GroupId:    group.ID.ToUint64(),
+		Version:    group.Version,
+		OwnerIsdAs: uint64(group.Owner.IAInt()),
+		Writers:    iaSetToPB(group.Writers),
+		Readers:    iaSetToPB(group.Readers),
+		Registries: iaSetToPB(group.Registries),
+	}
+}
+
+func groupFromPB(pb *hspb.HiddenPathGroup) *hiddenpath.Group {
+	return &hiddenpath.Group{
+		ID:         hiddenpath.GroupIDFromUint64(pb.GroupId),
+		Version:    pb.Version,
+		Owner:      addr.IAInt(pb.OwnerIsdAs).IA(),
+		Writers:    iaSetFromPB(pb.Writers),
+		Readers:    iaSetFromPB(pb.Readers),
+		Registries: iaSetFromPB(pb.Registries),
+	}
+}
+
+func iaSetToPB(set map[addr.IA]struct{}) []uint64 {
+	ret := make([]uint64, 0, len(set))
+	for ia := range set {
+		ret = append(ret, uint64(ia.IAInt()))
+	}
+	// make consistent output.
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+func iaSetFromPB(raw []uint64) map[addr.IA]struct{} {
+	ret := make(map[addr.IA]struct{}, len(raw))
+	for _, ia := range raw {
+		ret[addr.IAInt(ia).IA()] = struct{}{}
+	}
+	return ret
+}
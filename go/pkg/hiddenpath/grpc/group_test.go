@@ -0,0 +1,294 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/mock_infra"
+	"github.com/scionproto/scion/go/lib/scrypto/signed"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/hiddenpath"
+	hpgrpc "github.com/scionproto/scion/go/pkg/hiddenpath/grpc"
+	"github.com/scionproto/scion/go/pkg/hiddenpath/grpc/mock_grpc"
+	"github.com/scionproto/scion/go/pkg/hiddenpath/mock_hiddenpath"
+	cryptopb "github.com/scionproto/scion/go/pkg/proto/crypto"
+	hspb "github.com/scionproto/scion/go/pkg/proto/hidden_segment"
+	"github.com/scionproto/scion/go/pkg/proto/hidden_segment/mock_hidden_segment"
+)
+
+var (
+	groupOwner = xtest.MustParseIA("1-ff00:0:110")
+	groupID    = hiddenpath.GroupID{OwnerAS: groupOwner.A, Suffix: 0x42}
+)
+
+func TestGroupServerHiddenPathGroup(t *testing.T) {
+	peerCtx := func(ia string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &snet.UDPAddr{
+			IA: xtest.MustParseIA(ia),
+		}})
+	}
+	verifier := func(peer string, id hiddenpath.GroupID) func(*gomock.Controller) infra.Verifier {
+		return func(ctrl *gomock.Controller) infra.Verifier {
+			body, err := proto.Marshal(&hspb.HiddenPathGroupRequestBody{
+				GroupId: id.ToUint64(),
+			})
+			require.NoError(t, err)
+			v := mock_infra.NewMockVerifier(ctrl)
+			v.EXPECT().WithServer(gomock.Any()).Return(v)
+			v.EXPECT().WithIA(xtest.MustParseIA(peer)).Return(v)
+			v.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(&signed.Message{
+				Body: body,
+			}, nil)
+			return v
+		}
+	}
+
+	testCases := map[string]struct {
+		ctx       context.Context
+		localIA   addr.IA
+		verifier  func(*gomock.Controller) infra.Verifier
+		signer    func(*gomock.Controller) hpgrpc.Signer
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"no peer in context": {
+			ctx:     context.Background(),
+			localIA: groupOwner,
+			verifier: func(ctrl *gomock.Controller) infra.Verifier {
+				return mock_infra.NewMockVerifier(ctrl)
+			},
+			signer: func(ctrl *gomock.Controller) hpgrpc.Signer {
+				return mock_grpc.NewMockSigner(ctrl)
+			},
+			assertErr: assert.Error,
+		},
+		"signature verification error": {
+			ctx:     peerCtx("1-ff00:0:111"),
+			localIA: groupOwner,
+			verifier: func(ctrl *gomock.Controller) infra.Verifier {
+				v := mock_infra.NewMockVerifier(ctrl)
+				v.EXPECT().WithServer(gomock.Any()).Return(v)
+				v.EXPECT().WithIA(gomock.Any()).Return(v)
+				v.EXPECT().Verify(gomock.Any(), gomock.Any()).
+					Return(nil, serrors.New("verification failed"))
+				return v
+			},
+			signer: func(ctrl *gomock.Controller) hpgrpc.Signer {
+				return mock_grpc.NewMockSigner(ctrl)
+			},
+			assertErr: assert.Error,
+		},
+		"unknown group": {
+			ctx:      peerCtx("1-ff00:0:111"),
+			localIA:  groupOwner,
+			verifier: verifier("1-ff00:0:111", hiddenpath.GroupID{Suffix: 1}),
+			signer: func(ctrl *gomock.Controller) hpgrpc.Signer {
+				return mock_grpc.NewMockSigner(ctrl)
+			},
+			assertErr: assert.Error,
+		},
+		"not owner": {
+			ctx:      peerCtx("1-ff00:0:111"),
+			localIA:  xtest.MustParseIA("1-ff00:0:112"),
+			verifier: verifier("1-ff00:0:111", groupID),
+			signer: func(ctrl *gomock.Controller) hpgrpc.Signer {
+				return mock_grpc.NewMockSigner(ctrl)
+			},
+			assertErr: assert.Error,
+		},
+		"not member": {
+			ctx:      peerCtx("1-ff00:0:114"),
+			localIA:  groupOwner,
+			verifier: verifier("1-ff00:0:114", groupID),
+			signer: func(ctrl *gomock.Controller) hpgrpc.Signer {
+				return mock_grpc.NewMockSigner(ctrl)
+			},
+			assertErr: assert.Error,
+		},
+		"valid": {
+			ctx:      peerCtx("1-ff00:0:112"),
+			localIA:  groupOwner,
+			verifier: verifier("1-ff00:0:112", groupID),
+			signer: func(ctrl *gomock.Controller) hpgrpc.Signer {
+				signer := mock_grpc.NewMockSigner(ctrl)
+				signer.EXPECT().Sign(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, msg []byte,
+						_ ...[]byte) (*cryptopb.SignedMessage, error) {
+
+						var pb hspb.HiddenPathGroup
+						require.NoError(t, proto.Unmarshal(msg, &pb))
+						assert.Equal(t, groupID.ToUint64(), pb.GroupId)
+						assert.Equal(t, uint64(7), pb.Version)
+						return &cryptopb.SignedMessage{HeaderAndBody: msg}, nil
+					},
+				)
+				return signer
+			},
+			assertErr: assert.NoError,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := hpgrpc.GroupServer{
+				Groups:   hiddenpath.Groups{groupID: testGroup(groupID)},
+				LocalIA:  tc.localIA,
+				Signer:   tc.signer(ctrl),
+				Verifier: tc.verifier(ctrl),
+			}
+			rep, err := s.HiddenPathGroup(tc.ctx, &hspb.HiddenPathGroupRequest{})
+			tc.assertErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.NotNil(t, rep.SignedGroup)
+		})
+	}
+}
+
+func TestGroupFetcherGroup(t *testing.T) {
+	verifier := func(group *hiddenpath.Group, err error) func(*gomock.Controller) infra.Verifier {
+		return func(ctrl *gomock.Controller) infra.Verifier {
+			v := mock_infra.NewMockVerifier(ctrl)
+			v.EXPECT().WithServer(gomock.Any()).Return(v)
+			v.EXPECT().WithIA(groupOwner).Return(v)
+			if err != nil {
+				v.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(nil, err)
+				return v
+			}
+			body, err := proto.Marshal(&hspb.HiddenPathGroup{
+				GroupId:    group.ID.ToUint64(),
+				Version:    group.Version,
+				OwnerIsdAs: uint64(group.Owner.IAInt()),
+				Writers:    iasToPB(group.Writers),
+				Readers:    iasToPB(group.Readers),
+				Registries: iasToPB(group.Registries),
+			})
+			require.NoError(t, err)
+			v.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(&signed.Message{
+				Body: body,
+			}, nil)
+			return v
+		}
+	}
+
+	testCases := map[string]struct {
+		verifier  func(*gomock.Controller) infra.Verifier
+		want      *hiddenpath.Group
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			verifier:  verifier(testGroup(groupID), nil),
+			want:      testGroup(groupID),
+			assertErr: assert.NoError,
+		},
+		"verification error": {
+			verifier:  verifier(nil, serrors.New("verification failed")),
+			assertErr: assert.Error,
+		},
+		"group ID mismatch": {
+			verifier: verifier(testGroup(hiddenpath.GroupID{
+				OwnerAS: groupOwner.A,
+				Suffix:  0x43,
+			}), nil),
+			assertErr: assert.Error,
+		},
+		"invalid group": {
+			verifier: verifier(func() *hiddenpath.Group {
+				g := testGroup(groupID)
+				g.Writers = nil
+				return g
+			}(), nil),
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := mock_hidden_segment.NewMockHiddenPathGroupServiceServer(ctrl)
+			server.EXPECT().HiddenPathGroup(gomock.Any(), gomock.Any()).
+				Return(&hspb.HiddenPathGroupResponse{
+					SignedGroup: &cryptopb.SignedMessage{},
+				}, nil)
+			svc := xtest.NewGRPCService()
+			hspb.RegisterHiddenPathGroupServiceServer(svc.Server(), server)
+			svc.Start(t)
+
+			signer := mock_grpc.NewMockSigner(ctrl)
+			signer.EXPECT().Sign(gomock.Any(), gomock.Any()).Return(&cryptopb.SignedMessage{}, nil)
+			resolver := mock_hiddenpath.NewMockAddressResolver(ctrl)
+			resolver.EXPECT().Resolve(gomock.Any(), groupOwner).Return(&net.UDPAddr{}, nil)
+
+			f := hpgrpc.GroupFetcher{
+				Dialer:   svc,
+				Signer:   signer,
+				Verifier: tc.verifier(ctrl),
+				Resolver: resolver,
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			got, err := f.Group(ctx, groupID, groupOwner)
+			tc.assertErr(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func testGroup(id hiddenpath.GroupID) *hiddenpath.Group {
+	return &hiddenpath.Group{
+		ID:      id,
+		Owner:   groupOwner,
+		Version: 7,
+		Writers: map[addr.IA]struct{}{
+			xtest.MustParseIA("1-ff00:0:111"): {},
+		},
+		Readers: map[addr.IA]struct{}{
+			xtest.MustParseIA("1-ff00:0:112"): {},
+		},
+		Registries: map[addr.IA]struct{}{
+			xtest.MustParseIA("1-ff00:0:113"): {},
+		},
+	}
+}
+
+func iasToPB(set map[addr.IA]struct{}) []uint64 {
+	var ret []uint64
+	for ia := range set {
+		ret = append(ret, uint64(ia.IAInt()))
+	}
+	return ret
+}
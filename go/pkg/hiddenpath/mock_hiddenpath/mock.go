@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/go/pkg/hiddenpath (interfaces: Store,RPC,Verifier,Lookuper,AddressResolver,Discoverer,Registry,Register)
+// Source: github.com/scionproto/scion/go/pkg/hiddenpath (interfaces: Store,RPC,Verifier,Lookuper,AddressResolver,Discoverer,Registry,Register,GroupFetcher)
 
 // Package mock_hiddenpath is a generated GoMock package.
 package mock_hiddenpath
@@ -329,3 +329,41 @@ func (mr *MockRegisterMockRecorder) RegisterSegment(arg0, arg1, arg2 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSegment", reflect.TypeOf((*MockRegister)(nil).RegisterSegment), arg0, arg1, arg2)
 }
+
+// MockGroupFetcher is a mock of GroupFetcher interface.
+type MockGroupFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupFetcherMockRecorder
+}
+
+// MockGroupFetcherMockRecorder is the mock recorder for MockGroupFetcher.
+type MockGroupFetcherMockRecorder struct {
+	mock *MockGroupFetcher
+}
+
+// NewMockGroupFetcher creates a new mock instance.
+func NewMockGroupFetcher(ctrl *gomock.Controller) *MockGroupFetcher {
+	mock := &MockGroupFetcher{ctrl: ctrl}
+	mock.recorder = &MockGroupFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupFetcher) EXPECT() *MockGroupFetcherMockRecorder {
+	return m.recorder
+}
+
+// Group mocks base method.
+func (m *MockGroupFetcher) Group(arg0 context.Context, arg1 hiddenpath.GroupID, arg2 addr.IA) (*hiddenpath.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Group", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*hiddenpath.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Group indicates an expected call of Group.
+func (mr *MockGroupFetcherMockRecorder) Group(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Group", reflect.TypeOf((*MockGroupFetcher)(nil).Group), arg0, arg1, arg2)
+}
@@ -33,7 +33,7 @@ import (
 func TestRegistryRegister(t *testing.T) {
 	localIA := xtest.MustParseIA("1-ff00:0:114")
 	writer := xtest.MustParseIA("2-ff00:0:221")
-	groups := map[hiddenpath.GroupID]*hiddenpath.Group{
+	groups := hiddenpath.Groups{
 		mustParseGroupID(t, "ff00:0:4-5"): {
 			Writers:    map[addr.IA]struct{}{writer: {}},
 			Registries: map[addr.IA]struct{}{localIA: {}},
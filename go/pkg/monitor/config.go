@@ -0,0 +1,218 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"io"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// Defaults of the monitor configuration.
+const (
+	DefaultAddress            = "127.0.0.1:30480"
+	DefaultInterval           = 30 * time.Second
+	DefaultTracerouteInterval = 5 * time.Minute
+	DefaultPingCount          = 5
+	DefaultTimeout            = time.Second
+	DefaultLatencyShift       = 10 * time.Millisecond
+	DefaultMaxPaths           = 10
+)
+
+var _ config.Config = (*Config)(nil)
+
+// Config is the configuration of the path monitor.
+type Config struct {
+	// Address is the address the metrics and the status are served on.
+	Address string `toml:"address,omitempty"`
+	// Interval is the default interval at which the paths are refreshed and
+	// pinged.
+	Interval util.DurWrap `toml:"interval,omitempty"`
+	// TracerouteInterval is the default interval at which the paths are
+	// tracerouted.
+	TracerouteInterval util.DurWrap `toml:"traceroute_interval,omitempty"`
+	// PingCount is the number of echo requests that are sent per path and
+	// interval. The echo requests are sent one timeout apart.
+	PingCount int `toml:"ping_count,omitempty"`
+	// Timeout is the timeout of a single echo or traceroute request.
+	Timeout util.DurWrap `toml:"timeout,omitempty"`
+	// LatencyShift is the change of the round trip time to a hop that is
+	// reported as latency shift.
+	LatencyShift util.DurWrap `toml:"latency_shift,omitempty"`
+	// Targets are the monitored remotes.
+	Targets []Target `toml:"targets,omitempty"`
+}
+
+// Target is a monitored remote.
+type Target struct {
+	// Name identifies the target in the metrics and the status.
+	Name string `toml:"name,omitempty"`
+	// Remote is the SCION address of the remote host.
+	Remote string `toml:"remote,omitempty"`
+	// Sequence is the hop predicate sequence the monitored paths must match.
+	Sequence string `toml:"sequence,omitempty"`
+	// MaxPaths is the maximum number of monitored paths. The shortest paths
+	// are monitored.
+	MaxPaths int `toml:"max_paths,omitempty"`
+	// Interval overrides the global interval for this target.
+	Interval util.DurWrap `toml:"interval,omitempty"`
+	// TracerouteInterval overrides the global traceroute interval for this
+	// target.
+	TracerouteInterval util.DurWrap `toml:"traceroute_interval,omitempty"`
+}
+
+// InitDefaults initializes the unset values of the configuration, including
+// the per target overrides.
+func (cfg *Config) InitDefaults() {
+	if cfg.Address == "" {
+		cfg.Address = DefaultAddress
+	}
+	if cfg.Interval.Duration == 0 {
+		cfg.Interval.Duration = DefaultInterval
+	}
+	if cfg.TracerouteInterval.Duration == 0 {
+		cfg.TracerouteInterval.Duration = DefaultTracerouteInterval
+	}
+	if cfg.PingCount == 0 {
+		cfg.PingCount = DefaultPingCount
+	}
+	if cfg.Timeout.Duration == 0 {
+		cfg.Timeout.Duration = DefaultTimeout
+	}
+	if cfg.LatencyShift.Duration == 0 {
+		cfg.LatencyShift.Duration = DefaultLatencyShift
+	}
+	for i := range cfg.Targets {
+		t := &cfg.Targets[i]
+		if t.MaxPaths == 0 {
+			t.MaxPaths = DefaultMaxPaths
+		}
+		if t.Interval.Duration == 0 {
+			t.Interval = cfg.Interval
+		}
+		if t.TracerouteInterval.Duration == 0 {
+			t.TracerouteInterval = cfg.TracerouteInterval
+		}
+	}
+}
+
+// Validate validates the configuration.
+func (cfg *Config) Validate() error {
+	if cfg.PingCount < 0 {
+		return serrors.New("ping_count must not be negative", "ping_count", cfg.PingCount)
+	}
+	if cfg.Timeout.Duration <= 0 {
+		return serrors.New("timeout must be positive", "timeout", cfg.Timeout)
+	}
+	if len(cfg.Targets) == 0 {
+		return serrors.New("no targets configured")
+	}
+	names := make(map[string]struct{}, len(cfg.Targets))
+	for _, t := range cfg.Targets {
+		if err := t.Validate(); err != nil {
+			return serrors.WrapStr("validating target", err, "name", t.Name)
+		}
+		if _, ok := names[t.Name]; ok {
+			return serrors.New("duplicate target name", "name", t.Name)
+		}
+		names[t.Name] = struct{}{}
+		// The pings of an interval must be done before the next interval
+		// starts.
+		if time.Duration(cfg.PingCount)*cfg.Timeout.Duration > t.Interval.Duration {
+			return serrors.New("interval too short for ping_count and timeout",
+				"name", t.Name, "interval", t.Interval)
+		}
+	}
+	return nil
+}
+
+// Validate validates the target.
+func (t *Target) Validate() error {
+	if t.Name == "" {
+		return serrors.New("name must be set")
+	}
+	if _, err := t.RemoteAddr(); err != nil {
+		return err
+	}
+	if _, err := pathpol.NewSequence(t.Sequence); err != nil {
+		return serrors.WrapStr("parsing sequence", err)
+	}
+	if t.MaxPaths < 1 {
+		return serrors.New("max_paths must be positive", "max_paths", t.MaxPaths)
+	}
+	if t.Interval.Duration <= 0 || t.TracerouteInterval.Duration <= 0 {
+		return serrors.New("intervals must be positive")
+	}
+	return nil
+}
+
+// RemoteAddr returns the parsed remote address.
+func (t *Target) RemoteAddr() (*snet.UDPAddr, error) {
+	remote, err := snet.ParseUDPAddr(t.Remote)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing remote", err, "remote", t.Remote)
+	}
+	return remote, nil
+}
+
+// Sample writes a sample configuration.
+func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteString(dst, sample)
+}
+
+// ConfigName returns the name of the configuration.
+func (cfg *Config) ConfigName() string {
+	return "monitor"
+}
+
+const sample = `
+# The address the Prometheus metrics (/metrics) and the JSON status (/status)
+# are served on. (default "127.0.0.1:30480")
+address = "127.0.0.1:30480"
+
+# The interval at which the paths are refreshed and pinged. (default 30s)
+interval = "30s"
+
+# The interval at which the paths are tracerouted. (default 5m)
+traceroute_interval = "5m"
+
+# The number of echo requests sent per path and interval. The echo requests are
+# sent one timeout apart. (default 5)
+ping_count = 5
+
+# The timeout of a single echo or traceroute request. (default 1s)
+timeout = "1s"
+
+# The change of the round trip time to a hop that is reported as latency
+# shift. (default 10ms)
+latency_shift = "10ms"
+
+# The monitored remotes. The name identifies the target in the metrics and the
+# status. The remote is the SCION address of the remote host. The optional
+# sequence is the hop predicate sequence the paths must match, see
+# 'scion ping --help'. At most max_paths of the shortest paths are monitored
+# (default 10). The interval and the traceroute_interval can be overridden per
+# target.
+[[targets]]
+name = "core"
+remote = "1-ff00:0:110,10.0.0.1"
+sequence = ""
+max_paths = 10
+`
@@ -0,0 +1,87 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/prom"
+)
+
+// Metrics are the metrics exposed by the monitor. All metrics are optional.
+type Metrics struct {
+	// Paths is the number of monitored paths. Labels: target.
+	Paths metrics.Gauge
+	// PathUp is 1 for the monitored paths and 0 for the paths that
+	// disappeared. Labels: target, path.
+	PathUp metrics.Gauge
+	// PathChanges is the number of added and removed paths. Labels: target,
+	// change.
+	PathChanges metrics.Counter
+	// PingRTT is the average round trip time of the echo requests in
+	// seconds. Labels: target, path.
+	PingRTT metrics.Gauge
+	// PingLoss is the fraction of echo requests without reply. Labels: target,
+	// path.
+	PingLoss metrics.Gauge
+	// HopRTT is the round trip time to the hop in seconds. Labels: target,
+	// path, hop.
+	HopRTT metrics.Gauge
+	// LatencyShifts is the number of detected latency shifts. Labels: target,
+	// path, hop.
+	LatencyShifts metrics.Counter
+	// Errors is the number of failed probes. Labels: target, op.
+	Errors metrics.Counter
+}
+
+// NewMetrics creates the metrics and registers them with the default
+// prometheus registry.
+func NewMetrics() Metrics {
+	return Metrics{
+		Paths: metrics.NewPromGauge(prom.NewGaugeVec("monitor", "", "paths",
+			"The number of monitored paths.",
+			[]string{"target"},
+		)),
+		PathUp: metrics.NewPromGauge(prom.NewGaugeVec("monitor", "", "path_up",
+			"Whether the path is currently available (1) or disappeared (0).",
+			[]string{"target", "path"},
+		)),
+		PathChanges: metrics.NewPromCounter(prom.NewCounterVec("monitor", "",
+			"path_changes_total",
+			"The total number of added and removed paths.",
+			[]string{"target", "change"},
+		)),
+		PingRTT: metrics.NewPromGauge(prom.NewGaugeVec("monitor", "", "ping_rtt_seconds",
+			"The average round trip time of the echo requests on the path.",
+			[]string{"target", "path"},
+		)),
+		PingLoss: metrics.NewPromGauge(prom.NewGaugeVec("monitor", "", "ping_loss_ratio",
+			"The fraction of echo requests on the path without reply.",
+			[]string{"target", "path"},
+		)),
+		HopRTT: metrics.NewPromGauge(prom.NewGaugeVec("monitor", "", "hop_rtt_seconds",
+			"The round trip time to the hop on the path.",
+			[]string{"target", "path", "hop"},
+		)),
+		LatencyShifts: metrics.NewPromCounter(prom.NewCounterVec("monitor", "",
+			"latency_shifts_total",
+			"The total number of detected latency shifts to the hop on the path.",
+			[]string{"target", "path", "hop"},
+		)),
+		Errors: metrics.NewPromCounter(prom.NewCounterVec("monitor", "", "errors_total",
+			"The total number of failed path lookups, pings and traceroutes.",
+			[]string{"target", "op"},
+		)),
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package monitor continuously monitors the paths to a set of remotes.
+//
+// For every target, the monitor periodically looks up the paths, pings the
+// remote over every path and traceroutes the hops of every path. It reports
+// added and removed paths, and shifts of the round trip time to a hop. The
+// results are exposed as metrics and as status.
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+const (
+	// maxEvents is the number of events that are kept for the status.
+	maxEvents = 100
+	// baselineWeight is the weight of a new round trip time in the hop
+	// baseline, which is the exponentially weighted moving average of the
+	// round trip times.
+	baselineWeight = 0.125
+)
+
+// Event types.
+const (
+	EventPathAdded    = "path_added"
+	EventPathRemoved  = "path_removed"
+	EventLatencyShift = "latency_shift"
+)
+
+// Event is a change detected by the monitor.
+type Event struct {
+	Time        time.Time `json:"time"`
+	Target      string    `json:"target"`
+	Type        string    `json:"type"`
+	Path        string    `json:"path"`
+	Description string    `json:"description"`
+}
+
+// Monitor periodically probes the paths to the targets.
+type Monitor struct {
+	cfg     Config
+	prober  Prober
+	metrics Metrics
+
+	mu      sync.Mutex
+	targets []*target
+	events  []Event
+}
+
+type target struct {
+	Target
+	remote *snet.UDPAddr
+
+	// The fields below are protected by the mutex of the monitor.
+	updated time.Time
+	err     string
+	// order are the fingerprints of the paths in the order of the last
+	// lookup.
+	order []snet.PathFingerprint
+	paths map[snet.PathFingerprint]*pathState
+}
+
+type pathState struct {
+	path      snet.Path
+	label     string
+	since     time.Time
+	ping      *PingStatus
+	hops      []HopStatus
+	baselines map[int]time.Duration
+}
+
+// New creates a monitor. The configuration must be initialized and valid.
+func New(cfg Config, prober Prober, metrics Metrics) (*Monitor, error) {
+	m := &Monitor{
+		cfg:     cfg,
+		prober:  prober,
+		metrics: metrics,
+	}
+	for _, t := range cfg.Targets {
+		remote, err := t.RemoteAddr()
+		if err != nil {
+			return nil, err
+		}
+		m.targets = append(m.targets, &target{
+			Target: t,
+			remote: remote,
+			paths:  make(map[snet.PathFingerprint]*pathState),
+		})
+	}
+	return m, nil
+}
+
+// Run monitors the targets until the context is done.
+func (m *Monitor) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, t := range m.targets {
+		wg.Add(1)
+		go func(t *target) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			m.runTarget(ctx, t)
+		}(t)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (m *Monitor) runTarget(ctx context.Context, t *target) {
+	probeTicker := time.NewTicker(t.Interval.Duration)
+	defer probeTicker.Stop()
+	traceTicker := time.NewTicker(t.TracerouteInterval.Duration)
+	defer traceTicker.Stop()
+
+	m.probe(ctx, t)
+	m.trace(ctx, t)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-probeTicker.C:
+			m.probe(ctx, t)
+		case <-traceTicker.C:
+			m.trace(ctx, t)
+		}
+	}
+}
+
+// probe looks up the paths of the target and pings the remote over them.
+func (m *Monitor) probe(ctx context.Context, t *target) {
+	paths, err := m.prober.Paths(ctx, t.remote.IA, t.Sequence)
+	if err != nil {
+		m.fail(ctx, t, "paths", err)
+		return
+	}
+	if len(paths) > t.MaxPaths {
+		paths = paths[:t.MaxPaths]
+	}
+	m.updatePaths(t, paths)
+
+	results := make([]PingResult, len(paths))
+	errs := make([]error, len(paths))
+	var wg sync.WaitGroup
+	for i, p := range paths {
+		wg.Add(1)
+		go func(i int, p snet.Path) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			results[i], errs[i] = m.prober.Ping(ctx, t.remote, p)
+		}(i, p)
+	}
+	wg.Wait()
+
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, p := range paths {
+		ps := t.paths[snet.Fingerprint(p)]
+		if ps == nil {
+			continue
+		}
+		if errs[i] != nil {
+			m.failLocked(ctx, t, "ping", errs[i])
+			continue
+		}
+		ps.ping = newPingStatus(results[i], now)
+		metrics.GaugeSet(metrics.GaugeWith(m.metrics.PingLoss,
+			"target", t.Name, "path", ps.label), ps.ping.Loss)
+		if ps.ping.Received > 0 {
+			metrics.GaugeSet(metrics.GaugeWith(m.metrics.PingRTT,
+				"target", t.Name, "path", ps.label), ps.ping.AvgRTT.Seconds())
+		}
+	}
+}
+
+// updatePaths replaces the monitored paths of the target and reports the
+// added and removed paths.
+func (m *Monitor) updatePaths(t *target, paths []snet.Path) {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	initial := t.updated.IsZero()
+	t.updated = now
+	t.err = ""
+	current := make(map[snet.PathFingerprint]*pathState, len(paths))
+	t.order = t.order[:0]
+	for _, p := range paths {
+		fp := snet.Fingerprint(p)
+		t.order = append(t.order, fp)
+		if ps, ok := t.paths[fp]; ok {
+			// Keep the measurements, but use the path with the latest
+			// expiration and next hop.
+			ps.path = p
+			current[fp] = ps
+			continue
+		}
+		ps := &pathState{
+			path:      p,
+			label:     pathLabel(fp),
+			since:     now,
+			baselines: make(map[int]time.Duration),
+		}
+		current[fp] = ps
+		metrics.GaugeSet(metrics.GaugeWith(m.metrics.PathUp,
+			"target", t.Name, "path", ps.label), 1)
+		if !initial {
+			metrics.CounterInc(metrics.CounterWith(m.metrics.PathChanges,
+				"target", t.Name, "change", "added"))
+			m.addEvent(Event{
+				Time:        now,
+				Target:      t.Name,
+				Type:        EventPathAdded,
+				Path:        ps.label,
+				Description: fmt.Sprintf("path added: %s", p),
+			})
+		}
+	}
+	for fp, ps := range t.paths {
+		if _, ok := current[fp]; ok {
+			continue
+		}
+		metrics.GaugeSet(metrics.GaugeWith(m.metrics.PathUp,
+			"target", t.Name, "path", ps.label), 0)
+		metrics.CounterInc(metrics.CounterWith(m.metrics.PathChanges,
+			"target", t.Name, "change", "removed"))
+		m.addEvent(Event{
+			Time:        now,
+			Target:      t.Name,
+			Type:        EventPathRemoved,
+			Path:        ps.label,
+			Description: fmt.Sprintf("path removed: %s", ps.path),
+		})
+	}
+	t.paths = current
+	metrics.GaugeSet(metrics.GaugeWith(m.metrics.Paths, "target", t.Name),
+		float64(len(current)))
+}
+
+// trace traceroutes the monitored paths of the target and reports the latency
+// shifts.
+func (m *Monitor) trace(ctx context.Context, t *target) {
+	m.mu.Lock()
+	paths := make([]snet.Path, 0, len(t.order))
+	for _, fp := range t.order {
+		paths = append(paths, t.paths[fp].path)
+	}
+	m.mu.Unlock()
+
+	results := make([][]HopResult, len(paths))
+	errs := make([]error, len(paths))
+	var wg sync.WaitGroup
+	for i, p := range paths {
+		wg.Add(1)
+		go func(i int, p snet.Path) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			results[i], errs[i] = m.prober.Traceroute(ctx, t.remote, p)
+		}(i, p)
+	}
+	wg.Wait()
+
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, p := range paths {
+		ps := t.paths[snet.Fingerprint(p)]
+		if ps == nil {
+			continue
+		}
+		if errs[i] != nil {
+			m.failLocked(ctx, t, "traceroute", errs[i])
+			continue
+		}
+		ps.hops = ps.hops[:0]
+		for _, hop := range results[i] {
+			ps.hops = append(ps.hops, m.updateHop(t, ps, hop, now))
+		}
+	}
+}
+
+// updateHop updates the baseline of the hop and reports a latency shift if the
+// round trip time deviates from the baseline by more than the threshold.
+func (m *Monitor) updateHop(t *target, ps *pathState, hop HopResult,
+	now time.Time) HopStatus {
+
+	status := HopStatus{
+		Index:     hop.Index,
+		IA:        hop.IA.String(),
+		Interface: hop.Interface,
+		RTT:       hop.RTT,
+	}
+	if hop.RTT == 0 {
+		status.Baseline = ps.baselines[hop.Index]
+		return status
+	}
+	hopLabel := strconv.Itoa(hop.Index)
+	metrics.GaugeSet(metrics.GaugeWith(m.metrics.HopRTT,
+		"target", t.Name, "path", ps.label, "hop", hopLabel), hop.RTT.Seconds())
+
+	baseline, ok := ps.baselines[hop.Index]
+	switch {
+	case !ok:
+		baseline = hop.RTT
+	case absDuration(hop.RTT-baseline) > m.cfg.LatencyShift.Duration:
+		metrics.CounterInc(metrics.CounterWith(m.metrics.LatencyShifts,
+			"target", t.Name, "path", ps.label, "hop", hopLabel))
+		m.addEvent(Event{
+			Time:   now,
+			Target: t.Name,
+			Type:   EventLatencyShift,
+			Path:   ps.label,
+			Description: fmt.Sprintf("latency to hop %d (%s#%d) changed from %s to %s",
+				hop.Index, hop.IA, hop.Interface, baseline, hop.RTT),
+		})
+		// The new latency is the new normal.
+		baseline = hop.RTT
+	default:
+		baseline += time.Duration(baselineWeight * float64(hop.RTT-baseline))
+	}
+	ps.baselines[hop.Index] = baseline
+	status.Baseline = baseline
+	return status
+}
+
+func (m *Monitor) fail(ctx context.Context, t *target, op string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failLocked(ctx, t, op, err)
+}
+
+func (m *Monitor) failLocked(ctx context.Context, t *target, op string, err error) {
+	if ctx.Err() != nil {
+		// The monitor is shutting down.
+		return
+	}
+	log.Info("Monitoring failed", "target", t.Name, "op", op, "err", err)
+	metrics.CounterInc(metrics.CounterWith(m.metrics.Errors, "target", t.Name, "op", op))
+	t.err = fmt.Sprintf("%s: %s", op, err)
+}
+
+func (m *Monitor) addEvent(e Event) {
+	log.Info("Path monitor event", "target", e.Target, "type", e.Type, "path", e.Path,
+		"description", e.Description)
+	m.events = append(m.events, e)
+	if len(m.events) > maxEvents {
+		m.events = m.events[len(m.events)-maxEvents:]
+	}
+}
+
+// pathLabel returns the label of the path in the metrics and the status.
+func pathLabel(fp snet.PathFingerprint) string {
+	if fp == "" {
+		return "empty"
+	}
+	return fp.String()
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,264 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestConfig(t *testing.T) {
+	var sample bytes.Buffer
+	(&Config{}).Sample(&sample, nil, nil)
+	var cfg Config
+	require.NoError(t, config.Decode(sample.Bytes(), &cfg))
+	config.InitAll(&cfg)
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, DefaultInterval, cfg.Targets[0].Interval.Duration)
+	assert.Equal(t, DefaultTracerouteInterval, cfg.Targets[0].TracerouteInterval.Duration)
+
+	testCases := map[string]func(cfg *Config){
+		"no targets":      func(cfg *Config) { cfg.Targets = nil },
+		"invalid remote":  func(cfg *Config) { cfg.Targets[0].Remote = "10.0.0.1" },
+		"invalid seq":     func(cfg *Config) { cfg.Targets[0].Sequence = "invalid" },
+		"missing name":    func(cfg *Config) { cfg.Targets[0].Name = "" },
+		"duplicate name":  func(cfg *Config) { cfg.Targets = append(cfg.Targets, cfg.Targets[0]) },
+		"short interval":  func(cfg *Config) { cfg.Targets[0].Interval.Duration = time.Second },
+		"negative paths":  func(cfg *Config) { cfg.Targets[0].MaxPaths = -1 },
+		"negative pings":  func(cfg *Config) { cfg.PingCount = -1 },
+		"negative timout": func(cfg *Config) { cfg.Timeout.Duration = -1 },
+	}
+	for name, modify := range testCases {
+		t.Run(name, func(t *testing.T) {
+			invalid := cfg
+			invalid.Targets = append([]Target(nil), cfg.Targets...)
+			modify(&invalid)
+			assert.Error(t, invalid.Validate())
+		})
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+	pathA := newPath(1, 2)
+	pathB := newPath(3, 4)
+	ia110, ia111 := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:111")
+	prober := &fakeProber{
+		paths: []snet.Path{pathA},
+		ping: PingResult{
+			Sent:     4,
+			Received: 3,
+			RTTs: []time.Duration{
+				10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond,
+			},
+		},
+		hops: []HopResult{
+			{Index: 0, IA: ia110, Interface: 1, RTT: 5 * time.Millisecond},
+			{Index: 1, IA: ia111, Interface: 2},
+		},
+	}
+	m, testMetrics := newTestMonitor(t, prober)
+	target := m.targets[0]
+	labelA := snet.Fingerprint(pathA).String()
+	labelB := snet.Fingerprint(pathB).String()
+
+	m.probe(ctx, target)
+	m.trace(ctx, target)
+	assert.Equal(t, float64(1), metrics.GaugeValue(
+		testMetrics.Paths.With("target", "test")))
+	assert.Equal(t, 0.25, metrics.GaugeValue(
+		testMetrics.PingLoss.With("target", "test", "path", labelA)))
+	assert.Equal(t, 0.02, metrics.GaugeValue(
+		testMetrics.PingRTT.With("target", "test", "path", labelA)))
+	assert.Equal(t, 0.005, metrics.GaugeValue(
+		testMetrics.HopRTT.With("target", "test", "path", labelA, "hop", "0")))
+	assert.Empty(t, m.Status().Events, "the initial paths are no change")
+
+	t.Run("path change", func(t *testing.T) {
+		prober.setPaths(pathB)
+		m.probe(ctx, target)
+
+		assert.Equal(t, float64(1), metrics.CounterValue(
+			testMetrics.PathChanges.With("target", "test", "change", "added")))
+		assert.Equal(t, float64(1), metrics.CounterValue(
+			testMetrics.PathChanges.With("target", "test", "change", "removed")))
+		assert.Equal(t, float64(0), metrics.GaugeValue(
+			testMetrics.PathUp.With("target", "test", "path", labelA)))
+		assert.Equal(t, float64(1), metrics.GaugeValue(
+			testMetrics.PathUp.With("target", "test", "path", labelB)))
+
+		status := m.Status()
+		require.Len(t, status.Targets[0].Paths, 1)
+		assert.Equal(t, labelB, status.Targets[0].Paths[0].Fingerprint)
+		var types []string
+		for _, e := range status.Events {
+			types = append(types, e.Type)
+		}
+		assert.ElementsMatch(t, []string{EventPathAdded, EventPathRemoved}, types)
+	})
+
+	t.Run("latency shift", func(t *testing.T) {
+		shifts := testMetrics.LatencyShifts.With("target", "test", "path", labelB, "hop", "0")
+		prober.setHopRTT(10 * time.Millisecond)
+		m.trace(ctx, target)
+		// Small variations only move the baseline.
+		prober.setHopRTT(14 * time.Millisecond)
+		m.trace(ctx, target)
+		assert.Equal(t, float64(0), metrics.CounterValue(shifts))
+		hop := m.Status().Targets[0].Paths[0].Hops[0]
+		assert.Equal(t, 14*time.Millisecond, hop.RTT)
+		assert.Equal(t, 10500*time.Microsecond, hop.Baseline)
+
+		prober.setHopRTT(40 * time.Millisecond)
+		m.trace(ctx, target)
+		assert.Equal(t, float64(1), metrics.CounterValue(shifts))
+		hop = m.Status().Targets[0].Paths[0].Hops[0]
+		assert.Equal(t, 40*time.Millisecond, hop.Baseline)
+		events := m.Status().Events
+		assert.Equal(t, EventLatencyShift, events[len(events)-1].Type)
+
+		// The hop that does not reply has no baseline and no shifts.
+		hop = m.Status().Targets[0].Paths[0].Hops[1]
+		assert.Zero(t, hop.Baseline)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		prober.setErr(serrors.New("no daemon"))
+		m.probe(ctx, target)
+		assert.Equal(t, float64(1), metrics.CounterValue(
+			testMetrics.Errors.With("target", "test", "op", "paths")))
+		status := m.Status()
+		assert.Contains(t, status.Targets[0].Error, "no daemon")
+		assert.Len(t, status.Targets[0].Paths, 1, "paths are kept")
+
+		prober.setErr(nil)
+		m.probe(ctx, target)
+		assert.Empty(t, m.Status().Targets[0].Error)
+	})
+}
+
+func TestServeStatus(t *testing.T) {
+	prober := &fakeProber{
+		paths: []snet.Path{newPath(1, 2)},
+		ping:  PingResult{Sent: 1, Received: 1, RTTs: []time.Duration{time.Millisecond}},
+	}
+	m, _ := newTestMonitor(t, prober)
+	m.probe(context.Background(), m.targets[0])
+
+	rec := httptest.NewRecorder()
+	m.ServeStatus(rec, httptest.NewRequest("GET", "/status", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var status Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	require.Len(t, status.Targets, 1)
+	assert.Equal(t, "test", status.Targets[0].Name)
+	require.Len(t, status.Targets[0].Paths, 1)
+	assert.Equal(t, time.Millisecond, status.Targets[0].Paths[0].Ping.AvgRTT)
+}
+
+func newTestMonitor(t *testing.T, prober Prober) (*Monitor, Metrics) {
+	cfg := Config{
+		Targets: []Target{{Name: "test", Remote: "1-ff00:0:111,10.0.0.1"}},
+	}
+	config.InitAll(&cfg)
+	require.NoError(t, cfg.Validate())
+	testMetrics := Metrics{
+		Paths:         metrics.NewTestGauge(),
+		PathUp:        metrics.NewTestGauge(),
+		PathChanges:   metrics.NewTestCounter(),
+		PingRTT:       metrics.NewTestGauge(),
+		PingLoss:      metrics.NewTestGauge(),
+		HopRTT:        metrics.NewTestGauge(),
+		LatencyShifts: metrics.NewTestCounter(),
+		Errors:        metrics.NewTestCounter(),
+	}
+	m, err := New(cfg, prober, testMetrics)
+	require.NoError(t, err)
+	return m, testMetrics
+}
+
+func newPath(ifids ...uint64) snet.Path {
+	p := snetpath.Path{Dst: xtest.MustParseIA("1-ff00:0:111")}
+	for _, ifid := range ifids {
+		p.Meta.Interfaces = append(p.Meta.Interfaces, snet.PathInterface{
+			IA: xtest.MustParseIA("1-ff00:0:110"),
+			ID: common.IFIDType(ifid),
+		})
+	}
+	return p
+}
+
+type fakeProber struct {
+	mu    sync.Mutex
+	paths []snet.Path
+	ping  PingResult
+	hops  []HopResult
+	err   error
+}
+
+func (p *fakeProber) Paths(_ context.Context, _ addr.IA, _ string) ([]snet.Path, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]snet.Path(nil), p.paths...), p.err
+}
+
+func (p *fakeProber) Ping(_ context.Context, _ *snet.UDPAddr, _ snet.Path) (PingResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ping, nil
+}
+
+func (p *fakeProber) Traceroute(_ context.Context, _ *snet.UDPAddr,
+	_ snet.Path) ([]HopResult, error) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]HopResult(nil), p.hops...), nil
+}
+
+func (p *fakeProber) setPaths(paths ...snet.Path) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paths = paths
+}
+
+func (p *fakeProber) setHopRTT(rtt time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hops[0].RTT = rtt
+}
+
+func (p *fakeProber) setErr(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
@@ -0,0 +1,195 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/addrutil"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/pkg/app/path"
+	"github.com/scionproto/scion/go/pkg/ping"
+	"github.com/scionproto/scion/go/pkg/traceroute"
+)
+
+// DefaultProbesPerHop is the default number of traceroute requests per hop.
+const DefaultProbesPerHop = 3
+
+// Prober looks up and probes the paths to the targets.
+type Prober interface {
+	// Paths returns the sorted paths to the remote that match the sequence.
+	Paths(ctx context.Context, remote addr.IA, sequence string) ([]snet.Path, error)
+	// Ping sends echo requests to the remote over the path.
+	Ping(ctx context.Context, remote *snet.UDPAddr, p snet.Path) (PingResult, error)
+	// Traceroute sends traceroute requests to the hops of the path.
+	Traceroute(ctx context.Context, remote *snet.UDPAddr, p snet.Path) ([]HopResult, error)
+}
+
+// PingResult is the result of pinging over a path.
+type PingResult struct {
+	Sent     int
+	Received int
+	// RTTs are the round trip times of the replies.
+	RTTs []time.Duration
+}
+
+// HopResult is the result of tracerouting a hop.
+type HopResult struct {
+	// Index is the index of the hop in the path.
+	Index     int
+	IA        addr.IA
+	Interface uint64
+	// RTT is the lowest round trip time of the replies. It is zero if the hop
+	// did not reply.
+	RTT time.Duration
+}
+
+// SCIONProber probes the paths with SCMP echo and traceroute requests.
+type SCIONProber struct {
+	Daemon     daemon.Connector
+	Dispatcher reliable.Dispatcher
+	LocalIA    addr.IA
+	// LocalIP is the local IP address. If it is not set, it is resolved
+	// based on the next hop of the probed path.
+	LocalIP net.IP
+	// PingCount is the number of echo requests per ping.
+	PingCount int
+	// Timeout is the timeout of a single request. It is also used as the
+	// interval between the echo requests.
+	Timeout time.Duration
+	// ProbesPerHop is the number of traceroute requests per hop. If it is
+	// zero, DefaultProbesPerHop is used.
+	ProbesPerHop int
+}
+
+// Paths returns the sorted paths to the remote that match the sequence.
+func (p *SCIONProber) Paths(ctx context.Context, remote addr.IA,
+	sequence string) ([]snet.Path, error) {
+
+	all, err := p.Daemon.Paths(ctx, remote, addr.IA{}, daemon.PathReqFlags{})
+	if err != nil {
+		return nil, serrors.WrapStr("retrieving paths", err)
+	}
+	paths, err := path.Filter(sequence, all)
+	if err != nil {
+		return nil, err
+	}
+	path.Sort(paths)
+	return paths, nil
+}
+
+// Ping sends echo requests to the remote over the path.
+func (p *SCIONProber) Ping(ctx context.Context, remote *snet.UDPAddr,
+	sp snet.Path) (PingResult, error) {
+
+	local, dst, err := p.addrs(remote, sp)
+	if err != nil {
+		return PingResult{}, err
+	}
+	var res PingResult
+	stats, err := ping.Run(ctx, ping.Config{
+		Dispatcher: p.Dispatcher,
+		Attempts:   uint16(p.PingCount),
+		Interval:   p.Timeout,
+		Timeout:    p.Timeout,
+		Local:      local,
+		Remote:     dst,
+		UpdateHandler: func(u ping.Update) {
+			if u.State == ping.Success {
+				res.RTTs = append(res.RTTs, u.RTT)
+			}
+		},
+	})
+	res.Sent, res.Received = stats.Sent, stats.Received
+	return res, err
+}
+
+// Traceroute sends traceroute requests to the hops of the path. Paths within
+// the local AS have no hops.
+func (p *SCIONProber) Traceroute(ctx context.Context, remote *snet.UDPAddr,
+	sp snet.Path) ([]HopResult, error) {
+
+	intfs := sp.Metadata().Interfaces
+	if len(intfs) == 0 {
+		return nil, nil
+	}
+	local, dst, err := p.addrs(remote, sp)
+	if err != nil {
+		return nil, err
+	}
+	if dst.NextHop == nil {
+		dst.NextHop = &net.UDPAddr{IP: dst.Host.IP, Port: topology.EndhostPort}
+	}
+	probes := p.ProbesPerHop
+	if probes == 0 {
+		probes = DefaultProbesPerHop
+	}
+	var hops []HopResult
+	_, err = traceroute.Run(ctx, traceroute.Config{
+		Dispatcher:   p.Dispatcher,
+		Local:        local,
+		MTU:          sp.Metadata().MTU,
+		PathEntry:    sp,
+		Remote:       dst,
+		Timeout:      p.Timeout,
+		ProbesPerHop: probes,
+		UpdateHandler: func(u traceroute.Update) {
+			hop := HopResult{Index: u.Index, Interface: u.Interface}
+			switch {
+			case u.Remote != nil:
+				hop.IA = u.Remote.IA
+			case u.Index < len(intfs):
+				hop.IA = intfs[u.Index].IA
+				hop.Interface = uint64(intfs[u.Index].ID)
+			}
+			for _, rtt := range u.RTTs {
+				if rtt <= p.Timeout && (hop.RTT == 0 || rtt < hop.RTT) {
+					hop.RTT = rtt
+				}
+			}
+			hops = append(hops, hop)
+		},
+	})
+	return hops, err
+}
+
+// addrs returns the local address and the remote address that uses the path.
+func (p *SCIONProber) addrs(remote *snet.UDPAddr,
+	sp snet.Path) (*snet.UDPAddr, *snet.UDPAddr, error) {
+
+	dst := remote.Copy()
+	dst.Path = sp.Path()
+	dst.NextHop = sp.UnderlayNextHop()
+	localIP := p.LocalIP
+	if localIP == nil {
+		target := dst.Host.IP
+		if dst.NextHop != nil {
+			target = dst.NextHop.IP
+		}
+		var err error
+		if localIP, err = addrutil.ResolveLocal(target); err != nil {
+			return nil, nil, serrors.WrapStr("resolving local address", err)
+		}
+	}
+	local := &snet.UDPAddr{IA: p.LocalIA, Host: &net.UDPAddr{IP: localIP}}
+	return local, dst, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+// Status is the status of the monitored targets.
+type Status struct {
+	Targets []TargetStatus `json:"targets"`
+	// Events are the most recent events, oldest first.
+	Events []Event `json:"events"`
+}
+
+// TargetStatus is the status of a monitored target.
+type TargetStatus struct {
+	Name   string `json:"name"`
+	Remote string `json:"remote"`
+	// Updated is the time of the last successful path lookup.
+	Updated time.Time `json:"updated"`
+	// Error is the last error, if the last probe failed.
+	Error string       `json:"error,omitempty"`
+	Paths []PathStatus `json:"paths"`
+}
+
+// PathStatus is the status of a monitored path.
+type PathStatus struct {
+	Fingerprint string `json:"fingerprint"`
+	Path        string `json:"path"`
+	// Since is the time the path was first seen.
+	Since time.Time   `json:"since"`
+	Ping  *PingStatus `json:"ping,omitempty"`
+	Hops  []HopStatus `json:"hops,omitempty"`
+}
+
+// PingStatus is the result of the last ping over a path.
+type PingStatus struct {
+	Time     time.Time     `json:"time"`
+	Sent     int           `json:"sent"`
+	Received int           `json:"received"`
+	Loss     float64       `json:"loss"`
+	MinRTT   time.Duration `json:"min_rtt"`
+	AvgRTT   time.Duration `json:"avg_rtt"`
+	MaxRTT   time.Duration `json:"max_rtt"`
+}
+
+func newPingStatus(res PingResult, now time.Time) *PingStatus {
+	s := &PingStatus{
+		Time:     now,
+		Sent:     res.Sent,
+		Received: res.Received,
+	}
+	if res.Sent > 0 && res.Received < res.Sent {
+		s.Loss = float64(res.Sent-res.Received) / float64(res.Sent)
+	}
+	if len(res.RTTs) == 0 {
+		return s
+	}
+	var sum time.Duration
+	s.MinRTT = res.RTTs[0]
+	for _, rtt := range res.RTTs {
+		sum += rtt
+		if rtt < s.MinRTT {
+			s.MinRTT = rtt
+		}
+		if rtt > s.MaxRTT {
+			s.MaxRTT = rtt
+		}
+	}
+	s.AvgRTT = sum / time.Duration(len(res.RTTs))
+	return s
+}
+
+// HopStatus is the result of the last traceroute of a hop.
+type HopStatus struct {
+	Index     int    `json:"index"`
+	IA        string `json:"isd_as"`
+	Interface uint64 `json:"interface"`
+	// RTT is the lowest round trip time of the last traceroute. It is zero if
+	// the hop did not reply.
+	RTT time.Duration `json:"rtt"`
+	// Baseline is the moving average of the round trip times that latency
+	// shifts are detected against.
+	Baseline time.Duration `json:"baseline"`
+}
+
+// Status returns the current status.
+func (m *Monitor) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := Status{
+		Targets: make([]TargetStatus, 0, len(m.targets)),
+		Events:  append([]Event{}, m.events...),
+	}
+	for _, t := range m.targets {
+		ts := TargetStatus{
+			Name:    t.Name,
+			Remote:  t.remote.String(),
+			Updated: t.updated,
+			Error:   t.err,
+			Paths:   make([]PathStatus, 0, len(t.order)),
+		}
+		for _, fp := range t.order {
+			ps := t.paths[fp]
+			status := PathStatus{
+				Fingerprint: ps.label,
+				Path:        fmt.Sprint(ps.path),
+				Since:       ps.since,
+				Hops:        append([]HopStatus(nil), ps.hops...),
+			}
+			if ps.ping != nil {
+				ping := *ps.ping
+				status.Ping = &ping
+			}
+			ts.Paths = append(ts.Paths, status)
+		}
+		s.Targets = append(s.Targets, ts)
+	}
+	return s
+}
+
+// ServeStatus writes the current status as JSON.
+func (m *Monitor) ServeStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(m.Status()); err != nil {
+		log.Info("Writing status failed", "err", err)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netcat implements the building blocks of a path-aware netcat on top
+// of SCION. It provides a packet connection that sends all packets over a path
+// that can be switched at runtime, and an input loop that interprets path
+// commands embedded in the input stream.
+package netcat
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// Conn is a net.PacketConn on top of a SCION packet connection that controls
+// the path of the outgoing packets.
+//
+// If a path is selected with SetPath, all packets are sent over that path,
+// regardless of the path in the destination address. Otherwise, packets to a
+// remote are sent over the reverse of the path of the most recently received
+// packet from that remote. This allows the listening side to follow path
+// switches of the connecting side. If no packet has been received from the
+// remote yet, the path in the destination address is used.
+//
+// Conn can be used as the underlying connection of QUIC, in which case the
+// path of the QUIC session can be switched without the QUIC layer noticing.
+type Conn struct {
+	net.PacketConn
+
+	mu   sync.Mutex
+	path snet.Path
+	// replies contains the most recent reply address per remote.
+	replies map[string]*snet.UDPAddr
+}
+
+// NewConn wraps the SCION packet connection. The connection must read and
+// write *snet.UDPAddr addresses, e.g., a connection created with
+// snet.SCIONNetwork.
+func NewConn(conn net.PacketConn) *Conn {
+	return &Conn{
+		PacketConn: conn,
+		replies:    make(map[string]*snet.UDPAddr),
+	}
+}
+
+// SetPath sets the path that is used for all outgoing packets. If path is nil,
+// the packets are sent over the reverse path of the received packets.
+func (c *Conn) SetPath(path snet.Path) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.path = path
+}
+
+// Path returns the currently selected path, or nil if no path is selected.
+func (c *Conn) Path() snet.Path {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.path
+}
+
+// ReadFrom reads a packet from the connection and records its reverse path.
+func (c *Conn) ReadFrom(b []byte) (int, net.Addr, error) {
+	n, a, err := c.PacketConn.ReadFrom(b)
+	if err != nil {
+		return n, a, err
+	}
+	if remote, ok := a.(*snet.UDPAddr); ok {
+		c.mu.Lock()
+		c.replies[remoteKey(remote)] = remote.Copy()
+		c.mu.Unlock()
+	}
+	return n, a, nil
+}
+
+// WriteTo writes a packet to the remote over the selected path.
+func (c *Conn) WriteTo(b []byte, a net.Addr) (int, error) {
+	remote, ok := a.(*snet.UDPAddr)
+	if !ok {
+		return 0, serrors.New("unsupported address type", "type", fmt.Sprintf("%T", a))
+	}
+	remote = remote.Copy()
+	c.mu.Lock()
+	if c.path != nil {
+		remote.Path = c.path.Path()
+		remote.NextHop = c.path.UnderlayNextHop()
+	} else if reply, ok := c.replies[remoteKey(remote)]; ok {
+		remote.Path = reply.Path.Copy()
+		remote.NextHop = snet.CopyUDPAddr(reply.NextHop)
+	}
+	c.mu.Unlock()
+	return c.PacketConn.WriteTo(b, remote)
+}
+
+func remoteKey(a *snet.UDPAddr) string {
+	return fmt.Sprintf("%s,%s", a.IA, a.Host)
+}
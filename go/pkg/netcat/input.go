@@ -0,0 +1,148 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netcat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// CommandPrefix is the prefix of the lines in the input that are interpreted
+// as commands. A line starting with two prefixes is sent with one prefix
+// removed.
+const CommandPrefix = "~"
+
+// CommandHelp describes the commands that are supported in the input.
+const CommandHelp = `Lines starting with '~' are interpreted as commands:
+  ~paths     list the available paths, the active path is marked with '*'
+  ~path <n>  send all further data over the path with index n
+  ~~<text>   send '~<text>'`
+
+// Commands handles the path commands in the input.
+type Commands struct {
+	// Conn is the connection on which the path is switched.
+	Conn *Conn
+	// Paths are the paths that can be selected.
+	Paths []snet.Path
+	// Output receives the feedback of the commands, e.g., the list of paths.
+	Output io.Writer
+}
+
+// handle handles the command line, without the prefix.
+func (c *Commands) handle(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return serrors.New("empty command")
+	}
+	switch fields[0] {
+	case "paths":
+		current := c.Conn.Path()
+		for i, p := range c.Paths {
+			marker := " "
+			if current != nil && snet.Fingerprint(current) == snet.Fingerprint(p) {
+				marker = "*"
+			}
+			fmt.Fprintf(c.Output, "%s[%2d] %s\n", marker, i, p)
+		}
+		return nil
+	case "path":
+		if len(fields) != 2 {
+			return serrors.New("usage: ~path <n>")
+		}
+		idx, err := strconv.Atoi(fields[1])
+		if err != nil || idx < 0 || idx >= len(c.Paths) {
+			return serrors.New("path index outside of valid range",
+				"index", fields[1], "max", len(c.Paths)-1)
+		}
+		c.Conn.SetPath(c.Paths[idx])
+		fmt.Fprintf(c.Output, "Using path:\n  %s\n", c.Paths[idx])
+		return nil
+	default:
+		return serrors.New("unknown command", "command", fields[0])
+	}
+}
+
+// CopyInput copies the input to the output. If cmds is not nil, lines that
+// start with the CommandPrefix are interpreted as commands instead of being
+// copied. Errors of the commands are reported to the command output, and do
+// not abort the copy.
+func CopyInput(dst io.Writer, src io.Reader, cmds *Commands) error {
+	if cmds == nil {
+		_, err := io.Copy(dst, src)
+		return err
+	}
+	r := bufio.NewReader(src)
+	for {
+		line, err := r.ReadString('\n')
+		if len(line) > 0 {
+			if werr := copyLine(dst, line, cmds); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func copyLine(dst io.Writer, line string, cmds *Commands) error {
+	switch {
+	case strings.HasPrefix(line, CommandPrefix+CommandPrefix):
+		line = line[len(CommandPrefix):]
+	case strings.HasPrefix(line, CommandPrefix):
+		if err := cmds.handle(strings.TrimPrefix(line, CommandPrefix)); err != nil {
+			fmt.Fprintf(cmds.Output, "ERROR: %s\n", err)
+		}
+		return nil
+	}
+	_, err := io.WriteString(dst, line)
+	return err
+}
+
+// PacketWriter is an io.Writer that sends the written data as datagrams to a
+// fixed remote. Writes that are larger than the maximum payload size are split
+// into multiple datagrams.
+type PacketWriter struct {
+	Conn   net.PacketConn
+	Remote net.Addr
+	// MaxPayload is the maximum payload size of a datagram.
+	MaxPayload int
+}
+
+func (w PacketWriter) Write(b []byte) (int, error) {
+	var written int
+	for len(b) > 0 {
+		n := len(b)
+		if n > w.MaxPayload {
+			n = w.MaxPayload
+		}
+		if _, err := w.Conn.WriteTo(b[:n], w.Remote); err != nil {
+			return written, err
+		}
+		written += n
+		b = b[n:]
+	}
+	return written, nil
+}
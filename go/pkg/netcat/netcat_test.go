@@ -0,0 +1,150 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netcat_test
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/netcat"
+)
+
+func TestConn(t *testing.T) {
+	remote := &snet.UDPAddr{
+		IA:      xtest.MustParseIA("1-ff00:0:110"),
+		Host:    &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4000},
+		Path:    spath.Path{Raw: []byte{1}},
+		NextHop: &net.UDPAddr{IP: net.ParseIP("192.168.0.1"), Port: 30041},
+	}
+	reply := remote.Copy()
+	reply.Path = spath.Path{Raw: []byte{2}}
+	reply.NextHop = &net.UDPAddr{IP: net.ParseIP("192.168.0.2"), Port: 30041}
+	selected := newTestPath(3)
+
+	pconn := &recordingConn{reads: []*snet.UDPAddr{reply}}
+	conn := netcat.NewConn(pconn)
+
+	// Without any information, the path of the destination is used.
+	_, err := conn.WriteTo([]byte("a"), remote)
+	require.NoError(t, err)
+	assert.Equal(t, remote, pconn.last())
+
+	// After receiving a packet, the reply path is used.
+	_, _, err = conn.ReadFrom(make([]byte, 10))
+	require.NoError(t, err)
+	_, err = conn.WriteTo([]byte("b"), remote)
+	require.NoError(t, err)
+	assert.Equal(t, reply, pconn.last())
+
+	// The selected path has precedence.
+	conn.SetPath(selected)
+	_, err = conn.WriteTo([]byte("c"), remote)
+	require.NoError(t, err)
+	assert.Equal(t, selected.SPath, pconn.last().Path)
+	assert.Equal(t, selected.NextHop, pconn.last().NextHop)
+
+	// The destination address is not modified.
+	assert.Equal(t, spath.Path{Raw: []byte{1}}, remote.Path)
+}
+
+func TestCopyInput(t *testing.T) {
+	paths := []snet.Path{newTestPath(1), newTestPath(2)}
+	input := strings.Join([]string{
+		"hello",
+		"~path 1",
+		"~~tilde",
+		"~path 7",
+		"~bogus",
+		"~paths",
+		"no newline",
+	}, "\n")
+
+	var dst, out bytes.Buffer
+	conn := netcat.NewConn(&recordingConn{})
+	err := netcat.CopyInput(&dst, strings.NewReader(input), &netcat.Commands{
+		Conn:   conn,
+		Paths:  paths,
+		Output: &out,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n~tilde\nno newline", dst.String())
+	assert.Equal(t, paths[1], conn.Path())
+	assert.Contains(t, out.String(), "path index outside of valid range")
+	assert.Contains(t, out.String(), "unknown command")
+	assert.Contains(t, out.String(), "*[ 1]")
+
+	// Without commands, the input is copied verbatim.
+	dst.Reset()
+	require.NoError(t, netcat.CopyInput(&dst, strings.NewReader(input), nil))
+	assert.Equal(t, input, dst.String())
+}
+
+func TestPacketWriter(t *testing.T) {
+	pconn := &recordingConn{}
+	w := netcat.PacketWriter{Conn: pconn, Remote: &snet.UDPAddr{}, MaxPayload: 4}
+	n, err := w.Write([]byte("0123456789"))
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+	assert.Equal(t, []string{"0123", "4567", "89"}, pconn.payloads)
+}
+
+func newTestPath(id byte) snetpath.Path {
+	return snetpath.Path{
+		Dst:     xtest.MustParseIA("1-ff00:0:110"),
+		SPath:   spath.Path{Raw: []byte{id}},
+		NextHop: &net.UDPAddr{IP: net.IPv4(192, 168, 1, id), Port: 30041},
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: xtest.MustParseIA("1-ff00:0:111"), ID: 1},
+				{IA: xtest.MustParseIA("1-ff00:0:110"), ID: common.IFIDType(id)},
+			},
+		},
+	}
+}
+
+// recordingConn records the written packets, and returns the configured
+// addresses on reads.
+type recordingConn struct {
+	net.PacketConn
+	reads    []*snet.UDPAddr
+	writes   []*snet.UDPAddr
+	payloads []string
+}
+
+func (c *recordingConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	a := c.reads[0]
+	c.reads = c.reads[1:]
+	return 0, a, nil
+}
+
+func (c *recordingConn) WriteTo(b []byte, a net.Addr) (int, error) {
+	c.writes = append(c.writes, a.(*snet.UDPAddr))
+	c.payloads = append(c.payloads, string(b))
+	return len(b), nil
+}
+
+func (c *recordingConn) last() *snet.UDPAddr {
+	return c.writes[len(c.writes)-1]
+}
@@ -409,6 +409,234 @@ func (x *AuthoritativeHiddenSegmentsResponse) GetSegments() map[int32]*Segments
 	return nil
 }
 
+type HiddenPathGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SignedRequest *crypto.SignedMessage `protobuf:"bytes,1,opt,name=signed_request,json=signedRequest,proto3" json:"signed_request,omitempty"`
+}
+
+func (x *HiddenPathGroupRequest) Reset() {
+	*x = HiddenPathGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathGroupRequest) ProtoMessage() {}
+
+func (x *HiddenPathGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathGroupRequest.ProtoReflect.Descriptor instead.
+func (*HiddenPathGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HiddenPathGroupRequest) GetSignedRequest() *crypto.SignedMessage {
+	if x != nil {
+		return x.SignedRequest
+	}
+	return nil
+}
+
+type HiddenPathGroupRequestBody struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId uint64 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+}
+
+func (x *HiddenPathGroupRequestBody) Reset() {
+	*x = HiddenPathGroupRequestBody{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathGroupRequestBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathGroupRequestBody) ProtoMessage() {}
+
+func (x *HiddenPathGroupRequestBody) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathGroupRequestBody.ProtoReflect.Descriptor instead.
+func (*HiddenPathGroupRequestBody) Descriptor() ([]byte, []int) {
+	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HiddenPathGroupRequestBody) GetGroupId() uint64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+type HiddenPathGroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SignedGroup *crypto.SignedMessage `protobuf:"bytes,1,opt,name=signed_group,json=signedGroup,proto3" json:"signed_group,omitempty"`
+}
+
+func (x *HiddenPathGroupResponse) Reset() {
+	*x = HiddenPathGroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathGroupResponse) ProtoMessage() {}
+
+func (x *HiddenPathGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathGroupResponse.ProtoReflect.Descriptor instead.
+func (*HiddenPathGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *HiddenPathGroupResponse) GetSignedGroup() *crypto.SignedMessage {
+	if x != nil {
+		return x.SignedGroup
+	}
+	return nil
+}
+
+type HiddenPathGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId    uint64   `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Version    uint64   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	OwnerIsdAs uint64   `protobuf:"varint,3,opt,name=owner_isd_as,json=ownerIsdAs,proto3" json:"owner_isd_as,omitempty"`
+	Writers    []uint64 `protobuf:"varint,4,rep,packed,name=writers,proto3" json:"writers,omitempty"`
+	Readers    []uint64 `protobuf:"varint,5,rep,packed,name=readers,proto3" json:"readers,omitempty"`
+	Registries []uint64 `protobuf:"varint,6,rep,packed,name=registries,proto3" json:"registries,omitempty"`
+}
+
+func (x *HiddenPathGroup) Reset() {
+	*x = HiddenPathGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathGroup) ProtoMessage() {}
+
+func (x *HiddenPathGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathGroup.ProtoReflect.Descriptor instead.
+func (*HiddenPathGroup) Descriptor() ([]byte, []int) {
+	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HiddenPathGroup) GetGroupId() uint64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *HiddenPathGroup) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *HiddenPathGroup) GetOwnerIsdAs() uint64 {
+	if x != nil {
+		return x.OwnerIsdAs
+	}
+	return 0
+}
+
+func (x *HiddenPathGroup) GetWriters() []uint64 {
+	if x != nil {
+		return x.Writers
+	}
+	return nil
+}
+
+func (x *HiddenPathGroup) GetReaders() []uint64 {
+	if x != nil {
+		return x.Readers
+	}
+	return nil
+}
+
+func (x *HiddenPathGroup) GetRegistries() []uint64 {
+	if x != nil {
+		return x.Registries
+	}
+	return nil
+}
+
 var File_proto_hidden_segment_v1_hidden_segment_proto protoreflect.FileDescriptor
 
 var file_proto_hidden_segment_v1_hidden_segment_proto_rawDesc = []byte{
@@ -491,45 +719,81 @@ var file_proto_hidden_segment_v1_hidden_segment_proto_rawDesc = []byte{
 	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e,
 	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0xb9, 0x01, 0x0a, 0x20, 0x48, 0x69, 0x64,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5f, 0x0a, 0x16, 0x48, 0x69, 0x64, 0x64,
+	0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x45, 0x0a, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x37, 0x0a, 0x1a, 0x48, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x49, 0x64, 0x22, 0x5c, 0x0a, 0x17, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a,
+	0x0c, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x22, 0xbc, 0x01, 0x0a, 0x0f, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0c, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77,
+	0x72, 0x69, 0x74, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x04, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x69, 0x65, 0x73, 0x32,
+	0xb9, 0x01, 0x0a, 0x20, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x94, 0x01, 0x0a, 0x19, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x39, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64,
 	0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x94, 0x01,
-	0x0a, 0x19, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x2e, 0x70, 0x72,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3a, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x91, 0x01, 0x0a, 0x1a,
+	0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x6f,
+	0x6b, 0x75, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x73, 0x0a, 0x0e, 0x48, 0x69,
+	0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32,
+	0xc6, 0x01, 0x0a, 0x27, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f,
+	0x6f, 0x6b, 0x75, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x9a, 0x01, 0x0a, 0x1b,
+	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x3b, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68,
-	0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x32, 0x91, 0x01, 0x0a, 0x1a, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x73, 0x0a, 0x0e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69,
-	0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74,
+	0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65,
 	0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69,
-	0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
-	0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xc6, 0x01, 0x0a, 0x27, 0x41, 0x75, 0x74,
-	0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
-	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x9a, 0x01, 0x0a, 0x1b, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
-	0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x12, 0x3b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64,
-	0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64,
-	0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x3c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e,
-	0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e,
-	0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x68, 0x69,
-	0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x90, 0x01, 0x0a, 0x16, 0x48, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x76, 0x0a, 0x0f, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74,
+	0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68,
+	0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x39, 0x5a, 0x37, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -544,7 +808,7 @@ func file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP() []byte {
 	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescData
 }
 
-var file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_proto_hidden_segment_v1_hidden_segment_proto_goTypes = []interface{}{
 	(*Segments)(nil),                             // 0: proto.hidden_segment.v1.Segments
 	(*HiddenSegmentRegistrationRequest)(nil),     // 1: proto.hidden_segment.v1.HiddenSegmentRegistrationRequest
@@ -554,33 +818,41 @@ var file_proto_hidden_segment_v1_hidden_segment_proto_goTypes = []interface{}{
 	(*HiddenSegmentsResponse)(nil),               // 5: proto.hidden_segment.v1.HiddenSegmentsResponse
 	(*AuthoritativeHiddenSegmentsRequest)(nil),   // 6: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest
 	(*AuthoritativeHiddenSegmentsResponse)(nil),  // 7: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse
-	nil,                               // 8: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry
-	nil,                               // 9: proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry
-	nil,                               // 10: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry
-	(*control_plane.PathSegment)(nil), // 11: proto.control_plane.v1.PathSegment
-	(*crypto.SignedMessage)(nil),      // 12: proto.crypto.v1.SignedMessage
+	(*HiddenPathGroupRequest)(nil),               // 8: proto.hidden_segment.v1.HiddenPathGroupRequest
+	(*HiddenPathGroupRequestBody)(nil),           // 9: proto.hidden_segment.v1.HiddenPathGroupRequestBody
+	(*HiddenPathGroupResponse)(nil),              // 10: proto.hidden_segment.v1.HiddenPathGroupResponse
+	(*HiddenPathGroup)(nil),                      // 11: proto.hidden_segment.v1.HiddenPathGroup
+	nil,                                          // 12: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry
+	nil,                                          // 13: proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry
+	nil,                                          // 14: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry
+	(*control_plane.PathSegment)(nil),            // 15: proto.control_plane.v1.PathSegment
+	(*crypto.SignedMessage)(nil),                 // 16: proto.crypto.v1.SignedMessage
 }
 var file_proto_hidden_segment_v1_hidden_segment_proto_depIdxs = []int32{
-	11, // 0: proto.hidden_segment.v1.Segments.segments:type_name -> proto.control_plane.v1.PathSegment
-	12, // 1: proto.hidden_segment.v1.HiddenSegmentRegistrationRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
-	8,  // 2: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.segments:type_name -> proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry
-	9,  // 3: proto.hidden_segment.v1.HiddenSegmentsResponse.segments:type_name -> proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry
-	12, // 4: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
-	10, // 5: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.segments:type_name -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry
-	0,  // 6: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
-	0,  // 7: proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
-	0,  // 8: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
-	1,  // 9: proto.hidden_segment.v1.HiddenSegmentRegistrationService.HiddenSegmentRegistration:input_type -> proto.hidden_segment.v1.HiddenSegmentRegistrationRequest
-	4,  // 10: proto.hidden_segment.v1.HiddenSegmentLookupService.HiddenSegments:input_type -> proto.hidden_segment.v1.HiddenSegmentsRequest
-	6,  // 11: proto.hidden_segment.v1.AuthoritativeHiddenSegmentLookupService.AuthoritativeHiddenSegments:input_type -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest
-	3,  // 12: proto.hidden_segment.v1.HiddenSegmentRegistrationService.HiddenSegmentRegistration:output_type -> proto.hidden_segment.v1.HiddenSegmentRegistrationResponse
-	5,  // 13: proto.hidden_segment.v1.HiddenSegmentLookupService.HiddenSegments:output_type -> proto.hidden_segment.v1.HiddenSegmentsResponse
-	7,  // 14: proto.hidden_segment.v1.AuthoritativeHiddenSegmentLookupService.AuthoritativeHiddenSegments:output_type -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse
-	12, // [12:15] is the sub-list for method output_type
-	9,  // [9:12] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	15, // 0: proto.hidden_segment.v1.Segments.segments:type_name -> proto.control_plane.v1.PathSegment
+	16, // 1: proto.hidden_segment.v1.HiddenSegmentRegistrationRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
+	12, // 2: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.segments:type_name -> proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry
+	13, // 3: proto.hidden_segment.v1.HiddenSegmentsResponse.segments:type_name -> proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry
+	16, // 4: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
+	14, // 5: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.segments:type_name -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry
+	16, // 6: proto.hidden_segment.v1.HiddenPathGroupRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
+	16, // 7: proto.hidden_segment.v1.HiddenPathGroupResponse.signed_group:type_name -> proto.crypto.v1.SignedMessage
+	0,  // 8: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
+	0,  // 9: proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
+	0,  // 10: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
+	1,  // 11: proto.hidden_segment.v1.HiddenSegmentRegistrationService.HiddenSegmentRegistration:input_type -> proto.hidden_segment.v1.HiddenSegmentRegistrationRequest
+	4,  // 12: proto.hidden_segment.v1.HiddenSegmentLookupService.HiddenSegments:input_type -> proto.hidden_segment.v1.HiddenSegmentsRequest
+	6,  // 13: proto.hidden_segment.v1.AuthoritativeHiddenSegmentLookupService.AuthoritativeHiddenSegments:input_type -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest
+	8,  // 14: proto.hidden_segment.v1.HiddenPathGroupService.HiddenPathGroup:input_type -> proto.hidden_segment.v1.HiddenPathGroupRequest
+	3,  // 15: proto.hidden_segment.v1.HiddenSegmentRegistrationService.HiddenSegmentRegistration:output_type -> proto.hidden_segment.v1.HiddenSegmentRegistrationResponse
+	5,  // 16: proto.hidden_segment.v1.HiddenSegmentLookupService.HiddenSegments:output_type -> proto.hidden_segment.v1.HiddenSegmentsResponse
+	7,  // 17: proto.hidden_segment.v1.AuthoritativeHiddenSegmentLookupService.AuthoritativeHiddenSegments:output_type -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse
+	10, // 18: proto.hidden_segment.v1.HiddenPathGroupService.HiddenPathGroup:output_type -> proto.hidden_segment.v1.HiddenPathGroupResponse
+	15, // [15:19] is the sub-list for method output_type
+	11, // [11:15] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_proto_hidden_segment_v1_hidden_segment_proto_init() }
@@ -685,6 +957,54 @@ func file_proto_hidden_segment_v1_hidden_segment_proto_init() {
 				return nil
 			}
 		}
+		file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathGroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathGroupRequestBody); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathGroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -692,9 +1012,9 @@ func file_proto_hidden_segment_v1_hidden_segment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_hidden_segment_v1_hidden_segment_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   15,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   4,
 		},
 		GoTypes:           file_proto_hidden_segment_v1_hidden_segment_proto_goTypes,
 		DependencyIndexes: file_proto_hidden_segment_v1_hidden_segment_proto_depIdxs,
@@ -929,3 +1249,75 @@ var _AuthoritativeHiddenSegmentLookupService_serviceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/hidden_segment/v1/hidden_segment.proto",
 }
+
+// HiddenPathGroupServiceClient is the client API for HiddenPathGroupService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HiddenPathGroupServiceClient interface {
+	HiddenPathGroup(ctx context.Context, in *HiddenPathGroupRequest, opts ...grpc.CallOption) (*HiddenPathGroupResponse, error)
+}
+
+type hiddenPathGroupServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHiddenPathGroupServiceClient(cc grpc.ClientConnInterface) HiddenPathGroupServiceClient {
+	return &hiddenPathGroupServiceClient{cc}
+}
+
+func (c *hiddenPathGroupServiceClient) HiddenPathGroup(ctx context.Context, in *HiddenPathGroupRequest, opts ...grpc.CallOption) (*HiddenPathGroupResponse, error) {
+	out := new(HiddenPathGroupResponse)
+	err := c.cc.Invoke(ctx, "/proto.hidden_segment.v1.HiddenPathGroupService/HiddenPathGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HiddenPathGroupServiceServer is the server API for HiddenPathGroupService service.
+type HiddenPathGroupServiceServer interface {
+	HiddenPathGroup(context.Context, *HiddenPathGroupRequest) (*HiddenPathGroupResponse, error)
+}
+
+// UnimplementedHiddenPathGroupServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedHiddenPathGroupServiceServer struct {
+}
+
+func (*UnimplementedHiddenPathGroupServiceServer) HiddenPathGroup(context.Context, *HiddenPathGroupRequest) (*HiddenPathGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HiddenPathGroup not implemented")
+}
+
+func RegisterHiddenPathGroupServiceServer(s *grpc.Server, srv HiddenPathGroupServiceServer) {
+	s.RegisterService(&_HiddenPathGroupService_serviceDesc, srv)
+}
+
+func _HiddenPathGroupService_HiddenPathGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HiddenPathGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HiddenPathGroupServiceServer).HiddenPathGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.hidden_segment.v1.HiddenPathGroupService/HiddenPathGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HiddenPathGroupServiceServer).HiddenPathGroup(ctx, req.(*HiddenPathGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _HiddenPathGroupService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.hidden_segment.v1.HiddenPathGroupService",
+	HandlerType: (*HiddenPathGroupServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "HiddenPathGroup",
+			Handler:    _HiddenPathGroupService_HiddenPathGroup_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/hidden_segment/v1/hidden_segment.proto",
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/go/pkg/proto/hidden_segment (interfaces: AuthoritativeHiddenSegmentLookupServiceServer,HiddenSegmentRegistrationServiceServer,HiddenSegmentLookupServiceServer)
+// Source: github.com/scionproto/scion/go/pkg/proto/hidden_segment (interfaces: AuthoritativeHiddenSegmentLookupServiceServer,HiddenSegmentRegistrationServiceServer,HiddenSegmentLookupServiceServer,HiddenPathGroupServiceServer)
 
 // Package mock_hidden_segment is a generated GoMock package.
 package mock_hidden_segment
@@ -125,3 +125,41 @@ func (mr *MockHiddenSegmentLookupServiceServerMockRecorder) HiddenSegments(arg0,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HiddenSegments", reflect.TypeOf((*MockHiddenSegmentLookupServiceServer)(nil).HiddenSegments), arg0, arg1)
 }
+
+// MockHiddenPathGroupServiceServer is a mock of HiddenPathGroupServiceServer interface.
+type MockHiddenPathGroupServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockHiddenPathGroupServiceServerMockRecorder
+}
+
+// MockHiddenPathGroupServiceServerMockRecorder is the mock recorder for MockHiddenPathGroupServiceServer.
+type MockHiddenPathGroupServiceServerMockRecorder struct {
+	mock *MockHiddenPathGroupServiceServer
+}
+
+// NewMockHiddenPathGroupServiceServer creates a new mock instance.
+func NewMockHiddenPathGroupServiceServer(ctrl *gomock.Controller) *MockHiddenPathGroupServiceServer {
+	mock := &MockHiddenPathGroupServiceServer{ctrl: ctrl}
+	mock.recorder = &MockHiddenPathGroupServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHiddenPathGroupServiceServer) EXPECT() *MockHiddenPathGroupServiceServerMockRecorder {
+	return m.recorder
+}
+
+// HiddenPathGroup mocks base method.
+func (m *MockHiddenPathGroupServiceServer) HiddenPathGroup(arg0 context.Context, arg1 *hidden_segment.HiddenPathGroupRequest) (*hidden_segment.HiddenPathGroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HiddenPathGroup", arg0, arg1)
+	ret0, _ := ret[0].(*hidden_segment.HiddenPathGroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HiddenPathGroup indicates an expected call of HiddenPathGroup.
+func (mr *MockHiddenPathGroupServiceServerMockRecorder) HiddenPathGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HiddenPathGroup", reflect.TypeOf((*MockHiddenPathGroupServiceServer)(nil).HiddenPathGroup), arg0, arg1)
+}
@@ -0,0 +1,152 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/router/control"
+)
+
+// adminState is the administrative state of an external interface. It can be
+// changed while the data plane is running.
+type adminState struct {
+	// isDown is 1 if the interface is out of service, 0 otherwise. It is
+	// accessed atomically, such that the packet processing neither needs to
+	// acquire the lock nor to read the clock.
+	isDown int32
+
+	mtx   sync.Mutex
+	state control.InterfaceAdminState
+	// timer takes a draining interface down at the drain deadline.
+	timer *time.Timer
+}
+
+func newAdminState() *adminState {
+	return &adminState{
+		state: control.InterfaceAdminState{State: control.AdminUp},
+	}
+}
+
+// down indicates whether the interface is out of service.
+func (s *adminState) down() bool {
+	return atomic.LoadInt32(&s.isDown) == 1
+}
+
+// set sets the administrative state. A draining interface is taken down after
+// the grace period.
+func (s *adminState) set(state control.AdminState, gracePeriod time.Duration,
+	now time.Time) (control.InterfaceAdminState, error) {
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	switch state {
+	case control.AdminUp:
+		s.stopTimerLocked()
+		s.state = control.InterfaceAdminState{State: control.AdminUp}
+		atomic.StoreInt32(&s.isDown, 0)
+	case control.AdminDown:
+		s.stopTimerLocked()
+		s.state = control.InterfaceAdminState{State: control.AdminDown}
+		atomic.StoreInt32(&s.isDown, 1)
+	case control.AdminDraining:
+		if gracePeriod < 0 {
+			return control.InterfaceAdminState{},
+				serrors.New("grace period must not be negative", "grace_period", gracePeriod)
+		}
+		// Draining an interface that is already draining or down does not
+		// extend its service.
+		if s.stateLocked(now).State != control.AdminUp {
+			return s.stateLocked(now), nil
+		}
+		deadline := now.Add(gracePeriod)
+		s.state = control.InterfaceAdminState{
+			State:         control.AdminDraining,
+			DrainDeadline: deadline,
+		}
+		if gracePeriod == 0 {
+			atomic.StoreInt32(&s.isDown, 1)
+			break
+		}
+		s.timer = time.AfterFunc(gracePeriod, func() { s.drained(deadline) })
+	default:
+		return control.InterfaceAdminState{},
+			serrors.New("unknown administrative state", "state", state)
+	}
+	return s.stateLocked(now), nil
+}
+
+// drained takes the interface down if it is still draining with the given
+// deadline.
+func (s *adminState) drained(deadline time.Time) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.state.State == control.AdminDraining && s.state.DrainDeadline.Equal(deadline) {
+		atomic.StoreInt32(&s.isDown, 1)
+	}
+}
+
+func (s *adminState) stopTimerLocked() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
+
+// get returns the administrative state at the given point in time.
+func (s *adminState) get(now time.Time) control.InterfaceAdminState {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.stateLocked(now)
+}
+
+func (s *adminState) stateLocked(now time.Time) control.InterfaceAdminState {
+	if s.state.State == control.AdminDraining && !now.Before(s.state.DrainDeadline) {
+		return control.InterfaceAdminState{State: control.AdminDown}
+	}
+	return s.state
+}
+
+// setInterfaceAdminState sets the administrative state of the external
+// interface. A draining interface keeps forwarding traffic for the grace
+// period and is taken down afterwards.
+func (d *DataPlane) setInterfaceAdminState(ifID uint16, state control.AdminState,
+	gracePeriod time.Duration) (control.InterfaceAdminState, error) {
+
+	d.mtx.Lock()
+	s, ok := d.adminStates[ifID]
+	d.mtx.Unlock()
+	if !ok {
+		return control.InterfaceAdminState{},
+			serrors.WithCtx(control.ErrInterfaceNotFound, "interface_id", ifID)
+	}
+	return s.set(state, gracePeriod, time.Now())
+}
+
+// getInterfaceAdminState returns the administrative state of the external
+// interface.
+func (d *DataPlane) getInterfaceAdminState(ifID uint16) control.InterfaceAdminState {
+	d.mtx.Lock()
+	s, ok := d.adminStates[ifID]
+	d.mtx.Unlock()
+	if !ok {
+		return control.InterfaceAdminState{State: control.AdminUp}
+	}
+	return s.get(time.Now())
+}
@@ -0,0 +1,147 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/control"
+)
+
+func TestAdminState(t *testing.T) {
+	now := time.Now()
+	deadline := now.Add(time.Minute)
+
+	testCases := map[string]struct {
+		Prepare     func(t *testing.T, s *router.AdminState)
+		State       control.AdminState
+		GracePeriod time.Duration
+		AssertErr   assert.ErrorAssertionFunc
+		Expected    control.InterfaceAdminState
+		Down        bool
+	}{
+		"up": {
+			State:     control.AdminUp,
+			AssertErr: assert.NoError,
+			Expected:  control.InterfaceAdminState{State: control.AdminUp},
+		},
+		"down": {
+			State:     control.AdminDown,
+			AssertErr: assert.NoError,
+			Expected:  control.InterfaceAdminState{State: control.AdminDown},
+			Down:      true,
+		},
+		"draining": {
+			State:       control.AdminDraining,
+			GracePeriod: time.Minute,
+			AssertErr:   assert.NoError,
+			Expected: control.InterfaceAdminState{
+				State:         control.AdminDraining,
+				DrainDeadline: deadline,
+			},
+		},
+		"draining without grace period": {
+			State:     control.AdminDraining,
+			AssertErr: assert.NoError,
+			Expected:  control.InterfaceAdminState{State: control.AdminDown},
+			Down:      true,
+		},
+		"draining does not extend drain": {
+			Prepare: func(t *testing.T, s *router.AdminState) {
+				_, err := s.Set(control.AdminDraining, time.Minute, now)
+				require.NoError(t, err)
+			},
+			State:       control.AdminDraining,
+			GracePeriod: time.Hour,
+			AssertErr:   assert.NoError,
+			Expected: control.InterfaceAdminState{
+				State:         control.AdminDraining,
+				DrainDeadline: deadline,
+			},
+		},
+		"draining does not revive down": {
+			Prepare: func(t *testing.T, s *router.AdminState) {
+				_, err := s.Set(control.AdminDown, 0, now)
+				require.NoError(t, err)
+			},
+			State:       control.AdminDraining,
+			GracePeriod: time.Hour,
+			AssertErr:   assert.NoError,
+			Expected:    control.InterfaceAdminState{State: control.AdminDown},
+			Down:        true,
+		},
+		"up after down": {
+			Prepare: func(t *testing.T, s *router.AdminState) {
+				_, err := s.Set(control.AdminDown, 0, now)
+				require.NoError(t, err)
+			},
+			State:     control.AdminUp,
+			AssertErr: assert.NoError,
+			Expected:  control.InterfaceAdminState{State: control.AdminUp},
+		},
+		"negative grace period": {
+			State:       control.AdminDraining,
+			GracePeriod: -time.Second,
+			AssertErr:   assert.Error,
+			Expected:    control.InterfaceAdminState{State: control.AdminUp},
+		},
+		"unknown state": {
+			State:     "maintenance",
+			AssertErr: assert.Error,
+			Expected:  control.InterfaceAdminState{State: control.AdminUp},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			s := router.NewAdminState()
+			if tc.Prepare != nil {
+				tc.Prepare(t, s)
+			}
+			_, err := s.Set(tc.State, tc.GracePeriod, now)
+			tc.AssertErr(t, err)
+			assert.Equal(t, tc.Expected, s.Get(now))
+			assert.Equal(t, tc.Down, s.Down())
+		})
+	}
+}
+
+func TestAdminStateDrainCompletes(t *testing.T) {
+	now := time.Now()
+	s := router.NewAdminState()
+	_, err := s.Set(control.AdminDraining, 10*time.Millisecond, now)
+	require.NoError(t, err)
+	assert.False(t, s.Down())
+	assert.Eventually(t, s.Down, time.Second, time.Millisecond)
+	assert.Equal(t, control.InterfaceAdminState{State: control.AdminDown},
+		s.Get(now.Add(10*time.Millisecond)))
+}
+
+func TestAdminStateUpCancelsDrain(t *testing.T) {
+	now := time.Now()
+	s := router.NewAdminState()
+	_, err := s.Set(control.AdminDraining, 10*time.Millisecond, now)
+	require.NoError(t, err)
+	_, err = s.Set(control.AdminUp, 0, now)
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, s.Down())
+}
@@ -16,10 +16,17 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strings"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/api"
+	"github.com/scionproto/scion/go/pkg/api/jwtauth"
 	"github.com/scionproto/scion/go/pkg/router/control"
 )
 
@@ -111,6 +118,7 @@ func (s *Server) GetInterfaces(w http.ResponseWriter, r *http.Request) {
 			Relationship: LinkRelationship(intf.Link.LinkTo.String()),
 			ScionMtu:     ScionMTU(intf.Link.MTU),
 			State:        LinkState(intf.State),
+			AdminState:   adminStateToAPI(intf.AdminState),
 		}
 
 		intfs = append(intfs, newInterface)
@@ -148,6 +156,200 @@ func (s *Server) GetInterfaces(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetInterfaceStatistics gets the statistics of the interface.
+func (s *Server) GetInterfaceStatistics(w http.ResponseWriter, r *http.Request, interfaceID int) {
+	if !validInterfaceID(w, interfaceID) {
+		return
+	}
+	stats, err := s.Dataplane.InterfaceStats(uint16(interfaceID))
+	if errors.Is(err, control.ErrInterfaceNotFound) {
+		interfaceNotFound(w, err)
+		return
+	}
+	if err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error getting interface statistics",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+
+	rep := InterfaceStatistics{
+		InterfaceId: int(stats.InterfaceID),
+	}
+	if t := stats.Traffic; t != nil {
+		rep.Traffic = &TrafficStatistics{
+			InputPackets:   int64(t.InputPackets),
+			InputBytes:     int64(t.InputBytes),
+			OutputPackets:  int64(t.OutputPackets),
+			OutputBytes:    int64(t.OutputBytes),
+			DroppedPackets: int64(t.DroppedPackets),
+			Drops: DropStatistics{
+				InvalidMac: int64(t.Drops.InvalidMAC),
+				ExpiredHop: int64(t.Drops.ExpiredHop),
+			},
+			ScmpSent: int64(t.SCMPSent),
+		}
+		rates := make([]PacketRate, 0, len(stats.Rates))
+		for _, rate := range stats.Rates {
+			rates = append(rates, PacketRate{
+				Window:  rate.Window.String(),
+				Input:   rate.Input,
+				Output:  rate.Output,
+				Dropped: rate.Dropped,
+			})
+		}
+		rep.Rates = &rates
+	}
+	if b := stats.BFD; b != nil {
+		history := make([]BFDStateChange, 0, len(b.StateHistory))
+		for _, c := range b.StateHistory {
+			history = append(history, BFDStateChange{
+				Time: c.Time,
+				From: BFDSessionState(c.From),
+				To:   BFDSessionState(c.To),
+			})
+		}
+		rep.Bfd = &BFDSession{
+			State:               BFDSessionState(b.State),
+			RemoteState:         BFDSessionState(b.RemoteState),
+			LocalDiscriminator:  int64(b.LocalDiscriminator),
+			RemoteDiscriminator: int64(b.RemoteDiscriminator),
+			TxInterval:          b.TxInterval.String(),
+			RemoteMinRxInterval: b.RemoteMinRxInterval.String(),
+			DetectionTime:       b.DetectionTime.String(),
+			PacketsSent:         int64(b.PacketsSent),
+			PacketsReceived:     int64(b.PacketsReceived),
+			StateHistory:        history,
+		}
+		if !b.LastStateChange.IsZero() {
+			lastChange := b.LastStateChange
+			rep.Bfd.LastStateChange = &lastChange
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// AdminHandler wraps the API handler such that requests that set the
+// administrative state of an interface are only served if they are authorized
+// by the verifier. If the verifier is nil, these requests are rejected.
+func AdminHandler(handler http.Handler, verifier *jwtauth.HTTPVerifier) http.Handler {
+	return api.AuthorizedHandler(handler, verifier, func(r *http.Request) bool {
+		return r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/admin-state")
+	}, "setting the administrative state disabled")
+}
+
+// SetInterfaceAdminState sets the administrative state of the interface.
+func (s *Server) SetInterfaceAdminState(w http.ResponseWriter, r *http.Request,
+	interfaceID int) {
+
+	if !validInterfaceID(w, interfaceID) {
+		api.Audit(r, "set_interface_admin_state", serrors.New("invalid interface ID"),
+			"interface", interfaceID)
+		return
+	}
+	var body SetAdminStateRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		api.Audit(r, "set_interface_admin_state", err, "interface", interfaceID)
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "malformed request body",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	gracePeriod := control.DefaultDrainGracePeriod
+	if body.GracePeriod != nil {
+		var err error
+		if gracePeriod, err = util.ParseDuration(*body.GracePeriod); err != nil {
+			api.Audit(r, "set_interface_admin_state", err, "interface", interfaceID)
+			Error(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusBadRequest,
+				Title:  "malformed grace period",
+				Type:   api.StringRef(api.BadRequest),
+			})
+			return
+		}
+	}
+	state, err := s.Dataplane.SetInterfaceAdminState(uint16(interfaceID),
+		control.AdminState(body.State), gracePeriod)
+	api.Audit(r, "set_interface_admin_state", err, "interface", interfaceID,
+		"state", body.State, "grace_period", gracePeriod)
+	switch {
+	case errors.Is(err, control.ErrInterfaceNotFound):
+		interfaceNotFound(w, err)
+		return
+	case err != nil:
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "error setting administrative state",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(adminStateToAPI(state)); err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+func adminStateToAPI(s control.InterfaceAdminState) InterfaceAdminState {
+	state := InterfaceAdminState{
+		State: AdminState(s.State),
+	}
+	if !s.DrainDeadline.IsZero() {
+		deadline := s.DrainDeadline
+		state.DrainDeadline = &deadline
+	}
+	return state
+}
+
+// validInterfaceID checks that the interface ID is in the valid range. If it
+// is not, a bad request problem is written.
+func validInterfaceID(w http.ResponseWriter, interfaceID int) bool {
+	if interfaceID > 0 && interfaceID <= math.MaxUint16 {
+		return true
+	}
+	Error(w, Problem{
+		Detail: api.StringRef(fmt.Sprintf("interface ID %d out of range", interfaceID)),
+		Status: http.StatusBadRequest,
+		Title:  "invalid interface ID",
+		Type:   api.StringRef(api.BadRequest),
+	})
+	return false
+}
+
+func interfaceNotFound(w http.ResponseWriter, err error) {
+	Error(w, Problem{
+		Detail: api.StringRef(err.Error()),
+		Status: http.StatusNotFound,
+		Title:  "interface not found",
+		Type:   api.StringRef(api.NotFound),
+	})
+}
+
 // Error creates an detailed error response.
 func Error(w http.ResponseWriter, p Problem) {
 	w.Header().Set("Content-Type", "application/problem+json")
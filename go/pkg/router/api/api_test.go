@@ -18,9 +18,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,6 +30,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/api/jwtauth"
 	"github.com/scionproto/scion/go/pkg/router/control"
 	"github.com/scionproto/scion/go/pkg/router/control/mock_api"
 )
@@ -38,6 +41,8 @@ func TestAPI(t *testing.T) {
 	testCases := map[string]struct {
 		Handler            func(t *testing.T, ctrl *gomock.Controller) http.Handler
 		RequestURL         string
+		Method             string
+		Body               string
 		ResponseFile       string
 		Status             int
 		IgnoreResponseBody bool
@@ -118,6 +123,157 @@ func TestAPI(t *testing.T) {
 			ResponseFile: "testdata/interfaces-sibling-error.json",
 			Status:       500,
 		},
+		"interface statistics": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().InterfaceStats(uint16(1)).Return(
+					createInterfaceStats(t), nil,
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/interfaces/1/statistics",
+			ResponseFile: "testdata/interface-statistics.json",
+			Status:       200,
+		},
+		"interface statistics sibling": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().InterfaceStats(uint16(5)).Return(
+					control.InterfaceStats{InterfaceID: 5}, nil,
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/interfaces/5/statistics",
+			ResponseFile: "testdata/interface-statistics-sibling.json",
+			Status:       200,
+		},
+		"interface statistics not found": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().InterfaceStats(uint16(42)).Return(
+					control.InterfaceStats{},
+					serrors.WithCtx(control.ErrInterfaceNotFound, "interface_id", 42),
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/interfaces/42/statistics",
+			ResponseFile: "testdata/interface-statistics-not-found.json",
+			Status:       404,
+		},
+		"interface statistics invalid ID": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				return Handler(s)
+			},
+			RequestURL:   "/interfaces/70000/statistics",
+			ResponseFile: "testdata/interface-statistics-invalid-id.json",
+			Status:       400,
+		},
+		"set admin state draining": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().SetInterfaceAdminState(uint16(1), control.AdminDraining,
+					30*time.Second).Return(
+					control.InterfaceAdminState{
+						State:         control.AdminDraining,
+						DrainDeadline: time.Date(2021, 1, 4, 10, 30, 0, 0, time.UTC),
+					}, nil,
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/interfaces/1/admin-state",
+			Method:       "PUT",
+			Body:         `{"state": "draining", "grace_period": "30s"}`,
+			ResponseFile: "testdata/admin-state-draining.json",
+			Status:       200,
+		},
+		"set admin state default grace period": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().SetInterfaceAdminState(uint16(1), control.AdminDraining,
+					control.DefaultDrainGracePeriod).Return(
+					control.InterfaceAdminState{
+						State:         control.AdminDraining,
+						DrainDeadline: time.Date(2021, 1, 4, 10, 30, 0, 0, time.UTC),
+					}, nil,
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/interfaces/1/admin-state",
+			Method:       "PUT",
+			Body:         `{"state": "draining"}`,
+			ResponseFile: "testdata/admin-state-draining.json",
+			Status:       200,
+		},
+		"set admin state up": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().SetInterfaceAdminState(uint16(1), control.AdminUp,
+					gomock.Any()).Return(
+					control.InterfaceAdminState{State: control.AdminUp}, nil,
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/interfaces/1/admin-state",
+			Method:       "PUT",
+			Body:         `{"state": "up"}`,
+			ResponseFile: "testdata/admin-state-up.json",
+			Status:       200,
+		},
+		"set admin state malformed grace period": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				return Handler(s)
+			},
+			RequestURL:   "/interfaces/1/admin-state",
+			Method:       "PUT",
+			Body:         `{"state": "draining", "grace_period": "soon"}`,
+			ResponseFile: "testdata/admin-state-malformed-grace-period.json",
+			Status:       400,
+		},
+		"set admin state not found": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().SetInterfaceAdminState(uint16(5), control.AdminDown,
+					gomock.Any()).Return(
+					control.InterfaceAdminState{},
+					serrors.WithCtx(control.ErrInterfaceNotFound, "interface_id", 5),
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/interfaces/5/admin-state",
+			Method:       "PUT",
+			Body:         `{"state": "down"}`,
+			ResponseFile: "testdata/admin-state-not-found.json",
+			Status:       404,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -127,7 +283,11 @@ func TestAPI(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			req, err := http.NewRequest("GET", tc.RequestURL, nil)
+			method := tc.Method
+			if method == "" {
+				method = "GET"
+			}
+			req, err := http.NewRequest(method, tc.RequestURL, strings.NewReader(tc.Body))
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
@@ -171,7 +331,8 @@ func createExternalIntfs(t *testing.T) []control.ExternalInterface {
 				},
 				MTU: 1472,
 			},
-			State: control.InterfaceUp,
+			State:      control.InterfaceUp,
+			AdminState: control.InterfaceAdminState{State: control.AdminUp},
 		},
 		{
 			InterfaceID: 2,
@@ -194,7 +355,8 @@ func createExternalIntfs(t *testing.T) []control.ExternalInterface {
 				},
 				MTU: 1280,
 			},
-			State: control.InterfaceUp,
+			State:      control.InterfaceUp,
+			AdminState: control.InterfaceAdminState{State: control.AdminUp},
 		},
 		{
 			InterfaceID: 5,
@@ -218,6 +380,10 @@ func createExternalIntfs(t *testing.T) []control.ExternalInterface {
 				MTU: 1280,
 			},
 			State: control.InterfaceUp,
+			AdminState: control.InterfaceAdminState{
+				State:         control.AdminDraining,
+				DrainDeadline: time.Date(2021, 1, 4, 10, 30, 0, 0, time.UTC),
+			},
 		},
 		{
 			InterfaceID: 6,
@@ -240,7 +406,8 @@ func createExternalIntfs(t *testing.T) []control.ExternalInterface {
 				},
 				MTU: 1280,
 			},
-			State: control.InterfaceUp,
+			State:      control.InterfaceUp,
+			AdminState: control.InterfaceAdminState{State: control.AdminDown},
 		},
 	}
 }
@@ -270,3 +437,114 @@ func createSiblingIntfs(t *testing.T) []control.SiblingInterface {
 		},
 	}
 }
+
+func createInterfaceStats(t *testing.T) control.InterfaceStats {
+	up, err := time.Parse(time.RFC3339, "2021-01-04T10:30:00Z")
+	require.NoError(t, err)
+	return control.InterfaceStats{
+		InterfaceID: 1,
+		Traffic: &control.TrafficStats{
+			InputPackets:   1024,
+			InputBytes:     1048576,
+			OutputPackets:  1000,
+			OutputBytes:    1024000,
+			DroppedPackets: 3,
+			Drops: control.DropStats{
+				InvalidMAC: 2,
+				ExpiredHop: 1,
+			},
+			SCMPSent: 5,
+		},
+		Rates: []control.PacketRate{
+			{Window: time.Second, Input: 100, Output: 99, Dropped: 1},
+			{Window: 10 * time.Second, Input: 100.5, Output: 99.5, Dropped: 0.1},
+		},
+		BFD: &control.BFDSession{
+			State:               "Up",
+			RemoteState:         "Up",
+			LocalDiscriminator:  1238907,
+			RemoteDiscriminator: 8890123,
+			TxInterval:          200 * time.Millisecond,
+			RemoteMinRxInterval: 200 * time.Millisecond,
+			DetectionTime:       600 * time.Millisecond,
+			PacketsSent:         300,
+			PacketsReceived:     298,
+			LastStateChange:     up,
+			StateHistory: []control.BFDStateChange{
+				{Time: up.Add(-time.Second), From: "Down", To: "Init"},
+				{Time: up, From: "Init", To: "Up"},
+			},
+		},
+	}
+}
+
+func TestAdminHandler(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	generator := func() ([]byte, error) { return key, nil }
+	verifier := &jwtauth.HTTPVerifier{Generator: generator}
+
+	testCases := map[string]struct {
+		Verifier  *jwtauth.HTTPVerifier
+		Method    string
+		URL       string
+		Authorize bool
+		Status    int
+	}{
+		"disabled": {
+			Method:    http.MethodPut,
+			URL:       "/api/v1/interfaces/1/admin-state",
+			Authorize: true,
+			Status:    http.StatusForbidden,
+		},
+		"missing token": {
+			Verifier: verifier,
+			Method:   http.MethodPut,
+			URL:      "/api/v1/interfaces/1/admin-state",
+			Status:   http.StatusInternalServerError,
+		},
+		"authorized": {
+			Verifier:  verifier,
+			Method:    http.MethodPut,
+			URL:       "/api/v1/interfaces/1/admin-state",
+			Authorize: true,
+			Status:    http.StatusOK,
+		},
+		"read only": {
+			Method: http.MethodGet,
+			URL:    "/api/v1/interfaces",
+			Status: http.StatusOK,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dataplane := mock_api.NewMockObservableDataplane(ctrl)
+			dataplane.EXPECT().SetInterfaceAdminState(uint16(1), control.AdminUp,
+				control.DefaultDrainGracePeriod).MaxTimes(1).Return(
+				control.InterfaceAdminState{State: control.AdminUp}, nil,
+			)
+			dataplane.EXPECT().ListInternalInterfaces().MaxTimes(1)
+			dataplane.EXPECT().ListExternalInterfaces().MaxTimes(1)
+			dataplane.EXPECT().ListSiblingInterfaces().MaxTimes(1)
+			handler := AdminHandler(
+				HandlerFromMuxWithBaseURL(&Server{Dataplane: dataplane}, chi.NewRouter(),
+					"/api/v1"),
+				tc.Verifier,
+			)
+			req := httptest.NewRequest(tc.Method, tc.URL, strings.NewReader(`{"state": "up"}`))
+			if tc.Authorize {
+				src := &jwtauth.JWTTokenSource{Subject: "operator", Generator: generator}
+				token, err := src.Token()
+				require.NoError(t, err)
+				req.Header.Set("Authorization", "Bearer "+token.String())
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+		})
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 )
 
 // RequestEditorFn  is the function signature for the RequestEditor callback function
@@ -97,6 +99,14 @@ type ClientInterface interface {
 	// GetInterfaces request
 	GetInterfaces(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// SetInterfaceAdminState request with any body
+	SetInterfaceAdminStateWithBody(ctx context.Context, interfaceId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetInterfaceAdminState(ctx context.Context, interfaceId int, body SetInterfaceAdminStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInterfaceStatistics request
+	GetInterfaceStatistics(ctx context.Context, interfaceId int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetLogLevel request
 	GetLogLevel(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -142,6 +152,42 @@ func (c *Client) GetInterfaces(ctx context.Context, reqEditors ...RequestEditorF
 	return c.Client.Do(req)
 }
 
+func (c *Client) SetInterfaceAdminStateWithBody(ctx context.Context, interfaceId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetInterfaceAdminStateRequestWithBody(c.Server, interfaceId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetInterfaceAdminState(ctx context.Context, interfaceId int, body SetInterfaceAdminStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetInterfaceAdminStateRequest(c.Server, interfaceId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetInterfaceStatistics(ctx context.Context, interfaceId int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInterfaceStatisticsRequest(c.Server, interfaceId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetLogLevel(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetLogLevelRequest(c.Server)
 	if err != nil {
@@ -259,6 +305,87 @@ func NewGetInterfacesRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewSetInterfaceAdminStateRequest calls the generic SetInterfaceAdminState builder with application/json body
+func NewSetInterfaceAdminStateRequest(server string, interfaceId int, body SetInterfaceAdminStateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetInterfaceAdminStateRequestWithBody(server, interfaceId, "application/json", bodyReader)
+}
+
+// NewSetInterfaceAdminStateRequestWithBody generates requests for SetInterfaceAdminState with any type of body
+func NewSetInterfaceAdminStateRequestWithBody(server string, interfaceId int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "interface-id", runtime.ParamLocationPath, interfaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/interfaces/%s/admin-state", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetInterfaceStatisticsRequest generates requests for GetInterfaceStatistics
+func NewGetInterfaceStatisticsRequest(server string, interfaceId int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "interface-id", runtime.ParamLocationPath, interfaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/interfaces/%s/statistics", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetLogLevelRequest generates requests for GetLogLevel
 func NewGetLogLevelRequest(server string) (*http.Request, error) {
 	var err error
@@ -378,6 +505,14 @@ type ClientWithResponsesInterface interface {
 	// GetInterfaces request
 	GetInterfacesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInterfacesResponse, error)
 
+	// SetInterfaceAdminState request with any body
+	SetInterfaceAdminStateWithBodyWithResponse(ctx context.Context, interfaceId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetInterfaceAdminStateResponse, error)
+
+	SetInterfaceAdminStateWithResponse(ctx context.Context, interfaceId int, body SetInterfaceAdminStateJSONRequestBody, reqEditors ...RequestEditorFn) (*SetInterfaceAdminStateResponse, error)
+
+	// GetInterfaceStatistics request
+	GetInterfaceStatisticsWithResponse(ctx context.Context, interfaceId int, reqEditors ...RequestEditorFn) (*GetInterfaceStatisticsResponse, error)
+
 	// GetLogLevel request
 	GetLogLevelWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogLevelResponse, error)
 
@@ -453,6 +588,50 @@ func (r GetInterfacesResponse) StatusCode() int {
 	return 0
 }
 
+type SetInterfaceAdminStateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InterfaceAdminState
+}
+
+// Status returns HTTPResponse.Status
+func (r SetInterfaceAdminStateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetInterfaceAdminStateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInterfaceStatisticsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InterfaceStatistics
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInterfaceStatisticsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInterfaceStatisticsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetLogLevelResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -526,6 +705,32 @@ func (c *ClientWithResponses) GetInterfacesWithResponse(ctx context.Context, req
 	return ParseGetInterfacesResponse(rsp)
 }
 
+// SetInterfaceAdminStateWithBodyWithResponse request with arbitrary body returning *SetInterfaceAdminStateResponse
+func (c *ClientWithResponses) SetInterfaceAdminStateWithBodyWithResponse(ctx context.Context, interfaceId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetInterfaceAdminStateResponse, error) {
+	rsp, err := c.SetInterfaceAdminStateWithBody(ctx, interfaceId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetInterfaceAdminStateResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetInterfaceAdminStateWithResponse(ctx context.Context, interfaceId int, body SetInterfaceAdminStateJSONRequestBody, reqEditors ...RequestEditorFn) (*SetInterfaceAdminStateResponse, error) {
+	rsp, err := c.SetInterfaceAdminState(ctx, interfaceId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetInterfaceAdminStateResponse(rsp)
+}
+
+// GetInterfaceStatisticsWithResponse request returning *GetInterfaceStatisticsResponse
+func (c *ClientWithResponses) GetInterfaceStatisticsWithResponse(ctx context.Context, interfaceId int, reqEditors ...RequestEditorFn) (*GetInterfaceStatisticsResponse, error) {
+	rsp, err := c.GetInterfaceStatistics(ctx, interfaceId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInterfaceStatisticsResponse(rsp)
+}
+
 // GetLogLevelWithResponse request returning *GetLogLevelResponse
 func (c *ClientWithResponses) GetLogLevelWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogLevelResponse, error) {
 	rsp, err := c.GetLogLevel(ctx, reqEditors...)
@@ -630,6 +835,58 @@ func ParseGetInterfacesResponse(rsp *http.Response) (*GetInterfacesResponse, err
 	return response, nil
 }
 
+// ParseSetInterfaceAdminStateResponse parses an HTTP response from a SetInterfaceAdminStateWithResponse call
+func ParseSetInterfaceAdminStateResponse(rsp *http.Response) (*SetInterfaceAdminStateResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetInterfaceAdminStateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InterfaceAdminState
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInterfaceStatisticsResponse parses an HTTP response from a GetInterfaceStatisticsWithResponse call
+func ParseGetInterfaceStatisticsResponse(rsp *http.Response) (*GetInterfaceStatisticsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInterfaceStatisticsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InterfaceStatistics
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetLogLevelResponse parses an HTTP response from a GetLogLevelWithResponse call
 func ParseGetLogLevelResponse(rsp *http.Response) (*GetLogLevelResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
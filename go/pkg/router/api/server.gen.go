@@ -4,8 +4,10 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -20,6 +22,12 @@ type ServerInterface interface {
 	// List the SCION interfaces
 	// (GET /interfaces)
 	GetInterfaces(w http.ResponseWriter, r *http.Request)
+	// Set the administrative state of a SCION interface
+	// (PUT /interfaces/{interface-id}/admin-state)
+	SetInterfaceAdminState(w http.ResponseWriter, r *http.Request, interfaceId int)
+	// Get the statistics of a SCION interface
+	// (GET /interfaces/{interface-id}/statistics)
+	GetInterfaceStatistics(w http.ResponseWriter, r *http.Request, interfaceId int)
 	// Get logging level
 	// (GET /log/level)
 	GetLogLevel(w http.ResponseWriter, r *http.Request)
@@ -81,6 +89,58 @@ func (siw *ServerInterfaceWrapper) GetInterfaces(w http.ResponseWriter, r *http.
 	handler(w, r.WithContext(ctx))
 }
 
+// SetInterfaceAdminState operation middleware
+func (siw *ServerInterfaceWrapper) SetInterfaceAdminState(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "interface-id" -------------
+	var interfaceId int
+
+	err = runtime.BindStyledParameter("simple", false, "interface-id", chi.URLParam(r, "interface-id"), &interfaceId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter interface-id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetInterfaceAdminState(w, r, interfaceId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetInterfaceStatistics operation middleware
+func (siw *ServerInterfaceWrapper) GetInterfaceStatistics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "interface-id" -------------
+	var interfaceId int
+
+	err = runtime.BindStyledParameter("simple", false, "interface-id", chi.URLParam(r, "interface-id"), &interfaceId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter interface-id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInterfaceStatistics(w, r, interfaceId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetLogLevel operation middleware
 func (siw *ServerInterfaceWrapper) GetLogLevel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -157,6 +217,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/interfaces", wrapper.GetInterfaces)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/interfaces/{interface-id}/admin-state", wrapper.SetInterfaceAdminState)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/interfaces/{interface-id}/statistics", wrapper.GetInterfaceStatistics)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/log/level", wrapper.GetLogLevel)
 	})
@@ -18,45 +18,71 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xZ33PbNhL+VzBoH5op9cNOem305thOq5kk1lj29KH1eSBiRaIGARYAZet8+t9vFiAp",
-	"/rLs5K7tXJ9skQD2w7ffLnbBRxrrLNcKlLN09kgN2FwrC/7HO8Yv4fcCrMNfsVYOlP+X5bkUMXNCq8lv",
-	"Vit8ZuMUMob/fW1gTWf0q8l+6Ul4aydLxxRnhp8bow3d7XYR5WBjI3JcjM7QJjGlUXxbTvRw3p/hn9zo",
-	"HIwTASMHKwzw20wokRXZrXu4FcqB2TBZvm4sfpUCKQeSahRZgbsHUMQZpmwmrBVaEb0m796fEdyz0ZLk",
-	"LL4DZ4lLmSMuBYIQmNOGBPt2TK5SYcmGyQKIsITxDWK0wInTfkYOYCKS6nvYgPFPWOwKJvdAChwtLLE5",
-	"xGItgJPVljh2J1Tix2fswSPX69IqH5WbGbmHUb0MU9wPD1j02v8wkGkHntnWRAMxiA3sQfhZYxpReGBZ",
-	"LoHO6PF0mlkaUbfN8ad1RqiEes85iJHa26yQTuRSgBkmXRXZCgyCaTGZFdaRFfrElkxxiCUzQByyaSE4",
-	"g1nC9b1CjoHURveY1zoQih6r5ghLYibjQjIXiCwhbis2W/QoSLQTfmhLBnuRbAOkPj2va2JwcAIGmQHF",
-	"VhJ4n4y54mXgoOn7FFwKxgMXlpSzvAdjrdYiKQxwolWw7cGsWdy270wBNYSV1hKYQgiVq+vIKF39mVFR",
-	"zuKHwgFdtbUOMmJTXUhObJHn2rjng6KUJcYGPhKBHWjJfe3TgYq35BsxhnHUxjoKWGrgr2rkTwJGJHEM",
-	"uUO2KyRSx0yW23iR/BsU09kvB/PQE5Gyl8kBb91E1AnngbwTXJiwDJPkvTb3zHCU81kdEpVqaoUx1ZZN",
-	"uQm9+g1ihzKZV2/7qXW15s+lc0zJu4jWJm7FgOaXp/OLT3sYRHBQDhOceT6Q/CzF5K1o4uzrl3FuwFrc",
-	"cjWFdO1WqVAXrmOaHr09Hh/944fx8fh49vpoOp0OpTsFIklX2jxHSk3pp2qC14r0TrGpyJ9b4INQd5fN",
-	"8f4c9OpxxbMnLA78eHXtJznm4CXWln5gV9Mttzb230QTeZlUpjr7HPRfQ9HBQ/O9h1TDQwfV+qnhi7Zq",
-	"SyX0ZXJ9tpjMF6RQHIxk26Zk0GgHyxfoQ1h+GyqVg+qw/MT2qQ5zoxp+g6VqrxjqXU2D4rkWylW7kELd",
-	"HY5ze1mWeH3q6mXDLweZfbHW0UZplBnDtl5+YiWFSm6/YN1lmHpg+d2eoGpHRArrkKWQzPEYLSGQBoQh",
-	"crxPZo9Nj4/W6+l0Np0dHaGzc+ZQyHRG//nrr/zb0Te/sNF6Onp783gUvdnNXj0e79qPXv0bx31N9yjn",
-	"y7PRyZLM6+w3pKFe6CMoVWSokdOLy3Ma0dOf5h/OaEQXJ5fnn67wn/PzS9TLHnw1ZHD5ZZUUqnWvFzSi",
-	"Zxc/f2ov4h/3V9DJB9iA7KtHVo/bYfdBJ4n3iX8d1VY5rIrEZ4i1xse+IWgBKN8cPnfDsjcDTl0YvZKQ",
-	"DbUMjokBpCckLTKGJQ/jvjSAh1wyFc7SsiiPQ70gLNFxXBgDan+w5MFgXWSkIPN1IXEGCrIsa6pRqM4E",
-	"S2/GNyLkvlTf4+Dc6BiAj8nPRjgHeIKTc5VIYVM/q8aHdS+oRCgAYyNS2IJJuSVKO2ILgcUsjlCYVSFO",
-	"lfAVjmN3kGrJwVi/Go728SL+Bbyd9U61UmVhgaU5c2zFLBAnMqxKCzeYBJV1TA0d0yfk+nJODKwhsBZo",
-	"qqLBenJqlp9kNyIwTsZYjzPuix9G1oYlGajGYoZoQ2yxGuXMpXUDVrlnm8OYfGRb7DyKshhtOMhoXaZT",
-	"YetJIpxMVhcmBhJr3jkgJuXASVxzNvKS/srpO1Aj1PIIHTfy7I0Ce2ttMubojBZGjGpmhmjF47Www7XP",
-	"T1dXCxIGeGQkAYXdadlAYrdqRCIUsWCw9wzt0iEJt/b23fR1RMtinM6+e/s2omWRSmdH0+lQ1VamvL4C",
-	"bKoNijPLmNn24sY75q8W/RKMj8drxTZMSLQ55JDwAHe4ZoVEH7KVLtxsJZm6o9FLtF8o8XsBctsNgiYf",
-	"RCscENTnb2AeXIO3jeDAycliPiYXea4bnVUVSaxslcnl+9PR9z9Mv4+I8NlJgfC9p4FYZxkoHuausMOu",
-	"gHrCka9QYzhNWMiRo9odXMcFBl+wo7QhidQr75Kwv7o7b7n5ZcHzGSHSORbKeKmkOHQ+1IXycENcdp+t",
-	"64BCIXeKrLYOrN9YqMfK9rLsdw3kBiyWM9W+nY619Ak0LPHN4uz6VbvwlGwLxnMtbC3qxg0GszWkc/Sb",
-	"AkdytpWacTIi8wX5CRgHQ0bk+qz60WL56M33x0Ox2qu0ni4L/5Lubl61c516PdR4f3gzV9LzN2vlBoh/",
-	"sr/rdHQBSLOJK0vs+cESu8tjX2X/fff0v+6Z2pfVPcRQPW4L1o8mGVjLkucTVV33dqzvdmVp3D9FF/M6",
-	"p4atXdb9ctUQ+QekOspOFnMa0Q0YG1aYjqfjI9ygzkGxXNAZfT2ejo9Dn5P6zU3CVRL+m4C/9A9X3kKr",
-	"Oacz+iO40zAian80OJ5OO18L8Mya5JKJzneCLjG9bwHLIo7BWqyhLyrjCPtNMDGkkxrKpPHxwn9HCDUH",
-	"ndGFEVVqvrr4+KFzZ7YWMlyUscSif/Bw1Ire4BqTyiFPMTIPHcv/Fx/vmBUxwa3hSYsc5CwB4suZuuww",
-	"WvryEeXk+xNrD7DUbPdLrjpNobCuIeD9jFAZMQO9C/Dmxd0A8Y3c8wz9X/7xauAOZcBLfm963dvauOGq",
-	"J+CUddC3nweranQHsMzVhklRf1Ebd1z/pBsarm1c3HnvSp1M6lb/qUCobwn+QG/UNv60SPkRsGtoX2f0",
-	"IiCieTFAyrJDil//nebbP4WP6hKmaT+cQM4UsPtbeWn5Ei/5Kb4dxuePtDCSzmjqXD6bTB5Tbd1u9phr",
-	"43YTlovJ5ggPUGYE9jyeIxzS7v98Qeofowa06bx+PX3z5hhZuKnh9CqHDZitSxG4L7pCR9bPIxFVLKtK",
-	"6ep6tLvYqd8qnv0EHkJruNqWi5WZvLlUyczuZvefAAAA//9UPMQGEyAAAA==",
+	"H4sIAAAAAAAC/9w7aXPjuJV/BcXkw0yFkijbPd3WN7fdk9FWHy7LrqnaSa8KIh4lxCTAAKDdilf/fQsX",
+	"BR467PQ4s/lkiwTwHt598SlKeVFyBkzJaPIUCZAlZxLMj/eY3MA/KpBK/0o5U8DMv7gsc5piRTkb/V1y",
+	"pp/JdAUF1v/9WUAWTaI/jbZHj+xbOZopzAgW5IMQXESbzSaOCMhU0FIfFk00TCQcUP3WbdTnXpCCspnC",
+	"CvQvYFURTX6LqjKKIyIwZZQt9b/8kUVf40hRlUM0sbuoVAIr+gBI6v2IZwgzRJkCkeEUhlEcqXWpl0sl",
+	"9DmbOHr/85WGUwpeglDUkoSApALIXJ9ZVMVcfZubUx5w7l4Hd7ldAXILkV+FFqAeARhSAjNZUCkpZxqf",
+	"9z9fIU1iwXNU4vQelERqhRVSK0AaBay4QBa+HKLbFZXoAecVICoRJg8aRwkEKW52lAAiRiv+CA8gzBOc",
+	"qgrnW0QqvZpKJEtIaUaBoMUaKXxP2dKsL/A3gznPHFQycJcZqG+D+hjMiFluceGZ+SGg4AoMIxsbBaSg",
+	"uVDvNrs0+eEbLkrDsJMkKWQfQwgoSDVp50WVK1rmFEQ/0VlVLEBoZBqULCqp0ELzRDpKEUhzLAApTU0J",
+	"lhlYIi1EmsaAaqBbnDNuCao55vdQiVKcp1WOlSWkQ3HtqdkgD4MlV9QsbYjBVkjWFqUueU5rwujFSxCa",
+	"MsDwIgfSJcaUEaenGvTjCtQKhEGcSuR2GQ6mnGV0WQkgiDMLu6EdNXwlKqhRWHCeA2YaBc/qWjMcq5+p",
+	"FW4X2acOmlVrqaBAcsWrnCBZlSUX6rBSOLHUuqEfUUsdaIh7pm8CLF2jH+gQhnET14HFpUb8xxrznQhr",
+	"TNIUSqWp7THJeYpzd42jxD8gsbZ6++zQDk3ZiskebgWG8z0lVNhjcI5+5uIRC6LF+apWCS81tYTtMKp8",
+	"8XdIlTOqM6syfbbVY61osUNw9BuEMwUCPa5oujLEDJTQKTQxGoxohhhHnmWylq4mxX/aZXByLNXcOIx5",
+	"usJs2YPTNadMS4NFzKm33ofsjvqR5bdD1JzZZvvJeJCMB8nZ7TiZnCaTJPnvKI4yLgqsoklEsIKBoUsf",
+	"ovr0OaEas4Iy7Sm6qF6Fr5t4ASP+gUOxgdz45PTdefI2wIYy9dNZ1GeKnOh7geqxSZ9r49ynMr0sOjl/",
+	"9yzg0sUpzwGs9zQtbZIcBdQalRdR327tIz+aGoH9JwiOqH0VLKYSMa7QPdNuKsT53bvzZHxy+hy8C8rm",
+	"4sVRzDH2uoG6s9XySJ/vkJQ+6tsXW25tiw0SdfT4r+ybr6hUXKx3kIRLZa7PvK7LPcoeI54TkAplVEgj",
+	"Z1RBIY9BTW+/NACiTU0hLARem9+HWBfEGntjULlDMV7inSzZW9zrN1M79Kd5sZ3CGredRssG9NijNnMD",
+	"jzfzuYGJ7g67P8/h5zm+TgZjMpQrnbbEkfszZVQjf1f2oofD0HNX7hLKTcfVZoIXL1CLfr/c6wNtnmUV",
+	"4zt6OsWfjXZLNt3JhgLmvIDCl30++xDVtzy+ErzUQKlUNJVdqsO30gRdK17u807efD5StdKC5bahFS9R",
+	"RiFv+sbxUcaesgecUzIvcHo86C1E9Oni0przDFOdM5iUBgTNXHrRdNdHoNTiSohf3CBUwJ4unkTwsgRS",
+	"k2p2+ekaga4toAKkxEuIdS4lAMsdPJt6re2yC2u9PM711KcENYpNHC0ycoS4Wv64A+a0J2SaXU6/fN4a",
+	"GEQJMEUzCuJwbmh2MZzPaXjTrp/AhAiQxgv4LagN14ctvFIt0NH4/GQ4/und8GR4MjkdJ0nSp70M6HK1",
+	"4OIQUWpyfvYbjLjkRtTkipaHDvhI2f1NuN5UkkxCpKqDNSq98NPt3dEBhIbWb2wabA3uH2JjxSQOnGaA",
+	"dy//4oZohh7C8Gu65RcL+LVX+pu1tVZeJjBlcwKY5JQdtP9YBZmZr8uFsit1kQmYq7F8YfkaSVA+ym0s",
+	"9Nu/owM5iqGhFveGNs+sL5oLLtaNUt5+a/Q50JS2VTJ62mXD3dX1aHqNKkZA5HgdKrSG25KNF2gvlWSO",
+	"D4asU0kuZFcR7N64Rj806+6uWk7aFgcYKY14eZ9M2f1+yu1zwMfZY1+g+F3NssAKeph4bWsV5i3CDyDw",
+	"EgjiuoZLaJaBAKasnj1SRvijPDqVsCff+EiunUYInGU0PXTIrV0W0HivxWsFr3aLjaVatNvLUnnjuhJd",
+	"jtYH2F/HEKI+to8Oki5yypbzF5w7s1v3HL/ZksPfCOWaKGzpgk1dinUooACFPuIYNZs8bcUsGg+yLEkm",
+	"yWQ8TkzmoxQIFk2i//nb38hfBj/8hgdZMjj/+jSOzzaTH59ONs1HP/6vXvfnaIvldHY1uJihaS3XfWah",
+	"42uD1Obyy82HKI4uf5l+vIri6Pri5sPnW/3Phw83Wjq2yPslvcd3Uqa7a50rffn1c/OQu+veE/jyIzxA",
+	"3pWe3D9uKuFHvlwanpjXcQ2VwKJaGpeccf3Y9LAaCLg3+7Nje+zXHqYGOtrjhU2o21NZsi/qmLgEgSSk",
+	"nDXzhGQYZgqEV4s80DrbMbEmr6x6imc3vtBzAMw4SYZvjgLEK9ULaQZMHYJyfn4kEGsleyLerQm11nUb",
+	"szjbK6C2vy1fmRwugDiwnpr1ZeOajYFpbNj8duTQ9AE4tP29fvBa8EUORW91HdMeYb9Aq6rADAnAxHQo",
+	"4FuZY2ZL+q43mNq2BZWIp2klBLBtMlBagHWvYwV5mVW53pHzurviV2kDt9RxEiYP1EaoK/6oF5eCpwBk",
+	"iH4VVCnQNEAf2DKncmV21fjp9huwJWUAQsaokhXO87UpiMqKKiBmBeMMKUhXjLok/h5WugQnpDlNrzYm",
+	"l/6zzd9Lzpgr8OgOIVZ4gaXtPBDEK7WlehAaMakw60utLtDdzRQJMK47BUcmb1ClrZV4Ku+kboxguBzq",
+	"EBITU4TCKBN4WQALDhOICySrxaDEalX3gT171iUM0Se8Rguwrd8mgwTnLsiist5Ebf4geSVSQCknrbBx",
+	"5BaO0ppmA2MV/6T4PbCBNocDzTgTjpOBpV6ttpWgg5oyu4L1Svbnq7/c3l4ju8BghpbAQPj2q4m0BV1S",
+	"hiQIrTq2a7tPhBt3e5OcxpHrCUaTN+fnceSK4MbG9YV0Tp+7EiBXXGjhLAos1h29MYz5dwv9DITRxzuG",
+	"HzDN8SLvZYh9oG+Y4SrXPMQLXqnJIsfsPoqPkf2K0X9UkK/bShDSA3GdFTrpM3Mn31RAtwdKgKCL6+kQ",
+	"fSlLHjR4vSZh17FHNz9fDt6+S97GiBrrxICaFriAlBcFMGL3LgAR8Igagmt62cxDcYStjRzU7CA8rbTy",
+	"WTiMC7TM+cKwxN6vHhJosPk45XmGivQkp5WsA7i+EKMubvQ3NVwTvDGVUDFq0vvFWoE0F7PBu28Q2La7",
+	"gFKABKZqdiqe8twYUHvED9dXdz8209Ecr7VXsy0tL9TBIAWWNUofNN8Y6NBgnXNM0ABNr9EvgAkINEB3",
+	"V/5HMxw5e3vSp6szUNskPxhyavrMpdCJTAmCcrIjhsi4jx1wX7XjHqA0NPPdA5doucKHncEJK+Zh1QNd",
+	"WTWTWgbHTfsUnSbyVeobjjgaBQm2nYefN1IVCF87Rdqdz/1b6qBTt6ZdO7HJ2e9e9nTk+Q8revYWMHdU",
+	"Qlu1z26B0+XG0725cZuOXSn71ytZ37t+1RyM7GAM/nFTYD+ErY6D7qFOWDvQu3WdXfnn3Fn9Y7pI9XCA",
+	"q0NvyWIigUcQ0Ojg8EohjPwIalulj+hy6cMOsrXVpfMp79y4t33XMgt2X6qVBp+9e/P2pyN7cxr896Br",
+	"C4WTs6Pg29z02PtLYOoQ4OPv7mA/4/LHwD/y4jItyoOzQqal6DRMxra5aMNpyiwMOyEgoMypXmEQpKyW",
+	"Y5PcPo9zL2qghnRsynSHzC2exx3l9qoU0iiweM5coJRX+gLdqoWkNt43wYFQ3cZhywJtNq6q1s2erqd1",
+	"LG2N603dzfIRinmAfApzcT2N4ugBhJ0yjJJhMhwbWSuB4ZLq2GmYDE9siXRlhG5kJxn1v0sw4mDbNJSz",
+	"KYkm0V9BXdoVcXNE/iRJWrPxOlcZlTmmran4tmnuTL7PqjQFKXXt5IsHrtE+S5JdJq1GZRSM6uuTXa6p",
+	"i0uC+pD89sunj62RzYzmNlLDS6mlSCdFnEVf9Rkjz5BdFJnaYuf/L3q8x5KmoeqiEi8BmTS2Tjf1+JN0",
+	"4mTqUlLuoVLYKXC0atWTqVSBAG93WD+IBXTmr0Nd6SF8EP0cIP/LP9Xoab/0cMncjWedqw0DVu1Ax+W/",
+	"f3keWr7A2YPL1A6R+O9Hhi3W72RDwNr6YYe7o6f6/wElm5FJggZ1qLyjlH0gYWqjUldR+SPbFrKcKKCL",
+	"52SYtdU0SSyySewQXWQKzFLpSyJmUtqUO8KxzUczWZ8DftBICV4tV60YzlTImdRRXHsA55tLo7aLDRDn",
+	"RYfoyl+j4TT83XGu6yzr7WW5sPsJBzv46marqZKWlvZTDcd2+5lHioVYI4z+69fb+mBJl8zjanzTyoyK",
+	"S0gF1D6qwAwvwVRWdYWpo3+zQP+CPFo7E4EL0O4wmvz2FFEWTYyDieKI4cL7bi9AUei+7UcVW/nfl8lu",
+	"vtqdINV7Ttbf78usvorIZrNp47l5DZPTKFB09HzXyEWPbv8xzJBG4fQ1UZiBUv7Lo17jo/WeSvM5iMPv",
+	"7HVJ5JVeq3PGK2bRePPanHKGykT1bX/xbOv9Ij8iGzl3b/zwV4eIt+zbLbF5XgYNxLj+IK+hFuG3an2o",
+	"WwvaBVCfVjYGU4Qr0dfNAuNt+gKbPke2N6YJUvNXtqm/t1FrTM10VbYxIPOHNmV/AFPRUFSvH/LwjNEO",
+	"9cz5clQPg+zKd+o5kt9RWGoYr5YQaeLlrYGXTqIT+/i2Ews1iPL9o5J99PBjOiH814lTXp9Ls2O4ZLaY",
+	"brc1l5XIo0m0UqqcjEZPKy7VZvJUcqE2I1zS0cNY10mwoNqAGxrpJc32rul8mMdaBrhovT5Nzs5ONBW+",
+	"1uh0StQPINZqpRE31X3bcO2mi12LHm3i9mGX5qo6MNdjKqbzu1i7w1zCHh7lKLP5uvm/AQDM8qm26EAA",
+	"AA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -3,6 +3,30 @@
 // Code generated by unknown module path version unknown version DO NOT EDIT.
 package api
 
+import (
+	"time"
+)
+
+// Defines values for AdminState.
+const (
+	AdminStateDown AdminState = "down"
+
+	AdminStateDraining AdminState = "draining"
+
+	AdminStateUp AdminState = "up"
+)
+
+// Defines values for BFDSessionState.
+const (
+	BFDSessionStateAdminDown BFDSessionState = "AdminDown"
+
+	BFDSessionStateDown BFDSessionState = "Down"
+
+	BFDSessionStateInit BFDSessionState = "Init"
+
+	BFDSessionStateUp BFDSessionState = "Up"
+)
+
 // Defines values for LinkRelationship.
 const (
 	LinkRelationshipCHILD LinkRelationship = "CHILD"
@@ -30,6 +54,9 @@ const (
 	LogLevelLevelInfo LogLevelLevel = "info"
 )
 
+// AdminState defines model for AdminState.
+type AdminState string
+
 // BFD defines model for BFD.
 type BFD struct {
 	// The minimum interval between transmission of BFD control packets that the operator desires. This value is advertised to the peer, however the actual interval used is specified by taking the maximum of desired-minimum-tx-interval and the value of the remote required-minimum-receive interval value.
@@ -45,9 +72,63 @@ type BFD struct {
 	RequiredMinimumReceive string `json:"required_minimum_receive"`
 }
 
+// BFDSession defines model for BFDSession.
+type BFDSession struct {
+	// The time after which the session is declared down if no packet is received.
+	DetectionTime string `json:"detection_time"`
+
+	// Point in time of the last change of the local session state.
+	LastStateChange *time.Time `json:"last_state_change,omitempty"`
+
+	// Discriminator of the local end of the session.
+	LocalDiscriminator int64 `json:"local_discriminator"`
+
+	// Number of BFD control packets received.
+	PacketsReceived int64 `json:"packets_received"`
+
+	// Number of BFD control packets sent.
+	PacketsSent int64 `json:"packets_sent"`
+
+	// Discriminator of the remote end of the session. It is zero if the remote end is not known.
+	RemoteDiscriminator int64 `json:"remote_discriminator"`
+
+	// The minimum interval between received BFD control packets that the remote end supports.
+	RemoteMinRxInterval string          `json:"remote_min_rx_interval"`
+	RemoteState         BFDSessionState `json:"remote_state"`
+	State               BFDSessionState `json:"state"`
+
+	// The most recent changes of the local session state, oldest first.
+	StateHistory []BFDStateChange `json:"state_history"`
+
+	// The negotiated interval between transmissions of BFD control packets.
+	TxInterval string `json:"tx_interval"`
+}
+
+// BFDSessionState defines model for BFDSessionState.
+type BFDSessionState string
+
+// BFDStateChange defines model for BFDStateChange.
+type BFDStateChange struct {
+	From BFDSessionState `json:"from"`
+
+	// Point in time of the state change.
+	Time time.Time       `json:"time"`
+	To   BFDSessionState `json:"to"`
+}
+
+// DropStatistics defines model for DropStatistics.
+type DropStatistics struct {
+	// Number of packets with an expired hop field.
+	ExpiredHop int64 `json:"expired_hop"`
+
+	// Number of packets with a hop field MAC that failed the verification.
+	InvalidMac int64 `json:"invalid_mac"`
+}
+
 // Interface defines model for Interface.
 type Interface struct {
-	Bfd BFD `json:"bfd"`
+	AdminState InterfaceAdminState `json:"admin_state"`
+	Bfd        BFD                 `json:"bfd"`
 
 	// SCION interface identifier.
 	InterfaceId int `json:"interface_id"`
@@ -62,6 +143,13 @@ type Interface struct {
 	State    LinkState `json:"state"`
 }
 
+// InterfaceAdminState defines model for InterfaceAdminState.
+type InterfaceAdminState struct {
+	// Point in time at which the draining interface is taken down. Only set if the interface is draining.
+	DrainDeadline *time.Time `json:"drain_deadline,omitempty"`
+	State         AdminState `json:"state"`
+}
+
 // InterfaceNeighbor defines model for InterfaceNeighbor.
 type InterfaceNeighbor struct {
 	// UDP/IP underlay address of the SCION Interface.
@@ -69,6 +157,18 @@ type InterfaceNeighbor struct {
 	IsdAs   IsdAs  `json:"isd_as"`
 }
 
+// InterfaceStatistics defines model for InterfaceStatistics.
+type InterfaceStatistics struct {
+	Bfd *BFDSession `json:"bfd,omitempty"`
+
+	// SCION interface identifier.
+	InterfaceId int `json:"interface_id"`
+
+	// Packet rates averaged over different time windows.
+	Rates   *[]PacketRate      `json:"rates,omitempty"`
+	Traffic *TrafficStatistics `json:"traffic,omitempty"`
+}
+
 // InterfacesResponse defines model for InterfacesResponse.
 type InterfacesResponse struct {
 	Interfaces        *[]Interface        `json:"interfaces,omitempty"`
@@ -93,6 +193,21 @@ type LogLevel struct {
 // Logging level
 type LogLevelLevel string
 
+// PacketRate defines model for PacketRate.
+type PacketRate struct {
+	// Dropped packets per second.
+	Dropped float64 `json:"dropped"`
+
+	// Received packets per second.
+	Input float64 `json:"input"`
+
+	// Sent packets per second.
+	Output float64 `json:"output"`
+
+	// Time window over which the rates are averaged.
+	Window string `json:"window"`
+}
+
 // Problem defines model for Problem.
 type Problem struct {
 	// A human readable explanation specific to this occurrence of the problem that is helpful to locate the problem and give advice on how to proceed. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
@@ -114,6 +229,13 @@ type Problem struct {
 // The maximum transmission unit in bytes for SCION packets. This represents the protocol data unit (PDU) of the SCION layer and is usually calculated as maximum Ethernet payload - IP Header - UDP Header.
 type ScionMTU int
 
+// SetAdminStateRequest defines model for SetAdminStateRequest.
+type SetAdminStateRequest struct {
+	// Time for which a draining interface keeps forwarding traffic. Only used if the state is draining. Defaults to 1m.
+	GracePeriod *string    `json:"grace_period,omitempty"`
+	State       AdminState `json:"state"`
+}
+
 // SiblingInterface defines model for SiblingInterface.
 type SiblingInterface struct {
 	// SCION interface identifier.
@@ -140,11 +262,39 @@ type StandardError struct {
 	Error string `json:"error"`
 }
 
+// TrafficStatistics defines model for TrafficStatistics.
+type TrafficStatistics struct {
+	// Number of packets received on the interface that were dropped without a response.
+	DroppedPackets int64          `json:"dropped_packets"`
+	Drops          DropStatistics `json:"drops"`
+
+	// Number of bytes received on the interface.
+	InputBytes int64 `json:"input_bytes"`
+
+	// Number of packets received on the interface.
+	InputPackets int64 `json:"input_packets"`
+
+	// Number of bytes sent on the interface.
+	OutputBytes int64 `json:"output_bytes"`
+
+	// Number of packets sent on the interface.
+	OutputPackets int64 `json:"output_packets"`
+
+	// Number of SCMP messages, errors and informational replies, sent in response to packets received on the interface.
+	ScmpSent int64 `json:"scmp_sent"`
+}
+
 // BadRequest defines model for BadRequest.
 type BadRequest StandardError
 
+// SetInterfaceAdminStateJSONBody defines parameters for SetInterfaceAdminState.
+type SetInterfaceAdminStateJSONBody SetAdminStateRequest
+
 // SetLogLevelJSONBody defines parameters for SetLogLevel.
 type SetLogLevelJSONBody LogLevel
 
+// SetInterfaceAdminStateJSONRequestBody defines body for SetInterfaceAdminState for application/json ContentType.
+type SetInterfaceAdminStateJSONRequestBody SetInterfaceAdminStateJSONBody
+
 // SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
 type SetLogLevelJSONRequestBody SetLogLevelJSONBody
@@ -36,6 +36,9 @@ const (
 	// session that is down does not change the state. However, having such a timer
 	// simplifies the Go implementation's timer Stop/Reset code.
 	defaultDetectionTimeout = time.Minute
+	// stateHistorySize is the number of state changes that are kept for the
+	// session statistics.
+	stateHistorySize = 16
 )
 
 var (
@@ -156,6 +159,55 @@ type Session struct {
 	//
 	// If a metric is not initialized, it is not reported.
 	Metrics Metrics
+
+	// statsLock protects stats.
+	statsLock sync.Mutex
+	// stats contains the statistics that are updated by Run. They are kept
+	// separate from the state that is used by Run, such that they can be read
+	// concurrently.
+	stats SessionStats
+}
+
+// SessionStats is a snapshot of the state of a session.
+type SessionStats struct {
+	// State is the local state of the session.
+	State layers.BFDState
+	// RemoteState is the state of the remote session, as reported by the last
+	// received packet. It is only meaningful if packets were received.
+	RemoteState layers.BFDState
+	// LocalDiscriminator is the discriminator of the local session.
+	LocalDiscriminator layers.BFDDiscriminator
+	// RemoteDiscriminator is the discriminator of the remote session. It is
+	// zero if the session is not bootstrapped.
+	RemoteDiscriminator layers.BFDDiscriminator
+	// TxInterval is the negotiated interval between sent packets, before
+	// jitter is applied.
+	TxInterval time.Duration
+	// RemoteMinRxInterval is the required minimum receive interval announced
+	// by the remote session.
+	RemoteMinRxInterval time.Duration
+	// DetectionTime is the time without received packets after which the
+	// session goes down. It is zero if no packet was received.
+	DetectionTime time.Duration
+	// PacketsSent is the number of sent packets.
+	PacketsSent uint64
+	// PacketsReceived is the number of received packets that were accepted.
+	PacketsReceived uint64
+	// LastStateChange is the point in time of the last state change. It is
+	// zero if the state never changed.
+	LastStateChange time.Time
+	// StateHistory contains the most recent state changes, oldest first.
+	StateHistory []StateChange
+}
+
+// StateChange is a state transition of a session.
+type StateChange struct {
+	// Time is the point in time of the transition.
+	Time time.Time
+	// From is the state before the transition.
+	From layers.BFDState
+	// To is the state after the transition.
+	To layers.BFDState
 }
 
 func (s *Session) String() string {
@@ -190,6 +242,10 @@ func (s *Session) Run() error {
 	s.setLocalState(stateDown)
 
 	s.desiredMinTXInterval = defaultTransmissionInterval
+	s.updateStats(func(st *SessionStats) {
+		st.State = layers.BFDState(stateDown)
+		st.TxInterval = s.desiredMinTXInterval
+	})
 	sendTimer := time.NewTimer(s.desiredMinTXInterval)
 MainLoop:
 	for {
@@ -242,6 +298,14 @@ MainLoop:
 				}
 				sendTimer.Reset(s.computeNextSendInterval())
 			}
+			s.updateStats(func(st *SessionStats) {
+				st.PacketsReceived++
+				st.RemoteState = msg.State
+				st.RemoteDiscriminator = s.remoteDiscriminator
+				st.RemoteMinRxInterval = s.remoteMinRxInterval
+				st.DetectionTime = detectionTime
+				st.TxInterval = max(s.desiredMinTXInterval, s.remoteMinRxInterval)
+			})
 		case <-sendTimer.C:
 			// Send timer guaranteed to be expired, so we can reset.
 			sendTimer.Reset(s.computeNextSendInterval())
@@ -268,6 +332,9 @@ MainLoop:
 			if s.Metrics.PacketsSent != nil {
 				s.Metrics.PacketsSent.Add(1)
 			}
+			s.updateStats(func(st *SessionStats) {
+				st.PacketsSent++
+			})
 		case <-detectionTimer.C:
 			// detection timer guaranteed to be expired, so we can reset. We reset s.t. if some
 			// other branch wants to stop this timer, it can assume it hasn't been drained.
@@ -275,6 +342,10 @@ MainLoop:
 
 			s.transition(eventTimer)
 			s.remoteDiscriminator = 0
+			s.updateStats(func(st *SessionStats) {
+				st.RemoteDiscriminator = 0
+				st.TxInterval = max(s.desiredMinTXInterval, s.remoteMinRxInterval)
+			})
 			if s.getLocalState() == stateDown {
 				// Change the desired interval back to the default transmission interval, to
 				// avoid flooding the network while the session is down.
@@ -333,6 +404,24 @@ func (s *Session) IsUp() bool {
 	return s.getLocalState() == stateUp
 }
 
+// Stats returns a snapshot of the state of the session. It is safe to call
+// Stats while Run is executed.
+func (s *Session) Stats() SessionStats {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	st := s.stats
+	st.LocalDiscriminator = s.LocalDiscriminator
+	st.StateHistory = append([]StateChange(nil), s.stats.StateHistory...)
+	return st
+}
+
+// updateStats applies the update to the statistics of the session.
+func (s *Session) updateStats(update func(st *SessionStats)) {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	update(&s.stats)
+}
+
 // getLocalState is a concurrency-safe getter for local state.
 func (s *Session) getLocalState() state {
 	s.localStateLock.RLock()
@@ -409,6 +498,19 @@ func (s *Session) transition(e event) {
 		if s.Metrics.StateChanges != nil {
 			s.Metrics.StateChanges.Add(1)
 		}
+		s.updateStats(func(st *SessionStats) {
+			change := StateChange{
+				Time: time.Now(),
+				From: st.State,
+				To:   layers.BFDState(newState),
+			}
+			st.State = change.To
+			st.LastStateChange = change.Time
+			st.StateHistory = append(st.StateHistory, change)
+			if len(st.StateHistory) > stateHistorySize {
+				st.StateHistory = st.StateHistory[len(st.StateHistory)-stateHistorySize:]
+			}
+		})
 	}
 }
 
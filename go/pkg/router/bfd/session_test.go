@@ -414,6 +414,62 @@ func TestSessionDebootstrap(t *testing.T) {
 	wg.Wait()
 }
 
+func TestSessionStats(t *testing.T) {
+	newSession := func(disc layers.BFDDiscriminator) *bfd.Session {
+		return &bfd.Session{
+			DetectMult:            2,
+			DesiredMinTxInterval:  100 * time.Millisecond,
+			RequiredMinRxInterval: 50 * time.Millisecond,
+			LocalDiscriminator:    disc,
+			ReceiveQueueSize:      10,
+		}
+	}
+	sessionA, sessionB := newSession(1), newSession(2)
+	linkAToB := &redirectSender{Destination: sessionB.Messages()}
+	linkBToA := &redirectSender{Destination: sessionA.Messages()}
+	sessionA.Sender = linkAToB
+	sessionB.Sender = linkBToA
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	for _, s := range []*bfd.Session{sessionA, sessionB} {
+		s := s
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.Run())
+		}()
+	}
+	linkAToB.Sending(true)
+	linkBToA.Sending(true)
+	// The sessions are up once the remote state is reported as up, and the
+	// configured intervals are used once both sessions are up.
+	assert.Eventually(t, func() bool {
+		stats := sessionA.Stats()
+		return sessionB.IsUp() && stats.RemoteState == layers.BFDStateUp &&
+			stats.DetectionTime < time.Second
+	}, 5*time.Second, 50*time.Millisecond)
+
+	stats := sessionA.Stats()
+	assert.Equal(t, layers.BFDStateUp, stats.State)
+	assert.Equal(t, layers.BFDStateUp, stats.RemoteState)
+	assert.Equal(t, layers.BFDDiscriminator(1), stats.LocalDiscriminator)
+	assert.Equal(t, layers.BFDDiscriminator(2), stats.RemoteDiscriminator)
+	assert.Equal(t, 100*time.Millisecond, stats.TxInterval)
+	assert.Equal(t, 50*time.Millisecond, stats.RemoteMinRxInterval)
+	assert.Equal(t, 200*time.Millisecond, stats.DetectionTime)
+	assert.NotZero(t, stats.PacketsSent)
+	assert.NotZero(t, stats.PacketsReceived)
+	require.NotEmpty(t, stats.StateHistory)
+	last := stats.StateHistory[len(stats.StateHistory)-1]
+	assert.Equal(t, layers.BFDStateUp, last.To)
+	assert.Equal(t, layers.BFDStateDown, stats.StateHistory[0].From)
+	assert.Equal(t, last.Time, stats.LastStateChange)
+
+	linkAToB.Close()
+	linkBToA.Close()
+	wg.Wait()
+}
+
 func TestSessionRun(t *testing.T) {
 	testCases := map[string]struct {
 		session *bfd.Session
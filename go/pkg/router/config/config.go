@@ -33,6 +33,8 @@ type Config struct {
 	Logging  log.Config   `toml:"log,omitempty"`
 	Metrics  env.Metrics  `toml:"metrics,omitempty"`
 	API      api.Config   `toml:"api,omitempty"`
+
+	Management ManagementAPI `toml:"management_api,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -42,6 +44,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.API,
+		&cfg.Management,
 	)
 }
 
@@ -52,6 +55,7 @@ func (cfg *Config) Validate() error {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.API,
+		&cfg.Management,
 	)
 }
 
@@ -62,5 +66,38 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.API,
+		&cfg.Management,
 	)
 }
+
+var _ config.Config = (*ManagementAPI)(nil)
+
+// ManagementAPI holds the configuration of the management endpoints of the
+// router API.
+type ManagementAPI struct {
+	// SharedSecret is the path to the PEM-encoded shared secret that is used to
+	// verify the JWT tokens of requests that set the interface administrative
+	// state. If it is not set, these requests are rejected.
+	SharedSecret string `toml:"shared_secret,omitempty"`
+}
+
+func (cfg *ManagementAPI) InitDefaults() {}
+
+func (cfg *ManagementAPI) Validate() error {
+	return nil
+}
+
+func (cfg *ManagementAPI) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, managementAPISample)
+}
+
+func (cfg *ManagementAPI) ConfigName() string {
+	return "management_api"
+}
+
+const managementAPISample = `
+# The path to the PEM-encoded shared secret that is used to verify the JWT
+# tokens of requests that set the interface administrative state. If it is not
+# set, these requests are rejected. (default: "")
+shared_secret = ""
+`
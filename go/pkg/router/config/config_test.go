@@ -48,4 +48,5 @@ func CheckTestConfig(t *testing.T, cfg *config.Config, id string) {
 	apitest.CheckConfig(t, &cfg.API)
 	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, nil, nil, id)
 	logtest.CheckTestLogging(t, &cfg.Logging, id)
+	assert.Empty(t, cfg.Management.SharedSecret)
 }
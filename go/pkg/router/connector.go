@@ -17,6 +17,7 @@ package router
 import (
 	"net"
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -197,6 +198,8 @@ func (c *Connector) ListExternalInterfaces() ([]control.ExternalInterface, error
 	externalInterfaceList := make([]control.ExternalInterface, 0, len(c.externalInterfaces))
 	for _, externalInterface := range c.externalInterfaces {
 		externalInterface.State = c.DataPlane.getInterfaceState(externalInterface.InterfaceID)
+		externalInterface.AdminState =
+			c.DataPlane.getInterfaceAdminState(externalInterface.InterfaceID)
 		externalInterfaceList = append(externalInterfaceList, externalInterface)
 	}
 	return externalInterfaceList, nil
@@ -213,3 +216,36 @@ func (c *Connector) ListSiblingInterfaces() ([]control.SiblingInterface, error)
 	}
 	return siblingInterfaceList, nil
 }
+
+// InterfaceStats returns the statistics of the external or sibling interface
+// with the given ID.
+func (c *Connector) InterfaceStats(ifID uint16) (control.InterfaceStats, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	_, external := c.externalInterfaces[ifID]
+	_, sibling := c.siblingInterfaces[ifID]
+	if !external && !sibling {
+		return control.InterfaceStats{},
+			serrors.WithCtx(control.ErrInterfaceNotFound, "interface_id", ifID)
+	}
+	return c.DataPlane.getInterfaceStats(ifID), nil
+}
+
+// SetInterfaceAdminState sets the administrative state of the external
+// interface with the given ID. A draining interface keeps forwarding traffic
+// for the grace period and is taken down afterwards.
+func (c *Connector) SetInterfaceAdminState(ifID uint16, state control.AdminState,
+	gracePeriod time.Duration) (control.InterfaceAdminState, error) {
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.externalInterfaces[ifID]; !ok {
+		return control.InterfaceAdminState{},
+			serrors.WithCtx(control.ErrInterfaceNotFound, "interface_id", ifID)
+	}
+	log.Info("Setting interface admin state", "interface", ifID, "state", state,
+		"grace_period", gracePeriod)
+	return c.DataPlane.setInterfaceAdminState(ifID, state, gracePeriod)
+}
@@ -18,6 +18,7 @@ import (
 	"crypto/sha256"
 	"net"
 	"sort"
+	"time"
 
 	"golang.org/x/crypto/pbkdf2"
 
@@ -56,10 +57,16 @@ type LinkEnd struct {
 	Addr *net.UDPAddr
 }
 
+// ErrInterfaceNotFound indicates that the requested interface does not exist.
+var ErrInterfaceNotFound = serrors.New("interface not found")
+
 type ObservableDataplane interface {
 	ListInternalInterfaces() ([]InternalInterface, error)
 	ListExternalInterfaces() ([]ExternalInterface, error)
 	ListSiblingInterfaces() ([]SiblingInterface, error)
+	InterfaceStats(ifID uint16) (InterfaceStats, error)
+	SetInterfaceAdminState(ifID uint16, state AdminState,
+		gracePeriod time.Duration) (InterfaceAdminState, error)
 }
 
 // InternalInterface represents the internal interface of a router.
@@ -76,6 +83,8 @@ type ExternalInterface struct {
 	Link LinkInfo
 	// State indicates the interface state.
 	State InterfaceState
+	// AdminState indicates the administrative state of the interface.
+	AdminState InterfaceAdminState
 }
 
 // SiblingInterface represents a sibling interface of a router.
@@ -97,6 +106,100 @@ type SiblingInterface struct {
 	State InterfaceState
 }
 
+// InterfaceStats contains the statistics of an external or sibling interface.
+type InterfaceStats struct {
+	// InterfaceID is the identifier of the interface.
+	InterfaceID uint16
+	// Traffic contains the traffic counters of the interface. It is nil for
+	// sibling interfaces, their traffic is handled by the sibling router.
+	Traffic *TrafficStats
+	// Rates contains the packet rates of the interface, averaged over
+	// different time windows, shortest window first. It is empty for sibling
+	// interfaces.
+	Rates []PacketRate
+	// BFD contains the state of the BFD session of the interface. It is nil
+	// if BFD is disabled.
+	BFD *BFDSession
+}
+
+// TrafficStats contains the traffic counters of an interface, since the start
+// of the router.
+type TrafficStats struct {
+	InputPackets  uint64
+	InputBytes    uint64
+	OutputPackets uint64
+	OutputBytes   uint64
+	// DroppedPackets is the number of packets that were received on the
+	// interface and dropped without a response, e.g., because they were
+	// malformed or could not be written to the egress interface.
+	DroppedPackets uint64
+	// Drops contains the number of packets that were dropped with an SCMP
+	// error message, by reason.
+	Drops DropStats
+	// SCMPSent is the number of SCMP messages, errors and informational
+	// replies, that were sent in response to packets received on the
+	// interface.
+	SCMPSent uint64
+}
+
+// DropStats contains the number of packets dropped for a specific reason. The
+// router sends an SCMP error message for these packets.
+type DropStats struct {
+	// InvalidMAC is the number of packets with a hop field MAC that failed
+	// the verification.
+	InvalidMAC uint64
+	// ExpiredHop is the number of packets with an expired hop field.
+	ExpiredHop uint64
+}
+
+// PacketRate contains the packet rates of an interface in packets per second,
+// averaged over a time window.
+type PacketRate struct {
+	// Window is the time window over which the rates are averaged.
+	Window  time.Duration
+	Input   float64
+	Output  float64
+	Dropped float64
+}
+
+// BFDSession contains the state of a BFD session.
+type BFDSession struct {
+	// State is the local state of the session.
+	State string
+	// RemoteState is the state of the remote session, as reported by the last
+	// received packet.
+	RemoteState string
+	// LocalDiscriminator is the discriminator of the local session.
+	LocalDiscriminator uint32
+	// RemoteDiscriminator is the discriminator of the remote session. It is
+	// zero if the session is not bootstrapped.
+	RemoteDiscriminator uint32
+	// TxInterval is the negotiated interval between sent packets.
+	TxInterval time.Duration
+	// RemoteMinRxInterval is the required minimum receive interval announced
+	// by the remote session.
+	RemoteMinRxInterval time.Duration
+	// DetectionTime is the time without received packets after which the
+	// session goes down.
+	DetectionTime time.Duration
+	// PacketsSent is the number of sent BFD packets.
+	PacketsSent uint64
+	// PacketsReceived is the number of accepted received BFD packets.
+	PacketsReceived uint64
+	// LastStateChange is the point in time of the last state change. It is
+	// zero if the state never changed.
+	LastStateChange time.Time
+	// StateHistory contains the most recent state changes, oldest first.
+	StateHistory []BFDStateChange
+}
+
+// BFDStateChange is a state transition of a BFD session.
+type BFDStateChange struct {
+	Time time.Time
+	From string
+	To   string
+}
+
 // InterfaceState indicates the state of the interface.
 type InterfaceState string
 
@@ -105,6 +208,34 @@ const (
 	InterfaceDown InterfaceState = "down"
 )
 
+// DefaultDrainGracePeriod is the default time for which a draining interface
+// keeps forwarding traffic before it is taken down.
+const DefaultDrainGracePeriod = time.Minute
+
+// AdminState is the administrative state of an interface that is set by the
+// operator.
+type AdminState string
+
+const (
+	// AdminUp indicates that the interface is in service.
+	AdminUp AdminState = "up"
+	// AdminDraining indicates that the interface is being taken out of
+	// service. Traffic is still forwarded until the drain deadline.
+	AdminDraining AdminState = "draining"
+	// AdminDown indicates that the interface is out of service. Packets that
+	// would leave through the interface are answered with an SCMP external
+	// interface down message.
+	AdminDown AdminState = "down"
+)
+
+// InterfaceAdminState is the administrative state of an interface.
+type InterfaceAdminState struct {
+	State AdminState
+	// DrainDeadline is the point in time at which a draining interface is
+	// taken down. It is zero, unless the interface is draining.
+	DrainDeadline time.Time
+}
+
 // ConfigDataplane configures the data-plane with the new configuration.
 func ConfigDataplane(dp Dataplane, cfg *Config) error {
 	if cfg == nil {
@@ -6,6 +6,7 @@ package mock_api
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	control "github.com/scionproto/scion/go/pkg/router/control"
@@ -34,6 +35,21 @@ func (m *MockObservableDataplane) EXPECT() *MockObservableDataplaneMockRecorder
 	return m.recorder
 }
 
+// InterfaceStats mocks base method.
+func (m *MockObservableDataplane) InterfaceStats(arg0 uint16) (control.InterfaceStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InterfaceStats", arg0)
+	ret0, _ := ret[0].(control.InterfaceStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InterfaceStats indicates an expected call of InterfaceStats.
+func (mr *MockObservableDataplaneMockRecorder) InterfaceStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InterfaceStats", reflect.TypeOf((*MockObservableDataplane)(nil).InterfaceStats), arg0)
+}
+
 // ListExternalInterfaces mocks base method.
 func (m *MockObservableDataplane) ListExternalInterfaces() ([]control.ExternalInterface, error) {
 	m.ctrl.T.Helper()
@@ -78,3 +94,18 @@ func (mr *MockObservableDataplaneMockRecorder) ListSiblingInterfaces() *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSiblingInterfaces", reflect.TypeOf((*MockObservableDataplane)(nil).ListSiblingInterfaces))
 }
+
+// SetInterfaceAdminState mocks base method.
+func (m *MockObservableDataplane) SetInterfaceAdminState(arg0 uint16, arg1 control.AdminState, arg2 time.Duration) (control.InterfaceAdminState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInterfaceAdminState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(control.InterfaceAdminState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetInterfaceAdminState indicates an expected call of SetInterfaceAdminState.
+func (mr *MockObservableDataplaneMockRecorder) SetInterfaceAdminState(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInterfaceAdminState", reflect.TypeOf((*MockObservableDataplane)(nil).SetInterfaceAdminState), arg0, arg1, arg2)
+}
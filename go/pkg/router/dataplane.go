@@ -73,6 +73,7 @@ type bfdSession interface {
 	Run() error
 	Messages() chan<- *layers.BFD
 	IsUp() bool
+	Stats() bfd.SessionStats
 }
 
 // BatchConn is a connection that supports batch reads and writes.
@@ -100,11 +101,13 @@ type DataPlane struct {
 	svc               *services
 	macFactory        func() hash.Hash
 	bfdSessions       map[uint16]bfdSession
+	adminStates       map[uint16]*adminState
 	localIA           addr.IA
 	mtx               sync.Mutex
 	running           bool
 	Metrics           *Metrics
 	forwardingMetrics map[uint16]forwardingMetrics
+	interfaceStats    map[uint16]*interfaceStats
 }
 
 var (
@@ -211,7 +214,11 @@ func (d *DataPlane) AddExternalInterface(ifID uint16, conn BatchConn) error {
 	if d.external == nil {
 		d.external = make(map[uint16]BatchConn)
 	}
+	if d.adminStates == nil {
+		d.adminStates = make(map[uint16]*adminState)
+	}
 	d.external[ifID] = conn
+	d.adminStates[ifID] = newAdminState()
 	return nil
 }
 
@@ -452,6 +459,7 @@ func (d *DataPlane) Run(ctx context.Context) error {
 	d.running = true
 
 	d.initMetrics()
+	d.initStats()
 
 	read := func(ingressID uint16, rd BatchConn) {
 
@@ -479,6 +487,8 @@ func (d *DataPlane) Run(ctx context.Context) error {
 				inputCounters := d.forwardingMetrics[ingressID]
 				inputCounters.InputPacketsTotal.Inc()
 				inputCounters.InputBytesTotal.Add(float64(p.N))
+				inputStats := d.interfaceStats[ingressID]
+				inputStats.countInput(p.N)
 
 				srcAddr := p.Addr.(*net.UDPAddr)
 				result, err := processor.processPkt(p.Buffers[0][:p.N], srcAddr)
@@ -489,12 +499,14 @@ func (d *DataPlane) Run(ctx context.Context) error {
 					if !scmpErr.TypeCode.InfoMsg() {
 						log.Debug("SCMP", "err", scmpErr, "dst_addr", p.Addr)
 					}
+					inputStats.countSCMP(scmpErr.TypeCode)
 					// SCMP go back the way they came.
 					result.OutAddr = srcAddr
 					result.OutConn = rd
 				default:
 					log.Debug("Error processing packet", "err", err)
 					inputCounters.DroppedPacketsTotal.Inc()
+					inputStats.countDropped()
 					continue
 				}
 				if result.OutConn == nil { // e.g. BFD case no message is forwarded
@@ -519,12 +531,16 @@ func (d *DataPlane) Run(ctx context.Context) error {
 						// error metric
 					}
 					inputCounters.DroppedPacketsTotal.Inc()
+					inputStats.countDropped()
 					continue
 				}
 				// ok metric
 				outputCounters := d.forwardingMetrics[result.EgressID]
 				outputCounters.OutputPacketsTotal.Inc()
 				outputCounters.OutputBytesTotal.Add(float64(len(result.OutPkt)))
+				if outputStats, ok := d.interfaceStats[result.EgressID]; ok {
+					outputStats.countOutput(len(result.OutPkt))
+				}
 			}
 		}
 	}
@@ -547,6 +563,10 @@ func (d *DataPlane) Run(ctx context.Context) error {
 		defer log.HandlePanic()
 		read(0, c)
 	}(d.internal)
+	go func() {
+		defer log.HandlePanic()
+		d.sampleStats(ctx)
+	}()
 
 	d.mtx.Unlock()
 
@@ -1033,6 +1053,16 @@ func (p *scionPacketProcessor) egressInterface() uint16 {
 
 func (p *scionPacketProcessor) validateEgressUp() (processResult, error) {
 	egressID := p.egressInterface()
+	if s, ok := p.d.adminStates[egressID]; ok && s.down() {
+		scmpH := &slayers.SCMP{
+			TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeExternalInterfaceDown, 0),
+		}
+		scmpP := &slayers.SCMPExternalInterfaceDown{
+			IA:   p.d.localIA,
+			IfID: uint64(egressID),
+		}
+		return p.packSCMP(scmpH, scmpP, serrors.New("interface administratively down"))
+	}
 	if v, ok := p.d.bfdSessions[egressID]; ok {
 		if !v.IsUp() {
 			scmpH := &slayers.SCMP{
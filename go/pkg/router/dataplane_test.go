@@ -635,6 +635,34 @@ func TestProcessPkt(t *testing.T) {
 			srcInterface: 0,
 			assertFunc:   assert.NoError,
 		},
+		"outbound admin down": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				dp := router.NewDP(
+					map[uint16]router.BatchConn{
+						uint16(1): mock_router.NewMockBatchConn(ctrl),
+					},
+					map[uint16]topology.LinkType{
+						1: topology.Child,
+					},
+					nil, nil, nil, xtest.MustParseIA("1-ff00:0:110"), nil, key)
+				require.NoError(t, dp.SetAdminState(1, control.AdminDown, 0))
+				return dp
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, dpath := prepBaseMsg(now)
+				spkt.SrcIA = xtest.MustParseIA("1-ff00:0:110")
+				dpath.HopFields = []*path.HopField{
+					{ConsIngress: 0, ConsEgress: 1},
+					{ConsIngress: 31, ConsEgress: 30},
+					{ConsIngress: 41, ConsEgress: 40},
+				}
+				dpath.Base.PathMeta.CurrHF = 0
+				dpath.HopFields[0].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[0])
+				return toMsg(t, spkt, dpath)
+			},
+			srcInterface: 0,
+			assertFunc:   assert.Error,
+		},
 		"brtransit": {
 			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
 				return router.NewDP(
@@ -16,11 +16,14 @@ package router
 
 import (
 	"net"
+	"time"
 
 	"golang.org/x/net/ipv4"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/pkg/router/control"
 )
 
 var NewServices = newServices
@@ -72,3 +75,46 @@ func (d *DataPlane) ProcessPkt(ifID uint16, m *ipv4.Message) (ProcessResult, err
 func ExtractServices(s *services) map[addr.HostSVC][]*net.UDPAddr {
 	return s.m
 }
+
+type InterfaceStats = interfaceStats
+
+func (s *InterfaceStats) CountInput(bytes int)  { s.countInput(bytes) }
+func (s *InterfaceStats) CountOutput(bytes int) { s.countOutput(bytes) }
+func (s *InterfaceStats) CountDropped()         { s.countDropped() }
+
+func (s *InterfaceStats) CountSCMP(typeCode slayers.SCMPTypeCode) {
+	s.countSCMP(typeCode)
+}
+
+func (s *InterfaceStats) Sample(now time.Time) { s.sample(now) }
+
+func (s *InterfaceStats) Traffic() *control.TrafficStats { return s.traffic() }
+
+func (s *InterfaceStats) Rates(now time.Time) []control.PacketRate { return s.rates(now) }
+
+func (d *DataPlane) SetAdminState(ifID uint16, state control.AdminState,
+	gracePeriod time.Duration) error {
+
+	if d.adminStates == nil {
+		d.adminStates = make(map[uint16]*adminState)
+	}
+	if _, ok := d.adminStates[ifID]; !ok {
+		d.adminStates[ifID] = newAdminState()
+	}
+	_, err := d.setInterfaceAdminState(ifID, state, gracePeriod)
+	return err
+}
+
+type AdminState = adminState
+
+var NewAdminState = newAdminState
+
+func (s *AdminState) Down() bool { return s.down() }
+
+func (s *AdminState) Set(state control.AdminState, gracePeriod time.Duration,
+	now time.Time) (control.InterfaceAdminState, error) {
+
+	return s.set(state, gracePeriod, now)
+}
+
+func (s *AdminState) Get(now time.Time) control.InterfaceAdminState { return s.get(now) }
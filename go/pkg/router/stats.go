@@ -0,0 +1,228 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/pkg/router/bfd"
+	"github.com/scionproto/scion/go/pkg/router/control"
+)
+
+const (
+	// statsSampleInterval is the interval in which the counters of the
+	// interfaces are sampled for the packet rates.
+	statsSampleInterval = time.Second
+	// statsSamples is the number of samples that are kept per interface. It
+	// covers the longest rate window.
+	statsSamples = 61
+)
+
+// rateWindows are the time windows over which the packet rates of the
+// interfaces are averaged.
+var rateWindows = []time.Duration{time.Second, 10 * time.Second, time.Minute}
+
+// interfaceStats contains the traffic counters of an interface that are
+// exposed through the management API. The counters are updated atomically by
+// the packet processing.
+type interfaceStats struct {
+	inputPackets  uint64
+	inputBytes    uint64
+	outputPackets uint64
+	outputBytes   uint64
+	dropped       uint64
+	invalidMAC    uint64
+	expiredHop    uint64
+	scmpSent      uint64
+
+	mtx sync.Mutex
+	// samples contains the most recent samples of the packet counters, oldest
+	// first.
+	samples []rateSample
+}
+
+// rateSample is a sample of the packet counters of an interface.
+type rateSample struct {
+	time    time.Time
+	input   uint64
+	output  uint64
+	dropped uint64
+}
+
+func (s *interfaceStats) countInput(bytes int) {
+	atomic.AddUint64(&s.inputPackets, 1)
+	atomic.AddUint64(&s.inputBytes, uint64(bytes))
+}
+
+func (s *interfaceStats) countOutput(bytes int) {
+	atomic.AddUint64(&s.outputPackets, 1)
+	atomic.AddUint64(&s.outputBytes, uint64(bytes))
+}
+
+func (s *interfaceStats) countDropped() {
+	atomic.AddUint64(&s.dropped, 1)
+}
+
+// countSCMP counts an SCMP message that is sent in response to a packet. For
+// SCMP error messages, the reason why the packet was dropped is counted.
+func (s *interfaceStats) countSCMP(typeCode slayers.SCMPTypeCode) {
+	atomic.AddUint64(&s.scmpSent, 1)
+	if typeCode.Type() != slayers.SCMPTypeParameterProblem {
+		return
+	}
+	switch typeCode.Code() {
+	case slayers.SCMPCodeInvalidHopFieldMAC:
+		atomic.AddUint64(&s.invalidMAC, 1)
+	case slayers.SCMPCodePathExpired:
+		atomic.AddUint64(&s.expiredHop, 1)
+	}
+}
+
+func (s *interfaceStats) current(now time.Time) rateSample {
+	return rateSample{
+		time:    now,
+		input:   atomic.LoadUint64(&s.inputPackets),
+		output:  atomic.LoadUint64(&s.outputPackets),
+		dropped: atomic.LoadUint64(&s.dropped),
+	}
+}
+
+// sample records the current packet counters for the rate computation.
+func (s *interfaceStats) sample(now time.Time) {
+	c := s.current(now)
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.samples = append(s.samples, c)
+	if len(s.samples) > statsSamples {
+		s.samples = append(s.samples[:0], s.samples[len(s.samples)-statsSamples:]...)
+	}
+}
+
+func (s *interfaceStats) traffic() *control.TrafficStats {
+	return &control.TrafficStats{
+		InputPackets:   atomic.LoadUint64(&s.inputPackets),
+		InputBytes:     atomic.LoadUint64(&s.inputBytes),
+		OutputPackets:  atomic.LoadUint64(&s.outputPackets),
+		OutputBytes:    atomic.LoadUint64(&s.outputBytes),
+		DroppedPackets: atomic.LoadUint64(&s.dropped),
+		Drops: control.DropStats{
+			InvalidMAC: atomic.LoadUint64(&s.invalidMAC),
+			ExpiredHop: atomic.LoadUint64(&s.expiredHop),
+		},
+		SCMPSent: atomic.LoadUint64(&s.scmpSent),
+	}
+}
+
+// rates computes the packet rates for all rate windows. The current counters
+// are compared to the oldest sample within the window. If there is no such
+// sample, the rate is zero.
+func (s *interfaceStats) rates(now time.Time) []control.PacketRate {
+	c := s.current(now)
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	rates := make([]control.PacketRate, 0, len(rateWindows))
+	for _, w := range rateWindows {
+		rate := control.PacketRate{Window: w}
+		for _, base := range s.samples {
+			if now.Sub(base.time) > w {
+				continue
+			}
+			if elapsed := now.Sub(base.time).Seconds(); elapsed > 0 {
+				rate.Input = float64(c.input-base.input) / elapsed
+				rate.Output = float64(c.output-base.output) / elapsed
+				rate.Dropped = float64(c.dropped-base.dropped) / elapsed
+			}
+			break
+		}
+		rates = append(rates, rate)
+	}
+	return rates
+}
+
+// initStats initializes the statistics for the internal interface and all
+// owned external interfaces.
+func (d *DataPlane) initStats() {
+	d.interfaceStats = map[uint16]*interfaceStats{0: {}}
+	for id := range d.external {
+		if _, notOwned := d.internalNextHops[id]; notOwned {
+			continue
+		}
+		d.interfaceStats[id] = &interfaceStats{}
+	}
+}
+
+// sampleStats samples the packet counters of all interfaces until the context
+// is canceled.
+func (d *DataPlane) sampleStats(ctx context.Context) {
+	ticker := time.NewTicker(statsSampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, s := range d.interfaceStats {
+				s.sample(now)
+			}
+		}
+	}
+}
+
+// getInterfaceStats returns the statistics of the interface. The traffic
+// statistics are only available for owned interfaces once the data plane is
+// running.
+func (d *DataPlane) getInterfaceStats(ifID uint16) control.InterfaceStats {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	stats := control.InterfaceStats{InterfaceID: ifID}
+	if s, ok := d.interfaceStats[ifID]; ok {
+		stats.Traffic = s.traffic()
+		stats.Rates = s.rates(time.Now())
+	}
+	if s, ok := d.bfdSessions[ifID]; ok {
+		stats.BFD = bfdSessionStats(s.Stats())
+	}
+	return stats
+}
+
+func bfdSessionStats(s bfd.SessionStats) *control.BFDSession {
+	history := make([]control.BFDStateChange, 0, len(s.StateHistory))
+	for _, c := range s.StateHistory {
+		history = append(history, control.BFDStateChange{
+			Time: c.Time,
+			From: c.From.String(),
+			To:   c.To.String(),
+		})
+	}
+	return &control.BFDSession{
+		State:               s.State.String(),
+		RemoteState:         s.RemoteState.String(),
+		LocalDiscriminator:  uint32(s.LocalDiscriminator),
+		RemoteDiscriminator: uint32(s.RemoteDiscriminator),
+		TxInterval:          s.TxInterval,
+		RemoteMinRxInterval: s.RemoteMinRxInterval,
+		DetectionTime:       s.DetectionTime,
+		PacketsSent:         s.PacketsSent,
+		PacketsReceived:     s.PacketsReceived,
+		LastStateChange:     s.LastStateChange,
+		StateHistory:        history,
+	}
+}
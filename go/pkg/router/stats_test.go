@@ -0,0 +1,84 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/control"
+)
+
+func TestInterfaceStatsTraffic(t *testing.T) {
+	s := &router.InterfaceStats{}
+	s.CountInput(100)
+	s.CountInput(50)
+	s.CountOutput(80)
+	s.CountDropped()
+	s.CountSCMP(slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+		slayers.SCMPCodeInvalidHopFieldMAC))
+	s.CountSCMP(slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+		slayers.SCMPCodePathExpired))
+	s.CountSCMP(slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+		slayers.SCMPCodePathExpired))
+	s.CountSCMP(slayers.CreateSCMPTypeCode(slayers.SCMPTypeTracerouteReply, 0))
+
+	expected := &control.TrafficStats{
+		InputPackets:   2,
+		InputBytes:     150,
+		OutputPackets:  1,
+		OutputBytes:    80,
+		DroppedPackets: 1,
+		Drops: control.DropStats{
+			InvalidMAC: 1,
+			ExpiredHop: 2,
+		},
+		SCMPSent: 4,
+	}
+	assert.Equal(t, expected, s.Traffic())
+}
+
+func TestInterfaceStatsRates(t *testing.T) {
+	start := time.Now()
+	s := &router.InterfaceStats{}
+	// No samples yet, the rates are zero.
+	assert.Equal(t, []control.PacketRate{
+		{Window: time.Second},
+		{Window: 10 * time.Second},
+		{Window: time.Minute},
+	}, s.Rates(start))
+
+	s.Sample(start)
+	for i := 0; i < 100; i++ {
+		s.CountInput(1)
+	}
+	s.Sample(start.Add(9 * time.Second))
+	for i := 0; i < 10; i++ {
+		s.CountInput(1)
+		s.CountOutput(1)
+		s.CountDropped()
+	}
+
+	now := start.Add(10 * time.Second)
+	assert.Equal(t, []control.PacketRate{
+		{Window: time.Second, Input: 10, Output: 10, Dropped: 10},
+		{Window: 10 * time.Second, Input: 11, Output: 1, Dropped: 1},
+		{Window: time.Minute, Input: 11, Output: 1, Dropped: 1},
+	}, s.Rates(now))
+}
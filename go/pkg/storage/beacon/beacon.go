@@ -65,4 +65,9 @@ type Beacon struct {
 type BeaconAPI interface {
 	// GetBeacons returns all beacons matching the parameters specified.
 	GetBeacons(context.Context, *QueryParams) ([]Beacon, error)
+	// DeleteBeacons deletes all beacons matching the parameters specified. If
+	// params is nil, all beacons are deleted. Parameters that are set but do
+	// not restrict the selection, e.g., only a zero ISD-AS, are rejected. The
+	// return value indicates the number of beacons that were removed.
+	DeleteBeacons(context.Context, *QueryParams) (int, error)
 }
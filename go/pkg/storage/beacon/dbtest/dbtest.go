@@ -57,6 +57,7 @@ func Run(t *testing.T, db TestableDB) {
 
 func run(t *testing.T, db TestableDB) {
 	t.Run("GetBeacons", func(t *testing.T) { testGetBeacons(t, db) })
+	t.Run("DeleteBeacons", func(t *testing.T) { testDeleteBeacons(t, db) })
 	t.Run("DeleteExpired should delete expired segments", func(t *testing.T) {
 		if _, ok := db.(interface{ IgnoreCleanable() }); ok {
 			t.Skip("Ignoring beacon cleaning test")
@@ -87,6 +88,36 @@ func run(t *testing.T, db TestableDB) {
 	})
 }
 
+func testDeleteBeacons(t *testing.T, db TestableDB) {
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+	db.Prepare(t, ctx)
+
+	dbtest.InsertBeacon(t, db, dbtest.Info3, 12, 10, beaconlib.UsageProp)
+	dbtest.InsertBeacon(t, db, dbtest.Info2, 13, 10, beaconlib.UsageProp)
+	dbtest.InsertBeacon(t, db, dbtest.Info4, 13, 10, beaconlib.UsageProp)
+
+	deleted, err := db.DeleteBeacons(ctx, &beacon.QueryParams{IngressInterfaces: []uint16{14}})
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted, "Deleted")
+	deleted, err = db.DeleteBeacons(ctx, &beacon.QueryParams{IngressInterfaces: []uint16{13}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted, "Deleted")
+	res, err := db.GetBeacons(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, uint16(12), res[0].Beacon.InIfId)
+	_, err = db.DeleteBeacons(ctx, &beacon.QueryParams{})
+	assert.Error(t, err)
+	_, err = db.DeleteBeacons(ctx, &beacon.QueryParams{StartsAt: []addr.IA{{}}})
+	assert.Error(t, err)
+	_, err = db.DeleteBeacons(ctx, &beacon.QueryParams{SegIDs: [][]byte{{}}})
+	assert.Error(t, err)
+	deleted, err = db.DeleteBeacons(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted, "Deleted")
+}
+
 func testGetBeacons(t *testing.T, db TestableDB) {
 	// Beacons in results are sorted from newest (3) to oldest (1).
 	usages := []beaconlib.Usage{
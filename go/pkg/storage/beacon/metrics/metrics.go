@@ -127,6 +127,20 @@ func (d *db) GetBeacons(
 	return ret, err
 }
 
+func (d *db) DeleteBeacons(
+	ctx context.Context,
+	q *storagebeacon.QueryParams,
+) (int, error) {
+
+	var ret int
+	var err error
+	d.metrics.Observe(ctx, "delete_beacons", func(ctx context.Context) (string, error) {
+		ret, err = d.db.DeleteBeacons(ctx, q)
+		return dblib.ErrToMetricLabel(err), err
+	})
+	return ret, err
+}
+
 func (d *db) Close() error {
 	return d.db.Close()
 }
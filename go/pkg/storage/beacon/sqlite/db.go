@@ -239,10 +239,21 @@ func (e *executor) GetBeacons(
 }
 
 func (e *executor) buildQuery(params *storagebeacon.QueryParams) (string, []interface{}) {
-	var args []interface{}
 	query := "SELECT DISTINCT RowID, LastUpdated, Usage, Beacon, InIntfID FROM Beacons"
+	where, args := buildWhere(params)
+	if len(where) > 0 {
+		query += "\n" + fmt.Sprintf("WHERE %s", strings.Join(where, " AND\n"))
+	}
+	query += "\n" + "ORDER BY LastUpdated DESC"
+	return query, args
+}
+
+// buildWhere returns the conditions that select the beacons matching the
+// parameters.
+func buildWhere(params *storagebeacon.QueryParams) ([]string, []interface{}) {
+	var args []interface{}
 	if params == nil {
-		return query, args
+		return nil, args
 	}
 	where := []string{}
 	if len(params.SegIDs) > 0 {
@@ -301,12 +312,7 @@ func (e *executor) buildQuery(params *storagebeacon.QueryParams) (string, []inte
 		args = append(args, params.ValidAt.Unix())
 		args = append(args, params.ValidAt.Unix())
 	}
-	// Assemble the query.
-	if len(where) > 0 {
-		query += "\n" + fmt.Sprintf("WHERE %s", strings.Join(where, " AND\n"))
-	}
-	query += "\n" + "ORDER BY LastUpdated DESC"
-	return query, args
+	return where, args
 }
 
 // getBeaconMeta gets the metadata for existing beacons.
@@ -397,6 +403,35 @@ func (e *executor) DeleteExpiredBeacons(ctx context.Context, now time.Time) (int
 	})
 }
 
+func (e *executor) DeleteBeacons(
+	ctx context.Context,
+	params *storagebeacon.QueryParams,
+) (int, error) {
+
+	delStmt := "DELETE FROM Beacons"
+	if params != nil {
+		for _, id := range params.SegIDs {
+			// Segment IDs are matched by prefix, an empty ID matches all.
+			if len(id) == 0 {
+				return 0, serrors.New("empty segment ID")
+			}
+		}
+		where, args := buildWhere(params)
+		// Parameters that do not filter anything, e.g., only zero values,
+		// must not delete all beacons.
+		if len(where) == 0 {
+			return 0, serrors.New("no effective filter for deleting beacons")
+		}
+		delStmt += " WHERE " + strings.Join(where, " AND ")
+		return e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+			return tx.ExecContext(ctx, delStmt, args...)
+		})
+	}
+	return e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+		return tx.ExecContext(ctx, delStmt)
+	})
+}
+
 func (e *executor) deleteInTx(
 	ctx context.Context,
 	delFunc func(tx *sql.Tx) (sql.Result, error),
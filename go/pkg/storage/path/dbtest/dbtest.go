@@ -87,6 +87,8 @@ func TestPathDB(t *testing.T, db TestablePathDB) {
 		testWrapper(testUpdateIntfToSeg))
 	t.Run("DeleteExpired should delete expired segments",
 		testWrapper(testDeleteExpired))
+	t.Run("Delete should delete the matching segments",
+		testWrapper(testDelete))
 	t.Run("Get should return the correct path segments",
 		testWrapper(testGetMixed))
 	t.Run("Get with nil params should return all path segments",
@@ -127,6 +129,8 @@ func TestPathDB(t *testing.T, db TestablePathDB) {
 			txTestWrapper(testUpdateIntfToSeg))
 		t.Run("DeleteExpired should delete expired segments",
 			txTestWrapper(testDeleteExpired))
+		t.Run("Delete should delete the matching segments",
+			txTestWrapper(testDelete))
 		t.Run("Get should return the correct path segments",
 			txTestWrapper(testGetMixed))
 		t.Run("Get with nil params should return all path segments",
@@ -267,6 +271,31 @@ func testDeleteExpired(t *testing.T, pathDB pathdb.ReadWrite) {
 	assert.Equal(t, 1, deleted, "Deleted")
 }
 
+func testDelete(t *testing.T, pathDB pathdb.ReadWrite) {
+	TS := uint32(10)
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+	pseg1, segID1 := AllocPathSegment(t, ifs1, TS)
+	pseg2, segID2 := AllocPathSegment(t, ifs2, TS)
+	stat := InsertSeg(t, ctx, pathDB, pseg1, hpGroupIDs)
+	require.Equal(t, pathdb.InsertStats{Inserted: 1}, stat)
+	stat = InsertSeg(t, ctx, pathDB, pseg2, hpGroupIDs)
+	require.Equal(t, pathdb.InsertStats{Inserted: 1}, stat)
+	deleted, err := pathDB.Delete(ctx, &query.Params{SegIDs: [][]byte{{0xff}}})
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted, "Deleted")
+	deleted, err = pathDB.Delete(ctx, &query.Params{SegIDs: [][]byte{segID1}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted, "Deleted")
+	res, err := pathDB.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, segID2, res[0].Seg.ID())
+	deleted, err = pathDB.Delete(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted, "Deleted")
+}
+
 func testGetMixed(t *testing.T, pathDB pathdb.ReadWrite) {
 	// Setup
 	TS := uint32(10)
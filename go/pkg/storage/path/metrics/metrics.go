@@ -39,6 +39,7 @@ const (
 	promOpInsert          promOp = "insert"
 	promOpInsertHpCfg     promOp = "insert_with_hpcfg"
 	promOpDeleteExpired   promOp = "delete_expired"
+	promOpDelete          promOp = "delete"
 	promOpGet             promOp = "get"
 	promOpGetAll          promOp = "get_all"
 	promOpInsertNextQuery promOp = "insert_next_query"
@@ -201,6 +202,16 @@ func (db *metricsExecutor) DeleteExpired(ctx context.Context, now time.Time) (in
 	return cnt, err
 }
 
+func (db *metricsExecutor) Delete(ctx context.Context, params *query.Params) (int, error) {
+	var cnt int
+	var err error
+	db.metrics.Observe(ctx, promOpDelete, func(ctx context.Context) error {
+		cnt, err = db.pathDB.Delete(ctx, params)
+		return err
+	})
+	return cnt, err
+}
+
 func (db *metricsExecutor) Get(ctx context.Context, params *query.Params) (query.Results, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, fmt.Sprintf("pathdb.%s", string(promOpGet)))
 	defer span.Finish()
@@ -379,6 +379,15 @@ func (e *executor) DeleteExpired(ctx context.Context, now time.Time) (int, error
 	})
 }
 
+func (e *executor) Delete(ctx context.Context, params *query.Params) (int, error) {
+	stmt, args := e.buildQuery(params)
+	return e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := fmt.Sprintf(`DELETE FROM Segments WHERE RowID IN (SELECT SegRowID FROM (%s))`,
+			stmt)
+		return tx.ExecContext(ctx, delStmt, args...)
+	})
+}
+
 func (e *executor) deleteInTx(ctx context.Context,
 	delFunc func(tx *sql.Tx) (sql.Result, error)) (int, error) {
 
@@ -436,8 +445,8 @@ func (e *executor) Get(ctx context.Context, params *query.Params) (query.Results
 func (e *executor) buildQuery(params *query.Params) (string, []interface{}) {
 	var args []interface{}
 	query := []string{
-		"SELECT DISTINCT s.RowID, s.Segment, s.LastUpdated, group_concat(DISTINCT t.Type), " +
-			"group_concat(DISTINCT h.GroupID) FROM Segments s",
+		"SELECT DISTINCT s.RowID AS SegRowID, s.Segment, s.LastUpdated, " +
+			"group_concat(DISTINCT t.Type), group_concat(DISTINCT h.GroupID) FROM Segments s",
 		"JOIN SegTypes t ON t.SegRowID=s.RowID",
 		"JOIN HPGroupIDs h ON h.SegRowID=s.RowID",
 	}
@@ -0,0 +1,364 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transfer implements a simple file transfer protocol that spreads a
+// file over multiple streams, e.g., QUIC streams over different SCION paths.
+//
+// Every stream starts with a preamble that contains the size of the file
+// (64-bit) and the number of streams of the transfer (32-bit), followed by the
+// chunks of the file that are sent on this stream. All integers are encoded in
+// big-endian byte order.
+// Each chunk consists of a header with the offset of the chunk in the file
+// (64-bit) and the length of the chunk (32-bit), followed by the chunk data.
+// A chunk with zero length terminates the stream, and the receiver
+// acknowledges the termination with a single byte. The chunks are distributed
+// to the streams on demand, such that faster streams transfer more chunks.
+package transfer
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// HeaderLen is the length of the chunk header.
+	HeaderLen = 12
+	// DefaultChunkSize is the default size of the chunks.
+	DefaultChunkSize = 64 * 1024
+	// MaxChunkSize is the maximum size of a chunk that is accepted.
+	MaxChunkSize = 1024 * 1024
+
+	preambleLen      = 12
+	ack         byte = 1
+)
+
+// Header is the header of a chunk.
+type Header struct {
+	// Offset is the offset of the chunk in the file.
+	Offset uint64
+	// Length is the length of the chunk data.
+	Length uint32
+}
+
+// Encode encodes the header into b. The buffer must be at least HeaderLen
+// bytes long.
+func (h Header) Encode(b []byte) {
+	binary.BigEndian.PutUint64(b, h.Offset)
+	binary.BigEndian.PutUint32(b[8:], h.Length)
+}
+
+// DecodeHeader decodes the header from b. The buffer must be at least
+// HeaderLen bytes long.
+func DecodeHeader(b []byte) Header {
+	return Header{
+		Offset: binary.BigEndian.Uint64(b),
+		Length: binary.BigEndian.Uint32(b[8:]),
+	}
+}
+
+// Stats contains the statistics of one stream of a transfer.
+type Stats struct {
+	// Bytes is the number of file bytes transferred over the stream.
+	Bytes int64
+	// Chunks is the number of chunks transferred over the stream.
+	Chunks int
+	// Duration is the duration from the start of the transfer until the
+	// stream was acknowledged by the receiver.
+	Duration time.Duration
+}
+
+// Throughput returns the throughput of the stream in bits per second.
+func (s Stats) Throughput() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Bytes*8) / s.Duration.Seconds()
+}
+
+// Send sends the file of the given size over the streams. It returns the
+// statistics per stream, in the order of the streams. The streams are not
+// closed.
+func Send(ctx context.Context, src io.ReaderAt, size int64, streams []io.ReadWriter,
+	chunkSize int) ([]Stats, error) {
+
+	if len(streams) == 0 {
+		return nil, serrors.New("no streams")
+	}
+	if chunkSize <= 0 || chunkSize > MaxChunkSize {
+		return nil, serrors.New("invalid chunk size", "size", chunkSize, "max", MaxChunkSize)
+	}
+	var next int64
+	take := func() (int64, int, bool) {
+		offset := atomic.AddInt64(&next, int64(chunkSize)) - int64(chunkSize)
+		if offset >= size {
+			return 0, 0, false
+		}
+		n := size - offset
+		if n > int64(chunkSize) {
+			n = int64(chunkSize)
+		}
+		return offset, int(n), true
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	start := time.Now()
+	stats := make([]Stats, len(streams))
+	errs := make([]error, len(streams))
+	var wg sync.WaitGroup
+	for i, s := range streams {
+		wg.Add(1)
+		go func(i int, s io.ReadWriter) {
+			defer wg.Done()
+			if err := sendStream(ctx, src, size, len(streams), s, take, &stats[i]); err != nil {
+				errs[i] = serrors.WithCtx(err, "stream", i)
+				// Abort the other streams, the chunks taken by this stream
+				// would never arrive.
+				cancel()
+				return
+			}
+			stats[i].Duration = time.Since(start)
+		}(i, s)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+func sendStream(ctx context.Context, src io.ReaderAt, size int64, streams int,
+	s io.ReadWriter, take func() (int64, int, bool), stats *Stats) error {
+
+	var preamble [preambleLen]byte
+	binary.BigEndian.PutUint64(preamble[:], uint64(size))
+	binary.BigEndian.PutUint32(preamble[8:], uint32(streams))
+	if _, err := s.Write(preamble[:]); err != nil {
+		return serrors.WrapStr("writing preamble", err)
+	}
+	buf := make([]byte, HeaderLen+MaxChunkSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		offset, n, ok := take()
+		if !ok {
+			break
+		}
+		Header{Offset: uint64(offset), Length: uint32(n)}.Encode(buf)
+		if _, err := src.ReadAt(buf[HeaderLen:HeaderLen+n], offset); err != nil &&
+			err != io.EOF {
+
+			return serrors.WrapStr("reading file", err, "offset", offset)
+		}
+		if _, err := s.Write(buf[:HeaderLen+n]); err != nil {
+			return serrors.WrapStr("writing chunk", err, "offset", offset)
+		}
+		stats.Bytes += int64(n)
+		stats.Chunks++
+	}
+	Header{}.Encode(buf)
+	if _, err := s.Write(buf[:HeaderLen]); err != nil {
+		return serrors.WrapStr("writing end of stream", err)
+	}
+	if _, err := io.ReadFull(s, buf[:1]); err != nil {
+		return serrors.WrapStr("reading acknowledgement", err)
+	}
+	if buf[0] != ack {
+		return serrors.New("invalid acknowledgement", "value", buf[0])
+	}
+	return nil
+}
+
+// Acceptor accepts the streams of a transfer.
+type Acceptor interface {
+	AcceptCtx(ctx context.Context) (net.Conn, error)
+}
+
+// Receive receives a file that is sent with Send, and writes it to dst. It
+// accepts streams until all streams of the sender have been terminated, and
+// returns the statistics per stream in the order of acceptance. The accepted
+// streams are closed before Receive returns.
+func Receive(ctx context.Context, dst io.WriterAt, acceptor Acceptor) ([]Stats, error) {
+	r := &receiver{dst: dst, size: -1}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		idx   int
+		stats Stats
+		err   error
+	}
+	results := make(chan result)
+	done := make(chan struct{})
+	defer close(done)
+	var conns []net.Conn
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	accepted := make(chan net.Conn)
+	acceptErr := make(chan error, 1)
+	go func() {
+		for {
+			conn, err := acceptor.AcceptCtx(ctx)
+			if err != nil {
+				acceptErr <- err
+				return
+			}
+			select {
+			case accepted <- conn:
+			case <-ctx.Done():
+				conn.Close()
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	var stats []Stats
+	finished := 0
+	for {
+		select {
+		case conn := <-accepted:
+			idx := len(stats)
+			stats = append(stats, Stats{})
+			conns = append(conns, conn)
+			go func() {
+				s, err := r.receiveStream(conn)
+				s.Duration = time.Since(start)
+				select {
+				case results <- result{idx: idx, stats: s, err: err}:
+				case <-done:
+				}
+			}()
+		case res := <-results:
+			stats[res.idx] = res.stats
+			if res.err != nil {
+				return stats, serrors.WithCtx(res.err, "stream", res.idx)
+			}
+			finished++
+		case err := <-acceptErr:
+			return stats, serrors.WrapStr("accepting stream", err)
+		}
+		if size, streams, received := r.progress(); finished == streams {
+			if received != size {
+				return stats, serrors.New("incomplete file", "size", size,
+					"received", received)
+			}
+			return stats, nil
+		}
+	}
+}
+
+type receiver struct {
+	dst io.WriterAt
+
+	mu       sync.Mutex
+	size     int64
+	streams  int
+	received int64
+}
+
+func (r *receiver) receiveStream(s io.ReadWriter) (Stats, error) {
+	var stats Stats
+	var preamble [preambleLen]byte
+	if _, err := io.ReadFull(s, preamble[:]); err != nil {
+		return stats, serrors.WrapStr("reading preamble", err)
+	}
+	size := int64(binary.BigEndian.Uint64(preamble[:]))
+	streams := int(binary.BigEndian.Uint32(preamble[8:]))
+	if err := r.init(size, streams); err != nil {
+		return stats, err
+	}
+	buf := make([]byte, HeaderLen+MaxChunkSize)
+	for {
+		if _, err := io.ReadFull(s, buf[:HeaderLen]); err != nil {
+			return stats, serrors.WrapStr("reading chunk header", err)
+		}
+		h := DecodeHeader(buf)
+		if h.Length == 0 {
+			break
+		}
+		if h.Length > MaxChunkSize {
+			return stats, serrors.New("chunk too large", "length", h.Length)
+		}
+		if h.Offset+uint64(h.Length) > uint64(size) {
+			return stats, serrors.New("chunk exceeds file size",
+				"offset", h.Offset, "length", h.Length, "size", size)
+		}
+		data := buf[HeaderLen : HeaderLen+int(h.Length)]
+		if _, err := io.ReadFull(s, data); err != nil {
+			return stats, serrors.WrapStr("reading chunk", err, "offset", h.Offset)
+		}
+		if _, err := r.dst.WriteAt(data, int64(h.Offset)); err != nil {
+			return stats, serrors.WrapStr("writing file", err, "offset", h.Offset)
+		}
+		r.add(int64(h.Length))
+		stats.Bytes += int64(h.Length)
+		stats.Chunks++
+	}
+	if _, err := s.Write([]byte{ack}); err != nil {
+		return stats, serrors.WrapStr("writing acknowledgement", err)
+	}
+	return stats, nil
+}
+
+// init initializes the transfer with the information from the preamble of a
+// stream. All streams must announce the same information.
+func (r *receiver) init(size int64, streams int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size == -1 {
+		if size < 0 || streams <= 0 {
+			return serrors.New("invalid preamble", "size", size, "streams", streams)
+		}
+		r.size, r.streams = size, streams
+		return nil
+	}
+	if r.size != size || r.streams != streams {
+		return serrors.New("preamble mismatch between streams",
+			"expected_size", r.size, "actual_size", size,
+			"expected_streams", r.streams, "actual_streams", streams)
+	}
+	return nil
+}
+
+func (r *receiver) add(n int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received += n
+}
+
+// progress returns the size of the file, the number of streams and the
+// number of received bytes. The number of streams is -1 if no preamble has
+// been received yet.
+func (r *receiver) progress() (int64, int, int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size == -1 {
+		return -1, -1, 0
+	}
+	return r.size, r.streams, r.received
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transfer_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/pkg/transfer"
+)
+
+func TestHeader(t *testing.T) {
+	h := transfer.Header{Offset: 1 << 40, Length: 4096}
+	buf := make([]byte, transfer.HeaderLen)
+	h.Encode(buf)
+	assert.Equal(t, h, transfer.DecodeHeader(buf))
+}
+
+func TestTransfer(t *testing.T) {
+	testCases := map[string]struct {
+		Size      int
+		Streams   int
+		ChunkSize int
+	}{
+		"empty file":             {Size: 0, Streams: 1, ChunkSize: 16},
+		"single stream":          {Size: 1000, Streams: 1, ChunkSize: 64},
+		"multiple streams":       {Size: 100000, Streams: 3, ChunkSize: 1000},
+		"partial last chunk":     {Size: 1001, Streams: 2, ChunkSize: 100},
+		"more streams than data": {Size: 10, Streams: 4, ChunkSize: 100},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			data := make([]byte, tc.Size)
+			rand.Read(data)
+			acceptor := &pipeAcceptor{conns: make(chan net.Conn, tc.Streams)}
+			var streams []io.ReadWriter
+			for i := 0; i < tc.Streams; i++ {
+				local, remote := net.Pipe()
+				defer local.Close()
+				acceptor.conns <- remote
+				streams = append(streams, local)
+			}
+
+			dst := &bufferAt{}
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				stats, err := transfer.Receive(ctx, dst, acceptor)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(tc.Size), sumBytes(stats))
+			}()
+			stats, err := transfer.Send(ctx, bytes.NewReader(data), int64(tc.Size), streams,
+				tc.ChunkSize)
+			require.NoError(t, err)
+			assert.Len(t, stats, tc.Streams)
+			assert.Equal(t, int64(tc.Size), sumBytes(stats))
+			wg.Wait()
+			assert.Equal(t, data, dst.Bytes(tc.Size))
+		})
+	}
+}
+
+func TestReceiveRejectsOversizedChunk(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	local, remote := net.Pipe()
+	defer local.Close()
+	acceptor := &pipeAcceptor{conns: make(chan net.Conn, 1)}
+	acceptor.conns <- remote
+
+	go func() {
+		// Preamble announcing a file of 10 bytes sent over one stream.
+		buf := make([]byte, 12+transfer.HeaderLen)
+		buf[7], buf[11] = 10, 1
+		transfer.Header{Offset: 5, Length: 10}.Encode(buf[12:])
+		local.Write(buf)
+	}()
+	_, err := transfer.Receive(ctx, &bufferAt{}, acceptor)
+	assert.Error(t, err)
+}
+
+type pipeAcceptor struct {
+	conns chan net.Conn
+}
+
+func (a *pipeAcceptor) AcceptCtx(ctx context.Context) (net.Conn, error) {
+	select {
+	case conn := <-a.conns:
+		return conn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type bufferAt struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (b *bufferAt) WriteAt(p []byte, off int64) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if end := int(off) + len(p); end > len(b.buf) {
+		b.buf = append(b.buf, make([]byte, end-len(b.buf))...)
+	}
+	return copy(b.buf[off:], p), nil
+}
+
+func (b *bufferAt) Bytes(size int) []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append(make([]byte, 0, size), b.buf...)
+}
+
+func sumBytes(stats []transfer.Stats) int64 {
+	var sum int64
+	for _, s := range stats {
+		sum += s.Bytes
+	}
+	return sum
+}
@@ -33,4 +33,18 @@ var (
 		},
 		[]string{"type", prom.LabelResult},
 	)
+	TLSUnauthenticatedPeersTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "trustengine_tls_unauthenticated_peers_total",
+			Help: "Total number of accepted TLS peers that could not be authenticated.",
+		},
+		[]string{},
+	)
+	TLSBootstrapPeersTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "trustengine_tls_bootstrap_peers_total",
+			Help: "Total number of accepted TLS peers of ISDs for which no TRC is available.",
+		},
+		[]string{},
+	)
 )
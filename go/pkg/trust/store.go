@@ -16,6 +16,7 @@ package trust
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -24,6 +25,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
 )
@@ -155,3 +157,85 @@ func LoadTRCs(ctx context.Context, dir string, db DB) (LoadResult, error) {
 	}
 	return res, nil
 }
+
+// ImportTRC verifies the TRC and inserts it in the database. A base TRC is only
+// accepted if no TRC for the ISD is present in the database yet. A TRC update
+// is verified against its predecessor, which must be present in the database.
+// The return value indicates whether the TRC was inserted, i.e., false is
+// returned if the TRC was already present.
+func ImportTRC(ctx context.Context, trc cppki.SignedTRC, db DB) (bool, error) {
+	id := trc.TRC.ID
+	var predecessor *cppki.TRC
+	if id.IsBase() {
+		latest, err := db.SignedTRC(ctx, cppki.TRCID{
+			ISD:    id.ISD,
+			Base:   scrypto.LatestVer,
+			Serial: scrypto.LatestVer,
+		})
+		if err != nil {
+			return false, serrors.WrapStr("loading latest TRC", err, "isd", id.ISD)
+		}
+		if !latest.IsZero() && latest.TRC.ID != id {
+			return false, serrors.New("TRC for ISD already present, base TRC not accepted",
+				"id", id, "latest", latest.TRC.ID)
+		}
+	} else {
+		prev, err := db.SignedTRC(ctx, cppki.TRCID{
+			ISD:    id.ISD,
+			Base:   id.Base,
+			Serial: id.Serial - 1,
+		})
+		if err != nil {
+			return false, serrors.WrapStr("loading predecessor TRC", err, "id", id)
+		}
+		if prev.IsZero() {
+			return false, serrors.New("predecessor TRC not found", "id", id)
+		}
+		predecessor = &prev.TRC
+	}
+	if err := trc.Verify(predecessor); err != nil {
+		return false, serrors.WrapStr("verifying TRC", err, "id", id)
+	}
+	inserted, err := db.InsertTRC(ctx, trc)
+	if err != nil {
+		return false, serrors.WrapStr("inserting TRC", err, "id", id)
+	}
+	return inserted, nil
+}
+
+// ImportChain verifies the certificate chain against the active TRCs of its ISD
+// and inserts it in the database. The return value indicates whether the chain
+// was inserted, i.e., false is returned if the chain was already present.
+func ImportChain(ctx context.Context, chain []*x509.Certificate, db DB) (bool, error) {
+	if err := cppki.ValidateChain(chain); err != nil {
+		return false, serrors.WrapStr("validating certificate chain", err)
+	}
+	validity := cppki.Validity{NotBefore: chain[0].NotBefore, NotAfter: chain[0].NotAfter}
+	if !validity.Contains(time.Now()) {
+		return false, serrors.WithCtx(ErrOutsideValidity, "validity", validity)
+	}
+	ia, err := cppki.ExtractIA(chain[0].Subject)
+	if err != nil {
+		return false, serrors.WrapStr("extracting ISD-AS", err)
+	}
+	trcs, _, err := activeTRCs(ctx, db, ia.I)
+	if err != nil {
+		return false, serrors.WrapStr("loading TRC(s) to verify certificate chain", err,
+			"isd", ia.I)
+	}
+	var verifyErrors serrors.List
+	for _, trc := range trcs {
+		opts := cppki.VerifyOptions{TRC: []*cppki.TRC{&trc.TRC}}
+		if err := cppki.VerifyChain(chain, opts); err != nil {
+			verifyErrors = append(verifyErrors, err)
+		}
+	}
+	if len(verifyErrors) == len(trcs) {
+		return false, serrors.WrapStr("verifying certificate chain", verifyErrors.ToError())
+	}
+	inserted, err := db.InsertChain(ctx, chain)
+	if err != nil {
+		return false, serrors.WrapStr("inserting certificate chain", err)
+	}
+	return inserted, nil
+}
@@ -317,3 +317,92 @@ func TestLoadTRCs(t *testing.T) {
 		})
 	}
 }
+
+func TestImportTRC(t *testing.T) {
+	if *updateNonDeterministic {
+		t.Skip("test crypto is being updated")
+	}
+	trc1 := xtest.LoadTRC(t, filepath.Join(goldenDir, "trcs/ISD1-B1-S1.trc"))
+	trc2 := xtest.LoadTRC(t, filepath.Join(goldenDir, "trcs/ISD1-B1-S2.trc"))
+	latest := cppki.TRCID{ISD: 1}
+
+	testCases := map[string]struct {
+		TRC          cppki.SignedTRC
+		SetupDB      func(*gomock.Controller) trust.DB
+		Inserted     bool
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"base TRC": {
+			TRC: trc1,
+			SetupDB: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, latest).Return(cppki.SignedTRC{}, nil)
+				db.EXPECT().InsertTRC(ctxMatcher{}, trc1).Return(true, nil)
+				return db
+			},
+			Inserted:     true,
+			ErrAssertion: assert.NoError,
+		},
+		"base TRC already present": {
+			TRC: trc1,
+			SetupDB: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, latest).Return(trc1, nil)
+				db.EXPECT().InsertTRC(ctxMatcher{}, trc1).Return(false, nil)
+				return db
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"base TRC with newer TRC present": {
+			TRC: trc1,
+			SetupDB: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, latest).Return(trc2, nil)
+				return db
+			},
+			ErrAssertion: assert.Error,
+		},
+		"TRC update": {
+			TRC: trc2,
+			SetupDB: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, trc1.TRC.ID).Return(trc1, nil)
+				db.EXPECT().InsertTRC(ctxMatcher{}, trc2).Return(true, nil)
+				return db
+			},
+			Inserted:     true,
+			ErrAssertion: assert.NoError,
+		},
+		"TRC update without predecessor": {
+			TRC: trc2,
+			SetupDB: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, trc1.TRC.ID).Return(cppki.SignedTRC{}, nil)
+				return db
+			},
+			ErrAssertion: assert.Error,
+		},
+		"db.SignedTRC error": {
+			TRC: trc2,
+			SetupDB: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, trc1.TRC.ID).Return(
+					cppki.SignedTRC{}, serrors.New("db failed"))
+				return db
+			},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			inserted, err := trust.ImportTRC(context.Background(), tc.TRC, tc.SetupDB(ctrl))
+			tc.ErrAssertion(t, err)
+			assert.Equal(t, tc.Inserted, inserted)
+		})
+	}
+}
@@ -18,14 +18,21 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
 )
 
 const defaultTimeout = 5 * time.Second
 
+// errUnknownTRC indicates that no TRC is available for the ISD of the peer.
+var errUnknownTRC = serrors.New("unknown TRC")
+
 // X509KeyPairLoader provides a certificate to be presented during TLS handshake.
 type X509KeyPairLoader interface {
 	LoadX509KeyPair() (*tls.Certificate, error)
@@ -36,6 +43,27 @@ type TLSCryptoManager struct {
 	Loader  X509KeyPairLoader
 	DB      DB
 	Timeout time.Duration
+	// AllowUnauthenticated indicates that peers which do not present a
+	// certificate chain that can be verified against the TRCs are accepted.
+	// This is meant for the migration from unauthenticated connections, and
+	// should not be used otherwise.
+	AllowUnauthenticated bool
+	// RestrictUnauthenticated indicates that clients which cannot be
+	// authenticated are accepted as well. They are not authenticated by
+	// PeerIA, and the server must restrict the RPCs they can issue, see
+	// grpc.Authorizer. It does not affect the client side.
+	RestrictUnauthenticated bool
+	// Bootstrap indicates that peers of ISDs for which no TRC is available are
+	// accepted. Such peers cannot be authenticated, but they can fetch the TRCs
+	// that are required to authenticate each other. They are not authenticated
+	// by PeerIA, see IsBootstrapPeer.
+	Bootstrap bool
+	// UnauthenticatedPeers counts the accepted peers that could not be
+	// authenticated, except for the peers counted in BootstrapPeers.
+	UnauthenticatedPeers metrics.Counter
+	// BootstrapPeers counts the accepted peers of ISDs for which no TRC is
+	// available. It is only incremented if Bootstrap is set.
+	BootstrapPeers metrics.Counter
 }
 
 // NewTLSCryptoManager returns a new instance with the defaultTimeout.
@@ -66,18 +94,134 @@ func (m *TLSCryptoManager) GetClientCertificate(_ *tls.CertificateRequestInfo) (
 	return c, nil
 }
 
-// VerifyPeerCertificate verifies the certificate presented by the peer during TLS handshake,
-// based on the TRC.
+// ServerTLSConfig returns the TLS configuration for servers. Clients must
+// present their AS certificate chain, unless unauthenticated clients are
+// allowed or restricted.
+func (m *TLSCryptoManager) ServerTLSConfig() *tls.Config {
+	clientAuth := tls.RequireAnyClientCert
+	if m.AllowUnauthenticated || m.RestrictUnauthenticated {
+		clientAuth = tls.RequestClientCert
+	}
+	return &tls.Config{
+		GetCertificate:        m.GetCertificate,
+		ClientAuth:            clientAuth,
+		VerifyPeerCertificate: m.VerifyPeerCertificate,
+		NextProtos:            []string{"SCION"},
+	}
+}
+
+// ClientTLSConfig returns the TLS configuration for clients. The standard
+// verification of the server certificate is skipped, the certificate chain is
+// verified against the TRCs and the ISD-AS in the server name instead, see
+// VerifyConnection.
+func (m *TLSCryptoManager) ClientTLSConfig() *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify:   true,
+		GetClientCertificate: m.GetClientCertificate,
+		VerifyConnection:     m.VerifyConnection,
+		NextProtos:           []string{"SCION"},
+	}
+}
+
+// VerifyPeerCertificate verifies the certificate presented by the client during TLS
+// handshake, based on the TRC. If unauthenticated clients are allowed or restricted,
+// verification failures are only counted.
+//
+// Clients of ISDs for which no TRC is available cannot be authenticated. They are
+// accepted in bootstrap mode, or if unauthenticated clients are allowed or restricted.
 func (m *TLSCryptoManager) VerifyPeerCertificate(rawCerts [][]byte,
 	_ [][]*x509.Certificate) error {
+
+	chain, err := parseChain(rawCerts)
+	if err != nil {
+		return m.accept(err, m.RestrictUnauthenticated)
+	}
+	return m.accept(m.verify(chain), m.RestrictUnauthenticated)
+}
+
+// VerifyConnection verifies the certificate chain presented by the server
+// during the TLS handshake. The client sets the ISD-AS of the server it
+// connects to as server name, and the chain must be issued to that ISD-AS.
+// Servers that present a chain for a different ISD-AS are always rejected.
+// Otherwise, verification failures are handled as in VerifyPeerCertificate,
+// except that RestrictUnauthenticated does not apply.
+func (m *TLSCryptoManager) VerifyConnection(cs tls.ConnectionState) error {
+	expected, err := addr.IAFromString(cs.ServerName)
+	if err != nil {
+		return m.accept(serrors.WrapStr("parsing ISD-AS from server name", err,
+			"server_name", cs.ServerName), false)
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return m.accept(serrors.New("no peer certificate"), false)
+	}
+	ia, err := cppki.ExtractIA(cs.PeerCertificates[0].Subject)
+	if err != nil {
+		return m.accept(serrors.WrapStr("extracting ISD-AS from peer certificate", err),
+			false)
+	}
+	if !ia.Equal(expected) {
+		return serrors.New("server certificate does not match the expected ISD-AS",
+			"expected", expected, "actual", ia)
+	}
+	return m.accept(m.verify(cs.PeerCertificates), false)
+}
+
+// PeerIA returns the ISD-AS of the peer that presented the certificate chain
+// during the TLS handshake. Peers are not necessarily authenticated during the
+// handshake, see VerifyPeerCertificate, and the chain is verified again.
+func (m *TLSCryptoManager) PeerIA(chain []*x509.Certificate) (addr.IA, error) {
+	if len(chain) == 0 {
+		return addr.IA{}, serrors.New("no peer certificate")
+	}
+	if err := m.verify(chain); err != nil {
+		return addr.IA{}, err
+	}
+	return cppki.ExtractIA(chain[0].Subject)
+}
+
+// IsBootstrapPeer indicates whether the peer that presented the certificate
+// chain is accepted in bootstrap mode, i.e., whether Bootstrap is set and no
+// TRC is available for the ISD of the peer.
+func (m *TLSCryptoManager) IsBootstrapPeer(chain []*x509.Certificate) bool {
+	return m.Bootstrap && errors.Is(m.verify(chain), errUnknownTRC)
+}
+
+// accept decides whether the handshake continues after the peer certificate
+// chain was verified with the given result. Restricted indicates that the
+// peer is accepted, even if it cannot be authenticated.
+func (m *TLSCryptoManager) accept(err error, restricted bool) error {
+	switch {
+	case err == nil:
+		return nil
+	case m.Bootstrap && errors.Is(err, errUnknownTRC):
+		metrics.CounterInc(m.BootstrapPeers)
+		log.Debug("Accepting peer without TRC for bootstrapping", "err", err)
+		return nil
+	case m.AllowUnauthenticated || restricted:
+		metrics.CounterInc(m.UnauthenticatedPeers)
+		log.Debug("Accepting unauthenticated peer", "err", err)
+		return nil
+	default:
+		return err
+	}
+}
+
+func parseChain(rawCerts [][]byte) ([]*x509.Certificate, error) {
 	chain := make([]*x509.Certificate, len(rawCerts))
 	for i, asn1Data := range rawCerts {
 		cert, err := x509.ParseCertificate(asn1Data)
 		if err != nil {
-			return serrors.WrapStr("parsing peer certificate", err)
+			return nil, serrors.WrapStr("parsing peer certificate", err)
 		}
 		chain[i] = cert
 	}
+	return chain, nil
+}
+
+func (m *TLSCryptoManager) verify(chain []*x509.Certificate) error {
+	if len(chain) == 0 {
+		return serrors.New("no peer certificate")
+	}
 	ia, err := cppki.ExtractIA(chain[0].Subject)
 	if err != nil {
 		return serrors.WrapStr("extracting ISD-AS from peer certificate", err)
@@ -85,8 +229,11 @@ func (m *TLSCryptoManager) VerifyPeerCertificate(rawCerts [][]byte,
 	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
 	defer cancel()
 	trcs, _, err := activeTRCs(ctx, m.DB, ia.I)
+	if errors.Is(err, errNotFound) {
+		return serrors.Wrap(errUnknownTRC, err, "isd", ia.I)
+	}
 	if err != nil {
-		return serrors.WrapStr("loading TRCs", err)
+		return serrors.WrapStr("loading TRCs", err, "isd", ia.I)
 	}
 	if err := verifyChain(chain, trcs); err != nil {
 		return serrors.WrapStr("verifying chains", err)
@@ -16,6 +16,7 @@ package trust_test
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"net"
 	"testing"
 	"time"
@@ -24,7 +25,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/pkg/trust"
 	"github.com/scionproto/scion/go/pkg/trust/mock_trust"
@@ -35,8 +38,12 @@ func TestTLSCryptoManagerVerifyPeerCertificate(t *testing.T) {
 	crt111File := "testdata/common/ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem"
 
 	testCases := map[string]struct {
-		db        func(ctrl *gomock.Controller) trust.DB
-		assertErr assert.ErrorAssertionFunc
+		db           func(ctrl *gomock.Controller) trust.DB
+		allow        bool
+		bootstrap    bool
+		assertErr    assert.ErrorAssertionFunc
+		counted      int
+		bootstrapped int
 	}{
 		"valid": {
 			db: func(ctrl *gomock.Controller) trust.DB {
@@ -46,6 +53,56 @@ func TestTLSCryptoManagerVerifyPeerCertificate(t *testing.T) {
 			},
 			assertErr: assert.NoError,
 		},
+		"unknown TRC": {
+			db: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(
+					cppki.SignedTRC{}, nil)
+				return db
+			},
+			assertErr: assert.Error,
+		},
+		"unknown TRC allowed": {
+			db: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(
+					cppki.SignedTRC{}, nil)
+				return db
+			},
+			allow:     true,
+			assertErr: assert.NoError,
+			counted:   1,
+		},
+		"unknown TRC bootstrap": {
+			db: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(
+					cppki.SignedTRC{}, nil)
+				return db
+			},
+			bootstrap:    true,
+			assertErr:    assert.NoError,
+			bootstrapped: 1,
+		},
+		"db error": {
+			db: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(
+					cppki.SignedTRC{}, serrors.New("internal"))
+				return db
+			},
+			assertErr: assert.Error,
+		},
+		"db error bootstrap": {
+			db: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(
+					cppki.SignedTRC{}, serrors.New("internal"))
+				return db
+			},
+			bootstrap: true,
+			assertErr: assert.Error,
+		},
 	}
 	for name, tc := range testCases {
 		name, tc := name, tc
@@ -54,17 +111,212 @@ func TestTLSCryptoManagerVerifyPeerCertificate(t *testing.T) {
 			defer ctrl.Finish()
 
 			db := tc.db(ctrl)
+			counter := metrics.NewTestCounter()
+			bootstrapCounter := metrics.NewTestCounter()
 			mgr := trust.TLSCryptoManager{
-				DB:      db,
-				Timeout: 5 * time.Second,
+				DB:                   db,
+				Timeout:              5 * time.Second,
+				AllowUnauthenticated: tc.allow,
+				Bootstrap:            tc.bootstrap,
+				UnauthenticatedPeers: counter,
+				BootstrapPeers:       bootstrapCounter,
 			}
 			rawChain := loadRawChain(t, crt111File)
 			err := mgr.VerifyPeerCertificate(rawChain, nil)
 			tc.assertErr(t, err)
+			assert.Equal(t, float64(tc.counted), metrics.CounterValue(counter))
+			assert.Equal(t, float64(tc.bootstrapped), metrics.CounterValue(bootstrapCounter))
+		})
+	}
+}
+
+func TestTLSCryptoManagerVerifyConnection(t *testing.T) {
+	trc := xtest.LoadTRC(t, "testdata/common/trcs/ISD1-B1-S1.trc")
+	crt111File := "testdata/common/ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem"
+	chain := xtest.LoadChain(t, crt111File)
+
+	testCases := map[string]struct {
+		serverName string
+		chain      []*x509.Certificate
+		unknownTRC bool
+		allow      bool
+		restrict   bool
+		bootstrap  bool
+		assertErr  assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			serverName: "1-ff00:0:111",
+			chain:      chain,
+			assertErr:  assert.NoError,
+		},
+		"mismatch": {
+			serverName: "1-ff00:0:110",
+			chain:      chain,
+			assertErr:  assert.Error,
+		},
+		"mismatch allowed": {
+			serverName: "1-ff00:0:110",
+			chain:      chain,
+			allow:      true,
+			assertErr:  assert.Error,
+		},
+		"no server name": {
+			chain:     chain,
+			assertErr: assert.Error,
+		},
+		"no certificate": {
+			serverName: "1-ff00:0:111",
+			assertErr:  assert.Error,
+		},
+		"no certificate allowed": {
+			serverName: "1-ff00:0:111",
+			allow:      true,
+			assertErr:  assert.NoError,
+		},
+		"no certificate restricted": {
+			serverName: "1-ff00:0:111",
+			restrict:   true,
+			assertErr:  assert.Error,
+		},
+		"unknown TRC": {
+			serverName: "1-ff00:0:111",
+			chain:      chain,
+			unknownTRC: true,
+			assertErr:  assert.Error,
+		},
+		"unknown TRC bootstrap": {
+			serverName: "1-ff00:0:111",
+			chain:      chain,
+			unknownTRC: true,
+			bootstrap:  true,
+			assertErr:  assert.NoError,
+		},
+		"unknown TRC bootstrap mismatch": {
+			serverName: "1-ff00:0:110",
+			chain:      chain,
+			unknownTRC: true,
+			bootstrap:  true,
+			assertErr:  assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db := mock_trust.NewMockDB(ctrl)
+			if tc.unknownTRC {
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).AnyTimes().Return(
+					cppki.SignedTRC{}, nil)
+			} else {
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).AnyTimes().Return(trc, nil)
+			}
+			mgr := trust.TLSCryptoManager{
+				DB:                      db,
+				Timeout:                 5 * time.Second,
+				AllowUnauthenticated:    tc.allow,
+				RestrictUnauthenticated: tc.restrict,
+				Bootstrap:               tc.bootstrap,
+			}
+			err := mgr.VerifyConnection(tls.ConnectionState{
+				ServerName:       tc.serverName,
+				PeerCertificates: tc.chain,
+			})
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestTLSCryptoManagerUnauthenticated(t *testing.T) {
+	crt111File := "testdata/common/ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem"
+
+	testCases := map[string]struct {
+		allow     bool
+		restrict  bool
+		rawChain  [][]byte
+		assertErr assert.ErrorAssertionFunc
+		counted   int
+	}{
+		"no certificate": {
+			assertErr: assert.Error,
+		},
+		"no certificate allowed": {
+			allow:     true,
+			assertErr: assert.NoError,
+			counted:   1,
+		},
+		"unverifiable allowed": {
+			allow:     true,
+			rawChain:  loadRawChain(t, crt111File),
+			assertErr: assert.NoError,
+			counted:   1,
+		},
+		"no certificate restricted": {
+			restrict:  true,
+			assertErr: assert.NoError,
+			counted:   1,
+		},
+		"unverifiable restricted": {
+			restrict:  true,
+			rawChain:  loadRawChain(t, crt111File),
+			assertErr: assert.NoError,
+			counted:   1,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db := mock_trust.NewMockDB(ctrl)
+			db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).AnyTimes().Return(
+				cppki.SignedTRC{}, serrors.New("internal"))
+			counter := metrics.NewTestCounter()
+			mgr := trust.TLSCryptoManager{
+				DB:                      db,
+				Timeout:                 5 * time.Second,
+				AllowUnauthenticated:    tc.allow,
+				RestrictUnauthenticated: tc.restrict,
+				UnauthenticatedPeers:    counter,
+			}
+			err := mgr.VerifyPeerCertificate(tc.rawChain, nil)
+			tc.assertErr(t, err)
+			assert.Equal(t, float64(tc.counted), metrics.CounterValue(counter))
 		})
 	}
 }
 
+func TestTLSCryptoManagerPeerIA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trc := xtest.LoadTRC(t, "testdata/common/trcs/ISD1-B1-S1.trc")
+	crt111File := "testdata/common/ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem"
+	chain := xtest.LoadChain(t, crt111File)
+
+	db := mock_trust.NewMockDB(ctrl)
+	mgr := trust.TLSCryptoManager{
+		DB:      db,
+		Timeout: 5 * time.Second,
+	}
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+	ia, err := mgr.PeerIA(chain)
+	require.NoError(t, err)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:111"), ia)
+
+	// Peers of ISDs without TRC are not authenticated, even in bootstrap mode.
+	mgr.Bootstrap = true
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Times(2).Return(cppki.SignedTRC{}, nil)
+	_, err = mgr.PeerIA(chain)
+	assert.Error(t, err)
+	assert.True(t, mgr.IsBootstrapPeer(chain))
+
+	_, err = mgr.PeerIA(nil)
+	assert.Error(t, err)
+}
+
 func TestHandshake(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -121,6 +373,78 @@ func TestHandshake(t *testing.T) {
 	assert.True(t, server.ConnectionState().HandshakeComplete)
 }
 
+func TestHandshakeUnknownISD(t *testing.T) {
+	testCases := map[string]struct {
+		bootstrap bool
+	}{
+		"rejected": {},
+		"bootstrap": {
+			bootstrap: true,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			crt111File := "testdata/common/ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem"
+			key111File := "testdata/common/ISD1/ASff00_0_111/crypto/as/cp-as.key"
+			tlsCert, err := tls.LoadX509KeyPair(crt111File, key111File)
+			require.NoError(t, err)
+
+			// Neither control service has the TRC for the ISD of its peer.
+			db := mock_trust.NewMockDB(ctrl)
+			db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).AnyTimes().Return(
+				cppki.SignedTRC{}, nil)
+			loader := mock_trust.NewMockX509KeyPairLoader(ctrl)
+			loader.EXPECT().LoadX509KeyPair().AnyTimes().Return(&tlsCert, nil)
+
+			bootstrapped := metrics.NewTestCounter()
+			mgr := trust.NewTLSCryptoManager(loader, db)
+			mgr.Bootstrap = tc.bootstrap
+			mgr.BootstrapPeers = bootstrapped
+			clientConn, serverConn := net.Pipe()
+			defer clientConn.Close()
+			defer serverConn.Close()
+
+			clientCfg := mgr.ClientTLSConfig()
+			clientCfg.ServerName = "1-ff00:0:111"
+			client := tls.Client(clientConn, clientCfg)
+			server := tls.Server(serverConn, mgr.ServerTLSConfig())
+
+			clientErr := make(chan error, 1)
+			go func() {
+				// With TLS 1.3, the client completes the handshake before the
+				// server verifies the client certificate. Read the alert or the
+				// message sent by the server.
+				if err := client.Handshake(); err != nil {
+					clientErr <- err
+					return
+				}
+				_, err := client.Read(make([]byte, 1))
+				clientErr <- err
+			}()
+			err = server.Handshake()
+			if !tc.bootstrap {
+				assert.Error(t, err)
+				assert.False(t, server.ConnectionState().HandshakeComplete)
+				return
+			}
+			require.NoError(t, err)
+			_, err = server.Write([]byte{1})
+			require.NoError(t, err)
+			assert.NoError(t, <-clientErr)
+			assert.Equal(t, float64(2), metrics.CounterValue(bootstrapped))
+
+			// The peers are not authenticated.
+			_, err = mgr.PeerIA(server.ConnectionState().PeerCertificates)
+			assert.Error(t, err)
+			assert.True(t, mgr.IsBootstrapPeer(server.ConnectionState().PeerCertificates))
+		})
+	}
+}
+
 func loadRawChain(t *testing.T, file string) [][]byte {
 	var chain [][]byte
 	for _, cert := range xtest.LoadChain(t, file) {
@@ -33,6 +33,7 @@ import (
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/addrutil"
+	"github.com/scionproto/scion/go/lib/snet/resolver"
 	"github.com/scionproto/scion/go/pkg/app"
 	"github.com/scionproto/scion/go/pkg/app/path"
 	"github.com/scionproto/scion/go/pkg/command"
@@ -63,9 +64,9 @@ func main() {
 		SilenceErrors: true,
 		SilenceUsage:  true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			dst, err := snet.ParseUDPAddr(args[0])
+			dst, err := resolver.ResolveUDPAddr(cmd.Context(), resolver.Default(), args[0])
 			if err != nil {
-				return serrors.WrapStr("parsing destination addr", err)
+				return serrors.WrapStr("resolving destination addr", err)
 			}
 			return run(cfg, dst)
 		},
@@ -25,13 +25,16 @@ import (
 )
 
 type Config struct {
-	Features env.Features          `toml:"features,omitempty"`
-	Logging  log.Config            `toml:"log,omitempty"`
-	Metrics  env.Metrics           `toml:"metrics,omitempty"`
-	API      api.Config            `toml:"api,omitempty"`
-	Daemon   env.Daemon            `toml:"sciond_connection,omitempty"`
-	Gateway  gatewayconfig.Gateway `toml:"gateway,omitempty"`
-	Tunnel   gatewayconfig.Tunnel  `toml:"tunnel,omitempty"`
+	Features         env.Features                   `toml:"features,omitempty"`
+	Logging          log.Config                     `toml:"log,omitempty"`
+	Metrics          env.Metrics                    `toml:"metrics,omitempty"`
+	API              api.Config                     `toml:"api,omitempty"`
+	Daemon           env.Daemon                     `toml:"sciond_connection,omitempty"`
+	Gateway          gatewayconfig.Gateway          `toml:"gateway,omitempty"`
+	Tunnel           gatewayconfig.Tunnel           `toml:"tunnel,omitempty"`
+	TunnelEncryption gatewayconfig.TunnelEncryption `toml:"tunnel_encryption,omitempty"`
+	BGP              gatewayconfig.BGP              `toml:"bgp,omitempty"`
+	HA               gatewayconfig.HA               `toml:"ha,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -43,6 +46,9 @@ func (cfg *Config) InitDefaults() {
 		&cfg.Daemon,
 		&cfg.Gateway,
 		&cfg.Tunnel,
+		&cfg.TunnelEncryption,
+		&cfg.BGP,
+		&cfg.HA,
 	)
 }
 
@@ -55,6 +61,9 @@ func (cfg *Config) Validate() error {
 		&cfg.Daemon,
 		&cfg.Gateway,
 		&cfg.Tunnel,
+		&cfg.TunnelEncryption,
+		&cfg.BGP,
+		&cfg.HA,
 	)
 }
 
@@ -67,5 +76,8 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Daemon,
 		&cfg.Gateway,
 		&cfg.Tunnel,
+		&cfg.TunnelEncryption,
+		&cfg.BGP,
+		&cfg.HA,
 	)
 }
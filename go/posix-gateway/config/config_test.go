@@ -45,6 +45,9 @@ func InitConfig(cfg *config.Config) {
 	apitest.InitConfig(&cfg.API)
 	configtest.InitGateway(&cfg.Gateway)
 	configtest.InitTunnel(&cfg.Tunnel)
+	configtest.InitTunnelEncryption(&cfg.TunnelEncryption)
+	configtest.InitBGP(&cfg.BGP)
+	configtest.InitHA(&cfg.HA)
 }
 
 func CheckConfig(t *testing.T, cfg *config.Config) {
@@ -53,4 +56,7 @@ func CheckConfig(t *testing.T, cfg *config.Config) {
 	configtest.CheckGateway(t, &cfg.Gateway)
 	apitest.CheckConfig(t, &cfg.API)
 	configtest.CheckTunnel(t, &cfg.Tunnel)
+	configtest.CheckTunnelEncryption(t, &cfg.TunnelEncryption)
+	configtest.CheckBGP(t, &cfg.BGP)
+	configtest.CheckHA(t, &cfg.HA)
 }
@@ -20,22 +20,36 @@ import (
 	"net"
 	"net/http"
 	_ "net/http/pprof"
+	"path/filepath"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/resolver"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/daemon"
 	"github.com/scionproto/scion/go/lib/log"
+	libmetrics "github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet/addrutil"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/pkg/app"
 	"github.com/scionproto/scion/go/pkg/app/launcher"
+	cstrust "github.com/scionproto/scion/go/pkg/cs/trust"
+	sdtrust "github.com/scionproto/scion/go/pkg/daemon"
 	"github.com/scionproto/scion/go/pkg/gateway"
 	"github.com/scionproto/scion/go/pkg/gateway/api"
+	"github.com/scionproto/scion/go/pkg/gateway/bgp"
 	"github.com/scionproto/scion/go/pkg/gateway/dataplane"
+	"github.com/scionproto/scion/go/pkg/gateway/encryption"
+	"github.com/scionproto/scion/go/pkg/gateway/ha"
+	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
 	"github.com/scionproto/scion/go/pkg/service"
+	"github.com/scionproto/scion/go/pkg/storage"
+	"github.com/scionproto/scion/go/pkg/trust"
+	trustmetrics "github.com/scionproto/scion/go/pkg/trust/metrics"
 	"github.com/scionproto/scion/go/posix-gateway/config"
 )
 
@@ -126,6 +140,55 @@ func realMain(ctx context.Context) error {
 		"config":    service.NewConfigStatusPage(globalCfg),
 		"log/level": service.NewLogLevelStatusPage(),
 	}
+	var tunnelKeySigner encryption.Signer
+	var tunnelKeyVerifier encryption.Verifier
+	var controlTLS *trust.TLSCryptoManager
+	if cfgDir := globalCfg.TunnelEncryption.ConfigDir; cfgDir != "" {
+		trustDB, err := storage.NewTrustStorage(storage.DBConfig{
+			Connection: globalCfg.TunnelEncryption.TrustDB,
+		})
+		if err != nil {
+			return serrors.WrapStr("initializing trust database", err)
+		}
+		defer trustDB.Close()
+		signer := newASSigner(localIA, trustDB, cfgDir)
+		tunnelKeySigner = signer
+		controlTLS = trust.NewTLSCryptoManager(signer, trustDB)
+		controlTLS.AllowUnauthenticated = globalCfg.TunnelEncryption.AllowUnauthenticatedPeers
+		controlTLS.UnauthenticatedPeers = libmetrics.NewPromCounter(
+			trustmetrics.TLSUnauthenticatedPeersTotal)
+		dialer := &libgrpc.TCPDialer{
+			SvcResolver: func(dst addr.HostSVC) []resolver.Address {
+				return resolveSVC(ctx, daemon, dst)
+			},
+		}
+		engine, err := sdtrust.TrustEngine(cfgDir, localIA, trustDB, dialer)
+		if err != nil {
+			return serrors.WrapStr("creating trust engine", err)
+		}
+		tunnelKeyVerifier = encryption.TrustVerifier{
+			Verifier: trust.Verifier{Engine: engine},
+		}
+		log.Info("Tunnel encryption and peer authentication enabled", "config_dir", cfgDir,
+			"allow_unauthenticated_peers", controlTLS.AllowUnauthenticated)
+	}
+
+	var bgpConfig *bgp.Config
+	if globalCfg.BGP.Enabled() {
+		if bgpConfig, err = globalCfg.BGP.SpeakerConfig(); err != nil {
+			return serrors.WrapStr("creating BGP configuration", err)
+		}
+		log.Info("BGP speaker enabled", "local_as", bgpConfig.LocalAS)
+	}
+
+	var haConfig *ha.Config
+	if globalCfg.HA.Enabled() {
+		electorCfg := globalCfg.HA.ElectorConfig(globalCfg.Gateway.ID)
+		haConfig = &electorCfg
+		log.Info("High availability enabled", "id", haConfig.ID,
+			"priority", haConfig.Priority, "peers", haConfig.Peers)
+	}
+
 	routingTable := &dataplane.AtomicRoutingTable{}
 	gw := &gateway.Gateway{
 		ID:                       globalCfg.Gateway.ID,
@@ -150,6 +213,12 @@ func realMain(ctx context.Context) error {
 		HTTPEndpoints:            httpPages,
 		HTTPServeMux:             http.DefaultServeMux,
 		Metrics:                  gateway.NewMetrics(localIA),
+		TunnelKeySigner:          tunnelKeySigner,
+		TunnelKeyVerifier:        tunnelKeyVerifier,
+		TunnelRekeyInterval:      globalCfg.TunnelEncryption.RekeyInterval.Duration,
+		ControlTLS:               controlTLS,
+		BGP:                      bgpConfig,
+		HA:                       haConfig,
 	}
 
 	g.Go(func() error {
@@ -168,3 +237,44 @@ func realMain(ctx context.Context) error {
 
 	return g.Wait()
 }
+
+// newASSigner creates a renewing signer backed by the AS certificate chain in
+// the configuration directory.
+func newASSigner(ia addr.IA, db trust.DB, cfgDir string) cstrust.RenewingSigner {
+	gen := trust.SignerGen{
+		IA: ia,
+		DB: cstrust.CryptoLoader{
+			Dir:     filepath.Join(cfgDir, "crypto/as"),
+			TRCDirs: []string{filepath.Join(cfgDir, "certs")},
+			DB:      db,
+		},
+		KeyRing: cstrust.LoadingRing{
+			Dir: filepath.Join(cfgDir, "crypto/as"),
+		},
+	}
+	return cstrust.RenewingSigner{
+		SignerGen: &cstrust.CachingSignerGen{
+			SignerGen: gen,
+			Interval:  5 * time.Second,
+		},
+	}
+}
+
+// resolveSVC resolves the addresses of the service in the local AS with the
+// help of the daemon.
+func resolveSVC(ctx context.Context, sd daemon.Connector,
+	svc addr.HostSVC) []resolver.Address {
+
+	ctx, cancelF := context.WithTimeout(ctx, time.Second)
+	defer cancelF()
+	services, err := sd.SVCInfo(ctx, []addr.HostSVC{svc.Base()})
+	if err != nil {
+		log.Info("Failed to resolve service", "svc", svc, "err", err)
+		return nil
+	}
+	uri, ok := services[svc.Base()]
+	if !ok {
+		return nil
+	}
+	return []resolver.Address{{Addr: uri}}
+}
@@ -29,8 +29,10 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/pkg/api/jwtauth"
 	"github.com/scionproto/scion/go/pkg/app"
 	"github.com/scionproto/scion/go/pkg/app/launcher"
+	caconfig "github.com/scionproto/scion/go/pkg/ca/config"
 	"github.com/scionproto/scion/go/pkg/router"
 	"github.com/scionproto/scion/go/pkg/router/api"
 	"github.com/scionproto/scion/go/pkg/router/config"
@@ -99,8 +101,17 @@ func realMain(ctx context.Context) error {
 			LogLevel:  service.NewLogLevelStatusPage().Handler,
 			Dataplane: dp,
 		}
-		log.Info("Exposing API", "addr", globalCfg.API.Addr)
-		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
+		var verifier *jwtauth.HTTPVerifier
+		if globalCfg.Management.SharedSecret != "" {
+			sharedSecret := caconfig.NewPEMSymmetricKey(globalCfg.Management.SharedSecret)
+			verifier = &jwtauth.HTTPVerifier{
+				Generator: sharedSecret.Get,
+				Logger:    log.New("component", "management_api"),
+			}
+		}
+		log.Info("Exposing API", "addr", globalCfg.API.Addr,
+			"management_enabled", verifier != nil)
+		h := api.AdminHandler(api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1"), verifier)
 		mgmtServer := &http.Server{
 			Addr:    globalCfg.API.Addr,
 			Handler: h,
@@ -0,0 +1,345 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/command"
+	storagebeacon "github.com/scionproto/scion/go/pkg/storage/beacon"
+	"github.com/scionproto/scion/go/pkg/storage/beacon/sqlite"
+	"github.com/scionproto/scion/go/pkg/trust"
+)
+
+func newBeacons(pather command.Pather) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "beacons",
+		Short: "Inspect and repair a beacon database",
+		Args:  cobra.NoArgs,
+	}
+	joined := command.JoinedPather{pather, command.StringPather("beacons")}
+	cmd.AddCommand(
+		newBeaconsList(joined),
+		newBeaconsVerify(joined),
+		newBeaconsCheck(joined),
+		newBeaconsPurge(joined),
+	)
+	return cmd
+}
+
+// beaconFlags are the flags that select beacons.
+type beaconFlags struct {
+	commonFlags
+	ias     []string
+	ifaces  []string
+	ingress []uint
+	expired bool
+}
+
+func (f *beaconFlags) register(flags *pflag.FlagSet) {
+	f.commonFlags.register(flags)
+	flags.StringSliceVar(&f.ias, "isd-as", nil,
+		"Only select beacons that traverse one of the ISD-ASes (wildcards allowed)")
+	flags.StringSliceVar(&f.ifaces, "interface", nil,
+		"Only select beacons that traverse one of the interfaces (<ISD-AS>#<interface ID>)")
+	flags.UintSliceVar(&f.ingress, "ingress", nil,
+		"Only select beacons received on one of the local interfaces")
+	flags.BoolVar(&f.expired, "expired", false, "Only select expired beacons")
+}
+
+func (f *beaconFlags) selective() bool {
+	return len(f.ias) != 0 || len(f.ifaces) != 0 || len(f.ingress) != 0 || f.expired
+}
+
+// load loads the selected beacons from the database.
+func (f *beaconFlags) load(ctx context.Context,
+	db storagebeacon.BeaconAPI) ([]storagebeacon.Beacon, error) {
+
+	ias, err := parseIAs(f.ias)
+	if err != nil {
+		return nil, err
+	}
+	ifaces, err := parseInterfaces(f.ifaces)
+	if err != nil {
+		return nil, err
+	}
+	ingress := make([]uint16, 0, len(f.ingress))
+	for _, id := range f.ingress {
+		ingress = append(ingress, uint16(id))
+	}
+	beacons, err := db.GetBeacons(ctx, &storagebeacon.QueryParams{
+		IngressInterfaces: ingress,
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("loading beacons", err)
+	}
+	filter := segmentFilter{ias: ias, ifaces: ifaces, expired: f.expired, now: f.now.Time}
+	var selected []storagebeacon.Beacon
+	for _, b := range beacons {
+		if filter.match(b.Beacon.Segment) {
+			selected = append(selected, b)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		a, b := selected[i].Beacon, selected[j].Beacon
+		if a.InIfId != b.InIfId {
+			return a.InIfId < b.InIfId
+		}
+		return a.Segment.FirstIA().IAInt() < b.Segment.FirstIA().IAInt()
+	})
+	return selected, nil
+}
+
+func beaconInfo(b storagebeacon.Beacon) segmentInfo {
+	info := newSegmentInfo(b.Beacon.Segment, b.LastUpdated)
+	info.Ingress = b.Beacon.InIfId
+	info.Usage = b.Usage.String()
+	return info
+}
+
+func openBeaconDB(file string, write bool) (*sqlite.Backend, error) {
+	conn, err := connection(file, write)
+	if err != nil {
+		return nil, err
+	}
+	// The local ISD-AS is only required for inserting beacons.
+	db, err := sqlite.New(conn, addr.IA{})
+	if err != nil {
+		return nil, serrors.WrapStr("opening beacon database", err, "file", file)
+	}
+	return db, nil
+}
+
+func newBeaconsList(pather command.Pather) *cobra.Command {
+	var flags beaconFlags
+	cmd := &cobra.Command{
+		Use:   "list [flags] <beacon-db>",
+		Short: "List the beacons in a beacon database",
+		Example: fmt.Sprintf(`  %[1]s list cs1-ff00_0_110-1.beacon.db
+  %[1]s list --ingress 1,2 --json cs1-ff00_0_110-1.beacon.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openBeaconDB(args[0], false)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cmd.SilenceUsage = true
+
+			beacons, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			infos := make([]segmentInfo, 0, len(beacons))
+			for _, b := range beacons {
+				infos = append(infos, beaconInfo(b))
+			}
+			if flags.json {
+				return writeJSON(cmd.OutOrStdout(), infos)
+			}
+			for _, info := range infos {
+				fmt.Fprintln(cmd.OutOrStdout(), info)
+			}
+			return nil
+		},
+	}
+	flags.register(cmd.Flags())
+	return cmd
+}
+
+func newBeaconsVerify(pather command.Pather) *cobra.Command {
+	var flags beaconFlags
+	var trustDBFile string
+	cmd := &cobra.Command{
+		Use:   "verify [flags] <beacon-db>",
+		Short: "Verify the signatures of the beacons in a beacon database",
+		Long: `'verify' verifies the signatures of the selected beacons.
+
+The certificate chains and TRCs are taken from the trust database. Missing crypto
+material is not resolved over the network. The command exits with code 1 if any
+beacon fails verification.`,
+		Example: fmt.Sprintf(
+			`  %[1]s verify --trust-db cs1-ff00_0_110-1.trust.db cs1-ff00_0_110-1.beacon.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openBeaconDB(args[0], false)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			trustDB, err := openTrustDB(trustDBFile)
+			if err != nil {
+				return err
+			}
+			defer trustDB.Close()
+			cmd.SilenceUsage = true
+
+			beacons, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			verifier := newVerifier(trustDB)
+			problems := []segmentProblem{}
+			for _, b := range beacons {
+				if err := b.Beacon.Segment.Verify(cmd.Context(), verifier); err != nil {
+					problems = append(problems, segmentProblem{
+						Segment: beaconInfo(b),
+						Problem: err.Error(),
+					})
+				}
+			}
+			return reportSegmentProblems(cmd, flags.json, len(beacons), problems)
+		},
+	}
+	flags.register(cmd.Flags())
+	cmd.Flags().StringVar(&trustDBFile, "trust-db", "", "The trust database (required)")
+	cmd.MarkFlagRequired("trust-db")
+	return cmd
+}
+
+func newBeaconsCheck(pather command.Pather) *cobra.Command {
+	var flags beaconFlags
+	var trustDBFile string
+	cmd := &cobra.Command{
+		Use:   "check [flags] <beacon-db>",
+		Short: "Report expired and orphaned beacons",
+		Long: `'check' reports the selected beacons that are expired or orphaned.
+
+A beacon is orphaned if the certificate chain of any of its signers is missing
+from the trust database. Orphaned beacons are only reported if the trust database
+is provided. The command exits with code 1 if any problem is found.`,
+		Example: fmt.Sprintf(`  %[1]s check cs1-ff00_0_110-1.beacon.db
+  %[1]s check --trust-db cs1-ff00_0_110-1.trust.db cs1-ff00_0_110-1.beacon.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openBeaconDB(args[0], false)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			var trustDB trust.DB
+			if trustDBFile != "" {
+				tdb, err := openTrustDB(trustDBFile)
+				if err != nil {
+					return err
+				}
+				defer tdb.Close()
+				trustDB = tdb
+			}
+			cmd.SilenceUsage = true
+
+			beacons, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			problems := []segmentProblem{}
+			for _, b := range beacons {
+				found, err := checkSegment(cmd.Context(), trustDB, b.Beacon.Segment,
+					flags.now.Time)
+				if err != nil {
+					return err
+				}
+				for _, problem := range found {
+					problems = append(problems, segmentProblem{
+						Segment: beaconInfo(b),
+						Problem: problem,
+					})
+				}
+			}
+			return reportSegmentProblems(cmd, flags.json, len(beacons), problems)
+		},
+	}
+	flags.register(cmd.Flags())
+	cmd.Flags().StringVar(&trustDBFile, "trust-db", "",
+		"The trust database to check for orphaned beacons")
+	return cmd
+}
+
+func newBeaconsPurge(pather command.Pather) *cobra.Command {
+	var flags beaconFlags
+	var write bool
+	cmd := &cobra.Command{
+		Use:   "purge [flags] <beacon-db>",
+		Short: "Delete the selected beacons from a beacon database",
+		Long: `'purge' deletes the selected beacons from the beacon database.
+
+At least one selection flag must be provided. Without the --write flag, the
+beacons that would be deleted are only listed.
+
+Do not run this command with --write while the control service uses the
+database.`,
+		Example: fmt.Sprintf(`  %[1]s purge --expired cs1-ff00_0_110-1.beacon.db
+  %[1]s purge --ingress 2 --write cs1-ff00_0_110-1.beacon.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !flags.selective() {
+				return serrors.New("at least one selection flag must be provided")
+			}
+			db, err := openBeaconDB(args[0], write)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cmd.SilenceUsage = true
+
+			beacons, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			infos := make([]segmentInfo, 0, len(beacons))
+			ids := make([][]byte, 0, len(beacons))
+			for _, b := range beacons {
+				infos = append(infos, beaconInfo(b))
+				ids = append(ids, b.Beacon.Segment.ID())
+			}
+			if flags.json {
+				if err := writeJSON(cmd.OutOrStdout(), infos); err != nil {
+					return err
+				}
+			} else {
+				for _, info := range infos {
+					fmt.Fprintln(cmd.OutOrStdout(), info)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), purgeNote(write, len(ids)))
+			}
+			if !write || len(ids) == 0 {
+				return nil
+			}
+			deleted, err := db.DeleteBeacons(cmd.Context(),
+				&storagebeacon.QueryParams{SegIDs: ids})
+			if err != nil {
+				return serrors.WrapStr("deleting beacons", err)
+			}
+			if !flags.json {
+				fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d entries.\n", deleted)
+			}
+			return nil
+		},
+	}
+	flags.register(cmd.Flags())
+	cmd.Flags().BoolVar(&write, "write", false, "Open the database for writing and delete")
+	return cmd
+}
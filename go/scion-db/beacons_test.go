@@ -0,0 +1,177 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+	"github.com/scionproto/scion/go/pkg/command"
+	"github.com/scionproto/scion/go/pkg/storage/beacon/sqlite"
+)
+
+func TestBeaconsList(t *testing.T) {
+	file := newBeaconDB(t)
+
+	testCases := map[string]struct {
+		Args    []string
+		Beacons int
+	}{
+		"all": {
+			Beacons: 2,
+		},
+		"ingress": {
+			Args:    []string{"--ingress", fmt.Sprint(graph.If_111_B_120_X)},
+			Beacons: 1,
+		},
+		"isd-as": {
+			Args:    []string{"--isd-as", "1-ff00:0:121"},
+			Beacons: 1,
+		},
+		"interface": {
+			Args: []string{"--interface",
+				fmt.Sprintf("1-ff00:0:120#%d", graph.If_120_X_111_B)},
+			Beacons: 1,
+		},
+		"expired": {
+			Args: []string{"--expired"},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			out, err := runBeacons(append(append([]string{"list", "--json"}, tc.Args...),
+				file)...)
+			require.NoError(t, err)
+			var infos []segmentInfo
+			require.NoError(t, json.Unmarshal([]byte(out), &infos))
+			assert.Len(t, infos, tc.Beacons)
+		})
+	}
+}
+
+func TestBeaconsCheck(t *testing.T) {
+	file := newBeaconDB(t)
+
+	out, err := runBeacons("check", file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "found 0 problems")
+
+	out, err = runBeacons("check", "--time", "2100-01-01T00:00:00Z", "--json", file)
+	assert.Error(t, err)
+	var problems []segmentProblem
+	require.NoError(t, json.Unmarshal([]byte(out), &problems))
+	assert.Len(t, problems, 2)
+}
+
+func TestBeaconsVerify(t *testing.T) {
+	file := newBeaconDB(t)
+	trustDB := newTrustDB(t, nil, nil)
+
+	// The trust database does not contain the certificate chains of the
+	// signers.
+	out, err := runBeacons("verify", "--trust-db", trustDB, "--json", file)
+	assert.Error(t, err)
+	var problems []segmentProblem
+	require.NoError(t, json.Unmarshal([]byte(out), &problems))
+	assert.Len(t, problems, 2)
+
+	_, err = runBeacons("verify", file)
+	assert.Error(t, err, "trust database is required")
+}
+
+func TestBeaconsPurge(t *testing.T) {
+	file := newBeaconDB(t)
+
+	_, err := runBeacons("purge", file)
+	assert.Error(t, err, "selection is required")
+
+	ingress := fmt.Sprint(graph.If_111_B_120_X)
+	out, err := runBeacons("purge", "--ingress", ingress, file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Would delete 1 entries.")
+	assert.Equal(t, 2, countBeacons(t, file), "dry run must not delete")
+
+	out, err = runBeacons("purge", "--ingress", ingress, "--write", file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Deleted 1 entries.")
+	assert.Equal(t, 1, countBeacons(t, file))
+
+	out, err = runBeacons("purge", "--ingress", ingress, "--write", file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Deleting 0 entries.")
+	assert.Equal(t, 1, countBeacons(t, file))
+}
+
+// newBeaconDB creates a beacon database of AS 1-ff00:0:111 with a beacon
+// originated by 120 and a beacon originated by 121.
+func newBeaconDB(t *testing.T) string {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+
+	file := filepath.Join(t.TempDir(), "beacon.db")
+	db, err := sqlite.New(file, xtest.MustParseIA("1-ff00:0:111"))
+	require.NoError(t, err)
+	defer db.Close()
+	beacons := []beacon.Beacon{
+		{
+			Segment: receivedBeacon(g, graph.If_120_X_111_B),
+			InIfId:  graph.If_111_B_120_X,
+		},
+		{
+			Segment: receivedBeacon(g, graph.If_121_X_111_C),
+			InIfId:  graph.If_111_C_121_X,
+		},
+	}
+	for _, b := range beacons {
+		_, err := db.InsertBeacon(context.Background(), b, beacon.UsageProp)
+		require.NoError(t, err)
+	}
+	return file
+}
+
+// receivedBeacon returns the beacon as it is received by the AS at the end of
+// the interfaces, i.e., without the AS entry of that AS.
+func receivedBeacon(g *graph.Graph, ifids ...uint16) *seg.PathSegment {
+	pseg := g.Beacon(ifids)
+	pseg.ASEntries = pseg.ASEntries[:len(pseg.ASEntries)-1]
+	return pseg
+}
+
+func countBeacons(t *testing.T, file string) int {
+	db, err := sqlite.New(file, xtest.MustParseIA("1-ff00:0:111"))
+	require.NoError(t, err)
+	defer db.Close()
+	beacons, err := db.GetBeacons(context.Background(), nil)
+	require.NoError(t, err)
+	return len(beacons)
+}
+
+func runBeacons(args ...string) (string, error) {
+	return execute(newBeacons(command.StringPather("scion-db")), args...)
+}
@@ -0,0 +1,356 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/scrypto/signed"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/app/flag"
+	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+	"github.com/scionproto/scion/go/pkg/storage/trust/sqlite"
+	"github.com/scionproto/scion/go/pkg/trust"
+)
+
+// connection returns the connection string for the sqlite database file. The
+// database is opened read-only, unless write is set. In both cases, the file
+// must exist, i.e., a new database is never created.
+func connection(file string, write bool) (string, error) {
+	if _, err := os.Stat(file); err != nil {
+		return "", serrors.WrapStr("accessing database", err)
+	}
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+	mode := "ro"
+	if write {
+		mode = "rw"
+	}
+	u := url.URL{
+		Scheme:   "file",
+		Path:     abs,
+		RawQuery: url.Values{"mode": []string{mode}}.Encode(),
+	}
+	return u.String(), nil
+}
+
+// openTrustDB opens the trust database read-only.
+func openTrustDB(file string) (sqlite.DB, error) {
+	conn, err := connection(file, false)
+	if err != nil {
+		return sqlite.DB{}, err
+	}
+	db, err := sqlite.New(conn)
+	if err != nil {
+		return sqlite.DB{}, serrors.WrapStr("opening trust database", err, "file", file)
+	}
+	return db, nil
+}
+
+// commonFlags are the flags that are shared by all commands.
+type commonFlags struct {
+	json bool
+	now  flag.Time
+}
+
+func (f *commonFlags) register(flags *pflag.FlagSet) {
+	f.now = flag.Time{Time: time.Now().UTC(), Current: time.Now().UTC(), Default: "now"}
+	flags.BoolVar(&f.json, "json", false, "Write the output as machine readable json")
+	flags.Var(&f.now, "time", `Reference time for expiry checks. It can either be
+an RFC3339 timestamp, a unix timestamp, or a duration relative to now.`)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// parseIAs parses the ISD-AS filters. Zero values act as wildcards.
+func parseIAs(raw []string) ([]addr.IA, error) {
+	ias := make([]addr.IA, 0, len(raw))
+	for _, r := range raw {
+		ia, err := addr.IAFromString(r)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing ISD-AS", err, "input", r)
+		}
+		ias = append(ias, ia)
+	}
+	return ias, nil
+}
+
+// matchIA checks whether the ISD-AS matches any of the filters. An empty
+// filter list matches all ISD-ASes.
+func matchIA(filters []addr.IA, ia addr.IA) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, f := range filters {
+		if (f.I == 0 || f.I == ia.I) && (f.A == 0 || f.A == ia.A) {
+			return true
+		}
+	}
+	return false
+}
+
+// iface is an interface of an AS. The string representation is
+// "<ISD-AS>#<interface ID>".
+type iface struct {
+	IA   addr.IA
+	IfID common.IFIDType
+}
+
+func parseInterfaces(raw []string) ([]iface, error) {
+	ifaces := make([]iface, 0, len(raw))
+	for _, r := range raw {
+		parts := strings.Split(r, "#")
+		if len(parts) != 2 {
+			return nil, serrors.New("interface must be of form <ISD-AS>#<interface ID>",
+				"input", r)
+		}
+		ia, err := addr.IAFromString(parts[0])
+		if err != nil {
+			return nil, serrors.WrapStr("parsing ISD-AS", err, "input", r)
+		}
+		id, err := strconv.ParseUint(parts[1], 10, 16)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing interface ID", err, "input", r)
+		}
+		ifaces = append(ifaces, iface{IA: ia, IfID: common.IFIDType(id)})
+	}
+	return ifaces, nil
+}
+
+func parseSegTypes(raw []string) ([]seg.Type, error) {
+	types := make([]seg.Type, 0, len(raw))
+	for _, r := range raw {
+		var found bool
+		for _, t := range []seg.Type{seg.TypeUp, seg.TypeDown, seg.TypeCore} {
+			if strings.EqualFold(r, t.String()) {
+				types = append(types, t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, serrors.New("unknown segment type", "input", r)
+		}
+	}
+	return types, nil
+}
+
+// segmentFilter selects path segments.
+type segmentFilter struct {
+	ias     []addr.IA
+	ifaces  []iface
+	expired bool
+	now     time.Time
+}
+
+// match checks whether the segment passes the filter. The segment must
+// traverse one of the ISD-ASes and one of the interfaces, if set.
+func (f segmentFilter) match(ps *seg.PathSegment) bool {
+	if f.expired && !ps.MinExpiry().Before(f.now) {
+		return false
+	}
+	if len(f.ias) != 0 {
+		var found bool
+		for _, entry := range ps.ASEntries {
+			if matchIA(f.ias, entry.Local) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.ifaces) == 0 {
+		return true
+	}
+	for _, hop := range segmentHops(ps) {
+		for _, i := range f.ifaces {
+			if hop.IA.Equal(i.IA) && (hop.Ingress == uint16(i.IfID) ||
+				hop.Egress == uint16(i.IfID)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type hopInfo struct {
+	IA      addr.IA `json:"isd_as"`
+	Ingress uint16  `json:"ingress"`
+	Egress  uint16  `json:"egress"`
+}
+
+// segmentInfo is the printable representation of a path segment.
+type segmentInfo struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type,omitempty"`
+	Hops        []hopInfo `json:"hops"`
+	Timestamp   time.Time `json:"timestamp"`
+	Expiry      time.Time `json:"expiry"`
+	LastUpdated time.Time `json:"last_updated"`
+	// HPGroupIDs are the hidden path groups of path segments.
+	HPGroupIDs []uint64 `json:"hidden_path_group_ids,omitempty"`
+	// Ingress is the interface on which a beacon was received.
+	Ingress uint16 `json:"ingress_interface,omitempty"`
+	// Usage is the allowed usage of a beacon.
+	Usage string `json:"usage,omitempty"`
+}
+
+func newSegmentInfo(ps *seg.PathSegment, lastUpdated time.Time) segmentInfo {
+	return segmentInfo{
+		ID:          fmt.Sprintf("%x", ps.ID()),
+		Hops:        segmentHops(ps),
+		Timestamp:   ps.Info.Timestamp,
+		Expiry:      ps.MinExpiry(),
+		LastUpdated: lastUpdated,
+	}
+}
+
+func (s segmentInfo) String() string {
+	hops := make([]string, 0, len(s.Hops))
+	for _, hop := range s.Hops {
+		hops = append(hops, fmt.Sprintf("%d %s %d", hop.Ingress, hop.IA, hop.Egress))
+	}
+	str := fmt.Sprintf("%s %s", s.ID[:12], strings.Join(hops, ">"))
+	if s.Type != "" {
+		str = fmt.Sprintf("%-4s %s", s.Type, str)
+	}
+	if s.Ingress != 0 {
+		str += fmt.Sprintf(" | Ingress: %d", s.Ingress)
+	}
+	return str + fmt.Sprintf(" | Expiry: %s", s.Expiry.UTC().Format(time.RFC3339))
+}
+
+func segmentHops(ps *seg.PathSegment) []hopInfo {
+	hops := make([]hopInfo, 0, len(ps.ASEntries))
+	for _, entry := range ps.ASEntries {
+		hops = append(hops, hopInfo{
+			IA:      entry.Local,
+			Ingress: entry.HopEntry.HopField.ConsIngress,
+			Egress:  entry.HopEntry.HopField.ConsEgress,
+		})
+	}
+	return hops
+}
+
+// segmentProblem is a problem that was found with a path segment.
+type segmentProblem struct {
+	Segment segmentInfo `json:"segment"`
+	Problem string      `json:"problem"`
+}
+
+func (p segmentProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Segment, p.Problem)
+}
+
+// newVerifier creates a verifier that verifies signatures using only the
+// certificate chains and TRCs in the trust database.
+func newVerifier(db trust.DB) trust.Verifier {
+	return trust.Verifier{
+		Engine: trust.FetchingProvider{
+			DB:       db,
+			Recurser: offlineRecurser{},
+		},
+	}
+}
+
+// checkSegment returns the problems of the segment. Orphaned segments are only
+// detected if the trust database is set.
+func checkSegment(ctx context.Context, db trust.DB, ps *seg.PathSegment,
+	now time.Time) ([]string, error) {
+
+	var problems []string
+	if expiry := ps.MinExpiry(); expiry.Before(now) {
+		problems = append(problems, "expired at "+expiry.UTC().Format(time.RFC3339))
+	}
+	if db == nil {
+		return problems, nil
+	}
+	missing, err := missingChains(ctx, db, ps)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) != 0 {
+		problems = append(problems,
+			fmt.Sprintf("orphaned, certificate chains missing for %v", missing))
+	}
+	return problems, nil
+}
+
+// missingChains returns the ISD-ASes of the AS entries in the segment whose
+// signing certificate chain is not in the trust database.
+func missingChains(ctx context.Context, db trust.DB, ps *seg.PathSegment) ([]addr.IA, error) {
+	var missing []addr.IA
+	for _, entry := range ps.ASEntries {
+		hdr, err := signed.ExtractUnverifiedHeader(entry.Signed)
+		if err != nil {
+			return nil, serrors.WrapStr("extracting signature header", err)
+		}
+		var keyID cppb.VerificationKeyID
+		if err := proto.Unmarshal(hdr.VerificationKeyID, &keyID); err != nil {
+			return nil, serrors.WrapStr("parsing verification key ID", err)
+		}
+		chains, err := db.Chains(ctx, trust.ChainQuery{
+			IA:           addr.IAInt(keyID.IsdAs).IA(),
+			SubjectKeyID: keyID.SubjectKeyId,
+		})
+		if err != nil {
+			return nil, serrors.WrapStr("looking up chains", err)
+		}
+		if len(chains) == 0 {
+			missing = append(missing, entry.Local)
+		}
+	}
+	return missing, nil
+}
+
+// offlineRecurser prevents the trust engine from resolving missing crypto
+// material over the network.
+type offlineRecurser struct{}
+
+func (offlineRecurser) AllowRecursion(net.Addr) error {
+	return serrors.New("offline mode, crypto material must be in the trust database")
+}
+
+// purgeNote returns the note that is printed before purging entries.
+func purgeNote(write bool, count int) string {
+	if !write {
+		return fmt.Sprintf("Would delete %d entries. Run with --write to delete them.", count)
+	}
+	return fmt.Sprintf("Deleting %d entries.", count)
+}
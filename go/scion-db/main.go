@@ -0,0 +1,64 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// scion-db is an offline inspection and repair tool for the databases of the
+// control service.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/pkg/app"
+	"github.com/scionproto/scion/go/pkg/command"
+)
+
+func main() {
+	executable := filepath.Base(os.Args[0])
+	cmd := &cobra.Command{
+		Use:   executable,
+		Short: "SCION Control Plane Database Tool",
+		Long: `SCION Control Plane Database Tool
+
+The databases are opened read-only. Only the purge commands open them for
+writing, and only if the --write flag is provided.`,
+		Args: cobra.NoArgs,
+		// Silence the errors, since we print them in main. Otherwise, cobra
+		// will print any non-nil errors returned by a RunE function.
+		// See https://github.com/spf13/cobra/issues/340.
+		// Commands should turn off the usage help message, if they deem the arguments
+		// to be reasonable well-formed. This avoids outputing help message on errors
+		// that are not caused by malformed input.
+		// See https://github.com/spf13/cobra/issues/340#issuecomment-374617413.
+		SilenceErrors: true,
+	}
+	cmd.AddCommand(
+		command.NewVersion(cmd),
+		newSegments(cmd),
+		newBeacons(cmd),
+		newTrust(cmd),
+		newReservations(cmd),
+	)
+
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		if code := app.ExitCode(err); code != -1 {
+			os.Exit(code)
+		}
+		os.Exit(2)
+	}
+}
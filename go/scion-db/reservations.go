@@ -0,0 +1,385 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/sqlite"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/app"
+	"github.com/scionproto/scion/go/pkg/command"
+)
+
+func newReservations(pather command.Pather) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reservations",
+		Short: "Inspect and repair a COLIBRI reservation database",
+		Args:  cobra.NoArgs,
+	}
+	joined := command.JoinedPather{pather, command.StringPather("reservations")}
+	cmd.AddCommand(
+		newReservationsList(joined),
+		newReservationsCheck(joined),
+		newReservationsPurge(joined),
+	)
+	return cmd
+}
+
+// reservationFlags are the flags that select segment reservations.
+type reservationFlags struct {
+	commonFlags
+	ifaces  []uint
+	expired bool
+}
+
+func (f *reservationFlags) register(flags *pflag.FlagSet) {
+	f.commonFlags.register(flags)
+	flags.UintSliceVar(&f.ifaces, "interface", nil,
+		"Only select reservations that enter or leave through one of the local interfaces")
+	flags.BoolVar(&f.expired, "expired", false,
+		"Only select reservations whose indices are all expired")
+}
+
+func (f *reservationFlags) selective() bool {
+	return len(f.ifaces) != 0 || f.expired
+}
+
+// load loads the selected segment reservations from the database.
+func (f *reservationFlags) load(ctx context.Context,
+	db backend.TransitOnly) ([]*segment.Reservation, error) {
+
+	rsvs, err := db.GetAllSegmentRsvs(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("loading reservations", err)
+	}
+	var selected []*segment.Reservation
+	for _, rsv := range rsvs {
+		if f.expired && !reservationExpired(rsv, f.now.Time) {
+			continue
+		}
+		if len(f.ifaces) != 0 && !matchReservationInterface(f.ifaces, rsv) {
+			continue
+		}
+		selected = append(selected, rsv)
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].ID.String() < selected[j].ID.String()
+	})
+	return selected, nil
+}
+
+func matchReservationInterface(ifaces []uint, rsv *segment.Reservation) bool {
+	for _, id := range ifaces {
+		if uint(rsv.Ingress) == id || uint(rsv.Egress) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// reservationExpired checks whether all indices of the reservation are
+// expired. Reservations without indices are orphaned rather than expired.
+func reservationExpired(rsv *segment.Reservation, now time.Time) bool {
+	if len(rsv.Indices) == 0 {
+		return false
+	}
+	for _, index := range rsv.Indices {
+		if !index.Expiration.Before(now) {
+			return false
+		}
+	}
+	return true
+}
+
+var pathTypes = map[reservation.PathType]string{
+	reservation.UnknownPath:     "unknown",
+	reservation.DownPath:        "down",
+	reservation.UpPath:          "up",
+	reservation.PeeringDownPath: "peering_down",
+	reservation.PeeringUpPath:   "peering_up",
+	reservation.E2EPath:         "e2e",
+	reservation.CorePath:        "core",
+}
+
+var indexStates = map[segment.IndexState]string{
+	segment.IndexTemporary: "temporary",
+	segment.IndexPending:   "pending",
+	segment.IndexActive:    "active",
+}
+
+type indexInfo struct {
+	Index      uint8     `json:"index"`
+	State      string    `json:"state"`
+	Expiration time.Time `json:"expiration"`
+	MinBW      uint8     `json:"min_bw"`
+	MaxBW      uint8     `json:"max_bw"`
+	AllocBW    uint8     `json:"alloc_bw"`
+}
+
+type reservationInfo struct {
+	ID       string      `json:"id"`
+	Ingress  uint16      `json:"ingress_interface"`
+	Egress   uint16      `json:"egress_interface"`
+	PathType string      `json:"path_type"`
+	Indices  []indexInfo `json:"indices"`
+}
+
+func newReservationInfo(rsv *segment.Reservation) reservationInfo {
+	info := reservationInfo{
+		ID:       rsv.ID.String(),
+		Ingress:  rsv.Ingress,
+		Egress:   rsv.Egress,
+		PathType: pathTypes[rsv.PathType],
+		Indices:  make([]indexInfo, 0, len(rsv.Indices)),
+	}
+	for _, index := range rsv.Indices {
+		info.Indices = append(info.Indices, indexInfo{
+			Index:      uint8(index.Idx),
+			State:      indexStates[index.State()],
+			Expiration: index.Expiration,
+			MinBW:      uint8(index.MinBW),
+			MaxBW:      uint8(index.MaxBW),
+			AllocBW:    uint8(index.AllocBW),
+		})
+	}
+	return info
+}
+
+func (r reservationInfo) String() string {
+	indices := make([]string, 0, len(r.Indices))
+	for _, index := range r.Indices {
+		indices = append(indices, fmt.Sprintf("%d:%s:%s", index.Index, index.State,
+			index.Expiration.UTC().Format(time.RFC3339)))
+	}
+	return fmt.Sprintf("%s %s %d>%d | Indices: [%s]", r.ID, r.PathType, r.Ingress, r.Egress,
+		strings.Join(indices, " "))
+}
+
+type reservationProblem struct {
+	Reservation reservationInfo `json:"reservation"`
+	Problem     string          `json:"problem"`
+}
+
+func (p reservationProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Reservation, p.Problem)
+}
+
+func openReservationDB(file string, write bool) (*sqlite.Backend, error) {
+	conn, err := connection(file, write)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sqlite.New(conn)
+	if err != nil {
+		return nil, serrors.WrapStr("opening reservation database", err, "file", file)
+	}
+	return db, nil
+}
+
+func newReservationsList(pather command.Pather) *cobra.Command {
+	var flags reservationFlags
+	cmd := &cobra.Command{
+		Use:   "list [flags] <reservation-db>",
+		Short: "List the segment reservations in a reservation database",
+		Example: fmt.Sprintf(`  %[1]s list cs1-ff00_0_110-1.colibri.db
+  %[1]s list --interface 2 --json cs1-ff00_0_110-1.colibri.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openReservationDB(args[0], false)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cmd.SilenceUsage = true
+
+			rsvs, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			infos := make([]reservationInfo, 0, len(rsvs))
+			for _, rsv := range rsvs {
+				infos = append(infos, newReservationInfo(rsv))
+			}
+			if flags.json {
+				return writeJSON(cmd.OutOrStdout(), infos)
+			}
+			for _, info := range infos {
+				fmt.Fprintln(cmd.OutOrStdout(), info)
+			}
+			return nil
+		},
+	}
+	flags.register(cmd.Flags())
+	return cmd
+}
+
+func newReservationsCheck(pather command.Pather) *cobra.Command {
+	var flags reservationFlags
+	cmd := &cobra.Command{
+		Use:   "check [flags] <reservation-db>",
+		Short: "Report expired and orphaned segment reservations",
+		Long: `'check' reports the selected segment reservations that are expired or
+orphaned.
+
+A segment reservation is expired if all its indices are expired. It is orphaned
+if it has no indices at all. The command exits with code 1 if any problem is
+found.`,
+		Example: fmt.Sprintf(`  %[1]s check cs1-ff00_0_110-1.colibri.db`, pather.CommandPath()),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openReservationDB(args[0], false)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cmd.SilenceUsage = true
+
+			rsvs, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			problems := []reservationProblem{}
+			for _, rsv := range rsvs {
+				var problem string
+				switch {
+				case len(rsv.Indices) == 0:
+					problem = "orphaned, no indices"
+				case reservationExpired(rsv, flags.now.Time):
+					problem = "all indices expired"
+				default:
+					continue
+				}
+				problems = append(problems, reservationProblem{
+					Reservation: newReservationInfo(rsv),
+					Problem:     problem,
+				})
+			}
+			if flags.json {
+				if err := writeJSON(cmd.OutOrStdout(), problems); err != nil {
+					return err
+				}
+			} else {
+				for _, p := range problems {
+					fmt.Fprintln(cmd.OutOrStdout(), p)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Checked %d reservations, found %d problems.\n",
+					len(rsvs), len(problems))
+			}
+			if len(problems) != 0 {
+				return app.WithExitCode(
+					serrors.New("problems found", "count", len(problems)), 1)
+			}
+			return nil
+		},
+	}
+	flags.register(cmd.Flags())
+	return cmd
+}
+
+func newReservationsPurge(pather command.Pather) *cobra.Command {
+	var flags reservationFlags
+	var write bool
+	cmd := &cobra.Command{
+		Use:   "purge [flags] <reservation-db>",
+		Short: "Delete the selected segment reservations from a reservation database",
+		Long: `'purge' deletes the selected segment reservations from the reservation
+database.
+
+At least one selection flag must be provided. Without the --write flag, the
+reservations that would be deleted are only listed. All reservations are deleted
+in a single transaction. If any deletion fails, nothing is deleted.
+
+Do not run this command with --write while the control service uses the
+database.`,
+		Example: fmt.Sprintf(`  %[1]s purge --expired cs1-ff00_0_110-1.colibri.db
+  %[1]s purge --interface 2 --write cs1-ff00_0_110-1.colibri.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !flags.selective() {
+				return serrors.New("at least one selection flag must be provided")
+			}
+			db, err := openReservationDB(args[0], write)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cmd.SilenceUsage = true
+
+			rsvs, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			infos := make([]reservationInfo, 0, len(rsvs))
+			for _, rsv := range rsvs {
+				infos = append(infos, newReservationInfo(rsv))
+			}
+			if flags.json {
+				if err := writeJSON(cmd.OutOrStdout(), infos); err != nil {
+					return err
+				}
+			} else {
+				for _, info := range infos {
+					fmt.Fprintln(cmd.OutOrStdout(), info)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), purgeNote(write, len(rsvs)))
+			}
+			if !write || len(rsvs) == 0 {
+				return nil
+			}
+			if err := deleteReservations(cmd.Context(), db, rsvs); err != nil {
+				return err
+			}
+			if !flags.json {
+				fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d entries.\n", len(rsvs))
+			}
+			return nil
+		},
+	}
+	flags.register(cmd.Flags())
+	cmd.Flags().BoolVar(&write, "write", false, "Open the database for writing and delete")
+	return cmd
+}
+
+func deleteReservations(ctx context.Context, db backend.DB,
+	rsvs []*segment.Reservation) error {
+
+	tx, err := db.BeginTransaction(ctx, nil)
+	if err != nil {
+		return serrors.WrapStr("starting transaction", err)
+	}
+	defer tx.Rollback()
+	for _, rsv := range rsvs {
+		if err := tx.DeleteSegmentRsv(ctx, &rsv.ID); err != nil {
+			return serrors.WrapStr("deleting reservation", err, "id", rsv.ID.String())
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return serrors.WrapStr("committing transaction", err)
+	}
+	return nil
+}
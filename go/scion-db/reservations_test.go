@@ -0,0 +1,166 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/sqlite"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/command"
+)
+
+func TestReservationsList(t *testing.T) {
+	file := newReservationDB(t)
+
+	testCases := map[string]struct {
+		Args         []string
+		Reservations int
+	}{
+		"all": {
+			Reservations: 3,
+		},
+		"egress": {
+			Args:         []string{"--interface", "1"},
+			Reservations: 1,
+		},
+		"ingress": {
+			Args:         []string{"--interface", "2"},
+			Reservations: 1,
+		},
+		"multiple interfaces": {
+			Args:         []string{"--interface", "1,4"},
+			Reservations: 2,
+		},
+		"expired": {
+			Args:         []string{"--expired"},
+			Reservations: 1,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			out, err := runReservations(append(append([]string{"list", "--json"},
+				tc.Args...), file)...)
+			require.NoError(t, err)
+			var infos []reservationInfo
+			require.NoError(t, json.Unmarshal([]byte(out), &infos))
+			assert.Len(t, infos, tc.Reservations)
+		})
+	}
+}
+
+func TestReservationsCheck(t *testing.T) {
+	file := newReservationDB(t)
+
+	out, err := runReservations("check", "--json", file)
+	assert.Error(t, err)
+	var problems []reservationProblem
+	require.NoError(t, json.Unmarshal([]byte(out), &problems))
+	require.Len(t, problems, 2)
+	var found []string
+	for _, p := range problems {
+		found = append(found, p.Problem)
+	}
+	assert.ElementsMatch(t, []string{"all indices expired", "orphaned, no indices"}, found)
+
+	// Before the expired reservation expires, only the orphaned one is
+	// reported.
+	out, err = runReservations("check", "--json", "--time", "-2h", file)
+	assert.Error(t, err)
+	require.NoError(t, json.Unmarshal([]byte(out), &problems))
+	assert.Len(t, problems, 1)
+}
+
+func TestReservationsPurge(t *testing.T) {
+	file := newReservationDB(t)
+
+	_, err := runReservations("purge", file)
+	assert.Error(t, err, "selection is required")
+
+	out, err := runReservations("purge", "--expired", file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Would delete 1 entries.")
+	assert.Equal(t, 3, countReservations(t, file), "dry run must not delete")
+
+	out, err = runReservations("purge", "--expired", "--write", file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Deleted 1 entries.")
+	assert.Equal(t, 2, countReservations(t, file))
+
+	out, err = runReservations("purge", "--interface", "1,4", "--write", file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Deleted 2 entries.")
+	assert.Equal(t, 0, countReservations(t, file))
+}
+
+// newReservationDB creates a reservation database with an active reservation
+// 0>1 that starts in the local AS, an expired reservation 2>3 and an orphaned
+// reservation 4>5 without indices.
+func newReservationDB(t *testing.T) string {
+	file := filepath.Join(t.TempDir(), "colibri.db")
+	db, err := sqlite.New(file)
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	active := newSegmentReservation(t, 0, 1)
+	active.Path = segment.ReservationTransparentPath{}
+	_, err = active.NewIndexAtSource(now.Add(time.Hour), 1, 3, 2, 5, reservation.UpPath)
+	require.NoError(t, err)
+	expired := newSegmentReservation(t, 2, 3)
+	_, err = expired.NewIndexAtSource(now.Add(-time.Hour), 1, 3, 2, 5, reservation.UpPath)
+	require.NoError(t, err)
+	orphaned := newSegmentReservation(t, 4, 5)
+
+	for _, rsv := range []*segment.Reservation{active, expired, orphaned} {
+		require.NoError(t, db.NewSegmentRsv(context.Background(), rsv))
+	}
+	return file
+}
+
+func newSegmentReservation(t *testing.T, ingress, egress uint16) *segment.Reservation {
+	rsv := segment.NewReservation()
+	rsv.ID.ASID = xtest.MustParseAS("ff00:0:1")
+	rsv.Ingress = ingress
+	rsv.Egress = egress
+	rsv.PathType = reservation.UpPath
+	rsv.PathEndProps = reservation.StartLocal
+	rsv.TrafficSplit = 3
+	return rsv
+}
+
+func countReservations(t *testing.T, file string) int {
+	db, err := sqlite.New(file)
+	require.NoError(t, err)
+	defer db.Close()
+	rsvs, err := db.GetAllSegmentRsvs(context.Background())
+	require.NoError(t, err)
+	return len(rsvs)
+}
+
+func runReservations(args ...string) (string, error) {
+	return execute(newReservations(command.StringPather("scion-db")), args...)
+}
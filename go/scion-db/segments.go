@@ -0,0 +1,357 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/app"
+	"github.com/scionproto/scion/go/pkg/command"
+	"github.com/scionproto/scion/go/pkg/storage/path/sqlite"
+	"github.com/scionproto/scion/go/pkg/trust"
+)
+
+func newSegments(pather command.Pather) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "segments",
+		Short: "Inspect and repair a path database",
+		Args:  cobra.NoArgs,
+	}
+	joined := command.JoinedPather{pather, command.StringPather("segments")}
+	cmd.AddCommand(
+		newSegmentsList(joined),
+		newSegmentsVerify(joined),
+		newSegmentsCheck(joined),
+		newSegmentsPurge(joined),
+	)
+	return cmd
+}
+
+// segmentFlags are the flags that select path segments.
+type segmentFlags struct {
+	commonFlags
+	ias     []string
+	ifaces  []string
+	types   []string
+	expired bool
+}
+
+func (f *segmentFlags) register(flags *pflag.FlagSet) {
+	f.commonFlags.register(flags)
+	flags.StringSliceVar(&f.ias, "isd-as", nil,
+		"Only select segments that traverse one of the ISD-ASes (wildcards allowed)")
+	flags.StringSliceVar(&f.ifaces, "interface", nil,
+		"Only select segments that traverse one of the interfaces (<ISD-AS>#<interface ID>)")
+	flags.StringSliceVar(&f.types, "type", nil,
+		"Only select segments of the given types (up, down, core)")
+	flags.BoolVar(&f.expired, "expired", false, "Only select expired segments")
+}
+
+func (f *segmentFlags) selective() bool {
+	return len(f.ias) != 0 || len(f.ifaces) != 0 || len(f.types) != 0 || f.expired
+}
+
+// load loads the selected path segments from the database.
+func (f *segmentFlags) load(ctx context.Context, db pathdb.ReadWrite) (query.Results, error) {
+	types, err := parseSegTypes(f.types)
+	if err != nil {
+		return nil, err
+	}
+	ias, err := parseIAs(f.ias)
+	if err != nil {
+		return nil, err
+	}
+	ifaces, err := parseInterfaces(f.ifaces)
+	if err != nil {
+		return nil, err
+	}
+	results, err := db.Get(ctx, &query.Params{SegTypes: types})
+	if err != nil {
+		return nil, serrors.WrapStr("loading segments", err)
+	}
+	filter := segmentFilter{ias: ias, ifaces: ifaces, expired: f.expired, now: f.now.Time}
+	var selected query.Results
+	for _, r := range results {
+		if filter.match(r.Seg) {
+			selected = append(selected, r)
+		}
+	}
+	sort.Sort(selected)
+	return selected, nil
+}
+
+func pathSegmentInfo(r *query.Result) segmentInfo {
+	info := newSegmentInfo(r.Seg, r.LastUpdate)
+	info.Type = r.Type.String()
+	info.HPGroupIDs = r.HPGroupIDs
+	return info
+}
+
+func openPathDB(file string, write bool) (*sqlite.Backend, error) {
+	conn, err := connection(file, write)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sqlite.New(conn)
+	if err != nil {
+		return nil, serrors.WrapStr("opening path database", err, "file", file)
+	}
+	return db, nil
+}
+
+func newSegmentsList(pather command.Pather) *cobra.Command {
+	var flags segmentFlags
+	cmd := &cobra.Command{
+		Use:   "list [flags] <path-db>",
+		Short: "List the path segments in a path database",
+		Example: fmt.Sprintf(`  %[1]s list cs1-ff00_0_110-1.path.db
+  %[1]s list --type up --isd-as 1-ff00:0:111 cs1-ff00_0_110-1.path.db
+  %[1]s list --interface 1-ff00:0:110#2 --json cs1-ff00_0_110-1.path.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openPathDB(args[0], false)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cmd.SilenceUsage = true
+
+			results, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			infos := make([]segmentInfo, 0, len(results))
+			for _, r := range results {
+				infos = append(infos, pathSegmentInfo(r))
+			}
+			if flags.json {
+				return writeJSON(cmd.OutOrStdout(), infos)
+			}
+			for _, info := range infos {
+				fmt.Fprintln(cmd.OutOrStdout(), info)
+			}
+			return nil
+		},
+	}
+	flags.register(cmd.Flags())
+	return cmd
+}
+
+func newSegmentsVerify(pather command.Pather) *cobra.Command {
+	var flags segmentFlags
+	var trustDBFile string
+	cmd := &cobra.Command{
+		Use:   "verify [flags] <path-db>",
+		Short: "Verify the signatures of the path segments in a path database",
+		Long: `'verify' verifies the signatures of the selected path segments.
+
+The certificate chains and TRCs are taken from the trust database. Missing crypto
+material is not resolved over the network. The command exits with code 1 if any
+path segment fails verification.`,
+		Example: fmt.Sprintf(
+			`  %[1]s verify --trust-db cs1-ff00_0_110-1.trust.db cs1-ff00_0_110-1.path.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openPathDB(args[0], false)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			trustDB, err := openTrustDB(trustDBFile)
+			if err != nil {
+				return err
+			}
+			defer trustDB.Close()
+			cmd.SilenceUsage = true
+
+			results, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			verifier := newVerifier(trustDB)
+			problems := []segmentProblem{}
+			for _, r := range results {
+				if err := r.Seg.Verify(cmd.Context(), verifier); err != nil {
+					problems = append(problems, segmentProblem{
+						Segment: pathSegmentInfo(r),
+						Problem: err.Error(),
+					})
+				}
+			}
+			return reportSegmentProblems(cmd, flags.json, len(results), problems)
+		},
+	}
+	flags.register(cmd.Flags())
+	cmd.Flags().StringVar(&trustDBFile, "trust-db", "", "The trust database (required)")
+	cmd.MarkFlagRequired("trust-db")
+	return cmd
+}
+
+func newSegmentsCheck(pather command.Pather) *cobra.Command {
+	var flags segmentFlags
+	var trustDBFile string
+	cmd := &cobra.Command{
+		Use:   "check [flags] <path-db>",
+		Short: "Report expired and orphaned path segments",
+		Long: `'check' reports the selected path segments that are expired or orphaned.
+
+A path segment is orphaned if the certificate chain of any of its signers is
+missing from the trust database. Orphaned path segments are only reported if the
+trust database is provided. The command exits with code 1 if any problem is
+found.`,
+		Example: fmt.Sprintf(`  %[1]s check cs1-ff00_0_110-1.path.db
+  %[1]s check --trust-db cs1-ff00_0_110-1.trust.db cs1-ff00_0_110-1.path.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openPathDB(args[0], false)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			var trustDB trust.DB
+			if trustDBFile != "" {
+				tdb, err := openTrustDB(trustDBFile)
+				if err != nil {
+					return err
+				}
+				defer tdb.Close()
+				trustDB = tdb
+			}
+			cmd.SilenceUsage = true
+
+			results, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			problems := []segmentProblem{}
+			for _, r := range results {
+				found, err := checkSegment(cmd.Context(), trustDB, r.Seg, flags.now.Time)
+				if err != nil {
+					return err
+				}
+				for _, problem := range found {
+					problems = append(problems, segmentProblem{
+						Segment: pathSegmentInfo(r),
+						Problem: problem,
+					})
+				}
+			}
+			return reportSegmentProblems(cmd, flags.json, len(results), problems)
+		},
+	}
+	flags.register(cmd.Flags())
+	cmd.Flags().StringVar(&trustDBFile, "trust-db", "",
+		"The trust database to check for orphaned segments")
+	return cmd
+}
+
+func newSegmentsPurge(pather command.Pather) *cobra.Command {
+	var flags segmentFlags
+	var write bool
+	cmd := &cobra.Command{
+		Use:   "purge [flags] <path-db>",
+		Short: "Delete the selected path segments from a path database",
+		Long: `'purge' deletes the selected path segments from the path database.
+
+At least one selection flag must be provided. Without the --write flag, the
+segments that would be deleted are only listed. A path segment is deleted
+irrespective of the segment types it is registered with.
+
+Do not run this command with --write while the control service uses the
+database.`,
+		Example: fmt.Sprintf(`  %[1]s purge --expired cs1-ff00_0_110-1.path.db
+  %[1]s purge --interface 1-ff00:0:110#2 --write cs1-ff00_0_110-1.path.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !flags.selective() {
+				return serrors.New("at least one selection flag must be provided")
+			}
+			db, err := openPathDB(args[0], write)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cmd.SilenceUsage = true
+
+			results, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			infos := make([]segmentInfo, 0, len(results))
+			ids := make([][]byte, 0, len(results))
+			for _, r := range results {
+				infos = append(infos, pathSegmentInfo(r))
+				ids = append(ids, r.Seg.ID())
+			}
+			if flags.json {
+				if err := writeJSON(cmd.OutOrStdout(), infos); err != nil {
+					return err
+				}
+			} else {
+				for _, info := range infos {
+					fmt.Fprintln(cmd.OutOrStdout(), info)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), purgeNote(write, len(ids)))
+			}
+			if !write || len(ids) == 0 {
+				return nil
+			}
+			deleted, err := db.Delete(cmd.Context(), &query.Params{SegIDs: ids})
+			if err != nil {
+				return serrors.WrapStr("deleting segments", err)
+			}
+			if !flags.json {
+				fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d entries.\n", deleted)
+			}
+			return nil
+		},
+	}
+	flags.register(cmd.Flags())
+	cmd.Flags().BoolVar(&write, "write", false, "Open the database for writing and delete")
+	return cmd
+}
+
+// reportSegmentProblems writes the problems and fails if there are any.
+func reportSegmentProblems(cmd *cobra.Command, asJSON bool, total int,
+	problems []segmentProblem) error {
+
+	if asJSON {
+		if err := writeJSON(cmd.OutOrStdout(), problems); err != nil {
+			return err
+		}
+	} else {
+		for _, p := range problems {
+			fmt.Fprintln(cmd.OutOrStdout(), p)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Checked %d segments, found %d problems.\n",
+			total, len(problems))
+	}
+	if len(problems) != 0 {
+		return app.WithExitCode(serrors.New("problems found", "count", len(problems)), 1)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+	"github.com/scionproto/scion/go/pkg/command"
+	"github.com/scionproto/scion/go/pkg/storage/path/sqlite"
+)
+
+func TestSegmentsList(t *testing.T) {
+	file := newPathDB(t)
+
+	testCases := map[string]struct {
+		Args     []string
+		Segments int
+	}{
+		"all": {
+			Segments: 2,
+		},
+		"type": {
+			Args:     []string{"--type", "up"},
+			Segments: 1,
+		},
+		"isd-as": {
+			Args:     []string{"--isd-as", "1-ff00:0:130"},
+			Segments: 1,
+		},
+		"isd-as wildcard": {
+			Args:     []string{"--isd-as", "1-0"},
+			Segments: 2,
+		},
+		"interface": {
+			Args: []string{"--interface",
+				fmt.Sprintf("1-ff00:0:120#%d", graph.If_120_A_130_B)},
+			Segments: 1,
+		},
+		"expired": {
+			Args: []string{"--expired"},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			out, err := runSegments(append(append([]string{"list", "--json"}, tc.Args...),
+				file)...)
+			require.NoError(t, err)
+			var infos []segmentInfo
+			require.NoError(t, json.Unmarshal([]byte(out), &infos))
+			assert.Len(t, infos, tc.Segments)
+		})
+	}
+}
+
+func TestSegmentsPurge(t *testing.T) {
+	file := newPathDB(t)
+
+	_, err := runSegments("purge", file)
+	assert.Error(t, err, "selection is required")
+
+	out, err := runSegments("purge", "--type", "up", file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Would delete 1 entries.")
+	assert.Equal(t, 2, countSegments(t, file), "dry run must not delete")
+
+	out, err = runSegments("purge", "--type", "up", "--write", file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Deleted 1 entries.")
+	assert.Equal(t, 1, countSegments(t, file))
+}
+
+func TestConnectionReadOnly(t *testing.T) {
+	file := newPathDB(t)
+
+	conn, err := connection(file, false)
+	require.NoError(t, err)
+	db, err := sqlite.New(conn)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.DeleteExpired(context.Background(), time.Now().Add(24*time.Hour))
+	assert.Error(t, err)
+
+	_, err = connection(filepath.Join(t.TempDir(), "missing.db"), true)
+	assert.Error(t, err)
+}
+
+// newPathDB creates a path database with an up segment 120->111 and a down
+// segment 130->120->111.
+func newPathDB(t *testing.T) string {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+
+	file := filepath.Join(t.TempDir(), "path.db")
+	db, err := sqlite.New(file)
+	require.NoError(t, err)
+	defer db.Close()
+	metas := []*seg.Meta{
+		{
+			Type:    seg.TypeUp,
+			Segment: g.Beacon([]uint16{graph.If_120_X_111_B}),
+		},
+		{
+			Type:    seg.TypeDown,
+			Segment: g.Beacon([]uint16{graph.If_130_B_120_A, graph.If_120_X_111_B}),
+		},
+	}
+	for _, m := range metas {
+		_, err := db.Insert(context.Background(), m)
+		require.NoError(t, err)
+	}
+	return file
+}
+
+func countSegments(t *testing.T, file string) int {
+	db, err := sqlite.New(file)
+	require.NoError(t, err)
+	defer db.Close()
+	results, err := db.GetAll(context.Background())
+	require.NoError(t, err)
+	return len(results)
+}
+
+func runSegments(args ...string) (string, error) {
+	return execute(newSegments(command.StringPather("scion-db")), args...)
+}
+
+// execute runs the command with the arguments and returns what it wrote to
+// stdout. Errors are only returned, such that the output of failing commands
+// can still be parsed.
+func execute(cmd *cobra.Command, args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(ioutil.Discard)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return strings.TrimSpace(out.String()), err
+}
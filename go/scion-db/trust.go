@@ -0,0 +1,418 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/app"
+	"github.com/scionproto/scion/go/pkg/command"
+	truststorage "github.com/scionproto/scion/go/pkg/storage/trust"
+	"github.com/scionproto/scion/go/pkg/storage/trust/sqlite"
+	"github.com/scionproto/scion/go/pkg/trust"
+)
+
+func newTrust(pather command.Pather) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trust",
+		Short: "Inspect a trust database",
+		Args:  cobra.NoArgs,
+	}
+	joined := command.JoinedPather{pather, command.StringPather("trust")}
+	cmd.AddCommand(
+		newTrustList(joined),
+		newTrustVerify(joined),
+		newTrustCheck(joined),
+	)
+	return cmd
+}
+
+// trustFlags are the flags that select TRCs and certificate chains.
+type trustFlags struct {
+	commonFlags
+	ias []string
+}
+
+func (f *trustFlags) register(flags *pflag.FlagSet) {
+	f.commonFlags.register(flags)
+	flags.StringSliceVar(&f.ias, "isd-as", nil, "Only select the TRCs and certificate "+
+		"chains of the ISD-ASes (wildcards allowed)")
+}
+
+// trustMaterial is the selected content of a trust database.
+type trustMaterial struct {
+	trcs   cppki.SignedTRCs
+	chains [][]*x509.Certificate
+}
+
+// load loads the selected TRCs and certificate chains from the database. The
+// TRCs are sorted by ID, the chains by ISD-AS and validity.
+func (f *trustFlags) load(ctx context.Context, db sqlite.DB) (trustMaterial, error) {
+	ias, err := parseIAs(f.ias)
+	if err != nil {
+		return trustMaterial{}, err
+	}
+	trcs, err := db.SignedTRCs(ctx, truststorage.TRCsQuery{})
+	if err != nil {
+		return trustMaterial{}, serrors.WrapStr("loading TRCs", err)
+	}
+	chains, err := db.Chains(ctx, trust.ChainQuery{})
+	if err != nil {
+		return trustMaterial{}, serrors.WrapStr("loading certificate chains", err)
+	}
+	var m trustMaterial
+	for _, trc := range trcs {
+		if matchISD(ias, trc.TRC.ID.ISD) {
+			m.trcs = append(m.trcs, trc)
+		}
+	}
+	for _, chain := range chains {
+		ia, err := cppki.ExtractIA(chain[0].Subject)
+		if err != nil {
+			return trustMaterial{}, serrors.WrapStr("extracting ISD-AS", err)
+		}
+		if matchIA(ias, ia) {
+			m.chains = append(m.chains, chain)
+		}
+	}
+	sort.Slice(m.trcs, func(i, j int) bool {
+		a, b := m.trcs[i].TRC.ID, m.trcs[j].TRC.ID
+		switch {
+		case a.ISD != b.ISD:
+			return a.ISD < b.ISD
+		case a.Base != b.Base:
+			return a.Base < b.Base
+		default:
+			return a.Serial < b.Serial
+		}
+	})
+	sort.SliceStable(m.chains, func(i, j int) bool {
+		a, b := m.chains[i][0], m.chains[j][0]
+		if a.Subject.String() != b.Subject.String() {
+			return a.Subject.String() < b.Subject.String()
+		}
+		return a.NotBefore.Before(b.NotBefore)
+	})
+	return m, nil
+}
+
+// matchISD checks whether any of the filters matches the ISD. An empty filter
+// list matches all ISDs.
+func matchISD(filters []addr.IA, isd addr.ISD) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, f := range filters {
+		if f.I == 0 || f.I == isd {
+			return true
+		}
+	}
+	return false
+}
+
+// trcsOf returns the TRCs of the ISD. The TRCs of the ISD of every selected
+// certificate chain are selected as well.
+func (m trustMaterial) trcsOf(isd addr.ISD) []*cppki.TRC {
+	var trcs []*cppki.TRC
+	for i := range m.trcs {
+		if m.trcs[i].TRC.ID.ISD == isd {
+			trcs = append(trcs, &m.trcs[i].TRC)
+		}
+	}
+	return trcs
+}
+
+type trcInfo struct {
+	ID          string    `json:"id"`
+	ISD         addr.ISD  `json:"isd"`
+	Base        uint64    `json:"base_number"`
+	Serial      uint64    `json:"serial_number"`
+	NotBefore   time.Time `json:"not_before"`
+	NotAfter    time.Time `json:"not_after"`
+	Description string    `json:"description"`
+}
+
+func newTRCInfo(trc cppki.SignedTRC) trcInfo {
+	return trcInfo{
+		ID:          trc.TRC.ID.String(),
+		ISD:         trc.TRC.ID.ISD,
+		Base:        uint64(trc.TRC.ID.Base),
+		Serial:      uint64(trc.TRC.ID.Serial),
+		NotBefore:   trc.TRC.Validity.NotBefore,
+		NotAfter:    trc.TRC.Validity.NotAfter,
+		Description: trc.TRC.Description,
+	}
+}
+
+func (t trcInfo) String() string {
+	return fmt.Sprintf("TRC %s | Validity: %s - %s", t.ID,
+		t.NotBefore.UTC().Format(time.RFC3339), t.NotAfter.UTC().Format(time.RFC3339))
+}
+
+type chainInfo struct {
+	IA           addr.IA   `json:"isd_as"`
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	SubjectKeyID string    `json:"subject_key_id"`
+	NotBefore    time.Time `json:"not_before"`
+	NotAfter     time.Time `json:"not_after"`
+}
+
+func newChainInfo(chain []*x509.Certificate) chainInfo {
+	// The ISD-AS was successfully extracted when loading the chain.
+	ia, _ := cppki.ExtractIA(chain[0].Subject)
+	return chainInfo{
+		IA:           ia,
+		Subject:      chain[0].Subject.String(),
+		Issuer:       chain[0].Issuer.String(),
+		SubjectKeyID: fmt.Sprintf("% X", chain[0].SubjectKeyId),
+		NotBefore:    chain[0].NotBefore,
+		NotAfter:     chain[0].NotAfter,
+	}
+}
+
+func (c chainInfo) String() string {
+	return fmt.Sprintf("Chain %s %s | Validity: %s - %s", c.IA, c.SubjectKeyID,
+		c.NotBefore.UTC().Format(time.RFC3339), c.NotAfter.UTC().Format(time.RFC3339))
+}
+
+// trustProblem is a problem that was found with a TRC or a certificate chain.
+// Exactly one of TRC and Chain is set.
+type trustProblem struct {
+	TRC     *trcInfo   `json:"trc,omitempty"`
+	Chain   *chainInfo `json:"chain,omitempty"`
+	Problem string     `json:"problem"`
+}
+
+func (p trustProblem) String() string {
+	if p.TRC != nil {
+		return fmt.Sprintf("%s: %s", p.TRC, p.Problem)
+	}
+	return fmt.Sprintf("%s: %s", p.Chain, p.Problem)
+}
+
+func trcProblem(trc cppki.SignedTRC, problem string) trustProblem {
+	info := newTRCInfo(trc)
+	return trustProblem{TRC: &info, Problem: problem}
+}
+
+func chainProblem(chain []*x509.Certificate, problem string) trustProblem {
+	info := newChainInfo(chain)
+	return trustProblem{Chain: &info, Problem: problem}
+}
+
+func newTrustList(pather command.Pather) *cobra.Command {
+	var flags trustFlags
+	cmd := &cobra.Command{
+		Use:   "list [flags] <trust-db>",
+		Short: "List the TRCs and certificate chains in a trust database",
+		Example: fmt.Sprintf(`  %[1]s list cs1-ff00_0_110-1.trust.db
+  %[1]s list --isd-as 1-0 --json cs1-ff00_0_110-1.trust.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openTrustDB(args[0])
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cmd.SilenceUsage = true
+
+			m, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			trcs := make([]trcInfo, 0, len(m.trcs))
+			for _, trc := range m.trcs {
+				trcs = append(trcs, newTRCInfo(trc))
+			}
+			chains := make([]chainInfo, 0, len(m.chains))
+			for _, chain := range m.chains {
+				chains = append(chains, newChainInfo(chain))
+			}
+			if flags.json {
+				return writeJSON(cmd.OutOrStdout(), map[string]interface{}{
+					"trcs":   trcs,
+					"chains": chains,
+				})
+			}
+			for _, trc := range trcs {
+				fmt.Fprintln(cmd.OutOrStdout(), trc)
+			}
+			for _, chain := range chains {
+				fmt.Fprintln(cmd.OutOrStdout(), chain)
+			}
+			return nil
+		},
+	}
+	flags.register(cmd.Flags())
+	return cmd
+}
+
+func newTrustVerify(pather command.Pather) *cobra.Command {
+	var flags trustFlags
+	cmd := &cobra.Command{
+		Use:   "verify [flags] <trust-db>",
+		Short: "Verify the TRCs and certificate chains in a trust database",
+		Long: `'verify' verifies the selected TRCs and certificate chains.
+
+Base TRCs are verified on their own, TRC updates are verified against their
+predecessor. Certificate chains are verified against the TRCs of their ISD at
+the reference time. The command exits with code 1 if any verification fails.`,
+		Example: fmt.Sprintf(`  %[1]s verify cs1-ff00_0_110-1.trust.db
+  %[1]s verify --time 2021-06-01T00:00:00Z cs1-ff00_0_110-1.trust.db`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openTrustDB(args[0])
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cmd.SilenceUsage = true
+
+			m, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			problems := []trustProblem{}
+			for _, trc := range m.trcs {
+				if err := verifyTRC(cmd.Context(), db, trc); err != nil {
+					problems = append(problems, trcProblem(trc, err.Error()))
+				}
+			}
+			for _, chain := range m.chains {
+				opts := cppki.VerifyOptions{
+					TRC:         m.trcsOf(newChainInfo(chain).IA.I),
+					CurrentTime: flags.now.Time,
+				}
+				if err := cppki.VerifyChain(chain, opts); err != nil {
+					problems = append(problems, chainProblem(chain, err.Error()))
+				}
+			}
+			return reportTrustProblems(cmd, flags.json, len(m.trcs)+len(m.chains), problems)
+		},
+	}
+	flags.register(cmd.Flags())
+	return cmd
+}
+
+// verifyTRC verifies the TRC. Base TRCs are verified on their own, TRC updates
+// are verified against their predecessor.
+func verifyTRC(ctx context.Context, db trust.DB, trc cppki.SignedTRC) error {
+	if trc.TRC.ID.IsBase() {
+		return trc.Verify(nil)
+	}
+	predID := trc.TRC.ID
+	predID.Serial--
+	pred, err := db.SignedTRC(ctx, predID)
+	if err != nil {
+		return serrors.WrapStr("loading predecessor", err)
+	}
+	if pred.IsZero() {
+		return serrors.New("predecessor missing", "id", predID)
+	}
+	return trc.Verify(&pred.TRC)
+}
+
+func newTrustCheck(pather command.Pather) *cobra.Command {
+	var flags trustFlags
+	cmd := &cobra.Command{
+		Use:   "check [flags] <trust-db>",
+		Short: "Report expired and orphaned TRCs and certificate chains",
+		Long: `'check' reports the selected TRCs and certificate chains that are expired or
+orphaned.
+
+A TRC is reported as expired, if it is the latest TRC of its ISD and has expired.
+Earlier TRCs are kept for history and are not reported. A certificate chain is
+orphaned if there is no TRC of its ISD in the trust database. The command exits
+with code 1 if any problem is found.`,
+		Example: fmt.Sprintf(`  %[1]s check cs1-ff00_0_110-1.trust.db`, pather.CommandPath()),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openTrustDB(args[0])
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			cmd.SilenceUsage = true
+
+			m, err := flags.load(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			now := flags.now.Time
+			problems := []trustProblem{}
+			for _, trc := range m.trcs {
+				latest, err := db.SignedTRC(cmd.Context(), cppki.TRCID{
+					ISD:    trc.TRC.ID.ISD,
+					Base:   scrypto.LatestVer,
+					Serial: scrypto.LatestVer,
+				})
+				if err != nil {
+					return serrors.WrapStr("loading latest TRC", err)
+				}
+				if latest.TRC.ID == trc.TRC.ID && trc.TRC.Validity.NotAfter.Before(now) {
+					problems = append(problems, trcProblem(trc, "latest TRC expired at "+
+						trc.TRC.Validity.NotAfter.UTC().Format(time.RFC3339)))
+				}
+			}
+			for _, chain := range m.chains {
+				if chain[0].NotAfter.Before(now) {
+					problems = append(problems, chainProblem(chain, "expired at "+
+						chain[0].NotAfter.UTC().Format(time.RFC3339)))
+				}
+				if len(m.trcsOf(newChainInfo(chain).IA.I)) == 0 {
+					problems = append(problems, chainProblem(chain, "orphaned, no TRC of ISD"))
+				}
+			}
+			return reportTrustProblems(cmd, flags.json, len(m.trcs)+len(m.chains), problems)
+		},
+	}
+	flags.register(cmd.Flags())
+	return cmd
+}
+
+// reportTrustProblems writes the problems and fails if there are any.
+func reportTrustProblems(cmd *cobra.Command, asJSON bool, total int,
+	problems []trustProblem) error {
+
+	if asJSON {
+		if err := writeJSON(cmd.OutOrStdout(), problems); err != nil {
+			return err
+		}
+	} else {
+		for _, p := range problems {
+			fmt.Fprintln(cmd.OutOrStdout(), p)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Checked %d entries, found %d problems.\n",
+			total, len(problems))
+	}
+	if len(problems) != 0 {
+		return app.WithExitCode(serrors.New("problems found", "count", len(problems)), 1)
+	}
+	return nil
+}
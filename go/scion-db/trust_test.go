@@ -0,0 +1,186 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/command"
+	"github.com/scionproto/scion/go/pkg/storage/trust/sqlite"
+)
+
+const (
+	trcS1    = "../pkg/trust/testdata/common/trcs/ISD1-B1-S1.trc"
+	trcS2    = "../pkg/trust/testdata/common/trcs/ISD1-B1-S2.trc"
+	chain110 = "../pkg/trust/testdata/common/certs/ISD1-ASff00_0_110.pem"
+	chain111 = "../pkg/trust/testdata/common/certs/ISD1-ASff00_0_111.pem"
+
+	// validTime is a point in time at which all the test crypto material is
+	// valid.
+	validTime = "2021-06-01T00:00:00Z"
+)
+
+func TestTrustList(t *testing.T) {
+	file := newTrustDB(t, []string{trcS1, trcS2}, []string{chain110, chain111})
+
+	testCases := map[string]struct {
+		Args   []string
+		TRCs   int
+		Chains int
+	}{
+		"all": {
+			TRCs:   2,
+			Chains: 2,
+		},
+		"isd-as": {
+			Args:   []string{"--isd-as", "1-ff00:0:110"},
+			TRCs:   2,
+			Chains: 1,
+		},
+		"other isd": {
+			Args: []string{"--isd-as", "2-0"},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			out, err := runTrust(append(append([]string{"list", "--json"}, tc.Args...),
+				file)...)
+			require.NoError(t, err)
+			var material struct {
+				TRCs   []trcInfo   `json:"trcs"`
+				Chains []chainInfo `json:"chains"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(out), &material))
+			assert.Len(t, material.TRCs, tc.TRCs)
+			assert.Len(t, material.Chains, tc.Chains)
+		})
+	}
+}
+
+func TestTrustVerify(t *testing.T) {
+	testCases := map[string]struct {
+		TRCs      []string
+		Chains    []string
+		Time      string
+		Problems  int
+		AssertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			TRCs:      []string{trcS1, trcS2},
+			Chains:    []string{chain110, chain111},
+			Time:      validTime,
+			AssertErr: assert.NoError,
+		},
+		"expired chains": {
+			TRCs:      []string{trcS1, trcS2},
+			Chains:    []string{chain110, chain111},
+			Time:      "2100-01-01T00:00:00Z",
+			Problems:  2,
+			AssertErr: assert.Error,
+		},
+		"predecessor missing": {
+			TRCs:      []string{trcS2},
+			Time:      validTime,
+			Problems:  1,
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			file := newTrustDB(t, tc.TRCs, tc.Chains)
+			out, err := runTrust("verify", "--json", "--time", tc.Time, file)
+			tc.AssertErr(t, err)
+			var problems []trustProblem
+			require.NoError(t, json.Unmarshal([]byte(out), &problems))
+			assert.Len(t, problems, tc.Problems)
+		})
+	}
+}
+
+func TestTrustCheck(t *testing.T) {
+	testCases := map[string]struct {
+		TRCs      []string
+		Chains    []string
+		Time      string
+		Problems  int
+		AssertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			TRCs:      []string{trcS1, trcS2},
+			Chains:    []string{chain110, chain111},
+			Time:      validTime,
+			AssertErr: assert.NoError,
+		},
+		"expired": {
+			TRCs:   []string{trcS1, trcS2},
+			Chains: []string{chain110, chain111},
+			Time:   "2100-01-01T00:00:00Z",
+			// Only the latest TRC is reported.
+			Problems:  3,
+			AssertErr: assert.Error,
+		},
+		"orphaned": {
+			Chains:    []string{chain110, chain111},
+			Time:      validTime,
+			Problems:  2,
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			file := newTrustDB(t, tc.TRCs, tc.Chains)
+			out, err := runTrust("check", "--json", "--time", tc.Time, file)
+			tc.AssertErr(t, err)
+			var problems []trustProblem
+			require.NoError(t, json.Unmarshal([]byte(out), &problems))
+			assert.Len(t, problems, tc.Problems)
+		})
+	}
+}
+
+// newTrustDB creates a trust database with the TRCs and certificate chains
+// loaded from the files.
+func newTrustDB(t *testing.T, trcs, chains []string) string {
+	file := filepath.Join(t.TempDir(), "trust.db")
+	db, err := sqlite.New(file)
+	require.NoError(t, err)
+	defer db.Close()
+	for _, f := range trcs {
+		_, err := db.InsertTRC(context.Background(), xtest.LoadTRC(t, f))
+		require.NoError(t, err)
+	}
+	for _, f := range chains {
+		_, err := db.InsertChain(context.Background(), xtest.LoadChain(t, f))
+		require.NoError(t, err)
+	}
+	return file
+}
+
+func runTrust(args ...string) (string, error) {
+	return execute(newTrust(command.StringPather("scion-db")), args...)
+}
@@ -17,6 +17,7 @@ package certs
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -53,6 +54,7 @@ import (
 	"github.com/scionproto/scion/go/pkg/command"
 	"github.com/scionproto/scion/go/pkg/grpc"
 	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+	"github.com/scionproto/scion/go/pkg/storage/trust/sqlite"
 	"github.com/scionproto/scion/go/pkg/trust"
 	"github.com/scionproto/scion/go/scion-pki/file"
 	"github.com/scionproto/scion/go/scion-pki/key"
@@ -256,10 +258,11 @@ The template is expressed in JSON. A valid example:
 				"local", localIP,
 			)
 
-			trcs, err := loadTRCs(flags.trcFiles)
+			signedTRCs, err := loadSignedTRCs(flags.trcFiles)
 			if err != nil {
 				return err
 			}
+			trcs := trcSlice(signedTRCs)
 			chain, issuer, err := loadChain(trcs, certFile)
 			if err != nil {
 				return err
@@ -394,6 +397,14 @@ The template is expressed in JSON. A valid example:
 			if err != nil {
 				return serrors.WrapStr("dialing", err)
 			}
+			// Authenticate the control service with the TRCs, and present
+			// the current certificate chain such that the control service can
+			// authenticate the client.
+			tlsMgr, err := newTLSCryptoManager(ctx, signedTRCs,
+				tlsCertificate(chain, privPrev))
+			if err != nil {
+				return err
+			}
 			dialer := &grpc.QUICDialer{
 				Rewriter: &messenger.AddressRewriter{
 					Router: &snet.BaseRouter{
@@ -408,11 +419,8 @@ The template is expressed in JSON. A valid example:
 					SVCResolutionFraction: 1,
 				},
 				Dialer: squic.ConnDialer{
-					Conn: conn,
-					TLSConfig: &tls.Config{
-						InsecureSkipVerify: true,
-						NextProtos:         []string{"SCION"},
-					},
+					Conn:      conn,
+					TLSConfig: tlsMgr.ClientTLSConfig(),
 				},
 			}
 
@@ -581,6 +589,46 @@ The template is expressed in JSON. A valid example:
 	return cmd
 }
 
+// tlsCertificate returns the certificate chain and the private key as TLS
+// certificate.
+func tlsCertificate(chain []*x509.Certificate, key crypto.Signer) tls.Certificate {
+	raw := make([][]byte, 0, len(chain))
+	for _, c := range chain {
+		raw = append(raw, c.Raw)
+	}
+	return tls.Certificate{
+		Certificate: raw,
+		PrivateKey:  key,
+		Leaf:        chain[0],
+	}
+}
+
+// newTLSCryptoManager returns a TLS crypto manager that presents the given
+// certificate and verifies the peer against the given TRCs.
+func newTLSCryptoManager(ctx context.Context, trcs []cppki.SignedTRC,
+	cert tls.Certificate) (*trust.TLSCryptoManager, error) {
+
+	db, err := sqlite.New("file::memory:")
+	if err != nil {
+		return nil, serrors.WrapStr("creating trust database", err)
+	}
+	for _, trc := range trcs {
+		if _, err := db.InsertTRC(ctx, trc); err != nil {
+			return nil, serrors.WrapStr("inserting TRC", err, "id", trc.TRC.ID)
+		}
+	}
+	return trust.NewTLSCryptoManager(keyPairLoader{cert: cert}, db), nil
+}
+
+// keyPairLoader loads a fixed TLS certificate.
+type keyPairLoader struct {
+	cert tls.Certificate
+}
+
+func (l keyPairLoader) LoadX509KeyPair() (*tls.Certificate, error) {
+	return &l.cert, nil
+}
+
 func encodeChain(chain []*x509.Certificate) ([]byte, error) {
 	var buffer bytes.Buffer
 	for _, c := range chain {
@@ -178,6 +178,16 @@ func loadTRC(trcFile string) (cppki.SignedTRC, error) {
 // loadTRCs is a helper function to load the two latest TRCs from files. If any
 // file cannot be read, a nil slice is returned and an error.
 func loadTRCs(trcFiles []string) ([]*cppki.TRC, error) {
+	signedTRCs, err := loadSignedTRCs(trcFiles)
+	if err != nil {
+		return nil, err
+	}
+	return trcSlice(signedTRCs), nil
+}
+
+// loadSignedTRCs loads the TRCs from the given files and selects the latest
+// ones, see selectLatestTRCs.
+func loadSignedTRCs(trcFiles []string) ([]cppki.SignedTRC, error) {
 	// Resolve all glob patterns.
 	var resolvedTRCFiles []string
 	for _, trcFile := range trcFiles {
@@ -205,7 +215,7 @@ func loadTRCs(trcFiles []string) ([]*cppki.TRC, error) {
 	if err != nil {
 		return nil, serrors.WrapStr("selecting latest TRCs", err)
 	}
-	return trcSlice(latestSignedTRCs), nil
+	return latestSignedTRCs, nil
 }
 
 // selectLatestTRCs selects the latest two TRCs by finding the highest base
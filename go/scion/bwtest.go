@@ -0,0 +1,258 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet/resolver"
+	"github.com/scionproto/scion/go/pkg/app"
+	"github.com/scionproto/scion/go/pkg/app/flag"
+	"github.com/scionproto/scion/go/pkg/bwtest"
+)
+
+func newBwtest(pather CommandPather) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "bwtest",
+		Short: "Measure the bandwidth, loss and delay variation of SCION paths",
+		Args:  cobra.NoArgs,
+		Long: `'bwtest' measures the achievable goodput, the loss, the reordering and the
+one-way delay variation of one or several paths between a client and a server.
+
+The client and the server send UDP packets at the target rate for the duration
+of the test, in one or both directions. The measurements are taken by the
+receiving side, the client fetches the measurements of the server at the end of
+the test.
+`,
+	}
+	cmd.AddCommand(
+		newBwtestClient(cmd),
+		newBwtestServer(cmd),
+	)
+	return cmd
+}
+
+func newBwtestClient(pather CommandPather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		duration   time.Duration
+		packetSize int
+		rate       float64
+		direction  string
+		paths      int
+		sequence   string
+		refresh    bool
+		json       bool
+		logLevel   string
+	}
+
+	var cmd = &cobra.Command{
+		Use:   "client [flags] <remote>",
+		Short: "Run a bandwidth test against a remote that runs 'bwtest server'",
+		Example: fmt.Sprintf(`  %[1]s client 1-ff00:0:110,[10.0.0.1]:30100
+  %[1]s client --rate 50 --packet-size 1200 --duration 10s 1-ff00:0:110,[10.0.0.1]:30100
+  %[1]s client --paths 3 --direction up --json 1-ff00:0:110,[10.0.0.1]:30100`,
+			pather.CommandPath()),
+		Long: fmt.Sprintf(`'client' runs a bandwidth test against a remote that runs 'bwtest server'.
+
+The test runs in parallel over the first --paths paths that match the
+--sequence, in the order of the path length. The --rate, --packet-size and
+--duration apply to each path and direction. The packet size is the size of
+the UDP payload, the SCION packets must fit the MTU of the paths.
+
+For each path and direction, the report contains the goodput, i.e., the rate of
+the received data, the fraction of lost packets, the number of reordered
+packets, the interarrival jitter (RFC 3550) and the difference between the
+largest and smallest one-way delay.
+
+The remote can be specified as SCION address or as host name.
+%s
+%s`, app.ResolverHelp, app.SequenceHelp),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := bwtest.Parameters{
+				Duration:   flags.duration,
+				PacketSize: flags.packetSize,
+				Rate:       int64(flags.rate * 1e6),
+			}
+			if err := params.Validate(); err != nil {
+				return err
+			}
+			var up, down bwtest.Parameters
+			switch flags.direction {
+			case "both":
+				up, down = params, params
+			case "up":
+				up = params
+			case "down":
+				down = params
+			default:
+				return serrors.New("invalid direction", "direction", flags.direction)
+			}
+			if flags.paths < 1 {
+				return serrors.New("at least one path is required", "paths", flags.paths)
+			}
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return serrors.WrapStr("setting up logging", err)
+			}
+			ctx := app.WithSignal(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			remote, err := resolver.ResolveUDPAddr(ctx, resolver.Default(), args[0])
+			if err != nil {
+				return serrors.WrapStr("resolving remote", err)
+			}
+			cmd.SilenceUsage = true
+
+			env, err := setupDataEnv(ctx, &envFlags)
+			if err != nil {
+				return err
+			}
+			paths, err := env.paths(ctx, remote.IA, flags.sequence, flags.refresh)
+			if err != nil {
+				return err
+			}
+			if len(paths) > flags.paths {
+				paths = paths[:flags.paths]
+			}
+			if !flags.json {
+				fmt.Printf("Running %s bandwidth test over %d paths for %s\n",
+					flags.direction, len(paths), flags.duration)
+			}
+
+			report := bwtest.Report{
+				Remote: remote.String(),
+				Paths:  make([]bwtest.PathReport, len(paths)),
+			}
+			var wg sync.WaitGroup
+			for i, p := range paths {
+				dst := remote.Copy()
+				dst.Path = p.Path()
+				dst.NextHop = p.UnderlayNextHop()
+				conn, err := env.listen(ctx, dst, 0)
+				if err != nil {
+					return err
+				}
+				defer conn.Close()
+				conn.SetPath(p)
+
+				wg.Add(1)
+				go func(i int) {
+					defer log.HandlePanic()
+					defer wg.Done()
+					res, err := bwtest.Run(ctx, conn, dst, up, down)
+					report.Paths[i] = bwtest.NewPathReport(paths[i], res, err)
+				}(i)
+			}
+			wg.Wait()
+
+			if flags.json {
+				if err := report.JSON(os.Stdout); err != nil {
+					return err
+				}
+			} else {
+				report.Human(os.Stdout)
+			}
+			for _, p := range report.Paths {
+				if p.Error == "" {
+					return nil
+				}
+			}
+			return app.WithExitCode(serrors.New("bandwidth test failed on all paths"), 1)
+		},
+	}
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().DurationVar(&flags.duration, "duration", 3*time.Second,
+		"duration of the test")
+	cmd.Flags().IntVar(&flags.packetSize, "packet-size", 1000,
+		"size of the test packets in bytes")
+	cmd.Flags().Float64Var(&flags.rate, "rate", 1, "target rate in Mbit/s")
+	cmd.Flags().StringVar(&flags.direction, "direction", "both",
+		"direction of the test (up|down|both)")
+	cmd.Flags().IntVar(&flags.paths, "paths", 1, "number of paths to test in parallel")
+	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
+	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "set refresh flag for path request")
+	cmd.Flags().BoolVarP(&flags.json, "json", "j", false,
+		"Write the output as machine readable json")
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+	return cmd
+}
+
+func newBwtestServer(pather CommandPather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		port        uint16
+		maxDuration time.Duration
+		maxRate     float64
+		maxSessions int
+		logLevel    string
+	}
+
+	var cmd = &cobra.Command{
+		Use:   "server [flags]",
+		Short: "Serve bandwidth tests",
+		Example: fmt.Sprintf(`  %[1]s server -p 30100
+  %[1]s server -p 30100 --max-rate 1000 --max-duration 10s --max-sessions 1`,
+			pather.CommandPath()),
+		Long: `'server' serves the bandwidth tests of 'bwtest client' until it is
+interrupted. Tests that exceed the maximum duration or rate are rejected, as
+are tests that are requested while the maximum number of sessions is active.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return serrors.WrapStr("setting up logging", err)
+			}
+			cmd.SilenceUsage = true
+
+			ctx := app.WithSignal(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			env, err := setupDataEnv(ctx, &envFlags)
+			if err != nil {
+				return err
+			}
+			conn, err := env.listen(ctx, nil, flags.port)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			fmt.Printf("Listening on %s,%s\n", env.ia, conn.LocalAddr())
+			server := &bwtest.Server{
+				Conn:        conn,
+				MaxDuration: flags.maxDuration,
+				MaxRate:     int64(flags.maxRate * 1e6),
+				MaxSessions: flags.maxSessions,
+			}
+			return server.Run(ctx)
+		},
+	}
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().Uint16VarP(&flags.port, "port", "p", 0, "local port")
+	cmd.Flags().DurationVar(&flags.maxDuration, "max-duration", 30*time.Second,
+		"maximum duration of a test (0 disables the limit)")
+	cmd.Flags().Float64Var(&flags.maxRate, "max-rate", 100,
+		"maximum rate of a test in Mbit/s per direction (0 disables the limit)")
+	cmd.Flags().IntVar(&flags.maxSessions, "max-sessions", 4,
+		"maximum number of concurrent tests (0 disables the limit)")
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+	return cmd
+}
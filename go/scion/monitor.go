@@ -0,0 +1,128 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"syscall"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/pkg/app"
+	"github.com/scionproto/scion/go/pkg/app/flag"
+	"github.com/scionproto/scion/go/pkg/command"
+	"github.com/scionproto/scion/go/pkg/monitor"
+)
+
+func newMonitor(pather CommandPather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		config   string
+		logLevel string
+	}
+
+	var cmd = &cobra.Command{
+		Use:   "monitor [flags]",
+		Short: "Continuously monitor the paths to a set of remotes",
+		Example: fmt.Sprintf(`  %[1]s monitor sample config > monitor.toml
+  %[1]s monitor --config monitor.toml`, pather.CommandPath()),
+		Long: `'monitor' continuously monitors the paths to the targets in the configuration
+file until it is interrupted.
+
+For every target, the paths that match the sequence are looked up and pinged
+periodically. Less frequently, every hop of the paths is probed with traceroute
+requests. Added and removed paths, and shifts of the round trip time to a hop
+are reported as events.
+
+The results are exposed as Prometheus metrics under /metrics and as JSON status,
+including the most recent events, under /status on the configured address.
+'monitor sample config' displays a sample configuration file.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.config == "" {
+				return serrors.New("configuration file is required")
+			}
+			var cfg monitor.Config
+			if err := config.LoadFile(flags.config, &cfg); err != nil {
+				return serrors.WrapStr("loading config from file", err, "file", flags.config)
+			}
+			cfg.InitDefaults()
+			if err := cfg.Validate(); err != nil {
+				return serrors.WrapStr("validating config", err, "file", flags.config)
+			}
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return serrors.WrapStr("setting up logging", err)
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancel := context.WithCancel(
+				app.WithSignal(cmd.Context(), os.Interrupt, syscall.SIGTERM))
+			defer cancel()
+			env, err := setupDataEnv(ctx, &envFlags)
+			if err != nil {
+				return err
+			}
+			m, err := monitor.New(cfg, &monitor.SCIONProber{
+				Daemon:     env.sd,
+				Dispatcher: reliable.NewDispatcher(env.dispatcher),
+				LocalIA:    env.ia,
+				LocalIP:    env.localIP,
+				PingCount:  cfg.PingCount,
+				Timeout:    cfg.Timeout.Duration,
+			}, monitor.NewMetrics())
+			if err != nil {
+				return err
+			}
+
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.Handler())
+			mux.HandleFunc("/status", m.ServeStatus)
+			server := &http.Server{Addr: cfg.Address, Handler: mux}
+			go func() {
+				defer log.HandlePanic()
+				<-ctx.Done()
+				server.Close()
+			}()
+			go func() {
+				defer log.HandlePanic()
+				m.Run(ctx)
+			}()
+
+			fmt.Printf("Monitoring %d targets, serving metrics on http://%[2]s/metrics "+
+				"and status on http://%[2]s/status\n", len(cfg.Targets), cfg.Address)
+			if err := server.ListenAndServe(); err != http.ErrServerClosed {
+				return serrors.WrapStr("serving metrics and status", err)
+			}
+			return nil
+		},
+	}
+	cmd.AddCommand(
+		command.NewSample(cmd, command.NewSampleConfig(&monitor.Config{})),
+	)
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().StringVarP(&flags.config, "config", "c", "", "configuration file (required)")
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+	return cmd
+}
@@ -0,0 +1,428 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/lucas-clemente/quic-go"
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/infra/infraenv"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/addrutil"
+	"github.com/scionproto/scion/go/lib/snet/resolver"
+	"github.com/scionproto/scion/go/lib/snet/squic"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/pkg/app"
+	"github.com/scionproto/scion/go/pkg/app/flag"
+	"github.com/scionproto/scion/go/pkg/app/path"
+	"github.com/scionproto/scion/go/pkg/netcat"
+)
+
+// ncPayloadSize is the maximum payload of the datagrams in UDP mode. It is
+// chosen such that the packets fit the MTU of all practical paths.
+const ncPayloadSize = 1000
+
+func newNetcat(pather CommandPather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		listen      bool
+		port        uint16
+		quic        bool
+		commands    bool
+		interactive bool
+		sequence    string
+		refresh     bool
+		noColor     bool
+		timeout     time.Duration
+		wait        time.Duration
+		logLevel    string
+	}
+
+	var cmd = &cobra.Command{
+		Use:     "nc [flags] <remote>",
+		Aliases: []string{"netcat"},
+		Short:   "Read and write data over SCION, using UDP or QUIC streams",
+		Example: fmt.Sprintf(`  %[1]s nc 1-ff00:0:110,[10.0.0.1]:4000
+  %[1]s nc --quic --commands --sequence '0* 1-ff00:0:111 0*' 1-ff00:0:110,[10.0.0.1]:4000
+  %[1]s nc -l -p 4000
+  %[1]s nc -l --quic -p 4000 > received.txt`, pather.CommandPath()),
+		Long: fmt.Sprintf(`'nc' reads data from the standard input and sends it to a remote SCION host,
+and writes the data received from the remote to the standard output.
+
+By default, the data is sent in UDP datagrams. With the --quic option, the data
+is sent over a QUIC stream instead, which provides reliable and ordered delivery.
+The QUIC peers are not authenticated.
+
+In connect mode, the path to the remote is chosen according to the --sequence
+and --interactive options. With the --commands option, the path can be switched
+while the data is sent:
+
+%s
+
+In listen mode (--listen), nc waits for a remote to connect to the port given by
+--port and then exchanges data with the first remote that sent data. The
+replies are sent over the reverse of the path that the remote used most
+recently, i.e., path switches of the remote are followed.
+
+After the end of the input, nc keeps receiving data until the remote closes the
+QUIC stream, the time given by --wait has passed, or nc is interrupted.
+
+The remote can be specified as SCION address or as host name.
+%s
+%s`, netcat.CommandHelp, app.ResolverHelp, app.SequenceHelp),
+		Args: cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.listen != (len(args) == 0) {
+				return serrors.New("either specify a remote or listen with --listen")
+			}
+			if flags.listen && flags.commands {
+				return serrors.New("path commands are only supported in connect mode")
+			}
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return serrors.WrapStr("setting up logging", err)
+			}
+			cmd.SilenceUsage = true
+
+			ctx := app.WithSignal(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			env, err := setupDataEnv(ctx, &envFlags)
+			if err != nil {
+				return err
+			}
+			tlsConfig, err := infraenv.GenerateTLSConfig()
+			if err != nil {
+				return serrors.WrapStr("generating TLS configuration", err)
+			}
+
+			nc := &ncRunner{wait: flags.wait}
+			if flags.listen {
+				conn, err := env.listen(ctx, nil, flags.port)
+				if err != nil {
+					return err
+				}
+				defer conn.Close()
+				fmt.Fprintf(os.Stderr, "Listening on %s,%s\n", env.ia, conn.LocalAddr())
+				if flags.quic {
+					return nc.acceptQUIC(ctx, conn, tlsConfig)
+				}
+				return nc.acceptUDP(ctx, conn)
+			}
+
+			remote, err := resolver.ResolveUDPAddr(ctx, resolver.Default(), args[0])
+			if err != nil {
+				return serrors.WrapStr("resolving remote", err)
+			}
+			paths, selected, err := env.choosePaths(ctx, remote.IA, flags.sequence,
+				[]path.Option{
+					path.WithInteractive(flags.interactive),
+					path.WithRefresh(flags.refresh),
+					path.WithSequence(flags.sequence),
+					path.WithColorScheme(path.DefaultColorScheme(flags.noColor)),
+				},
+			)
+			if err != nil {
+				return err
+			}
+			remote.Path = selected.Path()
+			remote.NextHop = selected.UnderlayNextHop()
+			fmt.Fprintf(os.Stderr, "Using path:\n  %s\n", selected)
+
+			conn, err := env.listen(ctx, remote, flags.port)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			conn.SetPath(selected)
+			if flags.commands {
+				nc.cmds = &netcat.Commands{Conn: conn, Paths: paths, Output: os.Stderr}
+			}
+			if flags.quic {
+				dialCtx, cancel := context.WithTimeout(ctx, flags.timeout)
+				defer cancel()
+				stream, err := squic.ConnDialer{
+					Conn:      conn,
+					TLSConfig: tlsConfig,
+				}.Dial(dialCtx, remote)
+				if err != nil {
+					return serrors.WrapStr("dialing QUIC", err)
+				}
+				defer stream.Close()
+				return nc.run(ctx, stream, stream)
+			}
+			return nc.run(ctx,
+				netcat.PacketWriter{Conn: conn, Remote: remote, MaxPayload: ncPayloadSize},
+				readerFunc(func(b []byte) (int, error) {
+					return readFromPeer(conn, b, remote)
+				}),
+			)
+		},
+	}
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().BoolVarP(&flags.listen, "listen", "l", false, "listen for a remote to connect")
+	cmd.Flags().Uint16VarP(&flags.port, "port", "p", 0, "local port")
+	cmd.Flags().BoolVar(&flags.quic, "quic", false, "use a QUIC stream instead of UDP datagrams")
+	cmd.Flags().BoolVar(&flags.commands, "commands", false,
+		"interpret lines starting with '~' in the input as path commands")
+	cmd.Flags().BoolVarP(&flags.interactive, "interactive", "i", false, "interactive mode")
+	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
+	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "set refresh flag for path request")
+	cmd.Flags().BoolVar(&flags.noColor, "no-color", false, "disable colored output")
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", 5*time.Second,
+		"timeout for establishing the QUIC connection")
+	cmd.Flags().DurationVar(&flags.wait, "wait", 0,
+		"time to keep receiving after the end of the input (0 waits indefinitely)")
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+	return cmd
+}
+
+// ncRunner exchanges the data between the standard input/output and the
+// remote.
+type ncRunner struct {
+	wait time.Duration
+	cmds *netcat.Commands
+}
+
+func (nc *ncRunner) acceptQUIC(ctx context.Context, conn net.PacketConn,
+	tlsConfig *tls.Config) error {
+
+	listener, err := quic.Listen(conn, tlsConfig, nil)
+	if err != nil {
+		return serrors.WrapStr("listening QUIC", err)
+	}
+	connListener := squic.NewConnListener(listener)
+	defer connListener.Close()
+	stream, err := connListener.AcceptCtx(ctx)
+	if err != nil {
+		return serrors.WrapStr("accepting QUIC connection", err)
+	}
+	defer stream.Close()
+	fmt.Fprintf(os.Stderr, "Accepted connection from %s\n", stream.RemoteAddr())
+	return nc.run(ctx, stream, stream)
+}
+
+func (nc *ncRunner) acceptUDP(ctx context.Context, conn net.PacketConn) error {
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	// The first datagram determines the remote.
+	buf := make([]byte, ncPayloadSize)
+	n, a, err := conn.ReadFrom(buf)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return serrors.WrapStr("receiving", err)
+	}
+	remote, ok := a.(*snet.UDPAddr)
+	if !ok {
+		return serrors.New("unexpected address type", "type", fmt.Sprintf("%T", a))
+	}
+	fmt.Fprintf(os.Stderr, "Connection from %s\n", remote)
+	if _, err := os.Stdout.Write(buf[:n]); err != nil {
+		return err
+	}
+	return nc.run(ctx,
+		netcat.PacketWriter{Conn: conn, Remote: remote, MaxPayload: ncPayloadSize},
+		readerFunc(func(b []byte) (int, error) {
+			return readFromPeer(conn, b, remote)
+		}),
+	)
+}
+
+// run copies the standard input to w, and r to the standard output, until the
+// remote closes the connection, the context is done, or the wait time after
+// the end of the input has passed.
+func (nc *ncRunner) run(ctx context.Context, w io.Writer, r io.Reader) error {
+	inputDone := make(chan error, 1)
+	outputDone := make(chan error, 1)
+	go func() {
+		inputDone <- netcat.CopyInput(w, os.Stdin, nc.cmds)
+	}()
+	go func() {
+		_, err := io.Copy(os.Stdout, r)
+		outputDone <- err
+	}()
+
+	var waitTimer <-chan time.Time
+	for {
+		select {
+		case err := <-inputDone:
+			if err != nil {
+				return serrors.WrapStr("sending", err)
+			}
+			log.Debug("End of input reached")
+			if nc.wait > 0 {
+				waitTimer = time.After(nc.wait)
+			}
+		case err := <-outputDone:
+			if err != nil && ctx.Err() == nil {
+				return serrors.WrapStr("receiving", err)
+			}
+			return nil
+		case <-waitTimer:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// readFromPeer reads the next datagram from the peer. Datagrams from other
+// remotes are dropped.
+func readFromPeer(conn net.PacketConn, b []byte, peer *snet.UDPAddr) (int, error) {
+	for {
+		n, a, err := conn.ReadFrom(b)
+		if err != nil {
+			return n, err
+		}
+		remote, ok := a.(*snet.UDPAddr)
+		if ok && remote.IA == peer.IA && remote.Host.String() == peer.Host.String() {
+			return n, nil
+		}
+		log.Debug("Dropping datagram from unknown remote", "remote", a)
+	}
+}
+
+type readerFunc func([]byte) (int, error)
+
+func (f readerFunc) Read(b []byte) (int, error) {
+	return f(b)
+}
+
+// dataEnv is the SCION environment of the commands that send data.
+type dataEnv struct {
+	sd         daemon.Connector
+	ia         addr.IA
+	localIP    net.IP
+	dispatcher string
+}
+
+func setupDataEnv(ctx context.Context, envFlags *flag.SCIONEnvironment) (*dataEnv, error) {
+	if err := envFlags.LoadExternalVars(); err != nil {
+		return nil, err
+	}
+	daemonAddr := envFlags.Daemon()
+	dispatcher := envFlags.Dispatcher()
+	localIP := envFlags.Local().IPAddr().IP
+	log.Debug("Resolved SCION environment flags",
+		"daemon", daemonAddr,
+		"dispatcher", dispatcher,
+		"local", localIP,
+	)
+	connCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	sd, err := daemon.NewService(daemonAddr).Connect(connCtx)
+	if err != nil {
+		return nil, serrors.WrapStr("connecting to SCION Daemon", err)
+	}
+	info, err := app.QueryASInfo(ctx, sd)
+	if err != nil {
+		return nil, err
+	}
+	return &dataEnv{
+		sd:         sd,
+		ia:         info.IA,
+		localIP:    localIP,
+		dispatcher: dispatcher,
+	}, nil
+}
+
+// choosePaths returns the paths to the remote that match the sequence, and the
+// path that is chosen according to the options.
+func (e *dataEnv) choosePaths(ctx context.Context, remote addr.IA, sequence string,
+	opts []path.Option) ([]snet.Path, snet.Path, error) {
+
+	selected, err := path.Choose(ctx, e.sd, remote, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	paths, err := e.paths(ctx, remote, sequence, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paths, selected, nil
+}
+
+// paths returns the sorted paths to the remote that match the sequence.
+func (e *dataEnv) paths(ctx context.Context, remote addr.IA, sequence string,
+	refresh bool) ([]snet.Path, error) {
+
+	all, err := e.sd.Paths(ctx, remote, addr.IA{}, daemon.PathReqFlags{Refresh: refresh})
+	if err != nil {
+		return nil, serrors.WrapStr("retrieving paths", err)
+	}
+	paths, err := path.Filter(sequence, all)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, serrors.New("no path available")
+	}
+	path.Sort(paths)
+	return paths, nil
+}
+
+// listen opens a SCION connection on the local port. If the local IP is not
+// configured, it is resolved based on the next hop towards the remote, or the
+// default local IP if the remote is nil.
+func (e *dataEnv) listen(ctx context.Context, remote *snet.UDPAddr,
+	port uint16) (*netcat.Conn, error) {
+
+	localIP := e.localIP
+	if localIP == nil {
+		var err error
+		switch {
+		case remote == nil:
+			localIP, err = addrutil.DefaultLocalIP(ctx, e.sd)
+		case remote.NextHop != nil:
+			localIP, err = addrutil.ResolveLocal(remote.NextHop.IP)
+		default:
+			localIP, err = addrutil.ResolveLocal(remote.Host.IP)
+		}
+		if err != nil {
+			return nil, serrors.WrapStr("resolving local address", err)
+		}
+		log.Debug("Resolved local address", "ip", localIP)
+	}
+	network := &snet.SCIONNetwork{
+		LocalIA: e.ia,
+		Dispatcher: &snet.DefaultPacketDispatcherService{
+			Dispatcher: reliable.NewDispatcher(e.dispatcher),
+			SCMPHandler: snet.DefaultSCMPHandler{
+				RevocationHandler: daemon.RevHandler{Connector: e.sd},
+			},
+		},
+	}
+	conn, err := network.Listen(ctx, "udp", &net.UDPAddr{IP: localIP, Port: int(port)},
+		addr.SvcNone)
+	if err != nil {
+		return nil, serrors.WrapStr("listening", err)
+	}
+	return netcat.NewConn(conn), nil
+}
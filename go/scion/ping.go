@@ -30,6 +30,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/addrutil"
+	"github.com/scionproto/scion/go/lib/snet/resolver"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/tracing"
 	"github.com/scionproto/scion/go/pkg/app"
@@ -60,7 +61,8 @@ func newPing(pather CommandPather) *cobra.Command {
 		Use:   "ping [flags] <remote>",
 		Short: "Test connectivity to a remote SCION host using SCMP echo packets",
 		Example: fmt.Sprintf(`  %[1]s ping 1-ff00:0:110,10.0.0.1
-  %[1]s ping 1-ff00:0:110,10.0.0.1 -c 5`, pather.CommandPath()),
+  %[1]s ping 1-ff00:0:110,10.0.0.1 -c 5
+  %[1]s ping server.example.org`, pather.CommandPath()),
 		Long: fmt.Sprintf(`'ping' test connectivity to a remote SCION host using SCMP echo packets.
 
 When the --count option is set, ping sends the specified number of SCMP echo packets
@@ -72,12 +74,14 @@ chooses amongst them.
 If no reply packet is received at all, ping will exit with code 1.
 On other errors, ping will exit with code 2.
 
-%s`, app.SequenceHelp),
+The remote can be specified as SCION address or as host name.
+%s
+%s`, app.ResolverHelp, app.SequenceHelp),
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			remote, err := snet.ParseUDPAddr(args[0])
+			remote, err := resolver.ResolveUDPAddr(cmd.Context(), resolver.Default(), args[0])
 			if err != nil {
-				return serrors.WrapStr("parsing remote", err)
+				return serrors.WrapStr("resolving remote", err)
 			}
 			if err := app.SetupLog(flags.logLevel); err != nil {
 				return serrors.WrapStr("setting up logging", err)
@@ -103,8 +107,8 @@ On other errors, ping will exit with code 2.
 			)
 
 			span, traceCtx := tracing.CtxWith(context.Background(), "run")
-			span.SetTag("dst.isd_as", remote.IA)
 			span.SetTag("dst.host", remote.Host.IP)
+			tracing.DstIA(span, remote.IA)
 			defer span.Finish()
 
 			ctx, cancelF := context.WithTimeout(traceCtx, time.Second)
@@ -118,7 +122,7 @@ On other errors, ping will exit with code 2.
 			if err != nil {
 				return err
 			}
-			span.SetTag("src.isd_as", info.IA)
+			tracing.SrcIA(span, info.IA)
 
 			opts := []path.Option{
 				path.WithInteractive(flags.interactive),
@@ -137,6 +141,7 @@ On other errors, ping will exit with code 2.
 			if err != nil {
 				return err
 			}
+			tracing.PathFingerprint(span, snet.Fingerprint(path))
 			remote.Path = path.Path()
 			remote.NextHop = path.UnderlayNextHop()
 
@@ -51,6 +51,11 @@ func main() {
 		newShowpaths(cmd),
 		newTraceroute(cmd),
 		newAddress(cmd),
+		newStatistics(cmd),
+		newNetcat(cmd),
+		newTransfer(cmd),
+		newBwtest(cmd),
+		newMonitor(cmd),
 	)
 
 	if err := cmd.Execute(); err != nil {
@@ -22,9 +22,9 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet/resolver"
 	"github.com/scionproto/scion/go/lib/tracing"
 	"github.com/scionproto/scion/go/pkg/app"
 	"github.com/scionproto/scion/go/pkg/app/flag"
@@ -54,7 +54,8 @@ func newShowpaths(pather CommandPather) *cobra.Command {
   %[1]s showpaths 1-ff00:0:111 --sequence="0-0#2 0*" # outgoing IfID=2
   %[1]s showpaths 1-ff00:0:111 --sequence="0* 0-0#41" # incoming IfID=41 at dstIA
   %[1]s showpaths 1-ff00:0:111 --sequence="0* 1-ff00:0:112 0*" # 1-ff00:0:112 on the path
-  %[1]s showpaths 1-ff00:0:110 --no-probe`, pather.CommandPath()),
+  %[1]s showpaths 1-ff00:0:110 --no-probe
+  %[1]s showpaths server.example.org`, pather.CommandPath()),
 		Long: fmt.Sprintf(`'showpaths' lists available paths between the local and the specified
 SCION ASe a.
 
@@ -68,11 +69,13 @@ If no alive path is discovered, json output is not enabled, and probing is not
 disabled, showpaths will exit with the code 1.
 On other errors, showpaths will exit with code 2.
 
-%s`, app.SequenceHelp),
+Instead of an ISD-AS, the name of a host in the destination AS can be specified.
+%s
+%s`, app.ResolverHelp, app.SequenceHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			dst, err := addr.IAFromString(args[0])
+			dst, err := resolver.ResolveIA(cmd.Context(), resolver.Default(), args[0])
 			if err != nil {
-				return serrors.WrapStr("invalid destination ISD-AS", err)
+				return serrors.WrapStr("resolving destination", err)
 			}
 			if err := app.SetupLog(flags.logLevel); err != nil {
 				return serrors.WrapStr("setting up logging", err)
@@ -99,7 +102,7 @@ On other errors, showpaths will exit with code 2.
 			)
 
 			span, traceCtx := tracing.CtxWith(context.Background(), "run")
-			span.SetTag("dst.isd_as", dst)
+			tracing.DstIA(span, dst)
 			defer span.Finish()
 
 			ctx, cancel := context.WithTimeout(traceCtx, flags.timeout)
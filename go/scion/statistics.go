@@ -0,0 +1,266 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	csapi "github.com/scionproto/scion/go/pkg/cs/api"
+)
+
+func newStatistics(pather CommandPather) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "statistics",
+		Short:   "Display the beacon and segment lookup statistics of a control service",
+		Aliases: []string{"stats"},
+		Args:    cobra.NoArgs,
+		Long: `'statistics' displays the rolling statistics that a control service keeps
+about the beacons it receives and the segment lookups it serves.
+
+The statistics are fetched from the API of the control service, which must be
+enabled in the control service configuration.
+`,
+	}
+	cmd.AddCommand(
+		newStatisticsBeacons(cmd),
+		newStatisticsLookups(cmd),
+	)
+	return cmd
+}
+
+type statisticsFlags struct {
+	api     string
+	timeout time.Duration
+	json    bool
+}
+
+func (f *statisticsFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.api, "api", "",
+		"Address of the control service API (e.g., http://127.0.0.1:30452)")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 5*time.Second, "Timeout")
+	cmd.Flags().BoolVar(&f.json, "json", false, "Write the output as machine readable json")
+	cmd.MarkFlagRequired("api")
+}
+
+func (f *statisticsFlags) client() (*csapi.ClientWithResponses, error) {
+	api := f.api
+	if !strings.Contains(api, "://") {
+		api = "http://" + api
+	}
+	return csapi.NewClientWithResponses(api)
+}
+
+func newStatisticsBeacons(pather CommandPather) *cobra.Command {
+	var flags struct {
+		statisticsFlags
+		isdAS   string
+		ingress int
+	}
+
+	var cmd = &cobra.Command{
+		Use:   "beacons",
+		Short: "Display the statistics of the received beacons",
+		Args:  cobra.NoArgs,
+		Example: fmt.Sprintf(`  %[1]s beacons --api 127.0.0.1:30452
+  %[1]s beacons --api 127.0.0.1:30452 --isd-as 1-0 --interface 2
+  %[1]s beacons --api 127.0.0.1:30452 --json`, pather.CommandPath()),
+		Long: `'beacons' displays the statistics of the beacons that the control service
+received in the statistics window, per start AS and ingress interface.
+
+The output lists the number of received beacons by handling result, the beacons
+that were rejected by the beacon policies by reason, the age distribution of the
+beacons at reception, the number of times a beacon was not propagated because
+of a loop, and how often the beacons were selected by each beacon policy.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := &csapi.GetBeaconStatisticsParams{}
+			if flags.isdAS != "" {
+				if _, err := addr.IAFromString(flags.isdAS); err != nil {
+					return serrors.WrapStr("parsing ISD-AS", err)
+				}
+				ia := csapi.IsdAs(flags.isdAS)
+				params.StartIsdAs = &ia
+			}
+			if cmd.Flags().Changed("interface") {
+				params.IngressInterface = &flags.ingress
+			}
+			client, err := flags.client()
+			if err != nil {
+				return serrors.WrapStr("creating client", err)
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancel()
+			rep, err := client.GetBeaconStatisticsWithResponse(ctx, params)
+			if err != nil {
+				return serrors.WrapStr("fetching beacon statistics", err)
+			}
+			if rep.JSON200 == nil {
+				return statisticsError(rep.HTTPResponse, rep.Body)
+			}
+			if flags.json {
+				return writeStatisticsJSON(cmd.OutOrStdout(), rep.JSON200)
+			}
+			return writeBeaconStatistics(cmd.OutOrStdout(), *rep.JSON200)
+		},
+	}
+	flags.register(cmd)
+	cmd.Flags().StringVar(&flags.isdAS, "isd-as", "",
+		"Only show beacons that start in the ISD-AS (0 is a wildcard)")
+	cmd.Flags().IntVar(&flags.ingress, "interface", 0,
+		"Only show beacons received on the interface")
+	return cmd
+}
+
+func newStatisticsLookups(pather CommandPather) *cobra.Command {
+	var flags statisticsFlags
+
+	var cmd = &cobra.Command{
+		Use:   "lookups",
+		Short: "Display the statistics of the served segment lookups",
+		Args:  cobra.NoArgs,
+		Example: fmt.Sprintf(`  %[1]s lookups --api 127.0.0.1:30452
+  %[1]s lookups --api 127.0.0.1:30452 --json`, pather.CommandPath()),
+		Long: `'lookups' displays the statistics of the segment lookups that the control
+service served in the statistics window, per requester.
+
+Requesters are identified by their ISD-AS if they are authenticated, and by
+their address otherwise.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := flags.client()
+			if err != nil {
+				return serrors.WrapStr("creating client", err)
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancel()
+			rep, err := client.GetSegmentLookupStatisticsWithResponse(ctx)
+			if err != nil {
+				return serrors.WrapStr("fetching segment lookup statistics", err)
+			}
+			if rep.JSON200 == nil {
+				return statisticsError(rep.HTTPResponse, rep.Body)
+			}
+			if flags.json {
+				return writeStatisticsJSON(cmd.OutOrStdout(), rep.JSON200)
+			}
+			return writeLookupStatistics(cmd.OutOrStdout(), *rep.JSON200)
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func writeBeaconStatistics(w io.Writer, s csapi.BeaconStatistics) error {
+	fmt.Fprintf(w, "Beacon statistics of the last %s\n", time.Duration(s.Window)*time.Second)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "START\tINGRESS\tRECEIVED\tRESULTS\tFILTERED\tAGES\tLOOP DROPS\t"+
+		"SELECTED\tLAST RECEIVED")
+	for _, b := range s.Beacons {
+		var ages []string
+		for _, bucket := range b.Ages {
+			ages = append(ages, fmt.Sprintf("<=%s:%d", bucket.Le, bucket.Count))
+		}
+		var selection []string
+		for _, sel := range b.Selection {
+			selection = append(selection,
+				fmt.Sprintf("%s:%d/%d", sel.Policy, sel.Selected, sel.Candidates))
+		}
+		lastReceived := "-"
+		if b.LastReceived != nil {
+			lastReceived = b.LastReceived.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			b.StartIsdAs,
+			b.IngressInterface,
+			b.Received,
+			formatCounts(b.Results.AdditionalProperties),
+			formatCounts(b.FilterReasons.AdditionalProperties),
+			strings.Join(ages, " "),
+			b.LoopDrops,
+			orDash(strings.Join(selection, " ")),
+			lastReceived,
+		)
+	}
+	return tw.Flush()
+}
+
+func writeLookupStatistics(w io.Writer, s csapi.LookupStatistics) error {
+	fmt.Fprintf(w, "Segment lookup statistics of the last %s\n",
+		time.Duration(s.Window)*time.Second)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REQUESTER\tREQUESTS\tRESULTS\tSEGMENTS\tLAST REQUEST")
+	for _, l := range s.Lookups {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%s\n",
+			l.Requester,
+			l.Requests,
+			formatCounts(l.Results.AdditionalProperties),
+			l.Segments,
+			l.LastRequest.Format(time.RFC3339),
+		)
+	}
+	return tw.Flush()
+}
+
+// formatCounts formats the counts sorted by key, e.g., "err_verify:1 ok_new:3".
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s:%d", k, counts[k]))
+	}
+	return orDash(strings.Join(parts, " "))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func writeStatisticsJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func statisticsError(rep *http.Response, body []byte) error {
+	var problem csapi.Problem
+	if err := json.Unmarshal(body, &problem); err == nil && problem.Detail != nil {
+		return serrors.New("control service API error", "status", rep.Status,
+			"title", problem.Title, "detail", *problem.Detail)
+	}
+	return serrors.New("control service API error", "status", rep.Status)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/addrutil"
+	"github.com/scionproto/scion/go/lib/snet/resolver"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/lib/tracing"
@@ -56,19 +57,23 @@ func newTraceroute(pather CommandPather) *cobra.Command {
 		Use:     "traceroute [flags] <remote>",
 		Aliases: []string{"tr"},
 		Short:   "Trace the SCION route to a remote SCION AS using SCMP traceroute packets",
-		Example: fmt.Sprintf("  %[1]s traceroute 1-ff00:0:110,10.0.0.1", pather.CommandPath()),
+		Example: fmt.Sprintf(`  %[1]s traceroute 1-ff00:0:110,10.0.0.1
+  %[1]s traceroute server.example.org`, pather.CommandPath()),
 		Long: fmt.Sprintf(`'traceroute' traces the SCION path to a remote AS using
 SCMP traceroute packets.
 
 If any packet is dropped, traceroute will exit with code 1.
 On other errors, traceroute will exit with code 2.
-%s`, app.SequenceHelp),
+
+The remote can be specified as SCION address or as host name.
+%s
+%s`, app.ResolverHelp, app.SequenceHelp),
 
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			remote, err := snet.ParseUDPAddr(args[0])
+			remote, err := resolver.ResolveUDPAddr(cmd.Context(), resolver.Default(), args[0])
 			if err != nil {
-				return serrors.WrapStr("parsing remote", err)
+				return serrors.WrapStr("resolving remote", err)
 			}
 			if err := app.SetupLog(flags.logLevel); err != nil {
 				return serrors.WrapStr("setting up logging", err)
@@ -94,8 +99,8 @@ On other errors, traceroute will exit with code 2.
 			)
 
 			span, traceCtx := tracing.CtxWith(context.Background(), "run")
-			span.SetTag("dst.isd_as", remote.IA)
 			span.SetTag("dst.host", remote.Host.IP)
+			tracing.DstIA(span, remote.IA)
 			defer span.Finish()
 
 			ctx, cancelF := context.WithTimeout(traceCtx, time.Second)
@@ -108,7 +113,7 @@ On other errors, traceroute will exit with code 2.
 			if err != nil {
 				return err
 			}
-			span.SetTag("src.isd_as", info.IA)
+			tracing.SrcIA(span, info.IA)
 			path, err := path.Choose(traceCtx, sd, remote.IA,
 				path.WithInteractive(flags.interactive),
 				path.WithRefresh(flags.refresh),
@@ -118,6 +123,7 @@ On other errors, traceroute will exit with code 2.
 			if err != nil {
 				return err
 			}
+			tracing.PathFingerprint(span, snet.Fingerprint(path))
 			remote.Path = path.Path()
 			remote.NextHop = path.UnderlayNextHop()
 			if remote.NextHop == nil {
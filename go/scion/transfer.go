@@ -0,0 +1,273 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lucas-clemente/quic-go"
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/infra/infraenv"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/resolver"
+	"github.com/scionproto/scion/go/lib/snet/squic"
+	"github.com/scionproto/scion/go/pkg/app"
+	"github.com/scionproto/scion/go/pkg/app/flag"
+	"github.com/scionproto/scion/go/pkg/transfer"
+)
+
+func newTransfer(pather CommandPather) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "transfer",
+		Short: "Transfer files over one or several SCION paths",
+		Args:  cobra.NoArgs,
+		Long: `'transfer' sends a file to a remote SCION host over one or several paths, and
+reports the throughput per path.
+
+The file is split into chunks that are sent over QUIC connections, one
+connection per path. Each connection takes the next chunk as soon as it has
+sent the previous one, such that faster paths transfer more chunks. The QUIC
+peers are not authenticated.
+`,
+	}
+	cmd.AddCommand(
+		newTransferSend(cmd),
+		newTransferReceive(cmd),
+	)
+	return cmd
+}
+
+func newTransferSend(pather CommandPather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		paths     int
+		sequence  string
+		refresh   bool
+		chunkSize int
+		timeout   time.Duration
+		logLevel  string
+	}
+
+	var cmd = &cobra.Command{
+		Use:   "send [flags] <remote> <file>",
+		Short: "Send a file to a remote that runs 'transfer receive'",
+		Example: fmt.Sprintf(`  %[1]s send 1-ff00:0:110,[10.0.0.1]:4000 image.iso
+  %[1]s send --paths 3 1-ff00:0:110,[10.0.0.1]:4000 image.iso
+  %[1]s send --sequence '0* 1-ff00:0:111 0*' 1-ff00:0:110,[10.0.0.1]:4000 image.iso`,
+			pather.CommandPath()),
+		Long: fmt.Sprintf(`'send' sends a file to a remote that runs 'transfer receive'.
+
+The file is sent over the first --paths paths that match the --sequence, in the
+order of the path length.
+
+The remote can be specified as SCION address or as host name.
+%s
+%s`, app.ResolverHelp, app.SequenceHelp),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.paths < 1 {
+				return serrors.New("at least one path is required", "paths", flags.paths)
+			}
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return serrors.WrapStr("setting up logging", err)
+			}
+			ctx := app.WithSignal(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			remote, err := resolver.ResolveUDPAddr(ctx, resolver.Default(), args[0])
+			if err != nil {
+				return serrors.WrapStr("resolving remote", err)
+			}
+			file, err := os.Open(args[1])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			info, err := file.Stat()
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			env, err := setupDataEnv(ctx, &envFlags)
+			if err != nil {
+				return err
+			}
+			paths, err := env.paths(ctx, remote.IA, flags.sequence, flags.refresh)
+			if err != nil {
+				return err
+			}
+			if len(paths) < flags.paths {
+				fmt.Fprintf(os.Stderr, "Only %d of %d paths available\n",
+					len(paths), flags.paths)
+			} else {
+				paths = paths[:flags.paths]
+			}
+			tlsConfig, err := infraenv.GenerateTLSConfig()
+			if err != nil {
+				return serrors.WrapStr("generating TLS configuration", err)
+			}
+
+			// Dial one QUIC connection per path. Each connection uses its own
+			// socket, such that the path of each connection is fixed.
+			dialCtx, cancel := context.WithTimeout(ctx, flags.timeout)
+			defer cancel()
+			streams := make([]io.ReadWriter, 0, len(paths))
+			for i, p := range paths {
+				dst := remote.Copy()
+				dst.Path = p.Path()
+				dst.NextHop = p.UnderlayNextHop()
+				conn, err := env.listen(ctx, dst, 0)
+				if err != nil {
+					return err
+				}
+				defer conn.Close()
+				conn.SetPath(p)
+				stream, err := squic.ConnDialer{
+					Conn:      conn,
+					TLSConfig: tlsConfig,
+				}.Dial(dialCtx, dst)
+				if err != nil {
+					return serrors.WrapStr("dialing QUIC", err, "path", i)
+				}
+				defer stream.Close()
+				streams = append(streams, stream)
+			}
+
+			fmt.Printf("Sending %s (%d bytes) to %s over %d paths\n",
+				args[1], info.Size(), remote, len(paths))
+			stats, err := transfer.Send(ctx, file, info.Size(), streams, flags.chunkSize)
+			if err != nil {
+				return err
+			}
+			return writeTransferStats(os.Stdout, stats, paths)
+		},
+	}
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().IntVar(&flags.paths, "paths", 1, "number of paths to send the file over")
+	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
+	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "set refresh flag for path request")
+	cmd.Flags().IntVar(&flags.chunkSize, "chunk-size", transfer.DefaultChunkSize,
+		"size of the chunks in bytes")
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", 5*time.Second,
+		"timeout for establishing the QUIC connections")
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+	return cmd
+}
+
+func newTransferReceive(pather CommandPather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		port     uint16
+		logLevel string
+	}
+
+	var cmd = &cobra.Command{
+		Use:   "receive [flags] <file>",
+		Short: "Receive a file that is sent with 'transfer send'",
+		Example: fmt.Sprintf(`  %[1]s receive -p 4000 image.iso`,
+			pather.CommandPath()),
+		Long: `'receive' waits for a remote that runs 'transfer send' and writes the
+received file. The file is created or truncated.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return serrors.WrapStr("setting up logging", err)
+			}
+			cmd.SilenceUsage = true
+
+			ctx := app.WithSignal(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			env, err := setupDataEnv(ctx, &envFlags)
+			if err != nil {
+				return err
+			}
+			tlsConfig, err := infraenv.GenerateTLSConfig()
+			if err != nil {
+				return serrors.WrapStr("generating TLS configuration", err)
+			}
+			conn, err := env.listen(ctx, nil, flags.port)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			listener, err := quic.Listen(conn, tlsConfig, nil)
+			if err != nil {
+				return serrors.WrapStr("listening QUIC", err)
+			}
+			connListener := squic.NewConnListener(listener)
+			defer connListener.Close()
+
+			file, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			fmt.Printf("Listening on %s,%s\n", env.ia, conn.LocalAddr())
+			stats, err := transfer.Receive(ctx, file, connListener)
+			if err != nil {
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+			return writeTransferStats(os.Stdout, stats, nil)
+		},
+	}
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().Uint16VarP(&flags.port, "port", "p", 0, "local port")
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+	return cmd
+}
+
+// writeTransferStats writes the statistics per stream. If paths is not nil,
+// the path of each stream is included.
+func writeTransferStats(w io.Writer, stats []transfer.Stats, paths []snet.Path) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := "STREAM\tBYTES\tCHUNKS\tDURATION\tTHROUGHPUT"
+	if paths != nil {
+		header += "\tPATH"
+	}
+	fmt.Fprintln(tw, header)
+	var total transfer.Stats
+	for i, s := range stats {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s", i, s.Bytes, s.Chunks,
+			s.Duration.Round(time.Millisecond), fmtThroughput(s.Throughput()))
+		if paths != nil {
+			fmt.Fprintf(tw, "\t%s", paths[i])
+		}
+		fmt.Fprintln(tw)
+		total.Bytes += s.Bytes
+		total.Chunks += s.Chunks
+		if s.Duration > total.Duration {
+			total.Duration = s.Duration
+		}
+	}
+	fmt.Fprintf(tw, "total\t%d\t%d\t%s\t%s\n", total.Bytes, total.Chunks,
+		total.Duration.Round(time.Millisecond), fmtThroughput(total.Throughput()))
+	return tw.Flush()
+}
+
+func fmtThroughput(bps float64) string {
+	return fmt.Sprintf("%.2f Mbit/s", bps/1e6)
+}
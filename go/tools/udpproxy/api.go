@@ -0,0 +1,82 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+// NewAPI returns the control API of the link. It serves the following
+// endpoints:
+//
+//  GET    /impairments  returns the current configuration.
+//  PUT    /impairments  replaces the configuration with the one in the body.
+//  DELETE /impairments  removes all impairments.
+//  GET    /stats        returns the packet counters per direction.
+func NewAPI(l *Link) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/impairments", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, l.Config())
+		case http.MethodPut:
+			var cfg Config
+			dec := json.NewDecoder(r.Body)
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&cfg); err != nil {
+				http.Error(w, fmt.Sprintf("parsing config: %s", err), http.StatusBadRequest)
+				return
+			}
+			if err := l.SetConfig(cfg); err != nil {
+				http.Error(w, fmt.Sprintf("invalid config: %s", err), http.StatusBadRequest)
+				return
+			}
+			log.Info("Impairments updated", "config", cfg)
+			writeJSON(w, cfg)
+		case http.MethodDelete:
+			if err := l.SetConfig(Config{}); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			log.Info("Impairments removed")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", "GET, PUT, DELETE")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, l.Stats())
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(v); err != nil {
+		log.Error("Unable to write response", "err", err)
+	}
+}
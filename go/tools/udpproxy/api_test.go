@@ -0,0 +1,71 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI(t *testing.T) {
+	link := NewLink(Config{}, 1)
+	api := NewAPI(link)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		api.ServeHTTP(rr, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rr
+	}
+
+	rr := do(http.MethodPut, "/impairments",
+		`{"latency": "20ms", "rules": [{"match": {"protocol": "bfd"}, "action": "drop"}]}`)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	cfg := link.Config()
+	assert.Equal(t, 20*time.Millisecond, cfg.Latency.Duration)
+	require.Len(t, cfg.Rules, 1)
+
+	rr = do(http.MethodGet, "/impairments", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got Config
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, cfg, got)
+
+	rr = do(http.MethodPut, "/impairments", `{"loss": 2}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	rr = do(http.MethodPut, "/impairments", `{"lossy": 0.5}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, cfg, link.Config(), "invalid config must not be applied")
+
+	rr = do(http.MethodDelete, "/impairments", "")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, Config{}, link.Config())
+
+	rr = do(http.MethodGet, "/stats", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var stats map[string]Stats
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stats))
+	assert.Contains(t, stats, DirectionXToY)
+	assert.Contains(t, stats, DirectionYToX)
+
+	rr = do(http.MethodPost, "/stats", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
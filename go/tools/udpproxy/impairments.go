@@ -0,0 +1,324 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// Directions of the relayed traffic.
+const (
+	DirectionXToY = "x_to_y"
+	DirectionYToX = "y_to_x"
+)
+
+// Protocols that rules can match on.
+const (
+	ProtocolUDP  = "udp"
+	ProtocolSCMP = "scmp"
+	ProtocolBFD  = "bfd"
+)
+
+// Action is the action that a rule applies to the matching packets.
+type Action string
+
+const (
+	// ActionDrop drops the packet.
+	ActionDrop Action = "drop"
+	// ActionDelay delays the packet by the delay of the rule, in addition to
+	// the link impairments.
+	ActionDelay Action = "delay"
+	// ActionCorruptMAC flips the bits of the MAC in the current hop field of
+	// the SCION path. The packet is then subject to the link impairments.
+	ActionCorruptMAC Action = "corrupt_mac"
+	// ActionPass forwards the packet immediately, without applying the link
+	// impairments.
+	ActionPass Action = "pass"
+)
+
+// Config is the impairment configuration of the link. The impairments apply to
+// both directions. The rules are evaluated in order before the impairments,
+// and the first matching rule is applied.
+type Config struct {
+	// Latency is the one-way delay that is added to every packet.
+	Latency util.DurWrap `json:"latency"`
+	// Jitter is the maximum deviation from the latency. The delay of every
+	// packet is picked uniformly from [latency-jitter, latency+jitter].
+	Jitter util.DurWrap `json:"jitter"`
+	// Loss is the probability in the range [0, 1] with which a packet is
+	// dropped.
+	Loss float64 `json:"loss"`
+	// Duplicate is the probability in the range [0, 1] with which a packet is
+	// sent twice.
+	Duplicate float64 `json:"duplicate"`
+	// Reorder is the probability in the range [0, 1] with which a packet is
+	// sent without the latency, such that it overtakes the packets that are
+	// still delayed.
+	Reorder float64 `json:"reorder"`
+	// Rate is the bandwidth cap in bits per second. Packets that exceed it are
+	// queued, and dropped if the queue holds more than one second of traffic.
+	// Zero means unlimited.
+	Rate uint64 `json:"rate"`
+	// Rules are the rules that target specific traffic.
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// Rule applies an action to the packets that it matches.
+type Rule struct {
+	// Match selects the packets.
+	Match Match `json:"match"`
+	// Action is the action applied to the selected packets.
+	Action Action `json:"action"`
+	// Delay is the additional delay for the delay action.
+	Delay util.DurWrap `json:"delay,omitempty"`
+	// Probability is the probability in the range [0, 1] with which the rule
+	// applies to a matching packet. If it is zero, the rule always applies.
+	Probability float64 `json:"probability,omitempty"`
+}
+
+// Match selects packets. Empty fields match any packet. The fields other than
+// the direction only match SCION packets.
+type Match struct {
+	// Direction is either x_to_y or y_to_x.
+	Direction string `json:"direction,omitempty"`
+	// Protocol is the upper layer protocol, one of udp, scmp and bfd.
+	Protocol string `json:"protocol,omitempty"`
+	// SCMPTypes are the SCMP types to match. Only valid with protocol scmp.
+	SCMPTypes []int `json:"scmp_types,omitempty"`
+	// Src is the source ISD-AS. Wildcards are allowed.
+	Src *addr.IA `json:"src_isd_as,omitempty"`
+	// Dst is the destination ISD-AS. Wildcards are allowed.
+	Dst *addr.IA `json:"dst_isd_as,omitempty"`
+}
+
+// LoadConfig loads the configuration from a JSON file.
+func LoadConfig(file string) (Config, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return Config{}, serrors.WrapStr("reading config", err, "file", file)
+	}
+	var cfg Config
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return Config{}, serrors.WrapStr("parsing config", err, "file", file)
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, serrors.WrapStr("validating config", err, "file", file)
+	}
+	return cfg, nil
+}
+
+// Validate validates the configuration.
+func (c *Config) Validate() error {
+	if c.Latency.Duration < 0 || c.Jitter.Duration < 0 {
+		return serrors.New("latency and jitter must not be negative")
+	}
+	probabilities := map[string]float64{
+		"loss":      c.Loss,
+		"duplicate": c.Duplicate,
+		"reorder":   c.Reorder,
+	}
+	for name, p := range probabilities {
+		if p < 0 || p > 1 {
+			return serrors.New("probability out of range", "field", name, "value", p)
+		}
+	}
+	for i, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return serrors.WrapStr("invalid rule", err, "index", i)
+		}
+	}
+	return nil
+}
+
+// Validate validates the rule.
+func (r *Rule) Validate() error {
+	switch r.Action {
+	case ActionDrop, ActionCorruptMAC, ActionPass:
+		if r.Delay.Duration != 0 {
+			return serrors.New("delay is only allowed with the delay action",
+				"action", r.Action)
+		}
+	case ActionDelay:
+		if r.Delay.Duration <= 0 {
+			return serrors.New("delay action requires a positive delay")
+		}
+	default:
+		return serrors.New("unknown action", "action", r.Action)
+	}
+	if r.Probability < 0 || r.Probability > 1 {
+		return serrors.New("probability out of range", "value", r.Probability)
+	}
+	switch r.Match.Direction {
+	case "", DirectionXToY, DirectionYToX:
+	default:
+		return serrors.New("unknown direction", "direction", r.Match.Direction)
+	}
+	switch r.Match.Protocol {
+	case "", ProtocolUDP, ProtocolSCMP, ProtocolBFD:
+	default:
+		return serrors.New("unknown protocol", "protocol", r.Match.Protocol)
+	}
+	if len(r.Match.SCMPTypes) != 0 && r.Match.Protocol != ProtocolSCMP {
+		return serrors.New("SCMP types require protocol scmp")
+	}
+	for _, t := range r.Match.SCMPTypes {
+		if t < 0 || t > 255 {
+			return serrors.New("SCMP type out of range", "type", t)
+		}
+	}
+	return nil
+}
+
+// match returns the first rule that matches the packet and that applies
+// according to its probability.
+func (c *Config) match(direction string, pkt *packetInfo, r *rand.Rand) (Rule, bool) {
+	for _, rule := range c.Rules {
+		if !rule.Match.matches(direction, pkt) {
+			continue
+		}
+		if rule.Probability != 0 && r.Float64() >= rule.Probability {
+			continue
+		}
+		return rule, true
+	}
+	return Rule{}, false
+}
+
+func (m *Match) matches(direction string, pkt *packetInfo) bool {
+	if m.Direction != "" && m.Direction != direction {
+		return false
+	}
+	if m.Protocol == "" && len(m.SCMPTypes) == 0 && m.Src == nil && m.Dst == nil {
+		return true
+	}
+	if !pkt.scion {
+		return false
+	}
+	if m.Protocol != "" && m.Protocol != pkt.protocol {
+		return false
+	}
+	if len(m.SCMPTypes) != 0 && !containsSCMPType(m.SCMPTypes, pkt.scmpType) {
+		return false
+	}
+	if m.Src != nil && !matchIA(*m.Src, pkt.src) {
+		return false
+	}
+	if m.Dst != nil && !matchIA(*m.Dst, pkt.dst) {
+		return false
+	}
+	return true
+}
+
+func containsSCMPType(types []int, t slayers.SCMPType) bool {
+	for _, c := range types {
+		if c == int(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchIA checks whether the ISD-AS matches the pattern, which may contain
+// wildcards.
+func matchIA(pattern, ia addr.IA) bool {
+	return (pattern.I == 0 || pattern.I == ia.I) && (pattern.A == 0 || pattern.A == ia.A)
+}
+
+// packetInfo is the information about a packet that rules match on.
+type packetInfo struct {
+	// scion indicates whether the packet could be parsed as SCION packet. The
+	// other fields are only set for SCION packets.
+	scion    bool
+	protocol string
+	scmpType slayers.SCMPType
+	src      addr.IA
+	dst      addr.IA
+	// path is the SCION path of the packet. It is nil for other path types.
+	// The path references the packet buffer.
+	path *scion.Raw
+}
+
+// classify parses the packet. Packets that cannot be parsed are classified as
+// non-SCION packets.
+func classify(data []byte) *packetInfo {
+	var (
+		scn  slayers.SCION
+		hbh  slayers.HopByHopExtnSkipper
+		e2e  slayers.EndToEndExtnSkipper
+		udp  slayers.UDP
+		scmp slayers.SCMP
+	)
+	parser := gopacket.NewDecodingLayerParser(slayers.LayerTypeSCION,
+		&scn, &hbh, &e2e, &udp, &scmp)
+	parser.IgnoreUnsupported = true
+	decoded := make([]gopacket.LayerType, 0, 5)
+	if err := parser.DecodeLayers(data, &decoded); err != nil || len(decoded) == 0 {
+		return &packetInfo{}
+	}
+	info := &packetInfo{
+		scion: true,
+		src:   scn.SrcIA,
+		dst:   scn.DstIA,
+	}
+	info.path, _ = scn.Path.(*scion.Raw)
+	l4 := scn.NextHdr
+	for _, l := range decoded {
+		switch l {
+		case slayers.LayerTypeHopByHopExtn:
+			l4 = hbh.NextHdr
+		case slayers.LayerTypeEndToEndExtn:
+			l4 = e2e.NextHdr
+		case slayers.LayerTypeSCMP:
+			info.scmpType = scmp.TypeCode.Type()
+		}
+	}
+	switch l4 {
+	case common.L4UDP:
+		info.protocol = ProtocolUDP
+	case common.L4SCMP:
+		info.protocol = ProtocolSCMP
+	case common.L4BFD:
+		info.protocol = ProtocolBFD
+	}
+	return info
+}
+
+// corruptMAC flips the bits of the MAC of the current hop field in place. It
+// returns false if the packet has no SCION path.
+func corruptMAC(pkt *packetInfo) bool {
+	if pkt.path == nil || pkt.path.NumHops == 0 {
+		return false
+	}
+	idx := int(pkt.path.PathMeta.CurrHF)
+	hop, err := pkt.path.GetHopField(idx)
+	if err != nil {
+		return false
+	}
+	for i := range hop.Mac {
+		hop.Mac[i] ^= 0xff
+	}
+	return pkt.path.SetHopField(hop, idx) == nil
+}
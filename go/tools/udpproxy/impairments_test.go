@@ -0,0 +1,292 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestConfigUnmarshal(t *testing.T) {
+	raw := `{
+		"latency": "20ms",
+		"jitter": "5ms",
+		"loss": 0.1,
+		"rate": 1000000,
+		"rules": [
+			{"match": {"protocol": "bfd", "direction": "x_to_y"}, "action": "drop"},
+			{"match": {"protocol": "scmp", "scmp_types": [5]}, "action": "delay",
+				"delay": "1s"},
+			{"match": {"src_isd_as": "1-0"}, "action": "corrupt_mac", "probability": 0.5}
+		]
+	}`
+	var cfg Config
+	require.NoError(t, json.Unmarshal([]byte(raw), &cfg))
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, 20*time.Millisecond, cfg.Latency.Duration)
+	assert.Equal(t, 5*time.Millisecond, cfg.Jitter.Duration)
+	assert.Equal(t, uint64(1000000), cfg.Rate)
+	require.Len(t, cfg.Rules, 3)
+	assert.Equal(t, ActionDrop, cfg.Rules[0].Action)
+	assert.Equal(t, []int{5}, cfg.Rules[1].Match.SCMPTypes)
+	assert.Equal(t, time.Second, cfg.Rules[1].Delay.Duration)
+	assert.Equal(t, xtest.MustParseIA("1-0"), *cfg.Rules[2].Match.Src)
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Config    Config
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"empty": {
+			Assertion: assert.NoError,
+		},
+		"negative latency": {
+			Config:    Config{Latency: util.DurWrap{Duration: -time.Second}},
+			Assertion: assert.Error,
+		},
+		"loss out of range": {
+			Config:    Config{Loss: 1.5},
+			Assertion: assert.Error,
+		},
+		"unknown action": {
+			Config:    Config{Rules: []Rule{{Action: "reject"}}},
+			Assertion: assert.Error,
+		},
+		"delay without duration": {
+			Config:    Config{Rules: []Rule{{Action: ActionDelay}}},
+			Assertion: assert.Error,
+		},
+		"duration without delay action": {
+			Config: Config{Rules: []Rule{{
+				Action: ActionDrop,
+				Delay:  util.DurWrap{Duration: time.Second},
+			}}},
+			Assertion: assert.Error,
+		},
+		"unknown direction": {
+			Config: Config{Rules: []Rule{{
+				Action: ActionDrop,
+				Match:  Match{Direction: "up"},
+			}}},
+			Assertion: assert.Error,
+		},
+		"unknown protocol": {
+			Config: Config{Rules: []Rule{{
+				Action: ActionDrop,
+				Match:  Match{Protocol: "tcp"},
+			}}},
+			Assertion: assert.Error,
+		},
+		"SCMP types without SCMP": {
+			Config: Config{Rules: []Rule{{
+				Action: ActionDrop,
+				Match:  Match{Protocol: ProtocolUDP, SCMPTypes: []int{5}},
+			}}},
+			Assertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tc.Assertion(t, tc.Config.Validate())
+		})
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	ia110, ia111 := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:111")
+	bfd := &packetInfo{scion: true, protocol: ProtocolBFD, src: ia110, dst: ia111}
+	scmp := &packetInfo{scion: true, protocol: ProtocolSCMP, src: ia111, dst: ia110,
+		scmpType: slayers.SCMPTypeExternalInterfaceDown}
+	other := &packetInfo{}
+
+	testCases := map[string]struct {
+		Match     Match
+		Direction string
+		Packet    *packetInfo
+		Matches   bool
+	}{
+		"empty matches any": {
+			Direction: DirectionXToY,
+			Packet:    other,
+			Matches:   true,
+		},
+		"direction": {
+			Match:     Match{Direction: DirectionYToX},
+			Direction: DirectionXToY,
+			Packet:    bfd,
+		},
+		"protocol": {
+			Match:     Match{Protocol: ProtocolBFD},
+			Direction: DirectionXToY,
+			Packet:    bfd,
+			Matches:   true,
+		},
+		"protocol mismatch": {
+			Match:     Match{Protocol: ProtocolUDP},
+			Direction: DirectionXToY,
+			Packet:    bfd,
+		},
+		"protocol non-SCION": {
+			Match:     Match{Protocol: ProtocolBFD},
+			Direction: DirectionXToY,
+			Packet:    other,
+		},
+		"SCMP type": {
+			Match: Match{
+				Protocol:  ProtocolSCMP,
+				SCMPTypes: []int{int(slayers.SCMPTypeExternalInterfaceDown)},
+			},
+			Direction: DirectionXToY,
+			Packet:    scmp,
+			Matches:   true,
+		},
+		"SCMP type mismatch": {
+			Match: Match{
+				Protocol:  ProtocolSCMP,
+				SCMPTypes: []int{int(slayers.SCMPTypeEchoRequest)},
+			},
+			Direction: DirectionXToY,
+			Packet:    scmp,
+		},
+		"source wildcard": {
+			Match:     Match{Src: iaPtr(xtest.MustParseIA("1-0"))},
+			Direction: DirectionXToY,
+			Packet:    bfd,
+			Matches:   true,
+		},
+		"destination mismatch": {
+			Match:     Match{Dst: iaPtr(ia110)},
+			Direction: DirectionXToY,
+			Packet:    bfd,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := Config{Rules: []Rule{{Match: tc.Match, Action: ActionDrop}}}
+			_, ok := cfg.match(tc.Direction, tc.Packet, rand.New(rand.NewSource(1)))
+			assert.Equal(t, tc.Matches, ok)
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	testCases := map[string]struct {
+		Packet   []byte
+		Protocol string
+		SCMPType slayers.SCMPType
+	}{
+		"UDP": {
+			Packet:   newPacket(t, common.L4UDP, &slayers.UDP{SrcPort: 1, DstPort: 2}),
+			Protocol: ProtocolUDP,
+		},
+		"BFD": {
+			Packet:   newPacket(t, common.L4BFD, gopacket.Payload(make([]byte, 24))),
+			Protocol: ProtocolBFD,
+		},
+		"SCMP": {
+			Packet: newPacket(t, common.L4SCMP, &slayers.SCMP{
+				TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeExternalInterfaceDown, 0),
+			}, gopacket.Payload(make([]byte, 16))),
+			Protocol: ProtocolSCMP,
+			SCMPType: slayers.SCMPTypeExternalInterfaceDown,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			pkt := classify(tc.Packet)
+			assert.True(t, pkt.scion)
+			assert.Equal(t, tc.Protocol, pkt.protocol)
+			assert.Equal(t, tc.SCMPType, pkt.scmpType)
+			assert.Equal(t, xtest.MustParseIA("1-ff00:0:110"), pkt.src)
+			assert.Equal(t, xtest.MustParseIA("1-ff00:0:111"), pkt.dst)
+		})
+	}
+	t.Run("garbage", func(t *testing.T) {
+		assert.False(t, classify([]byte("not a SCION packet")).scion)
+	})
+}
+
+func TestCorruptMAC(t *testing.T) {
+	data := newPacket(t, common.L4UDP, &slayers.UDP{SrcPort: 1, DstPort: 2})
+	pkt := classify(data)
+	require.True(t, corruptMAC(pkt))
+
+	var scn slayers.SCION
+	require.NoError(t, scn.DecodeFromBytes(data, gopacket.NilDecodeFeedback))
+	hop, err := scn.Path.(*scion.Raw).GetCurrentHopField()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xfe, 0xfd, 0xfc, 0xfb, 0xfa, 0xf9}, hop.Mac)
+
+	assert.False(t, corruptMAC(classify([]byte("not a SCION packet"))))
+}
+
+// newPacket serializes a SCION packet from 1-ff00:0:110 to 1-ff00:0:111 with
+// the given upper layers.
+func newPacket(t *testing.T, l4 common.L4ProtocolType,
+	upper ...gopacket.SerializableLayer) []byte {
+
+	t.Helper()
+	scn := &slayers.SCION{
+		NextHdr:  l4,
+		PathType: scion.PathType,
+		SrcIA:    xtest.MustParseIA("1-ff00:0:110"),
+		DstIA:    xtest.MustParseIA("1-ff00:0:111"),
+		Path: &scion.Decoded{
+			Base: scion.Base{
+				PathMeta: scion.MetaHdr{SegLen: [3]uint8{2, 0, 0}},
+				NumINF:   1,
+				NumHops:  2,
+			},
+			InfoFields: []*path.InfoField{{ConsDir: true, SegID: 1}},
+			HopFields: []*path.HopField{
+				{ConsEgress: 1, Mac: []byte{1, 2, 3, 4, 5, 6}},
+				{ConsIngress: 2, Mac: []byte{6, 5, 4, 3, 2, 1}},
+			},
+		},
+	}
+	require.NoError(t, scn.SetSrcAddr(&net.IPAddr{IP: net.IP{10, 0, 0, 1}}))
+	require.NoError(t, scn.SetDstAddr(&net.IPAddr{IP: net.IP{10, 0, 0, 2}}))
+	buf := gopacket.NewSerializeBuffer()
+	layers := append([]gopacket.SerializableLayer{scn}, upper...)
+	require.NoError(t, gopacket.SerializeLayers(buf,
+		gopacket.SerializeOptions{FixLengths: true}, layers...))
+	return buf.Bytes()
+}
+
+func iaPtr(ia addr.IA) *addr.IA {
+	return &ia
+}
@@ -0,0 +1,338 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"container/heap"
+	"errors"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+// maxBacklog is the maximum amount of traffic, measured in transmission time,
+// that is queued by the bandwidth cap. Packets beyond it are dropped.
+const maxBacklog = time.Second
+
+// Stats are the packet counters of one direction of the link.
+type Stats struct {
+	// Received is the number of packets received.
+	Received uint64 `json:"received"`
+	// Sent is the number of packets sent, including duplicates.
+	Sent uint64 `json:"sent"`
+	// Lost is the number of packets dropped by the loss impairment.
+	Lost uint64 `json:"lost"`
+	// Dropped is the number of packets dropped by a rule.
+	Dropped uint64 `json:"dropped"`
+	// QueueDropped is the number of packets dropped by the bandwidth cap.
+	QueueDropped uint64 `json:"queue_dropped"`
+	// Duplicated is the number of duplicated packets.
+	Duplicated uint64 `json:"duplicated"`
+	// Reordered is the number of packets sent without latency.
+	Reordered uint64 `json:"reordered"`
+	// Corrupted is the number of packets with a corrupted hop field MAC.
+	Corrupted uint64 `json:"corrupted"`
+}
+
+// Link is an emulated link between the networks x and y.
+type Link struct {
+	mu  sync.Mutex
+	cfg *Config
+
+	xToY *direction
+	yToX *direction
+}
+
+// NewLink creates a link with the given initial configuration. The
+// configuration must be valid.
+func NewLink(cfg Config, seed int64) *Link {
+	l := &Link{cfg: &cfg}
+	l.xToY = newDirection(DirectionXToY, l.config, seed)
+	l.yToX = newDirection(DirectionYToX, l.config, seed+1)
+	return l
+}
+
+// Config returns the current configuration.
+func (l *Link) Config() Config {
+	return *l.config()
+}
+
+// SetConfig validates and applies the configuration. It applies to all
+// packets that are received afterwards.
+func (l *Link) SetConfig(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cfg = &cfg
+	return nil
+}
+
+// Stats returns the packet counters per direction.
+func (l *Link) Stats() map[string]Stats {
+	return map[string]Stats{
+		DirectionXToY: l.xToY.Stats(),
+		DirectionYToX: l.yToX.Stats(),
+	}
+}
+
+// Run relays packets between the connections until one of them is closed.
+// Packets received on x are sent to toY through y, and vice versa.
+func (l *Link) Run(x, y net.PacketConn, toX, toY net.Addr) error {
+	errs := make(chan error, 2)
+	go func() {
+		defer log.HandlePanic()
+		errs <- l.xToY.run(x, y, toY)
+	}()
+	go func() {
+		defer log.HandlePanic()
+		errs <- l.yToX.run(y, x, toX)
+	}()
+	return <-errs
+}
+
+// config returns the current configuration. The returned configuration must
+// not be modified.
+func (l *Link) config() *Config {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.cfg
+}
+
+// delivery is a packet that is scheduled to be sent.
+type delivery struct {
+	at   time.Time
+	data []byte
+	// seq orders deliveries with the same time by arrival.
+	seq uint64
+}
+
+// direction applies the impairments to the packets of one direction.
+type direction struct {
+	name   string
+	config func() *Config
+
+	mu        sync.Mutex
+	rand      *rand.Rand
+	busyUntil time.Time
+	seq       uint64
+	queue     deliveryQueue
+	stats     Stats
+	// wake is signaled when a delivery is scheduled.
+	wake chan struct{}
+}
+
+func newDirection(name string, config func() *Config, seed int64) *direction {
+	return &direction{
+		name:   name,
+		config: config,
+		rand:   rand.New(rand.NewSource(seed)),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Stats returns the packet counters.
+func (d *direction) Stats() Stats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.stats
+}
+
+func (d *direction) run(in, out net.PacketConn, to net.Addr) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer log.HandlePanic()
+		d.send(out, to, done)
+	}()
+	buf := make([]byte, 1<<16)
+	for {
+		n, _, err := in.ReadFrom(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return err
+		}
+		if err != nil {
+			log.Error("Unable to read from listen conn", "direction", d.name, "err", err)
+			continue
+		}
+		data := append([]byte(nil), buf[:n]...)
+		d.schedule(d.process(d.config(), data, time.Now()))
+	}
+}
+
+// process applies the configuration to the packet and returns the resulting
+// deliveries.
+func (d *direction) process(cfg *Config, data []byte, now time.Time) []delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stats.Received++
+	var extra time.Duration
+	if len(cfg.Rules) != 0 {
+		pkt := classify(data)
+		if rule, ok := cfg.match(d.name, pkt, d.rand); ok {
+			switch rule.Action {
+			case ActionDrop:
+				d.stats.Dropped++
+				return nil
+			case ActionPass:
+				return []delivery{d.delivery(now, data)}
+			case ActionDelay:
+				extra = rule.Delay.Duration
+			case ActionCorruptMAC:
+				if corruptMAC(pkt) {
+					d.stats.Corrupted++
+				}
+			}
+		}
+	}
+	if cfg.Loss > 0 && d.rand.Float64() < cfg.Loss {
+		d.stats.Lost++
+		return nil
+	}
+	copies := 1
+	if cfg.Duplicate > 0 && d.rand.Float64() < cfg.Duplicate {
+		d.stats.Duplicated++
+		copies = 2
+	}
+	delay := cfg.Latency.Duration
+	if cfg.Jitter.Duration > 0 {
+		delay += time.Duration(d.rand.Int63n(int64(2*cfg.Jitter.Duration)+1)) -
+			cfg.Jitter.Duration
+		if delay < 0 {
+			delay = 0
+		}
+	}
+	if cfg.Reorder > 0 && d.rand.Float64() < cfg.Reorder {
+		d.stats.Reordered++
+		delay = 0
+	}
+	delay += extra
+
+	deliveries := make([]delivery, 0, copies)
+	for i := 0; i < copies; i++ {
+		sent := now
+		if cfg.Rate > 0 {
+			start := d.busyUntil
+			if start.Before(now) {
+				start = now
+			}
+			if start.Sub(now) > maxBacklog {
+				d.stats.QueueDropped++
+				continue
+			}
+			d.busyUntil = start.Add(time.Duration(len(data)) * 8 * time.Second /
+				time.Duration(cfg.Rate))
+			sent = d.busyUntil
+		}
+		pkt := data
+		if i > 0 {
+			pkt = append([]byte(nil), data...)
+		}
+		deliveries = append(deliveries, d.delivery(sent.Add(delay), pkt))
+	}
+	return deliveries
+}
+
+func (d *direction) delivery(at time.Time, data []byte) delivery {
+	d.seq++
+	return delivery{at: at, data: data, seq: d.seq}
+}
+
+func (d *direction) schedule(deliveries []delivery) {
+	if len(deliveries) == 0 {
+		return
+	}
+	d.mu.Lock()
+	for _, del := range deliveries {
+		heap.Push(&d.queue, del)
+	}
+	d.mu.Unlock()
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// send sends the scheduled deliveries when they are due, until done is
+// closed.
+func (d *direction) send(out net.PacketConn, to net.Addr, done <-chan struct{}) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		d.mu.Lock()
+		var due []delivery
+		now := time.Now()
+		for len(d.queue) > 0 && !d.queue[0].at.After(now) {
+			due = append(due, heap.Pop(&d.queue).(delivery))
+		}
+		wait := time.Hour
+		if len(d.queue) > 0 {
+			wait = d.queue[0].at.Sub(now)
+		}
+		d.stats.Sent += uint64(len(due))
+		d.mu.Unlock()
+
+		for _, del := range due {
+			if _, err := out.WriteTo(del.data, to); err != nil {
+				log.Error("Unable to write to destination", "direction", d.name, "err", err)
+			}
+		}
+		if len(due) > 0 {
+			continue
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-d.wake:
+		case <-done:
+			return
+		}
+	}
+}
+
+// deliveryQueue is a priority queue of deliveries ordered by time.
+type deliveryQueue []delivery
+
+func (q deliveryQueue) Len() int { return len(q) }
+
+func (q deliveryQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+
+func (q deliveryQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *deliveryQueue) Push(x interface{}) { *q = append(*q, x.(delivery)) }
+
+func (q *deliveryQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
@@ -0,0 +1,217 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+func TestDirectionProcess(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	bfd := newPacket(t, common.L4BFD, gopacket.Payload(make([]byte, 24)))
+	udp := newPacket(t, common.L4UDP, &slayers.UDP{SrcPort: 1, DstPort: 2})
+
+	testCases := map[string]struct {
+		Config Config
+		Packet []byte
+		// Delays are the expected delays of the deliveries.
+		Delays []time.Duration
+		Stats  Stats
+	}{
+		"no impairments": {
+			Packet: udp,
+			Delays: []time.Duration{0},
+			Stats:  Stats{Received: 1},
+		},
+		"latency": {
+			Config: Config{Latency: util.DurWrap{Duration: 10 * time.Millisecond}},
+			Packet: udp,
+			Delays: []time.Duration{10 * time.Millisecond},
+			Stats:  Stats{Received: 1},
+		},
+		"loss": {
+			Config: Config{Loss: 1},
+			Packet: udp,
+			Stats:  Stats{Received: 1, Lost: 1},
+		},
+		"duplicate": {
+			Config: Config{Duplicate: 1},
+			Packet: udp,
+			Delays: []time.Duration{0, 0},
+			Stats:  Stats{Received: 1, Duplicated: 1},
+		},
+		"reorder": {
+			Config: Config{
+				Latency: util.DurWrap{Duration: 10 * time.Millisecond},
+				Reorder: 1,
+			},
+			Packet: udp,
+			Delays: []time.Duration{0},
+			Stats:  Stats{Received: 1, Reordered: 1},
+		},
+		"rate": {
+			// One byte per millisecond.
+			Config: Config{Rate: 8000, Duplicate: 1},
+			Packet: udp,
+			Delays: []time.Duration{
+				time.Duration(len(udp)) * time.Millisecond,
+				2 * time.Duration(len(udp)) * time.Millisecond,
+			},
+			Stats: Stats{Received: 1, Duplicated: 1},
+		},
+		"rate backlog": {
+			// One byte per second.
+			Config: Config{Rate: 8},
+			Packet: udp,
+			Delays: []time.Duration{time.Duration(len(udp)) * time.Second},
+			Stats:  Stats{Received: 1},
+		},
+		"drop rule": {
+			Config: Config{Rules: []Rule{{
+				Match:  Match{Protocol: ProtocolBFD, Direction: DirectionXToY},
+				Action: ActionDrop,
+			}}},
+			Packet: bfd,
+			Stats:  Stats{Received: 1, Dropped: 1},
+		},
+		"drop rule other protocol": {
+			Config: Config{Rules: []Rule{{
+				Match:  Match{Protocol: ProtocolBFD},
+				Action: ActionDrop,
+			}}},
+			Packet: udp,
+			Delays: []time.Duration{0},
+			Stats:  Stats{Received: 1},
+		},
+		"delay rule": {
+			Config: Config{
+				Latency: util.DurWrap{Duration: 10 * time.Millisecond},
+				Rules: []Rule{{
+					Match:  Match{Protocol: ProtocolBFD},
+					Action: ActionDelay,
+					Delay:  util.DurWrap{Duration: time.Second},
+				}},
+			},
+			Packet: bfd,
+			Delays: []time.Duration{time.Second + 10*time.Millisecond},
+			Stats:  Stats{Received: 1},
+		},
+		"pass rule": {
+			Config: Config{
+				Loss: 1,
+				Rules: []Rule{{
+					Match:  Match{Protocol: ProtocolBFD},
+					Action: ActionPass,
+				}},
+			},
+			Packet: bfd,
+			Delays: []time.Duration{0},
+			Stats:  Stats{Received: 1},
+		},
+		"corrupt rule": {
+			Config: Config{Rules: []Rule{{Action: ActionCorruptMAC}}},
+			Packet: udp,
+			Delays: []time.Duration{0},
+			Stats:  Stats{Received: 1, Corrupted: 1},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.NoError(t, tc.Config.Validate())
+			d := newDirection(DirectionXToY, nil, 1)
+			data := append([]byte(nil), tc.Packet...)
+			deliveries := d.process(&tc.Config, data, now)
+			delays := make([]time.Duration, 0, len(deliveries))
+			for _, del := range deliveries {
+				delays = append(delays, del.at.Sub(now))
+			}
+			assert.ElementsMatch(t, tc.Delays, delays)
+			assert.Equal(t, tc.Stats, d.Stats())
+		})
+	}
+	t.Run("rate backlog exceeded", func(t *testing.T) {
+		d := newDirection(DirectionXToY, nil, 1)
+		// One byte per second.
+		cfg := Config{Rate: 8}
+		assert.Len(t, d.process(&cfg, udp, now), 1)
+		assert.Len(t, d.process(&cfg, udp, now), 0)
+		assert.Equal(t, uint64(1), d.Stats().QueueDropped)
+	})
+}
+
+func TestLinkRun(t *testing.T) {
+	// The link connects x with y. The test sends from the remote end on
+	// network x and receives on the remote end on network y.
+	remoteX, remoteY := listenUDP(t), listenUDP(t)
+	x, y := listenUDP(t), listenUDP(t)
+
+	link := NewLink(Config{Latency: util.DurWrap{Duration: 50 * time.Millisecond}}, 1)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- link.Run(x, y, remoteX.LocalAddr(), remoteY.LocalAddr())
+	}()
+
+	start := time.Now()
+	_, err := remoteX.WriteTo([]byte("hello"), x.LocalAddr())
+	require.NoError(t, err)
+	buf := make([]byte, 100)
+	require.NoError(t, remoteY.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, from, err := remoteY.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf[:n]))
+	assert.Equal(t, y.LocalAddr().String(), from.String())
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(50*time.Millisecond))
+
+	require.NoError(t, link.SetConfig(Config{Rules: []Rule{{
+		Match:  Match{Direction: DirectionYToX},
+		Action: ActionDrop,
+	}}}))
+	_, err = remoteY.WriteTo([]byte("dropped"), y.LocalAddr())
+	require.NoError(t, err)
+	_, err = remoteX.WriteTo([]byte("passed"), x.LocalAddr())
+	require.NoError(t, err)
+	n, _, err = remoteY.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "passed", string(buf[:n]))
+
+	stats := link.Stats()
+	assert.Equal(t, Stats{Received: 2, Sent: 2}, stats[DirectionXToY])
+	assert.Eventually(t, func() bool {
+		return link.Stats()[DirectionYToX].Dropped == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	x.Close()
+	assert.ErrorIs(t, <-errs, net.ErrClosed)
+}
+
+func listenUDP(t *testing.T) net.PacketConn {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
@@ -12,12 +12,18 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// udpproxy relays UDP packets between two address pairs and emulates the
+// link in between. It is intended to be placed on a border router underlay
+// link in tests. The link impairments and rules are configured with a JSON
+// file and can be changed at runtime through the control API. See the README
+// for details.
 package main
 
 import (
 	"flag"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 
 	"github.com/scionproto/scion/go/lib/log"
@@ -33,18 +39,49 @@ var (
 		"local UDP address on network y, in IP:port format (required)")
 	remoteY = flag.String("remote_y", "",
 		"remote UDP address on network y, in IP:port format (required)")
+	configFile = flag.String("config", "",
+		"JSON file with the initial impairments (optional)")
+	apiAddr = flag.String("api_addr", "",
+		"address of the control API, in IP:port format (optional)")
+	seed = flag.Int64("seed", 0, "seed for the random impairments")
 )
 
 func main() {
 	flag.Parse()
 
-	if err := Proxy(*localX, *remoteX, *localY, *remoteY); err != nil {
+	if err := run(); err != nil {
 		log.Error("Fatal proxy error", "err", err)
 		os.Exit(1)
 	}
 }
 
-func Proxy(localX, remoteX, localY, remoteY string) error {
+func run() error {
+	var cfg Config
+	if *configFile != "" {
+		var err error
+		if cfg, err = LoadConfig(*configFile); err != nil {
+			return err
+		}
+	}
+	link := NewLink(cfg, *seed)
+	if *apiAddr != "" {
+		listener, err := net.Listen("tcp", *apiAddr)
+		if err != nil {
+			return serrors.New("unable to open API listener", "err", err)
+		}
+		log.Info(fmt.Sprintf("Serving control API on %v", listener.Addr()))
+		go func() {
+			defer log.HandlePanic()
+			if err := http.Serve(listener, NewAPI(link)); err != nil {
+				log.Error("Control API stopped", "err", err)
+			}
+		}()
+	}
+	return Proxy(link, *localX, *remoteX, *localY, *remoteY)
+}
+
+// Proxy relays packets between the networks x and y over the emulated link.
+func Proxy(link *Link, localX, remoteX, localY, remoteY string) error {
 	lxAddr, err := net.ResolveUDPAddr("udp", localX)
 	if err != nil {
 		return serrors.New("unable to parse local x address", "err", err)
@@ -86,28 +123,5 @@ func Proxy(localX, remoteX, localY, remoteY string) error {
 		),
 	)
 
-	go func() {
-		defer log.HandlePanic()
-		redirect(xConn, yConn, ryAddr)
-	}()
-	go func() {
-		defer log.HandlePanic()
-		redirect(yConn, xConn, rxAddr)
-	}()
-	select {}
-}
-
-func redirect(from, to net.PacketConn, toAddr *net.UDPAddr) {
-	b := make([]byte, 1<<16)
-	for {
-		n, _, err := from.ReadFrom(b)
-		if err != nil {
-			log.Error("Unable to read from listen conn", "err", err)
-		}
-
-		_, err = to.WriteTo(b[:n], toAddr)
-		if err != nil {
-			log.Error("unable to write to destination", "err", err)
-		}
-	}
+	return link.Run(xConn, yConn, rxAddr, ryAddr)
 }